@@ -0,0 +1,272 @@
+// Package client connects to a rail-connect server. It wraps the generated
+// TicketBookingServiceClient with retries on Unavailable, per-call timeouts,
+// request IDs and optional TLS so callers don't each reimplement dialing.
+package client
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/sanjaykishor/rail-connect/internal/middleware"
+	pb "github.com/sanjaykishor/rail-connect/proto"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Defaults used unless overridden with an Option.
+const (
+	DefaultTimeout     = 10 * time.Second
+	DefaultMaxAttempts = 4
+	DefaultBackoff     = 100 * time.Millisecond
+	DefaultMaxBackoff  = 2 * time.Second
+)
+
+// Client is a TicketBookingServiceClient bound to a connection. Close it when
+// done.
+type Client struct {
+	pb.TicketBookingServiceClient
+	conn *grpc.ClientConn
+}
+
+// options holds the settings applied by New.
+type options struct {
+	timeout     time.Duration
+	maxAttempts int
+	backoff     time.Duration
+	maxBackoff  time.Duration
+	tls         *tls.Config
+	apiKey      string
+	logger      *zap.Logger
+	dialOptions []grpc.DialOption
+}
+
+// Option configures a Client.
+type Option func(*options)
+
+// WithTimeout bounds each call, including its retries, when the caller's
+// context has no deadline. Zero disables the default timeout.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) { o.timeout = timeout }
+}
+
+// WithRetries sets how many times a call is attempted in total when the server
+// is Unavailable. One disables retries.
+func WithRetries(maxAttempts int) Option {
+	return func(o *options) { o.maxAttempts = maxAttempts }
+}
+
+// WithBackoff sets the delay before the first retry, which doubles after each
+// attempt up to max.
+func WithBackoff(initial, max time.Duration) Option {
+	return func(o *options) {
+		o.backoff = initial
+		o.maxBackoff = max
+	}
+}
+
+// WithTLS connects over TLS with the given configuration instead of plaintext.
+func WithTLS(config *tls.Config) Option {
+	return func(o *options) { o.tls = config }
+}
+
+// WithAPIKey authenticates every call with the key in the x-api-key header.
+func WithAPIKey(key string) Option {
+	return func(o *options) { o.apiKey = key }
+}
+
+// WithLogger logs every call with its request ID, booking ID, status and
+// latency, including any retries.
+func WithLogger(logger *zap.Logger) Option {
+	return func(o *options) { o.logger = logger }
+}
+
+// WithDialOptions passes extra options to grpc.NewClient, e.g. a custom dialer.
+func WithDialOptions(dialOptions ...grpc.DialOption) Option {
+	return func(o *options) { o.dialOptions = append(o.dialOptions, dialOptions...) }
+}
+
+// New creates a Client for the server at addr. The connection is established
+// lazily on the first call.
+func New(addr string, opts ...Option) (*Client, error) {
+	o := options{
+		timeout:     DefaultTimeout,
+		maxAttempts: DefaultMaxAttempts,
+		backoff:     DefaultBackoff,
+		maxBackoff:  DefaultMaxBackoff,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.maxAttempts < 1 {
+		return nil, fmt.Errorf("max attempts must be at least 1, got %d", o.maxAttempts)
+	}
+
+	creds := insecure.NewCredentials()
+	if o.tls != nil {
+		creds = credentials.NewTLS(o.tls)
+	}
+
+	interceptors := []grpc.UnaryClientInterceptor{
+		requestIDInterceptor(o.logger),
+		timeoutInterceptor(o.timeout),
+		retryInterceptor(o.maxAttempts, o.backoff, o.maxBackoff),
+	}
+	var streamInterceptors []grpc.StreamClientInterceptor
+	if o.apiKey != "" {
+		interceptors = append(interceptors, apiKeyInterceptor(o.apiKey))
+		streamInterceptors = append(streamInterceptors, apiKeyStreamInterceptor(o.apiKey))
+	}
+
+	dialOptions := append([]grpc.DialOption{
+		grpc.WithTransportCredentials(creds),
+		grpc.WithChainUnaryInterceptor(interceptors...),
+		grpc.WithChainStreamInterceptor(streamInterceptors...),
+	}, o.dialOptions...)
+
+	conn, err := grpc.NewClient(addr, dialOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client for %s: %w", addr, err)
+	}
+	return &Client{
+		TicketBookingServiceClient: pb.NewTicketBookingServiceClient(conn),
+		conn:                       conn,
+	}, nil
+}
+
+// Close closes the connection.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// timeoutInterceptor applies the default timeout to calls whose context has no
+// deadline.
+func timeoutInterceptor(timeout time.Duration) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if _, ok := ctx.Deadline(); !ok && timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// CallInfo receives the correlation IDs of a call made with WithCallInfo.
+type CallInfo struct {
+	// RequestID is the x-request-id the call was sent with, to find it in the
+	// server's logs
+	RequestID string
+	// BookingID is the booking reference the server returned in the
+	// x-booking-id trailer, empty if the response carried no receipt
+	BookingID string
+}
+
+// callInfoOption is the CallOption returned by WithCallInfo.
+type callInfoOption struct {
+	grpc.EmptyCallOption
+	info *CallInfo
+}
+
+// WithCallInfo fills info with the call's request and booking IDs once it
+// returns, e.g. client.PurchaseTicket(ctx, req, client.WithCallInfo(&info)).
+func WithCallInfo(info *CallInfo) grpc.CallOption {
+	return callInfoOption{info: info}
+}
+
+// requestIDInterceptor sends every call with an x-request-id header, keeping
+// one the caller already set, so retries of a call share one ID. It reads the
+// x-booking-id trailer into any CallInfo and logs the call if logger is set.
+func requestIDInterceptor(logger *zap.Logger) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		md, _ := metadata.FromOutgoingContext(ctx)
+		requestID := ""
+		if values := md.Get(middleware.RequestIDHeader); len(values) > 0 {
+			requestID = values[0]
+		} else {
+			requestID = newRequestID()
+			ctx = metadata.AppendToOutgoingContext(ctx, middleware.RequestIDHeader, requestID)
+		}
+
+		var trailer metadata.MD
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, append(opts, grpc.Trailer(&trailer))...)
+		latency := time.Since(start)
+
+		bookingID := ""
+		if values := trailer.Get(middleware.BookingIDTrailer); len(values) > 0 {
+			bookingID = values[0]
+		}
+		for _, opt := range opts {
+			if callInfo, ok := opt.(callInfoOption); ok {
+				*callInfo.info = CallInfo{RequestID: requestID, BookingID: bookingID}
+			}
+		}
+
+		if logger != nil {
+			logger.Info("Call completed",
+				zap.String("method", method),
+				zap.String("request_id", requestID),
+				zap.String("booking_id", bookingID),
+				zap.Stringer("code", status.Code(err)),
+				zap.Duration("latency", latency),
+			)
+		}
+		return err
+	}
+}
+
+// newRequestID returns a random 128-bit request ID in hex.
+func newRequestID() string {
+	id := make([]byte, 16)
+	rand.Read(id)
+	return hex.EncodeToString(id)
+}
+
+// apiKeyInterceptor sends key in the x-api-key header of every call.
+func apiKeyInterceptor(key string) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		ctx = metadata.AppendToOutgoingContext(ctx, middleware.APIKeyHeader, key)
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// apiKeyStreamInterceptor sends key in the x-api-key header of every stream,
+// such as WatchAvailability.
+func apiKeyStreamInterceptor(key string) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		ctx = metadata.AppendToOutgoingContext(ctx, middleware.APIKeyHeader, key)
+		return streamer(ctx, desc, cc, method, opts...)
+	}
+}
+
+// retryInterceptor retries calls failing with Unavailable, backing off
+// exponentially between attempts, until maxAttempts is reached or ctx is done.
+func retryInterceptor(maxAttempts int, backoff, maxBackoff time.Duration) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		delay := backoff
+		for attempt := 1; ; attempt++ {
+			err := invoker(ctx, method, req, reply, cc, opts...)
+			if status.Code(err) != codes.Unavailable || attempt >= maxAttempts {
+				return err
+			}
+
+			timer := time.NewTimer(delay)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				return err
+			}
+			delay = min(delay*2, maxBackoff)
+		}
+	}
+}
@@ -0,0 +1,203 @@
+package client
+
+import (
+	"context"
+	"net"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/sanjaykishor/rail-connect/internal/middleware"
+	pb "github.com/sanjaykishor/rail-connect/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// fakeServer fails GetReceipt with failCode until it has been called failures
+// times, and makes GetServerInfo wait for delay. GetReceipt records the
+// request ID of every attempt.
+type fakeServer struct {
+	pb.UnimplementedTicketBookingServiceServer
+	calls    atomic.Int32
+	failures int32
+	failCode codes.Code
+	delay    time.Duration
+
+	mu         sync.Mutex
+	requestIDs []string
+}
+
+func (s *fakeServer) GetReceipt(ctx context.Context, req *pb.GetReceiptRequest) (*pb.GetReceiptResponse, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	s.mu.Lock()
+	s.requestIDs = append(s.requestIDs, md.Get(middleware.RequestIDHeader)...)
+	s.mu.Unlock()
+
+	if s.calls.Add(1) <= s.failures {
+		return nil, status.Error(s.failCode, "try again")
+	}
+	return &pb.GetReceiptResponse{Receipt: &pb.Receipt{User: &pb.User{Email: req.Email}, BookingReference: "K7QX2M"}}, nil
+}
+
+func (s *fakeServer) GetServerInfo(ctx context.Context, req *pb.GetServerInfoRequest) (*pb.GetServerInfoResponse, error) {
+	select {
+	case <-time.After(s.delay):
+		return &pb.GetServerInfoResponse{Version: "test"}, nil
+	case <-ctx.Done():
+		return nil, status.FromContextError(ctx.Err()).Err()
+	}
+}
+
+// GetConfig echoes the API key the call was made with.
+func (s *fakeServer) GetConfig(ctx context.Context, req *pb.GetConfigRequest) (*pb.GetConfigResponse, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	return &pb.GetConfigResponse{Config: strings.Join(md.Get(middleware.APIKeyHeader), ",")}, nil
+}
+
+func (s *fakeServer) WatchAvailability(req *pb.WatchAvailabilityRequest, stream grpc.ServerStreamingServer[pb.WatchAvailabilityResponse]) error {
+	md, _ := metadata.FromIncomingContext(stream.Context())
+	section := &pb.SectionAvailability{Section: strings.Join(md.Get(middleware.APIKeyHeader), ",")}
+	return stream.Send(&pb.WatchAvailabilityResponse{Sections: []*pb.SectionAvailability{section}})
+}
+
+// startServer serves fake in-process behind the server's request ID
+// interceptor and returns a Client connected to it.
+func startServer(t *testing.T, fake *fakeServer, opts ...Option) *Client {
+	t.Helper()
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer(grpc.UnaryInterceptor(middleware.RequestIDInterceptor(zap.NewNop())))
+	pb.RegisterTicketBookingServiceServer(server, fake)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	dialer := func(ctx context.Context, _ string) (net.Conn, error) {
+		return listener.DialContext(ctx)
+	}
+	opts = append([]Option{WithBackoff(time.Millisecond, 5*time.Millisecond), WithDialOptions(grpc.WithContextDialer(dialer))}, opts...)
+	client, err := New("passthrough:///bufnet", opts...)
+	assert.NoError(t, err)
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+func TestRetriesUnavailable(t *testing.T) {
+	fake := &fakeServer{failures: 2, failCode: codes.Unavailable}
+	client := startServer(t, fake)
+
+	response, err := client.GetReceipt(context.Background(), &pb.GetReceiptRequest{Email: "test@example.com"})
+	assert.NoError(t, err)
+	assert.Equal(t, "test@example.com", response.Receipt.User.Email)
+	assert.Equal(t, int32(3), fake.calls.Load(), "Call should succeed on the third attempt")
+}
+
+func TestRetriesGiveUp(t *testing.T) {
+	fake := &fakeServer{failures: 10, failCode: codes.Unavailable}
+	client := startServer(t, fake, WithRetries(3))
+
+	_, err := client.GetReceipt(context.Background(), &pb.GetReceiptRequest{Email: "test@example.com"})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, int32(3), fake.calls.Load(), "Call should stop after the maximum attempts")
+}
+
+func TestNoRetryOnOtherErrors(t *testing.T) {
+	fake := &fakeServer{failures: 1, failCode: codes.NotFound}
+	client := startServer(t, fake)
+
+	_, err := client.GetReceipt(context.Background(), &pb.GetReceiptRequest{Email: "test@example.com"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.Equal(t, int32(1), fake.calls.Load(), "Only Unavailable should be retried")
+}
+
+func TestDefaultTimeout(t *testing.T) {
+	client := startServer(t, &fakeServer{delay: time.Second}, WithTimeout(20*time.Millisecond))
+
+	_, err := client.GetServerInfo(context.Background(), &pb.GetServerInfoRequest{})
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+}
+
+func TestCallerDeadlineOverridesTimeout(t *testing.T) {
+	client := startServer(t, &fakeServer{delay: 50 * time.Millisecond}, WithTimeout(20*time.Millisecond))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	response, err := client.GetServerInfo(ctx, &pb.GetServerInfoRequest{})
+	assert.NoError(t, err, "A caller's own deadline takes precedence over the default")
+	assert.Equal(t, "test", response.Version)
+}
+
+func TestNewInvalidRetries(t *testing.T) {
+	_, err := New("localhost:50051", WithRetries(0))
+	assert.Error(t, err)
+}
+
+func TestAPIKey(t *testing.T) {
+	client := startServer(t, &fakeServer{}, WithAPIKey("s3cret"))
+
+	res, err := client.GetConfig(context.Background(), &pb.GetConfigRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, "s3cret", res.Config, "Every call should carry the API key")
+
+	stream, err := client.WatchAvailability(context.Background(), &pb.WatchAvailabilityRequest{})
+	assert.NoError(t, err)
+	update, err := stream.Recv()
+	assert.NoError(t, err)
+	assert.Equal(t, "s3cret", update.Sections[0].Section, "Streams should carry the API key")
+
+	client = startServer(t, &fakeServer{})
+	res, err = client.GetConfig(context.Background(), &pb.GetConfigRequest{})
+	assert.NoError(t, err)
+	assert.Empty(t, res.Config, "No key should be sent unless configured")
+}
+
+func TestRequestID(t *testing.T) {
+	fake := &fakeServer{failures: 1, failCode: codes.Unavailable}
+	client := startServer(t, fake)
+
+	var info CallInfo
+	_, err := client.GetReceipt(context.Background(), &pb.GetReceiptRequest{Email: "test@example.com"}, WithCallInfo(&info))
+	assert.NoError(t, err)
+	assert.Len(t, info.RequestID, 32)
+	assert.Equal(t, []string{info.RequestID, info.RequestID}, fake.requestIDs, "Retries should share the request ID")
+	assert.Equal(t, "K7QX2M", info.BookingID, "The booking ID trailer should be read")
+
+	// A request ID set by the caller is kept
+	ctx := metadata.AppendToOutgoingContext(context.Background(), middleware.RequestIDHeader, "caller-id")
+	_, err = client.GetReceipt(ctx, &pb.GetReceiptRequest{Email: "test@example.com"}, WithCallInfo(&info))
+	assert.NoError(t, err)
+	assert.Equal(t, "caller-id", info.RequestID)
+	assert.Equal(t, "caller-id", fake.requestIDs[2])
+
+	// Responses without a receipt carry no booking ID
+	_, err = client.GetConfig(context.Background(), &pb.GetConfigRequest{}, WithCallInfo(&info))
+	assert.NoError(t, err)
+	assert.NotEmpty(t, info.RequestID)
+	assert.Empty(t, info.BookingID)
+}
+
+func TestCallLogging(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	client := startServer(t, &fakeServer{}, WithLogger(zap.New(core)))
+
+	var info CallInfo
+	_, err := client.GetReceipt(context.Background(), &pb.GetReceiptRequest{Email: "test@example.com"}, WithCallInfo(&info))
+	assert.NoError(t, err)
+
+	entries := logs.FilterMessage("Call completed").All()
+	assert.Len(t, entries, 1)
+	fields := entries[0].ContextMap()
+	assert.Equal(t, "/ticketBooking.TicketBookingService/GetReceipt", fields["method"])
+	assert.Equal(t, info.RequestID, fields["request_id"])
+	assert.Equal(t, "K7QX2M", fields["booking_id"])
+	assert.Equal(t, "OK", fields["code"])
+	assert.Contains(t, fields, "latency")
+}
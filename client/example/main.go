@@ -5,9 +5,8 @@ import (
 	"flag"
 	"log"
 
+	railclient "github.com/sanjaykishor/rail-connect/client"
 	"github.com/sanjaykishor/rail-connect/proto"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 )
 
 var (
@@ -15,14 +14,11 @@ var (
 )
 
 func main() {
-	conn, err := grpc.NewClient(*address, grpc.WithTransportCredentials(insecure.NewCredentials()))
-
+	client, err := railclient.New(*address)
 	if err != nil {
 		log.Fatalf("did not connect: %v\n", err)
 	}
-	defer conn.Close()
-
-	client := proto.NewTicketBookingServiceClient(conn)
+	defer client.Close()
 
 	// Purchase a ticket
 	user1 := &proto.User{
@@ -0,0 +1,627 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+)
+
+// cli runs admin commands against a TicketBookingService.
+type cli struct {
+	client pb.TicketBookingServiceClient
+	out    io.Writer
+	stderr io.Writer
+	json   bool
+}
+
+// command is a subcommand handler. It receives the arguments after its name.
+type command func(c *cli, ctx context.Context, args []string) error
+
+var commands = map[string]command{
+	"purchase":    (*cli).purchase,
+	"receipt":     (*cli).receipt,
+	"section":     (*cli).section,
+	"manifest":    (*cli).manifest,
+	"seat":        (*cli).seat,
+	"vacancy":     (*cli).vacancy,
+	"sections":    (*cli).sections,
+	"route":       (*cli).route,
+	"cheapest":    (*cli).cheapest,
+	"price-range": (*cli).priceRange,
+	"update":      (*cli).update,
+	"force-move":  (*cli).forceMove,
+	"remove":      (*cli).remove,
+	"swap":        (*cli).swap,
+	"history":     (*cli).history,
+	"maintenance": (*cli).maintenance,
+	"bulk-cancel": (*cli).bulkCancel,
+	"resize":      (*cli).resize,
+	"block":       (*cli).block,
+	"unblock":     (*cli).unblock,
+	"verify":      (*cli).verify,
+	"info":        (*cli).info,
+	"config":      (*cli).config,
+	"trace":       (*cli).trace,
+}
+
+// run dispatches to the named command.
+func (c *cli) run(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("no command given")
+	}
+
+	cmd, exists := commands[args[0]]
+	if !exists {
+		return fmt.Errorf("unknown command %q", args[0])
+	}
+	return cmd(c, ctx, args[1:])
+}
+
+// flags returns a flag set for a command that reports errors instead of exiting.
+func (c *cli) flags(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(c.stderr)
+	return fs
+}
+
+// parse parses a command's flags and checks that the required ones are set.
+func parse(fs *flag.FlagSet, args []string, required ...string) error {
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("%s: unexpected arguments: %s", fs.Name(), strings.Join(fs.Args(), " "))
+	}
+
+	set := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) { set[f.Name] = true })
+
+	missing := make([]string, 0)
+	for _, name := range required {
+		if !set[name] {
+			missing = append(missing, "-"+name)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%s: missing required flags: %s", fs.Name(), strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+func (c *cli) purchase(ctx context.Context, args []string) error {
+	fs := c.flags("purchase")
+	email := fs.String("email", "", "Email of the passenger")
+	firstName := fs.String("first", "", "First name of the passenger")
+	lastName := fs.String("last", "", "Last name of the passenger")
+	from := fs.String("from", "", "Departure station")
+	to := fs.String("to", "", "Arrival station")
+	dryRun := fs.Bool("dry-run", false, "Report the seat that would be booked without booking it")
+	prefer := fs.String("prefer", "", "Comma-separated sections to try in order")
+	date := fs.String("date", "", "Departure date as YYYY-MM-DD (default: the default train)")
+	train := fs.String("train", "", "Train ID (default: the configured default train)")
+	companion := fs.String("companion", "", "Email of a second passenger to seat alongside")
+	if err := parse(fs, args, "email", "from", "to"); err != nil {
+		return err
+	}
+
+	req := &pb.PurchaseTicketRequest{
+		User:          &pb.User{Email: *email, FirstName: *firstName, LastName: *lastName},
+		From:          *from,
+		To:            *to,
+		DryRun:        *dryRun,
+		DepartureDate: *date,
+		TrainId:       *train,
+	}
+	if *prefer != "" {
+		req.PreferredSections = strings.Split(*prefer, ",")
+	}
+	if *companion != "" {
+		req.Companion = &pb.User{Email: *companion}
+	}
+
+	res, err := c.client.PurchaseTicket(ctx, req)
+	if err != nil {
+		return err
+	}
+	if c.json {
+		return printJSON(c.out, res)
+	}
+	printMessage(c.out, res.Message)
+	if res.Receipt == nil {
+		// Waitlisted riders have no receipt yet
+		return nil
+	}
+	if res.CompanionReceipt != nil {
+		printMessage(c.out, "Seating: "+pairSeating(res.PairSeating))
+	}
+	return printReceipts(c.out, res.Receipt, res.CompanionReceipt)
+}
+
+func (c *cli) receipt(ctx context.Context, args []string) error {
+	fs := c.flags("receipt")
+	email := fs.String("email", "", "Email of the passenger")
+	reference := fs.String("ref", "", "Booking reference, instead of or as well as the email")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	if *email == "" && *reference == "" {
+		return fmt.Errorf("receipt: one of -email or -ref is required")
+	}
+
+	res, err := c.client.GetReceipt(ctx, &pb.GetReceiptRequest{Email: *email, BookingReference: *reference})
+	if err != nil {
+		return err
+	}
+	if c.json {
+		return printJSON(c.out, res)
+	}
+	return printReceipts(c.out, res.Receipt)
+}
+
+func (c *cli) seat(ctx context.Context, args []string) error {
+	fs := c.flags("seat")
+	section := fs.String("section", "", "Name of the section")
+	seat := fs.Int("seat", 0, "Seat number")
+	date := fs.String("date", "", "Departure date as YYYY-MM-DD (default: the default train)")
+	train := fs.String("train", "", "Train ID (default: the configured default train)")
+	if err := parse(fs, args, "section", "seat"); err != nil {
+		return err
+	}
+
+	res, err := c.client.GetSeat(ctx, &pb.GetSeatRequest{Section: *section, SeatNumber: int32(*seat), DepartureDate: *date, TrainId: *train})
+	if err != nil {
+		return err
+	}
+	if c.json {
+		return printJSON(c.out, res)
+	}
+	position := strings.ToLower(strings.TrimPrefix(res.Position.String(), "SEAT_POSITION_"))
+	if err := printTable(c.out, []string{"SECTION", "SEAT", "POSITION", "AVAILABLE", "BLOCKED"},
+		[]string{res.Seat.GetSection(), fmt.Sprint(res.Seat.GetSeatNumber()), position, fmt.Sprint(res.Available), fmt.Sprint(res.Blocked)}); err != nil {
+		return err
+	}
+	if len(res.Occupants) == 0 {
+		return nil
+	}
+	fmt.Fprintln(c.out)
+	return printReceipts(c.out, res.Occupants...)
+}
+
+func (c *cli) section(ctx context.Context, args []string) error {
+	fs := c.flags("section")
+	name := fs.String("name", "", "Name of the section")
+	date := fs.String("date", "", "Departure date as YYYY-MM-DD (default: the default train)")
+	train := fs.String("train", "", "Train ID (default: the configured default train)")
+	if err := parse(fs, args, "name"); err != nil {
+		return err
+	}
+
+	res, err := c.client.GetUsersBySection(ctx, &pb.GetUsersBySectionRequest{Section: *name, DepartureDate: *date, TrainId: *train})
+	if err != nil {
+		return err
+	}
+	if c.json {
+		return printJSON(c.out, res)
+	}
+	return printUserSeats(c.out, res.Users)
+}
+
+func (c *cli) manifest(ctx context.Context, args []string) error {
+	fs := c.flags("manifest")
+	section := fs.String("section", "", "Only list this section (default: every section)")
+	date := fs.String("date", "", "Departure date as YYYY-MM-DD (default: the default train)")
+	train := fs.String("train", "", "Train ID (default: the configured default train)")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+
+	res, err := c.client.GetManifest(ctx, &pb.GetManifestRequest{Section: *section, DepartureDate: *date, TrainId: *train})
+	if err != nil {
+		return err
+	}
+	if c.json {
+		return printJSON(c.out, res)
+	}
+	return printManifest(c.out, res)
+}
+
+func (c *cli) priceRange(ctx context.Context, args []string) error {
+	fs := c.flags("price-range")
+	minPrice := fs.Float64("min", 0, "Lowest price paid to include")
+	maxPrice := fs.Float64("max", 0, "Highest price paid to include")
+	if err := parse(fs, args, "max"); err != nil {
+		return err
+	}
+
+	res, err := c.client.ListReceiptsByPriceRange(ctx, &pb.ListReceiptsByPriceRangeRequest{MinPrice: *minPrice, MaxPrice: *maxPrice})
+	if err != nil {
+		return err
+	}
+	if c.json {
+		return printJSON(c.out, res)
+	}
+	return printReceipts(c.out, res.Receipts...)
+}
+
+func (c *cli) vacancy(ctx context.Context, args []string) error {
+	fs := c.flags("vacancy")
+	name := fs.String("section", "", "Name of the section")
+	date := fs.String("date", "", "Departure date as YYYY-MM-DD (default: the default train)")
+	train := fs.String("train", "", "Train ID (default: the configured default train)")
+	if err := parse(fs, args, "section"); err != nil {
+		return err
+	}
+
+	res, err := c.client.GetSectionVacancy(ctx, &pb.GetSectionVacancyRequest{Section: *name, DepartureDate: *date, TrainId: *train})
+	if err != nil {
+		return err
+	}
+	if c.json {
+		return printJSON(c.out, res)
+	}
+	fmt.Fprintf(c.out, "%d of %d seats vacant\n", res.VacantSeats, res.MaxSeats)
+	return nil
+}
+
+func (c *cli) sections(ctx context.Context, args []string) error {
+	fs := c.flags("sections")
+	date := fs.String("date", "", "Departure date as YYYY-MM-DD (default: the default train)")
+	train := fs.String("train", "", "Train ID (default: the configured default train)")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+
+	res, err := c.client.GetAllSections(ctx, &pb.GetAllSectionsRequest{DepartureDate: *date, TrainId: *train})
+	if err != nil {
+		return err
+	}
+	if c.json {
+		return printJSON(c.out, res)
+	}
+	return printSections(c.out, res.Sections)
+}
+
+func (c *cli) route(ctx context.Context, args []string) error {
+	fs := c.flags("route")
+	from := fs.String("from", "", "Departure station")
+	to := fs.String("to", "", "Arrival station")
+	if err := parse(fs, args, "from", "to"); err != nil {
+		return err
+	}
+
+	res, err := c.client.GetUsersByRoute(ctx, &pb.GetUsersByRouteRequest{From: *from, To: *to})
+	if err != nil {
+		return err
+	}
+	if c.json {
+		return printJSON(c.out, res)
+	}
+	return printRouteUsers(c.out, res.Users)
+}
+
+func (c *cli) cheapest(ctx context.Context, args []string) error {
+	fs := c.flags("cheapest")
+	from := fs.String("from", "", "Departure station")
+	limit := fs.Int("limit", 5, "Maximum number of routes (0 for all)")
+	if err := parse(fs, args, "from"); err != nil {
+		return err
+	}
+
+	res, err := c.client.GetCheapestRoutes(ctx, &pb.GetCheapestRoutesRequest{From: *from, Limit: int32(*limit)})
+	if err != nil {
+		return err
+	}
+	if c.json {
+		return printJSON(c.out, res)
+	}
+	return printRouteFares(c.out, res.Routes)
+}
+
+func (c *cli) update(ctx context.Context, args []string) error {
+	fs := c.flags("update")
+	email := fs.String("email", "", "Email of the passenger")
+	section := fs.String("section", "", "Section of the new seat (default: current section)")
+	seat := fs.Int("seat", 0, "Number of the new seat (default: any free seat in the section)")
+	if err := parse(fs, args, "email"); err != nil {
+		return err
+	}
+	if *section == "" && *seat == 0 {
+		return fmt.Errorf("update: one of -section or -seat is required")
+	}
+
+	res, err := c.client.UpdateUserSeat(ctx, &pb.UpdateUserSeatRequest{
+		Email:   *email,
+		NewSeat: &pb.Seat{Section: *section, SeatNumber: int32(*seat)},
+	})
+	if err != nil {
+		return err
+	}
+	if c.json {
+		return printJSON(c.out, res)
+	}
+	printMessage(c.out, res.Message)
+	return printReceipts(c.out, res.UpdatedReceipt)
+}
+
+func (c *cli) forceMove(ctx context.Context, args []string) error {
+	fs := c.flags("force-move")
+	email := fs.String("email", "", "Email of the passenger")
+	section := fs.String("section", "", "Section of the new seat")
+	seat := fs.Int("seat", 0, "Number of the new seat")
+	reason := fs.String("reason", "", "Why the passenger is being moved, kept in the ticket history")
+	if err := parse(fs, args, "email", "section", "seat", "reason"); err != nil {
+		return err
+	}
+
+	res, err := c.client.ForceReassign(ctx, &pb.ForceReassignRequest{
+		Email:         *email,
+		TargetSection: *section,
+		TargetSeat:    int32(*seat),
+		Reason:        *reason,
+	})
+	if err != nil {
+		return err
+	}
+	if c.json {
+		return printJSON(c.out, res)
+	}
+	printMessage(c.out, res.Message)
+	return printReceipts(c.out, res.UpdatedReceipt)
+}
+
+func (c *cli) remove(ctx context.Context, args []string) error {
+	fs := c.flags("remove")
+	email := fs.String("email", "", "Email of the passenger")
+	at := fs.String("at", "", "Station where the journey was cut short, for a prorated refund")
+	if err := parse(fs, args, "email"); err != nil {
+		return err
+	}
+
+	res, err := c.client.RemoveUser(ctx, &pb.RemoveUserRequest{Email: *email, CancelledAt: *at})
+	if err != nil {
+		return err
+	}
+	if c.json {
+		return printJSON(c.out, res)
+	}
+	printMessage(c.out, res.Message)
+	fmt.Fprintf(c.out, "Refund: %.2f\n", res.Refund)
+	return nil
+}
+
+func (c *cli) swap(ctx context.Context, args []string) error {
+	fs := c.flags("swap")
+	emailA := fs.String("a", "", "Email of the first passenger")
+	emailB := fs.String("b", "", "Email of the second passenger")
+	if err := parse(fs, args, "a", "b"); err != nil {
+		return err
+	}
+
+	res, err := c.client.SwapSeats(ctx, &pb.SwapSeatsRequest{EmailA: *emailA, EmailB: *emailB})
+	if err != nil {
+		return err
+	}
+	if c.json {
+		return printJSON(c.out, res)
+	}
+	printMessage(c.out, res.Message)
+	return printReceipts(c.out, res.ReceiptA, res.ReceiptB)
+}
+
+func (c *cli) history(ctx context.Context, args []string) error {
+	fs := c.flags("history")
+	email := fs.String("email", "", "Email of the passenger")
+	if err := parse(fs, args, "email"); err != nil {
+		return err
+	}
+
+	res, err := c.client.GetReceiptHistory(ctx, &pb.GetReceiptHistoryRequest{Email: *email})
+	if err != nil {
+		return err
+	}
+	if c.json {
+		return printJSON(c.out, res)
+	}
+	return printEvents(c.out, res.Events)
+}
+
+func (c *cli) maintenance(ctx context.Context, args []string) error {
+	fs := c.flags("maintenance")
+	enabled := fs.Bool("enabled", false, "Whether maintenance mode should be on")
+	if err := parse(fs, args, "enabled"); err != nil {
+		return err
+	}
+
+	res, err := c.client.SetMaintenanceMode(ctx, &pb.SetMaintenanceModeRequest{Enabled: *enabled})
+	if err != nil {
+		return err
+	}
+	if c.json {
+		return printJSON(c.out, res)
+	}
+	printMessage(c.out, res.Message)
+	return nil
+}
+
+func (c *cli) bulkCancel(ctx context.Context, args []string) error {
+	fs := c.flags("bulk-cancel")
+	section := fs.String("section", "", "Cancel tickets in this section")
+	from := fs.String("from", "", "Cancel tickets departing from this station")
+	to := fs.String("to", "", "Cancel tickets arriving at this station")
+	at := fs.String("at", "", "Station where the train was stopped, for prorated refunds")
+	date := fs.String("date", "", "Departure date as YYYY-MM-DD (default: the default train)")
+	train := fs.String("train", "", "Train ID (default: the configured default train)")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	if *section == "" && (*from == "" || *to == "") {
+		return fmt.Errorf("bulk-cancel: give -section, or both -from and -to")
+	}
+
+	res, err := c.client.BulkCancel(ctx, &pb.BulkCancelRequest{Section: *section, From: *from, To: *to, CancelledAt: *at, DepartureDate: *date, TrainId: *train})
+	if err != nil {
+		return err
+	}
+	if c.json {
+		return printJSON(c.out, res)
+	}
+	printMessage(c.out, res.Message)
+	if err := printReceipts(c.out, res.CancelledReceipts...); err != nil {
+		return err
+	}
+	fmt.Fprintln(c.out)
+	return printRefunds(c.out, res.Refunds)
+}
+
+func (c *cli) resize(ctx context.Context, args []string) error {
+	fs := c.flags("resize")
+	section := fs.String("section", "", "Name of the section")
+	seats := fs.Int("seats", 0, "New number of seats")
+	if err := parse(fs, args, "section", "seats"); err != nil {
+		return err
+	}
+
+	res, err := c.client.ResizeSection(ctx, &pb.ResizeSectionRequest{Section: *section, MaxSeats: int32(*seats)})
+	if err != nil {
+		return err
+	}
+	if c.json {
+		return printJSON(c.out, res)
+	}
+	printMessage(c.out, res.Message)
+	return printTable(c.out, []string{"SECTION", "MAX SEATS", "VACANT SEATS"},
+		[]string{res.Section, fmt.Sprint(res.MaxSeats), fmt.Sprint(res.VacantSeats)})
+}
+
+func (c *cli) block(ctx context.Context, args []string) error {
+	fs := c.flags("block")
+	section := fs.String("section", "", "Name of the section")
+	seat := fs.Int("seat", 0, "Seat number to block")
+	date := fs.String("date", "", "Departure date as YYYY-MM-DD (default: every train and date without -train)")
+	train := fs.String("train", "", "Train ID (default: the configured default train)")
+	if err := parse(fs, args, "section", "seat"); err != nil {
+		return err
+	}
+
+	res, err := c.client.BlockSeat(ctx, &pb.BlockSeatRequest{Section: *section, SeatNumber: int32(*seat), DepartureDate: *date, TrainId: *train})
+	if err != nil {
+		return err
+	}
+	if c.json {
+		return printJSON(c.out, res)
+	}
+	printMessage(c.out, res.Message)
+	return printTable(c.out, []string{"SECTION", "SEAT", "VACANT SEATS"},
+		[]string{res.Seat.GetSection(), fmt.Sprint(res.Seat.GetSeatNumber()), fmt.Sprint(res.VacantSeats)})
+}
+
+func (c *cli) unblock(ctx context.Context, args []string) error {
+	fs := c.flags("unblock")
+	section := fs.String("section", "", "Name of the section")
+	seat := fs.Int("seat", 0, "Seat number to unblock")
+	date := fs.String("date", "", "Departure date as YYYY-MM-DD (default: every train and date without -train)")
+	train := fs.String("train", "", "Train ID (default: the configured default train)")
+	if err := parse(fs, args, "section", "seat"); err != nil {
+		return err
+	}
+
+	res, err := c.client.UnblockSeat(ctx, &pb.UnblockSeatRequest{Section: *section, SeatNumber: int32(*seat), DepartureDate: *date, TrainId: *train})
+	if err != nil {
+		return err
+	}
+	if c.json {
+		return printJSON(c.out, res)
+	}
+	printMessage(c.out, res.Message)
+	return printTable(c.out, []string{"SECTION", "SEAT", "VACANT SEATS"},
+		[]string{res.Seat.GetSection(), fmt.Sprint(res.Seat.GetSeatNumber()), fmt.Sprint(res.VacantSeats)})
+}
+
+func (c *cli) verify(ctx context.Context, args []string) error {
+	fs := c.flags("verify")
+	repair := fs.Bool("repair", false, "Recreate missing seats and recount vacant seats")
+	date := fs.String("date", "", "Departure date as YYYY-MM-DD (default: the default train)")
+	train := fs.String("train", "", "Train ID (default: the configured default train)")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+
+	res, err := c.client.Verify(ctx, &pb.VerifyRequest{Repair: *repair, DepartureDate: *date, TrainId: *train})
+	if err != nil {
+		return err
+	}
+	if c.json {
+		return printJSON(c.out, res)
+	}
+	printMessage(c.out, res.Message)
+	rows := make([][]string, 0, len(res.Sections))
+	for _, section := range res.Sections {
+		missing := make([]string, 0, len(section.MissingSeats))
+		for _, seatNum := range section.MissingSeats {
+			missing = append(missing, fmt.Sprint(seatNum))
+		}
+		rows = append(rows, []string{section.Section, strings.Join(missing, ","),
+			fmt.Sprint(section.RecordedVacantSeats), fmt.Sprint(section.ActualVacantSeats)})
+	}
+	return printTable(c.out, []string{"SECTION", "MISSING SEATS", "RECORDED VACANT", "ACTUAL VACANT"}, rows...)
+}
+
+func (c *cli) info(ctx context.Context, args []string) error {
+	fs := c.flags("info")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+
+	res, err := c.client.GetServerInfo(ctx, &pb.GetServerInfoRequest{})
+	if err != nil {
+		return err
+	}
+	if c.json {
+		return printJSON(c.out, res)
+	}
+	return printTable(c.out, []string{"VERSION", "GIT COMMIT", "UPTIME", "FEATURES"},
+		[]string{res.Version, res.GitCommit, formatUptime(res.UptimeSeconds), strings.Join(res.EnabledFeatures, ",")})
+}
+
+func (c *cli) config(ctx context.Context, args []string) error {
+	fs := c.flags("config")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+
+	res, err := c.client.GetConfig(ctx, &pb.GetConfigRequest{})
+	if err != nil {
+		return err
+	}
+	if c.json {
+		return printJSON(c.out, res)
+	}
+	_, err = fmt.Fprint(c.out, res.Config)
+	return err
+}
+
+func (c *cli) trace(ctx context.Context, args []string) error {
+	fs := c.flags("trace")
+	reference := fs.String("ref", "", "Booking reference")
+	if err := parse(fs, args, "ref"); err != nil {
+		return err
+	}
+
+	res, err := c.client.GetAssignmentTrace(ctx, &pb.GetAssignmentTraceRequest{BookingReference: *reference})
+	if err != nil {
+		return err
+	}
+	if c.json {
+		return printJSON(c.out, res)
+	}
+	honored := "no"
+	if res.PreferenceHonored {
+		honored = "yes"
+	}
+	fmt.Fprintf(c.out, "Strategy: %s\nPreference honored: %s\nSkipped sections: %d\n", res.Strategy, honored, res.SkippedSections)
+	return nil
+}
@@ -0,0 +1,270 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+)
+
+// fakeClient records the requests it receives and returns canned responses.
+// Methods it does not override panic through the nil embedded interface.
+type fakeClient struct {
+	pb.TicketBookingServiceClient
+	purchaseReq   *pb.PurchaseTicketRequest
+	updateReq     *pb.UpdateUserSeatRequest
+	bulkReq       *pb.BulkCancelRequest
+	maintReq      *pb.SetMaintenanceModeRequest
+	priceRangeReq *pb.ListReceiptsByPriceRangeRequest
+	forceReq      *pb.ForceReassignRequest
+	err           error
+}
+
+var testReceipt = &pb.Receipt{
+	User:             &pb.User{FirstName: "Sanjay", LastName: "Kishor", Email: "test@example.com"},
+	From:             "London",
+	To:               "France",
+	PricePaid:        20.00,
+	Seat:             &pb.Seat{Section: "A", SeatNumber: 1},
+	BookingReference: "K7QX2M",
+}
+
+func (f *fakeClient) PurchaseTicket(ctx context.Context, req *pb.PurchaseTicketRequest, opts ...grpc.CallOption) (*pb.PurchaseTicketResponse, error) {
+	f.purchaseReq = req
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &pb.PurchaseTicketResponse{Message: "Ticket booked successfully", Receipt: testReceipt}, nil
+}
+
+func (f *fakeClient) UpdateUserSeat(ctx context.Context, req *pb.UpdateUserSeatRequest, opts ...grpc.CallOption) (*pb.UpdateUserSeatResponse, error) {
+	f.updateReq = req
+	return &pb.UpdateUserSeatResponse{Message: "Seat updated successfully", UpdatedReceipt: testReceipt}, nil
+}
+
+func (f *fakeClient) ForceReassign(ctx context.Context, req *pb.ForceReassignRequest, opts ...grpc.CallOption) (*pb.ForceReassignResponse, error) {
+	f.forceReq = req
+	return &pb.ForceReassignResponse{Message: "Passenger moved", UpdatedReceipt: testReceipt}, nil
+}
+
+func (f *fakeClient) BulkCancel(ctx context.Context, req *pb.BulkCancelRequest, opts ...grpc.CallOption) (*pb.BulkCancelResponse, error) {
+	f.bulkReq = req
+	return &pb.BulkCancelResponse{Message: "Cancelled 1 tickets", CancelledReceipts: []*pb.Receipt{testReceipt}}, nil
+}
+
+func (f *fakeClient) GetSectionVacancy(ctx context.Context, req *pb.GetSectionVacancyRequest, opts ...grpc.CallOption) (*pb.GetSectionVacancyResponse, error) {
+	return &pb.GetSectionVacancyResponse{VacantSeats: 19, MaxSeats: 20}, nil
+}
+
+func (f *fakeClient) SetMaintenanceMode(ctx context.Context, req *pb.SetMaintenanceModeRequest, opts ...grpc.CallOption) (*pb.SetMaintenanceModeResponse, error) {
+	f.maintReq = req
+	return &pb.SetMaintenanceModeResponse{Message: "Maintenance mode enabled", Enabled: req.Enabled}, nil
+}
+
+func (f *fakeClient) GetUsersBySection(ctx context.Context, req *pb.GetUsersBySectionRequest, opts ...grpc.CallOption) (*pb.GetUsersBySectionResponse, error) {
+	return &pb.GetUsersBySectionResponse{
+		Section: req.Section,
+		Users:   []*pb.UserSeat{{User: testReceipt.User, AllottedSeat: 1}},
+	}, nil
+}
+
+func (f *fakeClient) GetConfig(ctx context.Context, req *pb.GetConfigRequest, opts ...grpc.CallOption) (*pb.GetConfigResponse, error) {
+	return &pb.GetConfigResponse{Config: "log_level: info\n"}, nil
+}
+
+func (f *fakeClient) GetAssignmentTrace(ctx context.Context, req *pb.GetAssignmentTraceRequest, opts ...grpc.CallOption) (*pb.GetAssignmentTraceResponse, error) {
+	return &pb.GetAssignmentTraceResponse{Strategy: "round_robin", SkippedSections: 2}, nil
+}
+
+func (f *fakeClient) GetManifest(ctx context.Context, req *pb.GetManifestRequest, opts ...grpc.CallOption) (*pb.GetManifestResponse, error) {
+	return &pb.GetManifestResponse{
+		Entries: []*pb.ManifestEntry{{
+			Seat:      testReceipt.Seat,
+			User:      testReceipt.User,
+			From:      testReceipt.From,
+			To:        testReceipt.To,
+			PricePaid: testReceipt.PricePaid,
+		}},
+		TotalPassengers: 1,
+		OccupiedSeats:   1,
+		TotalFares:      testReceipt.PricePaid,
+	}, nil
+}
+
+func (f *fakeClient) ListReceiptsByPriceRange(ctx context.Context, req *pb.ListReceiptsByPriceRangeRequest, opts ...grpc.CallOption) (*pb.ListReceiptsByPriceRangeResponse, error) {
+	f.priceRangeReq = req
+	return &pb.ListReceiptsByPriceRangeResponse{Receipts: []*pb.Receipt{testReceipt}}, nil
+}
+
+func newTestCLI(client *fakeClient, json bool) (*cli, *bytes.Buffer) {
+	out := &bytes.Buffer{}
+	return &cli{client: client, out: out, stderr: &bytes.Buffer{}, json: json}, out
+}
+
+func TestParseOptions(t *testing.T) {
+	opts, args, err := parseOptions([]string{"-address", "rail:9000", "-json", "receipt", "-email", "a@b.c"}, &bytes.Buffer{})
+	assert.NoError(t, err)
+	assert.Equal(t, "rail:9000", opts.address)
+	assert.True(t, opts.json)
+	assert.Equal(t, []string{"receipt", "-email", "a@b.c"}, args, "Command arguments should be passed through")
+
+	_, _, err = parseOptions([]string{"-json"}, &bytes.Buffer{})
+	assert.Error(t, err, "Missing command should be an error")
+}
+
+func TestRunArgumentErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"No Command", []string{}},
+		{"Unknown Command", []string{"teleport"}},
+		{"Missing Required Flag", []string{"purchase", "-email", "test@example.com"}},
+		{"Unknown Flag", []string{"receipt", "-name", "A"}},
+		{"Unexpected Argument", []string{"info", "extra"}},
+		{"Bulk Cancel Without Filter", []string{"bulk-cancel", "-from", "London"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, _ := newTestCLI(&fakeClient{}, false)
+			assert.Error(t, c.run(context.Background(), test.args))
+		})
+	}
+}
+
+func TestRunPurchase(t *testing.T) {
+	client := &fakeClient{}
+	c, out := newTestCLI(client, false)
+
+	err := c.run(context.Background(), []string{"purchase", "-email", "test@example.com", "-first", "Sanjay", "-last", "Kishor", "-from", "London", "-to", "France", "-dry-run", "-prefer", "B,A", "-date", "2024-03-11", "-train", "IC101"})
+	assert.NoError(t, err)
+	assert.Equal(t, "test@example.com", client.purchaseReq.User.Email)
+	assert.Equal(t, "London", client.purchaseReq.From)
+	assert.Equal(t, "France", client.purchaseReq.To)
+	assert.True(t, client.purchaseReq.DryRun)
+	assert.Equal(t, []string{"B", "A"}, client.purchaseReq.PreferredSections)
+	assert.Equal(t, "2024-03-11", client.purchaseReq.DepartureDate)
+	assert.Equal(t, "IC101", client.purchaseReq.TrainId)
+
+	assert.Equal(t, "Ticket booked successfully\n"+
+		"REFERENCE  EMAIL             NAME           FROM    TO      SECTION  SEAT  PRICE\n"+
+		"K7QX2M     test@example.com  Sanjay Kishor  London  France  A        1     20.00\n", out.String())
+}
+
+func TestRunPurchaseError(t *testing.T) {
+	client := &fakeClient{err: status.Error(codes.ResourceExhausted, "no available seats")}
+	c, out := newTestCLI(client, false)
+
+	err := c.run(context.Background(), []string{"purchase", "-email", "test@example.com", "-from", "London", "-to", "France"})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err), "Server errors should be returned")
+	assert.Empty(t, out.String(), "Nothing should be printed on error")
+}
+
+func TestRunUpdateAndMaintenance(t *testing.T) {
+	client := &fakeClient{}
+	c, _ := newTestCLI(client, false)
+
+	err := c.run(context.Background(), []string{"update", "-email", "test@example.com", "-section", "B", "-seat", "7"})
+	assert.NoError(t, err)
+	assert.Equal(t, "B", client.updateReq.NewSeat.Section)
+	assert.Equal(t, int32(7), client.updateReq.NewSeat.SeatNumber)
+
+	err = c.run(context.Background(), []string{"update", "-email", "test@example.com", "-seat", "3"})
+	assert.NoError(t, err)
+	assert.Empty(t, client.updateReq.NewSeat.Section, "Section may be left to the server")
+	assert.Equal(t, int32(3), client.updateReq.NewSeat.SeatNumber)
+
+	err = c.run(context.Background(), []string{"update", "-email", "test@example.com"})
+	assert.Error(t, err, "Update needs a section or a seat")
+
+	err = c.run(context.Background(), []string{"maintenance", "-enabled=true"})
+	assert.NoError(t, err)
+	assert.True(t, client.maintReq.Enabled)
+
+	err = c.run(context.Background(), []string{"bulk-cancel", "-section", "A", "-at", "Paris"})
+	assert.NoError(t, err)
+	assert.Equal(t, "A", client.bulkReq.Section)
+	assert.Equal(t, "Paris", client.bulkReq.CancelledAt)
+}
+
+func TestRunVacancy(t *testing.T) {
+	c, out := newTestCLI(&fakeClient{}, false)
+
+	err := c.run(context.Background(), []string{"vacancy", "-section", "A"})
+	assert.NoError(t, err)
+	assert.Equal(t, "19 of 20 seats vacant\n", out.String())
+}
+
+func TestRunConfig(t *testing.T) {
+	c, out := newTestCLI(&fakeClient{}, false)
+
+	err := c.run(context.Background(), []string{"config"})
+	assert.NoError(t, err)
+	assert.Equal(t, "log_level: info\n", out.String())
+}
+
+func TestRunTrace(t *testing.T) {
+	c, out := newTestCLI(&fakeClient{}, false)
+
+	err := c.run(context.Background(), []string{"trace", "-ref", "ABC123"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Strategy: round_robin\nPreference honored: no\nSkipped sections: 2\n", out.String())
+
+	err = c.run(context.Background(), []string{"trace"})
+	assert.Error(t, err, "A booking reference is required")
+}
+
+func TestRunManifest(t *testing.T) {
+	c, out := newTestCLI(&fakeClient{}, false)
+
+	err := c.run(context.Background(), []string{"manifest"})
+	assert.NoError(t, err)
+	assert.Equal(t, "SECTION  SEAT  NAME           EMAIL             FROM    TO      PRICE\n"+
+		"A        1     Sanjay Kishor  test@example.com  London  France  20.00\n"+
+		"Passengers: 1\nOccupied seats: 1\nTotal fares: 20.00\n", out.String())
+}
+
+func TestRunPriceRange(t *testing.T) {
+	fake := &fakeClient{}
+	c, out := newTestCLI(fake, false)
+
+	err := c.run(context.Background(), []string{"price-range", "-min", "10", "-max", "30"})
+	assert.NoError(t, err)
+	assert.Equal(t, 10.0, fake.priceRangeReq.MinPrice)
+	assert.Equal(t, 30.0, fake.priceRangeReq.MaxPrice)
+	assert.Contains(t, out.String(), "K7QX2M")
+
+	err = c.run(context.Background(), []string{"price-range", "-min", "10"})
+	assert.Error(t, err, "A maximum price is required")
+}
+
+func TestRunForceMove(t *testing.T) {
+	fake := &fakeClient{}
+	c, out := newTestCLI(fake, false)
+
+	err := c.run(context.Background(), []string{"force-move", "-email", "test@example.com", "-section", "B", "-seat", "4", "-reason", "Disruptive"})
+	assert.NoError(t, err)
+	assert.Equal(t, &pb.ForceReassignRequest{Email: "test@example.com", TargetSection: "B", TargetSeat: 4, Reason: "Disruptive"}, fake.forceReq)
+	assert.Contains(t, out.String(), "Passenger moved")
+
+	err = c.run(context.Background(), []string{"force-move", "-email", "test@example.com", "-section", "B", "-seat", "4"})
+	assert.Error(t, err, "A reason is required")
+}
+
+func TestRunJSON(t *testing.T) {
+	c, out := newTestCLI(&fakeClient{}, true)
+
+	err := c.run(context.Background(), []string{"section", "-name", "A"})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"section": "A",
+		"users": [{"user": {"firstName": "Sanjay", "lastName": "Kishor", "email": "test@example.com"}, "allottedSeat": 1}]
+	}`, out.String())
+}
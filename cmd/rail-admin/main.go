@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/sanjaykishor/rail-connect/client"
+)
+
+const usage = `Usage: rail-admin [flags] <command> [command flags]
+
+Commands:
+  purchase     Purchase a ticket
+  receipt      Show a user's receipt, by email or booking reference
+  section      List the users seated in a section
+  manifest     Print the passenger manifest, by section then seat (needs an admin -api-key)
+  seat         Show the position, availability and occupants of a seat
+  vacancy      Show how many seats are left in a section
+  sections     List every section with its capacity and vacancy
+  route        List the users travelling between two stations (needs an admin -api-key)
+  cheapest     List the cheapest bookable destinations from a station
+  price-range  List the receipts whose price paid is within a range (needs an admin -api-key)
+  update       Move a user to another seat
+  force-move   Move a user to an exact free seat, bypassing seating rules (needs an admin -api-key)
+  remove       Cancel a user's ticket
+  swap         Swap the seats of two users
+  history      Show the history of a user's ticket
+  maintenance  Enable or disable maintenance mode (needs an admin -api-key)
+  bulk-cancel  Cancel every ticket in a section or on a route (needs an admin -api-key)
+  resize       Change the number of seats in a section (needs an admin -api-key)
+  block        Take a broken seat out of circulation (needs an admin -api-key)
+  unblock      Return a blocked seat to circulation (needs an admin -api-key)
+  verify       Check seat bookkeeping, optionally repairing it (needs an admin -api-key)
+  info         Show the server version and enabled features
+  config       Show the configuration the server is running (needs an admin -api-key)
+  trace        Show how a booking's seat was chosen (needs an admin -api-key)
+
+Run 'rail-admin <command> -h' for the flags of a command.
+
+Flags:
+`
+
+// options holds the flags shared by every command.
+type options struct {
+	address string
+	json    bool
+	timeout time.Duration
+	apiKey  string
+}
+
+// parseOptions parses the global flags and returns the remaining arguments,
+// starting with the command name.
+func parseOptions(args []string, stderr io.Writer) (options, []string, error) {
+	var opts options
+	fs := flag.NewFlagSet("rail-admin", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.StringVar(&opts.address, "address", "localhost:50051", "The server address in the format of host:port")
+	fs.BoolVar(&opts.json, "json", false, "Print responses as JSON instead of tables")
+	fs.DurationVar(&opts.timeout, "timeout", 10*time.Second, "How long to wait for the server")
+	fs.StringVar(&opts.apiKey, "api-key", "", "API key sent with every call")
+	fs.Usage = func() {
+		fmt.Fprint(stderr, usage)
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return opts, nil, err
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return opts, nil, fmt.Errorf("no command given")
+	}
+	return opts, fs.Args(), nil
+}
+
+func main() {
+	os.Exit(runMain(os.Args[1:]))
+}
+
+// runMain connects to the server, runs the command and returns the exit code.
+func runMain(args []string) int {
+	opts, args, err := parseOptions(args, os.Stderr)
+	if errors.Is(err, flag.ErrHelp) {
+		return 0
+	}
+	if err != nil {
+		return 2
+	}
+
+	conn, err := client.New(opts.address, client.WithTimeout(opts.timeout), client.WithAPIKey(opts.apiKey))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "rail-admin: did not connect: %v\n", err)
+		return 1
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), opts.timeout)
+	defer cancel()
+
+	admin := &cli{
+		client: conn,
+		out:    os.Stdout,
+		stderr: os.Stderr,
+		json:   opts.json,
+	}
+	if err := admin.run(ctx, args); err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintf(os.Stderr, "rail-admin: %v\n", err)
+		}
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,195 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// printJSON writes a response as indented JSON.
+func printJSON(out io.Writer, msg proto.Message) error {
+	data, err := protojson.MarshalOptions{Multiline: true, Indent: "  "}.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(out, string(data))
+	return err
+}
+
+// printMessage writes the server's status message, if any.
+func printMessage(out io.Writer, message string) {
+	if message != "" {
+		fmt.Fprintln(out, message)
+	}
+}
+
+// printTable writes rows as aligned columns under a header.
+func printTable(out io.Writer, header []string, rows ...[]string) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, strings.Join(header, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	return w.Flush()
+}
+
+// printReceipts writes one row per receipt.
+func printReceipts(out io.Writer, receipts ...*pb.Receipt) error {
+	rows := make([][]string, 0, len(receipts))
+	for _, receipt := range receipts {
+		if receipt == nil {
+			continue
+		}
+		rows = append(rows, []string{
+			receipt.BookingReference,
+			receipt.GetUser().GetEmail(),
+			fullName(receipt.GetUser()),
+			receipt.From,
+			receipt.To,
+			receipt.GetSeat().GetSection(),
+			fmt.Sprint(receipt.GetSeat().GetSeatNumber()),
+			fmt.Sprintf("%.2f", receipt.PricePaid),
+		})
+	}
+	return printTable(out, []string{"REFERENCE", "EMAIL", "NAME", "FROM", "TO", "SECTION", "SEAT", "PRICE"}, rows...)
+}
+
+// printRefunds writes the refund for each cancelled ticket.
+func printRefunds(out io.Writer, refunds []*pb.Refund) error {
+	rows := make([][]string, 0, len(refunds))
+	for _, refund := range refunds {
+		rows = append(rows, []string{refund.Email, fmt.Sprintf("%.2f", refund.Amount)})
+	}
+	return printTable(out, []string{"EMAIL", "REFUND"}, rows...)
+}
+
+// printUserSeats writes one row per seated user, as listed for a section.
+func printUserSeats(out io.Writer, users []*pb.UserSeat) error {
+	rows := make([][]string, 0, len(users))
+	for _, user := range users {
+		rows = append(rows, []string{
+			fmt.Sprint(user.AllottedSeat),
+			user.GetUser().GetEmail(),
+			fullName(user.GetUser()),
+		})
+	}
+	return printTable(out, []string{"SEAT", "EMAIL", "NAME"}, rows...)
+}
+
+// printRouteUsers writes one row per user, as listed for a route.
+func printRouteUsers(out io.Writer, users []*pb.RouteUser) error {
+	rows := make([][]string, 0, len(users))
+	for _, user := range users {
+		rows = append(rows, []string{
+			user.GetSeat().GetSection(),
+			fmt.Sprint(user.GetSeat().GetSeatNumber()),
+			user.GetUser().GetEmail(),
+			fullName(user.GetUser()),
+		})
+	}
+	return printTable(out, []string{"SECTION", "SEAT", "EMAIL", "NAME"}, rows...)
+}
+
+// printManifest writes one row per passenger followed by the manifest totals.
+func printManifest(out io.Writer, manifest *pb.GetManifestResponse) error {
+	rows := make([][]string, 0, len(manifest.Entries))
+	for _, entry := range manifest.Entries {
+		rows = append(rows, []string{
+			entry.GetSeat().GetSection(),
+			fmt.Sprint(entry.GetSeat().GetSeatNumber()),
+			fullName(entry.GetUser()),
+			entry.GetUser().GetEmail(),
+			entry.From,
+			entry.To,
+			fmt.Sprintf("%.2f", entry.PricePaid),
+		})
+	}
+	if err := printTable(out, []string{"SECTION", "SEAT", "NAME", "EMAIL", "FROM", "TO", "PRICE"}, rows...); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(out, "Passengers: %d\nOccupied seats: %d\nTotal fares: %.2f\n", manifest.TotalPassengers, manifest.OccupiedSeats, manifest.TotalFares)
+	return err
+}
+
+// printSections writes one row per section, marking the default section.
+func printSections(out io.Writer, sections []*pb.SectionInfo) error {
+	rows := make([][]string, 0, len(sections))
+	for _, section := range sections {
+		name := section.Name
+		if section.IsDefault {
+			name += " (default)"
+		}
+		rows = append(rows, []string{
+			name,
+			fmt.Sprint(section.MaxSeats),
+			fmt.Sprint(section.VacantSeats),
+			fmt.Sprint(section.BlockedSeats),
+			section.OverflowPolicy,
+		})
+	}
+	return printTable(out, []string{"SECTION", "SEATS", "VACANT", "BLOCKED", "WHEN FULL"}, rows...)
+}
+
+// printRouteFares writes one row per bookable route.
+func printRouteFares(out io.Writer, routes []*pb.RouteFare) error {
+	rows := make([][]string, 0, len(routes))
+	for _, route := range routes {
+		rows = append(rows, []string{
+			route.From,
+			route.To,
+			fmt.Sprintf("%.2f", route.Price),
+			fmt.Sprint(route.AvailableSeats),
+		})
+	}
+	return printTable(out, []string{"FROM", "TO", "PRICE", "FREE SEATS"}, rows...)
+}
+
+// printEvents writes one row per receipt history event.
+func printEvents(out io.Writer, events []*pb.ReceiptEvent) error {
+	// Only forced reassignments have a reason, so the column is shown only
+	// when one of them is listed
+	header := []string{"TIME", "EVENT", "FROM", "TO", "SECTION", "SEAT"}
+	withReason := slices.ContainsFunc(events, func(event *pb.ReceiptEvent) bool { return event.Reason != "" })
+	if withReason {
+		header = append(header, "REASON")
+	}
+
+	rows := make([][]string, 0, len(events))
+	for _, event := range events {
+		row := []string{
+			time.Unix(event.Timestamp, 0).UTC().Format(time.RFC3339),
+			strings.TrimPrefix(event.Type.String(), "RECEIPT_EVENT_"),
+			event.From,
+			event.To,
+			event.GetSeat().GetSection(),
+			fmt.Sprint(event.GetSeat().GetSeatNumber()),
+		}
+		if withReason {
+			row = append(row, event.Reason)
+		}
+		rows = append(rows, row)
+	}
+	return printTable(out, header, rows...)
+}
+
+// pairSeating renders how close together a pair was seated, e.g. SAME_SECTION.
+func pairSeating(seating pb.PairSeating) string {
+	return strings.TrimPrefix(seating.String(), "PAIR_SEATING_")
+}
+
+// fullName joins a user's first and last names.
+func fullName(user *pb.User) string {
+	return strings.TrimSpace(user.GetFirstName() + " " + user.GetLastName())
+}
+
+// formatUptime renders an uptime in seconds as a duration.
+func formatUptime(seconds int64) string {
+	return (time.Duration(seconds) * time.Second).String()
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+)
+
+func TestPrintTable(t *testing.T) {
+	out := &bytes.Buffer{}
+	err := printTable(out, []string{"SECTION", "SEATS"}, []string{"A", "50"}, []string{"Lounge", "8"})
+	assert.NoError(t, err)
+	assert.Equal(t, "SECTION  SEATS\nA        50\nLounge   8\n", out.String())
+}
+
+func TestPrintReceiptsSkipsNil(t *testing.T) {
+	out := &bytes.Buffer{}
+	err := printReceipts(out, nil, testReceipt)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, bytes.Count(out.Bytes(), []byte("\n")), "Only the header and one receipt should be printed")
+}
+
+func TestPrintRouteUsers(t *testing.T) {
+	out := &bytes.Buffer{}
+	err := printRouteUsers(out, []*pb.RouteUser{{User: testReceipt.User, Seat: testReceipt.Seat}})
+	assert.NoError(t, err)
+	assert.Equal(t, "SECTION  SEAT  EMAIL             NAME\n"+
+		"A        1     test@example.com  Sanjay Kishor\n", out.String())
+}
+
+func TestPrintRouteFares(t *testing.T) {
+	out := &bytes.Buffer{}
+	err := printRouteFares(out, []*pb.RouteFare{{From: "London", To: "France", Price: 20, AvailableSeats: 12}})
+	assert.NoError(t, err)
+	assert.Equal(t, "FROM    TO      PRICE  FREE SEATS\n"+
+		"London  France  20.00  12\n", out.String())
+}
+
+func TestPrintSections(t *testing.T) {
+	out := &bytes.Buffer{}
+	err := printSections(out, []*pb.SectionInfo{
+		{Name: "A", MaxSeats: 20, VacantSeats: 19, BlockedSeats: 1, OverflowPolicy: "waitlist", IsDefault: true},
+		{Name: "B", MaxSeats: 10, VacantSeats: 10, OverflowPolicy: "spill"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "SECTION      SEATS  VACANT  BLOCKED  WHEN FULL\n"+
+		"A (default)  20     19      1        waitlist\n"+
+		"B            10     10      0        spill\n", out.String())
+}
+
+func TestPrintRefunds(t *testing.T) {
+	out := &bytes.Buffer{}
+	err := printRefunds(out, []*pb.Refund{{Email: "test@example.com", Amount: 10}})
+	assert.NoError(t, err)
+	assert.Equal(t, "EMAIL             REFUND\n"+
+		"test@example.com  10.00\n", out.String())
+}
+
+func TestPrintEvents(t *testing.T) {
+	out := &bytes.Buffer{}
+	err := printEvents(out, []*pb.ReceiptEvent{{
+		Type:      pb.ReceiptEventType_RECEIPT_EVENT_SEAT_CHANGED,
+		From:      "London",
+		To:        "France",
+		Seat:      &pb.Seat{Section: "B", SeatNumber: 3},
+		Timestamp: 0,
+	}})
+	assert.NoError(t, err)
+	assert.Equal(t, "TIME                  EVENT         FROM    TO      SECTION  SEAT\n"+
+		"1970-01-01T00:00:00Z  SEAT_CHANGED  London  France  B        3\n", out.String())
+
+	// Forced reassignments show their reason
+	out.Reset()
+	err = printEvents(out, []*pb.ReceiptEvent{{
+		Type:   pb.ReceiptEventType_RECEIPT_EVENT_FORCE_REASSIGNED,
+		From:   "London",
+		To:     "France",
+		Seat:   &pb.Seat{Section: "A", SeatNumber: 1},
+		Reason: "Disruptive",
+	}})
+	assert.NoError(t, err)
+	assert.Equal(t, "TIME                  EVENT             FROM    TO      SECTION  SEAT  REASON\n"+
+		"1970-01-01T00:00:00Z  FORCE_REASSIGNED  London  France  A        1     Disruptive\n", out.String())
+}
+
+func TestPairSeating(t *testing.T) {
+	assert.Equal(t, "SAME_SECTION", pairSeating(pb.PairSeating_PAIR_SEATING_SAME_SECTION))
+}
+
+func TestFormatUptime(t *testing.T) {
+	assert.Equal(t, "1h1m1s", formatUptime(3661))
+	assert.Equal(t, "Sanjay Kishor", fullName(testReceipt.User))
+	assert.Equal(t, "", fullName(nil))
+}
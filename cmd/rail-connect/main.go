@@ -1,13 +1,20 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"log"
 	"net"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"slices"
 	"syscall"
+	"time"
 
 	"github.com/sanjaykishor/rail-connect/internal/config"
+	"github.com/sanjaykishor/rail-connect/internal/middleware"
 	"github.com/sanjaykishor/rail-connect/internal/service"
 	pb "github.com/sanjaykishor/rail-connect/proto"
 	"go.uber.org/zap"
@@ -16,35 +23,83 @@ import (
 	"google.golang.org/grpc/health/grpc_health_v1"
 )
 
+// Build information, injected at build time via -ldflags.
+var (
+	version   = "dev"
+	gitCommit = "unknown"
+)
+
+// configPath is the configuration file, relative to the working directory.
+const configPath = "config/config.yaml"
+
+// shutdownTimeout bounds how long pending notifications and flushes may delay
+// exit once the server has stopped.
+const shutdownTimeout = 30 * time.Second
+
+// expirySweepInterval is how often unpaid provisional bookings are cancelled
+// and expired seat holds released.
+const expirySweepInterval = time.Second
+
 func main() {
-	// Load configuration from config.yaml.
-	cfg, err := config.LoadConfig("config/config.yaml", config.OSFileReader{})
-	if err != nil {
+	// Load configuration from config.yaml, telling a missing file from a malformed one.
+	cfg, err := config.LoadConfig(configPath, config.OSFileReader{})
+	switch {
+	case errors.Is(err, config.ErrConfigNotFound):
+		log.Fatalf("Configuration file %s not found; check that it is mounted into the working directory: %v", configPath, err)
+	case errors.Is(err, config.ErrConfigParse):
+		log.Fatalf("Configuration file %s is not valid YAML; check it for typos: %v", configPath, err)
+	case err != nil:
 		log.Fatalf("Failed to load configuration: %v", err)
 	}
+	if err := cfg.Validate(); err != nil {
+		log.Fatalf("Invalid configuration: %v", err)
+	}
 
-	logger := config.NewLogger(cfg.LogLevel)
-
-	// Create a new gRPC server.
-	grpcServer := grpc.NewServer()
-
-	sections := cfg.Sections
+	// A logging misconfiguration shouldn't stop the service; carry on with
+	// the fallback logger.
+	logger, err := config.NewLogger(cfg.LogLevel)
+	if err != nil {
+		logger.Error("Falling back to the development logger", zap.Error(err))
+	}
 
-	// Initialize SeatManager using the configuration.
-	seatManager := service.NewSeatManager(sections, logger)
+	// Log the effective configuration so it's clear what took effect.
+	logger.Info("Configuration loaded", append(cfg.Summary(),
+		zap.String("version", version),
+		zap.String("git_commit", gitCommit))...)
 
-	// Initialize station connection prices from config
-	connectionStations := cfg.Stations
+	ticketService, err := newTicketService(cfg, logger)
+	if err != nil {
+		log.Fatalf("Invalid configuration: %v", err)
+	}
 
-	// Initialize your service, passing the dependencies.
-	ticketService := service.NewTicketManager(seatManager, connectionStations, logger)
+	// Reload the booking state saved by the previous run, if any, and save it
+	// again on shutdown.
+	if cfg.SnapshotPath != "" {
+		// Starting empty would overwrite the snapshot with no bookings on
+		// shutdown, so one that can't be restored stops the server
+		if err := restoreSnapshot(cfg.SnapshotPath, ticketService, logger); err != nil {
+			log.Fatalf("Failed to restore snapshot: %v", err)
+		}
+		flusher := snapshotFlusher{cfg.SnapshotPath, ticketService}
+		ticketService.Flushers = append(ticketService.Flushers, flusher)
+		ticketService.ReadinessChecks = append(ticketService.ReadinessChecks, service.ReadinessCheck{Name: "snapshot_store", Check: flusher.Ready})
+	}
 
-	// Register the service with the server.
-	pb.RegisterTicketBookingServiceServer(grpcServer, ticketService)
+	grpcServer, healthServer, err := newServer(cfg, ticketService)
+	if err != nil {
+		log.Fatalf("Invalid configuration: %v", err)
+	}
 
-	healthServer := health.NewServer()
-	grpc_health_v1.RegisterHealthServer(grpcServer, healthServer)
-	healthServer.SetServingStatus("", grpc_health_v1.HealthCheckResponse_SERVING)
+	// Keep the readiness status current while the server runs.
+	watchCtx, stopWatching := context.WithCancel(context.Background())
+	defer stopWatching()
+	go ticketService.WatchReadiness(watchCtx, healthServer, cfg.Server.ReadinessInterval())
+	if ticketService.PaymentWindow > 0 {
+		go ticketService.WatchPaymentDeadlines(watchCtx, expirySweepInterval)
+	}
+	if ticketService.HoldDuration > 0 {
+		go ticketService.WatchHolds(watchCtx, expirySweepInterval)
+	}
 
 	listen, err := net.Listen("tcp", cfg.Server.Port)
 	if err != nil {
@@ -58,13 +113,299 @@ func main() {
 		}
 	}()
 
+	// SIGHUP reloads the section layout from the configuration file; any
+	// other signal stops the server.
 	sigCh := make(chan os.Signal, 1)
-	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
 
 	sig := <-sigCh
+	for sig == syscall.SIGHUP {
+		reloadSections(configPath, ticketService, logger)
+		sig = <-sigCh
+	}
 	logger.Info("Received signal:", zap.String("signal", sig.String()))
 
 	logger.Info("Stopping server...")
+	stopWatching()
+	healthServer.Shutdown()
+	// Availability streams never finish on their own, so end them first
+	ticketService.StopWatchers()
 	grpcServer.GracefulStop()
 	logger.Info("Server stopped.")
+
+	// Let notifications for the last bookings finish sending, save the
+	// snapshot and report the final stats.
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if _, err := ticketService.Shutdown(ctx); err != nil {
+		logger.Error("Shutdown incomplete", zap.Error(err))
+	}
+}
+
+// snapshotFlusher saves the booking state to a file when the server shuts down.
+type snapshotFlusher struct {
+	path          string
+	ticketService *service.TicketManager
+}
+
+func (f snapshotFlusher) Flush(ctx context.Context) error {
+	if err := saveSnapshot(f.path, f.ticketService); err != nil {
+		return fmt.Errorf("failed to save snapshot to %s: %w", f.path, err)
+	}
+	return nil
+}
+
+// Ready checks that the snapshot's directory exists, so the snapshot can be
+// saved on shutdown.
+func (f snapshotFlusher) Ready(ctx context.Context) error {
+	info, err := os.Stat(filepath.Dir(f.path))
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", filepath.Dir(f.path))
+	}
+	return nil
+}
+
+// restoreSnapshot loads the booking state from path. A missing snapshot is
+// logged and the server starts with no bookings; one that can't be opened or
+// restored is returned as an error.
+func restoreSnapshot(path string, ticketService *service.TicketManager, logger *zap.Logger) error {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		logger.Info("No snapshot to restore", zap.String("path", path))
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if err := ticketService.Restore(file); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
+
+// reloadSections applies the sections of the configuration file at path to
+// the running service, logging each change made and each one skipped. Riders
+// of the default train in seats a change takes away are moved to free ones. A
+// file that fails to load or validate, or whose sections would take away seats
+// riders are sitting in on any dated departure, is rejected with each conflict
+// logged, leaving the sections as they are. Otherwise every difference from
+// the previous file is logged, though only sections take effect before a
+// restart.
+func reloadSections(path string, ticketService *service.TicketManager, logger *zap.Logger) {
+	cfg, err := config.LoadConfig(path, config.OSFileReader{})
+	if err != nil {
+		logger.Error("Failed to reload sections", zap.String("path", path), zap.Error(err))
+		return
+	}
+
+	compatibility, report := ticketService.ReloadCompatibleSections(cfg)
+	if compatibility.Invalid != nil {
+		logger.Error("Failed to reload sections", zap.String("path", path), zap.Error(compatibility.Invalid))
+		return
+	}
+	if !compatibility.Compatible() {
+		for _, conflict := range compatibility.Conflicts {
+			logger.Warn("Section change takes away occupied seats",
+				zap.String("train_id", conflict.TrainId),
+				zap.String("departure_date", conflict.DepartureDate),
+				zap.String("section", conflict.Section),
+				zap.String("change", conflict.Change),
+				zap.Int("max_seats", conflict.MaxSeats),
+				zap.Ints("occupied_seats", conflict.OccupiedSeats))
+		}
+		logger.Error("Failed to reload sections", zap.String("path", path), zap.Error(compatibility.Err()))
+		return
+	}
+
+	for _, change := range ticketService.RecordConfigReload(cfg) {
+		logger.Info("Config change",
+			zap.String("path", change.Path),
+			zap.String("change", change.Change),
+			zap.String("old", change.Old),
+			zap.String("new", change.New))
+	}
+
+	for _, change := range report.Applied {
+		logger.Info("Section change applied",
+			zap.String("section", change.Section),
+			zap.String("change", change.Change),
+			zap.Int("max_seats", change.MaxSeats),
+			zap.Int("migrated", change.Migrated))
+	}
+	for _, change := range report.Skipped {
+		logger.Warn("Section change skipped",
+			zap.String("section", change.Section),
+			zap.String("change", change.Change),
+			zap.Int("max_seats", change.MaxSeats),
+			zap.String("reason", change.Reason))
+	}
+}
+
+// saveSnapshot writes the booking state to path, replacing the previous
+// snapshot only once the new one is complete.
+func saveSnapshot(path string, ticketService *service.TicketManager) error {
+	file, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	if err := ticketService.Snapshot(file); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), path)
+}
+
+// newTicketService builds the seat and ticket managers from configuration.
+func newTicketService(cfg *config.Config, logger *zap.Logger) (*service.TicketManager, error) {
+	// Initialize SeatManager using the configuration.
+	seatManager := service.NewSeatManager(cfg.Sections, logger)
+	seatManager.DefaultSection = cfg.Seating.DefaultSection
+	seatManager.Positions = service.NewSeatPositions(cfg.Seating.RowLayout, cfg.Seating.PositionOrder)
+	seatManager.RowLayout = cfg.Seating.RowLayout
+	seatManager.Route = cfg.Route
+	seatManager.AssignLockTimeout = time.Duration(cfg.Seating.AssignLockTimeoutMillis) * time.Millisecond
+	if threshold := cfg.Alerts.OccupancyThreshold; threshold > 0 {
+		seatManager.AlertThreshold = threshold
+		seatManager.OnOccupancyAlert = func(above bool, occupied, total int) {
+			fields := []zap.Field{zap.Int("occupied", occupied), zap.Int("total", total), zap.Float64("threshold", threshold)}
+			if above {
+				logger.Warn("Occupancy reached alert threshold", fields...)
+			} else {
+				logger.Info("Occupancy back below alert threshold", fields...)
+			}
+		}
+	}
+
+	// Initialize your service, passing the dependencies.
+	ticketService := service.NewTicketManager(seatManager, cfg.Stations.Prices, logger)
+	ticketService.DedupeWindow = time.Duration(cfg.Booking.DedupeWindowSeconds) * time.Second
+	ticketService.RebookCooldown = time.Duration(cfg.Booking.RebookCooldownSeconds) * time.Second
+	ticketService.AdvanceDays = cfg.Booking.AdvanceDays
+	ticketService.DefaultTrain = cfg.Booking.DefaultTrain
+	ticketService.Trains = cfg.Booking.Trains
+	ticketService.PaymentWindow = time.Duration(cfg.Booking.PaymentWindowSeconds) * time.Second
+	ticketService.HoldDuration = time.Duration(cfg.Booking.HoldSeconds) * time.Second
+	ticketService.MaxHoldsPerUser = cfg.Booking.MaxHoldsPerUser
+	ticketService.RouteCaps = cfg.Booking.RouteCaps
+	ticketService.CanonicalizeStations = cfg.Stations.Canonicalize
+	ticketService.StrictRequests = cfg.Server.StrictRequests
+	ticketService.DestinationAffinity = cfg.Seating.DestinationAffinity
+	ticketService.LoyaltySections = cfg.Seating.LoyaltySections
+	ticketService.DistanceFares = service.DistanceFares{
+		RatePerKm:     cfg.Fares.RatePerKm,
+		Stations:      cfg.Fares.Coordinates,
+		MaxDistanceKm: cfg.Fares.MaxDistanceKm,
+	}
+	timeWindows, err := service.NewTimeWindows(cfg.Pricing.TimeWindows)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pricing time windows: %w", err)
+	}
+	ticketService.TimeWindows = timeWindows
+	ticketService.FareRounding = cfg.Pricing.Rounding
+	ticketService.BookingFee = cfg.Pricing.BookingFee
+	ticketService.FareClasses = service.NewFareClasses(cfg.Pricing.FareClasses)
+	ticketService.RefundPolicy = service.NewRefundPolicy(cfg.Pricing.RefundPolicy)
+	ticketService.ReferenceFormat = service.NewReferenceFormat(cfg.Booking.Reference)
+	if smtp := cfg.Notifications.SMTP; smtp.Host != "" {
+		ticketService.Notifier = service.NewSMTPNotifier(smtp.Host, smtp.Port, smtp.Username, smtp.Password, smtp.From)
+	}
+	ticketService.SetMaintenance(cfg.MaintenanceMode)
+	ticketService.BuildInfo = service.BuildInfo{
+		Version:         version,
+		GitCommit:       gitCommit,
+		EnabledFeatures: cfg.EnabledFeatures(),
+	}
+	ticketService.Config = cfg
+	ticketService.ReadinessChecks = []service.ReadinessCheck{
+		{Name: "config", Check: func(ctx context.Context) error { return cfg.Validate() }},
+	}
+
+	return ticketService, nil
+}
+
+// newServer builds the gRPC server with the configured interceptor chain and
+// registers the ticket and health services on it, with the readiness status
+// already checked once.
+func newServer(cfg *config.Config, ticketService *service.TicketManager) (*grpc.Server, *health.Server, error) {
+	// Feature flags must name methods the server actually has
+	for method := range cfg.Features {
+		if !slices.ContainsFunc(pb.TicketBookingService_ServiceDesc.Methods, func(desc grpc.MethodDesc) bool {
+			return desc.MethodName == method
+		}) && !slices.ContainsFunc(pb.TicketBookingService_ServiceDesc.Streams, func(desc grpc.StreamDesc) bool {
+			return desc.StreamName == method
+		}) {
+			return nil, nil, fmt.Errorf("feature flag for unknown method %s", method)
+		}
+	}
+
+	// Build the interceptor chain from configuration. Disabled methods are
+	// rejected first, as if they did not exist, though still under the caller's
+	// request ID.
+	interceptors := []grpc.UnaryServerInterceptor{
+		middleware.RequestIDInterceptor(ticketService.Logger),
+		middleware.FeatureFlagInterceptor(cfg.Features),
+		middleware.MaintenanceInterceptor(ticketService.InMaintenance, service.MutatingMethods...),
+	}
+	if hot := cfg.Server.HotRequests; hot.Threshold > 0 {
+		// Observe every request, including those rejected further down the chain
+		interceptors = append([]grpc.UnaryServerInterceptor{middleware.HotRequestInterceptor(
+			ticketService.Logger, hot.Threshold, time.Duration(hot.WindowSeconds)*time.Second, hot.SampleEvery)}, interceptors...)
+	}
+	streamInterceptors := []grpc.StreamServerInterceptor{
+		middleware.FeatureFlagStreamInterceptor(cfg.Features),
+		middleware.MaintenanceStreamInterceptor(ticketService.InMaintenance, service.MutatingMethods...),
+	}
+	if len(cfg.Server.APIKeys) > 0 {
+		keys := make(map[string]middleware.Principal, len(cfg.Server.APIKeys))
+		for _, apiKey := range cfg.Server.APIKeys {
+			keys[apiKey.Key] = middleware.Principal{Email: apiKey.Email, Admin: apiKey.Admin}
+		}
+		interceptors = append(interceptors, middleware.APIKeyInterceptor(keys), middleware.EmailOwnershipInterceptor())
+		streamInterceptors = append(streamInterceptors, middleware.APIKeyStreamInterceptor(keys))
+	}
+	interceptors = append(interceptors, middleware.AdminOnlyInterceptor(service.AdminMethods...))
+	if cfg.Server.MinClientVersion != "" {
+		versionCheck, err := middleware.ClientVersionInterceptor(cfg.Server.MinClientVersion)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid minimum client version: %w", err)
+		}
+		streamVersionCheck, err := middleware.ClientVersionStreamInterceptor(cfg.Server.MinClientVersion)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid minimum client version: %w", err)
+		}
+		interceptors = append(interceptors, versionCheck)
+		streamInterceptors = append(streamInterceptors, streamVersionCheck)
+	}
+	if cfg.Server.CompressResponses {
+		interceptors = append(interceptors, middleware.CompressionInterceptor())
+	}
+	if limit := cfg.Server.Concurrency; limit.MaxInFlight > 0 {
+		interceptors = append(interceptors, middleware.ConcurrencyLimitInterceptor(
+			limit.MaxInFlight, time.Duration(limit.WaitMillis)*time.Millisecond, limit.Methods...))
+		streamInterceptors = append(streamInterceptors, middleware.ConcurrencyLimitStreamInterceptor(
+			limit.MaxInFlight, time.Duration(limit.WaitMillis)*time.Millisecond, limit.Methods...))
+	}
+
+	// Create a new gRPC server.
+	grpcServer := grpc.NewServer(grpc.ChainUnaryInterceptor(interceptors...), grpc.ChainStreamInterceptor(streamInterceptors...))
+
+	// Register the service with the server.
+	pb.RegisterTicketBookingServiceServer(grpcServer, ticketService)
+
+	healthServer := health.NewServer()
+	grpc_health_v1.RegisterHealthServer(grpcServer, healthServer)
+	healthServer.SetServingStatus("", grpc_health_v1.HealthCheckResponse_SERVING)
+	ticketService.UpdateReadiness(context.Background(), healthServer)
+
+	return grpcServer, healthServer, nil
 }
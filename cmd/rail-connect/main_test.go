@@ -0,0 +1,512 @@
+package main
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sanjaykishor/rail-connect/internal/config"
+	"github.com/sanjaykishor/rail-connect/internal/middleware"
+	"github.com/sanjaykishor/rail-connect/internal/service"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// startTestServer runs the server built from cfg on an in-memory listener and
+// returns a connection to it.
+func startTestServer(t *testing.T, cfg *config.Config) *grpc.ClientConn {
+	t.Helper()
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("invalid test config: %v", err)
+	}
+
+	ticketService, err := newTicketService(cfg, zap.NewNop())
+	if err != nil {
+		t.Fatalf("failed to create ticket service: %v", err)
+	}
+	grpcServer, _, err := newServer(cfg, ticketService)
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+
+	listener := bufconn.Listen(1024 * 1024)
+	go grpcServer.Serve(listener)
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// testConfig returns a small valid configuration with every interceptor enabled.
+func testConfig() *config.Config {
+	return &config.Config{
+		Server: config.ServerConfig{
+			CompressResponses: true,
+			Concurrency:       config.ConcurrencyConfig{MaxInFlight: 4, WaitMillis: 100},
+			HotRequests:       config.HotRequestConfig{Threshold: 100, WindowSeconds: 60},
+		},
+		Sections: []config.SectionConfig{
+			{Name: "A", MaxSeats: 2},
+			{Name: "B", MaxSeats: 2},
+		},
+		Stations: config.StationConfig{Prices: map[string]float64{"London-France": 20.00}},
+	}
+}
+
+func TestServerBookingFlow(t *testing.T) {
+	conn := startTestServer(t, testConfig())
+	client := pb.NewTicketBookingServiceClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	health, err := grpc_health_v1.NewHealthClient(conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, health.Status)
+
+	health, err = grpc_health_v1.NewHealthClient(conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{Service: service.ReadinessService})
+	assert.NoError(t, err)
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, health.Status, "A valid config should report ready")
+
+	// Book
+	user := &pb.User{FirstName: "Sanjay", LastName: "Kishor", Email: "test@example.com"}
+	purchased, err := client.PurchaseTicket(ctx, &pb.PurchaseTicketRequest{User: user, From: "London", To: "France"})
+	assert.NoError(t, err)
+	assert.Equal(t, 20.00, purchased.Receipt.PricePaid)
+	assert.Equal(t, "A", purchased.Receipt.Seat.Section)
+
+	// Get
+	got, err := client.GetReceipt(ctx, &pb.GetReceiptRequest{Email: user.Email})
+	assert.NoError(t, err)
+	assert.Equal(t, purchased.Receipt.Seat.SeatNumber, got.Receipt.Seat.SeatNumber)
+
+	section, err := client.GetUsersBySection(ctx, &pb.GetUsersBySectionRequest{Section: "A"})
+	assert.NoError(t, err)
+	assert.Len(t, section.Users, 1)
+
+	// Update
+	updated, err := client.UpdateUserSeat(ctx, &pb.UpdateUserSeatRequest{
+		Email:   user.Email,
+		NewSeat: &pb.Seat{Section: "B", SeatNumber: 2},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "B", updated.UpdatedReceipt.Seat.Section)
+	assert.Equal(t, int32(2), updated.UpdatedReceipt.Seat.SeatNumber)
+
+	// Remove
+	removed, err := client.RemoveUser(ctx, &pb.RemoveUserRequest{Email: user.Email})
+	assert.NoError(t, err)
+	assert.Equal(t, user.Email, removed.RemovedUser.Email)
+
+	_, err = client.GetReceipt(ctx, &pb.GetReceiptRequest{Email: user.Email})
+	assert.Equal(t, codes.NotFound, status.Code(err), "Receipt should be gone after removal")
+
+	history, err := client.GetReceiptHistory(ctx, &pb.GetReceiptHistoryRequest{Email: user.Email})
+	assert.NoError(t, err)
+	assert.Len(t, history.Events, 3, "Booking, seat change and cancellation should be recorded")
+}
+
+func TestServerMaintenanceInterceptor(t *testing.T) {
+	cfg := testConfig()
+	cfg.Server.APIKeys = []config.APIKeyConfig{{Key: "admin-key", Admin: true}}
+	conn := startTestServer(t, cfg)
+	client := pb.NewTicketBookingServiceClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	ctx = metadata.AppendToOutgoingContext(ctx, middleware.APIKeyHeader, "admin-key")
+
+	user := &pb.User{FirstName: "Sanjay", LastName: "Kishor", Email: "test@example.com"}
+	_, err := client.PurchaseTicket(ctx, &pb.PurchaseTicketRequest{User: user, From: "London", To: "France"})
+	assert.NoError(t, err)
+
+	_, err = client.SetMaintenanceMode(ctx, &pb.SetMaintenanceModeRequest{Enabled: true})
+	assert.NoError(t, err)
+
+	_, err = client.RemoveUser(ctx, &pb.RemoveUserRequest{Email: user.Email})
+	assert.Equal(t, codes.Unavailable, status.Code(err), "Changes should be rejected in maintenance mode")
+	_, err = client.ReleaseHold(ctx, &pb.ReleaseHoldRequest{HoldId: "ABC123", Email: user.Email})
+	assert.Equal(t, codes.Unavailable, status.Code(err), "Holds should not be released in maintenance mode")
+	_, err = client.ConfirmPayment(ctx, &pb.ConfirmPaymentRequest{BookingReference: "ABC123"})
+	assert.Equal(t, codes.Unavailable, status.Code(err), "Payments should not change bookings in maintenance mode")
+
+	_, err = client.GetReceipt(ctx, &pb.GetReceiptRequest{Email: user.Email})
+	assert.NoError(t, err, "Reads should keep working in maintenance mode")
+}
+
+func TestServerClientVersionInterceptor(t *testing.T) {
+	cfg := testConfig()
+	cfg.Server.MinClientVersion = "2.0.0"
+	client := pb.NewTicketBookingServiceClient(startTestServer(t, cfg))
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := client.GetReceipt(ctx, &pb.GetReceiptRequest{Email: "test@example.com"})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err), "Calls without a client version should be rejected")
+	stream, err := client.WatchAvailability(ctx, &pb.WatchAvailabilityRequest{})
+	assert.NoError(t, err)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err), "Streams without a client version should be rejected")
+
+	ctx = metadata.AppendToOutgoingContext(ctx, middleware.ClientVersionHeader, "2.1.0")
+	_, err = client.GetReceipt(ctx, &pb.GetReceiptRequest{Email: "test@example.com"})
+	assert.Equal(t, codes.NotFound, status.Code(err), "Supported clients should reach the service")
+	stream, err = client.WatchAvailability(ctx, &pb.WatchAvailabilityRequest{})
+	assert.NoError(t, err)
+	_, err = stream.Recv()
+	assert.NoError(t, err, "Supported clients should be able to watch")
+}
+
+func TestServerAPIKeyAuth(t *testing.T) {
+	cfg := testConfig()
+	cfg.Server.APIKeys = []config.APIKeyConfig{{Key: "user-key", Email: "test@example.com"}}
+	client := pb.NewTicketBookingServiceClient(startTestServer(t, cfg))
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	ctx = metadata.AppendToOutgoingContext(ctx, middleware.APIKeyHeader, "user-key")
+	user := &pb.User{FirstName: "Sanjay", LastName: "Kishor", Email: "other@example.com"}
+	_, err := client.PurchaseTicket(ctx, &pb.PurchaseTicketRequest{User: user, From: "London", To: "France"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err), "Keys should not book for other users")
+
+	user.Email = "test@example.com"
+	_, err = client.PurchaseTicket(ctx, &pb.PurchaseTicketRequest{User: user, From: "London", To: "France"})
+	assert.NoError(t, err)
+}
+
+func TestServerWatchAvailability(t *testing.T) {
+	cfg := testConfig()
+	cfg.Server.APIKeys = []config.APIKeyConfig{{Key: "user-key", Email: "test@example.com"}}
+	client := pb.NewTicketBookingServiceClient(startTestServer(t, cfg))
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	stream, err := client.WatchAvailability(metadata.AppendToOutgoingContext(ctx, middleware.APIKeyHeader, "stolen-key"), &pb.WatchAvailabilityRequest{})
+	assert.NoError(t, err)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.Unauthenticated, status.Code(err), "Streams should reject unknown keys")
+
+	ctx = metadata.AppendToOutgoingContext(ctx, middleware.APIKeyHeader, "user-key")
+	stream, err = client.WatchAvailability(ctx, &pb.WatchAvailabilityRequest{Section: "A"})
+	assert.NoError(t, err)
+	update, err := stream.Recv()
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), update.Sections[0].VacantSeats)
+
+	user := &pb.User{FirstName: "Sanjay", LastName: "Kishor", Email: "test@example.com"}
+	_, err = client.PurchaseTicket(ctx, &pb.PurchaseTicketRequest{User: user, From: "London", To: "France"})
+	assert.NoError(t, err)
+	update, err = stream.Recv()
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), update.Sections[0].VacantSeats, "Bookings should be streamed")
+}
+
+func TestServerGetConfigAdminOnly(t *testing.T) {
+	cfg := testConfig()
+	cfg.Server.APIKeys = []config.APIKeyConfig{{Key: "user-key", Email: "test@example.com"}, {Key: "admin-key", Admin: true}}
+	client := pb.NewTicketBookingServiceClient(startTestServer(t, cfg))
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := client.GetConfig(ctx, &pb.GetConfigRequest{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err), "Calls without a key should be rejected")
+
+	_, err = client.GetConfig(metadata.AppendToOutgoingContext(ctx, middleware.APIKeyHeader, "user-key"), &pb.GetConfigRequest{})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err), "User keys should be denied")
+
+	res, err := client.GetConfig(metadata.AppendToOutgoingContext(ctx, middleware.APIKeyHeader, "admin-key"), &pb.GetConfigRequest{})
+	assert.NoError(t, err)
+	assert.Contains(t, res.Config, "London-France: 20")
+	assert.NotContains(t, res.Config, "admin-key", "Keys should be redacted")
+}
+
+func TestServerAdminMethods(t *testing.T) {
+	cfg := testConfig()
+	cfg.Server.APIKeys = []config.APIKeyConfig{{Key: "user-key", Email: "test@example.com"}, {Key: "admin-key", Admin: true}}
+	conn := startTestServer(t, cfg)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// Every admin method is denied to user keys before its handler runs, so
+	// an empty request is enough
+	userCtx := metadata.AppendToOutgoingContext(ctx, middleware.APIKeyHeader, "user-key")
+	for _, method := range service.AdminMethods {
+		err := conn.Invoke(userCtx, "/"+pb.TicketBookingService_ServiceDesc.ServiceName+"/"+method, &pb.VerifyRequest{}, &pb.VerifyResponse{})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err), "%s should need an admin key", method)
+	}
+
+	client := pb.NewTicketBookingServiceClient(conn)
+	adminCtx := metadata.AppendToOutgoingContext(ctx, middleware.APIKeyHeader, "admin-key")
+	_, err := client.SetMaintenanceMode(adminCtx, &pb.SetMaintenanceModeRequest{Enabled: true})
+	assert.NoError(t, err)
+	_, err = client.GetManifest(adminCtx, &pb.GetManifestRequest{})
+	assert.NoError(t, err)
+}
+
+func TestServerForceReassignAdminOnly(t *testing.T) {
+	cfg := testConfig()
+	cfg.Server.APIKeys = []config.APIKeyConfig{{Key: "user-key", Email: "test@example.com"}, {Key: "admin-key", Admin: true}}
+	client := pb.NewTicketBookingServiceClient(startTestServer(t, cfg))
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	userCtx := metadata.AppendToOutgoingContext(ctx, middleware.APIKeyHeader, "user-key")
+	user := &pb.User{FirstName: "Sanjay", LastName: "Kishor", Email: "test@example.com"}
+	_, err := client.PurchaseTicket(userCtx, &pb.PurchaseTicketRequest{User: user, From: "London", To: "France"})
+	assert.NoError(t, err)
+
+	req := &pb.ForceReassignRequest{Email: "test@example.com", TargetSection: "B", TargetSeat: 2, Reason: "Support request"}
+	_, err = client.ForceReassign(userCtx, req)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err), "User keys should not force moves")
+
+	res, err := client.ForceReassign(metadata.AppendToOutgoingContext(ctx, middleware.APIKeyHeader, "admin-key"), req)
+	assert.NoError(t, err)
+	assert.Equal(t, &pb.Seat{Section: "B", SeatNumber: 2}, res.UpdatedReceipt.Seat)
+}
+
+func TestServerReceiptByReferenceOwnership(t *testing.T) {
+	cfg := testConfig()
+	cfg.Server.APIKeys = []config.APIKeyConfig{
+		{Key: "user-key", Email: "test@example.com"},
+		{Key: "other-key", Email: "other@example.com"},
+		{Key: "admin-key", Admin: true},
+	}
+	client := pb.NewTicketBookingServiceClient(startTestServer(t, cfg))
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	userCtx := metadata.AppendToOutgoingContext(ctx, middleware.APIKeyHeader, "user-key")
+	user := &pb.User{FirstName: "Sanjay", LastName: "Kishor", Email: "test@example.com"}
+	purchased, err := client.PurchaseTicket(userCtx, &pb.PurchaseTicketRequest{User: user, From: "London", To: "France"})
+	assert.NoError(t, err)
+	reference := purchased.Receipt.BookingReference
+
+	// Another user's key can't read the receipt by its reference
+	otherCtx := metadata.AppendToOutgoingContext(ctx, middleware.APIKeyHeader, "other-key")
+	_, err = client.GetReceiptByReference(otherCtx, &pb.GetReceiptByReferenceRequest{BookingReference: reference})
+	assert.Equal(t, codes.NotFound, status.Code(err), "Other users' receipts should not be found")
+	_, err = client.GetReceipt(otherCtx, &pb.GetReceiptRequest{BookingReference: reference})
+	assert.Equal(t, codes.NotFound, status.Code(err), "Other users' receipts should not be found")
+
+	for _, ctx := range []context.Context{userCtx, metadata.AppendToOutgoingContext(ctx, middleware.APIKeyHeader, "admin-key")} {
+		res, err := client.GetReceiptByReference(ctx, &pb.GetReceiptByReferenceRequest{BookingReference: reference})
+		assert.NoError(t, err, "The owner and admins should read the receipt")
+		assert.Equal(t, "test@example.com", res.GetReceipt().GetUser().GetEmail())
+	}
+}
+
+func TestServerSeatOccupants(t *testing.T) {
+	cfg := testConfig()
+	cfg.Server.APIKeys = []config.APIKeyConfig{
+		{Key: "user-key", Email: "test@example.com"},
+		{Key: "other-key", Email: "other@example.com"},
+		{Key: "admin-key", Admin: true},
+	}
+	client := pb.NewTicketBookingServiceClient(startTestServer(t, cfg))
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	userCtx := metadata.AppendToOutgoingContext(ctx, middleware.APIKeyHeader, "user-key")
+	user := &pb.User{FirstName: "Sanjay", LastName: "Kishor", Email: "test@example.com"}
+	purchased, err := client.PurchaseTicket(userCtx, &pb.PurchaseTicketRequest{User: user, From: "London", To: "France"})
+	assert.NoError(t, err)
+	seatReq := &pb.GetSeatRequest{Section: purchased.Receipt.Seat.Section, SeatNumber: purchased.Receipt.Seat.SeatNumber}
+
+	// Another user's key sees the seat is taken, but not who by
+	otherCtx := metadata.AppendToOutgoingContext(ctx, middleware.APIKeyHeader, "other-key")
+	res, err := client.GetSeat(otherCtx, seatReq)
+	assert.NoError(t, err)
+	assert.False(t, res.Available)
+	assert.Empty(t, res.Occupants, "Other users' receipts should not be returned")
+
+	for _, ctx := range []context.Context{userCtx, metadata.AppendToOutgoingContext(ctx, middleware.APIKeyHeader, "admin-key")} {
+		res, err := client.GetSeat(ctx, seatReq)
+		assert.NoError(t, err)
+		assert.Len(t, res.Occupants, 1, "The owner and admins should see the occupant")
+		assert.Equal(t, "test@example.com", res.GetOccupants()[0].GetUser().GetEmail())
+	}
+}
+
+func TestServerFeatureFlags(t *testing.T) {
+	cfg := testConfig()
+	cfg.Features = map[string]bool{"GetSeat": false, "GetSectionVacancy": true, "WatchAvailability": false}
+	client := pb.NewTicketBookingServiceClient(startTestServer(t, cfg))
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := client.GetSeat(ctx, &pb.GetSeatRequest{Section: "A", SeatNumber: 1})
+	assert.Equal(t, codes.Unimplemented, status.Code(err), "Disabled methods should be unimplemented")
+	stream, err := client.WatchAvailability(ctx, &pb.WatchAvailabilityRequest{})
+	assert.NoError(t, err)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.Unimplemented, status.Code(err), "Disabled streams should be unimplemented")
+
+	_, err = client.GetSectionVacancy(ctx, &pb.GetSectionVacancyRequest{Section: "A"})
+	assert.NoError(t, err, "Enabled methods should be served")
+
+	_, err = client.GetUsersBySection(ctx, &pb.GetUsersBySectionRequest{Section: "A"})
+	assert.NoError(t, err, "Unlisted methods should be served")
+}
+
+func TestServerFeatureFlagUnknownMethod(t *testing.T) {
+	cfg := testConfig()
+	cfg.Features = map[string]bool{"BookTicket": false}
+	ticketService, err := newTicketService(cfg, zap.NewNop())
+	assert.NoError(t, err)
+
+	_, _, err = newServer(cfg, ticketService)
+	assert.Error(t, err, "Flags for methods the server lacks should be rejected")
+}
+
+func TestSnapshotFlusherReady(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, snapshotFlusher{path: filepath.Join(dir, "snapshot.json")}.Ready(context.Background()))
+	assert.Error(t, snapshotFlusher{path: filepath.Join(dir, "missing", "snapshot.json")}.Ready(context.Background()),
+		"A snapshot in a missing directory can't be saved")
+}
+
+func TestRestoreSnapshot(t *testing.T) {
+	dir := t.TempDir()
+	ticketService, err := newTicketService(testConfig(), zap.NewNop())
+	assert.NoError(t, err)
+
+	assert.NoError(t, restoreSnapshot(filepath.Join(dir, "missing.json"), ticketService, zap.NewNop()),
+		"A missing snapshot should start the server empty")
+
+	// A snapshot that can't be restored must not be overwritten with no bookings
+	path := filepath.Join(dir, "snapshot.json")
+	assert.NoError(t, os.WriteFile(path, []byte(`{"version": 99}`), 0o644))
+	assert.Error(t, restoreSnapshot(path, ticketService, zap.NewNop()))
+
+	assert.NoError(t, os.WriteFile(path, []byte(`{"version": 1, "sections": [{"name": "A", "max_seats": 20, "seats": [{"number": 1, "booked": 1}]}]}`), 0o644))
+	assert.NoError(t, restoreSnapshot(path, ticketService, zap.NewNop()), "Version 1 snapshots should still be restored")
+	assert.Equal(t, 19, ticketService.SeatManager.VacantSeats("A"))
+}
+
+func TestReloadSections(t *testing.T) {
+	ticketService, err := newTicketService(testConfig(), zap.NewNop())
+	assert.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	reloadSections(path, ticketService, zap.NewNop())
+	assert.Equal(t, []string{"A", "B"}, ticketService.SeatManager.SectionOrder, "A missing file should leave the sections alone")
+
+	assert.NoError(t, os.WriteFile(path, []byte(`
+sections:
+  - name: A
+    max_seats: 2
+  - name: B
+    max_seats: 4
+  - name: C
+    max_seats: 2
+stations:
+  London-France: 20.00
+`), 0o644))
+	reloadSections(path, ticketService, zap.NewNop())
+	assert.Equal(t, []string{"A", "B", "C"}, ticketService.SeatManager.SectionOrder)
+	assert.Equal(t, 4, ticketService.SeatManager.VacantSeats("B"))
+
+	// The reload's differences from the running configuration are kept
+	changes, err := ticketService.GetConfigChanges(context.Background(), &pb.GetConfigChangesRequest{})
+	assert.NoError(t, err)
+	assert.NotZero(t, changes.ReloadedAt)
+	kinds := make(map[string]pb.ConfigChangeType)
+	for _, change := range changes.Changes {
+		kinds[change.Path] = change.Change
+	}
+	assert.Equal(t, pb.ConfigChangeType_CONFIG_CHANGE_TYPE_ADDED, kinds["sections[C]"])
+	assert.Equal(t, pb.ConfigChangeType_CONFIG_CHANGE_TYPE_CHANGED, kinds["sections[B].max_seats"])
+}
+
+func TestReloadSectionsMovesRiders(t *testing.T) {
+	ticketService, err := newTicketService(testConfig(), zap.NewNop())
+	assert.NoError(t, err)
+	for _, email := range []string{"first@example.com", "second@example.com"} {
+		_, err = ticketService.PurchaseTicket(context.Background(), &pb.PurchaseTicketRequest{
+			User: &pb.User{FirstName: "Sanjay", LastName: "Kishor", Email: email},
+			From: "London",
+			To:   "France",
+		})
+		assert.NoError(t, err)
+	}
+
+	// The rider in B is moved to the free seat of A before B is removed
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte(`
+sections:
+  - name: A
+    max_seats: 2
+stations:
+  London-France: 20.00
+`), 0o644))
+	reloadSections(path, ticketService, zap.NewNop())
+	assert.Equal(t, []string{"A"}, ticketService.SeatManager.SectionOrder)
+	assert.Equal(t, &pb.Seat{Section: "A", SeatNumber: 2}, ticketService.Receipts["second@example.com"].Seat)
+}
+
+func TestReloadSectionsRejectsConflicts(t *testing.T) {
+	cfg := testConfig()
+	cfg.Booking.AdvanceDays = 7
+	ticketService, err := newTicketService(cfg, zap.NewNop())
+	assert.NoError(t, err)
+	_, err = ticketService.PurchaseTicket(context.Background(), &pb.PurchaseTicketRequest{
+		User:          &pb.User{FirstName: "Sanjay", LastName: "Kishor", Email: "test@example.com"},
+		From:          "London",
+		To:            "France",
+		DepartureDate: time.Now().AddDate(0, 0, 1).Format("2006-01-02"),
+	})
+	assert.NoError(t, err)
+
+	// Removing the section the rider sits in on a dated departure is
+	// rejected outright
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte(`
+sections:
+  - name: B
+    max_seats: 4
+stations:
+  London-France: 20.00
+`), 0o644))
+	reloadSections(path, ticketService, zap.NewNop())
+	assert.Equal(t, []string{"A", "B"}, ticketService.SeatManager.SectionOrder)
+	assert.Equal(t, 2, ticketService.SeatManager.VacantSeats("B"))
+
+	changes, err := ticketService.GetConfigChanges(context.Background(), &pb.GetConfigChangesRequest{})
+	assert.NoError(t, err)
+	assert.Zero(t, changes.ReloadedAt, "A rejected reload should not be recorded")
+}
+
+func TestServerRequestID(t *testing.T) {
+	conn := startTestServer(t, testConfig())
+	client := pb.NewTicketBookingServiceClient(conn)
+
+	var header, trailer metadata.MD
+	ctx := metadata.AppendToOutgoingContext(context.Background(), middleware.RequestIDHeader, "abc123")
+	response, err := client.PurchaseTicket(ctx, &pb.PurchaseTicketRequest{
+		User: &pb.User{FirstName: "Sanjay", LastName: "Kishor", Email: "test@example.com"},
+		From: "London",
+		To:   "France",
+	}, grpc.Header(&header), grpc.Trailer(&trailer))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"abc123"}, header.Get(middleware.RequestIDHeader), "The request ID should be echoed")
+	assert.Equal(t, []string{response.Receipt.BookingReference}, trailer.Get(middleware.BookingIDTrailer))
+}
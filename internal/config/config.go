@@ -1,9 +1,15 @@
 package config
 
 import (
+	"errors"
 	"fmt"
-	"log"
+	"io/fs"
+	"math"
 	"os"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -11,23 +17,339 @@ import (
 )
 
 type Config struct {
-	Server   ServerConfig       `yaml:"server"`
-	LogLevel string             `yaml:"log_level"`
-	Sections []SectionConfig    `yaml:"sections"`
-	Stations map[string]float64 `yaml:"stations"`
+	Server          ServerConfig       `yaml:"server"`
+	LogLevel        string             `yaml:"log_level"`
+	MaintenanceMode bool               `yaml:"maintenance_mode"`
+	Sections        []SectionConfig    `yaml:"sections"`
+	Stations        StationConfig      `yaml:"stations"`
+	Route           []string           `yaml:"route"`
+	Fares           FareConfig         `yaml:"fares"`
+	Pricing         PricingConfig      `yaml:"pricing"`
+	Booking         BookingConfig      `yaml:"booking"`
+	Seating         SeatingConfig      `yaml:"seating"`
+	Notifications   NotificationConfig `yaml:"notifications"`
+	Alerts          AlertConfig        `yaml:"alerts"`
+	// Features switches RPCs on or off by method name, e.g. GetSeat: false, so
+	// new endpoints can be dark-launched. Unlisted methods are enabled.
+	Features map[string]bool `yaml:"features"`
+	// SnapshotPath is where booking state is saved on shutdown and reloaded on
+	// boot. Empty disables snapshots.
+	SnapshotPath string `yaml:"snapshot_path"`
 }
 
 // ServerConfig holds the server-specific configuration.
 type ServerConfig struct {
-	Port string `yaml:"port"`
+	Port        string            `yaml:"port"`
+	Concurrency ConcurrencyConfig `yaml:"concurrency"`
+	// MinClientVersion rejects clients reporting an older x-client-version. Empty disables the check.
+	MinClientVersion string `yaml:"min_client_version"`
+	// CompressResponses gzips every response for clients that accept it.
+	CompressResponses bool `yaml:"compress_responses"`
+	// HotRequests warns about identical requests repeated at a high rate.
+	HotRequests HotRequestConfig `yaml:"hot_requests"`
+	// APIKeys authenticate callers sending an x-api-key header. Empty disables
+	// authentication.
+	APIKeys []APIKeyConfig `yaml:"api_keys"`
+	// ReadinessIntervalSeconds is how often the readiness checks rerun. Zero
+	// uses DefaultReadinessInterval.
+	ReadinessIntervalSeconds int `yaml:"readiness_interval_seconds"`
+	// StrictRequests rejects requests setting fields that contradict each
+	// other, where one would otherwise be silently ignored.
+	StrictRequests bool `yaml:"strict_requests"`
+}
+
+// DefaultReadinessInterval is how often readiness is rechecked when
+// ReadinessIntervalSeconds is unset.
+const DefaultReadinessInterval = 10 * time.Second
+
+// ReadinessInterval returns how often the readiness checks rerun.
+func (s ServerConfig) ReadinessInterval() time.Duration {
+	if s.ReadinessIntervalSeconds == 0 {
+		return DefaultReadinessInterval
+	}
+	return time.Duration(s.ReadinessIntervalSeconds) * time.Second
+}
+
+// APIKeyConfig ties an API key to the user it acts for.
+type APIKeyConfig struct {
+	Key string `yaml:"key"`
+	// Email is the only user whose bookings the key may make.
+	Email string `yaml:"email"`
+	// Admin keys may act for any user.
+	Admin bool `yaml:"admin"`
+}
+
+// HotRequestConfig holds the thresholds for detecting identical requests sent
+// in a burst, e.g. by a client stuck retrying.
+type HotRequestConfig struct {
+	// Threshold is how many identical requests to a method within the window
+	// log a warning. Zero disables the check.
+	Threshold int `yaml:"threshold"`
+	// WindowSeconds is the length of each counting window.
+	WindowSeconds int `yaml:"window_seconds"`
+	// SampleEvery examines only every n-th request to keep the check cheap.
+	// Zero or one examines every request.
+	SampleEvery int `yaml:"sample_every"`
+}
+
+// ConcurrencyConfig limits the number of in-flight requests.
+type ConcurrencyConfig struct {
+	// MaxInFlight is the number of concurrent calls admitted. Zero disables the limit.
+	MaxInFlight int `yaml:"max_in_flight"`
+	// WaitMillis is how long a call over the limit waits for a slot before rejection.
+	WaitMillis int `yaml:"wait_ms"`
+	// Methods restricts the limit to the named methods, each with its own limit.
+	Methods []string `yaml:"methods"`
+}
+
+// BookingConfig holds the ticket booking behaviour settings.
+type BookingConfig struct {
+	// DedupeWindowSeconds is how long an identical purchase (same user and route)
+	// returns the original receipt instead of booking again. Zero disables it.
+	DedupeWindowSeconds int `yaml:"dedupe_window_seconds"`
+	// RebookCooldownSeconds is how long a user who cancelled with RemoveUser
+	// must wait before booking again. Zero disables it.
+	RebookCooldownSeconds int `yaml:"rebook_cooldown_seconds"`
+	// AdvanceDays is how many days ahead a ticket can be booked for a dated
+	// departure, each with its own seats. Zero disables dated departures.
+	AdvanceDays int `yaml:"advance_days"`
+	// RouteCaps limits how many tickets are sold for a route, keyed "From-To"
+	// like Stations, to keep seats for later-boarding segments.
+	RouteCaps map[string]int `yaml:"route_caps"`
+	// Reference sets the format of the booking reference on each receipt.
+	Reference ReferenceConfig `yaml:"reference"`
+	// PaymentWindowSeconds is how long a provisional booking waits for
+	// ConfirmPayment before it is cancelled. Zero disables provisional
+	// bookings.
+	PaymentWindowSeconds int `yaml:"payment_window_seconds"`
+	// HoldSeconds is how long HoldSeat sets a seat aside for a user before
+	// releasing it. Zero disables seat holds.
+	HoldSeconds int `yaml:"hold_seconds"`
+	// MaxHoldsPerUser caps the seats one user may hold at once. Zero leaves
+	// holds uncapped.
+	MaxHoldsPerUser int `yaml:"max_holds_per_user"`
+	// Trains lists the IDs of the trains the server runs, each with its own
+	// seats laid out like Sections. Empty runs a single unnamed train.
+	Trains []string `yaml:"trains"`
+	// DefaultTrain is booked by requests that name no train. It must be one
+	// of Trains.
+	DefaultTrain string `yaml:"default_train"`
+}
+
+// ReferenceConfig holds the format of booking references, like airline PNRs.
+// Zero values use the service defaults.
+type ReferenceConfig struct {
+	// Length is the number of characters in a reference.
+	Length int `yaml:"length"`
+	// Alphabet is the characters a reference is drawn from.
+	Alphabet string `yaml:"alphabet"`
+}
+
+// Booking reference defaults. The alphabet leaves out characters that are
+// easily misread, like 0 and O or 1 and I.
+const (
+	DefaultReferenceLength   = 6
+	DefaultReferenceAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+)
+
+// minReferenceCombinations keeps collisions between random booking references
+// rare enough that regenerating one stays cheap.
+const minReferenceCombinations = 1_000_000
+
+// SeatingConfig holds the seat assignment strategy settings.
+type SeatingConfig struct {
+	// DestinationAffinity groups riders to the same destination in one section.
+	DestinationAffinity bool `yaml:"destination_affinity"`
+	// DefaultSection is filled first by bookings that express no preference.
+	DefaultSection string `yaml:"default_section"`
+	// RowLayout is the position of each seat across a row, one letter per
+	// seat: W for window, M for middle and A for aisle, e.g. "WAAW". Seat
+	// numbers run along the rows.
+	RowLayout string `yaml:"row_layout"`
+	// PositionOrder fills vacant seats by position in this order, e.g.
+	// [window, middle, aisle] to leave aisle seats for last. Unlisted
+	// positions come after listed ones. Empty fills seats in number order.
+	PositionOrder []string `yaml:"position_order"`
+	// AssignLockTimeoutMillis bounds how long a purchase waits for the booking
+	// lock, and a seat assignment for the seat lock, before failing so the
+	// client retries. Zero waits indefinitely.
+	AssignLockTimeoutMillis int `yaml:"assign_lock_timeout_ms"`
+	// LoyaltySections lists, for each loyalty tier, the sections its members
+	// are seated in first when they have a free seat, e.g. gold: [First].
+	// Tiers not listed get no special seating.
+	LoyaltySections map[string][]string `yaml:"loyalty_sections"`
+}
+
+// Seat positions within a row.
+const (
+	PositionWindow = "window"
+	PositionMiddle = "middle"
+	PositionAisle  = "aisle"
+)
+
+// RowLayoutPositions maps the letters of a row layout to seat positions.
+var RowLayoutPositions = map[rune]string{
+	'W': PositionWindow,
+	'M': PositionMiddle,
+	'A': PositionAisle,
+}
+
+// NotificationConfig holds the passenger notification settings.
+type NotificationConfig struct {
+	SMTP SMTPConfig `yaml:"smtp"`
+}
+
+// AlertConfig holds the operational alert settings.
+type AlertConfig struct {
+	// OccupancyThreshold is the share of seats, between 0 and 1, whose
+	// occupancy raises an alert, e.g. 0.9 when the train is 90% full. Zero
+	// disables the alert.
+	OccupancyThreshold float64 `yaml:"occupancy_threshold"`
+}
+
+// SMTPConfig configures confirmation and cancellation emails.
+type SMTPConfig struct {
+	// Host is the SMTP server. Empty disables emails.
+	Host     string `yaml:"host"`
+	Port     int    `yaml:"port"`
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+	// From is the sender address of every email.
+	From string `yaml:"from"`
+}
+
+// StationConfig holds the price of each station pair, keyed "From-To", and how
+// station names are matched.
+type StationConfig struct {
+	// Canonicalize trims, title-cases and collapses the whitespace in station
+	// names, so "  london " and "LONDON" both match "London".
+	Canonicalize bool               `yaml:"canonicalize"`
+	Prices       map[string]float64 `yaml:",inline"`
+}
+
+// FareConfig holds the distance-based pricing used for station pairs that have
+// no explicit price in Stations.
+type FareConfig struct {
+	// RatePerKm is the price per kilometre. Zero disables distance pricing.
+	RatePerKm float64 `yaml:"rate_per_km"`
+	// Coordinates locates each station for distance calculations.
+	Coordinates map[string]Coordinate `yaml:"coordinates"`
+	// MaxDistanceKm rejects journeys between located stations further apart
+	// than this, beyond the train's service range. Zero disables the limit.
+	MaxDistanceKm float64 `yaml:"max_distance_km"`
+}
+
+// PricingConfig holds the time-of-day fare adjustments.
+type PricingConfig struct {
+	// TimeWindows scale fares booked within them. The first matching window
+	// applies; bookings outside every window pay the base fare.
+	TimeWindows []TimeWindowConfig `yaml:"time_windows"`
+	// Rounding is how computed fares are rounded to the cent. Empty means
+	// nearest_cent.
+	Rounding string `yaml:"rounding"`
+	// RefundPolicy is how much of the fare a cancellation returns. Empty means
+	// prorated.
+	RefundPolicy string `yaml:"refund_policy"`
+	// BookingFee is a flat fee added to the fare of every ticket and itemized
+	// on the receipt. Zero charges no fee.
+	BookingFee float64 `yaml:"booking_fee"`
+	// FareClasses are pools of discounted fares, such as saver fares, each
+	// sold for any seat until its pool runs out. Purchases name the class
+	// they want; the rest pay the standard fare.
+	FareClasses []FareClassConfig `yaml:"fare_classes"`
+}
+
+// Fare rounding modes.
+const (
+	RoundingNone        = "none"         // Keep the exact computed fare
+	RoundingNearestCent = "nearest_cent" // Round half up to the nearest cent
+	RoundingUp          = "up"           // Round up to the next cent
+	RoundingDown        = "down"         // Round down to the cent
+)
+
+// Refund policies.
+const (
+	RefundProrated        = "prorated"         // Refund the share of the journey not travelled
+	RefundBeforeDeparture = "before_departure" // Refund in full before departure, nothing after
+)
+
+// FareClassConfig is a pool of Seats fares priced at Multiplier times the
+// standard fare. Once they are sold, purchases asking for the class pay the
+// standard fare, or are rejected if SoldOut is "reject".
+type FareClassConfig struct {
+	Name       string  `yaml:"name"`
+	Seats      int     `yaml:"seats"`
+	Multiplier float64 `yaml:"multiplier"`
+	SoldOut    string  `yaml:"sold_out"`
+}
+
+// What happens to purchases for a sold-out fare class.
+const (
+	SoldOutUpsell = "upsell" // Charge the standard fare instead
+	SoldOutReject = "reject" // Reject the purchase
+)
+
+// TimeWindowConfig multiplies fares booked between Start and End, given as
+// "15:04" in the server's local time. A window ending before it starts runs
+// past midnight.
+type TimeWindowConfig struct {
+	Name       string  `yaml:"name"`
+	Start      string  `yaml:"start"`
+	End        string  `yaml:"end"`
+	Multiplier float64 `yaml:"multiplier"`
+}
+
+// ParseClock parses a "15:04" time of day into the offset from midnight.
+func ParseClock(clock string) (time.Duration, error) {
+	t, err := time.Parse("15:04", clock)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time of day %q, expected HH:MM", clock)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// Coordinate is a station location in decimal degrees.
+type Coordinate struct {
+	Latitude  float64 `yaml:"latitude"`
+	Longitude float64 `yaml:"longitude"`
 }
 
 // SectionConfig holds the configuration for each section.
 type SectionConfig struct {
 	Name     string `yaml:"name"`
 	MaxSeats int    `yaml:"max_seats"`
+	// BlockedSeats are out of order and never assigned.
+	BlockedSeats []int `yaml:"blocked_seats"`
+	// OverflowPolicy decides what happens to a booking for this section, as a
+	// preferred or default section, once it is full. Empty means spill.
+	OverflowPolicy string `yaml:"overflow_policy"`
+	// PriceMultiplier scales the fare of seats in this section, e.g. 1.5 for
+	// first class. Zero leaves fares unchanged.
+	PriceMultiplier float64 `yaml:"price_multiplier"`
+	// Surcharge is added to the fare of seats in this section after scaling.
+	Surcharge float64 `yaml:"surcharge"`
+	// Restricted sections, such as crew seating, are never sold to the
+	// public; only admins can move riders into them.
+	Restricted bool `yaml:"restricted"`
+	// FillDirection is the order seats are handed out in: asc from the lowest
+	// seat number, or desc from the highest, e.g. for carriages boarded from
+	// the rear. Empty means asc.
+	FillDirection string `yaml:"fill_direction"`
 }
 
+// Fill directions of a section.
+const (
+	FillAscending  = "asc"
+	FillDescending = "desc"
+)
+
+// Overflow policies for a full section.
+const (
+	OverflowSpill    = "spill"    // Try the next section
+	OverflowReject   = "reject"   // Fail the booking
+	OverflowWaitlist = "waitlist" // Book the rider once a seat frees up
+)
+
 // FileReader is an interface for reading files
 type FileReader interface {
 	ReadFile(filename string) ([]byte, error)
@@ -39,23 +361,584 @@ func (r OSFileReader) ReadFile(filename string) ([]byte, error) {
 	return os.ReadFile(filename)
 }
 
+// Errors returned by LoadConfig, so callers can tell a missing file from a
+// malformed one with errors.Is.
+var (
+	ErrConfigNotFound = errors.New("config file not found")
+	ErrConfigParse    = errors.New("config file is malformed")
+)
+
 // LoadConfig loads configuration from a file using the provided FileReader
 func LoadConfig(filename string, reader FileReader) (*Config, error) {
 	data, err := reader.ReadFile(filename)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", ErrConfigNotFound, filename)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
 
 	var config Config
 	if err := yaml.Unmarshal(data, &config); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
+		return nil, fmt.Errorf("%w: %s: %w", ErrConfigParse, filename, err)
+	}
+
+	if config.Stations.Canonicalize {
+		if err := config.canonicalizeStations(); err != nil {
+			return nil, err
+		}
 	}
 
 	return &config, nil
 }
 
-// NewLogger initializes a new Zap logger.
-func NewLogger(logLevel string) *zap.Logger {
+// CanonicalStation trims a station name, collapses its internal whitespace
+// and title-cases each word.
+func CanonicalStation(name string) string {
+	words := strings.Fields(name)
+	for i, word := range words {
+		runes := []rune(strings.ToLower(word))
+		runes[0] = unicode.ToUpper(runes[0])
+		words[i] = string(runes)
+	}
+	return strings.Join(words, " ")
+}
+
+// canonicalRoute canonicalizes both stations of a "From-To" key.
+func canonicalRoute(key string) string {
+	from, to, found := strings.Cut(key, "-")
+	if !found {
+		return CanonicalStation(key)
+	}
+	return CanonicalStation(from) + "-" + CanonicalStation(to)
+}
+
+// canonicalizeStations rewrites every configured station name in canonical
+// form. Names that only differ in case or spacing are rejected.
+func (c *Config) canonicalizeStations() error {
+	prices := make(map[string]float64, len(c.Stations.Prices))
+	for key, price := range c.Stations.Prices {
+		canonical := canonicalRoute(key)
+		if _, exists := prices[canonical]; exists {
+			return fmt.Errorf("station price %q duplicates %q", key, canonical)
+		}
+		prices[canonical] = price
+	}
+	c.Stations.Prices = prices
+
+	if c.Booking.RouteCaps != nil {
+		caps := make(map[string]int, len(c.Booking.RouteCaps))
+		for key, limit := range c.Booking.RouteCaps {
+			canonical := canonicalRoute(key)
+			if _, exists := caps[canonical]; exists {
+				return fmt.Errorf("route cap %q duplicates %q", key, canonical)
+			}
+			caps[canonical] = limit
+		}
+		c.Booking.RouteCaps = caps
+	}
+
+	if c.Fares.Coordinates != nil {
+		coordinates := make(map[string]Coordinate, len(c.Fares.Coordinates))
+		for name, coordinate := range c.Fares.Coordinates {
+			canonical := CanonicalStation(name)
+			if _, exists := coordinates[canonical]; exists {
+				return fmt.Errorf("station coordinates %q duplicate %q", name, canonical)
+			}
+			coordinates[canonical] = coordinate
+		}
+		c.Fares.Coordinates = coordinates
+	}
+
+	for i, station := range c.Route {
+		c.Route[i] = CanonicalStation(station)
+	}
+	return nil
+}
+
+// maxRouteStations is the longest route whose segments a seat can track.
+const maxRouteStations = 65
+
+// Validate checks the configuration for inconsistencies that would otherwise
+// surface only once the server is running.
+func (c *Config) Validate() error {
+	sectionNames := make(map[string]bool, len(c.Sections))
+	restrictedSections := make(map[string]bool)
+	for _, section := range c.Sections {
+		sectionNames[section.Name] = true
+		restrictedSections[section.Name] = section.Restricted
+		switch section.OverflowPolicy {
+		case "", OverflowSpill, OverflowReject, OverflowWaitlist:
+		default:
+			return fmt.Errorf("section %s has unknown overflow policy %q", section.Name, section.OverflowPolicy)
+		}
+		for _, seat := range section.BlockedSeats {
+			if seat < 1 || seat > section.MaxSeats {
+				return fmt.Errorf("blocked seat %d is not in section %s", seat, section.Name)
+			}
+		}
+		if section.PriceMultiplier < 0 || section.Surcharge < 0 {
+			return fmt.Errorf("section %s price multiplier and surcharge must not be negative", section.Name)
+		}
+		switch section.FillDirection {
+		case "", FillAscending, FillDescending:
+		default:
+			return fmt.Errorf("section %s has unknown fill direction %q", section.Name, section.FillDirection)
+		}
+	}
+
+	if c.Seating.DefaultSection != "" && !sectionNames[c.Seating.DefaultSection] {
+		return fmt.Errorf("default section %s is not a configured section", c.Seating.DefaultSection)
+	}
+	for _, section := range c.Sections {
+		if section.Restricted && section.Name == c.Seating.DefaultSection {
+			return fmt.Errorf("default section %s must not be restricted", section.Name)
+		}
+	}
+	for tier, sections := range c.Seating.LoyaltySections {
+		if tier == "" {
+			return fmt.Errorf("loyalty tier names must not be empty")
+		}
+		for _, name := range sections {
+			if !sectionNames[name] {
+				return fmt.Errorf("loyalty tier %s section %s is not a configured section", tier, name)
+			}
+			if restrictedSections[name] {
+				return fmt.Errorf("loyalty tier %s section %s must not be restricted", tier, name)
+			}
+		}
+	}
+
+	for _, letter := range c.Seating.RowLayout {
+		if _, known := RowLayoutPositions[letter]; !known {
+			return fmt.Errorf("row layout %q must only contain W, M and A", c.Seating.RowLayout)
+		}
+	}
+	if len(c.Seating.PositionOrder) > 0 && c.Seating.RowLayout == "" {
+		return fmt.Errorf("seat position order needs a row layout")
+	}
+	positions := make(map[string]bool, len(c.Seating.PositionOrder))
+	for _, position := range c.Seating.PositionOrder {
+		switch position {
+		case PositionWindow, PositionMiddle, PositionAisle:
+		default:
+			return fmt.Errorf("unknown seat position %q", position)
+		}
+		if positions[position] {
+			return fmt.Errorf("seat position %s is listed more than once", position)
+		}
+		positions[position] = true
+	}
+	if c.Seating.AssignLockTimeoutMillis < 0 {
+		return fmt.Errorf("assignment lock timeout must not be negative")
+	}
+
+	trains := make(map[string]bool, len(c.Booking.Trains))
+	for _, train := range c.Booking.Trains {
+		if strings.TrimSpace(train) == "" {
+			return fmt.Errorf("train IDs must not be empty")
+		}
+		if trains[train] {
+			return fmt.Errorf("train %s is listed more than once", train)
+		}
+		trains[train] = true
+	}
+	if (len(trains) > 0 || c.Booking.DefaultTrain != "") && !trains[c.Booking.DefaultTrain] {
+		return fmt.Errorf("default train %q must be one of the configured trains", c.Booking.DefaultTrain)
+	}
+
+	if len(c.Route) == 1 || len(c.Route) > maxRouteStations {
+		return fmt.Errorf("route must have between 2 and %d stations", maxRouteStations)
+	}
+	routeStations := make(map[string]bool, len(c.Route))
+	for _, station := range c.Route {
+		if routeStations[station] {
+			return fmt.Errorf("route visits station %s more than once", station)
+		}
+		routeStations[station] = true
+	}
+
+	if c.Fares.RatePerKm < 0 {
+		return fmt.Errorf("fare rate per km must not be negative")
+	}
+	if c.Fares.MaxDistanceKm < 0 {
+		return fmt.Errorf("maximum journey distance must not be negative")
+	}
+	for station, coordinate := range c.Fares.Coordinates {
+		if coordinate.Latitude < -90 || coordinate.Latitude > 90 || coordinate.Longitude < -180 || coordinate.Longitude > 180 {
+			return fmt.Errorf("station %s has invalid coordinates", station)
+		}
+	}
+
+	if smtp := c.Notifications.SMTP; smtp.Host != "" {
+		if smtp.Port <= 0 || smtp.Port > 65535 {
+			return fmt.Errorf("smtp port %d is invalid", smtp.Port)
+		}
+		if smtp.From == "" {
+			return fmt.Errorf("smtp sender address is required")
+		}
+	}
+
+	for route, limit := range c.Booking.RouteCaps {
+		if limit <= 0 {
+			return fmt.Errorf("route cap for %s must be positive", route)
+		}
+	}
+
+	if hot := c.Server.HotRequests; hot.Threshold < 0 || hot.SampleEvery < 0 {
+		return fmt.Errorf("hot request threshold and sampling must not be negative")
+	} else if hot.Threshold > 0 && hot.WindowSeconds <= 0 {
+		return fmt.Errorf("hot request window must be positive")
+	}
+
+	if c.Server.ReadinessIntervalSeconds < 0 {
+		return fmt.Errorf("readiness interval must not be negative")
+	}
+
+	apiKeys := make(map[string]bool, len(c.Server.APIKeys))
+	for _, apiKey := range c.Server.APIKeys {
+		if apiKey.Key == "" || apiKeys[apiKey.Key] {
+			return fmt.Errorf("api keys must be set and unique")
+		}
+		apiKeys[apiKey.Key] = true
+		if !apiKey.Admin && !strings.Contains(apiKey.Email, "@") {
+			return fmt.Errorf("api key for %q needs an email or admin access", apiKey.Email)
+		}
+	}
+
+	if c.Booking.RebookCooldownSeconds < 0 {
+		return fmt.Errorf("rebook cooldown must not be negative")
+	}
+
+	if c.Booking.AdvanceDays < 0 {
+		return fmt.Errorf("advance booking days must not be negative")
+	}
+
+	if c.Booking.PaymentWindowSeconds < 0 {
+		return fmt.Errorf("payment window must not be negative")
+	}
+
+	if c.Booking.HoldSeconds < 0 {
+		return fmt.Errorf("seat hold duration must not be negative")
+	}
+
+	if c.Booking.MaxHoldsPerUser < 0 {
+		return fmt.Errorf("seat hold limit per user must not be negative")
+	}
+
+	if c.Alerts.OccupancyThreshold < 0 || c.Alerts.OccupancyThreshold > 1 {
+		return fmt.Errorf("occupancy alert threshold must be between 0 and 1")
+	}
+
+	for method := range c.Features {
+		if method == "" || strings.ContainsAny(method, "/ ") {
+			return fmt.Errorf("feature %q must be a method name, e.g. GetSeat", method)
+		}
+	}
+
+	if err := c.Booking.Reference.validate(); err != nil {
+		return err
+	}
+
+	switch c.Pricing.Rounding {
+	case "", RoundingNone, RoundingNearestCent, RoundingUp, RoundingDown:
+	default:
+		return fmt.Errorf("unknown fare rounding mode %q", c.Pricing.Rounding)
+	}
+
+	switch c.Pricing.RefundPolicy {
+	case "", RefundProrated, RefundBeforeDeparture:
+	default:
+		return fmt.Errorf("unknown refund policy %q", c.Pricing.RefundPolicy)
+	}
+
+	if c.Pricing.BookingFee < 0 {
+		return fmt.Errorf("booking fee must not be negative, got %v", c.Pricing.BookingFee)
+	}
+
+	for _, window := range c.Pricing.TimeWindows {
+		start, err := ParseClock(window.Start)
+		if err != nil {
+			return fmt.Errorf("time window %s: %w", window.Name, err)
+		}
+		end, err := ParseClock(window.End)
+		if err != nil {
+			return fmt.Errorf("time window %s: %w", window.Name, err)
+		}
+		if start == end {
+			return fmt.Errorf("time window %s must not start and end at the same time", window.Name)
+		}
+		if window.Multiplier <= 0 {
+			return fmt.Errorf("time window %s multiplier must be positive", window.Name)
+		}
+	}
+
+	fareClasses := make(map[string]bool, len(c.Pricing.FareClasses))
+	for _, class := range c.Pricing.FareClasses {
+		if class.Name == "" || fareClasses[class.Name] {
+			return fmt.Errorf("fare class names must be set and unique")
+		}
+		fareClasses[class.Name] = true
+		if class.Seats <= 0 || class.Multiplier <= 0 {
+			return fmt.Errorf("fare class %s seats and multiplier must be positive", class.Name)
+		}
+		switch class.SoldOut {
+		case "", SoldOutUpsell, SoldOutReject:
+		default:
+			return fmt.Errorf("fare class %s has unknown sold out policy %q", class.Name, class.SoldOut)
+		}
+	}
+
+	return nil
+}
+
+// WithDefaults returns the reference format with zero values replaced by the
+// defaults.
+func (r ReferenceConfig) WithDefaults() ReferenceConfig {
+	if r.Length == 0 {
+		r.Length = DefaultReferenceLength
+	}
+	if r.Alphabet == "" {
+		r.Alphabet = DefaultReferenceAlphabet
+	}
+	return r
+}
+
+// validate checks that the reference format allows enough distinct references.
+func (r ReferenceConfig) validate() error {
+	if r.Length < 0 {
+		return fmt.Errorf("booking reference length must not be negative")
+	}
+	r = r.WithDefaults()
+	seen := make(map[rune]bool, len(r.Alphabet))
+	for _, char := range r.Alphabet {
+		if seen[char] || unicode.IsSpace(char) {
+			return fmt.Errorf("booking reference alphabet must not repeat characters or contain spaces")
+		}
+		seen[char] = true
+	}
+	if math.Pow(float64(len(seen)), float64(r.Length)) < minReferenceCombinations {
+		return fmt.Errorf("booking reference format allows fewer than %d references", minReferenceCombinations)
+	}
+	return nil
+}
+
+// EnabledFeatures returns the names of the optional behaviours switched on by
+// this configuration.
+func (c *Config) EnabledFeatures() []string {
+	features := make([]string, 0)
+	if c.MaintenanceMode {
+		features = append(features, "maintenance_mode")
+	}
+	if c.Server.Concurrency.MaxInFlight > 0 {
+		features = append(features, "concurrency_limit")
+	}
+	if c.Server.MinClientVersion != "" {
+		features = append(features, "min_client_version")
+	}
+	if c.Server.CompressResponses {
+		features = append(features, "compress_responses")
+	}
+	if c.Server.HotRequests.Threshold > 0 {
+		features = append(features, "hot_request_logging")
+	}
+	if len(c.Server.APIKeys) > 0 {
+		features = append(features, "api_key_auth")
+	}
+	if c.Server.StrictRequests {
+		features = append(features, "strict_requests")
+	}
+	if c.Booking.DedupeWindowSeconds > 0 {
+		features = append(features, "purchase_dedupe")
+	}
+	if c.Booking.RebookCooldownSeconds > 0 {
+		features = append(features, "rebook_cooldown")
+	}
+	if c.Booking.PaymentWindowSeconds > 0 {
+		features = append(features, "provisional_bookings")
+	}
+	if c.Booking.HoldSeconds > 0 {
+		features = append(features, "seat_holds")
+	}
+	if c.Booking.AdvanceDays > 0 {
+		features = append(features, "dated_departures")
+	}
+	if len(c.Booking.Trains) > 1 {
+		features = append(features, "multiple_trains")
+	}
+	if len(c.Booking.RouteCaps) > 0 {
+		features = append(features, "route_caps")
+	}
+	if c.Seating.DestinationAffinity {
+		features = append(features, "destination_affinity")
+	}
+	if c.Seating.DefaultSection != "" {
+		features = append(features, "default_section")
+	}
+	if len(c.Seating.PositionOrder) > 0 {
+		features = append(features, "seat_position_order")
+	}
+	if len(c.Seating.LoyaltySections) > 0 {
+		features = append(features, "loyalty_seating")
+	}
+	if c.Seating.AssignLockTimeoutMillis > 0 {
+		features = append(features, "assign_lock_timeout")
+	}
+	if c.Notifications.SMTP.Host != "" {
+		features = append(features, "email_notifications")
+	}
+	if c.Alerts.OccupancyThreshold > 0 {
+		features = append(features, "occupancy_alerts")
+	}
+	if c.Stations.Canonicalize {
+		features = append(features, "canonical_station_names")
+	}
+	for _, section := range c.Sections {
+		if section.OverflowPolicy != "" && section.OverflowPolicy != OverflowSpill {
+			features = append(features, "overflow_policies")
+			break
+		}
+	}
+	for _, section := range c.Sections {
+		if (section.PriceMultiplier != 0 && section.PriceMultiplier != 1) || section.Surcharge != 0 {
+			features = append(features, "section_pricing")
+			break
+		}
+	}
+	for _, section := range c.Sections {
+		if section.Restricted {
+			features = append(features, "restricted_sections")
+			break
+		}
+	}
+	for _, section := range c.Sections {
+		if section.FillDirection == FillDescending {
+			features = append(features, "descending_fill")
+			break
+		}
+	}
+	for _, enabled := range c.Features {
+		if !enabled {
+			features = append(features, "feature_flags")
+			break
+		}
+	}
+	if c.SnapshotPath != "" {
+		features = append(features, "snapshots")
+	}
+	if len(c.Route) > 0 {
+		features = append(features, "segment_booking")
+	}
+	if c.Fares.RatePerKm > 0 {
+		features = append(features, "distance_fares")
+	}
+	if c.Fares.MaxDistanceKm > 0 {
+		features = append(features, "max_journey_distance")
+	}
+	if len(c.Pricing.TimeWindows) > 0 {
+		features = append(features, "time_windowed_pricing")
+	}
+	if c.Pricing.BookingFee > 0 {
+		features = append(features, "booking_fee")
+	}
+	if len(c.Pricing.FareClasses) > 0 {
+		features = append(features, "fare_classes")
+	}
+	return features
+}
+
+// RedactedValue replaces secrets in the output of Redacted.
+const RedactedValue = "REDACTED"
+
+// Redacted returns a copy of the configuration that is safe to show, with API
+// keys and the SMTP password replaced by RedactedValue.
+func (c *Config) Redacted() *Config {
+	redacted := *c
+	redacted.Server.APIKeys = make([]APIKeyConfig, len(c.Server.APIKeys))
+	for i, apiKey := range c.Server.APIKeys {
+		apiKey.Key = RedactedValue
+		redacted.Server.APIKeys[i] = apiKey
+	}
+	if redacted.Notifications.SMTP.Password != "" {
+		redacted.Notifications.SMTP.Password = RedactedValue
+	}
+	return &redacted
+}
+
+// DisabledMethods returns the RPCs switched off in Features, sorted by name.
+func (c *Config) DisabledMethods() []string {
+	methods := make([]string, 0)
+	for method, enabled := range c.Features {
+		if !enabled {
+			methods = append(methods, method)
+		}
+	}
+	sort.Strings(methods)
+	return methods
+}
+
+// Summary returns the effective configuration as structured log fields so the
+// server can report what actually took effect on boot.
+func (c *Config) Summary() []zap.Field {
+	totalSeats := 0
+	sectionNames := make([]string, 0, len(c.Sections))
+	for _, section := range c.Sections {
+		totalSeats += section.MaxSeats
+		sectionNames = append(sectionNames, section.Name)
+	}
+
+	return []zap.Field{
+		zap.String("port", c.Server.Port),
+		zap.Int("max_in_flight", c.Server.Concurrency.MaxInFlight),
+		zap.String("min_client_version", c.Server.MinClientVersion),
+		zap.Int("hot_request_threshold", c.Server.HotRequests.Threshold),
+		zap.Int("api_key_count", len(c.Server.APIKeys)),
+		zap.Duration("readiness_interval", c.Server.ReadinessInterval()),
+		zap.Bool("strict_requests", c.Server.StrictRequests),
+		zap.String("log_level", c.LogLevel),
+		zap.Bool("maintenance_mode", c.MaintenanceMode),
+		zap.String("snapshot_path", c.SnapshotPath),
+		zap.Int("section_count", len(c.Sections)),
+		zap.Strings("sections", sectionNames),
+		zap.Int("total_capacity", totalSeats),
+		zap.Int("station_count", len(c.Stations.Prices)),
+		zap.Bool("canonicalize_stations", c.Stations.Canonicalize),
+		zap.Strings("route", c.Route),
+		zap.Float64("fare_rate_per_km", c.Fares.RatePerKm),
+		zap.Int("located_station_count", len(c.Fares.Coordinates)),
+		zap.Float64("max_distance_km", c.Fares.MaxDistanceKm),
+		zap.Int("time_window_count", len(c.Pricing.TimeWindows)),
+		zap.String("fare_rounding", c.Pricing.Rounding),
+		zap.String("refund_policy", c.Pricing.RefundPolicy),
+		zap.Float64("booking_fee", c.Pricing.BookingFee),
+		zap.Int("fare_class_count", len(c.Pricing.FareClasses)),
+		zap.Strings("enabled_features", c.EnabledFeatures()),
+		zap.Strings("disabled_methods", c.DisabledMethods()),
+		zap.Int("dedupe_window_seconds", c.Booking.DedupeWindowSeconds),
+		zap.Int("rebook_cooldown_seconds", c.Booking.RebookCooldownSeconds),
+		zap.Int("advance_days", c.Booking.AdvanceDays),
+		zap.Int("payment_window_seconds", c.Booking.PaymentWindowSeconds),
+		zap.Int("hold_seconds", c.Booking.HoldSeconds),
+		zap.Int("max_holds_per_user", c.Booking.MaxHoldsPerUser),
+		zap.Int("route_cap_count", len(c.Booking.RouteCaps)),
+		zap.Strings("trains", c.Booking.Trains),
+		zap.String("default_train", c.Booking.DefaultTrain),
+		zap.Int("reference_length", c.Booking.Reference.Length),
+		zap.Bool("destination_affinity", c.Seating.DestinationAffinity),
+		zap.String("default_section", c.Seating.DefaultSection),
+		zap.String("row_layout", c.Seating.RowLayout),
+		zap.Strings("position_order", c.Seating.PositionOrder),
+		zap.Int("assign_lock_timeout_ms", c.Seating.AssignLockTimeoutMillis),
+		zap.Int("loyalty_tier_count", len(c.Seating.LoyaltySections)),
+		zap.String("smtp_host", c.Notifications.SMTP.Host),
+		zap.Float64("occupancy_alert_threshold", c.Alerts.OccupancyThreshold),
+	}
+}
+
+// NewLogger initializes a new Zap logger. If it can't be built, a fallback
+// logger is returned together with the error, so that a logging problem need
+// not stop the server.
+func NewLogger(logLevel string) (*zap.Logger, error) {
 	var level zap.AtomicLevel
 	switch logLevel {
 	case "debug":
@@ -85,9 +968,21 @@ func NewLogger(logLevel string) *zap.Logger {
 			EncodeCaller: zapcore.ShortCallerEncoder,
 		},
 	}
+	return buildLogger(cfg)
+}
+
+// buildLogger builds a logger from cfg, falling back to a development logger
+// writing to stderr, or failing that to one that discards everything.
+func buildLogger(cfg zap.Config) (*zap.Logger, error) {
 	logger, err := cfg.Build()
-	if err != nil {
-		log.Fatalf("failed to initialize zap logger: %v", err)
+	if err == nil {
+		return logger, nil
+	}
+	err = fmt.Errorf("failed to initialize zap logger: %w", err)
+
+	fallback, fallbackErr := zap.NewDevelopment()
+	if fallbackErr != nil {
+		return zap.NewNop(), err
 	}
-	return logger
+	return fallback, err
 }
@@ -1,9 +1,13 @@
 package config
 
 import (
-	"errors"
+	"fmt"
 	"github.com/stretchr/testify/assert"
+	"io/fs"
 	"testing"
+	"time"
+
+	"go.uber.org/zap"
 )
 
 // MockFileReader implements FileReader for testing
@@ -15,7 +19,7 @@ func (m MockFileReader) ReadFile(filename string) ([]byte, error) {
 	if data, ok := m.files[filename]; ok {
 		return data, nil
 	}
-	return nil, errors.New("file not found")
+	return nil, fmt.Errorf("open %s: %w", filename, fs.ErrNotExist)
 }
 
 func TestLoadConfig(t *testing.T) {
@@ -44,7 +48,7 @@ stations:
 	assert.Equal(t, 2, len(cfg.Sections), "There should be 2 sections in the config")
 	assert.Equal(t, "A", cfg.Sections[0].Name, "First section should be A")
 	assert.Equal(t, 20, cfg.Sections[1].MaxSeats, "Second section should have 20 max seats")
-	assert.Equal(t, 20.00, cfg.Stations["London-France"], "London-France should have a price of 20.00")
+	assert.Equal(t, 20.00, cfg.Stations.Prices["London-France"], "London-France should have a price of 20.00")
 
 
 	// Test loading an invalid configuration file
@@ -52,20 +56,398 @@ stations:
 	assert.Error(t, err, "Should return an error when loading an invalid config file")
 }
 
-func TestNewLogger(t *testing.T) {
-	// Test creating a logger with different log levels
-	logger := NewLogger("debug")
-	assert.NotNil(t, logger, "Logger should not be nil")
+func TestLoadConfigNotFound(t *testing.T) {
+	_, err := LoadConfig("missing.yaml", MockFileReader{})
+	assert.ErrorIs(t, err, ErrConfigNotFound, "A missing file should be reported as not found")
+	assert.NotErrorIs(t, err, ErrConfigParse)
+}
+
+func TestLoadConfigParseError(t *testing.T) {
+	mockReader := MockFileReader{
+		files: map[string][]byte{
+			"config.yaml": []byte("sections:\n  - name: \"A\"\n    max_seats: ten"),
+		},
+	}
+
+	_, err := LoadConfig("config.yaml", mockReader)
+	assert.ErrorIs(t, err, ErrConfigParse, "Bad YAML should be reported as malformed")
+	assert.NotErrorIs(t, err, ErrConfigNotFound)
+	assert.Contains(t, err.Error(), "config.yaml", "The error should name the file")
+}
+
+func TestLoadConfigCanonicalStations(t *testing.T) {
+	mockReader := MockFileReader{
+		files: map[string][]byte{
+			"config.yaml": []byte(`
+stations:
+  canonicalize: true
+  " london -FRANCE": 20.00
+route: ["london", "new  york"]
+booking:
+  route_caps:
+    LONDON-france: 5`),
+			"duplicate.yaml": []byte(`
+stations:
+  canonicalize: true
+  London-France: 20.00
+  london-france: 25.00`),
+		},
+	}
+
+	cfg, err := LoadConfig("config.yaml", mockReader)
+	assert.NoError(t, err)
+	assert.True(t, cfg.Stations.Canonicalize)
+	assert.Equal(t, map[string]float64{"London-France": 20.00}, cfg.Stations.Prices, "Price keys should be canonical")
+	assert.Equal(t, map[string]int{"London-France": 5}, cfg.Booking.RouteCaps, "Route cap keys should be canonical")
+	assert.Equal(t, []string{"London", "New York"}, cfg.Route)
+
+	_, err = LoadConfig("duplicate.yaml", mockReader)
+	assert.Error(t, err, "Prices differing only in case should be rejected")
+}
+
+func TestCanonicalStation(t *testing.T) {
+	assert.Equal(t, "London", CanonicalStation(" london "))
+	assert.Equal(t, "London", CanonicalStation("LONDON"))
+	assert.Equal(t, "New York", CanonicalStation("new\t  YORK"))
+	assert.Equal(t, "", CanonicalStation("   "))
+}
+
+func TestConfigValidate(t *testing.T) {
+	cfg := &Config{
+		Sections: []SectionConfig{
+			{Name: "A", MaxSeats: 10},
+			{Name: "B", MaxSeats: 20},
+		},
+	}
+	assert.NoError(t, cfg.Validate(), "Config without a default section should be valid")
+
+	cfg.Seating.DefaultSection = "B"
+	assert.NoError(t, cfg.Validate(), "Default section naming a configured section should be valid")
+
+	cfg.Seating.DefaultSection = "C"
+	assert.Error(t, cfg.Validate(), "Default section naming an unknown section should be invalid")
+
+	cfg.Seating.DefaultSection = "B"
+	cfg.Sections[1].Restricted = true
+	assert.Error(t, cfg.Validate(), "A restricted default section should be invalid")
+	cfg.Seating.DefaultSection = "A"
+	assert.NoError(t, cfg.Validate(), "Restricted sections other than the default should be valid")
+	cfg.Sections[1].Restricted = false
+
+	cfg.Sections[1].FillDirection = FillDescending
+	assert.NoError(t, cfg.Validate(), "Descending sections should be valid")
+	cfg.Sections[1].FillDirection = "backwards"
+	assert.Error(t, cfg.Validate(), "Unknown fill directions should be invalid")
+	cfg.Sections[1].FillDirection = ""
+
+	cfg.Seating.LoyaltySections = map[string][]string{"gold": {"B", "A"}}
+	assert.NoError(t, cfg.Validate(), "Loyalty tiers naming configured sections should be valid")
+	cfg.Seating.LoyaltySections["silver"] = []string{"C"}
+	assert.Error(t, cfg.Validate(), "Loyalty tiers naming unknown sections should be invalid")
+	cfg.Seating.LoyaltySections = map[string][]string{"": {"B"}}
+	assert.Error(t, cfg.Validate(), "Empty loyalty tier names should be invalid")
+	cfg.Seating.LoyaltySections = map[string][]string{"gold": {"B"}}
+	cfg.Sections[1].Restricted = true
+	assert.Error(t, cfg.Validate(), "Loyalty tiers naming restricted sections should be invalid")
+	cfg.Sections[1].Restricted = false
+	cfg.Seating.LoyaltySections = nil
+
+	cfg.Booking.Trains = []string{"IC101", "IC102"}
+	cfg.Booking.DefaultTrain = "IC101"
+	assert.NoError(t, cfg.Validate(), "Trains with a listed default should be valid")
+	cfg.Booking.DefaultTrain = ""
+	assert.Error(t, cfg.Validate(), "Trains without a default should be invalid")
+	cfg.Booking.DefaultTrain = "IC103"
+	assert.Error(t, cfg.Validate(), "A default train that is not listed should be invalid")
+	cfg.Booking.Trains = []string{"IC101", "IC101"}
+	cfg.Booking.DefaultTrain = "IC101"
+	assert.Error(t, cfg.Validate(), "Duplicate trains should be invalid")
+	cfg.Booking.Trains = []string{"IC101", " "}
+	assert.Error(t, cfg.Validate(), "Empty train IDs should be invalid")
+	cfg.Booking.Trains = nil
+	assert.Error(t, cfg.Validate(), "A default train needs configured trains")
+	cfg.Booking.DefaultTrain = ""
+
+	cfg.Seating.DefaultSection = ""
+	cfg.Route = []string{"London", "Paris", "Lyon"}
+	assert.NoError(t, cfg.Validate(), "Route with distinct stations should be valid")
+
+	cfg.Route = []string{"London"}
+	assert.Error(t, cfg.Validate(), "Route with a single station should be invalid")
+
+	cfg.Route = []string{"London", "Paris", "London"}
+	assert.Error(t, cfg.Validate(), "Route visiting a station twice should be invalid")
+
+	cfg.Route = nil
+	cfg.Fares = FareConfig{
+		RatePerKm:   0.1,
+		Coordinates: map[string]Coordinate{"London": {Latitude: 51.5072, Longitude: -0.1276}},
+	}
+	assert.NoError(t, cfg.Validate(), "Fares with valid coordinates should be valid")
+
+	cfg.Fares.RatePerKm = -0.1
+	assert.Error(t, cfg.Validate(), "Negative fare rate should be invalid")
+
+	cfg.Fares.RatePerKm = 0.1
+	cfg.Fares.MaxDistanceKm = -1
+	assert.Error(t, cfg.Validate(), "Negative maximum journey distance should be invalid")
+
+	cfg.Fares.MaxDistanceKm = 0
+	cfg.Fares.Coordinates["Nowhere"] = Coordinate{Latitude: 91, Longitude: 0}
+	assert.Error(t, cfg.Validate(), "Out of range coordinates should be invalid")
+
+	cfg.Fares.Coordinates = nil
+	cfg.Booking.RouteCaps = map[string]int{"London-France": 10}
+	assert.NoError(t, cfg.Validate(), "Positive route caps should be valid")
+
+	cfg.Booking.RouteCaps["London-Paris"] = 0
+	assert.Error(t, cfg.Validate(), "Route caps must be positive")
+
+	cfg.Booking.RouteCaps = nil
+	cfg.Notifications.SMTP = SMTPConfig{Host: "smtp.example.com", Port: 587, From: "tickets@example.com"}
+	assert.NoError(t, cfg.Validate(), "Complete SMTP settings should be valid")
+
+	cfg.Notifications.SMTP.From = ""
+	assert.Error(t, cfg.Validate(), "SMTP needs a sender address")
+
+	cfg.Notifications.SMTP = SMTPConfig{}
+	cfg.Pricing.TimeWindows = []TimeWindowConfig{{Name: "peak", Start: "07:00", End: "09:30", Multiplier: 1.5}}
+	assert.NoError(t, cfg.Validate(), "Valid time windows should be valid")
+
+	cfg.Pricing.TimeWindows[0].End = "25:00"
+	assert.Error(t, cfg.Validate(), "Time windows need valid times of day")
+
+	cfg.Pricing.TimeWindows[0].End = "09:30"
+	cfg.Pricing.TimeWindows[0].Multiplier = 0
+	assert.Error(t, cfg.Validate(), "Time window multipliers must be positive")
+
+	cfg.Pricing.TimeWindows = nil
+	cfg.Sections[0].BlockedSeats = []int{1, 10}
+	assert.NoError(t, cfg.Validate(), "Blocked seats within the section should be valid")
+
+	cfg.Sections[0].BlockedSeats = []int{11}
+	assert.Error(t, cfg.Validate(), "Blocked seats beyond the section should be invalid")
+
+	cfg.Sections[0].BlockedSeats = nil
+	cfg.Sections[0].OverflowPolicy = OverflowWaitlist
+	assert.NoError(t, cfg.Validate(), "Known overflow policies should be valid")
 
-	logger = NewLogger("info")
-	assert.NotNil(t, logger, "Logger should not be nil")
+	cfg.Sections[0].OverflowPolicy = "queue"
+	assert.Error(t, cfg.Validate(), "Unknown overflow policies should be invalid")
 
-	logger = NewLogger("warn")
-	assert.NotNil(t, logger, "Logger should not be nil")
+	cfg.Sections[0].OverflowPolicy = ""
+	cfg.Booking.Reference = ReferenceConfig{Length: 8, Alphabet: "0123456789"}
+	assert.NoError(t, cfg.Validate(), "Reference formats with enough combinations should be valid")
 
-	logger = NewLogger("error")
-	assert.NotNil(t, logger, "Logger should not be nil")
+	cfg.Booking.Reference.Length = 4
+	assert.Error(t, cfg.Validate(), "Reference formats with too few combinations should be invalid")
+
+	cfg.Booking.Reference = ReferenceConfig{Alphabet: "AABC"}
+	assert.Error(t, cfg.Validate(), "Reference alphabets must not repeat characters")
+
+	cfg.Booking.Reference = ReferenceConfig{}
+	cfg.Features = map[string]bool{"GetSeat": false}
+	assert.NoError(t, cfg.Validate(), "Feature flags keyed by method name should be valid")
+
+	cfg.Features = map[string]bool{"/ticketBooking.TicketBookingService/GetSeat": false}
+	assert.Error(t, cfg.Validate(), "Feature flags keyed by full method path should be invalid")
+
+	cfg.Features = nil
+	cfg.Alerts.OccupancyThreshold = 0.9
+	assert.NoError(t, cfg.Validate(), "Occupancy threshold between 0 and 1 should be valid")
+
+	cfg.Alerts.OccupancyThreshold = 90
+	assert.Error(t, cfg.Validate(), "Occupancy threshold given as a percentage should be invalid")
+
+	cfg.Alerts.OccupancyThreshold = 0
+	cfg.Pricing.Rounding = RoundingUp
+	assert.NoError(t, cfg.Validate(), "Known rounding modes should be valid")
+
+	cfg.Pricing.Rounding = "banker"
+	assert.Error(t, cfg.Validate(), "Unknown rounding modes should be invalid")
+
+	cfg.Pricing.Rounding = ""
+	cfg.Pricing.RefundPolicy = RefundBeforeDeparture
+	assert.NoError(t, cfg.Validate(), "Known refund policies should be valid")
+
+	cfg.Pricing.RefundPolicy = "partial"
+	assert.Error(t, cfg.Validate(), "Unknown refund policies should be invalid")
+
+	cfg.Pricing.RefundPolicy = ""
+	cfg.Booking.RebookCooldownSeconds = -1
+	assert.Error(t, cfg.Validate(), "Negative rebook cooldown should be invalid")
+
+	cfg.Booking.RebookCooldownSeconds = 0
+	cfg.Booking.AdvanceDays = -1
+	assert.Error(t, cfg.Validate(), "Negative advance booking days should be invalid")
+
+	cfg.Booking.AdvanceDays = 0
+	cfg.Server.HotRequests = HotRequestConfig{Threshold: 50, WindowSeconds: 10}
+	assert.NoError(t, cfg.Validate(), "Hot request detection with a window should be valid")
+
+	cfg.Server.HotRequests.WindowSeconds = 0
+	assert.Error(t, cfg.Validate(), "Hot request detection needs a window")
+
+	cfg.Server.HotRequests = HotRequestConfig{}
+	cfg.Server.APIKeys = []APIKeyConfig{{Key: "user-key", Email: "test@example.com"}, {Key: "admin-key", Admin: true}}
+	assert.NoError(t, cfg.Validate(), "User and admin API keys should be valid")
+
+	cfg.Server.APIKeys = append(cfg.Server.APIKeys, APIKeyConfig{Key: "user-key", Email: "other@example.com"})
+	assert.Error(t, cfg.Validate(), "Duplicate API keys should be invalid")
+
+	cfg.Server.APIKeys = []APIKeyConfig{{Key: "user-key"}}
+	assert.Error(t, cfg.Validate(), "User API keys need an email")
+
+	cfg.Server.APIKeys = nil
+	cfg.Seating.RowLayout = "WMAAMW"
+	cfg.Seating.PositionOrder = []string{PositionWindow, PositionMiddle}
+	assert.NoError(t, cfg.Validate(), "A partial position order should be valid")
+
+	cfg.Seating.PositionOrder = []string{PositionWindow, PositionWindow}
+	assert.Error(t, cfg.Validate(), "Repeated positions should be invalid")
+
+	cfg.Seating.PositionOrder = []string{"corridor"}
+	assert.Error(t, cfg.Validate(), "Unknown positions should be invalid")
+
+	cfg.Seating.PositionOrder = []string{PositionAisle}
+	cfg.Seating.RowLayout = "WXW"
+	assert.Error(t, cfg.Validate(), "Unknown layout letters should be invalid")
+
+	cfg.Seating.RowLayout = ""
+	assert.Error(t, cfg.Validate(), "A position order needs a row layout")
+
+	cfg.Seating.PositionOrder = nil
+	assert.Equal(t, DefaultReadinessInterval, cfg.Server.ReadinessInterval())
+	cfg.Server.ReadinessIntervalSeconds = 30
+	assert.Equal(t, 30*time.Second, cfg.Server.ReadinessInterval())
+	cfg.Server.ReadinessIntervalSeconds = -1
+	assert.Error(t, cfg.Validate(), "Negative readiness intervals should be invalid")
+
+	cfg.Server.ReadinessIntervalSeconds = 0
+	cfg.Seating.AssignLockTimeoutMillis = -1
+	assert.Error(t, cfg.Validate(), "Negative assignment lock timeouts should be invalid")
+
+	cfg.Seating.AssignLockTimeoutMillis = 0
+	cfg.Sections[0].PriceMultiplier = -1
+	assert.Error(t, cfg.Validate(), "Negative section price multipliers should be invalid")
+	cfg.Sections[0].PriceMultiplier = 0
+	cfg.Sections[0].Surcharge = -5
+	assert.Error(t, cfg.Validate(), "Negative section surcharges should be invalid")
+
+	cfg.Sections[0].Surcharge = 0
+	cfg.Pricing.BookingFee = -1
+	assert.Error(t, cfg.Validate(), "Negative booking fees should be invalid")
+	cfg.Pricing.BookingFee = 1.50
+	cfg.Booking.PaymentWindowSeconds = -1
+	assert.Error(t, cfg.Validate(), "Negative payment windows should be invalid")
+	cfg.Booking.PaymentWindowSeconds = 900
+	cfg.Booking.HoldSeconds = -1
+	assert.Error(t, cfg.Validate(), "Negative seat hold durations should be invalid")
+	cfg.Booking.HoldSeconds = 300
+	cfg.Booking.MaxHoldsPerUser = -1
+	assert.Error(t, cfg.Validate(), "Negative seat hold limits should be invalid")
+	cfg.Booking.MaxHoldsPerUser = 2
+	cfg.Pricing.FareClasses = []FareClassConfig{{Name: "saver", Seats: 10, Multiplier: 0.7, SoldOut: SoldOutReject}}
+	assert.NoError(t, cfg.Validate())
+	cfg.Pricing.FareClasses[0].Seats = 0
+	assert.Error(t, cfg.Validate(), "Empty fare class pools should be invalid")
+	cfg.Pricing.FareClasses[0].Seats = 10
+	cfg.Pricing.FareClasses[0].SoldOut = "refund"
+	assert.Error(t, cfg.Validate(), "Unknown sold out policies should be invalid")
+	cfg.Pricing.FareClasses[0].SoldOut = ""
+	cfg.Pricing.FareClasses = append(cfg.Pricing.FareClasses, FareClassConfig{Name: "saver", Seats: 5, Multiplier: 0.5})
+	assert.Error(t, cfg.Validate(), "Repeated fare class names should be invalid")
+	cfg.Pricing.FareClasses = cfg.Pricing.FareClasses[:1]
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestConfigSummary(t *testing.T) {
+	cfg := &Config{
+		Server:   ServerConfig{Port: ":50051"},
+		LogLevel: "debug",
+		Sections: []SectionConfig{
+			{Name: "A", MaxSeats: 10},
+			{Name: "B", MaxSeats: 20},
+		},
+		Stations: StationConfig{Prices: map[string]float64{"London-France": 20.00}},
+	}
+
+	fields := make(map[string]zap.Field)
+	for _, field := range cfg.Summary() {
+		fields[field.Key] = field
+	}
+
+	assert.Equal(t, ":50051", fields["port"].String, "Port should be reported")
+	assert.Equal(t, "debug", fields["log_level"].String, "Log level should be reported")
+	assert.Equal(t, int64(2), fields["section_count"].Integer, "Section count should be reported")
+	assert.Equal(t, int64(30), fields["total_capacity"].Integer, "Total capacity should be the sum of section seats")
+	assert.Equal(t, int64(1), fields["station_count"].Integer, "Station count should be reported")
+}
+
+func TestConfigEnabledFeatures(t *testing.T) {
+	cfg := &Config{}
+	assert.Empty(t, cfg.EnabledFeatures(), "No features should be enabled by default")
+
+	cfg.Seating.DestinationAffinity = true
+	cfg.Booking.DedupeWindowSeconds = 5
+	assert.ElementsMatch(t, []string{"destination_affinity", "purchase_dedupe"}, cfg.EnabledFeatures())
+
+	cfg.Sections = []SectionConfig{{Name: "A", MaxSeats: 10, PriceMultiplier: 1}}
+	assert.NotContains(t, cfg.EnabledFeatures(), "section_pricing", "A multiplier of 1 leaves fares unchanged")
+	cfg.Sections[0].Surcharge = 5
+	assert.Contains(t, cfg.EnabledFeatures(), "section_pricing")
+	cfg.Sections[0].Restricted = true
+	assert.Contains(t, cfg.EnabledFeatures(), "restricted_sections")
+	cfg.Sections[0].FillDirection = FillDescending
+	assert.Contains(t, cfg.EnabledFeatures(), "descending_fill")
+
+	cfg.Pricing.BookingFee = 1.50
+	assert.Contains(t, cfg.EnabledFeatures(), "booking_fee")
+	cfg.Pricing.FareClasses = []FareClassConfig{{Name: "saver", Seats: 10, Multiplier: 0.7}}
+	assert.Contains(t, cfg.EnabledFeatures(), "fare_classes")
+
+	cfg.Booking.PaymentWindowSeconds = 900
+	assert.Contains(t, cfg.EnabledFeatures(), "provisional_bookings")
+
+	cfg.Booking.HoldSeconds = 300
+	assert.Contains(t, cfg.EnabledFeatures(), "seat_holds")
+
+	cfg.Seating.LoyaltySections = map[string][]string{"gold": {"A"}}
+	assert.Contains(t, cfg.EnabledFeatures(), "loyalty_seating")
+
+	cfg.Server.StrictRequests = true
+	assert.Contains(t, cfg.EnabledFeatures(), "strict_requests")
+
+	cfg.Booking.Trains = []string{"IC101"}
+	assert.NotContains(t, cfg.EnabledFeatures(), "multiple_trains")
+	cfg.Booking.Trains = []string{"IC101", "IC102"}
+	assert.Contains(t, cfg.EnabledFeatures(), "multiple_trains")
+
+	cfg.Features = map[string]bool{"Verify": false, "GetSeat": false, "BulkCancel": true}
+	assert.Contains(t, cfg.EnabledFeatures(), "feature_flags")
+	assert.Equal(t, []string{"GetSeat", "Verify"}, cfg.DisabledMethods(), "Disabled methods should be sorted")
+}
+
+func TestNewLogger(t *testing.T) {
+	// Test creating a logger with different log levels
+	for _, level := range []string{"debug", "info", "warn", "error", "invalid"} {
+		logger, err := NewLogger(level)
+		assert.NoError(t, err)
+		assert.NotNil(t, logger, "Logger should not be nil")
+	}
+}
+
+func TestBuildLoggerFallback(t *testing.T) {
+	// An unknown encoding can't be built
+	cfg := zap.Config{
+		Encoding:         "bogus",
+		Level:            zap.NewAtomicLevelAt(zap.InfoLevel),
+		OutputPaths:      []string{"stderr"},
+		ErrorOutputPaths: []string{"stderr"},
+	}
 
-	logger = NewLogger("invalid")
-	assert.NotNil(t, logger, "Logger should not be nil")
+	logger, err := buildLogger(cfg)
+	assert.Error(t, err, "The build failure should be reported")
+	assert.NotNil(t, logger, "A fallback logger should be returned")
+	assert.NotPanics(t, func() { logger.Info("still logging") })
 }
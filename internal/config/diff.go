@@ -0,0 +1,157 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Kinds of ConfigChange.
+const (
+	ChangeAdded    = "added"
+	ChangeRemoved  = "removed"
+	ChangeModified = "changed"
+)
+
+// ConfigChange is one difference between two configurations.
+type ConfigChange struct {
+	// Path locates the setting by its YAML keys, e.g. "pricing.booking_fee",
+	// "stations.London-France" or "sections[A].max_seats". Entries of lists
+	// with names, like sections, are identified by name.
+	Path string
+	// Change is ChangeAdded, ChangeRemoved or ChangeModified.
+	Change string
+	// Old and New are the values before and after, empty for an entry that
+	// was added or removed respectively.
+	Old string
+	New string
+}
+
+// ConfigDiff returns every difference between two configurations, such as a
+// file before and after it is edited and reloaded, in the order the settings
+// are declared, with map entries by key. Secrets are redacted before
+// comparing, so the changes are safe to log; a changed secret doesn't show up.
+func ConfigDiff(before, after *Config) []ConfigChange {
+	changes := make([]ConfigChange, 0)
+	diffValues(&changes, "", reflect.ValueOf(*before.Redacted()), reflect.ValueOf(*after.Redacted()))
+	return changes
+}
+
+// diffValues appends the differences between two values of the same type at
+// path. Structs are compared field by field and maps entry by entry, as are
+// lists of named structs; any other values are compared whole.
+func diffValues(changes *[]ConfigChange, path string, before, after reflect.Value) {
+	switch before.Kind() {
+	case reflect.Struct:
+		for i := 0; i < before.NumField(); i++ {
+			name, inline := yamlName(before.Type().Field(i))
+			fieldPath := path
+			if !inline {
+				fieldPath = joinPath(path, name)
+			}
+			diffValues(changes, fieldPath, before.Field(i), after.Field(i))
+		}
+	case reflect.Map:
+		diffEntries(changes, path, mapEntries(before), mapEntries(after), func(key string) string {
+			return joinPath(path, key)
+		})
+	case reflect.Slice:
+		if beforeNamed, ok := namedEntries(before); ok {
+			afterNamed, _ := namedEntries(after)
+			diffEntries(changes, path, beforeNamed, afterNamed, func(name string) string {
+				return fmt.Sprintf("%s[%s]", path, name)
+			})
+			return
+		}
+		fallthrough
+	default:
+		// An empty list is the same whether or not it is nil
+		empty := before.Kind() == reflect.Slice && before.Len() == 0 && after.Len() == 0
+		if !empty && !reflect.DeepEqual(before.Interface(), after.Interface()) {
+			*changes = append(*changes, ConfigChange{Path: path, Change: ChangeModified, Old: format(before), New: format(after)})
+		}
+	}
+}
+
+// entries are the values of a map or named list by key, with the keys in order.
+type entries struct {
+	keys   []string
+	values map[string]reflect.Value
+}
+
+// diffEntries appends the entries added to, changed in and removed from a map
+// or named list, in the order of the entries after and then the removed ones.
+func diffEntries(changes *[]ConfigChange, path string, before, after entries, entryPath func(key string) string) {
+	for _, key := range after.keys {
+		beforeValue, exists := before.values[key]
+		if !exists {
+			*changes = append(*changes, ConfigChange{Path: entryPath(key), Change: ChangeAdded, New: format(after.values[key])})
+			continue
+		}
+		diffValues(changes, entryPath(key), beforeValue, after.values[key])
+	}
+	for _, key := range before.keys {
+		if _, exists := after.values[key]; !exists {
+			*changes = append(*changes, ConfigChange{Path: entryPath(key), Change: ChangeRemoved, Old: format(before.values[key])})
+		}
+	}
+}
+
+// mapEntries returns the entries of a map with string keys, sorted by key.
+func mapEntries(m reflect.Value) entries {
+	e := entries{keys: make([]string, 0, m.Len()), values: make(map[string]reflect.Value, m.Len())}
+	for _, key := range m.MapKeys() {
+		e.keys = append(e.keys, key.String())
+		e.values[key.String()] = m.MapIndex(key)
+	}
+	sort.Strings(e.keys)
+	return e
+}
+
+// namedEntries returns the entries of a list of structs with a Name field by
+// name, in list order. It reports false for any other list.
+func namedEntries(list reflect.Value) (entries, bool) {
+	element := list.Type().Elem()
+	if element.Kind() != reflect.Struct {
+		return entries{}, false
+	}
+	if field, ok := element.FieldByName("Name"); !ok || field.Type.Kind() != reflect.String {
+		return entries{}, false
+	}
+
+	e := entries{keys: make([]string, 0, list.Len()), values: make(map[string]reflect.Value, list.Len())}
+	for i := 0; i < list.Len(); i++ {
+		name := list.Index(i).FieldByName("Name").String()
+		e.keys = append(e.keys, name)
+		e.values[name] = list.Index(i)
+	}
+	return e, true
+}
+
+// yamlName returns the YAML key of a struct field and whether its entries are
+// inlined into the parent.
+func yamlName(field reflect.StructField) (string, bool) {
+	tag := field.Tag.Get("yaml")
+	name, options, _ := strings.Cut(tag, ",")
+	if name == "" {
+		name = strings.ToLower(field.Name)
+	}
+	return name, options == "inline"
+}
+
+// joinPath appends a key to a dotted path.
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// format prints a value for a change, with struct fields by name.
+func format(v reflect.Value) string {
+	if v.Kind() == reflect.Struct {
+		return fmt.Sprintf("%+v", v.Interface())
+	}
+	return fmt.Sprint(v.Interface())
+}
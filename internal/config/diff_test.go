@@ -0,0 +1,77 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// diffTestConfig returns a configuration with two sections and two stations.
+func diffTestConfig() *Config {
+	return &Config{
+		Sections: []SectionConfig{
+			{Name: "A", MaxSeats: 10},
+			{Name: "B", MaxSeats: 20, OverflowPolicy: OverflowSpill},
+		},
+		Stations: StationConfig{Prices: map[string]float64{
+			"London-France": 20,
+			"London-Paris":  30,
+		}},
+		Pricing: PricingConfig{Rounding: RoundingNearestCent},
+	}
+}
+
+func TestConfigDiffUnchanged(t *testing.T) {
+	assert.Empty(t, ConfigDiff(diffTestConfig(), diffTestConfig()))
+
+	// An empty list is the same as none
+	before, after := diffTestConfig(), diffTestConfig()
+	after.Route = []string{}
+	assert.Empty(t, ConfigDiff(before, after))
+}
+
+func TestConfigDiffStations(t *testing.T) {
+	before, after := diffTestConfig(), diffTestConfig()
+	after.Stations.Prices["London-France"] = 25
+	delete(after.Stations.Prices, "London-Paris")
+	after.Stations.Prices["London-Lyon"] = 45.5
+
+	assert.Equal(t, []ConfigChange{
+		{Path: "stations.London-France", Change: ChangeModified, Old: "20", New: "25"},
+		{Path: "stations.London-Lyon", Change: ChangeAdded, New: "45.5"},
+		{Path: "stations.London-Paris", Change: ChangeRemoved, Old: "30"},
+	}, ConfigDiff(before, after))
+}
+
+func TestConfigDiffSections(t *testing.T) {
+	before, after := diffTestConfig(), diffTestConfig()
+	after.Sections = []SectionConfig{
+		{Name: "B", MaxSeats: 25, OverflowPolicy: OverflowWaitlist},
+		{Name: "C", MaxSeats: 5},
+	}
+
+	changes := ConfigDiff(before, after)
+	assert.Equal(t, []ConfigChange{
+		{Path: "sections[B].max_seats", Change: ChangeModified, Old: "20", New: "25"},
+		{Path: "sections[B].overflow_policy", Change: ChangeModified, Old: OverflowSpill, New: OverflowWaitlist},
+		{Path: "sections[C]", Change: ChangeAdded, New: changes[2].New},
+		{Path: "sections[A]", Change: ChangeRemoved, Old: changes[3].Old},
+	}, changes, "Sections should be matched by name, not position")
+	assert.Contains(t, changes[2].New, "MaxSeats:5")
+	assert.Contains(t, changes[3].Old, "MaxSeats:10")
+}
+
+func TestConfigDiffSettings(t *testing.T) {
+	before, after := diffTestConfig(), diffTestConfig()
+	after.Pricing.BookingFee = 1.5
+	after.Route = []string{"London", "Paris"}
+	after.Seating.LoyaltySections = map[string][]string{"gold": {"A"}}
+	before.Notifications.SMTP.Password = "old-secret"
+	after.Notifications.SMTP.Password = "new-secret"
+
+	assert.Equal(t, []ConfigChange{
+		{Path: "route", Change: ChangeModified, Old: "[]", New: "[London Paris]"},
+		{Path: "pricing.booking_fee", Change: ChangeModified, Old: "0", New: "1.5"},
+		{Path: "seating.loyalty_sections.gold", Change: ChangeAdded, New: "[A]"},
+	}, ConfigDiff(before, after), "Secrets should not be compared")
+}
@@ -0,0 +1,184 @@
+package middleware
+
+import (
+	"context"
+	"path"
+	"slices"
+	"strings"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// APIKeyHeader is the metadata key clients use to authenticate.
+const APIKeyHeader = "x-api-key"
+
+// Principal is the identity behind an API key.
+type Principal struct {
+	// Email is the only user the key may act for, unless Admin is set.
+	Email string
+	// Admin keys may act for any user.
+	Admin bool
+}
+
+type principalKey struct{}
+
+// PrincipalFromContext returns the principal authenticated by APIKeyInterceptor.
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(Principal)
+	return principal, ok
+}
+
+// NormalizeEmail trims and lower-cases an email address so addresses that only
+// differ in case or surrounding spaces compare equal.
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// APIKeyInterceptor attaches the principal of the call's x-api-key header to
+// its context. Calls without the header or with an unknown key are rejected
+// with codes.Unauthenticated. Health checks are exempt.
+func APIKeyInterceptor(keys map[string]Principal) grpc.UnaryServerInterceptor {
+	principals := normalizePrincipals(keys)
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if strings.HasPrefix(info.FullMethod, healthServicePrefix) {
+			return handler(ctx, req)
+		}
+
+		ctx, err := authenticate(ctx, principals)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// APIKeyStreamInterceptor is APIKeyInterceptor for streaming calls such as
+// WatchAvailability.
+func APIKeyStreamInterceptor(keys map[string]Principal) grpc.StreamServerInterceptor {
+	principals := normalizePrincipals(keys)
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if strings.HasPrefix(info.FullMethod, healthServicePrefix) {
+			return handler(srv, stream)
+		}
+
+		ctx, err := authenticate(stream.Context(), principals)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &principalStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+// normalizePrincipals returns the principals of keys with normalized emails.
+func normalizePrincipals(keys map[string]Principal) map[string]Principal {
+	principals := make(map[string]Principal, len(keys))
+	for key, principal := range keys {
+		principal.Email = NormalizeEmail(principal.Email)
+		principals[key] = principal
+	}
+	return principals
+}
+
+// authenticate returns ctx carrying the principal of its x-api-key header, or
+// a codes.Unauthenticated error for missing and unknown keys.
+func authenticate(ctx context.Context, principals map[string]Principal) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(APIKeyHeader)
+	if len(values) == 0 {
+		return nil, status.Errorf(codes.Unauthenticated, "missing %s header", APIKeyHeader)
+	}
+
+	principal, ok := principals[values[0]]
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid %s header", APIKeyHeader)
+	}
+	return context.WithValue(ctx, principalKey{}, principal), nil
+}
+
+// principalStream is a server stream whose context carries the authenticated
+// principal.
+type principalStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context returns the stream's context with the principal attached.
+func (s *principalStream) Context() context.Context {
+	return s.ctx
+}
+
+// Requests made on behalf of users carry their emails in one or more of these
+// fields, e.g. PurchaseTicketRequest.User and Companion or SwapSeatsRequest.EmailA
+// and EmailB.
+type (
+	userRequest      interface{ GetUser() *pb.User }
+	companionRequest interface{ GetCompanion() *pb.User }
+	emailRequest     interface{ GetEmail() string }
+	swapRequest      interface {
+		GetEmailA() string
+		GetEmailB() string
+	}
+)
+
+// requestEmails returns every email a request acts for, skipping empty ones.
+func requestEmails(req interface{}) []string {
+	var emails []string
+	if request, ok := req.(userRequest); ok {
+		emails = append(emails, request.GetUser().GetEmail())
+	}
+	if request, ok := req.(companionRequest); ok {
+		emails = append(emails, request.GetCompanion().GetEmail())
+	}
+	if request, ok := req.(emailRequest); ok {
+		emails = append(emails, request.GetEmail())
+	}
+	if request, ok := req.(swapRequest); ok {
+		emails = append(emails, request.GetEmailA(), request.GetEmailB())
+	}
+	return slices.DeleteFunc(emails, func(email string) bool { return email == "" })
+}
+
+// EmailOwnershipInterceptor rejects calls with codes.PermissionDenied when any
+// email in the request, such as User.Email, Companion.Email, Email or the
+// EmailA and EmailB of a swap, is not the authenticated principal's, so one
+// user cannot book, cancel or look up tickets as another. Swaps therefore need
+// an admin key. Emails are compared after NormalizeEmail. Admin principals and
+// requests without an email are let through.
+func EmailOwnershipInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		principal, ok := PrincipalFromContext(ctx)
+		if ok && principal.Admin {
+			return handler(ctx, req)
+		}
+
+		for _, email := range requestEmails(req) {
+			if !ok || NormalizeEmail(email) != principal.Email {
+				return nil, status.Error(codes.PermissionDenied, "request user does not match the authenticated user")
+			}
+		}
+		return handler(ctx, req)
+	}
+}
+
+// AdminOnlyInterceptor rejects calls to the named methods (e.g. "GetConfig")
+// with codes.PermissionDenied unless APIKeyInterceptor authenticated an admin
+// key, so they stay closed when no API keys are configured.
+func AdminOnlyInterceptor(adminMethods ...string) grpc.UnaryServerInterceptor {
+	admin := make(map[string]bool, len(adminMethods))
+	for _, method := range adminMethods {
+		admin[method] = true
+	}
+
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if admin[path.Base(info.FullMethod)] {
+			if principal, ok := PrincipalFromContext(ctx); !ok || !principal.Admin {
+				return nil, status.Error(codes.PermissionDenied, "method requires an admin API key")
+			}
+		}
+		return handler(ctx, req)
+	}
+}
@@ -0,0 +1,154 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+)
+
+// authChain runs a call through APIKeyInterceptor and EmailOwnershipInterceptor
+// as the server chains them.
+func authChain(ctx context.Context, req interface{}) (interface{}, error) {
+	authenticate := APIKeyInterceptor(map[string]Principal{
+		"user-key":  {Email: " Test@Example.com"},
+		"admin-key": {Admin: true},
+	})
+	ownership := EmailOwnershipInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: purchaseMethod}
+
+	return authenticate(ctx, req, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return ownership(ctx, req, info, okHandler)
+	})
+}
+
+func withAPIKey(key string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(APIKeyHeader, key))
+}
+
+func purchaseFor(email string) *pb.PurchaseTicketRequest {
+	return &pb.PurchaseTicketRequest{
+		User: &pb.User{FirstName: "Sanjay", LastName: "Kishor", Email: email},
+		From: "London",
+		To:   "France",
+	}
+}
+
+func TestEmailOwnershipMatching(t *testing.T) {
+	resp, err := authChain(withAPIKey("user-key"), purchaseFor("test@example.com"))
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", resp)
+
+	_, err = authChain(withAPIKey("user-key"), purchaseFor(" TEST@example.COM "))
+	assert.NoError(t, err, "Emails should match regardless of case and spacing")
+}
+
+func TestEmailOwnershipMismatch(t *testing.T) {
+	_, err := authChain(withAPIKey("user-key"), purchaseFor("other@example.com"))
+	assert.Equal(t, codes.PermissionDenied, status.Code(err), "Booking as another user should be denied")
+
+	_, err = authChain(withAPIKey("stolen-key"), purchaseFor("test@example.com"))
+	assert.Equal(t, codes.Unauthenticated, status.Code(err), "Unknown keys should be rejected")
+}
+
+func TestEmailOwnershipAdminOverride(t *testing.T) {
+	resp, err := authChain(withAPIKey("admin-key"), purchaseFor("other@example.com"))
+	assert.NoError(t, err, "Admin keys should act for any user")
+	assert.Equal(t, "ok", resp)
+}
+
+func TestEmailOwnershipWithoutPrincipal(t *testing.T) {
+	_, err := authChain(context.Background(), purchaseFor("other@example.com"))
+	assert.Equal(t, codes.Unauthenticated, status.Code(err), "Calls without an API key should be rejected")
+
+	// Without APIKeyInterceptor there is no principal to own any email
+	ownership := EmailOwnershipInterceptor()
+	_, err = ownership(context.Background(), purchaseFor("other@example.com"), &grpc.UnaryServerInfo{FullMethod: purchaseMethod}, okHandler)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestEmailOwnershipEveryEmail(t *testing.T) {
+	companion := purchaseFor("test@example.com")
+	companion.Companion = &pb.User{FirstName: "Priya", LastName: "Kishor", Email: "other@example.com"}
+
+	for _, req := range []interface{}{
+		companion,
+		&pb.GetReceiptRequest{Email: "other@example.com"},
+		&pb.RemoveUserRequest{Email: "other@example.com"},
+		&pb.UpdateUserSeatRequest{Email: "other@example.com"},
+		&pb.HoldSeatRequest{Email: "other@example.com"},
+		&pb.SwapSeatsRequest{EmailA: "test@example.com", EmailB: "other@example.com"},
+	} {
+		_, err := authChain(withAPIKey("user-key"), req)
+		assert.Equal(t, codes.PermissionDenied, status.Code(err), "%T for another user should be denied", req)
+	}
+
+	_, err := authChain(withAPIKey("user-key"), &pb.RemoveUserRequest{Email: "Test@Example.com"})
+	assert.NoError(t, err, "Requests for the key's own email should be served")
+	_, err = authChain(withAPIKey("user-key"), &pb.GetSectionVacancyRequest{Section: "A"})
+	assert.NoError(t, err, "Requests without an email are not checked")
+	_, err = authChain(withAPIKey("admin-key"), &pb.SwapSeatsRequest{EmailA: "a@example.com", EmailB: "b@example.com"})
+	assert.NoError(t, err, "Admin keys should act for any user")
+}
+
+func TestAdminOnlyInterceptor(t *testing.T) {
+	authenticate := APIKeyInterceptor(map[string]Principal{
+		"user-key":  {Email: "test@example.com"},
+		"admin-key": {Admin: true},
+	})
+	adminOnly := AdminOnlyInterceptor("GetConfig")
+	call := func(ctx context.Context, method string) error {
+		info := &grpc.UnaryServerInfo{FullMethod: method}
+		_, err := authenticate(ctx, nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return adminOnly(ctx, req, info, okHandler)
+		})
+		return err
+	}
+	getConfig := "/ticketBooking.TicketBookingService/GetConfig"
+
+	assert.NoError(t, call(withAPIKey("admin-key"), getConfig))
+	assert.Equal(t, codes.PermissionDenied, status.Code(call(withAPIKey("user-key"), getConfig)), "User keys should be denied")
+	assert.Equal(t, codes.Unauthenticated, status.Code(call(context.Background(), getConfig)), "Calls without a key should be rejected")
+	assert.NoError(t, call(withAPIKey("user-key"), purchaseMethod), "Other methods should not need an admin key")
+}
+
+// contextStream is a server stream with only a context, for stream interceptors.
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestAPIKeyStreamInterceptor(t *testing.T) {
+	authenticate := APIKeyStreamInterceptor(map[string]Principal{"user-key": {Email: " Test@Example.com"}})
+	info := &grpc.StreamServerInfo{FullMethod: "/ticketBooking.TicketBookingService/WatchAvailability", IsServerStream: true}
+	call := func(ctx context.Context) (Principal, bool, error) {
+		var principal Principal
+		var ok bool
+		err := authenticate(nil, &contextStream{ctx: ctx}, info, func(srv interface{}, stream grpc.ServerStream) error {
+			principal, ok = PrincipalFromContext(stream.Context())
+			return nil
+		})
+		return principal, ok, err
+	}
+
+	principal, ok, err := call(withAPIKey("user-key"))
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, Principal{Email: "test@example.com"}, principal, "The stream should carry the normalized principal")
+
+	_, _, err = call(context.Background())
+	assert.Equal(t, codes.Unauthenticated, status.Code(err), "Streams without a key should be rejected")
+
+	_, _, err = call(withAPIKey("stolen-key"))
+	assert.Equal(t, codes.Unauthenticated, status.Code(err), "Unknown keys should be rejected")
+}
@@ -0,0 +1,194 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// ClientVersionHeader is the metadata key clients use to report their version.
+const ClientVersionHeader = "x-client-version"
+
+// healthServicePrefix identifies health check calls, which are exempt from
+// client checks so probes keep working.
+const healthServicePrefix = "/grpc.health.v1.Health/"
+
+// semver is a parsed semantic version.
+type semver struct {
+	major, minor, patch int
+	prerelease          string
+}
+
+// parseSemver parses versions of the form [v]MAJOR.MINOR.PATCH[-PRERELEASE][+BUILD].
+func parseSemver(version string) (semver, error) {
+	v := strings.TrimPrefix(strings.TrimSpace(version), "v")
+	if i := strings.IndexByte(v, '+'); i >= 0 {
+		v = v[:i]
+	}
+
+	var parsed semver
+	if i := strings.IndexByte(v, '-'); i >= 0 {
+		parsed.prerelease = v[i+1:]
+		if parsed.prerelease == "" {
+			return semver{}, fmt.Errorf("invalid version %q: empty prerelease", version)
+		}
+		v = v[:i]
+	}
+
+	parts := strings.Split(v, ".")
+	if len(parts) != 3 {
+		return semver{}, fmt.Errorf("invalid version %q: expected MAJOR.MINOR.PATCH", version)
+	}
+
+	numbers := make([]int, 3)
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return semver{}, fmt.Errorf("invalid version %q: %q is not a number", version, part)
+		}
+		numbers[i] = n
+	}
+	parsed.major, parsed.minor, parsed.patch = numbers[0], numbers[1], numbers[2]
+
+	return parsed, nil
+}
+
+// compare returns -1, 0 or 1 depending on whether v is lower than, equal to or
+// higher than other. A prerelease sorts before its release.
+func (v semver) compare(other semver) int {
+	for _, pair := range [][2]int{{v.major, other.major}, {v.minor, other.minor}, {v.patch, other.patch}} {
+		if c := compareInts(pair[0], pair[1]); c != 0 {
+			return c
+		}
+	}
+
+	switch {
+	case v.prerelease == other.prerelease:
+		return 0
+	case v.prerelease == "":
+		return 1
+	case other.prerelease == "":
+		return -1
+	default:
+		return comparePrerelease(v.prerelease, other.prerelease)
+	}
+}
+
+// comparePrerelease orders two prereleases as SemVer §11 does: dot separated
+// identifiers are compared in turn, numeric ones numerically and below
+// alphanumeric ones, and a longer prerelease wins when the shared identifiers
+// are equal.
+func comparePrerelease(a, b string) int {
+	left, right := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(left) && i < len(right); i++ {
+		l, lNumeric := numericIdentifier(left[i])
+		r, rNumeric := numericIdentifier(right[i])
+		switch {
+		case lNumeric && rNumeric:
+			if c := compareInts(l, r); c != 0 {
+				return c
+			}
+		case lNumeric:
+			return -1
+		case rNumeric:
+			return 1
+		default:
+			if c := strings.Compare(left[i], right[i]); c != 0 {
+				return c
+			}
+		}
+	}
+	return compareInts(len(left), len(right))
+}
+
+// numericIdentifier returns the value of a prerelease identifier made only of
+// digits, and whether it is one.
+func numericIdentifier(identifier string) (int, bool) {
+	if identifier == "" || strings.Trim(identifier, "0123456789") != "" {
+		return 0, false
+	}
+	n, err := strconv.Atoi(identifier)
+	return n, err == nil
+}
+
+// compareInts returns -1, 0 or 1 depending on whether a is lower than, equal
+// to or higher than b.
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// ClientVersionInterceptor rejects calls whose x-client-version header is missing,
+// malformed or below minVersion with codes.FailedPrecondition. Health checks are exempt.
+func ClientVersionInterceptor(minVersion string) (grpc.UnaryServerInterceptor, error) {
+	check, err := clientVersionCheck(minVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := check(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}, nil
+}
+
+// ClientVersionStreamInterceptor is ClientVersionInterceptor for streaming
+// calls such as WatchAvailability.
+func ClientVersionStreamInterceptor(minVersion string) (grpc.StreamServerInterceptor, error) {
+	check, err := clientVersionCheck(minVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := check(stream.Context(), info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, stream)
+	}, nil
+}
+
+// clientVersionCheck returns a function rejecting calls from clients below
+// minVersion.
+func clientVersionCheck(minVersion string) (func(ctx context.Context, fullMethod string) error, error) {
+	min, err := parseSemver(minVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context, fullMethod string) error {
+		if strings.HasPrefix(fullMethod, healthServicePrefix) {
+			return nil
+		}
+
+		md, _ := metadata.FromIncomingContext(ctx)
+		values := md.Get(ClientVersionHeader)
+		if len(values) == 0 {
+			return status.Errorf(codes.FailedPrecondition, "missing %s header, minimum supported client version is %s", ClientVersionHeader, minVersion)
+		}
+
+		version, err := parseSemver(values[0])
+		if err != nil {
+			return status.Errorf(codes.FailedPrecondition, "malformed %s header: %v", ClientVersionHeader, err)
+		}
+
+		if version.compare(min) < 0 {
+			return status.Errorf(codes.FailedPrecondition, "client version %s is below the minimum supported version %s", values[0], minVersion)
+		}
+		return nil
+	}, nil
+}
@@ -0,0 +1,82 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestParseSemver(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"1.2.3", "1.2.3", 0},
+		{"v1.2.3", "1.2.3", 0},
+		{"1.2.3+build.5", "1.2.3", 0},
+		{"1.2.3", "1.2.4", -1},
+		{"1.10.0", "1.9.0", 1},
+		{"2.0.0", "1.99.99", 1},
+		{"1.2.3-rc.1", "1.2.3", -1},
+		{"1.2.3-alpha", "1.2.3-beta", -1},
+		{"1.2.3-rc.10", "1.2.3-rc.2", 1},
+		{"1.2.3-rc.1", "1.2.3-rc.1", 0},
+		{"1.2.3-1", "1.2.3-alpha", -1},
+		{"1.2.3-alpha", "1.2.3-alpha.1", -1},
+		{"1.2.3-alpha.beta", "1.2.3-alpha.1", 1},
+	}
+
+	for _, test := range tests {
+		a, err := parseSemver(test.a)
+		assert.NoError(t, err)
+		b, err := parseSemver(test.b)
+		assert.NoError(t, err)
+		assert.Equal(t, test.expected, a.compare(b), "%s vs %s", test.a, test.b)
+	}
+
+	for _, invalid := range []string{"", "1.2", "1.2.x", "1.2.3-", "a.b.c"} {
+		_, err := parseSemver(invalid)
+		assert.Error(t, err, "%q should not parse", invalid)
+	}
+}
+
+func TestClientVersionInterceptor(t *testing.T) {
+	interceptor, err := ClientVersionInterceptor("1.2.0")
+	assert.NoError(t, err)
+	info := &grpc.UnaryServerInfo{FullMethod: purchaseMethod}
+
+	withVersion := func(version string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs(ClientVersionHeader, version))
+	}
+
+	tests := []struct {
+		name         string
+		ctx          context.Context
+		expectedCode codes.Code
+	}{
+		{"Below Minimum", withVersion("1.1.9"), codes.FailedPrecondition},
+		{"At Minimum", withVersion("1.2.0"), codes.OK},
+		{"Above Minimum", withVersion("v2.0.0"), codes.OK},
+		{"Malformed Header", withVersion("latest"), codes.FailedPrecondition},
+		{"Missing Header", context.Background(), codes.FailedPrecondition},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := interceptor(test.ctx, nil, info, okHandler)
+			assert.Equal(t, test.expectedCode, status.Code(err))
+		})
+	}
+
+	// Health checks are exempt
+	_, err = interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/grpc.health.v1.Health/Check"}, okHandler)
+	assert.NoError(t, err, "Health checks should not require a client version")
+
+	_, err = ClientVersionInterceptor("not-a-version")
+	assert.Error(t, err, "An invalid minimum version should be rejected")
+}
@@ -0,0 +1,20 @@
+package middleware
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding/gzip"
+)
+
+// CompressionInterceptor compresses every response with gzip when the client
+// advertises support for it. Clients can also opt in per call with
+// grpc.UseCompressor(gzip.Name), which works even without this interceptor
+// since importing this package registers the gzip codec.
+func CompressionInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		// Clients that don't accept gzip keep receiving uncompressed responses
+		_ = grpc.SetSendCompressor(ctx, gzip.Name)
+		return handler(ctx, req)
+	}
+}
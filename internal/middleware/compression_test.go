@@ -0,0 +1,102 @@
+package middleware_test
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/encoding/gzip"
+	"google.golang.org/grpc/stats"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/sanjaykishor/rail-connect/internal/config"
+	"github.com/sanjaykishor/rail-connect/internal/middleware"
+	"github.com/sanjaykishor/rail-connect/internal/service"
+	pb "github.com/sanjaykishor/rail-connect/proto"
+)
+
+// payloadRecorder records the sizes of payloads received by the client.
+type payloadRecorder struct {
+	length, compressedLength int
+}
+
+func (r *payloadRecorder) TagRPC(ctx context.Context, _ *stats.RPCTagInfo) context.Context {
+	return ctx
+}
+func (r *payloadRecorder) HandleRPC(_ context.Context, s stats.RPCStats) {
+	if in, ok := s.(*stats.InPayload); ok {
+		r.length, r.compressedLength = in.Length, in.CompressedLength
+	}
+}
+func (r *payloadRecorder) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+func (r *payloadRecorder) HandleConn(context.Context, stats.ConnStats) {}
+
+// startCompressionServer starts the ticket service on an in-memory listener with
+// the given interceptors and returns a client connected to it.
+func startCompressionServer(t *testing.T, interceptors ...grpc.UnaryServerInterceptor) (pb.TicketBookingServiceClient, *payloadRecorder) {
+	t.Helper()
+
+	logger := zap.NewNop()
+	seatManager := service.NewSeatManager([]config.SectionConfig{{Name: "A", MaxSeats: 500}}, logger)
+	ticketService := service.NewTicketManager(seatManager, map[string]float64{"London-France": 20.00}, logger)
+
+	// Fill the section so the response is large
+	for i := 0; i < 500; i++ {
+		_, err := ticketService.PurchaseTicket(context.Background(), &pb.PurchaseTicketRequest{
+			User: &pb.User{FirstName: "Sanjay", LastName: "Kishor", Email: fmt.Sprintf("test%d@example.com", i)},
+			From: "London",
+			To:   "France",
+		})
+		assert.NoError(t, err)
+	}
+
+	recorder := &payloadRecorder{}
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer(grpc.ChainUnaryInterceptor(interceptors...))
+	pb.RegisterTicketBookingServiceServer(server, ticketService)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(recorder),
+	)
+	assert.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return pb.NewTicketBookingServiceClient(conn), recorder
+}
+
+func TestCompressionPerCall(t *testing.T) {
+	client, recorder := startCompressionServer(t)
+
+	// Without opting in, the response is sent uncompressed
+	_, err := client.GetUsersBySection(context.Background(), &pb.GetUsersBySectionRequest{Section: "A"})
+	assert.NoError(t, err)
+	assert.Equal(t, recorder.length, recorder.compressedLength, "Response should not be compressed by default")
+
+	response, err := client.GetUsersBySection(context.Background(), &pb.GetUsersBySectionRequest{Section: "A"},
+		grpc.UseCompressor(gzip.Name))
+	assert.NoError(t, err)
+	assert.Len(t, response.Users, 500, "Large response should round-trip intact")
+	assert.Less(t, recorder.compressedLength, recorder.length, "Response should be gzip compressed")
+}
+
+func TestCompressionInterceptor(t *testing.T) {
+	client, recorder := startCompressionServer(t, middleware.CompressionInterceptor())
+
+	response, err := client.GetUsersBySection(context.Background(), &pb.GetUsersBySectionRequest{Section: "A"})
+	assert.NoError(t, err)
+	assert.Len(t, response.Users, 500, "Large response should round-trip intact")
+	assert.Less(t, recorder.compressedLength, recorder.length, "Response should be compressed by default")
+}
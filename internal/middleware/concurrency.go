@@ -0,0 +1,105 @@
+package middleware
+
+import (
+	"context"
+	"path"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ConcurrencyLimitInterceptor admits at most max concurrent calls. When methods are
+// given, each named method (e.g. "PurchaseTicket") gets its own limit and all other
+// methods pass through; otherwise a single limit is shared by every method.
+// A call over the limit waits up to wait for a slot before being rejected with
+// codes.ResourceExhausted.
+func ConcurrencyLimitInterceptor(max int, wait time.Duration, methods ...string) grpc.UnaryServerInterceptor {
+	slots := newConcurrencySlots(max, methods)
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		release, err := slots.take(ctx, info.FullMethod, wait)
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+
+		return handler(ctx, req)
+	}
+}
+
+// ConcurrencyLimitStreamInterceptor is ConcurrencyLimitInterceptor for
+// streaming calls such as WatchAvailability. A stream holds its slot until it
+// ends, so streams are counted apart from unary calls and can never starve them.
+func ConcurrencyLimitStreamInterceptor(max int, wait time.Duration, methods ...string) grpc.StreamServerInterceptor {
+	slots := newConcurrencySlots(max, methods)
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		release, err := slots.take(stream.Context(), info.FullMethod, wait)
+		if err != nil {
+			return err
+		}
+		defer release()
+
+		return handler(srv, stream)
+	}
+}
+
+// concurrencySlots are the semaphores of a concurrency limit: one shared by
+// every method, or one per named method.
+type concurrencySlots struct {
+	shared    chan struct{}
+	perMethod map[string]chan struct{}
+}
+
+func newConcurrencySlots(max int, methods []string) *concurrencySlots {
+	slots := &concurrencySlots{
+		shared:    make(chan struct{}, max),
+		perMethod: make(map[string]chan struct{}, len(methods)),
+	}
+	for _, method := range methods {
+		slots.perMethod[method] = make(chan struct{}, max)
+	}
+	return slots
+}
+
+// take takes a slot for the method, returning the function that gives it
+// back, or a codes.ResourceExhausted error if none frees up within wait.
+func (s *concurrencySlots) take(ctx context.Context, fullMethod string, wait time.Duration) (func(), error) {
+	sem := s.shared
+	if len(s.perMethod) > 0 {
+		var limited bool
+		if sem, limited = s.perMethod[path.Base(fullMethod)]; !limited {
+			return func() {}, nil
+		}
+	}
+
+	if !acquire(ctx, sem, wait) {
+		return nil, status.Errorf(codes.ResourceExhausted, "too many concurrent requests for %s", fullMethod)
+	}
+	return func() { <-sem }, nil
+}
+
+// acquire takes a slot from sem, waiting up to wait for one to free up.
+func acquire(ctx context.Context, sem chan struct{}, wait time.Duration) bool {
+	select {
+	case sem <- struct{}{}:
+		return true
+	default:
+	}
+
+	if wait <= 0 {
+		return false
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case sem <- struct{}{}:
+		return true
+	case <-timer.C:
+		return false
+	case <-ctx.Done():
+		return false
+	}
+}
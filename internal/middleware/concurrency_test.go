@@ -0,0 +1,121 @@
+package middleware
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const purchaseMethod = "/ticketBooking.TicketBookingService/PurchaseTicket"
+
+// blockingHandler returns a handler that signals when it starts and blocks until released.
+func blockingHandler(started *sync.WaitGroup, release chan struct{}) grpc.UnaryHandler {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		started.Done()
+		<-release
+		return "ok", nil
+	}
+}
+
+func TestConcurrencyLimitInterceptor(t *testing.T) {
+	const max = 3
+	interceptor := ConcurrencyLimitInterceptor(max, 0)
+	info := &grpc.UnaryServerInfo{FullMethod: purchaseMethod}
+
+	var started sync.WaitGroup
+	release := make(chan struct{})
+	started.Add(max)
+
+	var done sync.WaitGroup
+	for i := 0; i < max; i++ {
+		done.Add(1)
+		go func() {
+			defer done.Done()
+			_, err := interceptor(context.Background(), nil, info, blockingHandler(&started, release))
+			assert.NoError(t, err)
+		}()
+	}
+	started.Wait()
+
+	// The N+1th call is rejected while N are in flight
+	_, err := interceptor(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	})
+	st, _ := status.FromError(err)
+	assert.Equal(t, codes.ResourceExhausted, st.Code(), "Call over the limit should be rejected")
+
+	close(release)
+	done.Wait()
+
+	// Slots are returned once the in-flight calls finish
+	resp, err := interceptor(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", resp)
+}
+
+func TestConcurrencyLimitInterceptorWaits(t *testing.T) {
+	interceptor := ConcurrencyLimitInterceptor(1, time.Second)
+	info := &grpc.UnaryServerInfo{FullMethod: purchaseMethod}
+
+	var started sync.WaitGroup
+	release := make(chan struct{})
+	started.Add(1)
+	go interceptor(context.Background(), nil, info, blockingHandler(&started, release))
+	started.Wait()
+
+	// Free the slot shortly after the second call starts waiting
+	time.AfterFunc(20*time.Millisecond, func() { close(release) })
+
+	resp, err := interceptor(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	})
+	assert.NoError(t, err, "Call should be admitted once a slot frees up within the wait")
+	assert.Equal(t, "ok", resp)
+}
+
+func TestConcurrencyLimitInterceptorPerMethod(t *testing.T) {
+	interceptor := ConcurrencyLimitInterceptor(1, 0, "PurchaseTicket")
+
+	var started sync.WaitGroup
+	release := make(chan struct{})
+	defer close(release)
+	started.Add(1)
+	go interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: purchaseMethod}, blockingHandler(&started, release))
+	started.Wait()
+
+	// Methods that are not listed are not limited
+	_, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/ticketBooking.TicketBookingService/GetReceipt"}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	})
+	assert.NoError(t, err)
+
+	// The listed method is limited
+	_, err = interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: purchaseMethod}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	})
+	st, _ := status.FromError(err)
+	assert.Equal(t, codes.ResourceExhausted, st.Code())
+}
+
+func TestConcurrencyLimitStreamInterceptor(t *testing.T) {
+	interceptor := ConcurrencyLimitStreamInterceptor(1, 0, "WatchAvailability")
+	info := &grpc.StreamServerInfo{FullMethod: "/ticketBooking.TicketBookingService/WatchAvailability", IsServerStream: true}
+	stream := &contextStream{ctx: context.Background()}
+
+	// A second stream is rejected while the first is still open
+	err := interceptor(nil, stream, info, func(srv interface{}, stream grpc.ServerStream) error {
+		return interceptor(nil, stream, info, func(srv interface{}, stream grpc.ServerStream) error { return nil })
+	})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	// The slot is given back when the stream ends
+	assert.NoError(t, interceptor(nil, stream, info, func(srv interface{}, stream grpc.ServerStream) error { return nil }))
+}
@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"context"
+	"path"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Features switches methods on or off by name (e.g. "GetSeat"), so new RPCs
+// can be dark-launched per deployment. Methods not listed are enabled.
+type Features map[string]bool
+
+// Enabled reports whether the named method is switched on.
+func (f Features) Enabled(method string) bool {
+	enabled, listed := f[method]
+	return !listed || enabled
+}
+
+// FeatureFlagInterceptor rejects calls to methods switched off in features with
+// codes.Unimplemented, as if the server had no such method.
+func FeatureFlagInterceptor(features Features) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := features.check(info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// FeatureFlagStreamInterceptor is FeatureFlagInterceptor for streaming calls
+// such as WatchAvailability.
+func FeatureFlagStreamInterceptor(features Features) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := features.check(info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, stream)
+	}
+}
+
+// check returns a codes.Unimplemented error if the method is switched off.
+func (f Features) check(fullMethod string) error {
+	if method := path.Base(fullMethod); !f.Enabled(method) {
+		return status.Errorf(codes.Unimplemented, "method %s is not enabled on this server", method)
+	}
+	return nil
+}
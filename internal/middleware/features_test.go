@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestFeaturesEnabled(t *testing.T) {
+	features := Features{"GetSeat": false, "Verify": true}
+
+	assert.False(t, features.Enabled("GetSeat"), "Switched off methods should be disabled")
+	assert.True(t, features.Enabled("Verify"), "Switched on methods should be enabled")
+	assert.True(t, features.Enabled("PurchaseTicket"), "Unlisted methods should be enabled")
+	assert.True(t, Features(nil).Enabled("PurchaseTicket"), "No flags should enable every method")
+}
+
+func TestFeatureFlagInterceptor(t *testing.T) {
+	interceptor := FeatureFlagInterceptor(Features{"GetSeat": false, "PurchaseTicket": true})
+
+	_, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/ticketBooking.TicketBookingService/GetSeat"}, okHandler)
+	assert.Equal(t, codes.Unimplemented, status.Code(err), "Disabled methods should be unimplemented")
+
+	resp, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: purchaseMethod}, okHandler)
+	assert.NoError(t, err, "Enabled methods should be served")
+	assert.Equal(t, "ok", resp)
+
+	resp, err = interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/ticketBooking.TicketBookingService/GetReceipt"}, okHandler)
+	assert.NoError(t, err, "Unlisted methods should be served")
+	assert.Equal(t, "ok", resp)
+}
+
+func TestFeatureFlagStreamInterceptor(t *testing.T) {
+	interceptor := FeatureFlagStreamInterceptor(Features{"WatchAvailability": false})
+	info := &grpc.StreamServerInfo{FullMethod: "/ticketBooking.TicketBookingService/WatchAvailability", IsServerStream: true}
+	ok := func(srv interface{}, stream grpc.ServerStream) error { return nil }
+
+	err := interceptor(nil, &contextStream{ctx: context.Background()}, info, ok)
+	assert.Equal(t, codes.Unimplemented, status.Code(err), "Disabled streams should be unimplemented")
+
+	err = FeatureFlagStreamInterceptor(nil)(nil, &contextStream{ctx: context.Background()}, info, ok)
+	assert.NoError(t, err, "Unlisted streams should be served")
+}
@@ -0,0 +1,135 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"path"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+// HotRequestInterceptor warns when identical requests to a method arrive at a
+// high rate, which points at a client stuck retrying. A request is counted
+// against its method and a hash of its content; reaching threshold within
+// window logs a single warning for that window, however many more arrive.
+// Only every sampleEvery-th request is examined, and counts are scaled up to
+// match, so the check stays cheap under load. Requests are never rejected.
+// Warnings carry the method and request hash only, never the request itself,
+// so riders' details stay out of the logs.
+func HotRequestInterceptor(logger *zap.Logger, threshold int, window time.Duration, sampleEvery int) grpc.UnaryServerInterceptor {
+	return newHotRequests(logger, threshold, window, sampleEvery, time.Now).intercept
+}
+
+// hotRequests counts identical requests in fixed windows.
+type hotRequests struct {
+	logger      *zap.Logger
+	threshold   int
+	window      time.Duration
+	sampleEvery int
+	now         func() time.Time
+
+	seen atomic.Uint64 // Requests seen, for sampling
+
+	mu        sync.Mutex
+	counters  map[hotKey]*hotCounter
+	lastSweep time.Time
+}
+
+// hotKey identifies identical requests to a method.
+type hotKey struct {
+	method string
+	hash   uint64
+}
+
+// hotCounter counts the requests for a hotKey in the window starting at start.
+type hotCounter struct {
+	start  time.Time
+	count  int
+	warned bool
+}
+
+func newHotRequests(logger *zap.Logger, threshold int, window time.Duration, sampleEvery int, now func() time.Time) *hotRequests {
+	if sampleEvery < 1 {
+		sampleEvery = 1
+	}
+	return &hotRequests{
+		logger:      logger,
+		threshold:   threshold,
+		window:      window,
+		sampleEvery: sampleEvery,
+		now:         now,
+		counters:    make(map[hotKey]*hotCounter),
+		lastSweep:   now(),
+	}
+}
+
+func (h *hotRequests) intercept(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if h.seen.Add(1)%uint64(h.sampleEvery) == 0 {
+		h.observe(path.Base(info.FullMethod), req)
+	}
+	return handler(ctx, req)
+}
+
+// observe counts a sampled request and warns the first time its count reaches
+// the threshold in the current window.
+func (h *hotRequests) observe(method string, req interface{}) {
+	key := hotKey{method: method, hash: requestHash(req)}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	now := h.now()
+	h.sweep(now)
+
+	counter, exists := h.counters[key]
+	if !exists || now.Sub(counter.start) >= h.window {
+		counter = &hotCounter{start: now}
+		h.counters[key] = counter
+	}
+	counter.count += h.sampleEvery
+	if counter.count < h.threshold || counter.warned {
+		return
+	}
+	counter.warned = true
+
+	h.logger.Warn("Hot request pattern detected",
+		zap.String("method", method),
+		zap.String("request_hash", fmt.Sprintf("%016x", key.hash)),
+		zap.Int("count", counter.count),
+		zap.Duration("window", h.window),
+		zap.Int("sample_every", h.sampleEvery))
+}
+
+// sweep drops the counters of past windows, at most once per window, so keys
+// seen once don't accumulate. Callers must hold h.mu.
+func (h *hotRequests) sweep(now time.Time) {
+	if now.Sub(h.lastSweep) < h.window {
+		return
+	}
+	for key, counter := range h.counters {
+		if now.Sub(counter.start) >= h.window {
+			delete(h.counters, key)
+		}
+	}
+	h.lastSweep = now
+}
+
+// requestHash hashes the request content, so identical requests share a hash.
+func requestHash(req interface{}) uint64 {
+	hash := fnv.New64a()
+	if message, ok := req.(proto.Message); ok {
+		data, err := proto.MarshalOptions{Deterministic: true}.Marshal(message)
+		if err == nil {
+			hash.Write(data)
+			return hash.Sum64()
+		}
+	}
+	fmt.Fprintf(hash, "%v", req)
+	return hash.Sum64()
+}
@@ -0,0 +1,99 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"google.golang.org/grpc"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+)
+
+// fakeClock is a settable clock for windowed counters.
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func TestHotRequestBurst(t *testing.T) {
+	core, logs := observer.New(zapcore.WarnLevel)
+	clock := &fakeClock{now: time.Now()}
+	interceptor := newHotRequests(zap.New(core), 5, time.Minute, 1, clock.Now).intercept
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/ticketBooking.TicketBookingService/GetReceipt"}
+	hot := &pb.GetReceiptRequest{Email: "stuck@example.com"}
+	for i := 0; i < 20; i++ {
+		resp, err := interceptor(context.Background(), hot, info, okHandler)
+		assert.NoError(t, err, "Hot requests should still be served")
+		assert.Equal(t, "ok", resp)
+		clock.now = clock.now.Add(time.Second)
+	}
+
+	// Distinct requests at the same rate are not a hot pattern
+	for _, email := range []string{"a@example.com", "b@example.com", "c@example.com", "d@example.com"} {
+		_, err := interceptor(context.Background(), &pb.GetReceiptRequest{Email: email}, info, okHandler)
+		assert.NoError(t, err)
+	}
+
+	entries := logs.FilterMessage("Hot request pattern detected").All()
+	assert.Len(t, entries, 1, "A burst should log a single aggregated warning")
+	assert.Equal(t, "GetReceipt", entries[0].ContextMap()["method"])
+	assert.Equal(t, int64(5), entries[0].ContextMap()["count"])
+	assert.Equal(t, fmt.Sprintf("%016x", requestHash(hot)), entries[0].ContextMap()["request_hash"])
+	for _, field := range entries[0].Context {
+		assert.NotContains(t, fmt.Sprint(field.String, field.Interface), "stuck@example.com", "Warnings should not log the request")
+	}
+}
+
+func TestHotRequestWindow(t *testing.T) {
+	core, logs := observer.New(zapcore.WarnLevel)
+	clock := &fakeClock{now: time.Now()}
+	interceptor := newHotRequests(zap.New(core), 3, time.Minute, 1, clock.Now).intercept
+	info := &grpc.UnaryServerInfo{FullMethod: purchaseMethod}
+	req := &pb.GetReceiptRequest{Email: "slow@example.com"}
+
+	// Two requests per window never reach the threshold
+	for i := 0; i < 6; i++ {
+		_, err := interceptor(context.Background(), req, info, okHandler)
+		assert.NoError(t, err)
+		clock.now = clock.now.Add(40 * time.Second)
+	}
+	assert.Zero(t, logs.Len(), "Requests spread over windows should not warn")
+
+	// A fresh burst in a later window warns again
+	for i := 0; i < 3; i++ {
+		_, err := interceptor(context.Background(), req, info, okHandler)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 1, logs.Len())
+}
+
+func TestHotRequestSampling(t *testing.T) {
+	core, logs := observer.New(zapcore.WarnLevel)
+	clock := &fakeClock{now: time.Now()}
+	hot := newHotRequests(zap.New(core), 10, time.Minute, 4, clock.Now)
+	info := &grpc.UnaryServerInfo{FullMethod: purchaseMethod}
+	req := &pb.GetReceiptRequest{Email: "stuck@example.com"}
+
+	for i := 0; i < 8; i++ {
+		_, err := hot.intercept(context.Background(), req, info, okHandler)
+		assert.NoError(t, err)
+	}
+	assert.Zero(t, logs.Len(), "Two samples stand for eight requests, below the threshold")
+
+	for i := 0; i < 4; i++ {
+		_, err := hot.intercept(context.Background(), req, info, okHandler)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 1, logs.Len(), "Three samples stand for twelve requests")
+	assert.Len(t, hot.counters, 1, "Only sampled requests should be counted")
+}
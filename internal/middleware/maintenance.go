@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"context"
+	"path"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// MaintenanceInterceptor rejects the named mutating methods (e.g. "PurchaseTicket")
+// with codes.Unavailable while enabled reports true. All other methods keep working
+// so reads are still served during maintenance.
+func MaintenanceInterceptor(enabled func() bool, mutatingMethods ...string) grpc.UnaryServerInterceptor {
+	check := maintenanceCheck(enabled, mutatingMethods)
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := check(info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// MaintenanceStreamInterceptor is MaintenanceInterceptor for streaming calls
+// such as WatchAvailability.
+func MaintenanceStreamInterceptor(enabled func() bool, mutatingMethods ...string) grpc.StreamServerInterceptor {
+	check := maintenanceCheck(enabled, mutatingMethods)
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := check(info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, stream)
+	}
+}
+
+// maintenanceCheck returns a function rejecting the mutating methods while
+// enabled reports true.
+func maintenanceCheck(enabled func() bool, mutatingMethods []string) func(fullMethod string) error {
+	mutating := make(map[string]bool, len(mutatingMethods))
+	for _, method := range mutatingMethods {
+		mutating[method] = true
+	}
+
+	return func(fullMethod string) error {
+		if mutating[path.Base(fullMethod)] && enabled() {
+			return status.Error(codes.Unavailable, "service is in maintenance mode, changes are temporarily disabled")
+		}
+		return nil
+	}
+}
@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func okHandler(ctx context.Context, req interface{}) (interface{}, error) {
+	return "ok", nil
+}
+
+func TestMaintenanceInterceptor(t *testing.T) {
+	maintenance := true
+	interceptor := MaintenanceInterceptor(func() bool { return maintenance }, "PurchaseTicket", "RemoveUser")
+
+	// Writes are rejected during maintenance
+	_, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: purchaseMethod}, okHandler)
+	st, _ := status.FromError(err)
+	assert.Equal(t, codes.Unavailable, st.Code(), "Writes should be rejected in maintenance mode")
+
+	// Reads keep working during maintenance
+	resp, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/ticketBooking.TicketBookingService/GetReceipt"}, okHandler)
+	assert.NoError(t, err, "Reads should be served in maintenance mode")
+	assert.Equal(t, "ok", resp)
+
+	// Writes resume once maintenance is over
+	maintenance = false
+	resp, err = interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: purchaseMethod}, okHandler)
+	assert.NoError(t, err, "Writes should be accepted outside maintenance mode")
+	assert.Equal(t, "ok", resp)
+}
+
+func TestMaintenanceStreamInterceptor(t *testing.T) {
+	info := &grpc.StreamServerInfo{FullMethod: "/ticketBooking.TicketBookingService/WatchAvailability", IsServerStream: true}
+	ok := func(srv interface{}, stream grpc.ServerStream) error { return nil }
+
+	err := MaintenanceStreamInterceptor(func() bool { return true }, "WatchAvailability")(nil, &contextStream{ctx: context.Background()}, info, ok)
+	assert.Equal(t, codes.Unavailable, status.Code(err), "Listed streams should be rejected in maintenance mode")
+
+	err = MaintenanceStreamInterceptor(func() bool { return true }, "PurchaseTicket")(nil, &contextStream{ctx: context.Background()}, info, ok)
+	assert.NoError(t, err, "Read-only streams should be served in maintenance mode")
+}
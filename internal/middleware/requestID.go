@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"context"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// RequestIDHeader is the metadata key clients use to correlate a call with the
+// server's logs. The server echoes it in the response header.
+const RequestIDHeader = "x-request-id"
+
+// BookingIDTrailer is the trailer carrying the booking reference of the
+// receipt a call returned.
+const BookingIDTrailer = "x-booking-id"
+
+// receiptResponse is implemented by responses carrying a receipt, e.g.
+// PurchaseTicketResponse.
+type receiptResponse interface {
+	GetReceipt() *pb.Receipt
+}
+
+// RequestIDInterceptor echoes the call's x-request-id header back to the client
+// and logs the call's outcome under it at debug level. Responses carrying a
+// receipt with a booking reference send it in the x-booking-id trailer. Calls
+// without a request ID are served as usual.
+func RequestIDInterceptor(logger *zap.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		values := md.Get(RequestIDHeader)
+		if len(values) == 0 {
+			return handler(ctx, req)
+		}
+		requestID := values[0]
+		grpc.SetHeader(ctx, metadata.Pairs(RequestIDHeader, requestID))
+
+		resp, err := handler(ctx, req)
+		bookingID := ""
+		if response, ok := resp.(receiptResponse); ok {
+			bookingID = response.GetReceipt().GetBookingReference()
+		}
+		if bookingID != "" {
+			grpc.SetTrailer(ctx, metadata.Pairs(BookingIDTrailer, bookingID))
+		}
+
+		logger.Debug("Request completed",
+			zap.String("method", info.FullMethod),
+			zap.String("request_id", requestID),
+			zap.String("booking_id", bookingID),
+			zap.Stringer("code", status.Code(err)),
+		)
+		return resp, err
+	}
+}
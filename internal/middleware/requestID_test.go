@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+)
+
+func TestRequestIDInterceptor(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	interceptor := RequestIDInterceptor(zap.New(core))
+	info := &grpc.UnaryServerInfo{FullMethod: purchaseMethod}
+	purchased := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return &pb.PurchaseTicketResponse{Receipt: &pb.Receipt{BookingReference: "K7QX2M"}}, nil
+	}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(RequestIDHeader, "abc123"))
+	_, err := interceptor(ctx, nil, info, purchased)
+	assert.NoError(t, err)
+	entries := logs.FilterMessage("Request completed").All()
+	assert.Len(t, entries, 1)
+	assert.Equal(t, "abc123", entries[0].ContextMap()["request_id"])
+	assert.Equal(t, "K7QX2M", entries[0].ContextMap()["booking_id"])
+
+	resp, err := interceptor(context.Background(), nil, info, okHandler)
+	assert.NoError(t, err, "Calls without a request ID should be served")
+	assert.Equal(t, "ok", resp)
+	assert.Equal(t, 1, logs.Len(), "Calls without a request ID are not logged")
+}
@@ -0,0 +1,27 @@
+package service
+
+// OccupancyAlert is told when the share of occupied seats crosses the alert
+// threshold: above is true when it rises to or past the threshold and false
+// when it falls back below. It runs with the seat lock held, so it must not
+// call the SeatManager and should hand slow work, like paging, to a goroutine.
+type OccupancyAlert func(above bool, occupied, total int)
+
+// NopOccupancyAlert ignores occupancy alerts.
+func NopOccupancyAlert(above bool, occupied, total int) {}
+
+// checkOccupancy raises the occupancy alert when occupancy crosses
+// AlertThreshold. Each direction fires once per crossing, so bookings that keep
+// occupancy above the threshold don't repeat the alert. Callers must hold sm.mu.
+func (sm *SeatManager) checkOccupancy() {
+	if sm.AlertThreshold <= 0 {
+		return
+	}
+
+	occupied, total := sm.occupancy()
+	above := total > 0 && float64(occupied) >= sm.AlertThreshold*float64(total)
+	if above == sm.alertRaised {
+		return
+	}
+	sm.alertRaised = above
+	sm.OnOccupancyAlert(above, occupied, total)
+}
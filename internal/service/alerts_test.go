@@ -0,0 +1,90 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/sanjaykishor/rail-connect/internal/config"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+// alertRecorder collects the direction of every occupancy alert.
+type alertRecorder struct {
+	alerts []bool
+}
+
+func (r *alertRecorder) alert(above bool, occupied, total int) {
+	r.alerts = append(r.alerts, above)
+}
+
+// createAlertingSeatManager returns a ten-seat SeatManager alerting at 80%
+// occupancy.
+func createAlertingSeatManager() (*SeatManager, *alertRecorder) {
+	seatManager := NewSeatManager([]config.SectionConfig{
+		{Name: "A", MaxSeats: 5},
+		{Name: "B", MaxSeats: 5},
+	}, zap.NewNop())
+	recorder := &alertRecorder{}
+	seatManager.AlertThreshold = 0.8
+	seatManager.OnOccupancyAlert = recorder.alert
+	return seatManager, recorder
+}
+
+func TestOccupancyAlertCrossing(t *testing.T) {
+	seatManager, recorder := createAlertingSeatManager()
+
+	for i := 0; i < 7; i++ {
+		_, _, err := seatManager.AssignSeat(WholeRoute)
+		assert.NoError(t, err)
+	}
+	assert.Empty(t, recorder.alerts, "70% occupancy should not alert")
+
+	section, seat, err := seatManager.AssignSeat(WholeRoute)
+	assert.NoError(t, err)
+	assert.Equal(t, []bool{true}, recorder.alerts, "Reaching 80% should alert once")
+
+	_, _, err = seatManager.AssignSeat(WholeRoute)
+	assert.NoError(t, err)
+	assert.Equal(t, []bool{true}, recorder.alerts, "Staying above the threshold should not alert again")
+
+	assert.NoError(t, seatManager.ReleaseSeat(section, seat, WholeRoute))
+	assert.Equal(t, []bool{true}, recorder.alerts, "80% is still at the threshold")
+
+	_, _, err = seatManager.AssignSeat(WholeRoute)
+	assert.NoError(t, err)
+	assert.NoError(t, seatManager.ReleaseSeat(section, seat, WholeRoute))
+	assert.NoError(t, seatManager.ReleaseSeat("A", 1, WholeRoute))
+	assert.Equal(t, []bool{true, false}, recorder.alerts, "Dropping below 80% should alert once")
+
+	assert.NoError(t, seatManager.ReleaseSeat("B", 1, WholeRoute))
+	assert.Equal(t, []bool{true, false}, recorder.alerts, "Staying below the threshold should not alert again")
+}
+
+func TestOccupancyAlertBlockedSeats(t *testing.T) {
+	seatManager, recorder := createAlertingSeatManager()
+	for i := 0; i < 6; i++ {
+		_, _, err := seatManager.AssignSeat(WholeRoute)
+		assert.NoError(t, err)
+	}
+
+	// Blocking seats takes them out of circulation: 6 of 8 is 75%, 6 of 7 is 86%
+	assert.NoError(t, seatManager.BlockSeat("A", 5))
+	assert.NoError(t, seatManager.BlockSeat("B", 5))
+	assert.Empty(t, recorder.alerts)
+	assert.NoError(t, seatManager.BlockSeat("A", 4))
+	assert.Equal(t, []bool{true}, recorder.alerts, "Blocking seats can push occupancy over the threshold")
+
+	assert.NoError(t, seatManager.UnblockSeat("A", 4))
+	assert.Equal(t, []bool{true, false}, recorder.alerts, "Unblocking seats can bring occupancy back down")
+}
+
+func TestOccupancyAlertDisabled(t *testing.T) {
+	seatManager, recorder := createAlertingSeatManager()
+	seatManager.AlertThreshold = 0
+
+	for i := 0; i < 10; i++ {
+		_, _, err := seatManager.AssignSeat(WholeRoute)
+		assert.NoError(t, err)
+	}
+	assert.Empty(t, recorder.alerts, "A zero threshold should never alert")
+}
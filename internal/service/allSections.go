@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/sanjaykishor/rail-connect/internal/config"
+	pb "github.com/sanjaykishor/rail-connect/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GetAllSections lists every section of a departure, the undated default train
+// unless the request names a train or date, with its capacity, vacancy and
+// seating options, so clients can offer section preferences. Like
+// GetSectionVacancy it only takes the seat manager's read lock, and tm.mu only
+// to find another departure's seats.
+func (tm *TicketManager) GetAllSections(ctx context.Context, req *pb.GetAllSectionsRequest) (*pb.GetAllSectionsResponse, error) {
+	tm.Logger.Debug("GetAllSections request received",
+		zap.Time("timestamp", tm.Now()),
+	)
+
+	// Validate the request
+	if req == nil {
+		tm.Logger.Error("GetAllSections request is nil")
+		return nil, status.Error(codes.InvalidArgument, "request is nil")
+	}
+	train, err := tm.resolveDeparture(req.TrainId, req.DepartureDate)
+	if err != nil {
+		tm.Logger.Error("GetAllSections invalid departure",
+			zap.String("train_id", req.TrainId),
+			zap.String("departure_date", req.DepartureDate),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	seats := tm.SeatManager
+	if !tm.isDefaultDeparture(train, req.DepartureDate) {
+		tm.mu.Lock()
+		seats = tm.readDeparture(train, req.DepartureDate)
+		tm.mu.Unlock()
+	}
+
+	infos := seats.SectionInfos()
+	sections := make([]*pb.SectionInfo, 0, len(infos))
+	for _, info := range infos {
+		overflowPolicy := info.OverflowPolicy
+		if overflowPolicy == "" {
+			overflowPolicy = config.OverflowSpill
+		}
+		sections = append(sections, &pb.SectionInfo{
+			Name:           info.Name,
+			MaxSeats:       int32(info.MaxSeats),
+			VacantSeats:    int32(info.VacantSeats),
+			BlockedSeats:   int32(len(info.BlockedSeats)),
+			OverflowPolicy: overflowPolicy,
+			IsDefault:      info.Name == tm.SeatManager.DefaultSection,
+			Restricted:     info.Restricted,
+			Quiesced:       info.Quiesced,
+		})
+	}
+
+	tm.Logger.Debug("GetAllSections successful",
+		zap.Int("section_count", len(sections)),
+	)
+	return &pb.GetAllSectionsResponse{Sections: sections}, nil
+}
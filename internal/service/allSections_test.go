@@ -0,0 +1,37 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sanjaykishor/rail-connect/internal/config"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+)
+
+func TestGetAllSections(t *testing.T) {
+	seatManager := NewSeatManager([]config.SectionConfig{
+		{Name: "A", MaxSeats: 10, BlockedSeats: []int{4}, OverflowPolicy: config.OverflowWaitlist},
+		{Name: "B", MaxSeats: 20},
+		{Name: "C", MaxSeats: 5},
+	}, zap.NewNop())
+	seatManager.DefaultSection = "B"
+	tm := NewTicketManager(seatManager, map[string]float64{"London-France": 20.00}, zap.NewNop())
+	purchase(t, tm, "test@example.com", "London", "France")
+
+	response, err := tm.GetAllSections(context.Background(), &pb.GetAllSectionsRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, []*pb.SectionInfo{
+		{Name: "A", MaxSeats: 10, VacantSeats: 9, BlockedSeats: 1, OverflowPolicy: config.OverflowWaitlist},
+		{Name: "B", MaxSeats: 20, VacantSeats: 19, OverflowPolicy: config.OverflowSpill, IsDefault: true},
+		{Name: "C", MaxSeats: 5, VacantSeats: 5, OverflowPolicy: config.OverflowSpill},
+	}, response.Sections, "Every section should be listed in order with its capacity")
+
+	// Resizing is reflected straight away
+	assert.NoError(t, tm.SeatManager.ResizeSection("C", 8))
+	response, err = tm.GetAllSections(context.Background(), &pb.GetAllSectionsRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(8), response.Sections[2].MaxSeats)
+}
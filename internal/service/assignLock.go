@@ -0,0 +1,67 @@
+package service
+
+import (
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Bounds of the interval between attempts to take the seat lock while waiting
+// for it under AssignLockTimeout.
+const (
+	minLockPoll = 100 * time.Microsecond
+	maxLockPoll = 5 * time.Millisecond
+)
+
+// tryLocker is a lock that can be tried without blocking, such as sync.Mutex
+// and sync.RWMutex.
+type tryLocker interface {
+	sync.Locker
+	TryLock() bool
+}
+
+// lockForAssignment takes sm.mu for a seat assignment. With AssignLockTimeout
+// set it gives up with ErrLockTimeout once the timeout passes, so a long-held
+// lock fails bookings fast instead of queueing them; otherwise it waits for
+// the lock indefinitely.
+func (sm *SeatManager) lockForAssignment() error {
+	if !lockWithin(&sm.mu, sm.AssignLockTimeout, sm.Clock) {
+		sm.Logger.Warn("Timed out waiting for the seat lock")
+		return ErrLockTimeout
+	}
+	return nil
+}
+
+// lockForPurchase takes tm.mu for PurchaseTicket under the same
+// AssignLockTimeout as the seat lock, so a purchase queued behind another
+// long-held operation fails fast too. Each of the two locks may take up to
+// the timeout, so a purchase waits at most twice AssignLockTimeout for them.
+func (tm *TicketManager) lockForPurchase() error {
+	if !lockWithin(&tm.mu, tm.SeatManager.AssignLockTimeout, tm.SeatManager.Clock) {
+		tm.Logger.Warn("Timed out waiting for the booking lock",
+			zap.Duration("timeout", tm.SeatManager.AssignLockTimeout),
+		)
+		return ErrLockTimeout
+	}
+	return nil
+}
+
+// lockWithin takes mu, polling for it until timeout passes on clock, and
+// reports whether it was taken. A timeout of zero or less waits indefinitely.
+func lockWithin(mu tryLocker, timeout time.Duration, clock Clock) bool {
+	if timeout <= 0 {
+		mu.Lock()
+		return true
+	}
+
+	deadline := clock.Now().Add(timeout)
+	for wait := minLockPoll; !mu.TryLock(); wait = min(wait*2, maxLockPoll) {
+		remaining := deadline.Sub(clock.Now())
+		if remaining <= 0 {
+			return false
+		}
+		time.Sleep(min(wait, remaining))
+	}
+	return true
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// holdSeatLock holds the seat lock of sm from another goroutine until the
+// returned function is called.
+func holdSeatLock(sm *SeatManager) (release func()) {
+	locked, done := make(chan struct{}), make(chan struct{})
+	go func() {
+		sm.mu.Lock()
+		close(locked)
+		<-done
+		sm.mu.Unlock()
+	}()
+	<-locked
+	return func() { close(done) }
+}
+
+func TestAssignLockTimeout(t *testing.T) {
+	tm := createTestTicketManager()
+	tm.SeatManager.AssignLockTimeout = 20 * time.Millisecond
+	release := holdSeatLock(tm.SeatManager)
+
+	start := time.Now()
+	_, err := tryPurchase(tm, "test@example.com", withCompanion("companion@example.com"))
+	elapsed := time.Since(start)
+
+	st := status.Convert(err)
+	assert.Equal(t, codes.Unavailable, st.Code())
+	assert.Len(t, st.Details(), 1, "A lock timeout should carry a retry hint")
+	retryInfo, ok := st.Details()[0].(*errdetails.RetryInfo)
+	assert.True(t, ok, "Detail should be RetryInfo")
+	assert.Equal(t, lockRetryDelay, retryInfo.RetryDelay.AsDuration())
+	assert.GreaterOrEqual(t, elapsed, 20*time.Millisecond, "The assignment should wait out the timeout")
+	assert.Less(t, elapsed, time.Second, "The assignment should not wait for the lock holder")
+	assert.Empty(t, tm.Receipts)
+
+	_, err = tm.SeatManager.AssignSeatInSection("A", WholeRoute)
+	assert.ErrorIs(t, err, ErrLockTimeout)
+
+	// Once the lock is free bookings go through again
+	release()
+	purchase(t, tm, "test@example.com", "London", "France")
+	assert.Equal(t, 39, tm.SeatManager.FreeSeats(WholeRoute))
+}
+
+func TestPurchaseLockTimeout(t *testing.T) {
+	tm := createTestTicketManager()
+	tm.SeatManager.AssignLockTimeout = 20 * time.Millisecond
+
+	// Another operation holding the booking lock fails purchases fast too
+	tm.mu.Lock()
+	start := time.Now()
+	_, err := tm.PurchaseTicket(context.Background(), &pb.PurchaseTicketRequest{
+		User: &pb.User{FirstName: "Sanjay", LastName: "Kishor", Email: "test@example.com"},
+		From: "London",
+		To:   "France",
+	})
+	elapsed := time.Since(start)
+	tm.mu.Unlock()
+
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.GreaterOrEqual(t, elapsed, 20*time.Millisecond, "The purchase should wait out the timeout")
+	assert.Less(t, elapsed, time.Second, "The purchase should not wait for the lock holder")
+	assert.Empty(t, tm.Receipts)
+
+	purchase(t, tm, "test@example.com", "London", "France")
+}
+
+func TestAssignLockWaitsWithoutTimeout(t *testing.T) {
+	tm := createTestTicketManager()
+	release := holdSeatLock(tm.SeatManager)
+
+	done := make(chan error)
+	go func() {
+		_, err := tm.PurchaseTicket(context.Background(), &pb.PurchaseTicketRequest{
+			User: &pb.User{FirstName: "Sanjay", LastName: "Kishor", Email: "test@example.com"},
+			From: "London",
+			To:   "France",
+		})
+		done <- err
+	}()
+
+	select {
+	case <-done:
+		t.Fatalf("Assignment should wait for the lock without a timeout")
+	case <-time.After(20 * time.Millisecond):
+	}
+	release()
+	assert.NoError(t, <-done)
+}
@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"slices"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+)
+
+// Strategies that can assign a seat, as recorded in an AssignmentTrace.
+const (
+	StrategyHold                = "hold"
+	StrategyPreference          = "preference"
+	StrategyLoyaltyTier         = "loyalty_tier"
+	StrategyCheapestSection     = "cheapest_section"
+	StrategyDestinationAffinity = "destination_affinity"
+	StrategyDefaultSection      = "default_section"
+	StrategySharedSeat          = "shared_seat"
+	StrategyRoundRobin          = "round_robin"
+	StrategyPair                = "pair"
+	StrategyWaitlist            = "waitlist"
+)
+
+// AssignmentTrace records how a booking's seat was chosen, for debugging the
+// seating strategies. Traces are kept in memory only, until the booking is
+// cancelled or replaced.
+type AssignmentTrace struct {
+	Strategy          string
+	PreferenceHonored bool
+	// SkippedSections counts the sections tried or passed over before the
+	// one assigned.
+	SkippedSections int
+}
+
+// waitlistTrace returns the trace of a waitlisted purchase booked into the
+// section.
+func waitlistTrace(req *pb.PurchaseTicketRequest, section string) AssignmentTrace {
+	return AssignmentTrace{
+		Strategy:          StrategyWaitlist,
+		PreferenceHonored: slices.Contains(req.PreferredSections, section),
+	}
+}
+
+// GetAssignmentTrace returns how the seat of the booking with the reference
+// was chosen. Only admin API keys may call it.
+func (tm *TicketManager) GetAssignmentTrace(ctx context.Context, req *pb.GetAssignmentTraceRequest) (*pb.GetAssignmentTraceResponse, error) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	tm.Logger.Info("GetAssignmentTrace request received")
+
+	// Validate the request
+	if req == nil {
+		tm.Logger.Error("GetAssignmentTrace request is nil")
+		return nil, status.Error(codes.InvalidArgument, "request is nil")
+	}
+	if req.BookingReference == "" {
+		tm.Logger.Error("GetAssignmentTrace request missing booking reference")
+		return nil, status.Error(codes.InvalidArgument, "missing required fields")
+	}
+
+	tm.Logger.Info("GetAssignmentTrace request",
+		zap.String("booking_reference", req.BookingReference),
+		zap.Time("timestamp", tm.Now()),
+	)
+
+	trace, exists := tm.assignmentTraces[req.BookingReference]
+	if !exists {
+		tm.Logger.Error("GetAssignmentTrace trace not found",
+			zap.String("booking_reference", req.BookingReference),
+		)
+		return nil, status.Error(codes.NotFound, "assignment trace not found")
+	}
+
+	tm.Logger.Info("GetAssignmentTrace successful",
+		zap.String("booking_reference", req.BookingReference),
+		zap.String("strategy", trace.Strategy),
+		zap.Bool("preference_honored", trace.PreferenceHonored),
+		zap.Int("skipped_sections", trace.SkippedSections),
+	)
+	return &pb.GetAssignmentTraceResponse{
+		Strategy:          trace.Strategy,
+		PreferenceHonored: trace.PreferenceHonored,
+		SkippedSections:   int32(trace.SkippedSections),
+	}, nil
+}
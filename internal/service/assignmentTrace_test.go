@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sanjaykishor/rail-connect/internal/config"
+	"github.com/stretchr/testify/assert"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// assignmentTrace returns the trace of the receipt's booking.
+func assignmentTrace(t *testing.T, tm *TicketManager, receipt *pb.Receipt) *pb.GetAssignmentTraceResponse {
+	t.Helper()
+	response, err := tm.GetAssignmentTrace(context.Background(), &pb.GetAssignmentTraceRequest{BookingReference: receipt.BookingReference})
+	if err != nil {
+		t.Fatalf("failed to get assignment trace for %s: %v", receipt.BookingReference, err)
+	}
+	return response
+}
+
+func TestAssignmentTracePreferenceHonored(t *testing.T) {
+	tm := createTestTicketManager()
+
+	response, err := tryPurchase(tm, "test@example.com", inSection("B"))
+	assert.NoError(t, err)
+
+	trace := assignmentTrace(t, tm, response.Receipt)
+	assert.Equal(t, StrategyPreference, trace.Strategy)
+	assert.True(t, trace.PreferenceHonored)
+	assert.Equal(t, int32(0), trace.SkippedSections)
+}
+
+func TestAssignmentTraceFallback(t *testing.T) {
+	tm := createOverflowTicketManager(config.OverflowSpill)
+	fillSectionA(t, tm)
+
+	response, err := tryPurchase(tm, "late@example.com", inSection("A"))
+	assert.NoError(t, err)
+	assert.Equal(t, "B", response.Receipt.Seat.Section)
+
+	trace := assignmentTrace(t, tm, response.Receipt)
+	assert.Equal(t, StrategyRoundRobin, trace.Strategy)
+	assert.False(t, trace.PreferenceHonored)
+	assert.Equal(t, int32(2), trace.SkippedSections, "The full preferred section and its turn in the rotation should both be skipped")
+}
+
+func TestAssignmentTraceStrategies(t *testing.T) {
+	tm := createTestTicketManager()
+	trace := assignmentTrace(t, tm, purchase(t, tm, "first@example.com", "London", "France"))
+	assert.Equal(t, StrategyRoundRobin, trace.Strategy)
+	assert.Equal(t, int32(0), trace.SkippedSections)
+
+	tm.SeatManager.DefaultSection = "B"
+	trace = assignmentTrace(t, tm, purchase(t, tm, "second@example.com", "London", "France"))
+	assert.Equal(t, StrategyDefaultSection, trace.Strategy)
+
+	response, err := tryPurchase(tm, "third@example.com", withCompanion("third.companion@example.com"))
+	assert.NoError(t, err)
+	assert.Equal(t, StrategyPair, assignmentTrace(t, tm, response.Receipt).Strategy)
+	assert.Equal(t, StrategyPair, assignmentTrace(t, tm, response.CompanionReceipt).Strategy)
+}
+
+func TestAssignmentTraceWaitlist(t *testing.T) {
+	tm := createOverflowTicketManager(config.OverflowWaitlist)
+	fillSectionA(t, tm)
+	_, err := tryPurchase(tm, "waiting@example.com", inSection("A"))
+	assert.NoError(t, err)
+
+	_, err = tm.RemoveUser(context.Background(), &pb.RemoveUserRequest{Email: "first@example.com"})
+	assert.NoError(t, err)
+
+	trace := assignmentTrace(t, tm, tm.Receipts["waiting@example.com"])
+	assert.Equal(t, StrategyWaitlist, trace.Strategy)
+	assert.True(t, trace.PreferenceHonored)
+}
+
+func TestAssignmentTraceNotFound(t *testing.T) {
+	tm := createTestTicketManager()
+	receipt := purchase(t, tm, "test@example.com", "London", "France")
+
+	_, err := tm.RemoveUser(context.Background(), &pb.RemoveUserRequest{Email: "test@example.com"})
+	assert.NoError(t, err)
+	_, err = tm.GetAssignmentTrace(context.Background(), &pb.GetAssignmentTraceRequest{BookingReference: receipt.BookingReference})
+	assert.Equal(t, codes.NotFound, status.Code(err), "Cancelled bookings should have no trace")
+	assert.Empty(t, tm.assignmentTraces)
+
+	_, err = tm.GetAssignmentTrace(context.Background(), &pb.GetAssignmentTraceRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
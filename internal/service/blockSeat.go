@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// BlockSeat takes a vacant seat out of circulation, e.g. because it is broken:
+// on the departure the request names, or on the undated default train and every
+// booked departure when it names no train or date.
+func (tm *TicketManager) BlockSeat(ctx context.Context, req *pb.BlockSeatRequest) (*pb.BlockSeatResponse, error) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	tm.Logger.Info("BlockSeat request received")
+
+	// Validate the request
+	if req == nil {
+		tm.Logger.Error("BlockSeat request is nil")
+		return nil, status.Error(codes.InvalidArgument, "request is nil")
+	}
+	if req.Section == "" || req.SeatNumber <= 0 {
+		tm.Logger.Error("BlockSeat request missing required fields",
+			zap.String("section", req.Section),
+			zap.Int32("seat_number", req.SeatNumber),
+		)
+		return nil, status.Error(codes.InvalidArgument, "missing required fields")
+	}
+
+	train, err := tm.resolveDeparture(req.TrainId, req.DepartureDate)
+	if err != nil {
+		tm.Logger.Error("BlockSeat invalid departure",
+			zap.String("train_id", req.TrainId),
+			zap.String("departure_date", req.DepartureDate),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	tm.Logger.Info("BlockSeat request",
+		zap.String("section", req.Section),
+		zap.Int32("seat_number", req.SeatNumber),
+		zap.String("train_id", train),
+		zap.String("departure_date", req.DepartureDate),
+		zap.Time("timestamp", tm.Now()),
+	)
+
+	seats := tm.departure(train, req.DepartureDate)
+	if req.TrainId == "" && req.DepartureDate == "" {
+		err = tm.blockSeatEveryDeparture(req.Section, int(req.SeatNumber))
+	} else {
+		err = seats.BlockSeat(req.Section, int(req.SeatNumber))
+	}
+	if err != nil {
+		tm.Logger.Error("BlockSeat failed to block seat",
+			zap.String("section", req.Section),
+			zap.Int32("seat_number", req.SeatNumber),
+			zap.Error(err),
+		)
+		return nil, seatError(err)
+	}
+
+	vacantSeats := seats.VacantSeats(req.Section)
+
+	tm.Logger.Info("BlockSeat successful",
+		zap.String("section", req.Section),
+		zap.Int32("seat_number", req.SeatNumber),
+		zap.Int("vacant_seats", vacantSeats),
+	)
+	return &pb.BlockSeatResponse{
+		Message:     "Seat blocked successfully",
+		Seat:        &pb.Seat{Section: req.Section, SeatNumber: req.SeatNumber},
+		VacantSeats: int32(vacantSeats),
+	}, nil
+}
+
+// UnblockSeat returns a blocked seat to circulation on the departure the
+// request names, or on the undated default train and every booked departure
+// when it names no train or date.
+func (tm *TicketManager) UnblockSeat(ctx context.Context, req *pb.UnblockSeatRequest) (*pb.UnblockSeatResponse, error) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	tm.Logger.Info("UnblockSeat request received")
+
+	// Validate the request
+	if req == nil {
+		tm.Logger.Error("UnblockSeat request is nil")
+		return nil, status.Error(codes.InvalidArgument, "request is nil")
+	}
+	if req.Section == "" || req.SeatNumber <= 0 {
+		tm.Logger.Error("UnblockSeat request missing required fields",
+			zap.String("section", req.Section),
+			zap.Int32("seat_number", req.SeatNumber),
+		)
+		return nil, status.Error(codes.InvalidArgument, "missing required fields")
+	}
+
+	train, err := tm.resolveDeparture(req.TrainId, req.DepartureDate)
+	if err != nil {
+		tm.Logger.Error("UnblockSeat invalid departure",
+			zap.String("train_id", req.TrainId),
+			zap.String("departure_date", req.DepartureDate),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	tm.Logger.Info("UnblockSeat request",
+		zap.String("section", req.Section),
+		zap.Int32("seat_number", req.SeatNumber),
+		zap.String("train_id", train),
+		zap.String("departure_date", req.DepartureDate),
+		zap.Time("timestamp", tm.Now()),
+	)
+
+	seats := tm.departure(train, req.DepartureDate)
+	if req.TrainId == "" && req.DepartureDate == "" {
+		err = tm.unblockSeatEveryDeparture(req.Section, int(req.SeatNumber))
+	} else {
+		err = seats.UnblockSeat(req.Section, int(req.SeatNumber))
+	}
+	if err != nil {
+		tm.Logger.Error("UnblockSeat failed to unblock seat",
+			zap.String("section", req.Section),
+			zap.Int32("seat_number", req.SeatNumber),
+			zap.Error(err),
+		)
+		return nil, seatError(err)
+	}
+
+	tm.promoteWaitlist(req.Section, train, req.DepartureDate)
+
+	vacantSeats := seats.VacantSeats(req.Section)
+
+	tm.Logger.Info("UnblockSeat successful",
+		zap.String("section", req.Section),
+		zap.Int32("seat_number", req.SeatNumber),
+		zap.Int("vacant_seats", vacantSeats),
+	)
+	return &pb.UnblockSeatResponse{
+		Message:     "Seat unblocked successfully",
+		Seat:        &pb.Seat{Section: req.Section, SeatNumber: req.SeatNumber},
+		VacantSeats: int32(vacantSeats),
+	}, nil
+}
+
+// blockSeatEveryDeparture blocks a seat on the undated default train and every
+// booked departure, which are laid out like it, or on none of them if it is
+// taken on any. Departures where it is already blocked are left as they are.
+// Callers must hold tm.mu.
+func (tm *TicketManager) blockSeatEveryDeparture(section string, seatNumber int) error {
+	for _, key := range tm.departureKeys() {
+		available, blocked, err := tm.departures[key].SeatStatus(section, seatNumber)
+		if err != nil {
+			return err
+		}
+		if !available && !blocked {
+			return fmt.Errorf("%w: seat %d in section %s on train %s on %s", ErrSeatOccupied, seatNumber, section, key.train, key.date)
+		}
+	}
+
+	if err := tm.SeatManager.BlockSeat(section, seatNumber); err != nil {
+		return err
+	}
+	for _, key := range tm.departureKeys() {
+		_ = tm.departures[key].BlockSeat(section, seatNumber) // Vacant or already blocked
+	}
+	return nil
+}
+
+// unblockSeatEveryDeparture unblocks a seat blocked on the undated default
+// train there and on every booked departure where it is blocked, offering it to
+// each departure's waitlist. Callers must hold tm.mu.
+func (tm *TicketManager) unblockSeatEveryDeparture(section string, seatNumber int) error {
+	if err := tm.SeatManager.UnblockSeat(section, seatNumber); err != nil {
+		return err
+	}
+	for _, key := range tm.departureKeys() {
+		_ = tm.departures[key].UnblockSeat(section, seatNumber) // Not blocked on this departure
+		tm.promoteWaitlist(section, key.train, key.date)
+	}
+	return nil
+}
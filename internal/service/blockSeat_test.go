@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sanjaykishor/rail-connect/internal/config"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestBlockedSeatsFromConfig(t *testing.T) {
+	seatManager := NewSeatManager([]config.SectionConfig{
+		{Name: "A", MaxSeats: 3, BlockedSeats: []int{1, 3}},
+		{Name: "B", MaxSeats: 2, BlockedSeats: []int{2}},
+	}, zap.NewNop())
+
+	assert.Equal(t, 1, seatManager.VacantSeats("A"), "Blocked seats should not count as vacant")
+	assert.Equal(t, 1, seatManager.VacantSeats("B"))
+
+	// Only the unblocked seats are ever handed out
+	assigned := make(map[string]int)
+	for i := 0; i < 2; i++ {
+		sectionName, seatNumber, err := seatManager.AssignSeat(WholeRoute)
+		assert.NoError(t, err)
+		assigned[sectionName] = seatNumber
+	}
+	assert.Equal(t, map[string]int{"A": 2, "B": 1}, assigned)
+
+	_, _, err := seatManager.AssignSeat(WholeRoute)
+	assert.ErrorIs(t, err, ErrNoSeatsAvailable, "Blocked seats should never be assigned")
+
+	_, _, err = seatManager.AssignSeat(Journey{From: 0, To: 1})
+	assert.ErrorIs(t, err, ErrNoSeatsAvailable, "Blocked seats should never be shared")
+
+	err = seatManager.ReleaseSeat("A", 1, WholeRoute)
+	assert.ErrorIs(t, err, ErrSeatBlocked, "Blocked seats should not be released into circulation")
+	assert.Equal(t, 0, seatManager.VacantSeats("A"))
+
+	err = seatManager.UpdateSeat(2, "A", 3, "A", WholeRoute)
+	assert.ErrorIs(t, err, ErrSeatBlocked, "Nobody can move onto a blocked seat")
+}
+
+func TestBlockSeatRPC(t *testing.T) {
+	tm := createTestTicketManager()
+	receipt := purchase(t, tm, "test@example.com", "London", "France")
+
+	tests := []struct {
+		name           string
+		request        *pb.BlockSeatRequest
+		expectedCode   codes.Code
+		expectedVacant int32
+	}{
+		{"Vacant Seat", &pb.BlockSeatRequest{Section: "A", SeatNumber: 2}, codes.OK, 18},
+		{"Already Blocked", &pb.BlockSeatRequest{Section: "A", SeatNumber: 2}, codes.FailedPrecondition, 0},
+		{"Occupied Seat", &pb.BlockSeatRequest{Section: receipt.Seat.Section, SeatNumber: receipt.Seat.SeatNumber}, codes.AlreadyExists, 0},
+		{"Nonexistent Seat", &pb.BlockSeatRequest{Section: "A", SeatNumber: 99}, codes.NotFound, 0},
+		{"Nonexistent Section", &pb.BlockSeatRequest{Section: "C", SeatNumber: 1}, codes.NotFound, 0},
+		{"Missing Seat", &pb.BlockSeatRequest{Section: "A"}, codes.InvalidArgument, 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			response, err := tm.BlockSeat(context.Background(), test.request)
+			assert.Equal(t, test.expectedCode, status.Code(err))
+			if test.expectedCode == codes.OK {
+				assert.Equal(t, test.expectedVacant, response.VacantSeats)
+			} else {
+				assert.Nil(t, response)
+			}
+		})
+	}
+
+	// The next booking in section A skips the blocked seat
+	purchase(t, tm, "other@example.com", "London", "France")
+	next := purchase(t, tm, "third@example.com", "London", "France")
+	assert.Equal(t, "A", next.Seat.Section)
+	assert.Equal(t, int32(3), next.Seat.SeatNumber, "Blocked seat should be skipped")
+
+	response, err := tm.UnblockSeat(context.Background(), &pb.UnblockSeatRequest{Section: "A", SeatNumber: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(18), response.VacantSeats, "Unblocked seat should be vacant again")
+
+	_, err = tm.UnblockSeat(context.Background(), &pb.UnblockSeatRequest{Section: "A", SeatNumber: 2})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err), "Seat is no longer blocked")
+
+	_, err = tm.UpdateUserSeat(context.Background(), &pb.UpdateUserSeatRequest{
+		Email:   "test@example.com",
+		NewSeat: &pb.Seat{Section: "A", SeatNumber: 2},
+	})
+	assert.NoError(t, err, "Unblocked seat can be taken again")
+}
+
+func TestBlockSeatEveryDeparture(t *testing.T) {
+	tm := createDatedTicketManager()
+	seated := purchase(t, tm, "monday@example.com", "London", "France", onDate("2024-03-11"))
+	purchase(t, tm, "tuesday@example.com", "London", "France", onDate("2024-03-12"))
+	departures := []*SeatManager{
+		tm.SeatManager,
+		tm.departures[departureKey{"", "2024-03-11"}],
+		tm.departures[departureKey{"", "2024-03-12"}],
+	}
+
+	// A seat taken on one departure can't be blocked on all of them
+	_, err := tm.BlockSeat(context.Background(), &pb.BlockSeatRequest{Section: seated.Seat.Section, SeatNumber: seated.Seat.SeatNumber})
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+	_, blocked, _ := tm.SeatManager.SeatStatus(seated.Seat.Section, int(seated.Seat.SeatNumber))
+	assert.False(t, blocked, "No departure should be blocked when the seat is taken on one")
+
+	_, err = tm.BlockSeat(context.Background(), &pb.BlockSeatRequest{Section: "A", SeatNumber: 5})
+	assert.NoError(t, err)
+	for i, seats := range departures {
+		_, blocked, _ := seats.SeatStatus("A", 5)
+		assert.True(t, blocked, "Departure %d should have the seat blocked", i)
+	}
+
+	// Blocking on one date leaves the others alone
+	_, err = tm.BlockSeat(context.Background(), &pb.BlockSeatRequest{Section: "A", SeatNumber: 6, DepartureDate: "2024-03-11"})
+	assert.NoError(t, err)
+	_, blocked, _ = tm.SeatManager.SeatStatus("A", 6)
+	assert.False(t, blocked)
+
+	_, err = tm.UnblockSeat(context.Background(), &pb.UnblockSeatRequest{Section: "A", SeatNumber: 5})
+	assert.NoError(t, err)
+	for i, seats := range departures {
+		available, blocked, _ := seats.SeatStatus("A", 5)
+		assert.True(t, available && !blocked, "Departure %d should have the seat back", i)
+	}
+}
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxTrendEvents bounds the bookings and cancellations remembered for
+// GetBookingTrends. Once it is reached the oldest events are overwritten, so
+// on a very busy day the oldest buckets undercount.
+const maxTrendEvents = 10000
+
+// trendEvent is a booking or cancellation at a point in time.
+type trendEvent struct {
+	at        time.Time
+	cancelled bool
+}
+
+// trendEvents is a ring buffer of the most recent trend events. The zero value
+// is empty and ready to use.
+type trendEvents struct {
+	events []trendEvent
+	next   int // Index the next event overwrites once the buffer is full
+}
+
+// add records an event, overwriting the oldest one once the buffer is full.
+func (r *trendEvents) add(event trendEvent) {
+	if len(r.events) < maxTrendEvents {
+		r.events = append(r.events, event)
+		return
+	}
+	r.events[r.next] = event
+	r.next = (r.next + 1) % maxTrendEvents
+}
+
+// bucket counts the events in the count buckets of size ending with the one
+// now falls in, oldest first. Buckets start at multiples of size.
+func (r *trendEvents) bucket(now time.Time, size time.Duration, count int) []*pb.BookingTrend {
+	start := now.Truncate(size).Add(-time.Duration(count-1) * size)
+	buckets := make([]*pb.BookingTrend, count)
+	for i := range buckets {
+		buckets[i] = &pb.BookingTrend{StartsAt: start.Add(time.Duration(i) * size).Unix()}
+	}
+
+	for _, event := range r.events {
+		if event.at.Before(start) {
+			continue
+		}
+		i := int(event.at.Sub(start) / size)
+		if i >= count {
+			continue
+		}
+		if event.cancelled {
+			buckets[i].Cancellations++
+		} else {
+			buckets[i].Bookings++
+		}
+	}
+	return buckets
+}
+
+// trendWindow returns the size and number of the buckets reported for a
+// TrendBucket, or false if it is not a valid bucket.
+func trendWindow(bucket pb.TrendBucket) (time.Duration, int, bool) {
+	switch bucket {
+	case pb.TrendBucket_TREND_BUCKET_MINUTE:
+		return time.Minute, 60, true
+	case pb.TrendBucket_TREND_BUCKET_HOUR:
+		return time.Hour, 24, true
+	default:
+		return 0, 0, false
+	}
+}
+
+// trackTrend records a booking, or a cancellation if cancelled, at now for
+// GetBookingTrends. Callers must hold tm.mu.
+func (tm *TicketManager) trackTrend(now time.Time, cancelled bool) {
+	tm.trends.add(trendEvent{at: now, cancelled: cancelled})
+}
+
+// GetBookingTrends returns how many tickets were booked and cancelled in each
+// minute of the last hour or each hour of the last day, for capacity planning.
+func (tm *TicketManager) GetBookingTrends(ctx context.Context, req *pb.GetBookingTrendsRequest) (*pb.GetBookingTrendsResponse, error) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	tm.Logger.Info("GetBookingTrends request received")
+
+	// Validate the request
+	if req == nil {
+		tm.Logger.Error("GetBookingTrends request is nil")
+		return nil, status.Error(codes.InvalidArgument, "request is nil")
+	}
+	size, count, ok := trendWindow(req.Bucket)
+	if !ok {
+		tm.Logger.Error("GetBookingTrends request has an invalid bucket",
+			zap.String("bucket", req.Bucket.String()),
+		)
+		return nil, status.Error(codes.InvalidArgument, "invalid bucket")
+	}
+
+	now := tm.Now()
+	tm.Logger.Info("GetBookingTrends request",
+		zap.String("bucket", req.Bucket.String()),
+		zap.Time("timestamp", now),
+	)
+
+	buckets := tm.trends.bucket(now, size, count)
+
+	tm.Logger.Info("GetBookingTrends successful",
+		zap.String("bucket", req.Bucket.String()),
+		zap.Int("buckets", len(buckets)),
+	)
+	return &pb.GetBookingTrendsResponse{Buckets: buckets}, nil
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+)
+
+func TestBookingTrendsBuckets(t *testing.T) {
+	now := time.Date(2024, 3, 10, 9, 30, 20, 0, time.UTC)
+	var trends trendEvents
+	for _, event := range []trendEvent{
+		{at: now.Add(-2 * time.Hour)}, // Before the window
+		{at: time.Date(2024, 3, 10, 8, 31, 0, 0, time.UTC)},
+		{at: time.Date(2024, 3, 10, 8, 31, 59, 0, time.UTC), cancelled: true},
+		{at: time.Date(2024, 3, 10, 9, 29, 0, 0, time.UTC)},
+		{at: time.Date(2024, 3, 10, 9, 30, 0, 0, time.UTC)},
+		{at: time.Date(2024, 3, 10, 9, 30, 10, 0, time.UTC)},
+		{at: time.Date(2024, 3, 10, 9, 30, 15, 0, time.UTC), cancelled: true},
+	} {
+		trends.add(event)
+	}
+
+	buckets := trends.bucket(now, time.Minute, 60)
+	assert.Len(t, buckets, 60)
+	assert.Equal(t, time.Date(2024, 3, 10, 8, 31, 0, 0, time.UTC).Unix(), buckets[0].StartsAt)
+	assert.Equal(t, &pb.BookingTrend{StartsAt: buckets[0].StartsAt, Bookings: 1, Cancellations: 1}, buckets[0])
+	assert.Equal(t, int32(1), buckets[58].Bookings)
+	assert.Equal(t, time.Date(2024, 3, 10, 9, 30, 0, 0, time.UTC).Unix(), buckets[59].StartsAt, "The last bucket should be the current minute")
+	assert.Equal(t, int32(2), buckets[59].Bookings)
+	assert.Equal(t, int32(1), buckets[59].Cancellations)
+
+	total := 0
+	for _, bucket := range buckets {
+		total += int(bucket.Bookings + bucket.Cancellations)
+	}
+	assert.Equal(t, 6, total, "Events before the window should not be counted")
+
+	// The last day in hours takes in the event two hours ago
+	buckets = trends.bucket(now, time.Hour, 24)
+	assert.Len(t, buckets, 24)
+	assert.Equal(t, &pb.BookingTrend{StartsAt: time.Date(2024, 3, 10, 7, 0, 0, 0, time.UTC).Unix(), Bookings: 1}, buckets[21])
+	assert.Equal(t, int32(1), buckets[22].Bookings)
+	assert.Equal(t, int32(1), buckets[22].Cancellations)
+	assert.Equal(t, int32(3), buckets[23].Bookings)
+}
+
+func TestBookingTrendsBounded(t *testing.T) {
+	start := time.Date(2024, 3, 10, 9, 0, 0, 0, time.UTC)
+	var trends trendEvents
+	for i := 0; i < maxTrendEvents+10; i++ {
+		trends.add(trendEvent{at: start.Add(time.Duration(i) * time.Millisecond)})
+	}
+
+	assert.Len(t, trends.events, maxTrendEvents)
+	assert.Equal(t, start.Add(10*time.Millisecond), trends.events[10].at, "The oldest events should be overwritten first")
+	assert.Equal(t, start.Add(time.Duration(maxTrendEvents)*time.Millisecond), trends.events[0].at)
+}
+
+func TestGetBookingTrends(t *testing.T) {
+	tm := createTestTicketManager()
+	clock := NewFakeClock(time.Date(2024, 3, 10, 9, 0, 0, 0, time.UTC))
+	tm.Clock = clock
+
+	purchase(t, tm, "first@example.com", "London", "France")
+	clock.Advance(2 * time.Minute)
+	purchase(t, tm, "second@example.com", "London", "France")
+	_, err := tm.RemoveUser(context.Background(), &pb.RemoveUserRequest{Email: "first@example.com"})
+	assert.NoError(t, err)
+
+	// Dry runs book nothing
+	_, err = tm.PurchaseTicket(context.Background(), &pb.PurchaseTicketRequest{
+		User:   &pb.User{FirstName: "Sanjay", LastName: "Kishor", Email: "dry@example.com"},
+		From:   "London",
+		To:     "France",
+		DryRun: true,
+	})
+	assert.NoError(t, err)
+
+	response, err := tm.GetBookingTrends(context.Background(), &pb.GetBookingTrendsRequest{Bucket: pb.TrendBucket_TREND_BUCKET_MINUTE})
+	assert.NoError(t, err)
+	assert.Len(t, response.Buckets, 60)
+	assert.Equal(t, int32(1), response.Buckets[57].Bookings)
+	assert.Equal(t, int32(1), response.Buckets[59].Bookings)
+	assert.Equal(t, int32(1), response.Buckets[59].Cancellations)
+
+	// An hour later the bookings have left the minute view but not the day
+	clock.Advance(time.Hour)
+	response, err = tm.GetBookingTrends(context.Background(), &pb.GetBookingTrendsRequest{Bucket: pb.TrendBucket_TREND_BUCKET_MINUTE})
+	assert.NoError(t, err)
+	for _, bucket := range response.Buckets {
+		assert.Zero(t, bucket.Bookings+bucket.Cancellations)
+	}
+
+	response, err = tm.GetBookingTrends(context.Background(), &pb.GetBookingTrendsRequest{Bucket: pb.TrendBucket_TREND_BUCKET_HOUR})
+	assert.NoError(t, err)
+	assert.Len(t, response.Buckets, 24)
+	assert.Equal(t, &pb.BookingTrend{StartsAt: time.Date(2024, 3, 10, 9, 0, 0, 0, time.UTC).Unix(), Bookings: 2, Cancellations: 1}, response.Buckets[22])
+}
+
+func TestGetBookingTrendsInvalid(t *testing.T) {
+	tm := createTestTicketManager()
+
+	_, err := tm.GetBookingTrends(context.Background(), nil)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = tm.GetBookingTrends(context.Background(), &pb.GetBookingTrendsRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"sort"
+
+	"go.uber.org/zap"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// BulkCancel cancels every booking in a section and/or on a route of one
+// departure, the undated default train unless the request names a train or
+// date, releasing their seats. The cancelled receipts are returned with their refunds, which
+// are prorated by the refund policy when the train was stopped at a station.
+func (tm *TicketManager) BulkCancel(ctx context.Context, req *pb.BulkCancelRequest) (*pb.BulkCancelResponse, error) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	tm.Logger.Info("BulkCancel request received")
+
+	// Validate the request
+	if req == nil {
+		tm.Logger.Error("BulkCancel request is nil")
+		return nil, status.Error(codes.InvalidArgument, "request is nil")
+	}
+	req.From, req.To = tm.station(req.From), tm.station(req.To)
+	req.CancelledAt = tm.station(req.CancelledAt)
+	// A route needs both ends, and at least one filter must be given
+	if (req.From == "") != (req.To == "") || (req.Section == "" && req.From == "") {
+		tm.Logger.Error("BulkCancel request missing required fields",
+			zap.String("section", req.Section),
+			zap.String("from", req.From),
+			zap.String("to", req.To),
+		)
+		return nil, status.Error(codes.InvalidArgument, "missing required fields")
+	}
+
+	train, err := tm.resolveDeparture(req.TrainId, req.DepartureDate)
+	if err != nil {
+		tm.Logger.Error("BulkCancel invalid departure",
+			zap.String("train_id", req.TrainId),
+			zap.String("departure_date", req.DepartureDate),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	// Check if the section exists; every departure has the same sections
+	if req.Section != "" {
+		if _, exists := tm.SeatManager.Sections[req.Section]; !exists {
+			tm.Logger.Error("BulkCancel section not found",
+				zap.String("section", req.Section),
+			)
+			return nil, status.Error(codes.NotFound, "section not found")
+		}
+	}
+
+	tm.Logger.Info("BulkCancel request",
+		zap.String("section", req.Section),
+		zap.String("from", req.From),
+		zap.String("to", req.To),
+		zap.String("cancelled_at", req.CancelledAt),
+		zap.String("train_id", train),
+		zap.String("departure_date", req.DepartureDate),
+		zap.Time("timestamp", tm.Now()),
+	)
+
+	// Cancel in a consistent order so results are reproducible
+	emails := make([]string, 0)
+	for email, receipt := range tm.Receipts {
+		if receipt.Seat == nil {
+			tm.Logger.Error("BulkCancel ticket receipt has no seat",
+				zap.String("email", email),
+			)
+			continue
+		}
+		if !tm.onDeparture(receipt, train, req.DepartureDate) {
+			continue
+		}
+		if req.Section != "" && receipt.Seat.Section != req.Section {
+			continue
+		}
+		if req.From != "" && (receipt.From != req.From || receipt.To != req.To) {
+			continue
+		}
+		emails = append(emails, email)
+	}
+	sort.Strings(emails)
+
+	// Work out every refund first, so a cancellation point that is not on a
+	// journey cancels nothing
+	refunds := make(map[string]float64, len(emails))
+	for _, email := range emails {
+		receipt := tm.Receipts[email]
+		travelled, total, err := tm.segmentsTravelled(receipt, req.CancelledAt)
+		if err != nil {
+			tm.Logger.Error("BulkCancel invalid cancellation point",
+				zap.String("email", email),
+				zap.String("cancelled_at", req.CancelledAt),
+				zap.Error(err),
+			)
+			return nil, seatError(err)
+		}
+		refunds[email] = tm.refund(receipt, travelled, total)
+	}
+
+	cancelled := make([]*pb.Receipt, 0, len(emails))
+	refunded := make([]*pb.Refund, 0, len(emails))
+	for _, email := range emails {
+		receipt := tm.Receipts[email]
+		if err := tm.cancelTicket(email, receipt, pb.CancellationReason_CANCELLATION_REASON_TRAIN_CANCELLED); err != nil {
+			tm.Logger.Error("BulkCancel failed to release seat",
+				zap.String("email", email),
+				zap.String("section", receipt.Seat.Section),
+				zap.Int32("seat_number", receipt.Seat.SeatNumber),
+				zap.Error(err),
+			)
+			continue
+		}
+		cancelled = append(cancelled, receipt)
+		refunded = append(refunded, &pb.Refund{Email: email, Amount: refunds[email]})
+	}
+
+	tm.Logger.Info("BulkCancel successful",
+		zap.String("section", req.Section),
+		zap.String("from", req.From),
+		zap.String("to", req.To),
+		zap.String("train_id", train),
+		zap.String("departure_date", req.DepartureDate),
+		zap.Int("cancelled_count", len(cancelled)),
+	)
+	return &pb.BulkCancelResponse{
+		Message:           "Tickets cancelled successfully",
+		CancelledReceipts: cancelled,
+		Refunds:           refunded,
+	}, nil
+}
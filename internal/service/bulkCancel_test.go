@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestBulkCancelBySection(t *testing.T) {
+	tm := createTestTicketManager()
+
+	// Round-robin seats users alternately in A and B
+	purchase(t, tm, "test1@example.com", "London", "France")
+	purchase(t, tm, "test2@example.com", "London", "France")
+	purchase(t, tm, "test3@example.com", "London", "France")
+
+	response, err := tm.BulkCancel(context.Background(), &pb.BulkCancelRequest{Section: "A"})
+	assert.NoError(t, err)
+	assert.Len(t, response.CancelledReceipts, 2, "Both users in section A should be cancelled")
+	assert.Equal(t, "test1@example.com", response.CancelledReceipts[0].User.Email, "Cancellations should be ordered by email")
+	assert.Equal(t, "test3@example.com", response.CancelledReceipts[1].User.Email, "Cancellations should be ordered by email")
+
+	assert.Equal(t, 20, tm.SeatManager.Sections["A"].VacantSeats, "All seats in section A should be freed")
+	assert.Equal(t, 19, tm.SeatManager.Sections["B"].VacantSeats, "Section B should be untouched")
+	assert.Len(t, tm.Receipts, 1, "Only the user in section B should remain")
+	assert.Contains(t, tm.Receipts, "test2@example.com")
+}
+
+func TestBulkCancelByRoute(t *testing.T) {
+	tm := createTestTicketManager()
+	tm.StationConnection["London-Paris"] = 25.00
+
+	purchase(t, tm, "test1@example.com", "London", "France")
+	purchase(t, tm, "test2@example.com", "London", "Paris")
+	purchase(t, tm, "test3@example.com", "London", "France")
+
+	response, err := tm.BulkCancel(context.Background(), &pb.BulkCancelRequest{From: "London", To: "France"})
+	assert.NoError(t, err)
+	assert.Len(t, response.CancelledReceipts, 2, "Both London-France bookings should be cancelled")
+	assert.Equal(t, 20, tm.SeatManager.Sections["A"].VacantSeats, "Seats of cancelled bookings should be freed")
+	assert.Equal(t, 19, tm.SeatManager.Sections["B"].VacantSeats, "London-Paris booking should keep its seat")
+	assert.Contains(t, tm.Receipts, "test2@example.com")
+}
+
+func TestBulkCancelInvalidRequest(t *testing.T) {
+	tm := createTestTicketManager()
+
+	tests := []struct {
+		name         string
+		request      *pb.BulkCancelRequest
+		expectedCode codes.Code
+	}{
+		{"Missing Filters", &pb.BulkCancelRequest{}, codes.InvalidArgument},
+		{"Incomplete Route", &pb.BulkCancelRequest{From: "London"}, codes.InvalidArgument},
+		{"Nonexistent Section", &pb.BulkCancelRequest{Section: "C"}, codes.NotFound},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			response, err := tm.BulkCancel(context.Background(), test.request)
+			st, ok := status.FromError(err)
+			assert.True(t, ok)
+			assert.Equal(t, test.expectedCode, st.Code())
+			assert.Nil(t, response)
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"slices"
+
+	"go.uber.org/zap"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// knownCancellationReason reports whether the reason is one of the
+// CancellationReason values, rather than a number unknown to this server.
+func knownCancellationReason(reason pb.CancellationReason) bool {
+	_, known := pb.CancellationReason_name[int32(reason)]
+	return known
+}
+
+// GetCancellationStats counts the tickets cancelled since the server started
+// by reason, telling operational cancellations such as cancelled trains and
+// failed payments apart from riders cancelling themselves.
+func (tm *TicketManager) GetCancellationStats(ctx context.Context, req *pb.GetCancellationStatsRequest) (*pb.GetCancellationStatsResponse, error) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	tm.Logger.Info("GetCancellationStats request received")
+
+	// Validate the request
+	if req == nil {
+		tm.Logger.Error("GetCancellationStats request is nil")
+		return nil, status.Error(codes.InvalidArgument, "request is nil")
+	}
+
+	tm.Logger.Info("GetCancellationStats request",
+		zap.Time("timestamp", tm.Now()),
+	)
+
+	reasons := make([]int32, 0, len(pb.CancellationReason_name))
+	for reason := range pb.CancellationReason_name {
+		reasons = append(reasons, reason)
+	}
+	slices.Sort(reasons)
+
+	response := &pb.GetCancellationStatsResponse{Counts: make([]*pb.CancellationCount, 0, len(reasons))}
+	for _, reason := range reasons {
+		count := int32(tm.cancellationReasons[pb.CancellationReason(reason)])
+		response.Counts = append(response.Counts, &pb.CancellationCount{Reason: pb.CancellationReason(reason), Count: count})
+		response.Total += count
+	}
+
+	tm.Logger.Info("GetCancellationStats successful",
+		zap.Int32("total", response.Total),
+	)
+	return response, nil
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+)
+
+func TestRemoveUserRecordsReason(t *testing.T) {
+	tm := createTestTicketManager()
+	purchase(t, tm, "test@example.com", "London", "France")
+
+	_, err := tm.RemoveUser(context.Background(), &pb.RemoveUserRequest{
+		Email:  "test@example.com",
+		Reason: pb.CancellationReason_CANCELLATION_REASON_DUPLICATE_BOOKING,
+	})
+	assert.NoError(t, err)
+
+	history := tm.History["test@example.com"]
+	cancelled := history[len(history)-1]
+	assert.Equal(t, pb.ReceiptEventType_RECEIPT_EVENT_CANCELLED, cancelled.Type)
+	assert.Equal(t, pb.CancellationReason_CANCELLATION_REASON_DUPLICATE_BOOKING, cancelled.CancellationReason)
+	assert.Equal(t, pb.CancellationReason_CANCELLATION_REASON_UNSPECIFIED, history[0].CancellationReason, "Only cancellations carry a reason")
+}
+
+func TestRemoveUserUnknownReason(t *testing.T) {
+	tm := createTestTicketManager()
+	purchase(t, tm, "test@example.com", "London", "France")
+
+	_, err := tm.RemoveUser(context.Background(), &pb.RemoveUserRequest{
+		Email:  "test@example.com",
+		Reason: pb.CancellationReason(99),
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Contains(t, tm.Receipts, "test@example.com", "The ticket should not be cancelled")
+}
+
+func TestGetCancellationStats(t *testing.T) {
+	tm := createProvisionalTicketManager()
+	for _, email := range []string{"first@example.com", "second@example.com", "third@example.com"} {
+		purchase(t, tm, email, "London", "France")
+	}
+	purchase(t, tm, "unpaid@example.com", "London", "France", provisionally)
+
+	_, err := tm.RemoveUser(context.Background(), &pb.RemoveUserRequest{
+		Email:  "first@example.com",
+		Reason: pb.CancellationReason_CANCELLATION_REASON_USER_REQUESTED,
+	})
+	assert.NoError(t, err)
+	_, err = tm.RemoveUser(context.Background(), &pb.RemoveUserRequest{Email: "second@example.com"})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, tm.ExpireUnpaid(tm.Now().Add(time.Hour)))
+	_, err = tm.BulkCancel(context.Background(), &pb.BulkCancelRequest{From: "London", To: "France"})
+	assert.NoError(t, err)
+
+	response, err := tm.GetCancellationStats(context.Background(), &pb.GetCancellationStatsRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(4), response.Total)
+	assert.Len(t, response.Counts, len(pb.CancellationReason_name), "Every reason should be reported")
+	counts := make(map[pb.CancellationReason]int32)
+	for i, count := range response.Counts {
+		assert.Equal(t, pb.CancellationReason(i), count.Reason, "Reasons should be in enum order")
+		counts[count.Reason] = count.Count
+	}
+	assert.Equal(t, map[pb.CancellationReason]int32{
+		pb.CancellationReason_CANCELLATION_REASON_UNSPECIFIED:       1,
+		pb.CancellationReason_CANCELLATION_REASON_USER_REQUESTED:    1,
+		pb.CancellationReason_CANCELLATION_REASON_TRAIN_CANCELLED:   1,
+		pb.CancellationReason_CANCELLATION_REASON_PAYMENT_FAILED:    1,
+		pb.CancellationReason_CANCELLATION_REASON_DUPLICATE_BOOKING: 0,
+		pb.CancellationReason_CANCELLATION_REASON_OTHER:             0,
+	}, counts)
+
+	_, err = tm.GetCancellationStats(context.Background(), nil)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ChangeJourney moves the booking of a seat from one journey to another,
+// keeping the seat. It changes nothing and fails with ErrSeatOccupied if
+// another rider has booked part of the new journey on the seat.
+func (sm *SeatManager) ChangeJourney(sectionName string, seatNumber int, from, to Journey) error {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	defer sm.seatsChanged()
+
+	section, seat, err := sm.seat(sectionName, seatNumber)
+	if err != nil {
+		return err
+	}
+	if seat.Available || !seat.isBooked(from) {
+		return fmt.Errorf("%w: seat %d in section %s", ErrSeatAlreadyAvailable, seatNumber, sectionName)
+	}
+
+	section.release(seatNumber, from)
+	if !seat.isFree(to) {
+		section.occupy(seatNumber, from)
+		return fmt.Errorf("%w: seat %d in section %s", ErrSeatOccupied, seatNumber, sectionName)
+	}
+	section.occupy(seatNumber, to)
+
+	sm.Logger.Info("Seat journey changed",
+		zap.String("section", sectionName),
+		zap.Int("seat_number", seatNumber),
+		zap.Int("vacant_seats", section.VacantSeats))
+
+	return nil
+}
+
+// ChangeStations moves a user's booking to travel between other stations on
+// the same train and date, keeping their seat, which must be free for the new
+// journey. The fare paid is not recalculated.
+func (tm *TicketManager) ChangeStations(ctx context.Context, req *pb.ChangeStationsRequest) (*pb.ChangeStationsResponse, error) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	tm.Logger.Info("ChangeStations request received")
+
+	// Validate the request
+	if req == nil {
+		tm.Logger.Error("ChangeStations request is nil")
+		return nil, status.Error(codes.InvalidArgument, "request is nil")
+	}
+	from, to := tm.station(req.From), tm.station(req.To)
+	if req.Email == "" || from == "" || to == "" {
+		tm.Logger.Error("ChangeStations request missing required fields",
+			zap.String("email", req.Email),
+			zap.String("from", from),
+			zap.String("to", to),
+		)
+		return nil, status.Error(codes.InvalidArgument, "missing required fields")
+	}
+	if from == to {
+		tm.Logger.Error("ChangeStations origin and destination are the same",
+			zap.String("email", req.Email),
+			zap.String("station", from),
+		)
+		return nil, status.Error(codes.InvalidArgument, "origin and destination must differ")
+	}
+	if err := checkReceiptMask(req.ReceiptMask); err != nil {
+		tm.Logger.Error("ChangeStations invalid receipt mask",
+			zap.Strings("paths", req.ReceiptMask.GetPaths()),
+		)
+		return nil, err
+	}
+
+	tm.Logger.Info("ChangeStations request",
+		zap.String("email", req.Email),
+		zap.String("from", from),
+		zap.String("to", to),
+		zap.Time("timestamp", tm.Now()),
+	)
+
+	receipt, exists := tm.Receipts[req.Email]
+	if !exists {
+		tm.Logger.Error("ChangeStations ticket receipt not found",
+			zap.String("email", req.Email),
+		)
+		return nil, status.Error(codes.NotFound, "ticket receipt not found")
+	}
+	if receipt.Seat == nil {
+		tm.Logger.Error("ChangeStations ticket receipt has no seat",
+			zap.String("email", req.Email),
+		)
+		return nil, errReceiptWithoutSeat
+	}
+
+	// Changing to the stations already booked is a no-op
+	if receipt.From == from && receipt.To == to {
+		tm.Logger.Info("ChangeStations requested stations are the current stations",
+			zap.String("email", req.Email),
+		)
+		return &pb.ChangeStationsResponse{
+			Message:        "Stations unchanged",
+			UpdatedReceipt: maskReceipt(receipt, req.ReceiptMask),
+		}, nil
+	}
+
+	// The new journey must be one that could be bought
+	if _, ok := tm.fare(from, to); !ok {
+		tm.Logger.Error("ChangeStations invalid station names",
+			zap.String("from", from),
+			zap.String("to", to),
+		)
+		return nil, status.Error(codes.InvalidArgument, "invalid station")
+	}
+	if distance, ok := tm.DistanceFares.WithinRange(from, to); !ok {
+		tm.Logger.Error("ChangeStations journey beyond service range",
+			zap.String("from", from),
+			zap.String("to", to),
+			zap.Float64("distance_km", distance),
+			zap.Float64("max_distance_km", tm.DistanceFares.MaxDistanceKm),
+		)
+		return nil, status.Errorf(codes.InvalidArgument, "journey of %.0f km exceeds the maximum of %.0f km", distance, tm.DistanceFares.MaxDistanceKm)
+	}
+	if !tm.withinRouteCap(from, to, 1) {
+		tm.Logger.Error("ChangeStations route cap reached",
+			zap.String("email", req.Email),
+			zap.String("from", from),
+			zap.String("to", to),
+			zap.Int("route_cap", tm.RouteCaps[routeKey(from, to)]),
+		)
+		return nil, retryableError(codes.ResourceExhausted, "route cap reached", seatRetryDelay)
+	}
+
+	oldJourney, err := tm.journey(receipt)
+	if err != nil {
+		return nil, seatError(err)
+	}
+	newJourney, err := tm.SeatManager.Journey(from, to)
+	if err != nil {
+		tm.Logger.Error("ChangeStations journey not on route",
+			zap.String("from", from),
+			zap.String("to", to),
+			zap.Error(err),
+		)
+		return nil, seatError(err)
+	}
+
+	seats := tm.departure(receipt.TrainId, receipt.DepartureDate)
+	if err := seats.ChangeJourney(receipt.Seat.Section, int(receipt.Seat.SeatNumber), oldJourney, newJourney); err != nil {
+		tm.Logger.Error("ChangeStations failed to change journey",
+			zap.String("email", req.Email),
+			zap.String("section", receipt.Seat.Section),
+			zap.Int32("seat_number", receipt.Seat.SeatNumber),
+			zap.Error(err),
+		)
+		return nil, seatError(err)
+	}
+
+	tm.trackDestination(receipt, receipt.Seat.Section, -1)
+	tm.trackRoute(receipt.From, receipt.To, -1)
+	receipt.From, receipt.To = from, to
+	tm.trackDestination(receipt, receipt.Seat.Section, 1)
+	tm.trackRoute(receipt.From, receipt.To, 1)
+	tm.recordEvent(req.Email, pb.ReceiptEventType_RECEIPT_EVENT_ROUTE_CHANGED, receipt)
+	tm.notify(pb.ReceiptEventType_RECEIPT_EVENT_ROUTE_CHANGED, receipt)
+	tm.promoteWaitlist(receipt.Seat.Section, receipt.TrainId, receipt.DepartureDate)
+
+	tm.Logger.Info("ChangeStations successful",
+		zap.String("email", req.Email),
+		zap.String("from", from),
+		zap.String("to", to),
+	)
+	return &pb.ChangeStationsResponse{
+		Message:        "Stations changed successfully",
+		UpdatedReceipt: maskReceipt(receipt, req.ReceiptMask),
+	}, nil
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestChangeStations(t *testing.T) {
+	tm := createTestTicketManager()
+	tm.SeatManager.Route = []string{"London", "Paris", "Lyon"}
+	tm.StationConnection["London-Paris"] = 15.00
+	tm.StationConnection["Paris-Lyon"] = 10.00
+	tm.StationConnection["London-Lyon"] = 22.00
+
+	first := purchase(t, tm, "test1@example.com", "London", "Paris")
+	second := purchase(t, tm, "test2@example.com", "Paris", "Lyon")
+	assert.Equal(t, first.Seat.SeatNumber, second.Seat.SeatNumber, "Non-overlapping journeys should share a seat")
+
+	tests := []struct {
+		name         string
+		request      *pb.ChangeStationsRequest
+		expectedCode codes.Code
+	}{
+		{"Nil Request", nil, codes.InvalidArgument},
+		{"Missing Email", &pb.ChangeStationsRequest{From: "London", To: "Lyon"}, codes.InvalidArgument},
+		{"Missing Station", &pb.ChangeStationsRequest{Email: "test1@example.com", From: "London"}, codes.InvalidArgument},
+		{"Same Stations", &pb.ChangeStationsRequest{Email: "test1@example.com", From: "Lyon", To: "Lyon"}, codes.InvalidArgument},
+		{"Unknown User", &pb.ChangeStationsRequest{Email: "nonexist@example.com", From: "London", To: "Lyon"}, codes.NotFound},
+		{"Invalid Station", &pb.ChangeStationsRequest{Email: "test1@example.com", From: "London", To: "Berlin"}, codes.InvalidArgument},
+		{"Seat Taken On New Segment", &pb.ChangeStationsRequest{Email: "test1@example.com", From: "London", To: "Lyon"}, codes.AlreadyExists},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := tm.ChangeStations(context.Background(), test.request)
+			assert.Equal(t, test.expectedCode, status.Code(err))
+		})
+	}
+	assert.Equal(t, "Paris", tm.Receipts["test1@example.com"].To, "A failed change should keep the booking")
+
+	response, err := tm.ChangeStations(context.Background(), &pb.ChangeStationsRequest{Email: "test1@example.com", From: "London", To: "Paris"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Stations unchanged", response.Message)
+
+	// Once the other rider cancels, the whole route is free on the seat
+	_, err = tm.RemoveUser(context.Background(), &pb.RemoveUserRequest{Email: "test2@example.com"})
+	assert.NoError(t, err)
+	response, err = tm.ChangeStations(context.Background(), &pb.ChangeStationsRequest{Email: "test1@example.com", From: "London", To: "Lyon"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Lyon", response.UpdatedReceipt.To)
+	assert.Equal(t, first.Seat.SeatNumber, response.UpdatedReceipt.Seat.SeatNumber, "The rider should keep their seat")
+	assert.Equal(t, first.PricePaid, response.UpdatedReceipt.PricePaid, "The fare should not be recalculated")
+	assert.Zero(t, tm.routeCounts[routeKey("London", "Paris")])
+	assert.Equal(t, 1, tm.routeCounts[routeKey("London", "Lyon")])
+
+	// The seat is booked for the new journey, so nobody else can take part of it
+	_, err = tm.PurchaseTicket(context.Background(), &pb.PurchaseTicketRequest{
+		User: &pb.User{FirstName: "Sanjay", LastName: "Kishor", Email: "test3@example.com"},
+		From: "Paris",
+		To:   "Lyon",
+	})
+	assert.NoError(t, err)
+	assert.False(t, tm.Receipts["test3@example.com"].Seat.SeatNumber == first.Seat.SeatNumber && tm.Receipts["test3@example.com"].Seat.Section == first.Seat.Section)
+}
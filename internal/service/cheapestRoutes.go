@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GetCheapestRoutes lists the priced routes from a station that can be booked
+// right now, cheapest first. Routes with no free seats for the journey, or
+// that reached their route cap, are left out.
+func (tm *TicketManager) GetCheapestRoutes(ctx context.Context, req *pb.GetCheapestRoutesRequest) (*pb.GetCheapestRoutesResponse, error) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	tm.Logger.Info("GetCheapestRoutes request received")
+
+	// Validate the request
+	if req == nil {
+		tm.Logger.Error("GetCheapestRoutes request is nil")
+		return nil, status.Error(codes.InvalidArgument, "request is nil")
+	}
+	req.From = tm.station(req.From)
+	if req.From == "" {
+		tm.Logger.Error("GetCheapestRoutes request missing required fields",
+			zap.String("from", req.From),
+		)
+		return nil, status.Error(codes.InvalidArgument, "missing required fields")
+	}
+	if req.Limit < 0 {
+		tm.Logger.Error("GetCheapestRoutes invalid limit",
+			zap.Int32("limit", req.Limit),
+		)
+		return nil, status.Error(codes.InvalidArgument, "limit must not be negative")
+	}
+
+	tm.Logger.Info("GetCheapestRoutes request",
+		zap.String("from", req.From),
+		zap.Int32("limit", req.Limit),
+		zap.Time("timestamp", tm.Now()),
+	)
+
+	now := tm.Now()
+	routes := make([]*pb.RouteFare, 0)
+	for key := range tm.StationConnection {
+		from, to, found := strings.Cut(key, "-")
+		if !found || from != req.From {
+			continue
+		}
+		price, ok := tm.fare(from, to)
+		if !ok {
+			continue
+		}
+
+		// Skip destinations that can't be booked right now
+		journey, err := tm.SeatManager.Journey(from, to)
+		if err != nil || !tm.withinRouteCap(from, to, 1) {
+			continue
+		}
+		free := tm.SeatManager.FreeSeats(journey)
+		if free == 0 {
+			continue
+		}
+
+		fare, _ := tm.effectiveFare(price, SectionPrice{}, now)
+		routes = append(routes, &pb.RouteFare{
+			From:           from,
+			To:             to,
+			Price:          fare,
+			AvailableSeats: int32(free),
+		})
+	}
+
+	// Cheapest first, then by destination so equal fares list predictably
+	sort.Slice(routes, func(i, j int) bool {
+		if routes[i].Price != routes[j].Price {
+			return routes[i].Price < routes[j].Price
+		}
+		return routes[i].To < routes[j].To
+	})
+	if req.Limit > 0 && len(routes) > int(req.Limit) {
+		routes = routes[:req.Limit]
+	}
+
+	tm.Logger.Info("GetCheapestRoutes successful",
+		zap.String("from", req.From),
+		zap.Int("route_count", len(routes)),
+	)
+
+	return &pb.GetCheapestRoutesResponse{
+		Routes: routes,
+	}, nil
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sanjaykishor/rail-connect/internal/config"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// createRouteFareTicketManager returns a two-seat train on the route London,
+// Paris, Lyon, Geneva with fares from London and Paris.
+func createRouteFareTicketManager() *TicketManager {
+	seatManager := NewSeatManager([]config.SectionConfig{
+		{Name: "A", MaxSeats: 1},
+		{Name: "B", MaxSeats: 1},
+	}, zap.NewNop())
+	seatManager.Route = []string{"London", "Paris", "Lyon", "Geneva"}
+	return NewTicketManager(seatManager, map[string]float64{
+		"London-Paris":  30.00,
+		"London-Lyon":   20.00,
+		"London-Geneva": 40.00,
+		"Paris-Lyon":    10.00,
+	}, zap.NewNop())
+}
+
+// routeDestinations returns the destination of each route, in order.
+func routeDestinations(routes []*pb.RouteFare) []string {
+	destinations := make([]string, 0, len(routes))
+	for _, route := range routes {
+		destinations = append(destinations, route.To)
+	}
+	return destinations
+}
+
+func TestGetCheapestRoutes(t *testing.T) {
+	tm := createRouteFareTicketManager()
+
+	response, err := tm.GetCheapestRoutes(context.Background(), &pb.GetCheapestRoutesRequest{From: "London"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Lyon", "Paris", "Geneva"}, routeDestinations(response.Routes), "Routes should be sorted by price")
+	assert.Equal(t, 20.00, response.Routes[0].Price)
+	assert.Equal(t, int32(2), response.Routes[0].AvailableSeats)
+
+	response, err = tm.GetCheapestRoutes(context.Background(), &pb.GetCheapestRoutesRequest{From: "London", Limit: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Lyon", "Paris"}, routeDestinations(response.Routes), "Only the cheapest routes should be returned")
+}
+
+func TestGetCheapestRoutesNearlyFull(t *testing.T) {
+	tm := createRouteFareTicketManager()
+
+	// Seat A1 is taken to Paris and again from Lyon, and seat B1 from Paris, so
+	// only London to Paris can still be booked
+	tm.StationConnection["Lyon-Geneva"] = 15.00
+	tm.StationConnection["Paris-Geneva"] = 25.00
+	for _, req := range []*pb.PurchaseTicketRequest{
+		{User: &pb.User{Email: "first@example.com"}, From: "London", To: "Paris"},
+		{User: &pb.User{Email: "second@example.com"}, From: "Lyon", To: "Geneva"},
+		{User: &pb.User{Email: "third@example.com"}, From: "Paris", To: "Geneva"},
+	} {
+		_, err := tm.PurchaseTicket(context.Background(), req)
+		assert.NoError(t, err)
+	}
+
+	response, err := tm.GetCheapestRoutes(context.Background(), &pb.GetCheapestRoutesRequest{From: "London"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Paris"}, routeDestinations(response.Routes), "Routes without a free seat should be left out")
+	assert.Equal(t, int32(1), response.Routes[0].AvailableSeats, "Only seat B1 is free to Paris")
+
+	// A route cap also makes a route unavailable
+	tm.RouteCaps = map[string]int{"London-Paris": 1}
+	response, err = tm.GetCheapestRoutes(context.Background(), &pb.GetCheapestRoutesRequest{From: "London"})
+	assert.NoError(t, err)
+	assert.Empty(t, response.Routes, "Routes at their cap should be left out")
+}
+
+func TestGetCheapestRoutesTimeWindow(t *testing.T) {
+	tm := createRouteFareTicketManager()
+	tm.TimeWindows = []TimeWindow{{Name: "peak", Start: 7 * time.Hour, End: 10 * time.Hour, Multiplier: 2}}
+	tm.Clock = NewFakeClock(time.Date(2024, 1, 1, 8, 0, 0, 0, time.Local))
+
+	response, err := tm.GetCheapestRoutes(context.Background(), &pb.GetCheapestRoutesRequest{From: "Paris"})
+	assert.NoError(t, err)
+	assert.Len(t, response.Routes, 1)
+	assert.Equal(t, 20.00, response.Routes[0].Price, "Fares should include the current time window")
+}
+
+func TestGetCheapestRoutesInvalidRequest(t *testing.T) {
+	tm := createRouteFareTicketManager()
+
+	_, err := tm.GetCheapestRoutes(context.Background(), nil)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = tm.GetCheapestRoutes(context.Background(), &pb.GetCheapestRoutesRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = tm.GetCheapestRoutes(context.Background(), &pb.GetCheapestRoutesRequest{From: "London", Limit: -1})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
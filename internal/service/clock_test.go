@@ -0,0 +1,29 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFakeClock(t *testing.T) {
+	start := time.Date(2024, 3, 10, 9, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+	assert.Equal(t, start, clock.Now())
+	assert.Equal(t, start, clock.Now(), "A fake clock should not move on its own")
+
+	clock.Advance(90 * time.Second)
+	assert.Equal(t, start.Add(90*time.Second), clock.Now())
+
+	clock.Set(start)
+	assert.Equal(t, start, clock.Now())
+}
+
+func TestClockSharedByDepartures(t *testing.T) {
+	tm := createTestTicketManager()
+	clock := NewFakeClock(time.Date(2024, 3, 10, 9, 0, 0, 0, time.UTC))
+	tm.SeatManager.Clock = clock
+
+	assert.Same(t, clock, tm.departure("", "2030-01-01").Clock, "Dated departures should read the same clock")
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/sanjaykishor/rail-connect/internal/config"
+	pb "github.com/sanjaykishor/rail-connect/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// configChangeTypes maps each kind of config.ConfigChange to its proto value.
+var configChangeTypes = map[string]pb.ConfigChangeType{
+	config.ChangeAdded:    pb.ConfigChangeType_CONFIG_CHANGE_TYPE_ADDED,
+	config.ChangeRemoved:  pb.ConfigChangeType_CONFIG_CHANGE_TYPE_REMOVED,
+	config.ChangeModified: pb.ConfigChangeType_CONFIG_CHANGE_TYPE_CHANGED,
+}
+
+// RecordConfigReload compares a reloaded configuration with the one loaded
+// before it, the configuration the service was built from on the first
+// reload. The changes are returned and kept for GetConfigChanges, and cfg
+// becomes the base of the next comparison. Recording a reload applies none of
+// its settings.
+func (tm *TicketManager) RecordConfigReload(cfg *config.Config) []config.ConfigChange {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	previous := tm.reloadedConfig
+	if previous == nil {
+		previous = tm.Config
+	}
+	if previous == nil {
+		previous = &config.Config{}
+	}
+
+	tm.configChanges = config.ConfigDiff(previous, cfg)
+	tm.reloadedConfig = cfg
+	tm.configReloadedAt = tm.Now()
+	return tm.configChanges
+}
+
+// GetConfigChanges returns the differences the latest configuration reload
+// made to the file, for operators checking exactly what changed.
+func (tm *TicketManager) GetConfigChanges(ctx context.Context, req *pb.GetConfigChangesRequest) (*pb.GetConfigChangesResponse, error) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	tm.Logger.Info("GetConfigChanges request received")
+
+	// Validate the request
+	if req == nil {
+		tm.Logger.Error("GetConfigChanges request is nil")
+		return nil, status.Error(codes.InvalidArgument, "request is nil")
+	}
+
+	tm.Logger.Info("GetConfigChanges request",
+		zap.Time("timestamp", tm.Now()),
+	)
+
+	response := &pb.GetConfigChangesResponse{Changes: make([]*pb.ConfigChange, 0, len(tm.configChanges))}
+	if !tm.configReloadedAt.IsZero() {
+		response.ReloadedAt = tm.configReloadedAt.Unix()
+	}
+	for _, change := range tm.configChanges {
+		response.Changes = append(response.Changes, &pb.ConfigChange{
+			Path:     change.Path,
+			Change:   configChangeTypes[change.Change],
+			OldValue: change.Old,
+			NewValue: change.New,
+		})
+	}
+
+	tm.Logger.Info("GetConfigChanges successful",
+		zap.Int("change_count", len(response.Changes)),
+	)
+	return response, nil
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/sanjaykishor/rail-connect/internal/config"
+	pb "github.com/sanjaykishor/rail-connect/proto"
+)
+
+func TestGetConfigChanges(t *testing.T) {
+	tm := createTestTicketManager()
+	tm.Clock = NewFakeClock(time.Date(2024, 3, 10, 9, 0, 0, 0, time.UTC))
+	tm.Config = &config.Config{
+		Sections: []config.SectionConfig{{Name: "A", MaxSeats: 20}, {Name: "B", MaxSeats: 20}},
+		Stations: config.StationConfig{Prices: map[string]float64{"London-France": 20}},
+	}
+
+	response, err := tm.GetConfigChanges(context.Background(), &pb.GetConfigChangesRequest{})
+	assert.NoError(t, err)
+	assert.Empty(t, response.Changes)
+	assert.Zero(t, response.ReloadedAt, "Nothing has been reloaded yet")
+
+	// The first reload is compared with the configuration the service was built from
+	first := &config.Config{
+		Sections: []config.SectionConfig{{Name: "A", MaxSeats: 20}, {Name: "B", MaxSeats: 20}},
+		Stations: config.StationConfig{Prices: map[string]float64{"London-France": 25}},
+	}
+	assert.Len(t, tm.RecordConfigReload(first), 1)
+
+	// Later ones with the previous reload
+	second := &config.Config{
+		Sections: []config.SectionConfig{{Name: "A", MaxSeats: 20}},
+		Stations: config.StationConfig{Prices: map[string]float64{"London-France": 25}},
+	}
+	tm.RecordConfigReload(second)
+
+	response, err = tm.GetConfigChanges(context.Background(), &pb.GetConfigChangesRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, tm.Now().Unix(), response.ReloadedAt)
+	assert.Len(t, response.Changes, 1)
+	assert.Equal(t, "sections[B]", response.Changes[0].Path)
+	assert.Equal(t, pb.ConfigChangeType_CONFIG_CHANGE_TYPE_REMOVED, response.Changes[0].Change)
+	assert.Empty(t, response.Changes[0].NewValue)
+
+	_, err = tm.GetConfigChanges(context.Background(), nil)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
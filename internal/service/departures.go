@@ -0,0 +1,196 @@
+package service
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// departureDateLayout is the format of departure dates in requests and receipts.
+const departureDateLayout = time.DateOnly
+
+// departureKey identifies the seats of a train on a departure date.
+type departureKey struct {
+	train string
+	date  string
+}
+
+// departure returns the seats of the train running on the date, creating them
+// the first time the train and date are booked. The undated default train is
+// tm.SeatManager. Callers must hold tm.mu.
+func (tm *TicketManager) departure(train, date string) *SeatManager {
+	if tm.isDefaultDeparture(train, date) {
+		return tm.SeatManager
+	}
+	key := departureKey{tm.trainOrDefault(train), date}
+	seats, exists := tm.departures[key]
+	if !exists {
+		seats = tm.SeatManager.newDeparture()
+		tm.departures[key] = seats
+		tm.departuresAdded()
+	}
+	return seats
+}
+
+// existingDeparture returns the seats of the train running on the date
+// without creating them, and false if nobody has booked that departure yet.
+// Callers must hold tm.mu.
+func (tm *TicketManager) existingDeparture(train, date string) (*SeatManager, bool) {
+	if tm.isDefaultDeparture(train, date) {
+		return tm.SeatManager, true
+	}
+	seats, exists := tm.departures[departureKey{tm.trainOrDefault(train), date}]
+	return seats, exists
+}
+
+// readDeparture returns the seats of the train running on the date for reads,
+// which must not create the departure: one nobody has booked yet is an
+// unstored empty copy of the default layout. Callers must hold tm.mu.
+func (tm *TicketManager) readDeparture(train, date string) *SeatManager {
+	if seats, exists := tm.existingDeparture(train, date); exists {
+		return seats
+	}
+	return tm.SeatManager.newDeparture()
+}
+
+// departureKeys returns the departures other than the undated default train
+// that have been booked, ordered by train and then date. Callers must hold
+// tm.mu.
+func (tm *TicketManager) departureKeys() []departureKey {
+	keys := slices.Collect(maps.Keys(tm.departures))
+	slices.SortFunc(keys, func(a, b departureKey) int {
+		if a.train != b.train {
+			return strings.Compare(a.train, b.train)
+		}
+		return strings.Compare(a.date, b.date)
+	})
+	return keys
+}
+
+// departuresAdded wakes the streams waiting for a departure to be first
+// booked. Callers must hold tm.mu.
+func (tm *TicketManager) departuresAdded() {
+	close(tm.departureAdded)
+	tm.departureAdded = make(chan struct{})
+}
+
+// trainOrDefault returns the train, or the default train if it is empty, as it
+// is on receipts written before trains were configured.
+func (tm *TicketManager) trainOrDefault(train string) string {
+	if train == "" {
+		return tm.DefaultTrain
+	}
+	return train
+}
+
+// sameDeparture reports whether two receipts are for the same train and date.
+func (tm *TicketManager) sameDeparture(a, b *pb.Receipt) bool {
+	return tm.trainOrDefault(a.TrainId) == tm.trainOrDefault(b.TrainId) && a.DepartureDate == b.DepartureDate
+}
+
+// isDefaultDeparture reports whether the train and date name the undated
+// default train. An empty train is the default train.
+func (tm *TicketManager) isDefaultDeparture(train, date string) bool {
+	return date == "" && (train == "" || train == tm.DefaultTrain)
+}
+
+// resolveTrain returns the train a request names, or the default train if it
+// names none, with a NotFound status if the server doesn't run it.
+func (tm *TicketManager) resolveTrain(train string) (string, error) {
+	if train == "" || train == tm.DefaultTrain {
+		return tm.DefaultTrain, nil
+	}
+	if !slices.Contains(tm.Trains, train) {
+		return "", status.Error(codes.NotFound, "train not found")
+	}
+	return train, nil
+}
+
+// resolveDeparture returns the train a request names, like resolveTrain, after
+// checking that its departure date, if any, is YYYY-MM-DD. Unlike
+// checkDepartureDate it accepts any date, so departures can still be looked up
+// once booking them has closed.
+func (tm *TicketManager) resolveDeparture(train, date string) (string, error) {
+	if date != "" {
+		if _, err := time.Parse(departureDateLayout, date); err != nil {
+			return "", status.Error(codes.InvalidArgument, "departure date must be YYYY-MM-DD")
+		}
+	}
+	return tm.resolveTrain(train)
+}
+
+// onDeparture reports whether the receipt is for the train and date.
+func (tm *TicketManager) onDeparture(receipt *pb.Receipt, train, date string) bool {
+	return tm.trainOrDefault(receipt.TrainId) == train && receipt.DepartureDate == date
+}
+
+// checkDepartureDate returns an InvalidArgument status unless the date can be
+// booked now: dated departures must be enabled, and the date must fall between
+// today and AdvanceDays from today.
+func (tm *TicketManager) checkDepartureDate(date string, now time.Time) error {
+	if tm.AdvanceDays <= 0 {
+		return status.Error(codes.InvalidArgument, "dated departures are not enabled")
+	}
+
+	departure, err := time.ParseInLocation(departureDateLayout, date, now.Location())
+	if err != nil {
+		return status.Error(codes.InvalidArgument, "departure date must be YYYY-MM-DD")
+	}
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	switch {
+	case departure.Before(today):
+		return status.Error(codes.InvalidArgument, "departure date is in the past")
+	case departure.After(today.AddDate(0, 0, tm.AdvanceDays)):
+		return status.Error(codes.InvalidArgument, "departure date is too far ahead")
+	}
+	return nil
+}
+
+// newDeparture returns a seat manager for another train laid out like sm: the
+// same sections at their current size, blocked seats, quiesced sections, route
+// and seating options, with every other seat vacant. Occupancy alerts are only raised for
+// the default train, while seat assignment metrics are shared with it.
+func (sm *SeatManager) newDeparture() *SeatManager {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	departure := &SeatManager{
+		Sections:          make(map[string]*Section, len(sm.Sections)),
+		SectionOrder:      slices.Clone(sm.SectionOrder),
+		Logger:            sm.Logger,
+		DefaultSection:    sm.DefaultSection,
+		Route:             sm.Route,
+		Positions:         sm.Positions,
+		RowLayout:         sm.RowLayout,
+		AssignLockTimeout: sm.AssignLockTimeout,
+		Clock:             sm.Clock,
+		overflowPolicies:  maps.Clone(sm.overflowPolicies),
+		sectionPrices:     maps.Clone(sm.sectionPrices),
+		restricted:        maps.Clone(sm.restricted),
+		quiesced:          maps.Clone(sm.quiesced),
+		OnOccupancyAlert:  NopOccupancyAlert,
+		SectionsScanned:   sm.SectionsScanned,
+	}
+	for name, section := range sm.Sections {
+		departure.Sections[name] = emptySection(section)
+	}
+	return departure
+}
+
+// emptySection returns a section of the same size with only the blocked seats
+// of section taken.
+func emptySection(section *Section) *Section {
+	empty := newSection(section.Name, section.MaxSeats)
+	empty.Descending = section.Descending
+	for seatNum, seat := range section.Seats {
+		if seat.Blocked {
+			empty.block(seatNum)
+		}
+	}
+	return empty
+}
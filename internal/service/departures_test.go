@@ -0,0 +1,351 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sanjaykishor/rail-connect/internal/config"
+	"github.com/stretchr/testify/assert"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// createDatedTicketManager returns a ticket manager taking bookings up to a
+// week ahead, with the clock fixed on 2024-03-10.
+func createDatedTicketManager() *TicketManager {
+	tm := createTestTicketManager()
+	tm.AdvanceDays = 7
+	tm.Clock = NewFakeClock(time.Date(2024, 3, 10, 9, 0, 0, 0, time.UTC))
+	return tm
+}
+
+func TestDatedDeparturesDontCollide(t *testing.T) {
+	tm := createDatedTicketManager()
+
+	monday := purchase(t, tm, "monday@example.com", "London", "France", onDate("2024-03-11"))
+	tuesday := purchase(t, tm, "tuesday@example.com", "London", "France", onDate("2024-03-12"))
+	undated := purchase(t, tm, "undated@example.com", "London", "France", onDate(""))
+
+	for _, receipt := range []*pb.Receipt{monday, tuesday, undated} {
+		assert.Equal(t, "A", receipt.Seat.Section, "Each departure should start with its own empty seats")
+		assert.Equal(t, int32(1), receipt.Seat.SeatNumber)
+	}
+	assert.Equal(t, "2024-03-11", monday.DepartureDate)
+	assert.Empty(t, undated.DepartureDate)
+	assert.Equal(t, 19, tm.SeatManager.Sections["A"].VacantSeats, "Dated bookings should not use the default train")
+	assert.Equal(t, 19, tm.departures[departureKey{"", "2024-03-11"}].Sections["A"].VacantSeats)
+
+	// Cancelling frees the seat on its own date only
+	_, err := tm.RemoveUser(context.Background(), &pb.RemoveUserRequest{Email: "monday@example.com"})
+	assert.NoError(t, err)
+	assert.Equal(t, 20, tm.departures[departureKey{"", "2024-03-11"}].Sections["A"].VacantSeats)
+	assert.Equal(t, 19, tm.departures[departureKey{"", "2024-03-12"}].Sections["A"].VacantSeats)
+	assert.Equal(t, 19, tm.SeatManager.Sections["A"].VacantSeats)
+}
+
+func TestOneTicketAcrossDepartures(t *testing.T) {
+	tm := createDatedTicketManager()
+	purchase(t, tm, "test@example.com", "London", "France", onDate("2024-03-12"))
+
+	// A second ticket on another date would orphan the first seat
+	_, err := tm.PurchaseTicket(context.Background(), &pb.PurchaseTicketRequest{
+		User:          &pb.User{FirstName: "Sanjay", LastName: "Kishor", Email: "test@example.com"},
+		From:          "London",
+		To:            "France",
+		DepartureDate: "2024-03-13",
+	})
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+	assert.NotContains(t, tm.departures, departureKey{"", "2024-03-13"}, "A rejected purchase should not create a departure")
+
+	// Cancelling each booking frees its seat and counts
+	_, err = tm.RemoveUser(context.Background(), &pb.RemoveUserRequest{Email: "test@example.com"})
+	assert.NoError(t, err)
+	purchase(t, tm, "test@example.com", "London", "France", onDate("2024-03-13"))
+	_, err = tm.RemoveUser(context.Background(), &pb.RemoveUserRequest{Email: "test@example.com"})
+	assert.NoError(t, err)
+
+	for _, date := range []string{"2024-03-12", "2024-03-13"} {
+		assert.Equal(t, 20, tm.departures[departureKey{"", date}].Sections["A"].VacantSeats, "Seat on %s should be free", date)
+	}
+	assert.Empty(t, tm.Receipts)
+	assert.Zero(t, tm.routeCounts[routeKey("London", "France")])
+	for _, date := range []string{"2024-03-12", "2024-03-13"} {
+		assert.Zero(t, tm.destinationCounts[destinationKey{departureKey{"", date}, "A"}]["France"])
+	}
+}
+
+func TestDestinationAffinityPerDeparture(t *testing.T) {
+	tm := createDatedTicketManager()
+	tm.DestinationAffinity = true
+
+	_, err := tm.PurchaseTicket(context.Background(), &pb.PurchaseTicketRequest{
+		User:              &pb.User{FirstName: "Sanjay", LastName: "Kishor", Email: "monday@example.com"},
+		From:              "London",
+		To:                "France",
+		DepartureDate:     "2024-03-11",
+		PreferredSections: []string{"B"},
+	})
+	assert.NoError(t, err)
+
+	tuesday := purchase(t, tm, "tuesday@example.com", "London", "France", onDate("2024-03-12"))
+	assert.Equal(t, "A", tuesday.Seat.Section, "Riders on another departure should not draw riders to their section")
+	monday := purchase(t, tm, "monday2@example.com", "London", "France", onDate("2024-03-11"))
+	assert.Equal(t, "B", monday.Seat.Section, "Riders to the same destination on a departure should share a section")
+}
+
+func TestDatedDepartureCopiesLayout(t *testing.T) {
+	tm := createDatedTicketManager()
+	assert.NoError(t, tm.SeatManager.BlockSeat("A", 1))
+	assert.NoError(t, tm.SeatManager.ResizeSection("B", 5))
+
+	receipt := purchase(t, tm, "test@example.com", "London", "France", onDate("2024-03-11"))
+	assert.Equal(t, int32(2), receipt.Seat.SeatNumber, "Blocked seats should stay blocked on dated departures")
+	assert.Equal(t, 5, tm.departures[departureKey{"", "2024-03-11"}].Sections["B"].MaxSeats)
+}
+
+func TestDatedDepartureValidation(t *testing.T) {
+	tests := []struct {
+		name        string
+		advanceDays int
+		date        string
+	}{
+		{"Disabled", 0, "2024-03-11"},
+		{"Bad Format", 7, "11/03/2024"},
+		{"In The Past", 7, "2024-03-09"},
+		{"Too Far Ahead", 7, "2024-03-18"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tm := createDatedTicketManager()
+			tm.AdvanceDays = test.advanceDays
+			_, err := tm.PurchaseTicket(context.Background(), &pb.PurchaseTicketRequest{
+				User:          &pb.User{FirstName: "Sanjay", LastName: "Kishor", Email: "test@example.com"},
+				From:          "London",
+				To:            "France",
+				DepartureDate: test.date,
+			})
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+			assert.Empty(t, tm.departures, "Rejected dates should not create a departure")
+		})
+	}
+
+	// Today and the last bookable day are both accepted
+	tm := createDatedTicketManager()
+	purchase(t, tm, "today@example.com", "London", "France", onDate("2024-03-10"))
+	purchase(t, tm, "lastday@example.com", "London", "France", onDate("2024-03-17"))
+}
+
+func TestGetUsersBySectionDeparture(t *testing.T) {
+	tm := createDatedTicketManager()
+	purchase(t, tm, "monday@example.com", "London", "France", onDate("2024-03-11"))
+	purchase(t, tm, "undated@example.com", "London", "France", onDate(""))
+
+	response, err := tm.GetUsersBySection(context.Background(), &pb.GetUsersBySectionRequest{Section: "A", DepartureDate: "2024-03-11"})
+	assert.NoError(t, err)
+	assert.Len(t, response.Users, 1)
+	assert.Equal(t, "monday@example.com", response.Users[0].User.Email)
+
+	response, err = tm.GetUsersBySection(context.Background(), &pb.GetUsersBySectionRequest{Section: "A"})
+	assert.NoError(t, err)
+	assert.Len(t, response.Users, 1)
+	assert.Equal(t, "undated@example.com", response.Users[0].User.Email, "Undated requests should list the default train")
+
+	// Listing a departure nobody has booked reports its empty layout
+	// without creating it
+	response, err = tm.GetUsersBySection(context.Background(), &pb.GetUsersBySectionRequest{Section: "A", DepartureDate: "2024-03-12"})
+	assert.NoError(t, err)
+	assert.Empty(t, response.Users)
+	assert.Equal(t, int32(20), response.VacantSeats)
+	assert.NotContains(t, tm.departures, departureKey{tm.DefaultTrain, "2024-03-12"})
+
+	_, err = tm.GetUsersBySection(context.Background(), &pb.GetUsersBySectionRequest{Section: "A", DepartureDate: "Monday"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestGetSectionVacancyDeparture(t *testing.T) {
+	tm := createDatedTicketManager()
+	monday := purchase(t, tm, "monday@example.com", "London", "France", onDate("2024-03-11"))
+	purchase(t, tm, "undated@example.com", "London", "France", onDate(""))
+	purchase(t, tm, "undated2@example.com", "London", "France", onDate(""))
+	purchase(t, tm, "undated3@example.com", "London", "France", onDate(""))
+
+	vacancy := func(date string) int32 {
+		t.Helper()
+		response, err := tm.GetSectionVacancy(context.Background(), &pb.GetSectionVacancyRequest{Section: monday.Seat.Section, DepartureDate: date})
+		assert.NoError(t, err)
+		assert.Equal(t, int32(20), response.MaxSeats)
+		return response.VacantSeats
+	}
+	assert.Equal(t, int32(19), vacancy("2024-03-11"), "Dated requests should read their own departure")
+	assert.Equal(t, int32(18), vacancy(""), "Undated requests should read the default train")
+
+	// A departure nobody has booked is reported empty without being created
+	assert.Equal(t, int32(20), vacancy("2024-03-12"))
+	assert.NotContains(t, tm.departures, departureKey{tm.DefaultTrain, "2024-03-12"})
+}
+
+func TestSwapSeatsAcrossDepartures(t *testing.T) {
+	tm := createDatedTicketManager()
+	purchase(t, tm, "monday@example.com", "London", "France", onDate("2024-03-11"))
+	purchase(t, tm, "tuesday@example.com", "London", "France", onDate("2024-03-12"))
+
+	_, err := tm.SwapSeats(context.Background(), &pb.SwapSeatsRequest{EmailA: "monday@example.com", EmailB: "tuesday@example.com"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestUpdateUserSeatDeparture(t *testing.T) {
+	tm := createDatedTicketManager()
+	purchase(t, tm, "test@example.com", "London", "France", onDate("2024-03-11"))
+
+	response, err := tm.UpdateUserSeat(context.Background(), &pb.UpdateUserSeatRequest{Email: "test@example.com", NewSeat: &pb.Seat{Section: "B", SeatNumber: 4}})
+	assert.NoError(t, err)
+	assert.Equal(t, "2024-03-11", response.UpdatedReceipt.DepartureDate)
+
+	departure := tm.departures[departureKey{"", "2024-03-11"}]
+	assert.True(t, departure.Sections["A"].Seats[1].Available, "The old seat should be freed on the departure")
+	assert.False(t, departure.Sections["B"].Seats[4].Available)
+	assert.True(t, tm.SeatManager.Sections["B"].Seats[4].Available, "The default train should be untouched")
+}
+
+func TestSnapshotDepartures(t *testing.T) {
+	tm := createDatedTicketManager()
+	purchase(t, tm, "monday@example.com", "London", "France", onDate("2024-03-11"))
+	purchase(t, tm, "undated@example.com", "London", "France", onDate(""))
+
+	var buf bytes.Buffer
+	assert.NoError(t, tm.Snapshot(&buf))
+
+	restored := createDatedTicketManager()
+	assert.NoError(t, restored.Restore(&buf))
+	assert.Equal(t, tm.departures[departureKey{"", "2024-03-11"}].Sections, restored.departures[departureKey{"", "2024-03-11"}].Sections, "Dated seats should be restored")
+	assert.Equal(t, tm.SeatManager.Sections, restored.SeatManager.Sections)
+
+	// The restored seat is taken, so the next booking on the date gets another
+	receipt := purchase(t, restored, "other@example.com", "London", "France", onDate("2024-03-11"))
+	assert.Equal(t, "B", receipt.Seat.Section)
+}
+
+func TestWaitlistDeparture(t *testing.T) {
+	tm := createOverflowTicketManager(config.OverflowWaitlist)
+	tm.AdvanceDays = 7
+	tm.Clock = NewFakeClock(time.Date(2024, 3, 10, 9, 0, 0, 0, time.UTC))
+	fillSectionA(t, tm)
+
+	// Fill section A on Monday too, then waitlist a Monday rider
+	for _, email := range []string{"monday1@example.com", "monday2@example.com", "waiting@example.com"} {
+		_, err := tm.PurchaseTicket(context.Background(), &pb.PurchaseTicketRequest{
+			User:              &pb.User{FirstName: "Sanjay", LastName: "Kishor", Email: email},
+			From:              "London",
+			To:                "France",
+			PreferredSections: []string{"A"},
+			DepartureDate:     "2024-03-11",
+		})
+		assert.NoError(t, err)
+	}
+	assert.Len(t, tm.waitlists["A"], 1)
+
+	// A seat freed on the default train is no use to the Monday rider
+	_, err := tm.RemoveUser(context.Background(), &pb.RemoveUserRequest{Email: "first@example.com"})
+	assert.NoError(t, err)
+	assert.NotContains(t, tm.Receipts, "waiting@example.com")
+	assert.Len(t, tm.waitlists["A"], 1)
+
+	_, err = tm.RemoveUser(context.Background(), &pb.RemoveUserRequest{Email: "monday1@example.com"})
+	assert.NoError(t, err)
+	receipt, exists := tm.Receipts["waiting@example.com"]
+	assert.True(t, exists, "A seat freed on Monday should go to the Monday rider")
+	assert.Equal(t, "2024-03-11", receipt.DepartureDate)
+	assert.Empty(t, tm.waitlists["A"])
+}
+
+func TestBulkCancelDeparture(t *testing.T) {
+	tm := createDatedTicketManager()
+	purchase(t, tm, "undated@example.com", "London", "France", onDate(""))
+	purchase(t, tm, "monday@example.com", "London", "France", onDate("2024-03-11"))
+	purchase(t, tm, "tuesday@example.com", "London", "France", onDate("2024-03-12"))
+
+	response, err := tm.BulkCancel(context.Background(), &pb.BulkCancelRequest{From: "London", To: "France", DepartureDate: "2024-03-11"})
+	assert.NoError(t, err)
+	assert.Len(t, response.CancelledReceipts, 1, "Only the named departure should be cancelled")
+	assert.Equal(t, "monday@example.com", response.CancelledReceipts[0].User.Email)
+	assert.Contains(t, tm.Receipts, "undated@example.com")
+	assert.Contains(t, tm.Receipts, "tuesday@example.com")
+
+	// Without a date only the default train is cancelled
+	response, err = tm.BulkCancel(context.Background(), &pb.BulkCancelRequest{From: "London", To: "France"})
+	assert.NoError(t, err)
+	assert.Len(t, response.CancelledReceipts, 1)
+	assert.Equal(t, "undated@example.com", response.CancelledReceipts[0].User.Email)
+	assert.Contains(t, tm.Receipts, "tuesday@example.com")
+
+	_, err = tm.BulkCancel(context.Background(), &pb.BulkCancelRequest{Section: "A", DepartureDate: "Monday"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestSeatRPCsDeparture(t *testing.T) {
+	tm := createDatedTicketManager()
+	dated := purchase(t, tm, "dated@example.com", "London", "France", onDate("2024-03-11"))
+	ctx := context.Background()
+
+	// GetSeat reads the seat and its riders on the named departure only
+	seat, err := tm.GetSeat(ctx, &pb.GetSeatRequest{Section: "A", SeatNumber: 1, DepartureDate: "2024-03-11"})
+	assert.NoError(t, err)
+	assert.False(t, seat.Available)
+	assert.Len(t, seat.Occupants, 1)
+	assert.Equal(t, dated.BookingReference, seat.Occupants[0].BookingReference)
+	seat, err = tm.GetSeat(ctx, &pb.GetSeatRequest{Section: "A", SeatNumber: 1})
+	assert.NoError(t, err)
+	assert.True(t, seat.Available)
+	assert.Empty(t, seat.Occupants)
+
+	// Blocking a seat on one departure leaves the others alone
+	blocked, err := tm.BlockSeat(ctx, &pb.BlockSeatRequest{Section: "A", SeatNumber: 2, DepartureDate: "2024-03-11"})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(18), blocked.VacantSeats)
+	assert.Equal(t, 20, tm.SeatManager.VacantSeats("A"))
+	nearby, err := tm.GetNearbyAvailableSeats(ctx, &pb.GetNearbyAvailableSeatsRequest{Section: "A", SeatNumber: 1, Radius: 2, DepartureDate: "2024-03-11"})
+	assert.NoError(t, err)
+	assert.Equal(t, []*pb.Seat{{Section: "A", SeatNumber: 3}}, nearby.Seats)
+	unblocked, err := tm.UnblockSeat(ctx, &pb.UnblockSeatRequest{Section: "A", SeatNumber: 2, DepartureDate: "2024-03-11"})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(19), unblocked.VacantSeats)
+
+	// Listing and verifying a departure reads its own seats
+	sections, err := tm.GetAllSections(ctx, &pb.GetAllSectionsRequest{DepartureDate: "2024-03-11"})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(19), sections.Sections[0].VacantSeats)
+	sections, err = tm.GetAllSections(ctx, &pb.GetAllSectionsRequest{DepartureDate: "2024-03-12"})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(20), sections.Sections[0].VacantSeats)
+	assert.NotContains(t, tm.departures, departureKey{"", "2024-03-12"}, "Reading a departure should not create it")
+	verified, err := tm.Verify(ctx, &pb.VerifyRequest{DepartureDate: "2024-03-11"})
+	assert.NoError(t, err)
+	assert.True(t, verified.Healthy)
+
+	for name, call := range map[string]func() error{
+		"GetSeat": func() error {
+			_, err := tm.GetSeat(ctx, &pb.GetSeatRequest{Section: "A", SeatNumber: 1, TrainId: "T9"})
+			return err
+		},
+		"BlockSeat": func() error {
+			_, err := tm.BlockSeat(ctx, &pb.BlockSeatRequest{Section: "A", SeatNumber: 1, TrainId: "T9"})
+			return err
+		},
+		"UnblockSeat": func() error {
+			_, err := tm.UnblockSeat(ctx, &pb.UnblockSeatRequest{Section: "A", SeatNumber: 1, TrainId: "T9"})
+			return err
+		},
+		"Verify": func() error { _, err := tm.Verify(ctx, &pb.VerifyRequest{TrainId: "T9"}); return err },
+		"GetAllSections": func() error {
+			_, err := tm.GetAllSections(ctx, &pb.GetAllSectionsRequest{TrainId: "T9"})
+			return err
+		},
+	} {
+		assert.Equal(t, codes.NotFound, status.Code(call()), "%s should reject an unknown train", name)
+	}
+}
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// seatRetryDelay is the backoff suggested to clients when the train is full.
+const seatRetryDelay = 30 * time.Second
+
+// lockRetryDelay is the backoff suggested to clients when a seat assignment
+// timed out waiting for the seat lock.
+const lockRetryDelay = time.Second
+
+// Errors returned by SeatManager. They are wrapped with details, so callers
+// should match them with errors.Is.
+var (
+	ErrSectionNotFound      = errors.New("section not found")
+	ErrSeatNotFound         = errors.New("seat not found")
+	ErrSeatOccupied         = errors.New("seat is occupied")
+	ErrSeatAlreadyAvailable = errors.New("seat is not occupied")
+	ErrNoSeatsAvailable     = errors.New("no available seats")
+	ErrJourneyNotOnRoute    = errors.New("journey is not on the route")
+	ErrSeatBlocked          = errors.New("seat is blocked")
+	ErrSeatNotBlocked       = errors.New("seat is not blocked")
+	ErrSectionFull          = errors.New("section is full")
+	ErrStationNotOnJourney  = errors.New("station is not on the journey")
+	ErrLockTimeout          = errors.New("timed out waiting for the seat lock")
+	ErrSectionRestricted    = errors.New("section is restricted")
+	ErrSectionQuiesced      = errors.New("section is not accepting new bookings")
+)
+
+// errReceiptWithoutSeat is returned for a stored receipt that has no seat,
+// which only corrupted or externally loaded data can produce.
+var errReceiptWithoutSeat = status.Error(codes.Internal, "ticket receipt has no seat")
+
+// seatError maps a SeatManager failure to a gRPC status with a precise code.
+func seatError(err error) error {
+	switch {
+	case errors.Is(err, ErrSectionNotFound), errors.Is(err, ErrSeatNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, ErrSeatOccupied):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, ErrSeatAlreadyAvailable), errors.Is(err, ErrSeatBlocked), errors.Is(err, ErrSeatNotBlocked), errors.Is(err, ErrSectionQuiesced):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, ErrSectionRestricted):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, ErrSectionFull):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.Is(err, ErrNoSeatsAvailable):
+		return retryableError(codes.ResourceExhausted, err.Error(), seatRetryDelay)
+	case errors.Is(err, ErrLockTimeout):
+		return retryableError(codes.Unavailable, err.Error(), lockRetryDelay)
+	case errors.Is(err, ErrJourneyNotOnRoute), errors.Is(err, ErrStationNotOnJourney):
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+// retryableError returns a status carrying a RetryInfo detail that tells the
+// client how long to back off before trying again.
+func retryableError(code codes.Code, msg string, delay time.Duration) error {
+	st := status.New(code, msg)
+	detailed, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(delay)})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
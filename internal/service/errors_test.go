@@ -0,0 +1,91 @@
+package service
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestSeatManagerSentinelErrors(t *testing.T) {
+	seatManager := CreateSeatManager()
+	seatManager.Sections["A"].Seats[1].Available = false
+	seatManager.Sections["A"].VacantSeats--
+
+	tests := []struct {
+		name     string
+		err      error
+		expected error
+	}{
+		{"Release - Nonexistent Section", seatManager.ReleaseSeat("C", 1, WholeRoute), ErrSectionNotFound},
+		{"Release - Nonexistent Seat", seatManager.ReleaseSeat("A", 100, WholeRoute), ErrSeatNotFound},
+		{"Release - Already Available", seatManager.ReleaseSeat("A", 2, WholeRoute), ErrSeatAlreadyAvailable},
+		{"Update - Nonexistent Section", seatManager.UpdateSeat(1, "A", 1, "C", WholeRoute), ErrSectionNotFound},
+		{"Update - Nonexistent Seat", seatManager.UpdateSeat(1, "A", 100, "B", WholeRoute), ErrSeatNotFound},
+		{"Swap - Not Occupied", seatManager.SwapSeats("A", 1, WholeRoute, "B", 1, WholeRoute), ErrSeatAlreadyAvailable},
+		{"Swap - Nonexistent Seat", seatManager.SwapSeats("A", 1, WholeRoute, "B", 100, WholeRoute), ErrSeatNotFound},
+		{"Resize - Nonexistent Section", seatManager.ResizeSection("C", 10), ErrSectionNotFound},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.ErrorIs(t, test.err, test.expected)
+		})
+	}
+
+	// Fill the train to exhaust availability
+	for {
+		if _, _, err := seatManager.AssignSeat(WholeRoute); err != nil {
+			assert.ErrorIs(t, err, ErrNoSeatsAvailable, "Full train should report no available seats")
+			break
+		}
+	}
+
+	_, err := seatManager.AssignSeatInSection("A", WholeRoute)
+	assert.ErrorIs(t, err, ErrNoSeatsAvailable, "Full section should report no available seats")
+
+	_, err = seatManager.AssignSeatInSection("C", WholeRoute)
+	assert.ErrorIs(t, err, ErrSectionNotFound)
+
+	err = seatManager.UpdateSeat(1, "A", 2, "A", WholeRoute)
+	assert.ErrorIs(t, err, ErrSeatOccupied, "Occupied target should report the seat is occupied")
+
+	err = seatManager.ResizeSection("A", 10)
+	assert.ErrorIs(t, err, ErrSeatOccupied, "Shrinking below occupied seats should report the seat is occupied")
+}
+
+func TestSeatError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected codes.Code
+	}{
+		{fmt.Errorf("%w: C", ErrSectionNotFound), codes.NotFound},
+		{fmt.Errorf("%w: seat 100", ErrSeatNotFound), codes.NotFound},
+		{fmt.Errorf("%w: seat 1", ErrSeatOccupied), codes.AlreadyExists},
+		{fmt.Errorf("%w: seat 1", ErrSeatAlreadyAvailable), codes.FailedPrecondition},
+		{ErrNoSeatsAvailable, codes.ResourceExhausted},
+		{fmt.Errorf("%w: Lyon to London", ErrJourneyNotOnRoute), codes.InvalidArgument},
+		{fmt.Errorf("%w: Lyon", ErrStationNotOnJourney), codes.InvalidArgument},
+		{fmt.Errorf("unexpected"), codes.Internal},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, status.Code(seatError(test.err)), test.err.Error())
+	}
+}
+
+func TestSeatErrorRetryInfo(t *testing.T) {
+	st := status.Convert(seatError(ErrNoSeatsAvailable))
+	assert.Equal(t, codes.ResourceExhausted, st.Code())
+
+	details := st.Details()
+	assert.Len(t, details, 1, "No seats should carry a retry hint")
+	retryInfo, ok := details[0].(*errdetails.RetryInfo)
+	assert.True(t, ok, "Detail should be RetryInfo")
+	assert.Equal(t, seatRetryDelay, retryInfo.RetryDelay.AsDuration())
+
+	assert.Empty(t, status.Convert(seatError(ErrSeatNotFound)).Details(), "Other errors should carry no retry hint")
+}
@@ -0,0 +1,56 @@
+package service
+
+import "github.com/sanjaykishor/rail-connect/internal/config"
+
+// FareClass is a pool of Seats discounted fares, each Multiplier times the
+// standard fare. RejectWhenSoldOut refuses purchases for the class once the
+// pool is sold instead of charging them the standard fare.
+type FareClass struct {
+	Seats             int
+	Multiplier        float64
+	RejectWhenSoldOut bool
+}
+
+// NewFareClasses converts configured fare classes, keyed by name.
+func NewFareClasses(classes []config.FareClassConfig) map[string]FareClass {
+	converted := make(map[string]FareClass, len(classes))
+	for _, class := range classes {
+		converted[class.Name] = FareClass{
+			Seats:             class.Seats,
+			Multiplier:        class.Multiplier,
+			RejectWhenSoldOut: class.SoldOut == config.SoldOutReject,
+		}
+	}
+	return converted
+}
+
+// fareClassAvailable reports whether that many more tickets can be sold in a
+// fare class. The standard fare, named "", is never sold out. Callers must
+// hold tm.mu.
+func (tm *TicketManager) fareClassAvailable(class string, tickets int) bool {
+	if class == "" {
+		return true
+	}
+	return tm.fareClassCounts[class]+tickets <= tm.FareClasses[class].Seats
+}
+
+// fareClassFare returns the base fare of a ticket in a fare class, rounded to
+// the cent. Callers must hold tm.mu.
+func (tm *TicketManager) fareClassFare(class string, price float64) float64 {
+	if class == "" {
+		return price
+	}
+	return roundFare(price, tm.FareClasses[class].Multiplier, tm.FareRounding)
+}
+
+// trackFareClass adjusts the number of tickets sold in a fare class, so
+// cancelled tickets return their fare to the pool. Callers must hold tm.mu.
+func (tm *TicketManager) trackFareClass(class string, delta int) {
+	if class == "" {
+		return
+	}
+	tm.fareClassCounts[class] += delta
+	if tm.fareClassCounts[class] <= 0 {
+		delete(tm.fareClassCounts, class)
+	}
+}
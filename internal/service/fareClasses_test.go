@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/sanjaykishor/rail-connect/internal/config"
+	pb "github.com/sanjaykishor/rail-connect/proto"
+)
+
+// createFareClassTicketManager returns a test ticket manager selling two saver
+// fares at 70% of the standard fare, with the given sold out policy.
+func createFareClassTicketManager(soldOut string) *TicketManager {
+	tm := createTestTicketManager()
+	tm.FareClasses = NewFareClasses([]config.FareClassConfig{
+		{Name: "saver", Seats: 2, Multiplier: 0.7, SoldOut: soldOut},
+	})
+	return tm
+}
+
+func TestFareClassUpsell(t *testing.T) {
+	tm := createFareClassTicketManager(config.SoldOutUpsell)
+
+	for _, email := range []string{"saver1@example.com", "saver2@example.com"} {
+		response, err := tryPurchase(tm, email, inClass("saver"))
+		assert.NoError(t, err)
+		assert.Equal(t, "saver", response.Receipt.FareClass)
+		assert.Equal(t, 14.00, response.Receipt.PricePaid, "Saver fares should be discounted")
+		assert.Equal(t, 14.00, response.Receipt.BaseFare)
+	}
+
+	// The pool is exhausted, so the next saver purchase pays the standard fare
+	response, err := tryPurchase(tm, "late@example.com", inClass("saver"))
+	assert.NoError(t, err)
+	assert.Empty(t, response.Receipt.FareClass)
+	assert.Equal(t, 20.00, response.Receipt.PricePaid, "A sold out fare class should be upsold to the standard fare")
+
+	// Standard purchases never touch the pool
+	standard, err := tryPurchase(tm, "standard@example.com", inClass(""))
+	assert.NoError(t, err)
+	assert.Equal(t, 20.00, standard.Receipt.PricePaid)
+	assert.Equal(t, 2, tm.fareClassCounts["saver"])
+
+	// Cancelling a saver ticket returns its fare to the pool
+	_, err = tm.RemoveUser(context.Background(), &pb.RemoveUserRequest{Email: "saver1@example.com"})
+	assert.NoError(t, err)
+	response, err = tryPurchase(tm, "returned@example.com", inClass("saver"))
+	assert.NoError(t, err)
+	assert.Equal(t, "saver", response.Receipt.FareClass)
+	assert.Equal(t, 14.00, response.Receipt.PricePaid)
+}
+
+func TestFareClassReject(t *testing.T) {
+	tm := createFareClassTicketManager(config.SoldOutReject)
+
+	// A pair needs a fare for each rider
+	pair, err := tm.PurchaseTicket(context.Background(), &pb.PurchaseTicketRequest{
+		User:      &pb.User{FirstName: "Sanjay", LastName: "Kishor", Email: "test@example.com"},
+		Companion: &pb.User{FirstName: "Priya", LastName: "Kishor", Email: "companion@example.com"},
+		From:      "London",
+		To:        "France",
+		FareClass: "saver",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "saver", pair.Receipt.FareClass)
+	assert.Equal(t, "saver", pair.CompanionReceipt.FareClass)
+
+	_, err = tryPurchase(tm, "late@example.com", inClass("saver"))
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err), "A sold out fare class should be rejected when configured")
+	assert.NotContains(t, tm.Receipts, "late@example.com")
+
+	_, err = tryPurchase(tm, "unknown@example.com", inClass("super-saver"))
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
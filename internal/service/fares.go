@@ -0,0 +1,189 @@
+package service
+
+import (
+	"math"
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/sanjaykishor/rail-connect/internal/config"
+)
+
+// earthRadiusKm is the mean radius of the Earth used for distance fares.
+const earthRadiusKm = 6371.0
+
+// DistanceFares prices journeys between stations that have no configured fare
+// by the great-circle distance between them. MaxDistanceKm, when positive,
+// caps how far apart located stations on a journey may be.
+type DistanceFares struct {
+	RatePerKm     float64
+	Stations      map[string]config.Coordinate
+	MaxDistanceKm float64
+}
+
+// Fare returns the distance-based price between two located stations, rounded
+// to the cent. It reports false if pricing is disabled or a station is unknown.
+func (f DistanceFares) Fare(from, to string) (float64, bool) {
+	if f.RatePerKm <= 0 || from == to {
+		return 0, false
+	}
+
+	distance, ok := f.Distance(from, to)
+	if !ok {
+		return 0, false
+	}
+
+	fare := distance * f.RatePerKm
+	return math.Round(fare*100) / 100, true
+}
+
+// Distance returns the great-circle distance in kilometres between two
+// stations. It reports false if either station is not located.
+func (f DistanceFares) Distance(from, to string) (float64, bool) {
+	origin, originOk := f.Stations[from]
+	destination, destinationOk := f.Stations[to]
+	if !originOk || !destinationOk {
+		return 0, false
+	}
+	return distanceKm(origin, destination), true
+}
+
+// WithinRange reports whether a journey is within MaxDistanceKm, returning its
+// distance. Journeys are always within range when the limit is disabled or
+// a station is not located, as their length is unknown.
+func (f DistanceFares) WithinRange(from, to string) (float64, bool) {
+	if f.MaxDistanceKm <= 0 {
+		return 0, true
+	}
+	distance, ok := f.Distance(from, to)
+	return distance, !ok || distance <= f.MaxDistanceKm
+}
+
+// distanceKm returns the haversine distance between two coordinates.
+func distanceKm(a, b config.Coordinate) float64 {
+	lat1, lat2 := a.Latitude*math.Pi/180, b.Latitude*math.Pi/180
+	dLat := lat2 - lat1
+	dLon := (b.Longitude - a.Longitude) * math.Pi / 180
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(h))
+}
+
+// fare returns the price of a journey: the configured price for the station
+// pair, or a distance-based fare when the pair is not configured. A pair
+// configured at 0.00 is a free route, not a missing one.
+func (tm *TicketManager) fare(from, to string) (float64, bool) {
+	if price, exists := tm.StationConnection[routeKey(from, to)]; exists {
+		return price, true
+	}
+	return tm.DistanceFares.Fare(from, to)
+}
+
+// TimeWindow scales fares booked between Start and End, measured from
+// midnight in local time. A window ending before it starts runs past midnight.
+type TimeWindow struct {
+	Name       string
+	Start      time.Duration
+	End        time.Duration
+	Multiplier float64
+}
+
+// NewTimeWindows converts configured time windows, keeping their order.
+func NewTimeWindows(windows []config.TimeWindowConfig) ([]TimeWindow, error) {
+	converted := make([]TimeWindow, 0, len(windows))
+	for _, window := range windows {
+		start, err := config.ParseClock(window.Start)
+		if err != nil {
+			return nil, err
+		}
+		end, err := config.ParseClock(window.End)
+		if err != nil {
+			return nil, err
+		}
+		converted = append(converted, TimeWindow{
+			Name:       window.Name,
+			Start:      start,
+			End:        end,
+			Multiplier: window.Multiplier,
+		})
+	}
+	return converted, nil
+}
+
+// contains reports whether t falls within the window. The start is inclusive
+// and the end exclusive.
+func (w TimeWindow) contains(t time.Time) bool {
+	hour, minute, second := t.Clock()
+	offset := time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute + time.Duration(second)*time.Second
+	if w.Start <= w.End {
+		return offset >= w.Start && offset < w.End
+	}
+	return offset >= w.Start || offset < w.End
+}
+
+// effectiveFare applies the first time window containing now and then the
+// section price to the base fare, rounded as set by FareRounding. It returns no
+// window name when none applies.
+func (tm *TicketManager) effectiveFare(base float64, price SectionPrice, now time.Time) (float64, string) {
+	multiplier, name := 1.0, ""
+	for _, window := range tm.TimeWindows {
+		if window.contains(now) {
+			multiplier, name = window.Multiplier, window.Name
+			break
+		}
+	}
+
+	fare := new(big.Rat).Mul(decimal(base), decimal(multiplier))
+	fare.Mul(fare, decimal(price.multiplier()))
+	fare.Add(fare, decimal(price.Surcharge))
+	return roundRat(fare, tm.FareRounding), name
+}
+
+// addBookingFee returns the booking fee, rounded to the cent like fares, and
+// the total of the fare and the fee. The sum is exact, so the itemized fare and
+// fee always add up to the total.
+func (tm *TicketManager) addBookingFee(fare float64) (fee, total float64) {
+	fee = roundRat(decimal(tm.BookingFee), tm.FareRounding)
+	total, _ = new(big.Rat).Add(decimal(fare), decimal(fee)).Float64()
+	return fee, total
+}
+
+// roundFare multiplies the base fare and rounds the result to the cent with
+// the given config rounding mode. Both numbers are taken as the decimals they
+// print as, so 20.00 * 1.15 is exactly 23.00 rather than 22.999999999999996.
+func roundFare(base, multiplier float64, mode string) float64 {
+	return roundRat(new(big.Rat).Mul(decimal(base), decimal(multiplier)), mode)
+}
+
+// roundRat rounds an exact fare to the cent with the given config rounding
+// mode.
+func roundRat(fare *big.Rat, mode string) float64 {
+	if mode == config.RoundingNone {
+		amount, _ := fare.Float64()
+		return amount
+	}
+
+	// Fares are never negative, so integer division rounds down
+	cents := new(big.Rat).Mul(fare, big.NewRat(100, 1))
+	whole, remainder := new(big.Int).QuoRem(cents.Num(), cents.Denom(), new(big.Int))
+	switch mode {
+	case config.RoundingDown:
+	case config.RoundingUp:
+		if remainder.Sign() != 0 {
+			whole.Add(whole, big.NewInt(1))
+		}
+	default:
+		// Round half up: the remainder is at least half the denominator
+		if new(big.Int).Lsh(remainder, 1).Cmp(cents.Denom()) >= 0 {
+			whole.Add(whole, big.NewInt(1))
+		}
+	}
+	amount, _ := new(big.Rat).SetFrac(whole, big.NewInt(100)).Float64()
+	return amount
+}
+
+// decimal converts a float to the exact decimal it prints as.
+func decimal(f float64) *big.Rat {
+	r, _ := new(big.Rat).SetString(strconv.FormatFloat(f, 'f', -1, 64))
+	return r
+}
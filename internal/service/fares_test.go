@@ -0,0 +1,244 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/sanjaykishor/rail-connect/internal/config"
+	"github.com/stretchr/testify/assert"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var testCoordinates = map[string]config.Coordinate{
+	"London": {Latitude: 51.5072, Longitude: -0.1276},
+	"Paris":  {Latitude: 48.8566, Longitude: 2.3522},
+	"France": {Latitude: 48.8566, Longitude: 2.3522},
+}
+
+func TestDistanceFares(t *testing.T) {
+	fares := DistanceFares{RatePerKm: 0.1, Stations: testCoordinates}
+
+	fare, ok := fares.Fare("London", "Paris")
+	assert.True(t, ok)
+	assert.InDelta(t, 34.4, fare, 0.1, "London to Paris is about 344 km")
+
+	_, ok = fares.Fare("London", "Lyon")
+	assert.False(t, ok, "Unlocated stations should not be priced")
+
+	_, ok = fares.Fare("London", "London")
+	assert.False(t, ok, "A journey to the same station should not be priced")
+
+	_, ok = DistanceFares{Stations: testCoordinates}.Fare("London", "Paris")
+	assert.False(t, ok, "Zero rate should disable distance pricing")
+}
+
+func TestPurchaseTicketFares(t *testing.T) {
+	tm := createTestTicketManager()
+	tm.DistanceFares = DistanceFares{RatePerKm: 0.1, Stations: testCoordinates}
+
+	// The explicit price wins over the distance fare
+	receipt := purchase(t, tm, "test1@example.com", "London", "France")
+	assert.Equal(t, 20.00, receipt.PricePaid, "Configured pair should use its explicit price")
+
+	// An unconfigured pair falls back to the distance fare
+	receipt = purchase(t, tm, "test2@example.com", "London", "Paris")
+	assert.InDelta(t, 34.4, receipt.PricePaid, 0.1, "Unconfigured pair should use the computed fare")
+
+	_, err := tm.PurchaseTicket(context.Background(), &pb.PurchaseTicketRequest{
+		User: &pb.User{FirstName: "Sanjay", LastName: "Kishor", Email: "test3@example.com"},
+		From: "London",
+		To:   "Lyon",
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "Unpriced pair should be rejected")
+}
+
+func TestPurchaseTicketFreeRoute(t *testing.T) {
+	tm := createTestTicketManager()
+	tm.StationConnection["London-Promo"] = 0.00
+	tm.DistanceFares = DistanceFares{RatePerKm: 0.1, Stations: map[string]config.Coordinate{
+		"London": testCoordinates["London"],
+		"Promo":  testCoordinates["Paris"],
+	}}
+
+	receipt := purchase(t, tm, "test@example.com", "London", "Promo")
+	assert.Equal(t, 0.00, receipt.PricePaid, "A route configured as free should be honoured, not priced by distance")
+	assert.Equal(t, 0.00, receipt.BaseFare)
+}
+
+func TestPurchaseTicketMaxDistance(t *testing.T) {
+	tm := createTestTicketManager()
+	tm.StationConnection["London-Lyon"] = 90.00
+	tm.DistanceFares = DistanceFares{RatePerKm: 0.1, MaxDistanceKm: 500, Stations: map[string]config.Coordinate{
+		"London": testCoordinates["London"],
+		"Paris":  testCoordinates["Paris"],
+		"Lyon":   {Latitude: 45.7640, Longitude: 4.8357},
+	}}
+
+	// London to Paris is about 344 km, within range
+	purchase(t, tm, "short@example.com", "London", "Paris")
+
+	// London to Lyon is about 750 km, so even its configured fare is not sold
+	_, err := tm.PurchaseTicket(context.Background(), &pb.PurchaseTicketRequest{
+		User: &pb.User{FirstName: "Sanjay", LastName: "Kishor", Email: "long@example.com"},
+		From: "London",
+		To:   "Lyon",
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Contains(t, status.Convert(err).Message(), "exceeds the maximum of 500 km")
+	assert.NotContains(t, tm.Receipts, "long@example.com")
+
+	// Unlocated stations have no known length, so the limit can't apply
+	purchase(t, tm, "unlocated@example.com", "London", "France")
+}
+
+func TestTimeWindowedPricing(t *testing.T) {
+	tm := createTestTicketManager()
+	windows, err := NewTimeWindows([]config.TimeWindowConfig{
+		{Name: "morning_peak", Start: "07:00", End: "09:30", Multiplier: 1.5},
+		{Name: "night", Start: "23:00", End: "05:00", Multiplier: 0.75},
+	})
+	assert.NoError(t, err)
+	tm.TimeWindows = windows
+
+	tests := []struct {
+		email      string
+		bookedAt   string
+		pricePaid  float64
+		fareWindow string
+	}{
+		{"peak@example.com", "08:15", 30.00, "morning_peak"},
+		{"peak-start@example.com", "07:00", 30.00, "morning_peak"},
+		{"peak-end@example.com", "09:30", 20.00, ""},
+		{"offpeak@example.com", "13:00", 20.00, ""},
+		{"late@example.com", "23:30", 15.00, "night"},
+		{"early@example.com", "04:59", 15.00, "night"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.bookedAt, func(t *testing.T) {
+			bookedAt, err := time.ParseInLocation("15:04", test.bookedAt, time.Local)
+			assert.NoError(t, err)
+			tm.Clock = NewFakeClock(bookedAt)
+
+			receipt := purchase(t, tm, test.email, "London", "France")
+			assert.Equal(t, 20.00, receipt.BaseFare, "Base fare should be the configured price")
+			assert.Equal(t, test.pricePaid, receipt.PricePaid)
+			assert.Equal(t, test.fareWindow, receipt.FareWindow)
+		})
+	}
+}
+
+func TestNewTimeWindowsInvalid(t *testing.T) {
+	_, err := NewTimeWindows([]config.TimeWindowConfig{{Name: "peak", Start: "7am", End: "09:30", Multiplier: 1.5}})
+	assert.Error(t, err)
+}
+
+func TestRoundFare(t *testing.T) {
+	tests := []struct {
+		mode     string
+		base     float64
+		expected float64
+	}{
+		// 20.00 * 1.15 is 22.999999999999996 in floating point
+		{config.RoundingNone, 20.00, 23.00},
+		{config.RoundingNearestCent, 20.00, 23.00},
+		{config.RoundingUp, 20.00, 23.00},
+		{config.RoundingDown, 20.00, 23.00},
+		{"", 20.00, 23.00},
+
+		// 19.99 * 1.15 is 22.9885
+		{config.RoundingNone, 19.99, 22.9885},
+		{config.RoundingNearestCent, 19.99, 22.99},
+		{config.RoundingUp, 19.99, 22.99},
+		{config.RoundingDown, 19.99, 22.98},
+
+		// 10.01 * 1.15 is 11.5115
+		{config.RoundingNearestCent, 10.01, 11.51},
+		{config.RoundingUp, 10.01, 11.52},
+		{config.RoundingDown, 10.01, 11.51},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%s/%.2f", test.mode, test.base), func(t *testing.T) {
+			assert.Equal(t, test.expected, roundFare(test.base, 1.15, test.mode))
+		})
+	}
+}
+
+func TestPurchaseTicketFareRounding(t *testing.T) {
+	tm := createTestTicketManager()
+	tm.FareRounding = config.RoundingDown
+	tm.TimeWindows = []TimeWindow{{Name: "peak", Start: 7 * time.Hour, End: 10 * time.Hour, Multiplier: 1.15}}
+	tm.Clock = NewFakeClock(time.Date(2024, 1, 1, 8, 0, 0, 0, time.Local))
+
+	receipt := purchase(t, tm, "test@example.com", "London", "France")
+	assert.Equal(t, 20.00, receipt.BaseFare)
+	assert.Equal(t, 23.00, receipt.PricePaid, "Rounding down should not lose a cent to float drift")
+}
+
+func TestPurchaseTicketBookingFee(t *testing.T) {
+	tm := createTestTicketManager()
+
+	// Without a fee the total is the fare
+	receipt := purchase(t, tm, "nofee@example.com", "London", "France")
+	assert.Equal(t, 20.00, receipt.PricePaid)
+	assert.Equal(t, 20.00, receipt.Fare)
+	assert.Zero(t, receipt.BookingFee)
+
+	// The fee is added after the fare is scaled and rounded, and is never
+	// scaled itself
+	tm.BookingFee = 1.10
+	tm.FareRounding = config.RoundingDown
+	tm.TimeWindows = []TimeWindow{{Name: "peak", Start: 7 * time.Hour, End: 10 * time.Hour, Multiplier: 1.15}}
+	tm.Clock = NewFakeClock(time.Date(2024, 1, 1, 8, 0, 0, 0, time.Local))
+	receipt = purchase(t, tm, "test@example.com", "London", "France")
+	assert.Equal(t, 23.00, receipt.Fare)
+	assert.Equal(t, 1.10, receipt.BookingFee)
+	assert.Equal(t, 24.10, receipt.PricePaid, "The total should be the fare plus the fee")
+}
+
+func TestPurchaseTicketBookingFeeSectionPrice(t *testing.T) {
+	tm := createPricedTicketManager()
+	tm.BookingFee = 0.70
+
+	// A's multiplier and B's surcharge apply to the fare only
+	for _, expected := range []struct {
+		section string
+		fare    float64
+		total   float64
+	}{{"A", 30.00, 30.70}, {"B", 25.00, 25.70}, {"C", 20.00, 20.70}} {
+		receipt := purchase(t, tm, "test"+expected.section+"@example.com", "London", "France")
+		assert.Equal(t, expected.section, receipt.Seat.Section)
+		assert.Equal(t, expected.fare, receipt.Fare)
+		assert.Equal(t, expected.total, receipt.PricePaid)
+		assert.Equal(t, receipt.PricePaid, receipt.Fare+receipt.BookingFee)
+	}
+}
+
+func TestAddBookingFee(t *testing.T) {
+	tests := []struct {
+		mode  string
+		fee   float64
+		fare  float64
+		total float64
+	}{
+		{config.RoundingNearestCent, 0, 12.34, 12.34},
+		{config.RoundingNearestCent, 0.1, 0.2, 0.3},
+		{config.RoundingNearestCent, 1.005, 10.00, 11.01},
+		{config.RoundingDown, 1.005, 10.00, 11.00},
+		{config.RoundingNone, 1.005, 10.00, 11.005},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%s/%v", test.mode, test.fee), func(t *testing.T) {
+			tm := createTestTicketManager()
+			tm.BookingFee, tm.FareRounding = test.fee, test.mode
+			_, total := tm.addBookingFee(test.fare)
+			assert.Equal(t, test.total, total)
+		})
+	}
+}
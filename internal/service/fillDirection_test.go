@@ -0,0 +1,52 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/sanjaykishor/rail-connect/internal/config"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+// createDescendingSeatManager returns a seat manager with one section of four
+// seats filled from the back.
+func createDescendingSeatManager() *SeatManager {
+	return NewSeatManager([]config.SectionConfig{
+		{Name: "A", MaxSeats: 4, FillDirection: config.FillDescending},
+	}, zap.NewNop())
+}
+
+func TestFillDirectionDescending(t *testing.T) {
+	seatManager := createDescendingSeatManager()
+
+	for _, want := range []int{4, 3} {
+		seat, err := seatManager.AssignSeatInSection("A", WholeRoute)
+		assert.NoError(t, err)
+		assert.Equal(t, want, seat, "Descending sections should fill from the highest seat")
+	}
+
+	// A freed seat at the back is the next one handed out
+	assert.NoError(t, seatManager.ReleaseSeat("A", 4, WholeRoute))
+	seat, err := seatManager.AssignSeatInSection("A", WholeRoute)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, seat)
+}
+
+func TestFillDirectionSkipsBlocked(t *testing.T) {
+	seatManager := createDescendingSeatManager()
+	assert.NoError(t, seatManager.BlockSeat("A", 4))
+
+	section, seat, err := seatManager.AssignSeat(WholeRoute)
+	assert.NoError(t, err)
+	assert.Equal(t, "A", section)
+	assert.Equal(t, 3, seat, "Blocked seats should be skipped")
+}
+
+func TestFillDirectionDeparture(t *testing.T) {
+	seatManager := createDescendingSeatManager()
+	tm := NewTicketManager(seatManager, map[string]float64{"London-France": 20.00}, zap.NewNop())
+
+	seat, err := tm.departure("", "2030-01-01").AssignSeatInSection("A", WholeRoute)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, seat, "Dated departures should keep the fill direction")
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ForceReassign moves a passenger to an exact seat for support staff. The
+// target must be free for the passenger's journey, but no other rule applies:
+// any section can be chosen whatever its overflow policy or waitlist. The move
+// is recorded in the passenger's receipt history with its reason and logged
+// as a warning for auditing.
+func (tm *TicketManager) ForceReassign(ctx context.Context, req *pb.ForceReassignRequest) (*pb.ForceReassignResponse, error) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	tm.Logger.Info("ForceReassign request received")
+
+	// Validate the request
+	if req == nil {
+		tm.Logger.Error("ForceReassign request is nil")
+		return nil, status.Error(codes.InvalidArgument, "request is nil")
+	}
+	if req.Email == "" || req.TargetSection == "" || req.TargetSeat <= 0 || req.Reason == "" {
+		tm.Logger.Error("ForceReassign request missing required fields",
+			zap.String("email", req.Email),
+			zap.String("target_section", req.TargetSection),
+			zap.Int32("target_seat", req.TargetSeat),
+			zap.String("reason", req.Reason),
+		)
+		return nil, status.Error(codes.InvalidArgument, "missing required fields")
+	}
+
+	tm.Logger.Info("ForceReassign request",
+		zap.String("email", req.Email),
+		zap.String("target_section", req.TargetSection),
+		zap.Int32("target_seat", req.TargetSeat),
+		zap.Time("timestamp", tm.Now()),
+	)
+
+	receipt, exists := tm.Receipts[req.Email]
+	if !exists {
+		tm.Logger.Error("ForceReassign ticket receipt not found",
+			zap.String("email", req.Email),
+		)
+		return nil, status.Error(codes.NotFound, "ticket receipt not found")
+	}
+	if receipt.Seat == nil {
+		tm.Logger.Error("ForceReassign ticket receipt has no seat",
+			zap.String("email", req.Email),
+		)
+		return nil, errReceiptWithoutSeat
+	}
+
+	// Moving to the seat already held is a no-op
+	if receipt.Seat.Section == req.TargetSection && receipt.Seat.SeatNumber == req.TargetSeat {
+		tm.Logger.Info("ForceReassign target is the current seat",
+			zap.String("email", req.Email),
+			zap.String("section", receipt.Seat.Section),
+			zap.Int32("seat_number", receipt.Seat.SeatNumber),
+		)
+		return &pb.ForceReassignResponse{
+			Message:        "Seat unchanged",
+			UpdatedReceipt: receipt,
+		}, nil
+	}
+
+	journey, err := tm.journey(receipt)
+	if err != nil {
+		tm.Logger.Error("ForceReassign journey not on route",
+			zap.String("email", req.Email),
+			zap.Error(err),
+		)
+		return nil, seatError(err)
+	}
+
+	seats := tm.departure(receipt.TrainId, receipt.DepartureDate)
+	err = seats.UpdateSeat(int(receipt.Seat.SeatNumber), receipt.Seat.Section, int(req.TargetSeat), req.TargetSection, journey)
+	if err != nil {
+		tm.Logger.Error("ForceReassign failed to update seat",
+			zap.String("email", req.Email),
+			zap.String("target_section", req.TargetSection),
+			zap.Int32("target_seat", req.TargetSeat),
+			zap.Error(err),
+		)
+		return nil, seatError(err)
+	}
+
+	oldSeat := receipt.Seat
+	tm.trackDestination(receipt, oldSeat.Section, -1)
+	tm.trackDestination(receipt, req.TargetSection, 1)
+	receipt.Seat = &pb.Seat{Section: req.TargetSection, SeatNumber: req.TargetSeat}
+	event := tm.recordEvent(req.Email, pb.ReceiptEventType_RECEIPT_EVENT_FORCE_REASSIGNED, receipt)
+	event.Reason = req.Reason
+	tm.notify(pb.ReceiptEventType_RECEIPT_EVENT_FORCE_REASSIGNED, receipt)
+	tm.promoteWaitlist(oldSeat.Section, receipt.TrainId, receipt.DepartureDate)
+
+	tm.Logger.Warn("ForceReassign passenger moved",
+		zap.String("email", req.Email),
+		zap.String("old_section", oldSeat.Section),
+		zap.Int32("old_seat", oldSeat.SeatNumber),
+		zap.String("new_section", req.TargetSection),
+		zap.Int32("new_seat", req.TargetSeat),
+		zap.String("reason", req.Reason),
+	)
+	return &pb.ForceReassignResponse{
+		Message:        "Passenger moved",
+		UpdatedReceipt: receipt,
+	}, nil
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+)
+
+func TestForceReassign(t *testing.T) {
+	tm := createOverflowTicketManager("")
+	fillSectionA(t, tm)
+
+	response, err := tm.ForceReassign(context.Background(), &pb.ForceReassignRequest{
+		Email:         "first@example.com",
+		TargetSection: "B",
+		TargetSeat:    3,
+		Reason:        "Disruptive passenger",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, &pb.Seat{Section: "B", SeatNumber: 3}, response.UpdatedReceipt.Seat)
+	assert.Equal(t, 1, tm.SeatManager.VacantSeats("A"), "The old seat should be released")
+	assert.Equal(t, 3, tm.SeatManager.VacantSeats("B"))
+
+	// The move is audited in the receipt history
+	history := tm.History["first@example.com"]
+	last := history[len(history)-1]
+	assert.Equal(t, pb.ReceiptEventType_RECEIPT_EVENT_FORCE_REASSIGNED, last.Type)
+	assert.Equal(t, "Disruptive passenger", last.Reason)
+	assert.Equal(t, &pb.Seat{Section: "B", SeatNumber: 3}, last.Seat)
+}
+
+func TestForceReassignTargetOccupied(t *testing.T) {
+	tm := createOverflowTicketManager("")
+	fillSectionA(t, tm)
+
+	_, err := tm.ForceReassign(context.Background(), &pb.ForceReassignRequest{
+		Email:         "first@example.com",
+		TargetSection: "A",
+		TargetSeat:    2,
+		Reason:        "Disruptive passenger",
+	})
+	assert.Equal(t, codes.AlreadyExists, status.Code(err), "Nobody should be bumped from the target seat")
+	assert.Equal(t, &pb.Seat{Section: "A", SeatNumber: 1}, tm.Receipts["first@example.com"].Seat)
+	assert.Equal(t, &pb.Seat{Section: "A", SeatNumber: 2}, tm.Receipts["second@example.com"].Seat)
+	history := tm.History["first@example.com"]
+	assert.Equal(t, pb.ReceiptEventType_RECEIPT_EVENT_BOOKED, history[len(history)-1].Type)
+}
+
+func TestForceReassignInvalid(t *testing.T) {
+	tm := createOverflowTicketManager("")
+	fillSectionA(t, tm)
+
+	_, err := tm.ForceReassign(context.Background(), nil)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = tm.ForceReassign(context.Background(), &pb.ForceReassignRequest{Email: "first@example.com", TargetSection: "B", TargetSeat: 1})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "A reason is required for the audit trail")
+
+	_, err = tm.ForceReassign(context.Background(), &pb.ForceReassignRequest{Email: "nobody@example.com", TargetSection: "B", TargetSeat: 1, Reason: "Test"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = tm.ForceReassign(context.Background(), &pb.ForceReassignRequest{Email: "first@example.com", TargetSection: "Z", TargetSeat: 1, Reason: "Test"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
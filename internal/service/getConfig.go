@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"maps"
+
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v2"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// AdminMethods lists the RPCs only admin API keys may call: operator
+// controls, payment-service callbacks, and listings of other passengers'
+// bookings.
+var AdminMethods = []string{
+	"SetMaintenanceMode",
+	"BulkCancel",
+	"ResizeSection",
+	"BlockSeat",
+	"UnblockSeat",
+	"Verify",
+	"GetManifest",
+	"ListReceiptsByPriceRange",
+	"GetUsersByRoute",
+	"GetConfig",
+	"GetAssignmentTrace",
+	"ForceReassign",
+	"ImportReceipts",
+	"QuiesceSection",
+	"ResumeSection",
+	"GetConfigChanges",
+	"ConfirmPayment",
+}
+
+// GetConfig returns the configuration the server is running as YAML, with
+// secrets redacted. Sections, station prices and maintenance mode report their
+// live values, so changes made at runtime show up.
+func (tm *TicketManager) GetConfig(ctx context.Context, req *pb.GetConfigRequest) (*pb.GetConfigResponse, error) {
+	tm.Logger.Info("GetConfig request received")
+
+	if tm.Config == nil {
+		tm.Logger.Error("GetConfig without a configuration")
+		return nil, status.Error(codes.FailedPrecondition, "no configuration loaded")
+	}
+
+	tm.mu.Lock()
+	effective := tm.Config.Redacted()
+	effective.Sections = tm.SeatManager.SectionConfigs()
+	effective.Stations.Prices = maps.Clone(tm.StationConnection)
+	effective.MaintenanceMode = tm.InMaintenance()
+	tm.mu.Unlock()
+
+	out, err := yaml.Marshal(effective)
+	if err != nil {
+		tm.Logger.Error("GetConfig failed to encode configuration", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to encode configuration")
+	}
+
+	tm.Logger.Info("GetConfig successful")
+	return &pb.GetConfigResponse{Config: string(out)}, nil
+}
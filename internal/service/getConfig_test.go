@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+
+	"github.com/sanjaykishor/rail-connect/internal/config"
+	pb "github.com/sanjaykishor/rail-connect/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGetConfigRedactsSecrets(t *testing.T) {
+	tm := createTestTicketManager()
+	tm.Config = &config.Config{
+		Server: config.ServerConfig{
+			Port:    ":50051",
+			APIKeys: []config.APIKeyConfig{{Key: "s3cret-admin", Admin: true}, {Key: "s3cret-user", Email: "test@example.com"}},
+		},
+		Sections:      []config.SectionConfig{{Name: "A", MaxSeats: 20}, {Name: "B", MaxSeats: 20}},
+		Stations:      config.StationConfig{Prices: map[string]float64{"London-France": 20.00}},
+		Notifications: config.NotificationConfig{SMTP: config.SMTPConfig{Host: "smtp.example.com", Password: "s3cret-smtp"}},
+	}
+
+	response, err := tm.GetConfig(context.Background(), &pb.GetConfigRequest{})
+	assert.NoError(t, err)
+	assert.NotContains(t, response.Config, "s3cret", "Secrets should never be returned")
+
+	var effective config.Config
+	assert.NoError(t, yaml.Unmarshal([]byte(response.Config), &effective))
+	assert.Equal(t, []config.APIKeyConfig{
+		{Key: config.RedactedValue, Admin: true},
+		{Key: config.RedactedValue, Email: "test@example.com"},
+	}, effective.Server.APIKeys, "Keys should be redacted but still listed")
+	assert.Equal(t, config.RedactedValue, effective.Notifications.SMTP.Password)
+	assert.Equal(t, "smtp.example.com", effective.Notifications.SMTP.Host)
+	assert.Equal(t, map[string]float64{"London-France": 20.00}, effective.Stations.Prices)
+	assert.Len(t, effective.Sections, 2)
+	assert.Equal(t, "s3cret-user", tm.Config.Server.APIKeys[1].Key, "Redacting should not change the running configuration")
+}
+
+func TestGetConfigLiveValues(t *testing.T) {
+	tm := createTestTicketManager()
+	tm.Config = &config.Config{
+		Sections: []config.SectionConfig{{Name: "A", MaxSeats: 20}, {Name: "B", MaxSeats: 20}},
+	}
+	assert.NoError(t, tm.SeatManager.ResizeSection("B", 5))
+	assert.NoError(t, tm.SeatManager.BlockSeat("A", 3))
+	tm.SetMaintenance(true)
+
+	response, err := tm.GetConfig(context.Background(), &pb.GetConfigRequest{})
+	assert.NoError(t, err)
+
+	var effective config.Config
+	assert.NoError(t, yaml.Unmarshal([]byte(response.Config), &effective))
+	assert.Equal(t, []config.SectionConfig{
+		{Name: "A", MaxSeats: 20, BlockedSeats: []int{3}},
+		{Name: "B", MaxSeats: 5, BlockedSeats: []int{}},
+	}, effective.Sections, "Runtime changes to sections should be reported")
+	assert.True(t, effective.MaintenanceMode)
+}
+
+func TestGetConfigWithoutConfig(t *testing.T) {
+	tm := createTestTicketManager()
+
+	_, err := tm.GetConfig(context.Background(), &pb.GetConfigRequest{})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
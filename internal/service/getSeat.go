@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"sort"
+
+	"go.uber.org/zap"
+
+	"github.com/sanjaykishor/rail-connect/internal/config"
+	pb "github.com/sanjaykishor/rail-connect/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// seatPositions maps the positions of a row layout to their proto values.
+var seatPositions = map[string]pb.SeatPosition{
+	config.PositionWindow: pb.SeatPosition_SEAT_POSITION_WINDOW,
+	config.PositionMiddle: pb.SeatPosition_SEAT_POSITION_MIDDLE,
+	config.PositionAisle:  pb.SeatPosition_SEAT_POSITION_AISLE,
+}
+
+// seatPosition returns where a seat sits in its row by the row layout. Seat
+// numbers run along the rows, so seat n sits in column (n-1) % len. Without a
+// row layout the position is unspecified.
+func (sm *SeatManager) seatPosition(seatNumber int) pb.SeatPosition {
+	if sm.RowLayout == "" {
+		return pb.SeatPosition_SEAT_POSITION_UNSPECIFIED
+	}
+	letter := rune(sm.RowLayout[(seatNumber-1)%len(sm.RowLayout)])
+	return seatPositions[config.RowLayoutPositions[letter]]
+}
+
+// GetSeat returns everything about a single seat on a departure, the undated
+// default train unless the request names a train or date: its position,
+// availability and the receipts of the riders booked on it. Callers other than
+// admins only see their own receipt among the occupants.
+func (tm *TicketManager) GetSeat(ctx context.Context, req *pb.GetSeatRequest) (*pb.GetSeatResponse, error) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	tm.Logger.Info("GetSeat request received")
+
+	// Validate the request
+	if req == nil {
+		tm.Logger.Error("GetSeat request is nil")
+		return nil, status.Error(codes.InvalidArgument, "request is nil")
+	}
+	if req.Section == "" || req.SeatNumber <= 0 {
+		tm.Logger.Error("GetSeat request missing required fields",
+			zap.String("section", req.Section),
+			zap.Int32("seat_number", req.SeatNumber),
+		)
+		return nil, status.Error(codes.InvalidArgument, "missing required fields")
+	}
+
+	train, err := tm.resolveDeparture(req.TrainId, req.DepartureDate)
+	if err != nil {
+		tm.Logger.Error("GetSeat invalid departure",
+			zap.String("train_id", req.TrainId),
+			zap.String("departure_date", req.DepartureDate),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	tm.Logger.Info("GetSeat request",
+		zap.String("section", req.Section),
+		zap.Int32("seat_number", req.SeatNumber),
+		zap.String("train_id", train),
+		zap.String("departure_date", req.DepartureDate),
+		zap.Time("timestamp", tm.Now()),
+	)
+
+	// tm.mu is held, so the receipts can't change while the seat is read
+	seats := tm.readDeparture(train, req.DepartureDate)
+	available, blocked, err := seats.SeatStatus(req.Section, int(req.SeatNumber))
+	if err != nil {
+		tm.Logger.Error("GetSeat failed to find seat",
+			zap.String("section", req.Section),
+			zap.Int32("seat_number", req.SeatNumber),
+			zap.Error(err),
+		)
+		return nil, seatError(err)
+	}
+
+	occupants := make([]*pb.Receipt, 0)
+	for email, receipt := range tm.Receipts {
+		if receipt.Seat == nil {
+			tm.Logger.Error("GetSeat ticket receipt has no seat",
+				zap.String("email", email),
+			)
+			continue
+		}
+		if tm.onDeparture(receipt, train, req.DepartureDate) && receipt.Seat.Section == req.Section && receipt.Seat.SeatNumber == req.SeatNumber && visibleToCaller(ctx, receipt) {
+			occupants = append(occupants, receipt)
+		}
+	}
+	sort.Slice(occupants, func(i, j int) bool {
+		return occupants[i].User.Email < occupants[j].User.Email
+	})
+
+	tm.Logger.Info("GetSeat successful",
+		zap.String("section", req.Section),
+		zap.Int32("seat_number", req.SeatNumber),
+		zap.Bool("available", available),
+		zap.Int("occupant_count", len(occupants)),
+	)
+	return &pb.GetSeatResponse{
+		Seat:      &pb.Seat{Section: req.Section, SeatNumber: req.SeatNumber},
+		Position:  seats.seatPosition(int(req.SeatNumber)),
+		Available: available,
+		Blocked:   blocked,
+		Occupants: occupants,
+	}, nil
+}
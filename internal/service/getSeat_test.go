@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGetSeat(t *testing.T) {
+	tm := createTestTicketManager()
+	receipt := purchase(t, tm, "test@example.com", "London", "France")
+	assert.NoError(t, tm.SeatManager.BlockSeat("B", 2))
+
+	response, err := tm.GetSeat(context.Background(), &pb.GetSeatRequest{Section: receipt.Seat.Section, SeatNumber: receipt.Seat.SeatNumber})
+	assert.NoError(t, err)
+	assert.Equal(t, receipt.Seat.Section, response.Seat.Section)
+	assert.Equal(t, receipt.Seat.SeatNumber, response.Seat.SeatNumber)
+	assert.False(t, response.Available)
+	assert.False(t, response.Blocked)
+	assert.Len(t, response.Occupants, 1)
+	assert.Equal(t, "test@example.com", response.Occupants[0].User.Email)
+
+	response, err = tm.GetSeat(context.Background(), &pb.GetSeatRequest{Section: "B", SeatNumber: 2})
+	assert.NoError(t, err)
+	assert.False(t, response.Available)
+	assert.True(t, response.Blocked)
+	assert.Empty(t, response.Occupants, "Blocked seats have no occupant")
+
+	response, err = tm.GetSeat(context.Background(), &pb.GetSeatRequest{Section: "B", SeatNumber: 3})
+	assert.NoError(t, err)
+	assert.True(t, response.Available)
+	assert.Empty(t, response.Occupants)
+
+	tests := []struct {
+		name         string
+		request      *pb.GetSeatRequest
+		expectedCode codes.Code
+	}{
+		{"Nonexistent Seat", &pb.GetSeatRequest{Section: "A", SeatNumber: 99}, codes.NotFound},
+		{"Nonexistent Section", &pb.GetSeatRequest{Section: "C", SeatNumber: 1}, codes.NotFound},
+		{"Missing Section", &pb.GetSeatRequest{SeatNumber: 1}, codes.InvalidArgument},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := tm.GetSeat(context.Background(), test.request)
+			assert.Equal(t, test.expectedCode, status.Code(err))
+		})
+	}
+}
+
+func TestSeatPosition(t *testing.T) {
+	tm := createTestTicketManager()
+	assert.Equal(t, pb.SeatPosition_SEAT_POSITION_UNSPECIFIED, tm.SeatManager.seatPosition(1), "Seats have no position without a row layout")
+
+	tm.SeatManager.RowLayout = "WMAAMW"
+	positions := map[int]pb.SeatPosition{
+		1:  pb.SeatPosition_SEAT_POSITION_WINDOW,
+		2:  pb.SeatPosition_SEAT_POSITION_MIDDLE,
+		3:  pb.SeatPosition_SEAT_POSITION_AISLE,
+		4:  pb.SeatPosition_SEAT_POSITION_AISLE,
+		5:  pb.SeatPosition_SEAT_POSITION_MIDDLE,
+		6:  pb.SeatPosition_SEAT_POSITION_WINDOW,
+		7:  pb.SeatPosition_SEAT_POSITION_WINDOW,
+		11: pb.SeatPosition_SEAT_POSITION_MIDDLE,
+	}
+	for seatNumber, position := range positions {
+		assert.Equal(t, position, tm.SeatManager.seatPosition(seatNumber), "Seat %d", seatNumber)
+	}
+
+	res, err := tm.GetSeat(context.Background(), &pb.GetSeatRequest{Section: "A", SeatNumber: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, pb.SeatPosition_SEAT_POSITION_MIDDLE, res.Position)
+}
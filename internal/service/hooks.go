@@ -0,0 +1,86 @@
+package service
+
+import (
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// BeforePurchaseHook vets a validated purchase before a seat is assigned.
+// Returning an error vetoes the purchase: a gRPC status error is returned to
+// the caller as is, and any other error as FailedPrecondition. The hook must
+// not modify the request.
+type BeforePurchaseHook func(req *pb.PurchaseTicketRequest) error
+
+// AfterPurchaseHook is told about each booking once it is recorded, including
+// companions and bookings promoted from a waitlist. The receipt is a copy.
+type AfterPurchaseHook func(receipt *pb.Receipt)
+
+// CancelHook is told about each cancelled ticket and why it was cancelled,
+// whether by RemoveUser, BulkCancel or an expired payment. The receipt is a
+// copy.
+type CancelHook func(receipt *pb.Receipt, reason pb.CancellationReason)
+
+// hooks holds the registered hooks in registration order. Every hook runs
+// synchronously with tm.mu held, so it must not call the TicketManager and
+// should hand slow work to a goroutine.
+type hooks struct {
+	beforePurchase []BeforePurchaseHook
+	afterPurchase  []AfterPurchaseHook
+	cancel         []CancelHook
+}
+
+// OnBeforePurchase registers a hook that can veto purchases.
+func (tm *TicketManager) OnBeforePurchase(hook BeforePurchaseHook) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	tm.hooks.beforePurchase = append(tm.hooks.beforePurchase, hook)
+}
+
+// OnAfterPurchase registers a hook told about every booking.
+func (tm *TicketManager) OnAfterPurchase(hook AfterPurchaseHook) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	tm.hooks.afterPurchase = append(tm.hooks.afterPurchase, hook)
+}
+
+// OnCancel registers a hook told about every cancellation.
+func (tm *TicketManager) OnCancel(hook CancelHook) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	tm.hooks.cancel = append(tm.hooks.cancel, hook)
+}
+
+// runBeforePurchase runs the before-purchase hooks until one vetoes the
+// purchase, returning its error as a gRPC status. Callers must hold tm.mu.
+func (tm *TicketManager) runBeforePurchase(req *pb.PurchaseTicketRequest) error {
+	for _, hook := range tm.hooks.beforePurchase {
+		err := hook(req)
+		if err == nil {
+			continue
+		}
+		if _, ok := status.FromError(err); ok {
+			return err
+		}
+		return status.Errorf(codes.FailedPrecondition, "purchase rejected: %v", err)
+	}
+	return nil
+}
+
+// runAfterPurchase tells the after-purchase hooks about a booking. Callers
+// must hold tm.mu.
+func (tm *TicketManager) runAfterPurchase(receipt *pb.Receipt) {
+	for _, hook := range tm.hooks.afterPurchase {
+		hook(proto.Clone(receipt).(*pb.Receipt))
+	}
+}
+
+// runCancel tells the cancel hooks about a cancellation. Callers must hold
+// tm.mu.
+func (tm *TicketManager) runCancel(receipt *pb.Receipt, reason pb.CancellationReason) {
+	for _, hook := range tm.hooks.cancel {
+		hook(proto.Clone(receipt).(*pb.Receipt), reason)
+	}
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+)
+
+func TestBeforePurchaseHookVeto(t *testing.T) {
+	tm := createTestTicketManager()
+	var order []string
+	tm.OnBeforePurchase(func(req *pb.PurchaseTicketRequest) error {
+		order = append(order, "first")
+		if req.User.Email == "blocked@example.com" {
+			return errors.New("rider is blocked")
+		}
+		return nil
+	})
+	tm.OnBeforePurchase(func(req *pb.PurchaseTicketRequest) error {
+		order = append(order, "second")
+		return status.Error(codes.PermissionDenied, "no tickets for this rider")
+	})
+
+	_, err := tm.PurchaseTicket(context.Background(), &pb.PurchaseTicketRequest{
+		User: &pb.User{FirstName: "Sanjay", LastName: "Kishor", Email: "blocked@example.com"},
+		From: "London",
+		To:   "France",
+	})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err), "Plain errors should veto as FailedPrecondition")
+	assert.Contains(t, status.Convert(err).Message(), "rider is blocked")
+	assert.Equal(t, []string{"first"}, order, "Hooks after a veto should not run")
+	assert.NotContains(t, tm.Receipts, "blocked@example.com")
+	assert.Equal(t, 40, tm.SeatManager.VacantSeats("A")+tm.SeatManager.VacantSeats("B"), "A vetoed purchase should not take a seat")
+
+	_, err = tm.PurchaseTicket(context.Background(), &pb.PurchaseTicketRequest{
+		User: &pb.User{FirstName: "Sanjay", LastName: "Kishor", Email: "other@example.com"},
+		From: "London",
+		To:   "France",
+	})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err), "Status errors should be returned as they are")
+	assert.Equal(t, []string{"first", "first", "second"}, order, "Hooks should run in registration order")
+}
+
+func TestAfterPurchaseAndCancelHooks(t *testing.T) {
+	tm := createTestTicketManager()
+	var purchased []*pb.Receipt
+	tm.OnAfterPurchase(func(receipt *pb.Receipt) {
+		purchased = append(purchased, receipt)
+	})
+	var cancelled []pb.CancellationReason
+	tm.OnCancel(func(receipt *pb.Receipt, reason pb.CancellationReason) {
+		assert.Equal(t, "test@example.com", receipt.User.Email)
+		cancelled = append(cancelled, reason)
+	})
+
+	stored := purchase(t, tm, "test@example.com", "London", "France")
+	assert.Len(t, purchased, 1)
+	assert.Equal(t, stored.BookingReference, purchased[0].BookingReference)
+	assert.NotSame(t, stored, purchased[0], "Hooks should get a copy of the receipt")
+
+	_, err := tm.RemoveUser(context.Background(), &pb.RemoveUserRequest{
+		Email:  "test@example.com",
+		Reason: pb.CancellationReason_CANCELLATION_REASON_USER_REQUESTED,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []pb.CancellationReason{pb.CancellationReason_CANCELLATION_REASON_USER_REQUESTED}, cancelled)
+}
@@ -0,0 +1,202 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// importedSeat is a seat taken by an import that has not been committed yet.
+type importedSeat struct {
+	seats   *SeatManager
+	section string
+	seat    int
+	journey Journey
+}
+
+// ImportReceipts loads bookings made in another system, seating each rider in
+// the exact seat on their receipt. The import is all or nothing: every receipt
+// is checked, and if any is invalid or conflicts with a booked seat or another
+// receipt, the errors are reported per receipt and nothing is imported.
+// Imported bookings don't notify riders or count towards sales or trends.
+func (tm *TicketManager) ImportReceipts(ctx context.Context, req *pb.ImportReceiptsRequest) (*pb.ImportReceiptsResponse, error) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	tm.Logger.Info("ImportReceipts request received")
+
+	// Validate the request
+	if req == nil {
+		tm.Logger.Error("ImportReceipts request is nil")
+		return nil, status.Error(codes.InvalidArgument, "request is nil")
+	}
+	if len(req.Receipts) == 0 {
+		tm.Logger.Error("ImportReceipts request has no receipts")
+		return nil, status.Error(codes.InvalidArgument, "missing required fields")
+	}
+
+	tm.Logger.Info("ImportReceipts request",
+		zap.Int("receipts", len(req.Receipts)),
+		zap.Time("timestamp", tm.Now()),
+	)
+
+	// Take every seat before storing anything, so a conflict between two
+	// receipts is caught like one with an existing booking
+	createdDepartures := make(map[departureKey]bool)
+	taken := make([]importedSeat, 0, len(req.Receipts))
+	emails := make(map[string]bool, len(req.Receipts))
+	references := make(map[string]bool, len(req.Receipts))
+	imported := make([]*pb.Receipt, 0, len(req.Receipts))
+	var importErrors []*pb.ImportError
+	for i, original := range req.Receipts {
+		receipt, err := tm.checkImport(original, emails, references)
+		if err == nil {
+			var seat importedSeat
+			seat, err = tm.occupyImport(receipt, createdDepartures)
+			if err == nil {
+				taken = append(taken, seat)
+			}
+		}
+		if err != nil {
+			email := ""
+			if original.GetUser() != nil {
+				email = original.User.Email
+			}
+			importErrors = append(importErrors, &pb.ImportError{Index: int32(i), Email: email, Message: err.Error()})
+			continue
+		}
+		emails[receipt.User.Email] = true
+		if receipt.BookingReference != "" {
+			references[receipt.BookingReference] = true
+		}
+		imported = append(imported, receipt)
+	}
+
+	if len(importErrors) > 0 {
+		tm.rollbackImport(taken, createdDepartures)
+		tm.Logger.Error("ImportReceipts rejected",
+			zap.Int("receipts", len(req.Receipts)),
+			zap.Int("errors", len(importErrors)),
+		)
+		return &pb.ImportReceiptsResponse{
+			Message: fmt.Sprintf("%d of %d receipts rejected, nothing imported", len(importErrors), len(req.Receipts)),
+			Errors:  importErrors,
+		}, nil
+	}
+
+	for _, receipt := range imported {
+		tm.storeImport(receipt)
+	}
+
+	tm.Logger.Info("ImportReceipts successful",
+		zap.Int("imported", len(imported)),
+	)
+	return &pb.ImportReceiptsResponse{
+		Message:          fmt.Sprintf("%d receipts imported", len(imported)),
+		ImportedReceipts: imported,
+	}, nil
+}
+
+// checkImport validates a receipt to import against the stored bookings and
+// the receipts already accepted from the same import, whose emails and
+// booking references are given. It returns a copy of the receipt with its
+// stations and train resolved. Callers must hold tm.mu.
+func (tm *TicketManager) checkImport(original *pb.Receipt, emails, references map[string]bool) (*pb.Receipt, error) {
+	if original == nil || original.User == nil || original.User.Email == "" ||
+		original.From == "" || original.To == "" ||
+		original.Seat == nil || original.Seat.Section == "" || original.Seat.SeatNumber <= 0 {
+		return nil, errors.New("missing required fields")
+	}
+
+	receipt := proto.Clone(original).(*pb.Receipt)
+	receipt.From, receipt.To = tm.station(receipt.From), tm.station(receipt.To)
+	email := receipt.User.Email
+	switch {
+	case receipt.From == receipt.To:
+		return nil, errors.New("origin and destination must differ")
+	case receipt.Status != pb.BookingStatus_BOOKING_STATUS_CONFIRMED:
+		return nil, errors.New("only confirmed bookings can be imported")
+	case emails[email]:
+		return nil, errors.New("user has more than one receipt in the import")
+	case tm.Receipts[email] != nil:
+		return nil, errors.New("user already has a ticket")
+	}
+
+	if reference := receipt.BookingReference; reference != "" {
+		if _, taken := tm.references[reference]; taken || references[reference] {
+			return nil, fmt.Errorf("booking reference %s is already in use", reference)
+		}
+	}
+	if receipt.DepartureDate != "" {
+		if _, err := time.Parse(departureDateLayout, receipt.DepartureDate); err != nil {
+			return nil, errors.New("departure date must be YYYY-MM-DD")
+		}
+	}
+	train, err := tm.resolveTrain(receipt.TrainId)
+	if err != nil {
+		return nil, errors.New("train not found")
+	}
+	receipt.TrainId = train
+	return receipt, nil
+}
+
+// occupyImport takes the seat on an imported receipt, noting any departure it
+// is the first booking on in created. Callers must hold tm.mu.
+func (tm *TicketManager) occupyImport(receipt *pb.Receipt, created map[departureKey]bool) (importedSeat, error) {
+	journey, err := tm.journey(receipt)
+	if err != nil {
+		return importedSeat{}, err
+	}
+
+	if !tm.isDefaultDeparture(receipt.TrainId, receipt.DepartureDate) {
+		key := departureKey{tm.trainOrDefault(receipt.TrainId), receipt.DepartureDate}
+		if _, exists := tm.departures[key]; !exists {
+			created[key] = true
+		}
+	}
+	seats := tm.departure(receipt.TrainId, receipt.DepartureDate)
+	section, seat := receipt.Seat.Section, int(receipt.Seat.SeatNumber)
+	if err := seats.OccupySeat(section, seat, journey); err != nil {
+		return importedSeat{}, err
+	}
+	return importedSeat{seats: seats, section: section, seat: seat, journey: journey}, nil
+}
+
+// rollbackImport frees the seats taken by a rejected import and forgets the
+// departures it created. Callers must hold tm.mu.
+func (tm *TicketManager) rollbackImport(taken []importedSeat, created map[departureKey]bool) {
+	for _, seat := range taken {
+		if err := seat.seats.ReleaseSeat(seat.section, seat.seat, seat.journey); err != nil {
+			tm.Logger.Error("ImportReceipts failed to release seat",
+				zap.String("section", seat.section),
+				zap.Int("seat_number", seat.seat),
+				zap.Error(err),
+			)
+		}
+	}
+	for key := range created {
+		delete(tm.departures, key)
+	}
+}
+
+// storeImport stores an imported receipt whose seat is already taken, giving
+// it a booking reference if it has none. Callers must hold tm.mu.
+func (tm *TicketManager) storeImport(receipt *pb.Receipt) {
+	email := receipt.User.Email
+	if receipt.BookingReference == "" {
+		receipt.BookingReference = tm.newReference()
+	}
+	tm.references[receipt.BookingReference] = email
+	tm.Receipts[email] = receipt
+	tm.trackDestination(receipt, receipt.Seat.Section, 1)
+	tm.trackRoute(receipt.From, receipt.To, 1)
+	tm.trackFareClass(receipt.FareClass, 1)
+	tm.recordEvent(email, pb.ReceiptEventType_RECEIPT_EVENT_BOOKED, receipt)
+}
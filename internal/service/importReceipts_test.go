@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+)
+
+// importedReceipt returns a confirmed London to France receipt in a seat.
+func importedReceipt(email, section string, seat int32) *pb.Receipt {
+	return &pb.Receipt{
+		User:      &pb.User{FirstName: "Sanjay", LastName: "Kishor", Email: email},
+		From:      "London",
+		To:        "France",
+		PricePaid: 20.00,
+		Seat:      &pb.Seat{Section: section, SeatNumber: seat},
+	}
+}
+
+func TestImportReceipts(t *testing.T) {
+	tm := createTrainsTicketManager()
+	tm.AdvanceDays = 30
+	purchase(t, tm, "existing@example.com", "London", "France")
+
+	legacy := importedReceipt("legacy@example.com", "B", 7)
+	legacy.BookingReference = "LEGACY1"
+	dated := importedReceipt("dated@example.com", "A", 1)
+	dated.DepartureDate = "2030-01-01"
+	dated.TrainId = "IC102"
+
+	response, err := tm.ImportReceipts(context.Background(), &pb.ImportReceiptsRequest{
+		Receipts: []*pb.Receipt{legacy, dated, importedReceipt("other@example.com", "A", 5)},
+	})
+	assert.NoError(t, err)
+	assert.Empty(t, response.Errors)
+	assert.Len(t, response.ImportedReceipts, 3)
+
+	stored := tm.Receipts["legacy@example.com"]
+	assert.Equal(t, &pb.Seat{Section: "B", SeatNumber: 7}, stored.Seat)
+	assert.Equal(t, "LEGACY1", stored.BookingReference, "Imported booking references should be kept")
+	assert.NotEmpty(t, tm.Receipts["other@example.com"].BookingReference)
+	receipt, exists := tm.receiptByReference("LEGACY1")
+	assert.True(t, exists)
+	assert.Equal(t, stored, receipt)
+
+	// The seats are taken on the right departures and counted
+	assert.Equal(t, 18, tm.SeatManager.VacantSeats("A"))
+	assert.Equal(t, 19, tm.SeatManager.VacantSeats("B"))
+	assert.Equal(t, 19, tm.departure("IC102", "2030-01-01").VacantSeats("A"))
+	assert.Equal(t, 4, tm.routeCounts[routeKey("London", "France")])
+	_, err = tm.UpdateUserSeat(context.Background(), &pb.UpdateUserSeatRequest{
+		Email:   "existing@example.com",
+		NewSeat: &pb.Seat{Section: "B", SeatNumber: 7},
+	})
+	assert.Equal(t, codes.AlreadyExists, status.Code(err), "Imported seats should not be handed out again")
+
+	// Imported riders can cancel like any other
+	_, err = tm.RemoveUser(context.Background(), &pb.RemoveUserRequest{Email: "legacy@example.com"})
+	assert.NoError(t, err)
+	assert.Equal(t, 20, tm.SeatManager.VacantSeats("B"))
+}
+
+func TestImportReceiptsConflict(t *testing.T) {
+	tm := createTrainsTicketManager()
+	existing := purchase(t, tm, "existing@example.com", "London", "France")
+
+	dated := importedReceipt("dated@example.com", "A", 3)
+	dated.DepartureDate = "2030-01-01"
+	response, err := tm.ImportReceipts(context.Background(), &pb.ImportReceiptsRequest{
+		Receipts: []*pb.Receipt{
+			importedReceipt("first@example.com", "B", 4),
+			dated,
+			importedReceipt("taken@example.com", existing.Seat.Section, existing.Seat.SeatNumber),
+			importedReceipt("second@example.com", "B", 4),
+			importedReceipt("existing@example.com", "A", 9),
+			{User: &pb.User{Email: "noseat@example.com"}, From: "London", To: "France"},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Empty(t, response.ImportedReceipts)
+	assert.Len(t, response.Errors, 4)
+	for i, index := range []int32{2, 3, 4, 5} {
+		assert.Equal(t, index, response.Errors[i].Index)
+	}
+	assert.Equal(t, "taken@example.com", response.Errors[0].Email)
+	assert.Contains(t, response.Errors[0].Message, "occupied")
+	assert.Contains(t, response.Errors[1].Message, "occupied", "Receipts in the same import should not share a seat")
+	assert.Equal(t, "user already has a ticket", response.Errors[2].Message)
+	assert.Equal(t, "missing required fields", response.Errors[3].Message)
+
+	// Nothing was imported, including the valid receipts
+	assert.Len(t, tm.Receipts, 1)
+	assert.Equal(t, 19, tm.SeatManager.VacantSeats("A"))
+	assert.Equal(t, 20, tm.SeatManager.VacantSeats("B"))
+	assert.Empty(t, tm.departures, "Departures opened by the import should be forgotten")
+	assert.Len(t, tm.references, 1)
+}
+
+func TestImportReceiptsInvalid(t *testing.T) {
+	tm := createTestTicketManager()
+
+	_, err := tm.ImportReceipts(context.Background(), nil)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = tm.ImportReceipts(context.Background(), &pb.ImportReceiptsRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	pending := importedReceipt("pending@example.com", "A", 1)
+	pending.Status = pb.BookingStatus_BOOKING_STATUS_PENDING_PAYMENT
+	unknownTrain := importedReceipt("train@example.com", "A", 2)
+	unknownTrain.TrainId = "IC999"
+	badDate := importedReceipt("date@example.com", "A", 3)
+	badDate.DepartureDate = "01/01/2030"
+	response, err := tm.ImportReceipts(context.Background(), &pb.ImportReceiptsRequest{
+		Receipts: []*pb.Receipt{pending, unknownTrain, badDate, importedReceipt("section@example.com", "Z", 1)},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, response.Errors, 4)
+	assert.Empty(t, tm.Receipts)
+}
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// IntegrityReport describes the seat bookkeeping of one section.
+type IntegrityReport struct {
+	Section        string
+	MissingSeats   []int // Seat numbers up to MaxSeats with no Seat
+	RecordedVacant int   // Section.VacantSeats
+	ActualVacant   int   // Available seats actually present
+}
+
+// Healthy reports whether the section had no discrepancies.
+func (r IntegrityReport) Healthy() bool {
+	return len(r.MissingSeats) == 0 && r.RecordedVacant == r.ActualVacant
+}
+
+// Verify checks that every section has a seat for each number up to MaxSeats
+// and that its vacant seat count matches the available seats. With repair set,
+// missing seats are recreated as vacant and the counts are recomputed. A report
+// is returned for every section, in section order, describing the state found.
+func (sm *SeatManager) Verify(repair bool) []IntegrityReport {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	reports := make([]IntegrityReport, 0, len(sm.SectionOrder))
+	for _, name := range sm.SectionOrder {
+		section := sm.Sections[name]
+		report := IntegrityReport{Section: name, RecordedVacant: section.VacantSeats}
+		for seatNum := 1; seatNum <= section.MaxSeats; seatNum++ {
+			seat, exists := section.Seats[seatNum]
+			if !exists {
+				report.MissingSeats = append(report.MissingSeats, seatNum)
+				continue
+			}
+			if seat.Available {
+				report.ActualVacant++
+			}
+		}
+		reports = append(reports, report)
+
+		if report.Healthy() {
+			continue
+		}
+		sm.Logger.Warn("Seat integrity check failed",
+			zap.String("section", name),
+			zap.Ints("missing_seats", report.MissingSeats),
+			zap.Int("recorded_vacant", report.RecordedVacant),
+			zap.Int("actual_vacant", report.ActualVacant),
+			zap.Bool("repair", repair),
+		)
+		if repair {
+			section.repair(report)
+		}
+	}
+	return reports
+}
+
+// repair recreates the missing seats in the report as vacant and recounts the
+// section's vacant seats.
+func (section *Section) repair(report IntegrityReport) {
+	for _, seatNum := range report.MissingSeats {
+		section.Seats[seatNum] = &Seat{Number: seatNum, Available: true}
+	}
+	section.VacantSeats = report.ActualVacant + len(report.MissingSeats)
+	recomputeFirstVacant(section)
+}
+
+// Verify checks the seat bookkeeping of every section, optionally repairing
+// any discrepancies.
+func (tm *TicketManager) Verify(ctx context.Context, req *pb.VerifyRequest) (*pb.VerifyResponse, error) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	tm.Logger.Info("Verify request received")
+
+	// Validate the request
+	if req == nil {
+		tm.Logger.Error("Verify request is nil")
+		return nil, status.Error(codes.InvalidArgument, "request is nil")
+	}
+
+	train, err := tm.resolveDeparture(req.TrainId, req.DepartureDate)
+	if err != nil {
+		tm.Logger.Error("Verify invalid departure",
+			zap.String("train_id", req.TrainId),
+			zap.String("departure_date", req.DepartureDate),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	tm.Logger.Info("Verify request",
+		zap.Bool("repair", req.Repair),
+		zap.String("train_id", train),
+		zap.String("departure_date", req.DepartureDate),
+		zap.Time("timestamp", tm.Now()),
+	)
+
+	// A departure nobody has booked has nothing to check or repair
+	reports := tm.readDeparture(train, req.DepartureDate).Verify(req.Repair)
+
+	healthy := true
+	sections := make([]*pb.SectionIntegrity, 0, len(reports))
+	for _, report := range reports {
+		healthy = healthy && report.Healthy()
+		missing := make([]int32, 0, len(report.MissingSeats))
+		for _, seatNum := range report.MissingSeats {
+			missing = append(missing, int32(seatNum))
+		}
+		sections = append(sections, &pb.SectionIntegrity{
+			Section:             report.Section,
+			MissingSeats:        missing,
+			RecordedVacantSeats: int32(report.RecordedVacant),
+			ActualVacantSeats:   int32(report.ActualVacant),
+		})
+	}
+
+	message := "All sections are consistent"
+	switch {
+	case !healthy && req.Repair:
+		message = "Discrepancies found and repaired"
+	case !healthy:
+		message = "Discrepancies found"
+	}
+
+	tm.Logger.Info("Verify successful",
+		zap.Bool("healthy", healthy),
+		zap.Bool("repaired", !healthy && req.Repair),
+	)
+	return &pb.VerifyResponse{
+		Message:  message,
+		Healthy:  healthy,
+		Sections: sections,
+		Repaired: !healthy && req.Repair,
+	}, nil
+}
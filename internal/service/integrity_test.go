@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+)
+
+func TestVerifyHealthy(t *testing.T) {
+	seatManager := CreateSeatManager()
+
+	for _, report := range seatManager.Verify(false) {
+		assert.True(t, report.Healthy(), "A new seat manager should be consistent")
+	}
+}
+
+func TestVerifyDetectsAndRepairsGap(t *testing.T) {
+	tm := createTestTicketManager()
+	section := tm.SeatManager.Sections["A"]
+	delete(section.Seats, 1)
+	delete(section.Seats, 5)
+	section.VacantSeats = 3
+
+	response, err := tm.Verify(context.Background(), &pb.VerifyRequest{})
+	assert.NoError(t, err)
+	assert.False(t, response.Healthy)
+	assert.False(t, response.Repaired)
+	assert.Equal(t, []int32{1, 5}, response.Sections[0].MissingSeats)
+	assert.Equal(t, int32(3), response.Sections[0].RecordedVacantSeats)
+	assert.Equal(t, int32(18), response.Sections[0].ActualVacantSeats)
+	assert.Empty(t, response.Sections[1].MissingSeats, "Section B is untouched")
+	assert.Len(t, section.Seats, 18, "Verify without repair should not change anything")
+
+	response, err = tm.Verify(context.Background(), &pb.VerifyRequest{Repair: true})
+	assert.NoError(t, err)
+	assert.True(t, response.Repaired)
+	assert.Len(t, section.Seats, 20, "Missing seats should be recreated")
+	assert.Equal(t, 20, section.VacantSeats)
+	assert.Equal(t, 1, section.FirstVacant)
+
+	response, err = tm.Verify(context.Background(), &pb.VerifyRequest{})
+	assert.NoError(t, err)
+	assert.True(t, response.Healthy, "Repaired sections should pass the check")
+
+	// Seats recreated by the repair are assignable again
+	assert.Equal(t, int32(1), purchase(t, tm, "test@example.com", "London", "France").Seat.SeatNumber)
+}
+
+func TestVerifyRepairsMiscount(t *testing.T) {
+	seatManager := CreateSeatManager()
+	_, _, err := seatManager.AssignSeat(WholeRoute)
+	assert.NoError(t, err)
+	section := seatManager.Sections[seatManager.SectionOrder[0]]
+	section.VacantSeats = section.MaxSeats
+
+	reports := seatManager.Verify(true)
+	assert.False(t, reports[0].Healthy())
+	assert.Empty(t, reports[0].MissingSeats)
+	assert.Equal(t, section.MaxSeats-1, reports[0].ActualVacant)
+	assert.Equal(t, section.MaxSeats-1, section.VacantSeats, "Vacant count should be recomputed")
+}
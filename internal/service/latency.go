@@ -0,0 +1,49 @@
+package service
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// phaseTimer records how long each phase of a request takes, so a slow call
+// can be pinned on e.g. lock contention or seat assignment. A nil timer
+// records nothing, which keeps the cost to a nil check when debug logging is
+// off.
+type phaseTimer struct {
+	clock  Clock
+	start  time.Time
+	last   time.Time
+	phases []zap.Field
+}
+
+// newPhaseTimer starts timing a request, or returns nil when the logger would
+// drop the breakdown anyway.
+func (tm *TicketManager) newPhaseTimer() *phaseTimer {
+	if !tm.Logger.Core().Enabled(zapcore.DebugLevel) {
+		return nil
+	}
+	now := tm.Now()
+	return &phaseTimer{clock: tm.Clock, start: now, last: now}
+}
+
+// mark ends the current phase, recording its duration under the phase name.
+func (p *phaseTimer) mark(phase string) {
+	if p == nil {
+		return
+	}
+	now := p.clock.Now()
+	p.phases = append(p.phases, zap.Duration(phase, now.Sub(p.last)))
+	p.last = now
+}
+
+// log writes the duration of each phase marked so far and the total at debug
+// level.
+func (p *phaseTimer) log(logger *zap.Logger, method string) {
+	if p == nil {
+		return
+	}
+	fields := append(p.phases, zap.Duration("total", p.clock.Now().Sub(p.start)))
+	logger.Debug(method+" latency breakdown", fields...)
+}
@@ -0,0 +1,35 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestPurchaseTicketLatencyBreakdown(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	tm := createTestTicketManager()
+	tm.Logger = zap.New(core)
+
+	purchase(t, tm, "test@example.com", "London", "France")
+
+	entries := logs.FilterMessage("PurchaseTicket latency breakdown").All()
+	assert.Len(t, entries, 1)
+	fields := entries[0].ContextMap()
+	for _, phase := range []string{"validate", "lock_wait", "checks", "assign_seat", "record", "total"} {
+		assert.Contains(t, fields, phase, "The breakdown should time the %s phase", phase)
+	}
+}
+
+func TestPurchaseTicketLatencyBreakdownDisabled(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	tm := createTestTicketManager()
+	tm.Logger = zap.New(core)
+
+	assert.Nil(t, tm.newPhaseTimer(), "Nothing should be timed without debug logging")
+	purchase(t, tm, "test@example.com", "London", "France")
+	assert.Zero(t, logs.FilterMessage("PurchaseTicket latency breakdown").Len())
+}
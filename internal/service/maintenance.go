@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// MutatingMethods lists the RPCs that change booking state. They are rejected
+// while the service is in maintenance mode.
+var MutatingMethods = []string{
+	"PurchaseTicket",
+	"UpdateUserSeat",
+	"ChangeStations",
+	"RemoveUser",
+	"SwapSeats",
+	"BulkCancel",
+	"ResizeSection",
+	"BlockSeat",
+	"UnblockSeat",
+	"ForceReassign",
+	"HoldSeat",
+	"ReleaseHold",
+	"ConfirmPayment",
+	"ImportReceipts",
+}
+
+// InMaintenance reports whether mutating RPCs are currently disabled.
+func (tm *TicketManager) InMaintenance() bool {
+	return tm.maintenance.Load()
+}
+
+// SetMaintenance enables or disables maintenance mode.
+func (tm *TicketManager) SetMaintenance(enabled bool) {
+	tm.maintenance.Store(enabled)
+}
+
+// SetMaintenanceMode enables or disables maintenance mode.
+func (tm *TicketManager) SetMaintenanceMode(ctx context.Context, req *pb.SetMaintenanceModeRequest) (*pb.SetMaintenanceModeResponse, error) {
+	tm.Logger.Info("SetMaintenanceMode request received")
+
+	// Validate the request
+	if req == nil {
+		tm.Logger.Error("SetMaintenanceMode request is nil")
+		return nil, status.Error(codes.InvalidArgument, "request is nil")
+	}
+
+	tm.SetMaintenance(req.Enabled)
+
+	tm.Logger.Warn("SetMaintenanceMode successful",
+		zap.Bool("enabled", req.Enabled),
+	)
+
+	message := "Maintenance mode disabled"
+	if req.Enabled {
+		message = "Maintenance mode enabled"
+	}
+	return &pb.SetMaintenanceModeResponse{
+		Message: message,
+		Enabled: req.Enabled,
+	}, nil
+}
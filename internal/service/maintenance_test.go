@@ -0,0 +1,28 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+)
+
+func TestSetMaintenanceMode(t *testing.T) {
+	tm := createTestTicketManager()
+	assert.False(t, tm.InMaintenance(), "Maintenance mode should be off by default")
+
+	response, err := tm.SetMaintenanceMode(context.Background(), &pb.SetMaintenanceModeRequest{Enabled: true})
+	assert.NoError(t, err)
+	assert.True(t, response.Enabled)
+	assert.True(t, tm.InMaintenance(), "Maintenance mode should be on after enabling")
+
+	response, err = tm.SetMaintenanceMode(context.Background(), &pb.SetMaintenanceModeRequest{Enabled: false})
+	assert.NoError(t, err)
+	assert.False(t, response.Enabled)
+	assert.False(t, tm.InMaintenance(), "Maintenance mode should be off after disabling")
+
+	_, err = tm.SetMaintenanceMode(context.Background(), nil)
+	assert.Error(t, err, "Nil request should be rejected")
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GetManifest lists every passenger on a departure in seating order, with
+// their route and fare, for conductors to check tickets against. It can be
+// limited to one section.
+func (tm *TicketManager) GetManifest(ctx context.Context, req *pb.GetManifestRequest) (*pb.GetManifestResponse, error) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	tm.Logger.Info("GetManifest request received")
+
+	// Validate the request
+	if req == nil {
+		tm.Logger.Error("GetManifest request is nil")
+		return nil, status.Error(codes.InvalidArgument, "request is nil")
+	}
+
+	if req.DepartureDate != "" {
+		if _, err := time.Parse(departureDateLayout, req.DepartureDate); err != nil {
+			tm.Logger.Error("GetManifest invalid departure date",
+				zap.String("departure_date", req.DepartureDate),
+			)
+			return nil, status.Error(codes.InvalidArgument, "departure date must be YYYY-MM-DD")
+		}
+	}
+	train, err := tm.resolveTrain(req.TrainId)
+	if err != nil {
+		tm.Logger.Error("GetManifest unknown train",
+			zap.String("train_id", req.TrainId),
+		)
+		return nil, err
+	}
+
+	// Check if the section exists; every departure has the same sections
+	if req.Section != "" {
+		if _, exists := tm.SeatManager.Sections[req.Section]; !exists {
+			tm.Logger.Error("GetManifest section not found",
+				zap.String("section", req.Section),
+			)
+			return nil, status.Error(codes.NotFound, "section not found")
+		}
+	}
+
+	tm.Logger.Info("GetManifest request",
+		zap.String("section", req.Section),
+		zap.String("train_id", train),
+		zap.String("departure_date", req.DepartureDate),
+		zap.Time("timestamp", tm.Now()),
+	)
+
+	response := &pb.GetManifestResponse{Entries: make([]*pb.ManifestEntry, 0)}
+	seats := make(map[string]map[int32]bool)
+	for email, receipt := range tm.Receipts {
+		if receipt.Seat == nil {
+			// Leave a corrupted receipt out rather than failing the listing
+			tm.Logger.Error("GetManifest ticket receipt has no seat",
+				zap.String("email", email),
+			)
+			continue
+		}
+		if !tm.onDeparture(receipt, train, req.DepartureDate) || (req.Section != "" && receipt.Seat.Section != req.Section) {
+			continue
+		}
+
+		response.Entries = append(response.Entries, &pb.ManifestEntry{
+			Seat:             receipt.Seat,
+			User:             receipt.User,
+			From:             receipt.From,
+			To:               receipt.To,
+			PricePaid:        receipt.PricePaid,
+			BookingReference: receipt.BookingReference,
+		})
+		response.TotalFares += receipt.PricePaid
+		if seats[receipt.Seat.Section] == nil {
+			seats[receipt.Seat.Section] = make(map[int32]bool)
+		}
+		seats[receipt.Seat.Section][receipt.Seat.SeatNumber] = true
+	}
+	for _, occupied := range seats {
+		response.OccupiedSeats += int32(len(occupied))
+	}
+	response.TotalPassengers = int32(len(response.Entries))
+
+	// List passengers in seating order; riders sharing a seat on different
+	// segments are ordered by email
+	entries := response.Entries
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Seat.Section != entries[j].Seat.Section {
+			return entries[i].Seat.Section < entries[j].Seat.Section
+		}
+		if entries[i].Seat.SeatNumber != entries[j].Seat.SeatNumber {
+			return entries[i].Seat.SeatNumber < entries[j].Seat.SeatNumber
+		}
+		return entries[i].User.Email < entries[j].User.Email
+	})
+
+	tm.Logger.Info("GetManifest successful",
+		zap.String("section", req.Section),
+		zap.String("departure_date", req.DepartureDate),
+		zap.Int32("total_passengers", response.TotalPassengers),
+		zap.Float64("total_fares", response.TotalFares),
+	)
+	return response, nil
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGetManifest(t *testing.T) {
+	tm := createTestTicketManager()
+	tm.StationConnection["London-Paris"] = 25.00
+
+	// Round-robin seats users alternately in A and B
+	purchase(t, tm, "test1@example.com", "London", "France")
+	purchase(t, tm, "test2@example.com", "London", "Paris")
+	purchase(t, tm, "test3@example.com", "London", "France")
+
+	response, err := tm.GetManifest(context.Background(), &pb.GetManifestRequest{})
+	assert.NoError(t, err)
+	assert.Len(t, response.Entries, 3)
+
+	// Passengers are listed by section, then seat
+	seats := make([]*pb.Seat, 0, len(response.Entries))
+	for _, entry := range response.Entries {
+		seats = append(seats, entry.Seat)
+	}
+	assert.Equal(t, []*pb.Seat{
+		{Section: "A", SeatNumber: 1},
+		{Section: "A", SeatNumber: 2},
+		{Section: "B", SeatNumber: 1},
+	}, seats)
+
+	entry := response.Entries[2]
+	assert.Equal(t, "test2@example.com", entry.User.Email)
+	assert.Equal(t, "Paris", entry.To)
+	assert.Equal(t, 25.00, entry.PricePaid)
+	assert.Equal(t, tm.Receipts["test2@example.com"].BookingReference, entry.BookingReference)
+
+	assert.Equal(t, int32(3), response.TotalPassengers)
+	assert.Equal(t, int32(3), response.OccupiedSeats)
+	assert.Equal(t, 65.00, response.TotalFares)
+
+	// Filtering by section only totals that section
+	response, err = tm.GetManifest(context.Background(), &pb.GetManifestRequest{Section: "A"})
+	assert.NoError(t, err)
+	assert.Len(t, response.Entries, 2)
+	assert.Equal(t, int32(2), response.TotalPassengers)
+	assert.Equal(t, 40.00, response.TotalFares)
+}
+
+func TestGetManifestEmpty(t *testing.T) {
+	tm := createTestTicketManager()
+
+	response, err := tm.GetManifest(context.Background(), &pb.GetManifestRequest{})
+	assert.NoError(t, err)
+	assert.Empty(t, response.Entries)
+	assert.Zero(t, response.TotalPassengers)
+	assert.Zero(t, response.TotalFares)
+}
+
+func TestGetManifestInvalidRequest(t *testing.T) {
+	tm := createTestTicketManager()
+
+	tests := []struct {
+		name         string
+		request      *pb.GetManifestRequest
+		expectedCode codes.Code
+	}{
+		{"Nil Request", nil, codes.InvalidArgument},
+		{"Invalid Date", &pb.GetManifestRequest{DepartureDate: "tomorrow"}, codes.InvalidArgument},
+		{"Nonexistent Section", &pb.GetManifestRequest{Section: "C"}, codes.NotFound},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			response, err := tm.GetManifest(context.Background(), test.request)
+			assert.Equal(t, test.expectedCode, status.Code(err))
+			assert.Nil(t, response)
+		})
+	}
+}
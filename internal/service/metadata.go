@@ -0,0 +1,34 @@
+package service
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// Limits on the metadata integrators attach to a purchase, so it can't bloat
+// receipts and snapshots.
+const (
+	maxMetadataEntries     = 16
+	maxMetadataKeyLength   = 64
+	maxMetadataValueLength = 256
+)
+
+// checkMetadata returns an error describing the first way the purchase
+// metadata breaks the limits, or nil if it is within them.
+func checkMetadata(metadata map[string]string) error {
+	if len(metadata) > maxMetadataEntries {
+		return fmt.Errorf("metadata has %d entries, at most %d are allowed", len(metadata), maxMetadataEntries)
+	}
+	for key, value := range metadata {
+		if key == "" {
+			return fmt.Errorf("metadata keys must not be empty")
+		}
+		if utf8.RuneCountInString(key) > maxMetadataKeyLength {
+			return fmt.Errorf("metadata keys must be at most %d characters", maxMetadataKeyLength)
+		}
+		if utf8.RuneCountInString(value) > maxMetadataValueLength {
+			return fmt.Errorf("metadata value for %q is longer than %d characters", key, maxMetadataValueLength)
+		}
+	}
+	return nil
+}
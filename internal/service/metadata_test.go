@@ -0,0 +1,74 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestPurchaseTicketMetadata(t *testing.T) {
+	tm := createTestTicketManager()
+	metadata := map[string]string{"loyalty_id": "L-1234", "promo_code": "SPRING"}
+
+	response, err := tryPurchase(tm, "test@example.com", withMetadata(metadata))
+	assert.NoError(t, err)
+	assert.Equal(t, metadata, response.Receipt.Metadata)
+
+	// The caller's map is not shared with the stored receipt
+	metadata["promo_code"] = "CHANGED"
+	assert.Equal(t, "SPRING", tm.Receipts["test@example.com"].Metadata["promo_code"])
+
+	byEmail, err := tm.GetReceipt(context.Background(), &pb.GetReceiptRequest{Email: "test@example.com"})
+	assert.NoError(t, err)
+	assert.Equal(t, "L-1234", byEmail.Receipt.Metadata["loyalty_id"])
+
+	byReference, err := tm.GetReceiptByReference(context.Background(), &pb.GetReceiptByReferenceRequest{BookingReference: response.Receipt.BookingReference})
+	assert.NoError(t, err)
+	assert.Equal(t, "L-1234", byReference.Receipt.Metadata["loyalty_id"])
+
+	// Metadata survives a restart
+	var buf bytes.Buffer
+	assert.NoError(t, tm.Snapshot(&buf))
+	restored := createTestTicketManager()
+	assert.NoError(t, restored.Restore(&buf))
+	assert.Equal(t, map[string]string{"loyalty_id": "L-1234", "promo_code": "SPRING"}, restored.Receipts["test@example.com"].Metadata)
+}
+
+func TestPurchaseTicketMetadataLimits(t *testing.T) {
+	tooMany := make(map[string]string, maxMetadataEntries+1)
+	for i := range maxMetadataEntries + 1 {
+		tooMany[fmt.Sprintf("key%d", i)] = "value"
+	}
+
+	tests := []struct {
+		name     string
+		metadata map[string]string
+	}{
+		{"Too Many Entries", tooMany},
+		{"Empty Key", map[string]string{"": "value"}},
+		{"Long Key", map[string]string{strings.Repeat("k", maxMetadataKeyLength+1): "value"}},
+		{"Long Value", map[string]string{"note": strings.Repeat("v", maxMetadataValueLength+1)}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tm := createTestTicketManager()
+			_, err := tryPurchase(tm, "test@example.com", withMetadata(test.metadata))
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+			assert.Empty(t, tm.Receipts)
+		})
+	}
+
+	// Metadata right at the limits is accepted
+	atLimit := map[string]string{strings.Repeat("k", maxMetadataKeyLength): strings.Repeat("v", maxMetadataValueLength)}
+	_, err := tryPurchase(createTestTicketManager(), "test@example.com", withMetadata(atLimit))
+	assert.NoError(t, err)
+}
@@ -0,0 +1,38 @@
+package service
+
+import (
+	"slices"
+	"sync"
+)
+
+// Histogram counts observations of small positive integers, such as the
+// sections scanned per seat assignment. It is safe for concurrent use.
+type Histogram struct {
+	mu     sync.Mutex
+	counts []int64 // counts[i] is the number of observations of i+1
+}
+
+// NewHistogram returns an empty histogram.
+func NewHistogram() *Histogram {
+	return &Histogram{}
+}
+
+// Observe records one observation of value. Values below 1 are counted as 1.
+func (h *Histogram) Observe(value int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	value = max(value, 1)
+	if len(h.counts) < value {
+		h.counts = append(h.counts, make([]int64, value-len(h.counts))...)
+	}
+	h.counts[value-1]++
+}
+
+// Counts returns the number of observations of each value: the first entry
+// counts observations of 1, the second of 2, up to the largest value seen.
+func (h *Histogram) Counts() []int64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return slices.Clone(h.counts)
+}
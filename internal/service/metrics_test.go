@@ -0,0 +1,46 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/sanjaykishor/rail-connect/internal/config"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestHistogram(t *testing.T) {
+	histogram := NewHistogram()
+	assert.Empty(t, histogram.Counts())
+
+	histogram.Observe(1)
+	histogram.Observe(3)
+	histogram.Observe(1)
+	histogram.Observe(0)
+	assert.Equal(t, []int64{3, 0, 1}, histogram.Counts(), "Values below 1 should be counted as 1")
+}
+
+func TestSectionsScanned(t *testing.T) {
+	seatManager := NewSeatManager([]config.SectionConfig{
+		{Name: "A", MaxSeats: 1},
+		{Name: "B", MaxSeats: 1},
+		{Name: "C", MaxSeats: 2},
+	}, zap.NewNop())
+
+	// On an empty train every rider is placed in the first section tried
+	for i := 0; i < 3; i++ {
+		_, _, err := seatManager.AssignSeat(WholeRoute)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, []int64{3}, seatManager.SectionsScanned.Counts())
+
+	// With A and B full the next rider is placed in the third section tried
+	section, _, err := seatManager.AssignSeat(WholeRoute)
+	assert.NoError(t, err)
+	assert.Equal(t, "C", section)
+	assert.Equal(t, []int64{3, 0, 1}, seatManager.SectionsScanned.Counts())
+
+	// Dated departures add to the same metric
+	_, _, err = seatManager.newDeparture().AssignSeat(WholeRoute)
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{4, 0, 1}, seatManager.SectionsScanned.Counts())
+}
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"sort"
+
+	"go.uber.org/zap"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// seatDistance returns how far apart two seats of a section are. With a row
+// layout it is the larger of the rows and the seats across between them, so
+// the seats surrounding one are all a distance of 1 away; otherwise it is the
+// difference in seat numbers.
+func (sm *SeatManager) seatDistance(a, b int) int {
+	width := len(sm.RowLayout)
+	if width == 0 {
+		return abs(a - b)
+	}
+	rows := abs((a-1)/width - (b-1)/width)
+	across := abs((a-1)%width - (b-1)%width)
+	return max(rows, across)
+}
+
+// abs returns the absolute value of n.
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// NearbyVacantSeats returns the vacant seats within radius of an anchor seat
+// in the same section, closest first and then by seat number. The anchor
+// itself is left out.
+func (sm *SeatManager) NearbyVacantSeats(sectionName string, seatNumber, radius int) ([]int, error) {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	section, _, err := sm.seat(sectionName, seatNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only rows within radius can hold nearby seats
+	low, high := seatNumber-radius, seatNumber+radius
+	if width := len(sm.RowLayout); width > 0 {
+		low, high = seatNumber-radius*width-width, seatNumber+radius*width+width
+	}
+	nearby := make([]int, 0)
+	for seatNum := max(low, 1); seatNum <= min(high, section.MaxSeats); seatNum++ {
+		seat, exists := section.Seats[seatNum]
+		if seatNum == seatNumber || !exists || !seat.Available || seat.Blocked {
+			continue
+		}
+		if sm.seatDistance(seatNumber, seatNum) <= radius {
+			nearby = append(nearby, seatNum)
+		}
+	}
+	sort.SliceStable(nearby, func(i, j int) bool {
+		return sm.seatDistance(seatNumber, nearby[i]) < sm.seatDistance(seatNumber, nearby[j])
+	})
+	return nearby, nil
+}
+
+// GetNearbyAvailableSeats returns the vacant seats on a departure, the undated
+// default train unless the request names a train or date, near a given seat,
+// such as the caller's own, to move a friend close to them.
+func (tm *TicketManager) GetNearbyAvailableSeats(ctx context.Context, req *pb.GetNearbyAvailableSeatsRequest) (*pb.GetNearbyAvailableSeatsResponse, error) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	tm.Logger.Info("GetNearbyAvailableSeats request received")
+
+	// Validate the request
+	if req == nil {
+		tm.Logger.Error("GetNearbyAvailableSeats request is nil")
+		return nil, status.Error(codes.InvalidArgument, "request is nil")
+	}
+	if req.Section == "" || req.SeatNumber <= 0 || req.Radius <= 0 {
+		tm.Logger.Error("GetNearbyAvailableSeats request missing required fields",
+			zap.String("section", req.Section),
+			zap.Int32("seat_number", req.SeatNumber),
+			zap.Int32("radius", req.Radius),
+		)
+		return nil, status.Error(codes.InvalidArgument, "missing required fields")
+	}
+
+	train, err := tm.resolveDeparture(req.TrainId, req.DepartureDate)
+	if err != nil {
+		tm.Logger.Error("GetNearbyAvailableSeats invalid departure",
+			zap.String("train_id", req.TrainId),
+			zap.String("departure_date", req.DepartureDate),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	tm.Logger.Info("GetNearbyAvailableSeats request",
+		zap.String("section", req.Section),
+		zap.Int32("seat_number", req.SeatNumber),
+		zap.Int32("radius", req.Radius),
+		zap.String("train_id", train),
+		zap.String("departure_date", req.DepartureDate),
+		zap.Time("timestamp", tm.Now()),
+	)
+
+	nearby, err := tm.readDeparture(train, req.DepartureDate).NearbyVacantSeats(req.Section, int(req.SeatNumber), int(req.Radius))
+	if err != nil {
+		tm.Logger.Error("GetNearbyAvailableSeats failed to find seat",
+			zap.String("section", req.Section),
+			zap.Int32("seat_number", req.SeatNumber),
+			zap.Error(err),
+		)
+		return nil, seatError(err)
+	}
+
+	seats := make([]*pb.Seat, 0, len(nearby))
+	for _, seatNum := range nearby {
+		seats = append(seats, &pb.Seat{Section: req.Section, SeatNumber: int32(seatNum)})
+	}
+
+	tm.Logger.Info("GetNearbyAvailableSeats successful",
+		zap.String("section", req.Section),
+		zap.Int32("seat_number", req.SeatNumber),
+		zap.Int("seat_count", len(seats)),
+	)
+	return &pb.GetNearbyAvailableSeatsResponse{Seats: seats}, nil
+}
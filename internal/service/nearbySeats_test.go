@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+)
+
+// createNearbyTicketManager seats riders in A5 and A7 and blocks A4.
+func createNearbyTicketManager(t *testing.T) *TicketManager {
+	tm := createTestTicketManager()
+	for email, seat := range map[string]int32{"first@example.com": 5, "second@example.com": 7} {
+		purchase(t, tm, email, "London", "France")
+		_, err := tm.UpdateUserSeat(context.Background(), &pb.UpdateUserSeatRequest{
+			Email:   email,
+			NewSeat: &pb.Seat{Section: "A", SeatNumber: seat},
+		})
+		if err != nil {
+			t.Fatalf("Failed to move %s to seat %d: %v", email, seat, err)
+		}
+	}
+	if err := tm.SeatManager.BlockSeat("A", 4); err != nil {
+		t.Fatalf("Failed to block seat: %v", err)
+	}
+	return tm
+}
+
+// nearbySeatNumbers returns the seat numbers near an anchor seat in section A.
+func nearbySeatNumbers(t *testing.T, tm *TicketManager, seatNumber, radius int32) []int32 {
+	response, err := tm.GetNearbyAvailableSeats(context.Background(), &pb.GetNearbyAvailableSeatsRequest{
+		Section:    "A",
+		SeatNumber: seatNumber,
+		Radius:     radius,
+	})
+	if err != nil {
+		t.Fatalf("Failed to get nearby seats: %v", err)
+	}
+	numbers := make([]int32, 0, len(response.Seats))
+	for _, seat := range response.Seats {
+		numbers = append(numbers, seat.SeatNumber)
+	}
+	return numbers
+}
+
+func TestGetNearbyAvailableSeats(t *testing.T) {
+	tm := createNearbyTicketManager(t)
+
+	assert.Equal(t, []int32{6}, nearbySeatNumbers(t, tm, 5, 1), "Blocked seat 4 should be left out")
+	assert.Equal(t, []int32{6, 3}, nearbySeatNumbers(t, tm, 5, 2), "Occupied seat 7 should be left out, closest first")
+	assert.Equal(t, []int32{2}, nearbySeatNumbers(t, tm, 1, 1), "The radius should stop at the start of the section")
+	assert.Equal(t, []int32{19}, nearbySeatNumbers(t, tm, 20, 1), "The radius should stop at the end of the section")
+}
+
+func TestGetNearbyAvailableSeatsRowLayout(t *testing.T) {
+	tm := createNearbyTicketManager(t)
+	tm.SeatManager.RowLayout = "WAAW"
+
+	// Seat 6 is the second seat of the second row, surrounded by 1-3, 5, 7 and 9-11
+	assert.Equal(t, []int32{1, 2, 3, 9, 10, 11}, nearbySeatNumbers(t, tm, 6, 1))
+
+	// Seat 8 is two seats across, and 14 two rows back
+	nearby := nearbySeatNumbers(t, tm, 6, 2)
+	assert.Contains(t, nearby, int32(8))
+	assert.Contains(t, nearby, int32(14))
+	assert.Equal(t, []int32{1, 2, 3, 9, 10, 11}, nearby[:6], "Seats a row away should come first")
+}
+
+func TestGetNearbyAvailableSeatsInvalid(t *testing.T) {
+	tm := createNearbyTicketManager(t)
+
+	for _, req := range []*pb.GetNearbyAvailableSeatsRequest{
+		{Section: "Z", SeatNumber: 1, Radius: 1},
+		{Section: "A", SeatNumber: 21, Radius: 1},
+	} {
+		_, err := tm.GetNearbyAvailableSeats(context.Background(), req)
+		assert.Equal(t, codes.NotFound, status.Code(err), "Unknown anchor %v", req)
+	}
+
+	_, err := tm.GetNearbyAvailableSeats(context.Background(), &pb.GetNearbyAvailableSeatsRequest{Section: "A", SeatNumber: 1})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "A radius is required")
+
+	_, err = tm.GetNearbyAvailableSeats(context.Background(), nil)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
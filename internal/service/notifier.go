@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+)
+
+// notifyTimeout bounds how long a single notification may take to send.
+const notifyTimeout = 30 * time.Second
+
+// Notifier tells passengers about changes to their tickets. TicketManager calls
+// it in the background after a booking, seat change or cancellation succeeds.
+type Notifier interface {
+	SendConfirmation(ctx context.Context, receipt *pb.Receipt) error
+	SendCancellation(ctx context.Context, receipt *pb.Receipt) error
+}
+
+// NopNotifier discards every notification. It is the default Notifier.
+type NopNotifier struct{}
+
+func (NopNotifier) SendConfirmation(ctx context.Context, receipt *pb.Receipt) error { return nil }
+
+func (NopNotifier) SendCancellation(ctx context.Context, receipt *pb.Receipt) error { return nil }
+
+// SMTPNotifier emails passengers through an SMTP server.
+type SMTPNotifier struct {
+	Addr string
+	Auth smtp.Auth
+	From string
+}
+
+// NewSMTPNotifier creates an SMTPNotifier for the server at host:port. Plain
+// authentication is used when a username is given.
+func NewSMTPNotifier(host string, port int, username, password, from string) *SMTPNotifier {
+	notifier := &SMTPNotifier{
+		Addr: net.JoinHostPort(host, strconv.Itoa(port)),
+		From: from,
+	}
+	if username != "" {
+		notifier.Auth = smtp.PlainAuth("", username, password, host)
+	}
+	return notifier
+}
+
+// SendConfirmation emails the passenger the details of their ticket.
+func (n *SMTPNotifier) SendConfirmation(ctx context.Context, receipt *pb.Receipt) error {
+	return n.send(ctx, receipt, "Your ticket is confirmed", "Your ticket is confirmed.")
+}
+
+// SendCancellation emails the passenger that their ticket was cancelled.
+func (n *SMTPNotifier) SendCancellation(ctx context.Context, receipt *pb.Receipt) error {
+	return n.send(ctx, receipt, "Your ticket has been cancelled", "Your ticket has been cancelled.")
+}
+
+// send delivers a message about the receipt, giving up once ctx is done.
+func (n *SMTPNotifier) send(ctx context.Context, receipt *pb.Receipt, subject, summary string) error {
+	to := receipt.GetUser().GetEmail()
+	if to == "" {
+		return fmt.Errorf("receipt has no email address")
+	}
+
+	msg := composeMessage(n.From, to, subject, summary, receipt)
+	done := make(chan error, 1)
+	go func() {
+		done <- smtp.SendMail(n.Addr, n.Auth, n.From, []string{to}, msg)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// composeMessage builds a plain-text email describing the receipt.
+func composeMessage(from, to, subject, summary string, receipt *pb.Receipt) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", to)
+	fmt.Fprintf(&b, "Subject: %s\r\n", subject)
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	fmt.Fprintf(&b, "%s\r\n\r\n", summary)
+	fmt.Fprintf(&b, "Journey: %s to %s\r\n", receipt.From, receipt.To)
+	fmt.Fprintf(&b, "Seat: section %s, seat %d\r\n", receipt.GetSeat().GetSection(), receipt.GetSeat().GetSeatNumber())
+	if receipt.BookingFee > 0 {
+		fmt.Fprintf(&b, "Fare: %.2f\r\n", receipt.Fare)
+		fmt.Fprintf(&b, "Booking fee: %.2f\r\n", receipt.BookingFee)
+	}
+	fmt.Fprintf(&b, "Price paid: %.2f\r\n", receipt.PricePaid)
+	if receipt.Status == pb.BookingStatus_BOOKING_STATUS_PENDING_PAYMENT {
+		fmt.Fprintf(&b, "Payment due by: %s\r\n", time.Unix(receipt.PaymentDueAt, 0).UTC().Format(time.RFC3339))
+	}
+	return []byte(b.String())
+}
+
+// notify tells the passenger about a change to their ticket in the background
+// so the RPC never waits on it. Cancellations send a cancellation notice and
+// every other change a fresh confirmation. Failures are logged. Callers must
+// hold tm.mu.
+func (tm *TicketManager) notify(eventType pb.ReceiptEventType, receipt *pb.Receipt) {
+	send := Notifier.SendConfirmation
+	if eventType == pb.ReceiptEventType_RECEIPT_EVENT_CANCELLED {
+		send = Notifier.SendCancellation
+	}
+
+	// The receipt may change once tm.mu is released
+	receipt = proto.Clone(receipt).(*pb.Receipt)
+	notifier := tm.Notifier
+
+	tm.notifications.Add(1)
+	go func() {
+		defer tm.notifications.Done()
+
+		ctx, cancel := context.WithTimeout(context.Background(), notifyTimeout)
+		defer cancel()
+
+		if err := send(notifier, ctx, receipt); err != nil {
+			tm.Logger.Error("Failed to send notification",
+				zap.String("email", receipt.GetUser().GetEmail()),
+				zap.String("event", eventType.String()),
+				zap.Error(err),
+			)
+		}
+	}()
+}
+
+// WaitForNotifications blocks until every notification already started has
+// been sent or has failed.
+func (tm *TicketManager) WaitForNotifications() {
+	tm.notifications.Wait()
+}
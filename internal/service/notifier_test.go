@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+)
+
+// notification is a message captured by recordingNotifier.
+type notification struct {
+	kind    string
+	email   string
+	section string
+	seat    int32
+}
+
+// recordingNotifier records every notification it is asked to send.
+type recordingNotifier struct {
+	mu   sync.Mutex
+	sent []notification
+	err  error
+}
+
+func (n *recordingNotifier) record(kind string, receipt *pb.Receipt) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.sent = append(n.sent, notification{kind, receipt.User.Email, receipt.Seat.Section, receipt.Seat.SeatNumber})
+	return n.err
+}
+
+func (n *recordingNotifier) SendConfirmation(ctx context.Context, receipt *pb.Receipt) error {
+	return n.record("confirmation", receipt)
+}
+
+func (n *recordingNotifier) SendCancellation(ctx context.Context, receipt *pb.Receipt) error {
+	return n.record("cancellation", receipt)
+}
+
+func TestNotifications(t *testing.T) {
+	tm := createTestTicketManager()
+	notifier := &recordingNotifier{}
+	tm.Notifier = notifier
+
+	purchase(t, tm, "user1@example.com", "London", "France")
+	purchase(t, tm, "user2@example.com", "London", "France")
+
+	_, err := tm.PurchaseTicket(context.Background(), &pb.PurchaseTicketRequest{
+		User:   &pb.User{Email: "user3@example.com"},
+		From:   "London",
+		To:     "France",
+		DryRun: true,
+	})
+	assert.NoError(t, err)
+
+	_, err = tm.UpdateUserSeat(context.Background(), &pb.UpdateUserSeatRequest{
+		Email:   "user1@example.com",
+		NewSeat: &pb.Seat{Section: "A", SeatNumber: 5},
+	})
+	assert.NoError(t, err)
+
+	_, err = tm.RemoveUser(context.Background(), &pb.RemoveUserRequest{Email: "user2@example.com"})
+	assert.NoError(t, err)
+
+	// Failed mutations send nothing
+	_, err = tm.RemoveUser(context.Background(), &pb.RemoveUserRequest{Email: "user2@example.com"})
+	assert.Error(t, err)
+
+	tm.WaitForNotifications()
+	assert.ElementsMatch(t, []notification{
+		{"confirmation", "user1@example.com", "A", 1},
+		{"confirmation", "user2@example.com", "B", 1},
+		{"confirmation", "user1@example.com", "A", 5},
+		{"cancellation", "user2@example.com", "B", 1},
+	}, notifier.sent)
+}
+
+func TestNotificationFailureDoesNotFailRPC(t *testing.T) {
+	tm := createTestTicketManager()
+	notifier := &recordingNotifier{err: errors.New("mail server down")}
+	tm.Notifier = notifier
+
+	receipt := purchase(t, tm, "user1@example.com", "London", "France")
+	assert.NotNil(t, receipt, "Booking should succeed even if the notification fails")
+
+	tm.WaitForNotifications()
+	assert.Len(t, notifier.sent, 1)
+}
+
+func TestComposeMessage(t *testing.T) {
+	msg := string(composeMessage("tickets@example.com", "user1@example.com", "Your ticket is confirmed", "Your ticket is confirmed.", &pb.Receipt{
+		User:      &pb.User{Email: "user1@example.com"},
+		From:      "London",
+		To:        "France",
+		PricePaid: 20,
+		Seat:      &pb.Seat{Section: "A", SeatNumber: 3},
+	}))
+
+	assert.True(t, strings.HasPrefix(msg, "From: tickets@example.com\r\nTo: user1@example.com\r\nSubject: Your ticket is confirmed\r\n"))
+	assert.Contains(t, msg, "Journey: London to France")
+	assert.Contains(t, msg, "Seat: section A, seat 3")
+	assert.Contains(t, msg, "Price paid: 20.00")
+	assert.NotContains(t, msg, "Booking fee", "Receipts without a fee should not itemize one")
+
+	msg = string(composeMessage("tickets@example.com", "user1@example.com", "Your ticket is confirmed", "Your ticket is confirmed.", &pb.Receipt{
+		User:       &pb.User{Email: "user1@example.com"},
+		PricePaid:  21.50,
+		Fare:       20,
+		BookingFee: 1.50,
+		Seat:       &pb.Seat{Section: "A", SeatNumber: 3},
+	}))
+	assert.Contains(t, msg, "Fare: 20.00\r\nBooking fee: 1.50\r\nPrice paid: 21.50")
+	assert.NotContains(t, msg, "Payment due", "Confirmed bookings have nothing left to pay")
+
+	msg = string(composeMessage("tickets@example.com", "user1@example.com", "Your ticket is confirmed", "Your ticket is confirmed.", &pb.Receipt{
+		User:         &pb.User{Email: "user1@example.com"},
+		PricePaid:    20,
+		Seat:         &pb.Seat{Section: "A", SeatNumber: 3},
+		Status:       pb.BookingStatus_BOOKING_STATUS_PENDING_PAYMENT,
+		PaymentDueAt: time.Date(2024, 3, 10, 9, 15, 0, 0, time.UTC).Unix(),
+	}))
+	assert.Contains(t, msg, "Payment due by: 2024-03-10T09:15:00Z")
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/sanjaykishor/rail-connect/internal/config"
+	pb "github.com/sanjaykishor/rail-connect/proto"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+// errWaitlisted is returned by assignSeat when the rider's section is full and
+// waitlists overflow.
+var errWaitlisted = errors.New("section is full, rider waitlisted")
+
+// waitlistEntry is a purchase waiting for a seat in a full section.
+type waitlistEntry struct {
+	request *pb.PurchaseTicketRequest
+	journey Journey
+}
+
+// overflow applies the section's overflow policy after it turned out to be
+// full. It returns nil when the booking should spill into other sections.
+func (tm *TicketManager) overflow(section string) error {
+	switch tm.SeatManager.OverflowPolicy(section) {
+	case config.OverflowReject:
+		return fmt.Errorf("%w: %s", ErrSectionFull, section)
+	case config.OverflowWaitlist:
+		return errWaitlisted
+	default:
+		return nil
+	}
+}
+
+// joinWaitlist queues a purchase for a seat in the full section and returns the
+// rider's position in the queue, starting at 1. A rider already waiting for the
+// section keeps their place. Callers must hold tm.mu.
+func (tm *TicketManager) joinWaitlist(section string, req *pb.PurchaseTicketRequest, journey Journey) int {
+	queue := tm.waitlists[section]
+	for i, entry := range queue {
+		if entry.request.User.Email == req.User.Email {
+			return i + 1
+		}
+	}
+
+	// The request may be reused by the caller once the RPC returns
+	request := proto.Clone(req).(*pb.PurchaseTicketRequest)
+	tm.waitlists[section] = append(queue, waitlistEntry{request: request, journey: journey})
+	return len(tm.waitlists[section])
+}
+
+// promoteWaitlist books riders waiting for the section on the train and date
+// into it, in the order they joined, for as long as seats are free for them.
+// Riders waiting for other trains or dates keep their place, and riders who
+// booked elsewhere in the meantime are dropped. Callers must hold tm.mu.
+func (tm *TicketManager) promoteWaitlist(section, train, date string) {
+	train = tm.trainOrDefault(train)
+	queue := tm.waitlists[section]
+	remaining := make([]waitlistEntry, 0, len(queue))
+	stopped := false
+	for _, entry := range queue {
+		req := entry.request
+		if _, booked := tm.Receipts[req.User.Email]; booked {
+			continue
+		}
+		if stopped || req.TrainId != train || req.DepartureDate != date {
+			remaining = append(remaining, entry)
+			continue
+		}
+		if !tm.withinRouteCap(req.From, req.To, 1) {
+			remaining = append(remaining, entry)
+			stopped = true
+			continue
+		}
+
+		seat, err := tm.departure(train, date).AssignSeatInSection(section, entry.journey)
+		if err != nil {
+			remaining = append(remaining, entry)
+			stopped = true
+			continue
+		}
+
+		// The fare class may have sold out while the rider waited
+		if !tm.fareClassAvailable(req.FareClass, 1) {
+			req.FareClass = ""
+		}
+		price, _ := tm.fare(req.From, req.To)
+		receipt := tm.newReceipt(req, section, seat, price, tm.Now())
+		tm.recordBooking(receipt, waitlistTrace(req, section))
+
+		tm.Logger.Info("Waitlisted rider booked",
+			zap.String("user", req.User.Email),
+			zap.String("section", section),
+			zap.Int("seat_number", seat),
+			zap.String("train_id", train),
+			zap.String("departure_date", date),
+		)
+	}
+
+	if len(remaining) == 0 {
+		delete(tm.waitlists, section)
+	} else {
+		tm.waitlists[section] = remaining
+	}
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sanjaykishor/rail-connect/internal/config"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// createOverflowTicketManager returns a TicketManager whose two-seat section A
+// overflows with the given policy, next to a roomier section B.
+func createOverflowTicketManager(policy string) *TicketManager {
+	seatManager := NewSeatManager([]config.SectionConfig{
+		{Name: "A", MaxSeats: 2, OverflowPolicy: policy},
+		{Name: "B", MaxSeats: 4},
+	}, zap.NewNop())
+	return NewTicketManager(seatManager, map[string]float64{"London-France": 20.00}, zap.NewNop())
+}
+
+// fillSectionA books both seats of section A.
+func fillSectionA(t *testing.T, tm *TicketManager) {
+	t.Helper()
+	for _, email := range []string{"first@example.com", "second@example.com"} {
+		response, err := tryPurchase(tm, email, inSection("A"))
+		assert.NoError(t, err)
+		assert.Equal(t, "A", response.Receipt.Seat.Section)
+	}
+}
+
+func TestOverflowSpill(t *testing.T) {
+	tm := createOverflowTicketManager(config.OverflowSpill)
+	fillSectionA(t, tm)
+
+	response, err := tryPurchase(tm, "late@example.com", inSection("A"))
+	assert.NoError(t, err)
+	assert.Equal(t, "B", response.Receipt.Seat.Section, "Full section should spill into the next one")
+	assert.Empty(t, response.WaitlistedSection)
+}
+
+func TestOverflowReject(t *testing.T) {
+	tm := createOverflowTicketManager(config.OverflowReject)
+	fillSectionA(t, tm)
+
+	_, err := tryPurchase(tm, "late@example.com", inSection("A"))
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err), "Full section should reject the booking")
+	assert.NotContains(t, tm.Receipts, "late@example.com")
+	assert.Equal(t, 4, tm.SeatManager.VacantSeats("B"), "Nothing should spill into section B")
+
+	// Bookings without a preference still use round-robin
+	purchase(t, tm, "any@example.com", "London", "France")
+}
+
+func TestOverflowRejectDefaultSection(t *testing.T) {
+	tm := createOverflowTicketManager(config.OverflowReject)
+	tm.SeatManager.DefaultSection = "A"
+	purchase(t, tm, "first@example.com", "London", "France")
+	purchase(t, tm, "second@example.com", "London", "France")
+
+	_, err := tm.PurchaseTicket(context.Background(), &pb.PurchaseTicketRequest{
+		User: &pb.User{FirstName: "Sanjay", LastName: "Kishor", Email: "late@example.com"},
+		From: "London",
+		To:   "France",
+	})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err), "Full default section should reject the booking")
+}
+
+func TestOverflowWaitlist(t *testing.T) {
+	tm := createOverflowTicketManager(config.OverflowWaitlist)
+	fillSectionA(t, tm)
+
+	// A dry run reports the waitlist without joining it
+	response, err := tm.PurchaseTicket(context.Background(), &pb.PurchaseTicketRequest{
+		User:              &pb.User{FirstName: "Sanjay", LastName: "Kishor", Email: "late@example.com"},
+		From:              "London",
+		To:                "France",
+		PreferredSections: []string{"A"},
+		DryRun:            true,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "A", response.WaitlistedSection)
+	assert.Empty(t, tm.waitlists["A"])
+
+	response, err = tryPurchase(tm, "late@example.com", inSection("A"))
+	assert.NoError(t, err)
+	assert.Equal(t, "A", response.WaitlistedSection)
+	assert.Nil(t, response.Receipt, "Waitlisted riders get no receipt yet")
+	assert.NotContains(t, tm.Receipts, "late@example.com")
+
+	response, err = tryPurchase(tm, "later@example.com", inSection("A"))
+	assert.NoError(t, err)
+	assert.Contains(t, response.Message, "position 2")
+
+	// Asking again keeps the rider's place
+	response, err = tryPurchase(tm, "late@example.com", inSection("A"))
+	assert.NoError(t, err)
+	assert.Contains(t, response.Message, "position 1")
+
+	// A freed seat goes to the first rider in the queue
+	_, err = tm.RemoveUser(context.Background(), &pb.RemoveUserRequest{Email: "first@example.com"})
+	assert.NoError(t, err)
+	receipt, exists := tm.Receipts["late@example.com"]
+	assert.True(t, exists, "First waitlisted rider should be booked")
+	assert.Equal(t, "A", receipt.Seat.Section)
+	assert.NotContains(t, tm.Receipts, "later@example.com")
+	assert.Len(t, tm.waitlists["A"], 1)
+
+	// Moving a rider out of the section frees a seat too
+	_, err = tm.UpdateUserSeat(context.Background(), &pb.UpdateUserSeatRequest{
+		Email:   "second@example.com",
+		NewSeat: &pb.Seat{Section: "B"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "A", tm.Receipts["later@example.com"].Seat.Section)
+	assert.Empty(t, tm.waitlists["A"])
+}
@@ -0,0 +1,216 @@
+package service
+
+import (
+	"slices"
+	"time"
+
+	"go.uber.org/zap"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+)
+
+// PairSeating is how close together two riders booked together are seated,
+// best first.
+type PairSeating int
+
+const (
+	PairAdjacent    PairSeating = iota + 1 // Neighbouring seats in one section
+	PairSameSection                        // One section, not next to each other
+	PairSplit                              // Different sections
+)
+
+// pairSeatingLevels maps each seating level to its proto value.
+var pairSeatingLevels = map[PairSeating]pb.PairSeating{
+	PairAdjacent:    pb.PairSeating_PAIR_SEATING_ADJACENT,
+	PairSameSection: pb.PairSeating_PAIR_SEATING_SAME_SECTION,
+	PairSplit:       pb.PairSeating_PAIR_SEATING_SPLIT,
+}
+
+// Pair is the two seats assigned to riders booked together.
+type Pair struct {
+	Seating  PairSeating
+	SectionA string
+	SeatA    int
+	SectionB string
+	SeatB    int
+}
+
+// AssignPair books the journey on two seats for riders travelling together,
+// preferring neighbouring seats, then two seats in one section, then any two
+// seats. Sections are searched in the preferred order, then the default
+// section, then round-robin order.
+func (sm *SeatManager) AssignPair(preferred []string, journey Journey) (Pair, error) {
+	if err := sm.lockForAssignment(); err != nil {
+		return Pair{}, err
+	}
+	defer sm.mu.Unlock()
+	defer sm.seatsChanged()
+
+	pair, err := sm.findPair(preferred, journey)
+	if err != nil {
+		return Pair{}, err
+	}
+	sm.Sections[pair.SectionA].occupy(pair.SeatA, journey)
+	sm.Sections[pair.SectionB].occupy(pair.SeatB, journey)
+
+	sm.Logger.Info("Seats assigned to pair",
+		zap.String("section_a", pair.SectionA),
+		zap.Int("seat_a", pair.SeatA),
+		zap.String("section_b", pair.SectionB),
+		zap.Int("seat_b", pair.SeatB),
+		zap.Int("seating", int(pair.Seating)))
+	return pair, nil
+}
+
+// PeekPair reports the seats AssignPair would hand out next without assigning
+// them.
+func (sm *SeatManager) PeekPair(preferred []string, journey Journey) (Pair, error) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	return sm.findPair(preferred, journey)
+}
+
+// findPair returns the best two seats free for the journey without changing
+// any state. Callers must hold sm.mu.
+func (sm *SeatManager) findPair(preferred []string, journey Journey) (Pair, error) {
+	order := sm.pairOrder(preferred)
+
+	for _, section := range order {
+		for seatNum := 1; seatNum < section.MaxSeats; seatNum++ {
+			if section.isFree(seatNum, journey) && section.isFree(seatNum+1, journey) {
+				return Pair{PairAdjacent, section.Name, seatNum, section.Name, seatNum + 1}, nil
+			}
+		}
+	}
+
+	// Otherwise take two seats in one section, or the first free seat of two
+	// different sections
+	var singles []Pair
+	for _, section := range order {
+		free := make([]int, 0, 2)
+		for seatNum := 1; seatNum <= section.MaxSeats && len(free) < 2; seatNum++ {
+			if section.isFree(seatNum, journey) {
+				free = append(free, seatNum)
+			}
+		}
+		switch len(free) {
+		case 2:
+			return Pair{PairSameSection, section.Name, free[0], section.Name, free[1]}, nil
+		case 1:
+			singles = append(singles, Pair{SectionA: section.Name, SeatA: free[0]})
+		}
+	}
+	if len(singles) >= 2 {
+		return Pair{PairSplit, singles[0].SectionA, singles[0].SeatA, singles[1].SectionA, singles[1].SeatA}, nil
+	}
+
+	return Pair{}, ErrNoSeatsAvailable
+}
+
+// pairOrder returns the sections accepting new riders to search for a pair:
+// the preferred ones, then the default section, then the rest from the
+// round-robin index. Callers must hold sm.mu.
+func (sm *SeatManager) pairOrder(preferred []string) []*Section {
+	names := append(slices.Clone(preferred), sm.DefaultSection)
+	for i := range sm.SectionOrder {
+		names = append(names, sm.SectionOrder[(sm.nextSectionIdx+i)%len(sm.SectionOrder)])
+	}
+
+	order := make([]*Section, 0, len(sm.SectionOrder))
+	for _, name := range names {
+		section, exists := sm.Sections[name]
+		if exists && sm.acceptsNew(name) && !slices.Contains(order, section) {
+			order = append(order, section)
+		}
+	}
+	return order
+}
+
+// isFree reports whether the seat exists and none of the journey's segments
+// are booked on it.
+func (section *Section) isFree(seatNum int, journey Journey) bool {
+	seat, exists := section.Seats[seatNum]
+	return exists && seat.isFree(journey)
+}
+
+// assignPair picks seats for a purchase with a companion on the purchase's
+// departure, trying the rider's preferred sections and then their loyalty
+// tier's. Pairs are never waitlisted, so overflow policies and destination
+// affinity don't apply. Pairs minimizing the price prefer the cheapest
+// sections at the base fare and time now. Callers must hold tm.mu.
+func (tm *TicketManager) assignPair(req *pb.PurchaseTicketRequest, price float64, journey Journey, now time.Time) (Pair, error) {
+	departure := tm.departure(req.TrainId, req.DepartureDate)
+	preferred := append(slices.Clone(req.PreferredSections), tm.tierSections(departure, req.LoyaltyTier)...)
+	if req.MinimizePrice {
+		preferred = tm.sectionsByFare(departure, price, now)
+	}
+	if req.DryRun {
+		return departure.PeekPair(preferred, journey)
+	}
+	return departure.AssignPair(preferred, journey)
+}
+
+// riders returns the number of tickets a purchase books.
+func riders(req *pb.PurchaseTicketRequest) int {
+	if req.Companion != nil {
+		return 2
+	}
+	return 1
+}
+
+// bookPair seats a purchase and its companion together and records both
+// bookings, reporting whether they were made rather than a dry run. The timer
+// marks each phase of the booking. Callers must hold tm.mu.
+func (tm *TicketManager) bookPair(req *pb.PurchaseTicketRequest, price float64, journey Journey, now time.Time, timer *phaseTimer) (*pb.PurchaseTicketResponse, bool, error) {
+	pair, err := tm.assignPair(req, price, journey, now)
+	timer.mark("assign_seat")
+	if err != nil {
+		tm.Logger.Error("PurchaseTicket failed to assign seats to pair",
+			zap.String("user", req.User.Email),
+			zap.String("companion", req.Companion.Email),
+			zap.String("from", req.From),
+			zap.String("to", req.To),
+			zap.Error(err),
+		)
+		return nil, false, seatError(err)
+	}
+
+	receipt := tm.newReceipt(req, pair.SectionA, pair.SeatA, price, now)
+	companion := tm.newReceipt(req, pair.SectionB, pair.SeatB, price, now)
+	companion.User = req.Companion
+	response := &pb.PurchaseTicketResponse{
+		Message:          "Tickets booked successfully",
+		Receipt:          receipt,
+		CompanionReceipt: companion,
+		PairSeating:      pairSeatingLevels[pair.Seating],
+	}
+	if slices.Contains(req.PreferredSections, pair.SectionA) {
+		response.MatchedPreference = pair.SectionA
+	}
+
+	// A dry run reports the would-be seats without recording anything
+	if req.DryRun {
+		tm.Logger.Info("PurchaseTicket dry run successful",
+			zap.String("user", req.User.Email),
+			zap.String("companion", req.Companion.Email),
+			zap.Stringer("pair_seating", response.PairSeating),
+		)
+		response.Message = "Tickets can be booked"
+		return response, false, nil
+	}
+
+	trace := AssignmentTrace{Strategy: StrategyPair, PreferenceHonored: response.MatchedPreference != ""}
+	tm.recordBooking(receipt, trace)
+	tm.recordBooking(companion, trace)
+	if tm.DedupeWindow > 0 {
+		tm.recentPurchases[purchaseKey{req.User.Email, req.From, req.To, req.TrainId, req.DepartureDate}] = recentPurchase{
+			receipt:     receipt,
+			companion:   companion,
+			pairSeating: response.PairSeating,
+			purchasedAt: now,
+		}
+	}
+	timer.mark("record")
+	return response, true, nil
+}
@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sanjaykishor/rail-connect/internal/config"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// createPairTicketManager returns a ticket manager with two sections of three
+// seats, where seat 2 of section B is blocked.
+func createPairTicketManager() *TicketManager {
+	seatManager := NewSeatManager([]config.SectionConfig{
+		{Name: "A", MaxSeats: 3},
+		{Name: "B", MaxSeats: 3, BlockedSeats: []int{2}},
+	}, zap.NewNop())
+	return NewTicketManager(seatManager, map[string]float64{"London-France": 20.00}, zap.NewNop())
+}
+
+func TestPairSeatingFallbacks(t *testing.T) {
+	tm := createPairTicketManager()
+
+	// Both sections are empty, so the first pair sits together
+	response, err := tryPurchase(tm, "first@example.com", withCompanion("first.companion@example.com"))
+	assert.NoError(t, err)
+	assert.Equal(t, pb.PairSeating_PAIR_SEATING_ADJACENT, response.PairSeating)
+	assert.Equal(t, &pb.Seat{Section: "A", SeatNumber: 1}, response.Receipt.Seat)
+	assert.Equal(t, &pb.Seat{Section: "A", SeatNumber: 2}, response.CompanionReceipt.Seat)
+	assert.Equal(t, "first.companion@example.com", response.CompanionReceipt.User.Email)
+
+	// Only section B has two seats left, either side of the blocked one
+	response, err = tryPurchase(tm, "second@example.com", withCompanion("second.companion@example.com"))
+	assert.NoError(t, err)
+	assert.Equal(t, pb.PairSeating_PAIR_SEATING_SAME_SECTION, response.PairSeating)
+	assert.Equal(t, &pb.Seat{Section: "B", SeatNumber: 1}, response.Receipt.Seat)
+	assert.Equal(t, &pb.Seat{Section: "B", SeatNumber: 3}, response.CompanionReceipt.Seat)
+
+	// A single seat left can't take a pair
+	_, err = tryPurchase(tm, "third@example.com", withCompanion("third.companion@example.com"))
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.NotContains(t, tm.Receipts, "third@example.com")
+
+	// One seat left in each section splits the pair
+	_, err = tm.RemoveUser(context.Background(), &pb.RemoveUserRequest{Email: "second@example.com"})
+	assert.NoError(t, err)
+	response, err = tryPurchase(tm, "third@example.com", withCompanion("third.companion@example.com"))
+	assert.NoError(t, err)
+	assert.Equal(t, pb.PairSeating_PAIR_SEATING_SPLIT, response.PairSeating)
+	assert.Equal(t, &pb.Seat{Section: "A", SeatNumber: 3}, response.Receipt.Seat)
+	assert.Equal(t, &pb.Seat{Section: "B", SeatNumber: 1}, response.CompanionReceipt.Seat)
+
+	assert.Contains(t, tm.Receipts, "third.companion@example.com", "The companion should have a ticket of their own")
+	assert.Equal(t, 0, tm.SeatManager.Sections["A"].VacantSeats)
+	assert.Equal(t, 0, tm.SeatManager.Sections["B"].VacantSeats)
+}
+
+func TestPairSeatingPreferredSection(t *testing.T) {
+	tm := createTestTicketManager()
+
+	response, err := tm.PurchaseTicket(context.Background(), &pb.PurchaseTicketRequest{
+		User:              &pb.User{FirstName: "Sanjay", LastName: "Kishor", Email: "test@example.com"},
+		Companion:         &pb.User{FirstName: "Priya", LastName: "Kishor", Email: "companion@example.com"},
+		From:              "London",
+		To:                "France",
+		PreferredSections: []string{"B"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "B", response.MatchedPreference)
+	assert.Equal(t, "B", response.CompanionReceipt.Seat.Section)
+	assert.Equal(t, pb.PairSeating_PAIR_SEATING_ADJACENT, response.PairSeating)
+}
+
+func TestPairSeatingDryRun(t *testing.T) {
+	tm := createPairTicketManager()
+
+	response, err := tm.PurchaseTicket(context.Background(), &pb.PurchaseTicketRequest{
+		User:      &pb.User{FirstName: "Sanjay", LastName: "Kishor", Email: "test@example.com"},
+		Companion: &pb.User{FirstName: "Priya", LastName: "Kishor", Email: "companion@example.com"},
+		From:      "London",
+		To:        "France",
+		DryRun:    true,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, pb.PairSeating_PAIR_SEATING_ADJACENT, response.PairSeating)
+	assert.Empty(t, tm.Receipts, "A dry run should not book anything")
+	assert.Equal(t, 3, tm.SeatManager.Sections["A"].VacantSeats)
+}
+
+func TestPairSeatingRouteCap(t *testing.T) {
+	tm := createTestTicketManager()
+	tm.RouteCaps = map[string]int{"London-France": 1}
+
+	_, err := tryPurchase(tm, "test@example.com", withCompanion("companion@example.com"))
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err), "A pair needs two tickets under the cap")
+	assert.Empty(t, tm.Receipts)
+}
+
+func TestPairSeatingInvalidCompanion(t *testing.T) {
+	tm := createTestTicketManager()
+
+	for _, companion := range []string{"", "test@example.com"} {
+		_, err := tryPurchase(tm, "test@example.com", withCompanion(companion))
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), "Companion %q should be rejected", companion)
+	}
+}
+
+func TestPairSeatingDedupe(t *testing.T) {
+	tm := createTestTicketManager()
+	tm.DedupeWindow = time.Minute
+
+	first, err := tryPurchase(tm, "test@example.com", withCompanion("companion@example.com"))
+	assert.NoError(t, err)
+	retry, err := tryPurchase(tm, "test@example.com", withCompanion("companion@example.com"))
+	assert.NoError(t, err)
+	assert.Same(t, first.CompanionReceipt, retry.CompanionReceipt, "A retry should return the original companion receipt")
+	assert.Equal(t, first.PairSeating, retry.PairSeating)
+	assert.Equal(t, 38, tm.SeatManager.Sections["A"].VacantSeats+tm.SeatManager.Sections["B"].VacantSeats)
+}
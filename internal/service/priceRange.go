@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"sort"
+
+	"go.uber.org/zap"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ListReceiptsByPriceRange lists the receipts of every departure whose price
+// paid is within the range, bounds included, for revenue analysis.
+func (tm *TicketManager) ListReceiptsByPriceRange(ctx context.Context, req *pb.ListReceiptsByPriceRangeRequest) (*pb.ListReceiptsByPriceRangeResponse, error) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	tm.Logger.Info("ListReceiptsByPriceRange request received")
+
+	// Validate the request
+	if req == nil {
+		tm.Logger.Error("ListReceiptsByPriceRange request is nil")
+		return nil, status.Error(codes.InvalidArgument, "request is nil")
+	}
+	if req.MinPrice < 0 || req.MaxPrice < req.MinPrice {
+		tm.Logger.Error("ListReceiptsByPriceRange invalid price range",
+			zap.Float64("min_price", req.MinPrice),
+			zap.Float64("max_price", req.MaxPrice),
+		)
+		return nil, status.Error(codes.InvalidArgument, "price range must be non-negative with min no greater than max")
+	}
+	if err := checkReceiptMask(req.ReceiptMask); err != nil {
+		tm.Logger.Error("ListReceiptsByPriceRange invalid receipt mask",
+			zap.Strings("paths", req.ReceiptMask.GetPaths()),
+		)
+		return nil, err
+	}
+
+	tm.Logger.Info("ListReceiptsByPriceRange request",
+		zap.Float64("min_price", req.MinPrice),
+		zap.Float64("max_price", req.MaxPrice),
+		zap.Time("timestamp", tm.Now()),
+	)
+
+	receipts := make([]*pb.Receipt, 0)
+	for email, receipt := range tm.Receipts {
+		if receipt.Seat == nil {
+			tm.Logger.Error("ListReceiptsByPriceRange ticket receipt has no seat",
+				zap.String("email", email),
+			)
+			continue
+		}
+		if receipt.PricePaid >= req.MinPrice && receipt.PricePaid <= req.MaxPrice {
+			receipts = append(receipts, receipt)
+		}
+	}
+
+	// List the cheapest first; receipts at the same price are ordered by email
+	sort.Slice(receipts, func(i, j int) bool {
+		if receipts[i].PricePaid != receipts[j].PricePaid {
+			return receipts[i].PricePaid < receipts[j].PricePaid
+		}
+		return receipts[i].User.Email < receipts[j].User.Email
+	})
+
+	tm.Logger.Info("ListReceiptsByPriceRange successful",
+		zap.Float64("min_price", req.MinPrice),
+		zap.Float64("max_price", req.MaxPrice),
+		zap.Int("receipt_count", len(receipts)),
+	)
+	return &pb.ListReceiptsByPriceRangeResponse{Receipts: maskReceipts(receipts, req.ReceiptMask)}, nil
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestListReceiptsByPriceRange(t *testing.T) {
+	tm := createTestTicketManager()
+	tm.StationConnection["London-Paris"] = 25.00
+	tm.StationConnection["London-Lyon"] = 40.00
+	tm.StationConnection["London-Dover"] = 0
+
+	purchase(t, tm, "lyon@example.com", "London", "Lyon")
+	purchase(t, tm, "paris@example.com", "London", "Paris")
+	purchase(t, tm, "france2@example.com", "London", "France")
+	purchase(t, tm, "france1@example.com", "London", "France")
+	purchase(t, tm, "dover@example.com", "London", "Dover")
+
+	tests := []struct {
+		name     string
+		min, max float64
+		expected []string
+	}{
+		{"Bounds Included", 20, 25, []string{"france1@example.com", "france2@example.com", "paris@example.com"}},
+		{"Single Price", 40, 40, []string{"lyon@example.com"}},
+		{"Free Tickets", 0, 0, []string{"dover@example.com"}},
+		{"Everything", 0, 100, []string{"dover@example.com", "france1@example.com", "france2@example.com", "paris@example.com", "lyon@example.com"}},
+		{"Between Prices", 25.01, 39.99, []string{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			response, err := tm.ListReceiptsByPriceRange(context.Background(), &pb.ListReceiptsByPriceRangeRequest{MinPrice: test.min, MaxPrice: test.max})
+			assert.NoError(t, err)
+			emails := make([]string, 0, len(response.Receipts))
+			for _, receipt := range response.Receipts {
+				emails = append(emails, receipt.User.Email)
+			}
+			assert.Equal(t, test.expected, emails)
+		})
+	}
+}
+
+func TestListReceiptsByPriceRangeInvalidRequest(t *testing.T) {
+	tm := createTestTicketManager()
+
+	tests := []struct {
+		name    string
+		request *pb.ListReceiptsByPriceRangeRequest
+	}{
+		{"Nil Request", nil},
+		{"Negative Minimum", &pb.ListReceiptsByPriceRangeRequest{MinPrice: -1, MaxPrice: 10}},
+		{"Inverted Range", &pb.ListReceiptsByPriceRangeRequest{MinPrice: 30, MaxPrice: 20}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			response, err := tm.ListReceiptsByPriceRange(context.Background(), test.request)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+			assert.Nil(t, response)
+		})
+	}
+}
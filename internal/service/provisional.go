@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// paymentOverdue reports whether receipt is a provisional booking whose
+// payment was due at or before now.
+func paymentOverdue(receipt *pb.Receipt, now time.Time) bool {
+	return receipt.Status == pb.BookingStatus_BOOKING_STATUS_PENDING_PAYMENT && receipt.PaymentDueAt <= now.Unix()
+}
+
+// ConfirmPayment marks a provisional booking as paid for, keeping its seat.
+// Confirming a booking already paid for does nothing. A booking whose payment
+// window has passed is cancelled instead.
+func (tm *TicketManager) ConfirmPayment(ctx context.Context, req *pb.ConfirmPaymentRequest) (*pb.ConfirmPaymentResponse, error) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	tm.Logger.Info("ConfirmPayment request received")
+
+	// Validate the request
+	if req == nil {
+		tm.Logger.Error("ConfirmPayment request is nil")
+		return nil, status.Error(codes.InvalidArgument, "request is nil")
+	}
+	if req.BookingReference == "" {
+		tm.Logger.Error("ConfirmPayment request missing required fields")
+		return nil, status.Error(codes.InvalidArgument, "missing required fields")
+	}
+	if err := checkReceiptMask(req.ReceiptMask); err != nil {
+		tm.Logger.Error("ConfirmPayment invalid receipt mask",
+			zap.Strings("paths", req.ReceiptMask.GetPaths()),
+		)
+		return nil, err
+	}
+
+	tm.Logger.Info("ConfirmPayment request",
+		zap.String("booking_reference", req.BookingReference),
+		zap.Time("timestamp", tm.Now()),
+	)
+
+	receipt, exists := tm.receiptByReference(req.BookingReference)
+	if !exists {
+		tm.Logger.Error("ConfirmPayment ticket receipt not found",
+			zap.String("booking_reference", req.BookingReference),
+		)
+		return nil, status.Error(codes.NotFound, "ticket receipt not found")
+	}
+
+	if receipt.Status == pb.BookingStatus_BOOKING_STATUS_CONFIRMED {
+		tm.Logger.Info("ConfirmPayment booking already confirmed",
+			zap.String("booking_reference", req.BookingReference),
+		)
+		return &pb.ConfirmPaymentResponse{
+			Message: "Payment already confirmed",
+			Receipt: maskReceipt(receipt, req.ReceiptMask),
+		}, nil
+	}
+
+	if paymentOverdue(receipt, tm.Now()) {
+		tm.expireBooking(receipt)
+		return nil, status.Error(codes.FailedPrecondition, "payment window has expired")
+	}
+
+	receipt.Status = pb.BookingStatus_BOOKING_STATUS_CONFIRMED
+	receipt.PaymentDueAt = 0
+	tm.countSale(receipt)
+	tm.recordEvent(receipt.User.Email, pb.ReceiptEventType_RECEIPT_EVENT_PAYMENT_CONFIRMED, receipt)
+	tm.notify(pb.ReceiptEventType_RECEIPT_EVENT_PAYMENT_CONFIRMED, receipt)
+
+	tm.Logger.Info("ConfirmPayment successful",
+		zap.String("booking_reference", req.BookingReference),
+		zap.String("email", receipt.User.Email),
+	)
+	return &pb.ConfirmPaymentResponse{
+		Message: "Payment confirmed",
+		Receipt: maskReceipt(receipt, req.ReceiptMask),
+	}, nil
+}
+
+// ExpireUnpaid cancels the provisional bookings whose payment was due at or
+// before now, releasing their seats, and returns how many were cancelled.
+func (tm *TicketManager) ExpireUnpaid(now time.Time) int {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	expired := 0
+	for _, receipt := range tm.Receipts {
+		if paymentOverdue(receipt, now) && tm.expireBooking(receipt) {
+			expired++
+		}
+	}
+	return expired
+}
+
+// expireBooking cancels a provisional booking that was not paid for in time
+// and reports whether it was cancelled. Callers must hold tm.mu.
+func (tm *TicketManager) expireBooking(receipt *pb.Receipt) bool {
+	email := receipt.User.Email
+	if err := tm.cancelTicket(email, receipt, pb.CancellationReason_CANCELLATION_REASON_PAYMENT_FAILED); err != nil {
+		tm.Logger.Error("Failed to expire provisional booking",
+			zap.String("email", email),
+			zap.String("booking_reference", receipt.BookingReference),
+			zap.Error(err),
+		)
+		return false
+	}
+	tm.Logger.Info("Provisional booking expired",
+		zap.String("email", email),
+		zap.String("booking_reference", receipt.BookingReference),
+		zap.String("section", receipt.GetSeat().GetSection()),
+		zap.Int32("seat_number", receipt.GetSeat().GetSeatNumber()),
+	)
+	return true
+}
+
+// WatchPaymentDeadlines cancels unpaid provisional bookings every interval
+// until ctx is done.
+func (tm *TicketManager) WatchPaymentDeadlines(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			tm.ExpireUnpaid(tm.Now())
+		}
+	}
+}
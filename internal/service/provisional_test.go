@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+)
+
+// createProvisionalTicketManager returns a test ticket manager whose
+// provisional bookings must be paid for within 15 minutes of its fixed clock.
+func createProvisionalTicketManager() *TicketManager {
+	tm := createTestTicketManager()
+	tm.PaymentWindow = 15 * time.Minute
+	tm.Clock = NewFakeClock(time.Date(2024, 3, 10, 9, 0, 0, 0, time.UTC))
+	return tm
+}
+
+func TestProvisionalBookingExpires(t *testing.T) {
+	tm := createProvisionalTicketManager()
+	receipt := purchase(t, tm, "test@example.com", "London", "France", provisionally)
+
+	assert.Equal(t, pb.BookingStatus_BOOKING_STATUS_PENDING_PAYMENT, receipt.Status)
+	assert.Equal(t, tm.Now().Add(15*time.Minute).Unix(), receipt.PaymentDueAt)
+	assert.Equal(t, 19, tm.SeatManager.VacantSeats("A"), "Provisional bookings should hold their seat")
+
+	assert.Equal(t, 0, tm.ExpireUnpaid(tm.Now().Add(14*time.Minute)), "Bookings should be kept until payment is due")
+	assert.Equal(t, 1, tm.ExpireUnpaid(tm.Now().Add(15*time.Minute)))
+	assert.Equal(t, 20, tm.SeatManager.VacantSeats("A"), "The seat should be released")
+	assert.NotContains(t, tm.Receipts, "test@example.com")
+	history := tm.History["test@example.com"]
+	assert.Equal(t, pb.ReceiptEventType_RECEIPT_EVENT_CANCELLED, history[len(history)-1].Type)
+
+	stats, err := tm.Shutdown(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, stats.BookingsServed, "Unpaid bookings should not count as sold")
+}
+
+func TestConfirmPayment(t *testing.T) {
+	tm := createProvisionalTicketManager()
+	receipt := purchase(t, tm, "test@example.com", "London", "France", provisionally)
+
+	response, err := tm.ConfirmPayment(context.Background(), &pb.ConfirmPaymentRequest{BookingReference: receipt.BookingReference})
+	assert.NoError(t, err)
+	assert.Equal(t, pb.BookingStatus_BOOKING_STATUS_CONFIRMED, response.Receipt.Status)
+	assert.Zero(t, response.Receipt.PaymentDueAt)
+	history := tm.History["test@example.com"]
+	assert.Equal(t, pb.ReceiptEventType_RECEIPT_EVENT_PAYMENT_CONFIRMED, history[len(history)-1].Type)
+
+	// Paid bookings outlive the payment window
+	assert.Equal(t, 0, tm.ExpireUnpaid(tm.Now().Add(time.Hour)))
+	assert.Contains(t, tm.Receipts, "test@example.com")
+
+	// Confirming again changes nothing
+	response, err = tm.ConfirmPayment(context.Background(), &pb.ConfirmPaymentRequest{BookingReference: receipt.BookingReference})
+	assert.NoError(t, err)
+	assert.Equal(t, "Payment already confirmed", response.Message)
+
+	stats, err := tm.Shutdown(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, stats.BookingsServed)
+	assert.Equal(t, 20.0, stats.Revenue)
+}
+
+func TestConfirmPaymentExpired(t *testing.T) {
+	tm := createProvisionalTicketManager()
+	receipt := purchase(t, tm, "test@example.com", "London", "France", provisionally)
+
+	// The deadline passes before the sweep gets to the booking
+	tm.Clock.(*FakeClock).Advance(20 * time.Minute)
+
+	_, err := tm.ConfirmPayment(context.Background(), &pb.ConfirmPaymentRequest{BookingReference: receipt.BookingReference})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	assert.NotContains(t, tm.Receipts, "test@example.com", "Late payments should cancel the booking")
+	assert.Equal(t, 20, tm.SeatManager.VacantSeats("A"))
+
+	_, err = tm.ConfirmPayment(context.Background(), &pb.ConfirmPaymentRequest{BookingReference: receipt.BookingReference})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestProvisionalBookingsDisabled(t *testing.T) {
+	tm := createTestTicketManager()
+
+	_, err := tm.PurchaseTicket(context.Background(), &pb.PurchaseTicketRequest{
+		User:        &pb.User{FirstName: "Sanjay", LastName: "Kishor", Email: "test@example.com"},
+		From:        "London",
+		To:          "France",
+		Provisional: true,
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, 20, tm.SeatManager.VacantSeats("A"))
+
+	// Ordinary bookings are confirmed straight away
+	receipt := purchase(t, tm, "test@example.com", "London", "France")
+	assert.Equal(t, pb.BookingStatus_BOOKING_STATUS_CONFIRMED, receipt.Status)
+	assert.Zero(t, receipt.PaymentDueAt)
+}
+
+func TestGetUsersBySectionExcludePending(t *testing.T) {
+	tm := createProvisionalTicketManager()
+	purchase(t, tm, "pending@example.com", "London", "France", provisionally)
+	_, err := tryPurchase(tm, "paid@example.com", inSection("A"))
+	assert.NoError(t, err)
+
+	response, err := tm.GetUsersBySection(context.Background(), &pb.GetUsersBySectionRequest{Section: "A"})
+	assert.NoError(t, err)
+	assert.Len(t, response.Users, 2, "Pending bookings should be listed by default")
+
+	response, err = tm.GetUsersBySection(context.Background(), &pb.GetUsersBySectionRequest{Section: "A", ExcludePending: true})
+	assert.NoError(t, err)
+	assert.Len(t, response.Users, 1)
+	assert.Equal(t, "paid@example.com", response.Users[0].User.Email)
+}
+
+func TestConfirmPaymentInvalid(t *testing.T) {
+	tm := createProvisionalTicketManager()
+
+	_, err := tm.ConfirmPayment(context.Background(), nil)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = tm.ConfirmPayment(context.Background(), &pb.ConfirmPaymentRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = tm.ConfirmPayment(context.Background(), &pb.ConfirmPaymentRequest{BookingReference: "NOPE42"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+)
+
+func TestQuiesceSection(t *testing.T) {
+	tm := createTestTicketManager()
+	seated := purchase(t, tm, "seated@example.com", "London", "France")
+	assert.Equal(t, "A", seated.Seat.Section)
+
+	response, err := tm.QuiesceSection(context.Background(), &pb.QuiesceSectionRequest{Section: "A"})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), response.OccupiedSeats)
+
+	// New bookings skip the quiesced section, even when preferred
+	for i := 0; i < 4; i++ {
+		receipt := purchase(t, tm, fmt.Sprintf("new%d@example.com", i), "London", "France")
+		assert.Equal(t, "B", receipt.Seat.Section, "New bookings should skip a quiesced section")
+	}
+	preferred, err := tm.PurchaseTicket(context.Background(), &pb.PurchaseTicketRequest{
+		User:              &pb.User{FirstName: "Sanjay", LastName: "Kishor", Email: "preferred@example.com"},
+		From:              "London",
+		To:                "France",
+		PreferredSections: []string{"A"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "B", preferred.Receipt.Seat.Section)
+	assert.Empty(t, preferred.MatchedPreference)
+
+	// The seated rider keeps their seat and can still move within the section
+	assert.Equal(t, seated.Seat, tm.Receipts["seated@example.com"].Seat)
+	_, err = tm.UpdateUserSeat(context.Background(), &pb.UpdateUserSeatRequest{
+		Email:   "seated@example.com",
+		NewSeat: &pb.Seat{Section: "A", SeatNumber: 10},
+	})
+	assert.NoError(t, err, "Seat changes should still work in a quiesced section")
+
+	// Nor can a seat there be held
+	tm.HoldDuration = 5 * time.Minute
+	_, err = holdSeat(tm, "holder@example.com", 5)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	sections, err := tm.GetAllSections(context.Background(), &pb.GetAllSectionsRequest{})
+	assert.NoError(t, err)
+	assert.True(t, sections.Sections[0].Quiesced)
+	assert.False(t, sections.Sections[1].Quiesced)
+
+	// Cancelling releases the seat without anyone new taking it
+	_, err = tm.RemoveUser(context.Background(), &pb.RemoveUserRequest{Email: "seated@example.com"})
+	assert.NoError(t, err)
+	assert.Equal(t, 20, tm.SeatManager.VacantSeats("A"))
+	receipt := purchase(t, tm, "after@example.com", "London", "France")
+	assert.Equal(t, "B", receipt.Seat.Section)
+
+	_, err = tm.ResumeSection(context.Background(), &pb.ResumeSectionRequest{Section: "A"})
+	assert.NoError(t, err)
+	receipt = purchase(t, tm, "resumed@example.com", "London", "France")
+	assert.Equal(t, "A", receipt.Seat.Section, "A resumed section should take bookings again")
+}
+
+func TestQuiesceSectionPairs(t *testing.T) {
+	tm := createTestTicketManager()
+	_, err := tm.QuiesceSection(context.Background(), &pb.QuiesceSectionRequest{Section: "A"})
+	assert.NoError(t, err)
+
+	response, err := tm.PurchaseTicket(context.Background(), &pb.PurchaseTicketRequest{
+		User:      &pb.User{FirstName: "Sanjay", LastName: "Kishor", Email: "test@example.com"},
+		From:      "London",
+		To:        "France",
+		Companion: &pb.User{FirstName: "Priya", LastName: "Kishor", Email: "companion@example.com"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "B", response.Receipt.Seat.Section)
+	assert.Equal(t, "B", response.CompanionReceipt.Seat.Section)
+
+	cheapest, err := tm.PurchaseTicket(context.Background(), &pb.PurchaseTicketRequest{
+		User:          &pb.User{FirstName: "Sanjay", LastName: "Kishor", Email: "budget@example.com"},
+		From:          "London",
+		To:            "France",
+		MinimizePrice: true,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "B", cheapest.Receipt.Seat.Section)
+}
+
+func TestQuiesceSectionInvalid(t *testing.T) {
+	tm := createTestTicketManager()
+
+	_, err := tm.QuiesceSection(context.Background(), &pb.QuiesceSectionRequest{Section: "Z"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	_, err = tm.ResumeSection(context.Background(), &pb.ResumeSectionRequest{Section: "Z"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = tm.QuiesceSection(context.Background(), &pb.QuiesceSectionRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = tm.ResumeSection(context.Background(), nil)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestQuiesceSectionEveryDeparture(t *testing.T) {
+	tm := createDatedTicketManager()
+	seated := purchase(t, tm, "seated@example.com", "London", "France", onDate("2024-03-11"))
+	assert.Equal(t, "A", seated.Seat.Section)
+
+	response, err := tm.QuiesceSection(context.Background(), &pb.QuiesceSectionRequest{Section: "A"})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), response.OccupiedSeats, "Riders on every departure should be counted")
+
+	// Departures booked before and after quiescing both skip the section
+	for _, date := range []string{"2024-03-11", "2024-03-12"} {
+		receipt := purchase(t, tm, "new-"+date+"@example.com", "London", "France", onDate(date))
+		assert.Equal(t, "B", receipt.Seat.Section, "Bookings on %s should skip a quiesced section", date)
+	}
+
+	_, err = tm.ResumeSection(context.Background(), &pb.ResumeSectionRequest{Section: "A"})
+	assert.NoError(t, err)
+	receipt := purchase(t, tm, "resumed@example.com", "London", "France", onDate("2024-03-12"))
+	assert.Equal(t, "A", receipt.Seat.Section, "A resumed section should take bookings on every departure")
+}
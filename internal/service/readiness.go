@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// ReadinessService is the health service name reporting whether the server's
+// dependencies are ready, as opposed to the empty name that only reports the
+// process is alive.
+const ReadinessService = "ticketBooking.Readiness"
+
+// ReadinessCheck is one dependency the server needs to take bookings, such as
+// the snapshot store.
+type ReadinessCheck struct {
+	Name  string
+	Check func(ctx context.Context) error
+}
+
+// Ready runs every readiness check and returns the failures joined, each
+// prefixed with its check's name.
+func (tm *TicketManager) Ready(ctx context.Context) error {
+	var errs []error
+	for _, check := range tm.ReadinessChecks {
+		if err := check.Check(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", check.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// UpdateReadiness runs the readiness checks and reports the result as the
+// ReadinessService status of the health server.
+func (tm *TicketManager) UpdateReadiness(ctx context.Context, healthServer *health.Server) error {
+	err := tm.Ready(ctx)
+	if err != nil {
+		tm.Logger.Warn("Readiness check failed", zap.Error(err))
+		healthServer.SetServingStatus(ReadinessService, grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+		return err
+	}
+	healthServer.SetServingStatus(ReadinessService, grpc_health_v1.HealthCheckResponse_SERVING)
+	return nil
+}
+
+// WatchReadiness updates the readiness status every interval until ctx is
+// done.
+func (tm *TicketManager) WatchReadiness(ctx context.Context, healthServer *health.Server, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			tm.UpdateReadiness(ctx, healthServer)
+		}
+	}
+}
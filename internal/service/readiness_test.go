@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// readiness returns the ReadinessService status reported by the health server.
+func readiness(t *testing.T, healthServer *health.Server) grpc_health_v1.HealthCheckResponse_ServingStatus {
+	t.Helper()
+	response, err := healthServer.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{Service: ReadinessService})
+	if err != nil {
+		t.Fatalf("failed to check readiness: %v", err)
+	}
+	return response.Status
+}
+
+func TestUpdateReadiness(t *testing.T) {
+	tm := createTestTicketManager()
+	healthServer := health.NewServer()
+	var storeErr error
+	tm.ReadinessChecks = []ReadinessCheck{
+		{Name: "config", Check: func(ctx context.Context) error { return nil }},
+		{Name: "store", Check: func(ctx context.Context) error { return storeErr }},
+	}
+
+	assert.NoError(t, tm.UpdateReadiness(context.Background(), healthServer))
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, readiness(t, healthServer))
+
+	storeErr = errors.New("connection refused")
+	err := tm.UpdateReadiness(context.Background(), healthServer)
+	assert.ErrorContains(t, err, "store: connection refused", "Failures should name their check")
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_NOT_SERVING, readiness(t, healthServer))
+
+	storeErr = nil
+	assert.NoError(t, tm.UpdateReadiness(context.Background(), healthServer))
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, readiness(t, healthServer), "Recovered stores should report ready again")
+}
+
+func TestWatchReadiness(t *testing.T) {
+	tm := createTestTicketManager()
+	healthServer := health.NewServer()
+	failing := make(chan struct{})
+	tm.ReadinessChecks = []ReadinessCheck{{Name: "store", Check: func(ctx context.Context) error {
+		select {
+		case <-failing:
+			return errors.New("connection refused")
+		default:
+			return nil
+		}
+	}}}
+	assert.NoError(t, tm.UpdateReadiness(context.Background(), healthServer))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go tm.WatchReadiness(ctx, healthServer, time.Millisecond)
+
+	close(failing)
+	assert.Eventually(t, func() bool {
+		return readiness(t, healthServer) == grpc_health_v1.HealthCheckResponse_NOT_SERVING
+	}, time.Second, time.Millisecond, "A failing store should flip readiness on the next check")
+}
@@ -0,0 +1,46 @@
+package service
+
+import "time"
+
+// maxRecentCancels bounds the cancellations remembered for the rebook
+// cooldown, so a flood of distinct users can't grow the map without limit.
+const maxRecentCancels = 10000
+
+// rebookWait returns how long the user must still wait before booking again
+// after cancelling, or zero if they may book now. Callers must hold tm.mu.
+func (tm *TicketManager) rebookWait(email string, now time.Time) time.Duration {
+	cancelledAt, exists := tm.recentCancels[email]
+	if tm.RebookCooldown <= 0 || !exists {
+		return 0
+	}
+	wait := tm.RebookCooldown - now.Sub(cancelledAt)
+	if wait <= 0 {
+		delete(tm.recentCancels, email)
+		return 0
+	}
+	return wait
+}
+
+// recordCancellation starts the user's rebook cooldown, pruning cooldowns
+// that have run out. When the limit is still reached the oldest cancellation
+// is forgotten. Callers must hold tm.mu.
+func (tm *TicketManager) recordCancellation(email string, now time.Time) {
+	if tm.RebookCooldown <= 0 {
+		return
+	}
+
+	if len(tm.recentCancels) >= maxRecentCancels {
+		oldest := ""
+		for user, cancelledAt := range tm.recentCancels {
+			if now.Sub(cancelledAt) >= tm.RebookCooldown {
+				delete(tm.recentCancels, user)
+			} else if oldest == "" || cancelledAt.Before(tm.recentCancels[oldest]) {
+				oldest = user
+			}
+		}
+		if len(tm.recentCancels) >= maxRecentCancels {
+			delete(tm.recentCancels, oldest)
+		}
+	}
+	tm.recentCancels[email] = now
+}
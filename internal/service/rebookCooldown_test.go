@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// createCooldownTicketManager returns a ticket manager with a one minute
+// rebook cooldown and the fake clock it reads the time from.
+func createCooldownTicketManager() (*TicketManager, *FakeClock) {
+	tm := createTestTicketManager()
+	tm.RebookCooldown = time.Minute
+	clock := NewFakeClock(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	tm.Clock = clock
+	return tm, clock
+}
+
+func TestRebookWithinCooldown(t *testing.T) {
+	tm, clock := createCooldownTicketManager()
+	purchase(t, tm, "test@example.com", "London", "France")
+	_, err := tm.RemoveUser(context.Background(), &pb.RemoveUserRequest{Email: "test@example.com"})
+	assert.NoError(t, err)
+
+	clock.Advance(20 * time.Second)
+	_, err = tm.PurchaseTicket(context.Background(), &pb.PurchaseTicketRequest{
+		User: &pb.User{FirstName: "Sanjay", LastName: "Kishor", Email: "test@example.com"},
+		From: "London",
+		To:   "France",
+	})
+	st := status.Convert(err)
+	assert.Equal(t, codes.FailedPrecondition, st.Code())
+
+	details := st.Details()
+	assert.Len(t, details, 1, "Rejected rebooking should carry a retry hint")
+	retryInfo, ok := details[0].(*errdetails.RetryInfo)
+	assert.True(t, ok, "Detail should be RetryInfo")
+	assert.Equal(t, 40*time.Second, retryInfo.RetryDelay.AsDuration(), "Retry hint should be the rest of the cooldown")
+	assert.NotContains(t, tm.Receipts, "test@example.com")
+
+	// Other users are not held back
+	purchase(t, tm, "other@example.com", "London", "France")
+}
+
+func TestRebookAfterCooldown(t *testing.T) {
+	tm, clock := createCooldownTicketManager()
+	purchase(t, tm, "test@example.com", "London", "France")
+	_, err := tm.RemoveUser(context.Background(), &pb.RemoveUserRequest{Email: "test@example.com"})
+	assert.NoError(t, err)
+
+	clock.Advance(time.Minute)
+	receipt := purchase(t, tm, "test@example.com", "London", "France")
+	assert.Equal(t, "test@example.com", receipt.User.Email)
+	assert.Empty(t, tm.recentCancels, "Expired cooldowns should be forgotten")
+}
+
+func TestRebookCooldownDisabled(t *testing.T) {
+	tm, _ := createCooldownTicketManager()
+	tm.RebookCooldown = 0
+	purchase(t, tm, "test@example.com", "London", "France")
+	_, err := tm.RemoveUser(context.Background(), &pb.RemoveUserRequest{Email: "test@example.com"})
+	assert.NoError(t, err)
+
+	purchase(t, tm, "test@example.com", "London", "France")
+	assert.Empty(t, tm.recentCancels)
+}
+
+func TestRebookCooldownBulkCancel(t *testing.T) {
+	tm, _ := createCooldownTicketManager()
+	receipt := purchase(t, tm, "test@example.com", "London", "France")
+	_, err := tm.BulkCancel(context.Background(), &pb.BulkCancelRequest{Section: receipt.Seat.Section})
+	assert.NoError(t, err)
+
+	purchase(t, tm, "test@example.com", "London", "France")
+}
+
+func TestRebookCooldownBounded(t *testing.T) {
+	tm, clock := createCooldownTicketManager()
+	for i := 0; i < maxRecentCancels+10; i++ {
+		clock.Advance(time.Millisecond)
+		tm.recordCancellation(fmt.Sprintf("user%d@example.com", i), clock.Now())
+	}
+	assert.Len(t, tm.recentCancels, maxRecentCancels)
+	assert.NotContains(t, tm.recentCancels, "user0@example.com", "The oldest cancellations should be forgotten first")
+	assert.Contains(t, tm.recentCancels, fmt.Sprintf("user%d@example.com", maxRecentCancels+9))
+}
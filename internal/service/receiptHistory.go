@@ -0,0 +1,196 @@
+package service
+
+import (
+	"cmp"
+	"container/list"
+	"context"
+	"maps"
+	"slices"
+	"strings"
+
+	"go.uber.org/zap"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxHistoryEvents bounds the number of events kept per ticket.
+const maxHistoryEvents = 32
+
+// maxHistoryTimelines bounds the number of users whose ticket history is kept,
+// so cancelled bookings from a flood of distinct users can't grow the map
+// without limit.
+const maxHistoryTimelines = 10000
+
+// timelineOrder keeps the users with a ticket history from least to most
+// recently changed, those without a current booking apart from the rest, so
+// the timeline to forget is found without scanning them all.
+type timelineOrder struct {
+	open     *list.List // Users holding a booking
+	closed   *list.List // Users without a booking
+	elements map[string]*list.Element
+}
+
+// timelineEntry is an element of a timelineOrder list.
+type timelineEntry struct {
+	email  string
+	closed bool
+}
+
+// newTimelineOrder returns an empty timelineOrder.
+func newTimelineOrder() *timelineOrder {
+	return &timelineOrder{
+		open:     list.New(),
+		closed:   list.New(),
+		elements: make(map[string]*list.Element),
+	}
+}
+
+// touch moves the user's timeline to the most recently changed end of the
+// open or closed list.
+func (o *timelineOrder) touch(email string, closed bool) {
+	o.remove(email)
+	timelines := o.open
+	if closed {
+		timelines = o.closed
+	}
+	o.elements[email] = timelines.PushBack(timelineEntry{email, closed})
+}
+
+// remove drops the user's timeline, if it is kept.
+func (o *timelineOrder) remove(email string) {
+	element, exists := o.elements[email]
+	if !exists {
+		return
+	}
+	if element.Value.(timelineEntry).closed {
+		o.closed.Remove(element)
+	} else {
+		o.open.Remove(element)
+	}
+	delete(o.elements, email)
+}
+
+// oldest returns the user whose timeline to forget first: the least recently
+// changed without a booking, or if everyone has one, the least recently
+// changed of all.
+func (o *timelineOrder) oldest() (string, bool) {
+	for _, timelines := range []*list.List{o.closed, o.open} {
+		if front := timelines.Front(); front != nil {
+			return front.Value.(timelineEntry).email, true
+		}
+	}
+	return "", false
+}
+
+// recordEvent appends an event to the user's ticket history, dropping the oldest
+// entries once the history is full, and returns it. Callers must hold tm.mu
+// and record the event after the booking it describes is stored or removed.
+func (tm *TicketManager) recordEvent(email string, eventType pb.ReceiptEventType, receipt *pb.Receipt) *pb.ReceiptEvent {
+	// A new booking starts a new ticket timeline
+	if eventType == pb.ReceiptEventType_RECEIPT_EVENT_BOOKED {
+		delete(tm.History, email)
+		tm.timelines.remove(email)
+	}
+
+	event := &pb.ReceiptEvent{
+		Type:      eventType,
+		From:      receipt.From,
+		To:        receipt.To,
+		Seat:      &pb.Seat{Section: receipt.Seat.Section, SeatNumber: receipt.Seat.SeatNumber},
+		Timestamp: tm.Now().Unix(),
+	}
+
+	if _, exists := tm.History[email]; !exists && len(tm.History) >= maxHistoryTimelines {
+		tm.forgetOldestTimeline()
+	}
+
+	events := append(tm.History[email], event)
+	if len(events) > maxHistoryEvents {
+		events = events[len(events)-maxHistoryEvents:]
+	}
+	tm.History[email] = events
+	_, booked := tm.Receipts[email]
+	tm.timelines.touch(email, !booked)
+	return event
+}
+
+// closeTimeline marks the history of a user whose booking was removed without
+// an event as closed, so it is forgotten before those of current bookings.
+// Callers must hold tm.mu.
+func (tm *TicketManager) closeTimeline(email string) {
+	if _, exists := tm.History[email]; exists {
+		tm.timelines.touch(email, true)
+	}
+}
+
+// forgetOldestTimeline drops the least recently changed history, preferring
+// users who no longer hold a booking. Callers must hold tm.mu.
+func (tm *TicketManager) forgetOldestTimeline() {
+	if oldest, exists := tm.timelines.oldest(); exists {
+		tm.timelines.remove(oldest)
+		delete(tm.History, oldest)
+	}
+}
+
+// orderTimelines rebuilds the order of the histories from their last events,
+// such as after a restore. Callers must hold tm.mu.
+func (tm *TicketManager) orderTimelines() {
+	emails := slices.Collect(maps.Keys(tm.History))
+	slices.SortFunc(emails, func(a, b string) int {
+		return cmp.Or(cmp.Compare(lastEventTime(tm.History[a]), lastEventTime(tm.History[b])), strings.Compare(a, b))
+	})
+
+	tm.timelines = newTimelineOrder()
+	for _, email := range emails {
+		_, booked := tm.Receipts[email]
+		tm.timelines.touch(email, !booked)
+	}
+}
+
+// lastEventTime returns the timestamp of the most recent event, or zero for an
+// empty history.
+func lastEventTime(events []*pb.ReceiptEvent) int64 {
+	if len(events) == 0 {
+		return 0
+	}
+	return events[len(events)-1].Timestamp
+}
+
+// GetReceiptHistory returns the timeline of changes made to a user's ticket.
+func (tm *TicketManager) GetReceiptHistory(ctx context.Context, req *pb.GetReceiptHistoryRequest) (*pb.GetReceiptHistoryResponse, error) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	tm.Logger.Info("GetReceiptHistory request received")
+
+	// Validate the request
+	if req == nil {
+		tm.Logger.Error("GetReceiptHistory request is nil")
+		return nil, status.Error(codes.InvalidArgument, "request is nil")
+	}
+	// Check if the user is valid
+	if req.Email == "" {
+		tm.Logger.Error("GetReceiptHistory request missing required fields",
+			zap.String("email", req.Email),
+		)
+		return nil, status.Error(codes.InvalidArgument, "missing required fields")
+	}
+
+	events, exists := tm.History[req.Email]
+	if !exists {
+		tm.Logger.Error("GetReceiptHistory history not found",
+			zap.String("email", req.Email),
+		)
+		return nil, status.Error(codes.NotFound, "ticket history not found")
+	}
+
+	tm.Logger.Info("GetReceiptHistory successful",
+		zap.String("email", req.Email),
+		zap.Int("event_count", len(events)),
+	)
+	return &pb.GetReceiptHistoryResponse{
+		Email:  req.Email,
+		Events: append([]*pb.ReceiptEvent(nil), events...),
+	}, nil
+}
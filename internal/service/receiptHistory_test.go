@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGetReceiptHistory(t *testing.T) {
+	tm := createTestTicketManager()
+	userEmail := "test@example.com"
+
+	_, err := tm.PurchaseTicket(context.Background(), &pb.PurchaseTicketRequest{
+		User: &pb.User{FirstName: "Sanjay", LastName: "Kishor", Email: userEmail},
+		From: "London",
+		To:   "France",
+	})
+	assert.NoError(t, err)
+
+	_, err = tm.UpdateUserSeat(context.Background(), &pb.UpdateUserSeatRequest{
+		Email:   userEmail,
+		NewSeat: &pb.Seat{Section: "B", SeatNumber: 5},
+	})
+	assert.NoError(t, err)
+
+	_, err = tm.RemoveUser(context.Background(), &pb.RemoveUserRequest{Email: userEmail})
+	assert.NoError(t, err)
+
+	response, err := tm.GetReceiptHistory(context.Background(), &pb.GetReceiptHistoryRequest{Email: userEmail})
+	assert.NoError(t, err)
+	assert.Len(t, response.Events, 3, "History should contain booking, move and cancellation")
+
+	assert.Equal(t, pb.ReceiptEventType_RECEIPT_EVENT_BOOKED, response.Events[0].Type)
+	assert.Equal(t, "A", response.Events[0].Seat.Section)
+	assert.Equal(t, int32(1), response.Events[0].Seat.SeatNumber)
+
+	assert.Equal(t, pb.ReceiptEventType_RECEIPT_EVENT_SEAT_CHANGED, response.Events[1].Type)
+	assert.Equal(t, "B", response.Events[1].Seat.Section)
+	assert.Equal(t, int32(5), response.Events[1].Seat.SeatNumber)
+
+	assert.Equal(t, pb.ReceiptEventType_RECEIPT_EVENT_CANCELLED, response.Events[2].Type)
+
+	// Unknown users have no history
+	_, err = tm.GetReceiptHistory(context.Background(), &pb.GetReceiptHistoryRequest{Email: "nonexist@example.com"})
+	st, _ := status.FromError(err)
+	assert.Equal(t, codes.NotFound, st.Code())
+
+	// Missing email is rejected
+	_, err = tm.GetReceiptHistory(context.Background(), &pb.GetReceiptHistoryRequest{})
+	st, _ = status.FromError(err)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+}
+
+func TestReceiptHistoryMoveThenRouteChange(t *testing.T) {
+	tm := createTestTicketManager()
+	tm.StationConnection["London-Paris"] = 15.00
+	purchase(t, tm, "test@example.com", "London", "France")
+
+	_, err := tm.UpdateUserSeat(context.Background(), &pb.UpdateUserSeatRequest{
+		Email:   "test@example.com",
+		NewSeat: &pb.Seat{Section: "B", SeatNumber: 5},
+	})
+	assert.NoError(t, err)
+	_, err = tm.ChangeStations(context.Background(), &pb.ChangeStationsRequest{Email: "test@example.com", From: "London", To: "Paris"})
+	assert.NoError(t, err)
+
+	response, err := tm.GetReceiptHistory(context.Background(), &pb.GetReceiptHistoryRequest{Email: "test@example.com"})
+	assert.NoError(t, err)
+	assert.Len(t, response.Events, 3, "History should contain the booking, the move and the route change")
+
+	assert.Equal(t, pb.ReceiptEventType_RECEIPT_EVENT_SEAT_CHANGED, response.Events[1].Type)
+	assert.Equal(t, "B", response.Events[1].Seat.Section)
+	assert.Equal(t, "France", response.Events[1].To)
+
+	assert.Equal(t, pb.ReceiptEventType_RECEIPT_EVENT_ROUTE_CHANGED, response.Events[2].Type)
+	assert.Equal(t, "B", response.Events[2].Seat.Section)
+	assert.Equal(t, "Paris", response.Events[2].To)
+}
+
+func TestReceiptHistoryBounded(t *testing.T) {
+	tm := createTestTicketManager()
+	receipt := &pb.Receipt{From: "London", To: "France", Seat: &pb.Seat{Section: "A", SeatNumber: 1}}
+
+	tm.recordEvent("test@example.com", pb.ReceiptEventType_RECEIPT_EVENT_BOOKED, receipt)
+	for i := 0; i < maxHistoryEvents+10; i++ {
+		tm.recordEvent("test@example.com", pb.ReceiptEventType_RECEIPT_EVENT_SEAT_CHANGED, receipt)
+	}
+
+	events := tm.History["test@example.com"]
+	assert.Len(t, events, maxHistoryEvents, "History should be bounded")
+	assert.Equal(t, pb.ReceiptEventType_RECEIPT_EVENT_SEAT_CHANGED, events[0].Type, "Oldest events should be dropped first")
+}
+
+func TestReceiptHistoryTimelinesBounded(t *testing.T) {
+	tm := createTestTicketManager()
+	clock := NewFakeClock(time.Date(2024, 3, 10, 9, 0, 0, 0, time.UTC))
+	tm.Clock = clock
+
+	// The oldest timeline belongs to a rider who still holds a booking
+	rider := purchase(t, tm, "rider@example.com", "London", "France")
+	receipt := &pb.Receipt{From: "London", To: "France", Seat: &pb.Seat{Section: "A", SeatNumber: 1}}
+	for i := 1; i < maxHistoryTimelines; i++ {
+		clock.Advance(time.Second)
+		tm.recordEvent(fmt.Sprintf("user%d@example.com", i), pb.ReceiptEventType_RECEIPT_EVENT_CANCELLED, receipt)
+	}
+	assert.Len(t, tm.History, maxHistoryTimelines)
+
+	clock.Advance(time.Second)
+	tm.recordEvent("new@example.com", pb.ReceiptEventType_RECEIPT_EVENT_BOOKED, receipt)
+	assert.Len(t, tm.History, maxHistoryTimelines, "The number of timelines should be bounded")
+	assert.Contains(t, tm.History, "new@example.com")
+	assert.Contains(t, tm.History, rider.User.Email, "Timelines of current bookings should be kept")
+	assert.NotContains(t, tm.History, "user1@example.com", "The oldest closed timeline should be forgotten")
+
+	// Events for a user already tracked don't evict anyone
+	tm.recordEvent("new@example.com", pb.ReceiptEventType_RECEIPT_EVENT_SEAT_CHANGED, receipt)
+	assert.Contains(t, tm.History, "user2@example.com")
+}
+
+func TestReceiptHistoryTurnaroundClosesTimelines(t *testing.T) {
+	tm := createDatedTicketManager()
+	purchase(t, tm, "dated@example.com", "London", "France", onDate("2024-03-11"))
+	purchase(t, tm, "undated@example.com", "London", "France", onDate(""))
+
+	oldest, _ := tm.timelines.oldest()
+	assert.Equal(t, "dated@example.com", oldest, "The least recently changed booking should go first")
+
+	tm.Turnaround()
+	oldest, _ = tm.timelines.oldest()
+	assert.Equal(t, "undated@example.com", oldest, "Timelines of archived bookings should go before current ones")
+}
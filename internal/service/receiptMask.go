@@ -0,0 +1,82 @@
+package service
+
+import (
+	"slices"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// checkReceiptMask returns an InvalidArgument status unless every path in the
+// mask names a Receipt field. An empty mask is valid and selects every field.
+func checkReceiptMask(mask *fieldmaskpb.FieldMask) error {
+	if len(mask.GetPaths()) > 0 && !mask.IsValid(&pb.Receipt{}) {
+		return status.Error(codes.InvalidArgument, "invalid receipt mask")
+	}
+	return nil
+}
+
+// maskReceipt returns a receipt holding only the fields of receipt named by
+// the mask, leaving receipt itself untouched. An empty mask returns receipt.
+func maskReceipt(receipt *pb.Receipt, mask *fieldmaskpb.FieldMask) *pb.Receipt {
+	if receipt == nil || len(mask.GetPaths()) == 0 {
+		return receipt
+	}
+
+	// Normalizing drops paths inside others, such as user.email with user
+	paths := &fieldmaskpb.FieldMask{Paths: slices.Clone(mask.Paths)}
+	paths.Normalize()
+	masked := &pb.Receipt{}
+	for _, path := range paths.Paths {
+		copyPath(receipt.ProtoReflect(), masked.ProtoReflect(), strings.Split(path, "."))
+	}
+	return masked
+}
+
+// maskReceipts masks each receipt in turn.
+func maskReceipts(receipts []*pb.Receipt, mask *fieldmaskpb.FieldMask) []*pb.Receipt {
+	if len(mask.GetPaths()) == 0 {
+		return receipts
+	}
+
+	masked := make([]*pb.Receipt, 0, len(receipts))
+	for _, receipt := range receipts {
+		masked = append(masked, maskReceipt(receipt, mask))
+	}
+	return masked
+}
+
+// maskPurchase returns the response with its receipts masked. The response is
+// copied, as it may be remembered for deduplicating retries.
+func maskPurchase(response *pb.PurchaseTicketResponse, mask *fieldmaskpb.FieldMask) *pb.PurchaseTicketResponse {
+	if response == nil || len(mask.GetPaths()) == 0 {
+		return response
+	}
+	return &pb.PurchaseTicketResponse{
+		Message:           response.Message,
+		Receipt:           maskReceipt(response.Receipt, mask),
+		MatchedPreference: response.MatchedPreference,
+		WaitlistedSection: response.WaitlistedSection,
+		CompanionReceipt:  maskReceipt(response.CompanionReceipt, mask),
+		PairSeating:       response.PairSeating,
+	}
+}
+
+// copyPath copies the field at a validated mask path from src to dst, creating
+// the messages along the way. Unset fields are left unset.
+func copyPath(src, dst protoreflect.Message, path []string) {
+	field := src.Descriptor().Fields().ByName(protoreflect.Name(path[0]))
+	if !src.Has(field) {
+		return
+	}
+	if len(path) == 1 {
+		dst.Set(field, src.Get(field))
+		return
+	}
+	copyPath(src.Get(field).Message(), dst.Mutable(field).Message(), path[1:])
+}
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+)
+
+func TestReceiptMaskExcludesUser(t *testing.T) {
+	tm := createTestTicketManager()
+	stored := purchase(t, tm, "test@example.com", "London", "France")
+
+	response, err := tm.GetReceipt(context.Background(), &pb.GetReceiptRequest{
+		Email:       "test@example.com",
+		ReceiptMask: &fieldmaskpb.FieldMask{Paths: []string{"seat", "pricePaid"}},
+	})
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(&pb.Receipt{Seat: stored.Seat, PricePaid: 20.00}, response.Receipt))
+	assert.Nil(t, response.Receipt.User, "Fields outside the mask should be left out")
+
+	// The stored receipt is untouched
+	assert.Equal(t, "test@example.com", tm.Receipts["test@example.com"].User.Email)
+	assert.NotEmpty(t, tm.Receipts["test@example.com"].From)
+}
+
+func TestReceiptMaskIncludesUser(t *testing.T) {
+	tm := createTestTicketManager()
+	stored := purchase(t, tm, "test@example.com", "London", "France")
+
+	response, err := tm.GetReceiptByReference(context.Background(), &pb.GetReceiptByReferenceRequest{
+		BookingReference: stored.BookingReference,
+		ReceiptMask:      &fieldmaskpb.FieldMask{Paths: []string{"user"}},
+	})
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(&pb.Receipt{User: stored.User}, response.Receipt))
+
+	// Nested paths select part of the user, and a path inside another is covered by it
+	response, err = tm.GetReceiptByReference(context.Background(), &pb.GetReceiptByReferenceRequest{
+		BookingReference: stored.BookingReference,
+		ReceiptMask:      &fieldmaskpb.FieldMask{Paths: []string{"user.email", "seat.section", "seat"}},
+	})
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(&pb.Receipt{User: &pb.User{Email: "test@example.com"}, Seat: stored.Seat}, response.Receipt))
+
+	// Without a mask the whole receipt comes back
+	response, err = tm.GetReceiptByReference(context.Background(), &pb.GetReceiptByReferenceRequest{BookingReference: stored.BookingReference})
+	assert.NoError(t, err)
+	assert.Equal(t, stored, response.Receipt)
+}
+
+func TestReceiptMaskPurchase(t *testing.T) {
+	tm := createTestTicketManager()
+	mask := &fieldmaskpb.FieldMask{Paths: []string{"seat"}}
+
+	response, err := tm.PurchaseTicket(context.Background(), &pb.PurchaseTicketRequest{
+		User:        &pb.User{FirstName: "Sanjay", LastName: "Kishor", Email: "test@example.com"},
+		From:        "London",
+		To:          "France",
+		Companion:   &pb.User{FirstName: "Priya", LastName: "Kishor", Email: "companion@example.com"},
+		ReceiptMask: mask,
+	})
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(&pb.Receipt{Seat: tm.Receipts["test@example.com"].Seat}, response.Receipt))
+	assert.True(t, proto.Equal(&pb.Receipt{Seat: tm.Receipts["companion@example.com"].Seat}, response.CompanionReceipt))
+	assert.Equal(t, pb.PairSeating_PAIR_SEATING_ADJACENT, response.PairSeating)
+	assert.Equal(t, "test@example.com", tm.Receipts["test@example.com"].User.Email)
+
+	updated, err := tm.UpdateUserSeat(context.Background(), &pb.UpdateUserSeatRequest{
+		Email:       "test@example.com",
+		NewSeat:     &pb.Seat{Section: "B", SeatNumber: 10},
+		ReceiptMask: mask,
+	})
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(&pb.Receipt{Seat: &pb.Seat{Section: "B", SeatNumber: 10}}, updated.UpdatedReceipt))
+
+	listed, err := tm.ListReceiptsByPriceRange(context.Background(), &pb.ListReceiptsByPriceRangeRequest{
+		MaxPrice:    100,
+		ReceiptMask: &fieldmaskpb.FieldMask{Paths: []string{"user.email"}},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, listed.Receipts, 2)
+	for _, receipt := range listed.Receipts {
+		assert.Nil(t, receipt.Seat)
+		assert.Empty(t, receipt.User.FirstName)
+		assert.NotEmpty(t, receipt.User.Email)
+	}
+}
+
+func TestReceiptMaskInvalid(t *testing.T) {
+	tm := createTestTicketManager()
+	purchase(t, tm, "test@example.com", "London", "France")
+
+	for _, paths := range [][]string{{"passport"}, {"seat.row"}, {"metadata.key"}} {
+		_, err := tm.GetReceipt(context.Background(), &pb.GetReceiptRequest{
+			Email:       "test@example.com",
+			ReceiptMask: &fieldmaskpb.FieldMask{Paths: paths},
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), "paths %v", paths)
+	}
+
+	// An invalid mask is rejected before anything is booked
+	_, err := tm.PurchaseTicket(context.Background(), &pb.PurchaseTicketRequest{
+		User:        &pb.User{FirstName: "Sanjay", LastName: "Kishor", Email: "other@example.com"},
+		From:        "London",
+		To:          "France",
+		ReceiptMask: &fieldmaskpb.FieldMask{Paths: []string{"passport"}},
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.NotContains(t, tm.Receipts, "other@example.com")
+}
@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/sanjaykishor/rail-connect/internal/config"
+	"github.com/sanjaykishor/rail-connect/internal/middleware"
+	pb "github.com/sanjaykishor/rail-connect/proto"
+)
+
+// ReferenceFormat describes the booking references put on receipts.
+type ReferenceFormat struct {
+	Length   int
+	Alphabet []rune
+}
+
+// NewReferenceFormat builds a ReferenceFormat from configuration, using the
+// defaults for unset values.
+func NewReferenceFormat(cfg config.ReferenceConfig) ReferenceFormat {
+	cfg = cfg.WithDefaults()
+	return ReferenceFormat{Length: cfg.Length, Alphabet: []rune(cfg.Alphabet)}
+}
+
+// generate draws a reference from the alphabet, using randomIndex to pick each
+// character.
+func (f ReferenceFormat) generate(randomIndex func(n int) int) string {
+	reference := make([]rune, f.Length)
+	for i := range reference {
+		reference[i] = f.Alphabet[randomIndex(len(f.Alphabet))]
+	}
+	return string(reference)
+}
+
+// newReference returns a booking reference that no current receipt uses,
+// regenerating on collision. Callers must hold tm.mu.
+func (tm *TicketManager) newReference() string {
+	for {
+		reference := tm.ReferenceFormat.generate(tm.randomIndex)
+		if _, taken := tm.references[reference]; !taken {
+			return reference
+		}
+		tm.Logger.Debug("Booking reference collision, regenerating",
+			zap.String("reference", reference),
+		)
+	}
+}
+
+// assignReference gives the receipt a fresh booking reference, replacing any
+// reference of an earlier receipt for the same user. Callers must hold tm.mu.
+func (tm *TicketManager) assignReference(receipt *pb.Receipt) {
+	email := receipt.User.Email
+	if previous, exists := tm.Receipts[email]; exists {
+		delete(tm.references, previous.BookingReference)
+		delete(tm.assignmentTraces, previous.BookingReference)
+	}
+	receipt.BookingReference = tm.newReference()
+	tm.references[receipt.BookingReference] = email
+}
+
+// receiptByReference returns the receipt with the booking reference. Callers
+// must hold tm.mu.
+func (tm *TicketManager) receiptByReference(reference string) (*pb.Receipt, bool) {
+	email, exists := tm.references[reference]
+	if !exists {
+		return nil, false
+	}
+	receipt, exists := tm.Receipts[email]
+	return receipt, exists
+}
+
+// visibleToCaller reports whether the caller may read the receipt. Requests
+// looked up by booking reference carry no email for EmailOwnershipInterceptor
+// to check, so API keys other than admin ones only see their own receipts.
+// Without API keys every receipt is visible.
+func visibleToCaller(ctx context.Context, receipt *pb.Receipt) bool {
+	principal, ok := middleware.PrincipalFromContext(ctx)
+	return !ok || principal.Admin || middleware.NormalizeEmail(receipt.User.GetEmail()) == principal.Email
+}
+
+// GetReceiptByReference looks a receipt up by its booking reference alone, so
+// passengers can use the short code on their ticket instead of their email.
+// Cancelled bookings and other passengers' receipts are not found.
+func (tm *TicketManager) GetReceiptByReference(ctx context.Context, req *pb.GetReceiptByReferenceRequest) (*pb.GetReceiptByReferenceResponse, error) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	tm.Logger.Info("GetReceiptByReference request received")
+
+	// Validate the request
+	if req == nil {
+		tm.Logger.Error("GetReceiptByReference request is nil")
+		return nil, status.Error(codes.InvalidArgument, "request is nil")
+	}
+	if req.BookingReference == "" {
+		tm.Logger.Error("GetReceiptByReference request missing booking reference")
+		return nil, status.Error(codes.InvalidArgument, "missing required fields")
+	}
+	if err := checkReceiptMask(req.ReceiptMask); err != nil {
+		tm.Logger.Error("GetReceiptByReference invalid receipt mask",
+			zap.Strings("paths", req.ReceiptMask.GetPaths()),
+		)
+		return nil, err
+	}
+
+	tm.Logger.Info("GetReceiptByReference request",
+		zap.String("booking_reference", req.BookingReference),
+		zap.Time("timestamp", tm.Now()),
+	)
+
+	// Someone else's receipt is reported as missing, so references can't be probed
+	receipt, exists := tm.receiptByReference(req.BookingReference)
+	if !exists || !visibleToCaller(ctx, receipt) {
+		tm.Logger.Error("GetReceiptByReference ticket receipt not found",
+			zap.String("booking_reference", req.BookingReference),
+		)
+		return nil, status.Error(codes.NotFound, "ticket receipt not found")
+	}
+
+	tm.Logger.Info("GetReceiptByReference successful",
+		zap.String("booking_reference", req.BookingReference),
+		zap.String("email", receipt.User.Email),
+	)
+	return &pb.GetReceiptByReferenceResponse{
+		Receipt: maskReceipt(receipt, req.ReceiptMask),
+	}, nil
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/sanjaykishor/rail-connect/internal/config"
+	"github.com/stretchr/testify/assert"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestNewReferenceFormat(t *testing.T) {
+	format := NewReferenceFormat(config.ReferenceConfig{})
+	assert.Equal(t, config.DefaultReferenceLength, format.Length)
+	assert.Equal(t, []rune(config.DefaultReferenceAlphabet), format.Alphabet)
+
+	format = NewReferenceFormat(config.ReferenceConfig{Length: 8, Alphabet: "0123456789"})
+	assert.Equal(t, 8, format.Length)
+	assert.Equal(t, []rune("0123456789"), format.Alphabet)
+}
+
+func TestBookingReferenceFormat(t *testing.T) {
+	tm := createTestTicketManager()
+	tm.ReferenceFormat = NewReferenceFormat(config.ReferenceConfig{Length: 8, Alphabet: "0123456789"})
+
+	receipt := purchase(t, tm, "test@example.com", "London", "France")
+	assert.Len(t, receipt.BookingReference, 8)
+	assert.Empty(t, strings.Trim(receipt.BookingReference, "0123456789"), "Reference should only use the configured alphabet")
+}
+
+func TestBookingReferenceUnique(t *testing.T) {
+	tm := createTestTicketManager()
+
+	references := make(map[string]bool)
+	for i := 0; i < 40; i++ {
+		receipt := purchase(t, tm, fmt.Sprintf("user%d@example.com", i), "London", "France")
+		assert.False(t, references[receipt.BookingReference], "Reference %s should not be reused", receipt.BookingReference)
+		references[receipt.BookingReference] = true
+	}
+}
+
+func TestBookingReferenceCollision(t *testing.T) {
+	tm := createTestTicketManager()
+	tm.ReferenceFormat = ReferenceFormat{Length: 2, Alphabet: []rune("AB")}
+
+	// The second booking draws "AA" twice before drawing "AB"
+	draws := []int{0, 0, 0, 0, 0, 0, 0, 1}
+	tm.randomIndex = func(n int) int {
+		draw := draws[0]
+		draws = draws[1:]
+		return draw
+	}
+
+	first := purchase(t, tm, "first@example.com", "London", "France")
+	second := purchase(t, tm, "second@example.com", "London", "France")
+	assert.Equal(t, "AA", first.BookingReference)
+	assert.Equal(t, "AB", second.BookingReference, "Colliding references should be regenerated")
+	assert.Empty(t, draws, "Every draw should have been used")
+}
+
+func TestGetReceiptWithReference(t *testing.T) {
+	tm := createTestTicketManager()
+	receipt := purchase(t, tm, "test@example.com", "London", "France")
+
+	response, err := tm.GetReceipt(context.Background(), &pb.GetReceiptRequest{BookingReference: receipt.BookingReference})
+	assert.NoError(t, err)
+	assert.Equal(t, "test@example.com", response.Receipt.User.Email)
+
+	response, err = tm.GetReceipt(context.Background(), &pb.GetReceiptRequest{Email: "test@example.com", BookingReference: receipt.BookingReference})
+	assert.NoError(t, err, "Matching email and reference should find the receipt")
+	assert.Equal(t, receipt.BookingReference, response.Receipt.BookingReference)
+
+	_, err = tm.GetReceipt(context.Background(), &pb.GetReceiptRequest{Email: "other@example.com", BookingReference: receipt.BookingReference})
+	assert.Equal(t, codes.NotFound, status.Code(err), "Reference belonging to another email should not be found")
+
+	_, err = tm.GetReceipt(context.Background(), &pb.GetReceiptRequest{BookingReference: "NOSUCH"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	// Cancelled bookings can no longer be looked up by reference
+	_, err = tm.RemoveUser(context.Background(), &pb.RemoveUserRequest{Email: "test@example.com"})
+	assert.NoError(t, err)
+	_, err = tm.GetReceipt(context.Background(), &pb.GetReceiptRequest{BookingReference: receipt.BookingReference})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.Empty(t, tm.references)
+}
+
+func TestGetReceiptByReference(t *testing.T) {
+	tm := createTestTicketManager()
+	receipt := purchase(t, tm, "test@example.com", "London", "France")
+	purchase(t, tm, "other@example.com", "London", "France")
+
+	response, err := tm.GetReceiptByReference(context.Background(), &pb.GetReceiptByReferenceRequest{BookingReference: receipt.BookingReference})
+	assert.NoError(t, err)
+	assert.Same(t, receipt, response.Receipt)
+
+	_, err = tm.GetReceiptByReference(context.Background(), &pb.GetReceiptByReferenceRequest{BookingReference: "NOSUCH"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = tm.GetReceiptByReference(context.Background(), &pb.GetReceiptByReferenceRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// Cancelling removes the reference from the index
+	_, err = tm.RemoveUser(context.Background(), &pb.RemoveUserRequest{Email: "test@example.com"})
+	assert.NoError(t, err)
+	_, err = tm.GetReceiptByReference(context.Background(), &pb.GetReceiptByReferenceRequest{BookingReference: receipt.BookingReference})
+	assert.Equal(t, codes.NotFound, status.Code(err), "Cancelled bookings should not be found")
+}
+
+func TestBookingReferenceDryRun(t *testing.T) {
+	tm := createTestTicketManager()
+
+	response, err := tm.PurchaseTicket(context.Background(), &pb.PurchaseTicketRequest{
+		User:   &pb.User{FirstName: "Sanjay", LastName: "Kishor", Email: "test@example.com"},
+		From:   "London",
+		To:     "France",
+		DryRun: true,
+	})
+	assert.NoError(t, err)
+	assert.Empty(t, response.Receipt.BookingReference, "Dry runs should not reserve a reference")
+	assert.Empty(t, tm.references)
+}
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"fmt"
+	"math/big"
+	"slices"
+
+	"github.com/sanjaykishor/rail-connect/internal/config"
+	pb "github.com/sanjaykishor/rail-connect/proto"
+)
+
+// RefundPolicy returns the refund for a fare once travelled of the journey's
+// total route segments have been ridden. travelled is 0 before departure and
+// never exceeds total.
+type RefundPolicy func(fare float64, travelled, total int) float64
+
+// ProratedRefund refunds the share of the fare for the segments not travelled.
+func ProratedRefund(fare float64, travelled, total int) float64 {
+	if total <= 0 {
+		return fare
+	}
+	refund := new(big.Rat).Mul(decimal(fare), big.NewRat(int64(total-travelled), int64(total)))
+	amount, _ := refund.Float64()
+	return amount
+}
+
+// DepartureRefund refunds the whole fare before departure and nothing after.
+func DepartureRefund(fare float64, travelled, total int) float64 {
+	if travelled > 0 {
+		return 0
+	}
+	return fare
+}
+
+// NewRefundPolicy returns the policy for a config refund policy, prorating
+// unless another is named.
+func NewRefundPolicy(name string) RefundPolicy {
+	if name == config.RefundBeforeDeparture {
+		return DepartureRefund
+	}
+	return ProratedRefund
+}
+
+// segmentsTravelled returns how many of the receipt's route segments lie
+// before the station where the journey was cut short, and how many segments
+// the receipt covers. An empty station is before departure. The count is
+// negative for a station before the origin and above the total for one past
+// the destination. Without a configured route a booking is a single segment,
+// so only its origin and destination are accepted. Callers must hold tm.mu.
+func (tm *TicketManager) segmentsTravelled(receipt *pb.Receipt, station string) (int, int, error) {
+	journey, err := tm.journey(receipt)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if len(tm.SeatManager.Route) == 0 {
+		switch station {
+		case "", receipt.From:
+			return 0, 1, nil
+		case receipt.To:
+			return 1, 1, nil
+		}
+		return 0, 0, fmt.Errorf("%w: %s", ErrStationNotOnJourney, station)
+	}
+
+	total := journey.To - journey.From
+	if station == "" {
+		return 0, total, nil
+	}
+	index := slices.Index(tm.SeatManager.Route, station)
+	if index < 0 {
+		return 0, 0, fmt.Errorf("%w: %s", ErrStationNotOnJourney, station)
+	}
+	return index - journey.From, total, nil
+}
+
+// refund applies the refund policy to the fare paid for the receipt, rounded
+// like fares. Stations before the origin count as before departure and those
+// past the destination as a completed journey.
+func (tm *TicketManager) refund(receipt *pb.Receipt, travelled, total int) float64 {
+	travelled = min(max(travelled, 0), total)
+	return roundFare(tm.RefundPolicy(receipt.PricePaid, travelled, total), 1, tm.FareRounding)
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestProratedRefund(t *testing.T) {
+	assert.Equal(t, 20.00, ProratedRefund(20.00, 0, 2), "Cancelling before departure should refund in full")
+	assert.Equal(t, 10.00, ProratedRefund(20.00, 1, 2), "Cancelling at the midpoint should refund half")
+	assert.Equal(t, 0.0, ProratedRefund(20.00, 2, 2), "A completed journey should not be refunded")
+	assert.Equal(t, 7.00, ProratedRefund(21.00, 2, 3))
+}
+
+func TestDepartureRefund(t *testing.T) {
+	assert.Equal(t, 20.00, DepartureRefund(20.00, 0, 2))
+	assert.Equal(t, 0.0, DepartureRefund(20.00, 1, 2))
+}
+
+func TestRemoveUserRefundBeforeDeparture(t *testing.T) {
+	tm := createRouteFareTicketManager()
+	purchase(t, tm, "test@example.com", "London", "Lyon")
+
+	response, err := tm.RemoveUser(context.Background(), &pb.RemoveUserRequest{Email: "test@example.com"})
+	assert.NoError(t, err)
+	assert.Equal(t, 20.00, response.Refund)
+}
+
+func TestRemoveUserRefundAtMidpoint(t *testing.T) {
+	tm := createRouteFareTicketManager()
+	purchase(t, tm, "test@example.com", "London", "Lyon")
+
+	response, err := tm.RemoveUser(context.Background(), &pb.RemoveUserRequest{Email: "test@example.com", CancelledAt: "Paris"})
+	assert.NoError(t, err)
+	assert.Equal(t, 10.00, response.Refund, "Half the journey is left from Paris")
+}
+
+func TestRemoveUserRefundRounding(t *testing.T) {
+	tm := createRouteFareTicketManager()
+	purchase(t, tm, "test@example.com", "London", "Geneva")
+
+	response, err := tm.RemoveUser(context.Background(), &pb.RemoveUserRequest{Email: "test@example.com", CancelledAt: "Paris"})
+	assert.NoError(t, err)
+	assert.Equal(t, 26.67, response.Refund, "Two thirds of 40.00 should round to the cent")
+}
+
+func TestRemoveUserRefundPolicy(t *testing.T) {
+	tm := createRouteFareTicketManager()
+	tm.RefundPolicy = DepartureRefund
+	purchase(t, tm, "test@example.com", "London", "Lyon")
+
+	response, err := tm.RemoveUser(context.Background(), &pb.RemoveUserRequest{Email: "test@example.com", CancelledAt: "Paris"})
+	assert.NoError(t, err)
+	assert.Equal(t, 0.0, response.Refund, "The configured policy should set the refund")
+}
+
+func TestRemoveUserRefundNotOnJourney(t *testing.T) {
+	tm := createRouteFareTicketManager()
+	purchase(t, tm, "test@example.com", "London", "Paris")
+
+	for _, station := range []string{"Geneva", "Berlin"} {
+		_, err := tm.RemoveUser(context.Background(), &pb.RemoveUserRequest{Email: "test@example.com", CancelledAt: station})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), "%s is not on the journey", station)
+	}
+	assert.Contains(t, tm.Receipts, "test@example.com", "A rejected cancellation should keep the ticket")
+}
+
+func TestRemoveUserRefundWithoutRoute(t *testing.T) {
+	tm := createTestTicketManager()
+	purchase(t, tm, "test@example.com", "London", "France")
+
+	response, err := tm.RemoveUser(context.Background(), &pb.RemoveUserRequest{Email: "test@example.com", CancelledAt: "London"})
+	assert.NoError(t, err)
+	assert.Equal(t, 20.00, response.Refund, "Cancelling at the origin should refund in full")
+}
+
+func TestBulkCancelRefunds(t *testing.T) {
+	tm := createRouteFareTicketManager()
+	purchase(t, tm, "first@example.com", "London", "Lyon")
+	purchase(t, tm, "second@example.com", "Paris", "Lyon")
+
+	response, err := tm.BulkCancel(context.Background(), &pb.BulkCancelRequest{Section: "A", CancelledAt: "Paris"})
+	assert.NoError(t, err)
+	assert.Len(t, response.CancelledReceipts, 1)
+	assert.Equal(t, []*pb.Refund{{Email: "first@example.com", Amount: 10.00}}, response.Refunds)
+
+	// The second rider had not boarded when the train stopped at Paris
+	response, err = tm.BulkCancel(context.Background(), &pb.BulkCancelRequest{Section: "B", CancelledAt: "Paris"})
+	assert.NoError(t, err)
+	assert.Equal(t, []*pb.Refund{{Email: "second@example.com", Amount: 10.00}}, response.Refunds)
+}
+
+func TestBulkCancelRefundNotOnRoute(t *testing.T) {
+	tm := createRouteFareTicketManager()
+	purchase(t, tm, "test@example.com", "London", "Lyon")
+
+	_, err := tm.BulkCancel(context.Background(), &pb.BulkCancelRequest{Section: "A", CancelledAt: "Berlin"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Contains(t, tm.Receipts, "test@example.com", "Nothing should be cancelled")
+}
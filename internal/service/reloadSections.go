@@ -0,0 +1,229 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+
+	"go.uber.org/zap"
+
+	"github.com/sanjaykishor/rail-connect/internal/config"
+	pb "github.com/sanjaykishor/rail-connect/proto"
+)
+
+// Kinds of SectionChange made by ReloadSections.
+const (
+	SectionAdded   = "add"
+	SectionGrown   = "grow"
+	SectionShrunk  = "shrink"
+	SectionRemoved = "remove"
+)
+
+// SectionChange is one difference between the configured sections and the
+// live layout.
+type SectionChange struct {
+	Section  string
+	Change   string // SectionAdded, SectionGrown, SectionShrunk or SectionRemoved
+	MaxSeats int    // Configured seat count; zero for removals
+	Migrated int    // Riders moved to other seats for the change
+	Reason   string // Why a skipped change could not be made
+}
+
+// SectionReloadReport lists the section changes ReloadSections made and those
+// it left for an operator to resolve.
+type SectionReloadReport struct {
+	Applied []SectionChange
+	Skipped []SectionChange
+}
+
+// ReloadSections brings the undated train's sections in line with the
+// configured ones. New sections are added and grown sections resized. Riders
+// in the trailing seats of a shrunk section are moved to free seats below the
+// new size, and riders in a removed section to free seats in the remaining
+// ones; when they can't all be moved the change is skipped, and riders already
+// moved keep their new seats. The default section and sections with a waitlist
+// are never removed. Blocked seats and overflow policies of existing sections
+// are left as they are. Dated departures keep their layout; ones created later
+// copy the new one.
+func (tm *TicketManager) ReloadSections(sections []config.SectionConfig) SectionReloadReport {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	return tm.reloadSections(sections)
+}
+
+// ReloadCompatibleSections reloads the sections of cfg with ReloadSections
+// only if cfg is valid and takes away no occupied seat of any dated departure.
+// Riders of the default train in seats it takes away are moved as
+// ReloadSections does. The check and the reload run under one lock, so no
+// booking can take a seat in between. An incompatible configuration changes
+// nothing; its report says why.
+func (tm *TicketManager) ReloadCompatibleSections(cfg *config.Config) (CompatibilityReport, SectionReloadReport) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	compatibility := tm.validateAgainstDepartures(cfg)
+	if !compatibility.Compatible() {
+		tm.Logger.Warn("Sections reload rejected",
+			zap.Error(compatibility.Err()),
+		)
+		return compatibility, SectionReloadReport{}
+	}
+	return compatibility, tm.reloadSections(cfg.Sections)
+}
+
+// reloadSections is ReloadSections. Callers must hold tm.mu.
+func (tm *TicketManager) reloadSections(sections []config.SectionConfig) SectionReloadReport {
+	var report SectionReloadReport
+	configured := make(map[string]bool, len(sections))
+	for _, section := range sections {
+		configured[section.Name] = true
+
+		_, maxSeats, err := tm.SeatManager.SectionVacancy(section.Name)
+		switch {
+		case err != nil:
+			change := SectionChange{Section: section.Name, Change: SectionAdded, MaxSeats: section.MaxSeats}
+			if err := tm.SeatManager.AddSection(section); err != nil {
+				change.Reason = err.Error()
+				report.Skipped = append(report.Skipped, change)
+				continue
+			}
+			report.Applied = append(report.Applied, change)
+		case section.MaxSeats > maxSeats:
+			change := SectionChange{Section: section.Name, Change: SectionGrown, MaxSeats: section.MaxSeats}
+			if err := tm.SeatManager.ResizeSection(section.Name, section.MaxSeats); err != nil {
+				change.Reason = err.Error()
+				report.Skipped = append(report.Skipped, change)
+				continue
+			}
+			tm.promoteWaitlist(section.Name, tm.DefaultTrain, "")
+			report.Applied = append(report.Applied, change)
+		case section.MaxSeats < maxSeats:
+			report.add(tm.shrinkSection(section))
+		}
+	}
+
+	for _, section := range tm.SeatManager.SectionConfigs() {
+		if !configured[section.Name] {
+			report.add(tm.removeSection(section.Name, sections))
+		}
+	}
+
+	tm.Logger.Info("Sections reloaded",
+		zap.Int("applied", len(report.Applied)),
+		zap.Int("skipped", len(report.Skipped)),
+	)
+	return report
+}
+
+// add files the change under Applied, or under Skipped if it has a reason.
+func (r *SectionReloadReport) add(change SectionChange) {
+	if change.Reason != "" {
+		r.Skipped = append(r.Skipped, change)
+		return
+	}
+	r.Applied = append(r.Applied, change)
+}
+
+// shrinkSection moves riders out of the seats a shrink removes, then resizes
+// the section. Callers must hold tm.mu.
+func (tm *TicketManager) shrinkSection(section config.SectionConfig) SectionChange {
+	change := SectionChange{Section: section.Name, Change: SectionShrunk, MaxSeats: section.MaxSeats}
+	for _, receipt := range tm.seatedIn(section.Name, func(seat int32) bool { return int(seat) > section.MaxSeats }) {
+		if !tm.migrateRider(receipt, []string{section.Name}, section.MaxSeats) {
+			change.Reason = fmt.Sprintf("no free seat below %d for %s", section.MaxSeats+1, receipt.User.Email)
+			return change
+		}
+		change.Migrated++
+	}
+
+	if err := tm.SeatManager.ResizeSection(section.Name, section.MaxSeats); err != nil {
+		change.Reason = err.Error()
+	}
+	return change
+}
+
+// removeSection moves every rider out of the section into the configured
+// sections that remain, then removes it. Callers must hold tm.mu.
+func (tm *TicketManager) removeSection(name string, sections []config.SectionConfig) SectionChange {
+	change := SectionChange{Section: name, Change: SectionRemoved}
+	if name == tm.SeatManager.DefaultSection {
+		change.Reason = "section is the default section"
+		return change
+	}
+	if len(tm.waitlists[name]) > 0 {
+		change.Reason = "riders are waitlisted for the section"
+		return change
+	}
+
+	targets := make([]string, 0, len(sections))
+	for _, section := range sections {
+		if tm.SeatManager.HasSection(section.Name) {
+			targets = append(targets, section.Name)
+		}
+	}
+	for _, receipt := range tm.seatedIn(name, func(int32) bool { return true }) {
+		if !tm.migrateRider(receipt, targets, 0) {
+			change.Reason = fmt.Sprintf("no free seat in another section for %s", receipt.User.Email)
+			return change
+		}
+		change.Migrated++
+	}
+
+	if err := tm.SeatManager.RemoveSection(name); err != nil {
+		change.Reason = err.Error()
+	}
+	return change
+}
+
+// seatedIn returns the receipts for the undated default train seated in the
+// section whose seat matches, ordered by email. Callers must hold tm.mu.
+func (tm *TicketManager) seatedIn(section string, match func(seat int32) bool) []*pb.Receipt {
+	var receipts []*pb.Receipt
+	for _, receipt := range tm.Receipts {
+		if tm.isDefaultDeparture(receipt.TrainId, receipt.DepartureDate) && receipt.Seat != nil && receipt.Seat.Section == section && match(receipt.Seat.SeatNumber) {
+			receipts = append(receipts, receipt)
+		}
+	}
+	sort.Slice(receipts, func(i, j int) bool { return receipts[i].User.Email < receipts[j].User.Email })
+	return receipts
+}
+
+// migrateRider moves an undated rider to the lowest free seat of the first
+// target section that has one, searching seats up to limit, or the whole
+// section when limit is zero. Callers must hold tm.mu.
+func (tm *TicketManager) migrateRider(receipt *pb.Receipt, targets []string, limit int) bool {
+	journey, err := tm.journey(receipt)
+	if err != nil {
+		return false
+	}
+
+	for _, target := range targets {
+		seatLimit := limit
+		if seatLimit == 0 {
+			_, seatLimit, _ = tm.SeatManager.SectionVacancy(target)
+		}
+		seat, ok := tm.SeatManager.FreeSeatUpTo(target, seatLimit, journey)
+		if !ok {
+			continue
+		}
+		err := tm.SeatManager.UpdateSeat(int(receipt.Seat.SeatNumber), receipt.Seat.Section, seat, target, journey)
+		if err != nil {
+			continue
+		}
+
+		oldSection := receipt.Seat.Section
+		tm.trackDestination(receipt, oldSection, -1)
+		tm.trackDestination(receipt, target, 1)
+		receipt.Seat = &pb.Seat{Section: target, SeatNumber: int32(seat)}
+		tm.recordEvent(receipt.User.Email, pb.ReceiptEventType_RECEIPT_EVENT_SEAT_CHANGED, receipt)
+		tm.notify(pb.ReceiptEventType_RECEIPT_EVENT_SEAT_CHANGED, receipt)
+
+		tm.Logger.Info("Rider moved for section reload",
+			zap.String("user", receipt.User.Email),
+			zap.String("old_section", oldSection),
+			zap.String("new_section", target),
+			zap.Int("new_seat", seat),
+		)
+		return true
+	}
+	return false
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sanjaykishor/rail-connect/internal/config"
+	"github.com/stretchr/testify/assert"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+)
+
+func TestReloadSectionsAdditive(t *testing.T) {
+	tm := createTestTicketManager()
+	purchase(t, tm, "test@example.com", "London", "France")
+
+	report := tm.ReloadSections([]config.SectionConfig{
+		{Name: "A", MaxSeats: 20},
+		{Name: "B", MaxSeats: 25},
+		{Name: "C", MaxSeats: 5, BlockedSeats: []int{5}},
+	})
+	assert.Equal(t, []SectionChange{
+		{Section: "B", Change: SectionGrown, MaxSeats: 25},
+		{Section: "C", Change: SectionAdded, MaxSeats: 5},
+	}, report.Applied)
+	assert.Empty(t, report.Skipped)
+
+	assert.Equal(t, []string{"A", "B", "C"}, tm.SeatManager.SectionOrder)
+	assert.Equal(t, 25, tm.SeatManager.VacantSeats("B"))
+	assert.Equal(t, 4, tm.SeatManager.VacantSeats("C"), "Blocked seats of a new section should not be sold")
+
+	// The new section can be booked straight away
+	response, err := tryPurchase(tm, "other@example.com", inSection("C"))
+	assert.NoError(t, err)
+	assert.Equal(t, &pb.Seat{Section: "C", SeatNumber: 1}, response.Receipt.Seat)
+}
+
+func TestReloadSectionsConflictingShrink(t *testing.T) {
+	tm := createOverflowTicketManager("")
+	fillSectionA(t, tm)
+	sections := []config.SectionConfig{{Name: "A", MaxSeats: 1}, {Name: "B", MaxSeats: 4}}
+
+	// Both riders in A can't fit in one seat
+	report := tm.ReloadSections(sections)
+	assert.Empty(t, report.Applied)
+	assert.Len(t, report.Skipped, 1)
+	assert.Equal(t, SectionShrunk, report.Skipped[0].Change)
+	assert.Contains(t, report.Skipped[0].Reason, "second@example.com")
+	_, maxSeats, _ := tm.SeatManager.SectionVacancy("A")
+	assert.Equal(t, 2, maxSeats)
+	assert.Equal(t, &pb.Seat{Section: "A", SeatNumber: 2}, tm.Receipts["second@example.com"].Seat)
+
+	// Once seat 1 is free the rider in seat 2 is moved into it
+	_, err := tm.RemoveUser(context.Background(), &pb.RemoveUserRequest{Email: "first@example.com"})
+	assert.NoError(t, err)
+	report = tm.ReloadSections(sections)
+	assert.Equal(t, []SectionChange{{Section: "A", Change: SectionShrunk, MaxSeats: 1, Migrated: 1}}, report.Applied)
+	assert.Empty(t, report.Skipped)
+	assert.Equal(t, &pb.Seat{Section: "A", SeatNumber: 1}, tm.Receipts["second@example.com"].Seat)
+	history := tm.History["second@example.com"]
+	assert.Equal(t, pb.ReceiptEventType_RECEIPT_EVENT_SEAT_CHANGED, history[len(history)-1].Type)
+}
+
+func TestReloadSectionsRemoval(t *testing.T) {
+	tm := createOverflowTicketManager("")
+	_, err := tryPurchase(tm, "test@example.com", inSection("B"))
+	assert.NoError(t, err)
+
+	report := tm.ReloadSections([]config.SectionConfig{{Name: "A", MaxSeats: 2}})
+	assert.Equal(t, []SectionChange{{Section: "B", Change: SectionRemoved, Migrated: 1}}, report.Applied)
+	assert.Equal(t, []string{"A"}, tm.SeatManager.SectionOrder)
+	assert.Equal(t, &pb.Seat{Section: "A", SeatNumber: 1}, tm.Receipts["test@example.com"].Seat)
+}
+
+func TestReloadSectionsKeepsDepartureLayout(t *testing.T) {
+	tm := createOverflowTicketManager(config.OverflowReject)
+	departure := tm.departure("", "2030-01-01")
+
+	report := tm.ReloadSections([]config.SectionConfig{{Name: "B", MaxSeats: 4}})
+	assert.Equal(t, []SectionChange{{Section: "A", Change: SectionRemoved}}, report.Applied)
+	assert.Equal(t, config.OverflowReject, departure.OverflowPolicy("A"), "Dated departures should keep their layout")
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"go.uber.org/zap"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ResizeSection grows or shrinks the number of sellable seats in a section on
+// every departure. The vacant seats returned are those of the undated default
+// train.
+func (tm *TicketManager) ResizeSection(ctx context.Context, req *pb.ResizeSectionRequest) (*pb.ResizeSectionResponse, error) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	tm.Logger.Info("ResizeSection request received")
+
+	// Validate the request
+	if req == nil {
+		tm.Logger.Error("ResizeSection request is nil")
+		return nil, status.Error(codes.InvalidArgument, "request is nil")
+	}
+	if req.Section == "" || req.MaxSeats <= 0 {
+		tm.Logger.Error("ResizeSection request missing required fields",
+			zap.String("section", req.Section),
+			zap.Int32("max_seats", req.MaxSeats),
+		)
+		return nil, status.Error(codes.InvalidArgument, "missing required fields")
+	}
+
+	// Check if the section exists
+	if _, exists := tm.SeatManager.Sections[req.Section]; !exists {
+		tm.Logger.Error("ResizeSection section not found",
+			zap.String("section", req.Section),
+		)
+		return nil, status.Error(codes.NotFound, "section not found")
+	}
+
+	tm.Logger.Info("ResizeSection request",
+		zap.String("section", req.Section),
+		zap.Int32("max_seats", req.MaxSeats),
+		zap.Time("timestamp", tm.Now()),
+	)
+
+	// Every departure keeps the same sections, so resize all of them or none
+	if key, err := tm.checkResize(req.Section, int(req.MaxSeats)); err != nil {
+		tm.Logger.Error("ResizeSection failed to resize section",
+			zap.String("section", req.Section),
+			zap.Int32("max_seats", req.MaxSeats),
+			zap.String("train_id", key.train),
+			zap.String("departure_date", key.date),
+			zap.Error(err),
+		)
+		if errors.Is(err, ErrSeatOccupied) {
+			return nil, status.Error(codes.FailedPrecondition, "cannot shrink section below occupied seats")
+		}
+		return nil, seatError(err)
+	}
+	_ = tm.SeatManager.ResizeSection(req.Section, int(req.MaxSeats)) // Checked above
+	tm.promoteWaitlist(req.Section, tm.DefaultTrain, "")
+	for _, key := range tm.departureKeys() {
+		_ = tm.departures[key].ResizeSection(req.Section, int(req.MaxSeats))
+		tm.promoteWaitlist(req.Section, key.train, key.date)
+	}
+
+	vacantSeats := tm.SeatManager.VacantSeats(req.Section)
+
+	tm.Logger.Info("ResizeSection successful",
+		zap.String("section", req.Section),
+		zap.Int32("max_seats", req.MaxSeats),
+		zap.Int("vacant_seats", vacantSeats),
+	)
+	return &pb.ResizeSectionResponse{
+		Message:     "Section resized successfully",
+		Section:     req.Section,
+		MaxSeats:    req.MaxSeats,
+		VacantSeats: int32(vacantSeats),
+	}, nil
+}
+
+// checkResize returns the first departure, starting with the undated default
+// train, whose section can't be resized to maxSeats, and why. Callers must
+// hold tm.mu.
+func (tm *TicketManager) checkResize(section string, maxSeats int) (departureKey, error) {
+	if err := tm.SeatManager.CheckResize(section, maxSeats); err != nil {
+		return departureKey{train: tm.DefaultTrain}, err
+	}
+	for _, key := range tm.departureKeys() {
+		if err := tm.departures[key].CheckResize(section, maxSeats); err != nil {
+			return key, err
+		}
+	}
+	return departureKey{}, nil
+}
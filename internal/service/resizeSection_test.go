@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestResizeSectionRPC(t *testing.T) {
+	tm := createTestTicketManager()
+
+	// Occupy seat 15 in section A
+	tm.SeatManager.Sections["A"].Seats[15].Available = false
+	tm.SeatManager.Sections["A"].VacantSeats--
+
+	tests := []struct {
+		name           string
+		request        *pb.ResizeSectionRequest
+		expectedCode   codes.Code
+		expectedVacant int32
+	}{
+		{"Grow", &pb.ResizeSectionRequest{Section: "A", MaxSeats: 25}, codes.OK, 24},
+		{"Shrink Above Occupied Seat", &pb.ResizeSectionRequest{Section: "A", MaxSeats: 15}, codes.OK, 14},
+		{"Shrink Below Occupied Seat", &pb.ResizeSectionRequest{Section: "A", MaxSeats: 10}, codes.FailedPrecondition, 0},
+		{"Nonexistent Section", &pb.ResizeSectionRequest{Section: "C", MaxSeats: 10}, codes.NotFound, 0},
+		{"Invalid Size", &pb.ResizeSectionRequest{Section: "A", MaxSeats: 0}, codes.InvalidArgument, 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			response, err := tm.ResizeSection(context.Background(), test.request)
+			assert.Equal(t, test.expectedCode, status.Code(err))
+			if test.expectedCode == codes.OK {
+				assert.Equal(t, test.request.MaxSeats, response.MaxSeats)
+				assert.Equal(t, test.expectedVacant, response.VacantSeats)
+			} else {
+				assert.Nil(t, response)
+			}
+		})
+	}
+}
+
+func TestResizeSectionEveryDeparture(t *testing.T) {
+	tm := createDatedTicketManager()
+	purchase(t, tm, "monday@example.com", "London", "France", onDate("2024-03-11"))
+	purchase(t, tm, "tuesday@example.com", "London", "France", onDate("2024-03-12"))
+	monday := tm.departures[departureKey{"", "2024-03-11"}]
+	departures := []*SeatManager{tm.SeatManager, monday, tm.departures[departureKey{"", "2024-03-12"}]}
+
+	// Occupy seat 15 in section A on Monday only
+	monday.Sections["A"].Seats[15].Available = false
+	monday.Sections["A"].VacantSeats--
+
+	_, err := tm.ResizeSection(context.Background(), &pb.ResizeSectionRequest{Section: "A", MaxSeats: 10})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err), "A rider on any departure should stop the shrink")
+	for i, seats := range departures {
+		assert.Equal(t, 20, seats.Sections["A"].MaxSeats, "Departure %d should not be resized", i)
+	}
+
+	_, err = tm.ResizeSection(context.Background(), &pb.ResizeSectionRequest{Section: "A", MaxSeats: 25})
+	assert.NoError(t, err)
+	for i, seats := range departures {
+		assert.Equal(t, 25, seats.Sections["A"].MaxSeats, "Departure %d should be resized", i)
+	}
+}
@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sanjaykishor/rail-connect/internal/config"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// createRestrictedTicketManager returns a TicketManager with two public
+// sections of two seats around a cheaper, crew-only section.
+func createRestrictedTicketManager() *TicketManager {
+	seatManager := NewSeatManager([]config.SectionConfig{
+		{Name: "A", MaxSeats: 2},
+		{Name: "Crew", MaxSeats: 2, Restricted: true, PriceMultiplier: 0.5},
+		{Name: "B", MaxSeats: 2},
+	}, zap.NewNop())
+	return NewTicketManager(seatManager, map[string]float64{"London-France": 20.00}, zap.NewNop())
+}
+
+func TestRestrictedSectionNeverSold(t *testing.T) {
+	tm := createRestrictedTicketManager()
+
+	// Round-robin, dry runs, budget riders and pairs all skip the crew section
+	dryRun, err := tm.PurchaseTicket(context.Background(), &pb.PurchaseTicketRequest{
+		User:   &pb.User{FirstName: "Sanjay", LastName: "Kishor", Email: "dry@example.com"},
+		From:   "London",
+		To:     "France",
+		DryRun: true,
+	})
+	assert.NoError(t, err)
+	assert.NotEqual(t, "Crew", dryRun.Receipt.Seat.Section)
+
+	cheapest, err := tryPurchase(tm, "budget@example.com", minimizingPrice)
+	assert.NoError(t, err)
+	assert.NotEqual(t, "Crew", cheapest.Receipt.Seat.Section, "The cheapest section should not be sold if restricted")
+
+	pair, err := tm.PurchaseTicket(context.Background(), &pb.PurchaseTicketRequest{
+		User:      &pb.User{FirstName: "Sanjay", LastName: "Kishor", Email: "pair@example.com"},
+		From:      "London",
+		To:        "France",
+		Companion: &pb.User{FirstName: "Priya", LastName: "Kishor", Email: "pair.companion@example.com"},
+	})
+	assert.NoError(t, err)
+	assert.NotEqual(t, "Crew", pair.Receipt.Seat.Section)
+	assert.NotEqual(t, "Crew", pair.CompanionReceipt.Seat.Section)
+
+	receipt := purchase(t, tm, "last@example.com", "London", "France")
+	assert.NotEqual(t, "Crew", receipt.Seat.Section)
+
+	// The public sections are now full
+	_, err = tm.PurchaseTicket(context.Background(), &pb.PurchaseTicketRequest{
+		User: &pb.User{FirstName: "Sanjay", LastName: "Kishor", Email: "late@example.com"},
+		From: "London",
+		To:   "France",
+	})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.Equal(t, 2, tm.SeatManager.VacantSeats("Crew"))
+}
+
+func TestRestrictedSectionPublicPaths(t *testing.T) {
+	tm := createRestrictedTicketManager()
+	tm.HoldDuration = 5 * time.Minute
+	purchase(t, tm, "test@example.com", "London", "France")
+
+	_, err := tryPurchase(tm, "crew@example.com", inSection("Crew"))
+	assert.Equal(t, codes.PermissionDenied, status.Code(err), "Restricted sections can't be preferred")
+
+	_, err = tm.UpdateUserSeat(context.Background(), &pb.UpdateUserSeatRequest{
+		Email:   "test@example.com",
+		NewSeat: &pb.Seat{Section: "Crew", SeatNumber: 1},
+	})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err), "Riders can't move themselves into restricted sections")
+
+	_, err = tm.HoldSeat(context.Background(), &pb.HoldSeatRequest{
+		Email: "test@example.com",
+		From:  "London",
+		To:    "France",
+		Seat:  &pb.Seat{Section: "Crew", SeatNumber: 1},
+	})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err), "Restricted seats can't be held")
+	assert.Equal(t, 2, tm.SeatManager.VacantSeats("Crew"))
+}
+
+func TestRestrictedSectionAdminAssign(t *testing.T) {
+	tm := createRestrictedTicketManager()
+	purchase(t, tm, "test@example.com", "London", "France")
+
+	response, err := tm.ForceReassign(context.Background(), &pb.ForceReassignRequest{
+		Email:         "test@example.com",
+		TargetSection: "Crew",
+		TargetSeat:    2,
+		Reason:        "Travelling as relief crew",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, &pb.Seat{Section: "Crew", SeatNumber: 2}, response.UpdatedReceipt.Seat)
+	assert.Equal(t, 1, tm.SeatManager.VacantSeats("Crew"))
+}
+
+func TestRestrictedSectionSwap(t *testing.T) {
+	tm := createRestrictedTicketManager()
+	public := purchase(t, tm, "test@example.com", "London", "France")
+	purchase(t, tm, "crew@example.com", "London", "France")
+	_, err := tm.ForceReassign(context.Background(), &pb.ForceReassignRequest{
+		Email:         "crew@example.com",
+		TargetSection: "Crew",
+		TargetSeat:    1,
+		Reason:        "Travelling as relief crew",
+	})
+	assert.NoError(t, err)
+
+	_, err = tm.SwapSeats(context.Background(), &pb.SwapSeatsRequest{EmailA: "test@example.com", EmailB: "crew@example.com"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err), "Riders can't swap into restricted sections")
+	assert.Equal(t, "Crew", tm.Receipts["crew@example.com"].Seat.Section, "A rejected swap should move nobody")
+	assert.Equal(t, public.Seat.Section, tm.Receipts["test@example.com"].Seat.Section)
+
+	// Riders already in the restricted section may swap among themselves
+	purchase(t, tm, "relief@example.com", "London", "France")
+	_, err = tm.ForceReassign(context.Background(), &pb.ForceReassignRequest{
+		Email:         "relief@example.com",
+		TargetSection: "Crew",
+		TargetSeat:    2,
+		Reason:        "Travelling as relief crew",
+	})
+	assert.NoError(t, err)
+	_, err = tm.SwapSeats(context.Background(), &pb.SwapSeatsRequest{EmailA: "crew@example.com", EmailB: "relief@example.com"})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), tm.Receipts["crew@example.com"].Seat.SeatNumber)
+}
+
+func TestAllSectionsReportsRestricted(t *testing.T) {
+	tm := createRestrictedTicketManager()
+
+	response, err := tm.GetAllSections(context.Background(), &pb.GetAllSectionsRequest{})
+	assert.NoError(t, err)
+	for _, section := range response.Sections {
+		assert.Equal(t, section.Name == "Crew", section.Restricted, "section %s", section.Name)
+	}
+
+	// Dated departures keep the restriction
+	assert.True(t, tm.departure("", "2030-01-01").Restricted("Crew"))
+}
@@ -0,0 +1,24 @@
+package service
+
+import "fmt"
+
+// routeKey returns the "From-To" key used for station pairs in configuration.
+func routeKey(from, to string) string {
+	return fmt.Sprintf("%s-%s", from, to)
+}
+
+// withinRouteCap reports whether that many more tickets can be sold for the
+// route. Callers must hold tm.mu.
+func (tm *TicketManager) withinRouteCap(from, to string, tickets int) bool {
+	limit, capped := tm.RouteCaps[routeKey(from, to)]
+	return !capped || tm.routeCounts[routeKey(from, to)]+tickets <= limit
+}
+
+// trackRoute adjusts the number of tickets sold for a route. Callers must hold tm.mu.
+func (tm *TicketManager) trackRoute(from, to string, delta int) {
+	key := routeKey(from, to)
+	tm.routeCounts[key] += delta
+	if tm.routeCounts[key] <= 0 {
+		delete(tm.routeCounts, key)
+	}
+}
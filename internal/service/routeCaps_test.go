@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestPurchaseTicketRouteCap(t *testing.T) {
+	tm := createTestTicketManager()
+	tm.StationConnection["London-Paris"] = 30.00
+	tm.RouteCaps = map[string]int{"London-France": 2}
+
+	purchase := func(email, to string) error {
+		_, err := tm.PurchaseTicket(context.Background(), &pb.PurchaseTicketRequest{
+			User: &pb.User{Email: email},
+			From: "London",
+			To:   to,
+		})
+		return err
+	}
+
+	assert.NoError(t, purchase("user1@example.com", "France"))
+	assert.NoError(t, purchase("user2@example.com", "France"))
+
+	// The third ticket on the capped route is rejected
+	err := purchase("user3@example.com", "France")
+	st, _ := status.FromError(err)
+	assert.Equal(t, codes.ResourceExhausted, st.Code(), "Route cap should be a capacity error")
+	assert.Equal(t, 38, tm.SeatManager.VacantSeats("A")+tm.SeatManager.VacantSeats("B"), "Rejected purchase should not take a seat")
+
+	// Dry runs honour the cap too
+	_, err = tm.PurchaseTicket(context.Background(), &pb.PurchaseTicketRequest{
+		User:   &pb.User{Email: "user3@example.com"},
+		From:   "London",
+		To:     "France",
+		DryRun: true,
+	})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	// Uncapped routes are unaffected
+	assert.NoError(t, purchase("user3@example.com", "Paris"))
+
+	// Changing seats keeps the ticket counted against the cap
+	_, err = tm.UpdateUserSeat(context.Background(), &pb.UpdateUserSeatRequest{
+		Email:   "user1@example.com",
+		NewSeat: &pb.Seat{Section: "A", SeatNumber: 10},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, tm.routeCounts["London-France"])
+
+	// Cancelling a ticket releases its place under the cap
+	_, err = tm.RemoveUser(context.Background(), &pb.RemoveUserRequest{Email: "user1@example.com"})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, tm.routeCounts["London-France"])
+	assert.NoError(t, purchase("user4@example.com", "France"))
+	assert.Error(t, purchase("user5@example.com", "France"))
+}
+
+func TestBulkCancelReleasesRouteCap(t *testing.T) {
+	tm := createTestTicketManager()
+	tm.RouteCaps = map[string]int{"London-France": 1}
+
+	req := &pb.PurchaseTicketRequest{User: &pb.User{Email: "user1@example.com"}, From: "London", To: "France"}
+	_, err := tm.PurchaseTicket(context.Background(), req)
+	assert.NoError(t, err)
+
+	_, err = tm.BulkCancel(context.Background(), &pb.BulkCancelRequest{From: "London", To: "France"})
+	assert.NoError(t, err)
+	assert.Empty(t, tm.routeCounts, "Cancelled routes should no longer count against the cap")
+
+	req.User.Email = "user2@example.com"
+	_, err = tm.PurchaseTicket(context.Background(), req)
+	assert.NoError(t, err, "Cap should allow a booking after bulk cancellation")
+}
@@ -0,0 +1,413 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// OccupySeat books the journey on a specific seat, which must be free for it.
+func (sm *SeatManager) OccupySeat(sectionName string, seatNumber int, journey Journey) error {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	defer sm.seatsChanged()
+
+	section, seat, err := sm.seat(sectionName, seatNumber)
+	if err != nil {
+		return err
+	}
+	if seat.Blocked {
+		return fmt.Errorf("%w: seat %d in section %s", ErrSeatBlocked, seatNumber, sectionName)
+	}
+	if !seat.isFree(journey) {
+		return fmt.Errorf("%w: seat %d in section %s", ErrSeatOccupied, seatNumber, sectionName)
+	}
+
+	section.occupy(seatNumber, journey)
+
+	sm.Logger.Info("Seat occupied",
+		zap.String("section", section.Name),
+		zap.Int("seat_number", seatNumber),
+		zap.Int("vacant_seats", section.VacantSeats))
+
+	return nil
+}
+
+// HoldSeat sets a seat aside for a user's journey for HoldDuration, so they
+// can book it with PurchaseTicket before anyone else takes it. Each user may
+// hold at most MaxHoldsPerUser seats at once.
+func (tm *TicketManager) HoldSeat(ctx context.Context, req *pb.HoldSeatRequest) (*pb.HoldSeatResponse, error) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	tm.Logger.Info("HoldSeat request received")
+
+	// Validate the request
+	if req == nil {
+		tm.Logger.Error("HoldSeat request is nil")
+		return nil, status.Error(codes.InvalidArgument, "request is nil")
+	}
+	req.From, req.To = tm.station(req.From), tm.station(req.To)
+	if req.Email == "" || req.From == "" || req.To == "" || req.Seat == nil || req.Seat.Section == "" || req.Seat.SeatNumber <= 0 {
+		tm.Logger.Error("HoldSeat request missing required fields",
+			zap.String("email", req.Email),
+			zap.String("from", req.From),
+			zap.String("to", req.To),
+		)
+		return nil, status.Error(codes.InvalidArgument, "missing required fields")
+	}
+
+	if tm.HoldDuration <= 0 {
+		tm.Logger.Error("HoldSeat seat holds not enabled",
+			zap.String("email", req.Email),
+		)
+		return nil, status.Error(codes.InvalidArgument, "seat holds are not enabled")
+	}
+
+	tm.Logger.Info("HoldSeat request",
+		zap.String("email", req.Email),
+		zap.String("section", req.Seat.Section),
+		zap.Int32("seat_number", req.Seat.SeatNumber),
+		zap.String("train_id", req.TrainId),
+		zap.String("departure_date", req.DepartureDate),
+		zap.Time("timestamp", tm.Now()),
+	)
+
+	train, err := tm.resolveTrain(req.TrainId)
+	if err != nil {
+		tm.Logger.Error("HoldSeat unknown train",
+			zap.String("email", req.Email),
+			zap.String("train_id", req.TrainId),
+		)
+		return nil, err
+	}
+
+	now := tm.Now()
+	if req.DepartureDate != "" {
+		if err := tm.checkDepartureDate(req.DepartureDate, now); err != nil {
+			tm.Logger.Error("HoldSeat invalid departure date",
+				zap.String("email", req.Email),
+				zap.String("departure_date", req.DepartureDate),
+				zap.Error(err),
+			)
+			return nil, err
+		}
+	}
+
+	if _, ok := tm.fare(req.From, req.To); !ok {
+		tm.Logger.Error("HoldSeat invalid station names",
+			zap.String("from", req.From),
+			zap.String("to", req.To),
+		)
+		return nil, status.Error(codes.InvalidArgument, "invalid station")
+	}
+
+	journey, err := tm.SeatManager.Journey(req.From, req.To)
+	if err != nil {
+		tm.Logger.Error("HoldSeat journey not on route",
+			zap.String("from", req.From),
+			zap.String("to", req.To),
+			zap.Error(err),
+		)
+		return nil, seatError(err)
+	}
+
+	// Holds past their expiry no longer count towards the cap
+	tm.expireHolds(now)
+	if tm.MaxHoldsPerUser > 0 && tm.userHolds[req.Email] >= tm.MaxHoldsPerUser {
+		tm.Logger.Error("HoldSeat hold limit reached",
+			zap.String("email", req.Email),
+			zap.Int("max_holds_per_user", tm.MaxHoldsPerUser),
+		)
+		return nil, status.Error(codes.ResourceExhausted, "seat hold limit reached")
+	}
+
+	if tm.SeatManager.Restricted(req.Seat.Section) {
+		tm.Logger.Error("HoldSeat section is restricted",
+			zap.String("email", req.Email),
+			zap.String("section", req.Seat.Section),
+		)
+		return nil, seatError(fmt.Errorf("%w: %s", ErrSectionRestricted, req.Seat.Section))
+	}
+	if tm.departure(train, req.DepartureDate).Quiesced(req.Seat.Section) {
+		tm.Logger.Error("HoldSeat section is quiesced",
+			zap.String("email", req.Email),
+			zap.String("section", req.Seat.Section),
+		)
+		return nil, seatError(fmt.Errorf("%w: %s", ErrSectionQuiesced, req.Seat.Section))
+	}
+
+	err = tm.departure(train, req.DepartureDate).OccupySeat(req.Seat.Section, int(req.Seat.SeatNumber), journey)
+	if err != nil {
+		tm.Logger.Error("HoldSeat failed to occupy seat",
+			zap.String("email", req.Email),
+			zap.String("section", req.Seat.Section),
+			zap.Int32("seat_number", req.Seat.SeatNumber),
+			zap.Error(err),
+		)
+		return nil, seatError(err)
+	}
+
+	hold := &pb.SeatHold{
+		HoldId:        tm.newHoldID(),
+		Email:         req.Email,
+		Seat:          &pb.Seat{Section: req.Seat.Section, SeatNumber: req.Seat.SeatNumber},
+		From:          req.From,
+		To:            req.To,
+		TrainId:       train,
+		DepartureDate: req.DepartureDate,
+		ExpiresAt:     now.Add(tm.HoldDuration).Unix(),
+	}
+	tm.holds[hold.HoldId] = hold
+	tm.userHolds[req.Email]++
+
+	tm.Logger.Info("HoldSeat successful",
+		zap.String("email", req.Email),
+		zap.String("hold_id", hold.HoldId),
+		zap.String("section", hold.Seat.Section),
+		zap.Int32("seat_number", hold.Seat.SeatNumber),
+	)
+	return &pb.HoldSeatResponse{
+		Message: "Seat held",
+		Hold:    hold,
+	}, nil
+}
+
+// ReleaseHold gives a held seat back before its hold expires.
+func (tm *TicketManager) ReleaseHold(ctx context.Context, req *pb.ReleaseHoldRequest) (*pb.ReleaseHoldResponse, error) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	tm.Logger.Info("ReleaseHold request received")
+
+	// Validate the request
+	if req == nil {
+		tm.Logger.Error("ReleaseHold request is nil")
+		return nil, status.Error(codes.InvalidArgument, "request is nil")
+	}
+	if req.HoldId == "" || req.Email == "" {
+		tm.Logger.Error("ReleaseHold request missing required fields",
+			zap.String("hold_id", req.HoldId),
+			zap.String("email", req.Email),
+		)
+		return nil, status.Error(codes.InvalidArgument, "missing required fields")
+	}
+
+	tm.Logger.Info("ReleaseHold request",
+		zap.String("hold_id", req.HoldId),
+		zap.String("email", req.Email),
+		zap.Time("timestamp", tm.Now()),
+	)
+
+	hold, exists := tm.holds[req.HoldId]
+	if !exists {
+		tm.Logger.Error("ReleaseHold seat hold not found",
+			zap.String("hold_id", req.HoldId),
+		)
+		return nil, status.Error(codes.NotFound, "seat hold not found")
+	}
+	if hold.Email != req.Email {
+		tm.Logger.Error("ReleaseHold seat hold belongs to another user",
+			zap.String("hold_id", req.HoldId),
+			zap.String("email", req.Email),
+		)
+		return nil, status.Error(codes.PermissionDenied, "seat hold belongs to another user")
+	}
+	if err := tm.releaseHold(hold); err != nil {
+		tm.Logger.Error("ReleaseHold failed to release seat",
+			zap.String("hold_id", req.HoldId),
+			zap.Error(err),
+		)
+		return nil, seatError(err)
+	}
+
+	tm.Logger.Info("ReleaseHold successful",
+		zap.String("hold_id", req.HoldId),
+		zap.String("email", hold.Email),
+	)
+	return &pb.ReleaseHoldResponse{Message: "Seat hold released"}, nil
+}
+
+// GetExpiringHolds lists the held seats whose hold expires within the given
+// window, soonest first, so seat maps can count them down. Holds don't say who
+// made them.
+func (tm *TicketManager) GetExpiringHolds(ctx context.Context, req *pb.GetExpiringHoldsRequest) (*pb.GetExpiringHoldsResponse, error) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	tm.Logger.Info("GetExpiringHolds request received")
+
+	// Validate the request
+	if req == nil {
+		tm.Logger.Error("GetExpiringHolds request is nil")
+		return nil, status.Error(codes.InvalidArgument, "request is nil")
+	}
+	if req.WithinSeconds <= 0 {
+		tm.Logger.Error("GetExpiringHolds invalid window",
+			zap.Int32("within_seconds", req.WithinSeconds),
+		)
+		return nil, status.Error(codes.InvalidArgument, "window must be positive")
+	}
+
+	tm.Logger.Info("GetExpiringHolds request",
+		zap.Int32("within_seconds", req.WithinSeconds),
+		zap.Time("timestamp", tm.Now()),
+	)
+
+	now := tm.Now().Unix()
+	holds := make([]*pb.ExpiringHold, 0)
+	for _, hold := range tm.holds {
+		// Holds already due are released by the next sweep
+		remaining := hold.ExpiresAt - now
+		if remaining <= 0 || remaining > int64(req.WithinSeconds) {
+			continue
+		}
+		holds = append(holds, &pb.ExpiringHold{
+			Seat:             hold.Seat,
+			TrainId:          hold.TrainId,
+			DepartureDate:    hold.DepartureDate,
+			ExpiresAt:        hold.ExpiresAt,
+			RemainingSeconds: int32(remaining),
+		})
+	}
+	sort.Slice(holds, func(i, j int) bool {
+		if holds[i].ExpiresAt != holds[j].ExpiresAt {
+			return holds[i].ExpiresAt < holds[j].ExpiresAt
+		}
+		if holds[i].Seat.Section != holds[j].Seat.Section {
+			return holds[i].Seat.Section < holds[j].Seat.Section
+		}
+		return holds[i].Seat.SeatNumber < holds[j].Seat.SeatNumber
+	})
+
+	tm.Logger.Info("GetExpiringHolds successful",
+		zap.Int("hold_count", len(holds)),
+	)
+	return &pb.GetExpiringHoldsResponse{Holds: holds}, nil
+}
+
+// checkHold verifies that a purchase's hold is active and was made by the
+// same user for the same journey. Callers must hold tm.mu.
+func (tm *TicketManager) checkHold(req *pb.PurchaseTicketRequest, now time.Time) error {
+	tm.expireHolds(now)
+	hold, exists := tm.holds[req.HoldId]
+	if !exists {
+		return status.Error(codes.NotFound, "seat hold not found")
+	}
+	if hold.Email != req.User.Email || hold.From != req.From || hold.To != req.To || hold.TrainId != req.TrainId || hold.DepartureDate != req.DepartureDate {
+		return status.Error(codes.FailedPrecondition, "seat hold is for another user or journey")
+	}
+	return nil
+}
+
+// takeHold ends a purchase's hold and returns its seat, which stays occupied
+// for the booking. Dry runs leave the hold in place. Callers must hold tm.mu
+// and have checked the hold with checkHold.
+func (tm *TicketManager) takeHold(req *pb.PurchaseTicketRequest) (string, int) {
+	hold := tm.holds[req.HoldId]
+	if !req.DryRun {
+		tm.forgetHold(hold)
+	}
+	return hold.Seat.Section, int(hold.Seat.SeatNumber)
+}
+
+// expireHolds releases the seats of holds that expired at or before now.
+// Callers must hold tm.mu.
+func (tm *TicketManager) expireHolds(now time.Time) int {
+	expired := 0
+	for _, hold := range tm.holds {
+		if hold.ExpiresAt > now.Unix() {
+			continue
+		}
+		if err := tm.releaseHold(hold); err != nil {
+			tm.Logger.Error("Failed to expire seat hold",
+				zap.String("hold_id", hold.HoldId),
+				zap.Error(err),
+			)
+			continue
+		}
+		tm.Logger.Info("Seat hold expired",
+			zap.String("hold_id", hold.HoldId),
+			zap.String("email", hold.Email),
+			zap.String("section", hold.Seat.Section),
+			zap.Int32("seat_number", hold.Seat.SeatNumber),
+		)
+		expired++
+	}
+	return expired
+}
+
+// ExpireHolds releases the seats of holds that expired at or before now and
+// returns how many were released.
+func (tm *TicketManager) ExpireHolds(now time.Time) int {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	return tm.expireHolds(now)
+}
+
+// WatchHolds releases expired seat holds every interval until ctx is done.
+func (tm *TicketManager) WatchHolds(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			tm.ExpireHolds(tm.Now())
+		}
+	}
+}
+
+// ReleaseHolds frees the seats of every active hold.
+func (tm *TicketManager) ReleaseHolds() {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	for _, hold := range tm.holds {
+		if err := tm.releaseHold(hold); err != nil {
+			tm.Logger.Error("Failed to release seat hold",
+				zap.String("hold_id", hold.HoldId),
+				zap.Error(err),
+			)
+		}
+	}
+}
+
+// releaseHold frees a held seat and ends the hold. Callers must hold tm.mu.
+func (tm *TicketManager) releaseHold(hold *pb.SeatHold) error {
+	journey, err := tm.SeatManager.Journey(hold.From, hold.To)
+	if err != nil {
+		return err
+	}
+	if err := tm.departure(hold.TrainId, hold.DepartureDate).ReleaseSeat(hold.Seat.Section, int(hold.Seat.SeatNumber), journey); err != nil {
+		return err
+	}
+	tm.forgetHold(hold)
+	return nil
+}
+
+// forgetHold ends a hold without touching its seat. Callers must hold tm.mu.
+func (tm *TicketManager) forgetHold(hold *pb.SeatHold) {
+	delete(tm.holds, hold.HoldId)
+	tm.userHolds[hold.Email]--
+	if tm.userHolds[hold.Email] <= 0 {
+		delete(tm.userHolds, hold.Email)
+	}
+}
+
+// newHoldID returns an ID, in the booking reference format, that no active
+// hold uses. Callers must hold tm.mu.
+func (tm *TicketManager) newHoldID() string {
+	for {
+		id := tm.ReferenceFormat.generate(tm.randomIndex)
+		if _, taken := tm.holds[id]; !taken {
+			return id
+		}
+	}
+}
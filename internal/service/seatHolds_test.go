@@ -0,0 +1,230 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+)
+
+// createHoldingTicketManager returns a test ticket manager whose seat holds
+// last 5 minutes from its fixed clock, with at most maxHolds per user.
+func createHoldingTicketManager(maxHolds int) *TicketManager {
+	tm := createTestTicketManager()
+	tm.HoldDuration = 5 * time.Minute
+	tm.MaxHoldsPerUser = maxHolds
+	tm.Clock = NewFakeClock(time.Date(2024, 3, 10, 9, 0, 0, 0, time.UTC))
+	return tm
+}
+
+// holdSeat holds a seat in section A from London to France.
+func holdSeat(tm *TicketManager, email string, seat int32) (*pb.HoldSeatResponse, error) {
+	return tm.HoldSeat(context.Background(), &pb.HoldSeatRequest{
+		Email: email,
+		From:  "London",
+		To:    "France",
+		Seat:  &pb.Seat{Section: "A", SeatNumber: seat},
+	})
+}
+
+func TestHoldSeat(t *testing.T) {
+	tm := createHoldingTicketManager(0)
+
+	response, err := holdSeat(tm, "test@example.com", 7)
+	assert.NoError(t, err)
+	assert.Equal(t, &pb.Seat{Section: "A", SeatNumber: 7}, response.Hold.Seat)
+	assert.Equal(t, tm.Now().Add(5*time.Minute).Unix(), response.Hold.ExpiresAt)
+	assert.Equal(t, 19, tm.SeatManager.VacantSeats("A"), "Held seats should not be sold to others")
+
+	_, err = holdSeat(tm, "other@example.com", 7)
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+
+	// Booking with the hold takes the held seat
+	purchased, err := tm.PurchaseTicket(context.Background(), &pb.PurchaseTicketRequest{
+		User:   &pb.User{FirstName: "Sanjay", LastName: "Kishor", Email: "test@example.com"},
+		From:   "London",
+		To:     "France",
+		HoldId: response.Hold.HoldId,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, &pb.Seat{Section: "A", SeatNumber: 7}, purchased.Receipt.Seat)
+	assert.Equal(t, 19, tm.SeatManager.VacantSeats("A"))
+	assert.Equal(t, StrategyHold, tm.assignmentTraces[purchased.Receipt.BookingReference].Strategy)
+	assert.Empty(t, tm.holds, "The hold should end once booked")
+	assert.Empty(t, tm.userHolds)
+}
+
+func TestHoldSeatLimit(t *testing.T) {
+	tm := createHoldingTicketManager(3)
+
+	// Hold the limit concurrently
+	var wg sync.WaitGroup
+	for seat := int32(1); seat <= 3; seat++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := holdSeat(tm, "test@example.com", seat)
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	_, err := holdSeat(tm, "test@example.com", 4)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err), "Holds beyond the limit should be rejected")
+	assert.Equal(t, 17, tm.SeatManager.VacantSeats("A"))
+
+	// The limit is per user
+	_, err = holdSeat(tm, "other@example.com", 4)
+	assert.NoError(t, err)
+}
+
+func TestHoldSeatLimitFreedUp(t *testing.T) {
+	tm := createHoldingTicketManager(2)
+	first, err := holdSeat(tm, "test@example.com", 1)
+	assert.NoError(t, err)
+	second, err := holdSeat(tm, "test@example.com", 2)
+	assert.NoError(t, err)
+
+	// Only the user who made a hold may release it
+	_, err = tm.ReleaseHold(context.Background(), &pb.ReleaseHoldRequest{HoldId: first.Hold.HoldId, Email: "other@example.com"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	assert.Contains(t, tm.holds, first.Hold.HoldId)
+
+	// Releasing a hold makes room for another
+	_, err = tm.ReleaseHold(context.Background(), &pb.ReleaseHoldRequest{HoldId: first.Hold.HoldId, Email: "test@example.com"})
+	assert.NoError(t, err)
+	third, err := holdSeat(tm, "test@example.com", 3)
+	assert.NoError(t, err)
+
+	// So does booking with one
+	_, err = tm.PurchaseTicket(context.Background(), &pb.PurchaseTicketRequest{
+		User:   &pb.User{FirstName: "Sanjay", LastName: "Kishor", Email: "test@example.com"},
+		From:   "London",
+		To:     "France",
+		HoldId: second.Hold.HoldId,
+	})
+	assert.NoError(t, err)
+	_, err = holdSeat(tm, "test@example.com", 4)
+	assert.NoError(t, err)
+	_, err = holdSeat(tm, "test@example.com", 5)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	// And expiry
+	assert.Equal(t, 2, tm.ExpireHolds(tm.Now().Add(5*time.Minute)))
+	assert.NotContains(t, tm.holds, third.Hold.HoldId)
+	assert.Equal(t, 19, tm.SeatManager.VacantSeats("A"), "Expired holds should release their seats")
+	_, err = holdSeat(tm, "test@example.com", 5)
+	assert.NoError(t, err)
+}
+
+func TestPurchaseWithInvalidHold(t *testing.T) {
+	tm := createHoldingTicketManager(0)
+	response, err := holdSeat(tm, "test@example.com", 7)
+	assert.NoError(t, err)
+
+	purchaseWithHold := func(email string) error {
+		_, err := tm.PurchaseTicket(context.Background(), &pb.PurchaseTicketRequest{
+			User:   &pb.User{FirstName: "Sanjay", LastName: "Kishor", Email: email},
+			From:   "London",
+			To:     "France",
+			HoldId: response.Hold.HoldId,
+		})
+		return err
+	}
+	assert.Equal(t, codes.FailedPrecondition, status.Code(purchaseWithHold("other@example.com")), "Holds are not transferable")
+
+	// Expired holds can't be booked
+	tm.Clock.(*FakeClock).Advance(10 * time.Minute)
+	assert.Equal(t, codes.NotFound, status.Code(purchaseWithHold("test@example.com")))
+	assert.Equal(t, 20, tm.SeatManager.VacantSeats("A"))
+}
+
+func TestHoldSeatInvalid(t *testing.T) {
+	tm := createHoldingTicketManager(0)
+
+	_, err := tm.HoldSeat(context.Background(), nil)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = tm.HoldSeat(context.Background(), &pb.HoldSeatRequest{Email: "test@example.com", From: "London", To: "France"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = tm.HoldSeat(context.Background(), &pb.HoldSeatRequest{
+		Email: "test@example.com",
+		From:  "London",
+		To:    "France",
+		Seat:  &pb.Seat{Section: "Z", SeatNumber: 1},
+	})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = tm.ReleaseHold(context.Background(), &pb.ReleaseHoldRequest{HoldId: "NOPE42", Email: "test@example.com"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	_, err = tm.ReleaseHold(context.Background(), &pb.ReleaseHoldRequest{HoldId: "NOPE42"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	tm.HoldDuration = 0
+	_, err = holdSeat(tm, "test@example.com", 1)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "Holds should be disabled")
+}
+
+func TestGetExpiringHolds(t *testing.T) {
+	tm := createHoldingTicketManager(0)
+	clock := tm.Clock.(*FakeClock)
+	start := clock.Now()
+
+	// Hold seats 1 to 4 a minute apart, so they expire 5 to 8 minutes from start
+	for seat := int32(1); seat <= 4; seat++ {
+		clock.Set(start.Add(time.Duration(seat-1) * time.Minute))
+		_, err := holdSeat(tm, "test@example.com", seat)
+		assert.NoError(t, err)
+	}
+
+	// Seat 1 is already due, and of the rest only seats 2 and 3 expire
+	// within two minutes
+	clock.Set(start.Add(5*time.Minute + 30*time.Second))
+	response, err := tm.GetExpiringHolds(context.Background(), &pb.GetExpiringHoldsRequest{WithinSeconds: 120})
+	assert.NoError(t, err)
+	assert.Len(t, response.Holds, 2)
+	assert.Equal(t, &pb.Seat{Section: "A", SeatNumber: 2}, response.Holds[0].Seat)
+	assert.Equal(t, int32(30), response.Holds[0].RemainingSeconds)
+	assert.Equal(t, start.Add(6*time.Minute).Unix(), response.Holds[0].ExpiresAt)
+	assert.Equal(t, &pb.Seat{Section: "A", SeatNumber: 3}, response.Holds[1].Seat)
+	assert.Equal(t, int32(90), response.Holds[1].RemainingSeconds)
+
+	// Holds past their expiry are left out until the sweep releases them
+	clock.Set(start.Add(6 * time.Minute))
+	response, err = tm.GetExpiringHolds(context.Background(), &pb.GetExpiringHoldsRequest{WithinSeconds: 60})
+	assert.NoError(t, err)
+	assert.Len(t, response.Holds, 1)
+	assert.Equal(t, int32(3), response.Holds[0].Seat.SeatNumber)
+}
+
+func TestGetExpiringHoldsInvalid(t *testing.T) {
+	tm := createHoldingTicketManager(0)
+
+	_, err := tm.GetExpiringHolds(context.Background(), nil)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = tm.GetExpiringHolds(context.Background(), &pb.GetExpiringHoldsRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	response, err := tm.GetExpiringHolds(context.Background(), &pb.GetExpiringHoldsRequest{WithinSeconds: 60})
+	assert.NoError(t, err)
+	assert.Empty(t, response.Holds)
+}
+
+func TestShutdownReleasesHolds(t *testing.T) {
+	tm := createHoldingTicketManager(0)
+	_, err := holdSeat(tm, "test@example.com", 7)
+	assert.NoError(t, err)
+
+	_, err = tm.Shutdown(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 20, tm.SeatManager.VacantSeats("A"), "Holds should not outlive the server")
+	assert.Empty(t, tm.holds)
+}
@@ -2,7 +2,9 @@ package service
 
 import (
 	"fmt"
+	"slices"
 	"sync"
+	"time"
 
 	"go.uber.org/zap"
 	"github.com/sanjaykishor/rail-connect/internal/config"
@@ -16,12 +18,64 @@ type Section struct {
 	Seats        map[int]*Seat
 	VacantSeats  int  // Track number of vacant seats
 	FirstVacant  int  // Track first vacant seat for faster lookup
+	LastVacant   int  // Track last vacant seat for descending sections
+	Descending   bool // Hand out the highest numbered vacant seat first
 }
 
 // Seat represents an individual seat within a section
 type Seat struct {
 	Number    int
-	Available bool
+	Available bool   // True while no segment of the route is booked
+	Blocked   bool   // Out of order; never assigned and never released
+	booked    uint64 // Bitmask of booked route segments
+}
+
+// maxRouteSegments is the number of segments a seat can track.
+const maxRouteSegments = 64
+
+// Journey is a range of the route from station index From to station index To,
+// covering segments From through To-1. The zero Journey covers the whole route.
+type Journey struct {
+	From int
+	To   int
+}
+
+// WholeRoute books every segment of the route.
+var WholeRoute = Journey{}
+
+// segments returns the bitmask of route segments covered by the journey.
+func (j Journey) segments() uint64 {
+	if j.To <= j.From {
+		return ^uint64(0)
+	}
+	return (^uint64(0) >> (maxRouteSegments - (j.To - j.From))) << j.From
+}
+
+// bookedSegments returns the segments booked on the seat. A seat marked
+// unavailable without segment details is booked for the whole route.
+func (s *Seat) bookedSegments() uint64 {
+	if s.Available {
+		return 0
+	}
+	if s.booked == 0 || s.Blocked {
+		return WholeRoute.segments()
+	}
+	return s.booked
+}
+
+// isFree reports whether none of the journey's segments are booked on the seat.
+func (s *Seat) isFree(journey Journey) bool {
+	return s.bookedSegments()&journey.segments() == 0
+}
+
+// isBooked reports whether all of the journey's segments are booked on the seat.
+// Blocked seats are never booked.
+func (s *Seat) isBooked(journey Journey) bool {
+	if s.Blocked {
+		return false
+	}
+	mask := journey.segments()
+	return s.bookedSegments()&mask == mask
 }
 
 // SeatManager manages seat assignments across multiple sections
@@ -29,39 +83,81 @@ type SeatManager struct {
 	Sections       map[string]*Section
 	SectionOrder   []string           // Maintains section order for round robin
 	nextSectionIdx int                // Next section index for round-robin assignments
-	mu             sync.Mutex        
+	mu             sync.RWMutex       // Read-only lookups take the read lock
 	Logger         *zap.Logger
+	DefaultSection string             // Section filled first before round-robin, if set
+	Route          []string           // Ordered stations; seats are booked per segment between them
+	Positions      SeatPositions      // Fills seats by position within a row, if set
+	RowLayout      string             // Position of each seat across a row, e.g. "WAAW"; empty when there is none
+
+	// AssignLockTimeout bounds how long AssignSeat, AssignSeatInSection and
+	// AssignPair wait for the lock, and PurchaseTicket for the booking lock
+	// before them; zero waits indefinitely
+	AssignLockTimeout time.Duration
+
+	// Clock measures AssignLockTimeout. It defaults to SystemClock; a stopped
+	// FakeClock never times out.
+	Clock Clock
+
+	overflowPolicies map[string]string       // Configured overflow policy of each section
+	sectionPrices    map[string]SectionPrice // Configured fare adjustment of each section
+	restricted       map[string]bool         // Sections never assigned to the public
+	quiesced         map[string]bool         // Sections taking no new assignments, see Quiesce
+
+	AlertThreshold   float64        // Share of seats occupied that raises an occupancy alert; zero disables
+	OnOccupancyAlert OccupancyAlert // Told when occupancy crosses AlertThreshold
+	alertRaised      bool           // Whether occupancy is at or above AlertThreshold
+
+	watchers map[chan struct{}]struct{} // Woken whenever seats change, see Watch
+
+	// SectionsScanned counts how many sections AssignSeat looked at to place
+	// each rider; it rises as the train fills. Dated departures share it.
+	SectionsScanned *Histogram
 }
 
 // NewSeatManager creates a new SeatManager with the specified sections
 func NewSeatManager(sections []config.SectionConfig, logger *zap.Logger) *SeatManager {
 	seatManager := &SeatManager{
-		Sections:       make(map[string]*Section),
-		SectionOrder:   make([]string, len(sections)),
-		nextSectionIdx: 0,
-		Logger:         logger,
+		Sections:         make(map[string]*Section),
+		SectionOrder:     make([]string, len(sections)),
+		nextSectionIdx:   0,
+		Logger:           logger,
+		overflowPolicies: make(map[string]string),
+		sectionPrices:    make(map[string]SectionPrice),
+		restricted:       make(map[string]bool),
+		quiesced:         make(map[string]bool),
+		OnOccupancyAlert: NopOccupancyAlert,
+		SectionsScanned:  NewHistogram(),
+		Clock:            SystemClock{},
 	}
 
 	for i, sectionConfig := range sections {
-		section := &Section{
-			Name:        sectionConfig.Name,
-			MaxSeats:    sectionConfig.MaxSeats,
-			Seats:       make(map[int]*Seat),
-			VacantSeats: sectionConfig.MaxSeats,
-			FirstVacant: 1, // Initially, the first seat is vacant
-		}
-
-		for j := 1; j <= sectionConfig.MaxSeats; j++ {
-			section.Seats[j] = &Seat{
-				Number:    j,
-				Available: true,
+		section := newSection(sectionConfig.Name, sectionConfig.MaxSeats)
+		section.Descending = sectionConfig.FillDirection == config.FillDescending
+		for _, seatNum := range sectionConfig.BlockedSeats {
+			if !section.block(seatNum) {
+				logger.Warn("Blocked seat not in section",
+					zap.String("section", sectionConfig.Name),
+					zap.Int("seat_number", seatNum))
 			}
 		}
 
 		seatManager.Sections[sectionConfig.Name] = section
 		seatManager.SectionOrder[i] = sectionConfig.Name
+		if sectionConfig.OverflowPolicy != "" {
+			seatManager.overflowPolicies[sectionConfig.Name] = sectionConfig.OverflowPolicy
+		}
+		if price := newSectionPrice(sectionConfig); price != (SectionPrice{}) {
+			seatManager.sectionPrices[sectionConfig.Name] = price
+		}
+		if sectionConfig.Restricted {
+			seatManager.restricted[sectionConfig.Name] = true
+		}
 	}
 
+	// Catch bookkeeping bugs before the first booking relies on it
+	seatManager.Verify(true)
+
 	seatManager.Logger.Info("SeatManager initialized", 
 		zap.Int("sections", len(sections)),
 		zap.Strings("sectionNames", seatManager.SectionOrder))
@@ -69,94 +165,456 @@ func NewSeatManager(sections []config.SectionConfig, logger *zap.Logger) *SeatMa
 	return seatManager
 }
 
-// AssignSeat assigns a seat using round-robin algorithm across sections
-func (sm *SeatManager) AssignSeat() (string, int, error) {
-	sm.mu.Lock()
+// newSection creates a section with every seat vacant. The seats are laid out
+// in one slice that the Seats map points into, so a large section costs a
+// couple of allocations rather than one per seat.
+func newSection(name string, maxSeats int) *Section {
+	section := &Section{
+		Name:        name,
+		MaxSeats:    maxSeats,
+		Seats:       make(map[int]*Seat, maxSeats),
+		VacantSeats: maxSeats,
+		FirstVacant: 1, // Initially, the first seat is vacant
+		LastVacant:  maxSeats,
+	}
+
+	seats := make([]Seat, maxSeats)
+	for j := range seats {
+		seats[j] = Seat{
+			Number:    j + 1,
+			Available: true,
+		}
+		section.Seats[j+1] = &seats[j]
+	}
+	return section
+}
+
+// Journey returns the part of the route between two stations. Without a
+// configured route every booking covers the whole route.
+func (sm *SeatManager) Journey(from, to string) (Journey, error) {
+	if len(sm.Route) == 0 {
+		return WholeRoute, nil
+	}
+
+	fromIdx, toIdx := -1, -1
+	for i, station := range sm.Route {
+		if station == from {
+			fromIdx = i
+		}
+		if station == to {
+			toIdx = i
+		}
+	}
+
+	if fromIdx < 0 || toIdx < 0 || fromIdx >= toIdx {
+		return WholeRoute, fmt.Errorf("%w: %s to %s", ErrJourneyNotOnRoute, from, to)
+	}
+	return Journey{From: fromIdx, To: toIdx}, nil
+}
+
+// AssignSeat assigns a seat for the journey, preferring a seat already booked
+// for other segments, then using round-robin across sections. Both passes start
+// at the round-robin index, which advances on every assignment, so concurrent
+// bookings spread evenly instead of piling into the first section.
+func (sm *SeatManager) AssignSeat(journey Journey) (string, int, error) {
+	placement, err := sm.Place(journey)
+	return placement.Section, placement.Seat, err
+}
+
+// Placement records where AssignSeat put a rider and how it chose the seat.
+type Placement struct {
+	Section string
+	Seat    int
+	// Strategy is StrategySharedSeat, StrategyDefaultSection or
+	// StrategyRoundRobin.
+	Strategy string
+	// Skipped is how many sections were passed over before Section.
+	Skipped int
+}
+
+// Place assigns a seat like AssignSeat, reporting how it was chosen.
+func (sm *SeatManager) Place(journey Journey) (Placement, error) {
+	if err := sm.lockForAssignment(); err != nil {
+		return Placement{Seat: -1}, err
+	}
 	defer sm.mu.Unlock()
+	defer sm.seatsChanged()
 	
 	// Try each section once, starting from nextSectionIdx
 	totalSections := len(sm.SectionOrder)
 	if totalSections == 0 {
-		return "", -1, fmt.Errorf("no available sections")
+		return Placement{Seat: -1}, fmt.Errorf("%w: no sections configured", ErrNoSeatsAvailable)
+	}
+
+	// Reuse a seat booked for other segments before taking a vacant one
+	if section, seatNum, skipped, ok := sm.rotate(func(section *Section) (int, bool) {
+		return findSharedSeat(section, journey)
+	}); ok {
+		section.occupy(seatNum, journey)
+		sm.SectionsScanned.Observe(skipped + 1)
+		sm.Logger.Info("Seat assigned for journey segments",
+			zap.String("section", section.Name),
+			zap.Int("seat_number", seatNum),
+			zap.Int("from_station", journey.From),
+			zap.Int("to_station", journey.To),
+			zap.Int("sections_scanned", skipped+1))
+		return Placement{section.Name, seatNum, StrategySharedSeat, skipped}, nil
 	}
 	
+	// Fill the default section first, if one is configured
+	skipped := 0
+	if section, exists := sm.Sections[sm.DefaultSection]; exists && !sm.quiesced[section.Name] {
+		if seatNum, ok := sm.takeFirstVacant(section, journey); ok {
+			sm.SectionsScanned.Observe(1)
+			sm.Logger.Info("Seat assigned in default section",
+				zap.String("section", section.Name),
+				zap.Int("seat_number", seatNum),
+				zap.Int("remaining_vacant", section.VacantSeats),
+				zap.Int("sections_scanned", 1))
+			return Placement{section.Name, seatNum, StrategyDefaultSection, 0}, nil
+		}
+		skipped++
+	}
+
 	// Try sections in round-robin order
+	if section, seatNum, passed, ok := sm.rotate(func(section *Section) (int, bool) {
+		return sm.takeFirstVacant(section, journey)
+	}); ok {
+		scanned := skipped + passed + 1
+		sm.SectionsScanned.Observe(scanned)
+		sm.Logger.Info("Seat assigned via round-robin",
+			zap.String("section", section.Name),
+			zap.Int("seat_number", seatNum),
+			zap.Int("remaining_vacant", section.VacantSeats),
+			zap.Int("sections_scanned", scanned))
+
+		return Placement{section.Name, seatNum, StrategyRoundRobin, skipped + passed}, nil
+	}
+	
+	sm.Logger.Warn("No available seats in any section")
+	return Placement{Seat: -1}, ErrNoSeatsAvailable
+}
+
+// rotate offers each section accepting new riders to pick in round-robin order,
+// starting from nextSectionIdx, and advances the index past the section that
+// yields a seat so the next request starts at the following section. It also
+// returns how many sections were passed over. Callers must hold sm.mu.
+func (sm *SeatManager) rotate(pick func(section *Section) (int, bool)) (*Section, int, int, bool) {
+	totalSections := len(sm.SectionOrder)
 	for i := 0; i < totalSections; i++ {
 		currentIdx := (sm.nextSectionIdx + i) % totalSections
-		sectionName := sm.SectionOrder[currentIdx]
-		section := sm.Sections[sectionName]
-		
-		// Skip if no vacant seats
-		if section.VacantSeats <= 0 {
+		section := sm.Sections[sm.SectionOrder[currentIdx]]
+		if !sm.acceptsNew(section.Name) {
 			continue
 		}
-		
-		// Find the first available seat
-		seatNum := section.FirstVacant
-		for seatNum <= section.MaxSeats {
-			seat, exists := section.Seats[seatNum]
-			if exists && seat.Available {
-				// Found a seat - assign it
-				seat.Available = false
-				section.VacantSeats--
-				
-				// Update first vacant seat pointer
-				section.FirstVacant = seatNum + 1
-				for section.FirstVacant <= section.MaxSeats {
-					if s, ex := section.Seats[section.FirstVacant]; ex && s.Available {
-						break
-					}
-					section.FirstVacant++
-				}
-				
-				// Update next section for round-robin
-				sm.nextSectionIdx = (currentIdx + 1) % totalSections
-				
-				sm.Logger.Info("Seat assigned via round-robin",
-					zap.String("section", section.Name),
-					zap.Int("seat_number", seat.Number),
-					zap.Int("remaining_vacant", section.VacantSeats))
-					
-				return section.Name, seat.Number, nil
-			}
-			seatNum++
+
+		if seatNum, ok := pick(section); ok {
+			sm.nextSectionIdx = (currentIdx + 1) % totalSections
+			return section, seatNum, i, true
 		}
-		
+	}
+	return nil, -1, totalSections, false
+}
+
+// AssignSeatInSection assigns a seat for the journey in the named section,
+// preferring a seat already booked for other segments. Restricted and
+// quiesced sections are refused.
+func (sm *SeatManager) AssignSeatInSection(sectionName string, journey Journey) (int, error) {
+	if err := sm.lockForAssignment(); err != nil {
+		return -1, err
+	}
+	defer sm.mu.Unlock()
+	defer sm.seatsChanged()
+
+	section, exists := sm.Sections[sectionName]
+	if !exists {
+		return -1, fmt.Errorf("%w: %s", ErrSectionNotFound, sectionName)
+	}
+	if sm.restricted[sectionName] {
+		return -1, fmt.Errorf("%w: %s", ErrSectionRestricted, sectionName)
+	}
+	if sm.quiesced[sectionName] {
+		return -1, fmt.Errorf("%w: %s", ErrSectionQuiesced, sectionName)
+	}
+
+	seatNum, ok := findSharedSeat(section, journey)
+	if ok {
+		section.occupy(seatNum, journey)
+	} else {
+		seatNum, ok = sm.takeFirstVacant(section, journey)
+	}
+	if !ok {
+		return -1, fmt.Errorf("%w: section %s", ErrNoSeatsAvailable, sectionName)
+	}
+
+	sm.Logger.Info("Seat assigned in section",
+		zap.String("section", section.Name),
+		zap.Int("seat_number", seatNum),
+		zap.Int("remaining_vacant", section.VacantSeats))
+
+	return seatNum, nil
+}
+
+// takeFirstVacant books the journey on the next vacant seat in the section and
+// returns its number. Callers must hold sm.mu.
+func (sm *SeatManager) takeFirstVacant(section *Section, journey Journey) (int, bool) {
+	// Skip if no vacant seats
+	if section.VacantSeats <= 0 {
+		return -1, false
+	}
+
+	seatNum, ok := sm.firstVacant(section)
+	if !ok {
 		// there was an inconsistency - fix the count
 		section.VacantSeats = 0
+		return -1, false
 	}
-	
-	sm.Logger.Warn("No available seats in any section")
-	return "", -1, fmt.Errorf("no available seats")
+
+	// Found a seat - assign it
+	section.occupy(seatNum, journey)
+
+	return seatNum, true
 }
 
-// ReleaseSeat releases a previously assigned seat
-func (sm *SeatManager) ReleaseSeat(sectionName string, seatNumber int) error {
+// occupy books the journey's segments on a seat, updating the vacancy count and
+// first vacant pointer if the seat was vacant.
+func (section *Section) occupy(seatNum int, journey Journey) {
+	seat := section.Seats[seatNum]
+	seat.booked = seat.bookedSegments() | journey.segments()
+	if seat.Available {
+		seat.Available = false
+		section.VacantSeats--
+		section.vacancyChanged(seatNum)
+	}
+}
+
+// release frees the journey's segments on a seat, making the seat vacant again
+// once no segment remains booked.
+func (section *Section) release(seatNum int, journey Journey) {
+	seat := section.Seats[seatNum]
+	seat.booked = seat.bookedSegments() &^ journey.segments()
+	if seat.booked != 0 || seat.Available {
+		return
+	}
+
+	seat.Available = true
+	section.VacantSeats++
+	section.vacancyChanged(seatNum)
+}
+
+// vacancyChanged moves FirstVacant and LastVacant after seatNum alone was
+// taken or freed. Seats below the lower of FirstVacant and seatNum, and above
+// the higher of LastVacant and seatNum, can't have become vacant, so only the
+// seats between are rescanned.
+func (section *Section) vacancyChanged(seatNum int) {
+	first := min(section.FirstVacant, seatNum)
+	section.FirstVacant = section.MaxSeats + 1
+	for n := first; n <= section.MaxSeats; n++ {
+		if seat, exists := section.Seats[n]; exists && seat.Available {
+			section.FirstVacant = n
+			break
+		}
+	}
+
+	last := min(max(section.LastVacant, seatNum), section.MaxSeats)
+	section.LastVacant = 0
+	for n := last; n >= 1; n-- {
+		if seat, exists := section.Seats[n]; exists && seat.Available {
+			section.LastVacant = n
+			break
+		}
+	}
+}
+
+// recomputeFirstVacant points FirstVacant at the lowest and LastVacant at the
+// highest vacant seat in the section, or at MaxSeats+1 and 0 when none is
+// vacant, scanning the whole section. It is for changes to many seats at once,
+// such as resizing or restoring a section; occupy and release move the
+// pointers with vacancyChanged.
+func recomputeFirstVacant(section *Section) {
+	section.FirstVacant = section.MaxSeats + 1
+	section.LastVacant = 0
+	for seatNum := 1; seatNum <= section.MaxSeats; seatNum++ {
+		if seat, exists := section.Seats[seatNum]; exists && seat.Available {
+			section.FirstVacant = seatNum
+			break
+		}
+	}
+	for seatNum := section.MaxSeats; seatNum >= 1; seatNum-- {
+		if seat, exists := section.Seats[seatNum]; exists && seat.Available {
+			section.LastVacant = seatNum
+			break
+		}
+	}
+}
+
+// scanOrder returns the seat numbers a search for a vacant seat starts and
+// ends at, and the step between them, following the section's fill
+// direction. Loop while seatNum*step <= last*step.
+func (section *Section) scanOrder() (first, last, step int) {
+	if section.Descending {
+		return section.LastVacant, 1, -1
+	}
+	return section.FirstVacant, section.MaxSeats, 1
+}
+
+// findSharedSeat returns the first seat in the section's fill direction that is
+// booked for other segments but free for the journey, without changing any
+// state.
+func findSharedSeat(section *Section, journey Journey) (int, bool) {
+	// A whole-route journey cannot share a seat
+	if journey == WholeRoute {
+		return -1, false
+	}
+
+	first, last, step := 1, section.MaxSeats, 1
+	if section.Descending {
+		first, last, step = section.MaxSeats, 1, -1
+	}
+	for seatNum := first; seatNum*step <= last*step; seatNum += step {
+		if seat, exists := section.Seats[seatNum]; exists && !seat.Available && seat.isFree(journey) {
+			return seatNum, true
+		}
+	}
+	return -1, false
+}
+
+// findFirstVacant returns the first vacant seat in the section's fill
+// direction without changing any state. Callers must hold sm.mu.
+func findFirstVacant(section *Section) (int, bool) {
+	if section.VacantSeats <= 0 {
+		return -1, false
+	}
+
+	first, last, step := section.scanOrder()
+	for seatNum := first; seatNum*step <= last*step; seatNum += step {
+		if seat, exists := section.Seats[seatNum]; exists && seat.Available {
+			return seatNum, true
+		}
+	}
+	return -1, false
+}
+
+// PeekSeat reports the seat AssignSeat would hand out next without assigning it.
+func (sm *SeatManager) PeekSeat(journey Journey) (string, int, error) {
+	placement, err := sm.PeekPlace(journey)
+	return placement.Section, placement.Seat, err
+}
+
+// PeekPlace reports the placement Place would make next without assigning the
+// seat.
+func (sm *SeatManager) PeekPlace(journey Journey) (Placement, error) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	totalSections := len(sm.SectionOrder)
+	for i := 0; i < totalSections; i++ {
+		section := sm.Sections[sm.SectionOrder[(sm.nextSectionIdx+i)%totalSections]]
+		if !sm.acceptsNew(section.Name) {
+			continue
+		}
+		if seatNum, ok := findSharedSeat(section, journey); ok {
+			return Placement{section.Name, seatNum, StrategySharedSeat, i}, nil
+		}
+	}
+
+	skipped := 0
+	if section, exists := sm.Sections[sm.DefaultSection]; exists && !sm.quiesced[section.Name] {
+		if seatNum, ok := sm.firstVacant(section); ok {
+			return Placement{section.Name, seatNum, StrategyDefaultSection, 0}, nil
+		}
+		skipped++
+	}
+
+	for i := 0; i < totalSections; i++ {
+		section := sm.Sections[sm.SectionOrder[(sm.nextSectionIdx+i)%totalSections]]
+		if !sm.acceptsNew(section.Name) {
+			continue
+		}
+		if seatNum, ok := sm.firstVacant(section); ok {
+			return Placement{section.Name, seatNum, StrategyRoundRobin, skipped + i}, nil
+		}
+	}
+
+	return Placement{Seat: -1}, ErrNoSeatsAvailable
+}
+
+// PeekSeatInSection reports the seat AssignSeatInSection would hand out next
+// without assigning it.
+func (sm *SeatManager) PeekSeatInSection(sectionName string, journey Journey) (int, error) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	section, exists := sm.Sections[sectionName]
+	if !exists {
+		return -1, fmt.Errorf("%w: %s", ErrSectionNotFound, sectionName)
+	}
+	if sm.restricted[sectionName] {
+		return -1, fmt.Errorf("%w: %s", ErrSectionRestricted, sectionName)
+	}
+	if sm.quiesced[sectionName] {
+		return -1, fmt.Errorf("%w: %s", ErrSectionQuiesced, sectionName)
+	}
+
+	seatNum, ok := findSharedSeat(section, journey)
+	if !ok {
+		seatNum, ok = sm.firstVacant(section)
+	}
+	if !ok {
+		return -1, fmt.Errorf("%w: section %s", ErrNoSeatsAvailable, sectionName)
+	}
+	return seatNum, nil
+}
+
+// ReleaseAll frees every seat that isn't blocked, for all segments, in one
+// locked pass, e.g. to turn the train around for its next service.
+func (sm *SeatManager) ReleaseAll() {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	defer sm.seatsChanged()
+
+	for _, section := range sm.Sections {
+		section.VacantSeats = 0
+		for seatNum := 1; seatNum <= section.MaxSeats; seatNum++ {
+			seat, exists := section.Seats[seatNum]
+			if !exists || seat.Blocked {
+				continue
+			}
+			seat.Available = true
+			seat.booked = 0
+			section.VacantSeats++
+		}
+		recomputeFirstVacant(section)
+	}
+	sm.Logger.Info("All seats released")
+}
+
+// ReleaseSeat releases a previously assigned seat for the journey
+func (sm *SeatManager) ReleaseSeat(sectionName string, seatNumber int, journey Journey) error {
 	sm.mu.Lock()
 	defer sm.mu.Unlock()
+	defer sm.seatsChanged()
 	
 	section, exists := sm.Sections[sectionName]
 	if !exists {
-		return fmt.Errorf("section %s does not exist", sectionName)
+		return fmt.Errorf("%w: %s", ErrSectionNotFound, sectionName)
 	}
 	
 	seat, exists := section.Seats[seatNumber]
 	if !exists {
-		return fmt.Errorf("seat %d does not exist in section %s", seatNumber, sectionName)
+		return fmt.Errorf("%w: seat %d in section %s", ErrSeatNotFound, seatNumber, sectionName)
 	}
 	
-	if seat.Available {
-		return fmt.Errorf("seat %d is already available in section %s", seatNumber, sectionName)
+	if seat.Blocked {
+		return fmt.Errorf("%w: seat %d in section %s", ErrSeatBlocked, seatNumber, sectionName)
+	}
+
+	if seat.Available || !seat.isBooked(journey) {
+		return fmt.Errorf("%w: seat %d in section %s", ErrSeatAlreadyAvailable, seatNumber, sectionName)
 	}
 	
 	// Update seat status
-	seat.Available = true
-	section.VacantSeats++
-	
-	// Update first vacant pointer if this is now earlier than current pointer
-	if seatNumber < section.FirstVacant {
-		section.FirstVacant = seatNumber
-	}
+	section.release(seatNumber, journey)
 	
 	sm.Logger.Info("Seat released",
 		zap.String("section", section.Name),
@@ -166,62 +624,56 @@ func (sm *SeatManager) ReleaseSeat(sectionName string, seatNumber int) error {
 	return nil
 }
 
-// UpdateSeat changes a user's seat from one to another
-func (sm *SeatManager) UpdateSeat(currSeat int, currSection string, reqSeat int, reqSection string) error {
+// UpdateSeat moves a user's journey from one seat to another
+func (sm *SeatManager) UpdateSeat(currSeat int, currSection string, reqSeat int, reqSection string, journey Journey) error {
 	sm.mu.Lock()
 	defer sm.mu.Unlock()
+	defer sm.seatsChanged()
 	
 	oldSectionObj, oldExists := sm.Sections[currSection]
 	if !oldExists {
-		return fmt.Errorf("section %s does not exist", currSection)
+		return fmt.Errorf("%w: %s", ErrSectionNotFound, currSection)
 	}
 	
 	newSectionObj, newExists := sm.Sections[reqSection]
 	if !newExists {
-		return fmt.Errorf("section %s does not exist", reqSection)
+		return fmt.Errorf("%w: %s", ErrSectionNotFound, reqSection)
 	}
 	
 	oldSeat, oldSeatExists := oldSectionObj.Seats[currSeat]
 	if !oldSeatExists {
-		return fmt.Errorf("seat %d does not exist in section %s", currSeat, currSection)
+		return fmt.Errorf("%w: seat %d in section %s", ErrSeatNotFound, currSeat, currSection)
 	}
 	
-	if oldSeat.Available {
-		return fmt.Errorf("current seat %d in section %s is not occupied", currSeat, currSection)
+	if oldSeat.Available || !oldSeat.isBooked(journey) {
+		return fmt.Errorf("%w: current seat %d in section %s", ErrSeatAlreadyAvailable, currSeat, currSection)
+	}
+
+	// Moving to the seat already held is a no-op
+	if currSection == reqSection && currSeat == reqSeat {
+		sm.Logger.Info("Seat update to the same seat ignored",
+			zap.String("section", currSection),
+			zap.Int("seat_number", currSeat))
+		return nil
 	}
 	
 	newSeat, newSeatExists := newSectionObj.Seats[reqSeat]
 	if !newSeatExists {
-		return fmt.Errorf("requested seat %d does not exist in section %s", reqSeat, reqSection)
-	}
-	
-	if !newSeat.Available {
-		return fmt.Errorf("requested seat %d in section %s is not available", reqSeat, reqSection)
+		return fmt.Errorf("%w: requested seat %d in section %s", ErrSeatNotFound, reqSeat, reqSection)
 	}
 	
-	// Update seats
-	oldSeat.Available = true
-	newSeat.Available = false
-	
-	// Update vacancy counts
-	oldSectionObj.VacantSeats++
-	newSectionObj.VacantSeats--
-	
-	// Update FirstVacant pointers if needed
-	if currSeat < oldSectionObj.FirstVacant {
-		oldSectionObj.FirstVacant = currSeat
+	if newSeat.Blocked {
+		return fmt.Errorf("%w: requested seat %d in section %s", ErrSeatBlocked, reqSeat, reqSection)
 	}
-	if reqSeat == newSectionObj.FirstVacant {
-		// Find next vacant seat
-		newSectionObj.FirstVacant = reqSeat + 1
-		for newSectionObj.FirstVacant <= newSectionObj.MaxSeats {
-			if s, ex := newSectionObj.Seats[newSectionObj.FirstVacant]; ex && s.Available {
-				break
-			}
-			newSectionObj.FirstVacant++
-		}
+
+	if !newSeat.isFree(journey) {
+		return fmt.Errorf("%w: requested seat %d in section %s", ErrSeatOccupied, reqSeat, reqSection)
 	}
 	
+	// Update seats, vacancy counts and FirstVacant pointers
+	oldSectionObj.release(currSeat, journey)
+	newSectionObj.occupy(reqSeat, journey)
+	
 	sm.Logger.Info("Seat updated",
 		zap.String("old_section", oldSectionObj.Name),
 		zap.Int("old_seat", currSeat),
@@ -230,3 +682,489 @@ func (sm *SeatManager) UpdateSeat(currSeat int, currSection string, reqSeat int,
 		
 	return nil
 }
+
+// SwapSeats exchanges the seats booked for two journeys. Each seat must be free
+// for the other journey once its own journey is moved off it.
+func (sm *SeatManager) SwapSeats(sectionA string, seatA int, journeyA Journey, sectionB string, seatB int, journeyB Journey) error {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	defer sm.seatsChanged()
+
+	for _, s := range []struct {
+		section string
+		seat    int
+		journey Journey
+	}{{sectionA, seatA, journeyA}, {sectionB, seatB, journeyB}} {
+		section, exists := sm.Sections[s.section]
+		if !exists {
+			return fmt.Errorf("%w: %s", ErrSectionNotFound, s.section)
+		}
+
+		seat, exists := section.Seats[s.seat]
+		if !exists {
+			return fmt.Errorf("%w: seat %d in section %s", ErrSeatNotFound, s.seat, s.section)
+		}
+
+		if seat.Available || !seat.isBooked(s.journey) {
+			return fmt.Errorf("%w: seat %d in section %s", ErrSeatAlreadyAvailable, s.seat, s.section)
+		}
+	}
+
+	// Whole-route swaps never conflict; partial journeys must fit the other seat
+	sameSeat := sectionA == sectionB && seatA == seatB
+	if !sameSeat {
+		bookedA := sm.Sections[sectionA].Seats[seatA].bookedSegments() &^ journeyA.segments()
+		bookedB := sm.Sections[sectionB].Seats[seatB].bookedSegments() &^ journeyB.segments()
+		if bookedB&journeyA.segments() != 0 {
+			return fmt.Errorf("%w: seat %d in section %s", ErrSeatOccupied, seatB, sectionB)
+		}
+		if bookedA&journeyB.segments() != 0 {
+			return fmt.Errorf("%w: seat %d in section %s", ErrSeatOccupied, seatA, sectionA)
+		}
+
+		sm.Sections[sectionA].release(seatA, journeyA)
+		sm.Sections[sectionB].release(seatB, journeyB)
+		sm.Sections[sectionB].occupy(seatB, journeyA)
+		sm.Sections[sectionA].occupy(seatA, journeyB)
+	}
+
+	sm.Logger.Info("Seats swapped",
+		zap.String("section_a", sectionA),
+		zap.Int("seat_a", seatA),
+		zap.String("section_b", sectionB),
+		zap.Int("seat_b", seatB))
+
+	return nil
+}
+
+// Restricted reports whether the section is never assigned to the public.
+func (sm *SeatManager) Restricted(sectionName string) bool {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	return sm.restricted[sectionName]
+}
+
+// OverflowPolicy returns what should happen to a booking for the section once
+// it is full, one of the config.Overflow policies.
+func (sm *SeatManager) OverflowPolicy(sectionName string) string {
+	if policy, exists := sm.overflowPolicies[sectionName]; exists {
+		return policy
+	}
+	return config.OverflowSpill
+}
+
+// SeatStatus reports whether a seat is vacant for the whole route and whether
+// it is blocked.
+func (sm *SeatManager) SeatStatus(sectionName string, seatNumber int) (available, blocked bool, err error) {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	_, seat, err := sm.seat(sectionName, seatNumber)
+	if err != nil {
+		return false, false, err
+	}
+	return seat.Available, seat.Blocked, nil
+}
+
+// BlockSeat takes a vacant seat out of circulation so it is never assigned.
+func (sm *SeatManager) BlockSeat(sectionName string, seatNumber int) error {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	defer sm.seatsChanged()
+
+	section, seat, err := sm.seat(sectionName, seatNumber)
+	if err != nil {
+		return err
+	}
+
+	if seat.Blocked {
+		return fmt.Errorf("%w: seat %d in section %s", ErrSeatBlocked, seatNumber, sectionName)
+	}
+	if !seat.Available {
+		return fmt.Errorf("%w: seat %d in section %s", ErrSeatOccupied, seatNumber, sectionName)
+	}
+
+	section.block(seatNumber)
+
+	sm.Logger.Info("Seat blocked",
+		zap.String("section", sectionName),
+		zap.Int("seat_number", seatNumber),
+		zap.Int("vacant_seats", section.VacantSeats))
+
+	return nil
+}
+
+// UnblockSeat returns a blocked seat to circulation.
+func (sm *SeatManager) UnblockSeat(sectionName string, seatNumber int) error {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	defer sm.seatsChanged()
+
+	section, seat, err := sm.seat(sectionName, seatNumber)
+	if err != nil {
+		return err
+	}
+
+	if !seat.Blocked {
+		return fmt.Errorf("%w: seat %d in section %s", ErrSeatNotBlocked, seatNumber, sectionName)
+	}
+
+	seat.Blocked = false
+	section.release(seatNumber, WholeRoute)
+
+	sm.Logger.Info("Seat unblocked",
+		zap.String("section", sectionName),
+		zap.Int("seat_number", seatNumber),
+		zap.Int("vacant_seats", section.VacantSeats))
+
+	return nil
+}
+
+// seat looks up a seat by section and number. Callers must hold sm.mu.
+func (sm *SeatManager) seat(sectionName string, seatNumber int) (*Section, *Seat, error) {
+	section, exists := sm.Sections[sectionName]
+	if !exists {
+		return nil, nil, fmt.Errorf("%w: %s", ErrSectionNotFound, sectionName)
+	}
+
+	seat, exists := section.Seats[seatNumber]
+	if !exists {
+		return nil, nil, fmt.Errorf("%w: seat %d in section %s", ErrSeatNotFound, seatNumber, sectionName)
+	}
+	return section, seat, nil
+}
+
+// block marks a vacant seat as out of order, removing it from the vacancy
+// count. It reports false if the seat does not exist or is not vacant.
+func (section *Section) block(seatNum int) bool {
+	seat, exists := section.Seats[seatNum]
+	if !exists || !seat.Available {
+		return false
+	}
+
+	section.occupy(seatNum, WholeRoute)
+	seat.Blocked = true
+	return true
+}
+
+// occupiedFrom returns the occupied seats numbered first or above, in order.
+// Blocked seats are out of order rather than occupied.
+func (section *Section) occupiedFrom(first int) []int {
+	var occupied []int
+	for seatNum := first; seatNum <= section.MaxSeats; seatNum++ {
+		if seat, ok := section.Seats[seatNum]; ok && !seat.Available && !seat.Blocked {
+			occupied = append(occupied, seatNum)
+		}
+	}
+	return occupied
+}
+
+// VacantSeats returns the number of vacant seats in the named section, or 0 if
+// the section does not exist.
+func (sm *SeatManager) VacantSeats(sectionName string) int {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	section, exists := sm.Sections[sectionName]
+	if !exists {
+		return 0
+	}
+	return section.VacantSeats
+}
+
+// SectionVacancy returns the number of vacant seats in a section and its size.
+// It only takes the read lock, so frequent polling doesn't hold up bookings.
+func (sm *SeatManager) SectionVacancy(sectionName string) (vacant, maxSeats int, err error) {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	section, exists := sm.Sections[sectionName]
+	if !exists {
+		return 0, 0, fmt.Errorf("%w: %s", ErrSectionNotFound, sectionName)
+	}
+	return section.VacantSeats, section.MaxSeats, nil
+}
+
+// SectionConfigs returns the current layout of every section in round-robin
+// order, reflecting any resizing and seats blocked since startup.
+func (sm *SeatManager) SectionConfigs() []config.SectionConfig {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	sections := make([]config.SectionConfig, 0, len(sm.SectionOrder))
+	for _, name := range sm.SectionOrder {
+		sections = append(sections, sm.sectionConfig(name))
+	}
+	return sections
+}
+
+// SectionInfo is the current layout and vacancy of a section.
+type SectionInfo struct {
+	config.SectionConfig
+	VacantSeats int
+	Quiesced    bool
+}
+
+// SectionInfos returns the current layout and vacancy of every section in
+// round-robin order, read together under the read lock.
+func (sm *SeatManager) SectionInfos() []SectionInfo {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	sections := make([]SectionInfo, 0, len(sm.SectionOrder))
+	for _, name := range sm.SectionOrder {
+		sections = append(sections, SectionInfo{
+			SectionConfig: sm.sectionConfig(name),
+			VacantSeats:   sm.Sections[name].VacantSeats,
+			Quiesced:      sm.quiesced[name],
+		})
+	}
+	return sections
+}
+
+// sectionConfig returns the current layout of the named section. Callers must
+// hold sm.mu.
+func (sm *SeatManager) sectionConfig(name string) config.SectionConfig {
+	section := sm.Sections[name]
+	blocked := make([]int, 0)
+	for seatNum := 1; seatNum <= section.MaxSeats; seatNum++ {
+		if seat, exists := section.Seats[seatNum]; exists && seat.Blocked {
+			blocked = append(blocked, seatNum)
+		}
+	}
+	return config.SectionConfig{
+		Name:            name,
+		MaxSeats:        section.MaxSeats,
+		BlockedSeats:    blocked,
+		OverflowPolicy:  sm.overflowPolicies[name],
+		PriceMultiplier: sm.sectionPrices[name].Multiplier,
+		Surcharge:       sm.sectionPrices[name].Surcharge,
+		Restricted:      sm.restricted[name],
+		FillDirection:   fillDirection(section),
+	}
+}
+
+// fillDirection returns the configured fill direction of a section, empty for
+// the default ascending order.
+func fillDirection(section *Section) string {
+	if section.Descending {
+		return config.FillDescending
+	}
+	return ""
+}
+
+// FreeSeats returns the number of seats across all sections that are free for
+// every segment of the journey.
+func (sm *SeatManager) FreeSeats(journey Journey) int {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	free := 0
+	for _, section := range sm.Sections {
+		for _, seat := range section.Seats {
+			if seat.isFree(journey) {
+				free++
+			}
+		}
+	}
+	return free
+}
+
+// Occupancy returns the number of occupied seats and the number of seats in
+// circulation across all sections. Blocked seats count towards neither.
+func (sm *SeatManager) Occupancy() (occupied, total int) {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	return sm.occupancy()
+}
+
+// occupancy counts the occupied and circulating seats. Callers must hold sm.mu.
+func (sm *SeatManager) occupancy() (occupied, total int) {
+	for _, section := range sm.Sections {
+		for _, seat := range section.Seats {
+			if seat.Blocked {
+				continue
+			}
+			total++
+			if !seat.Available {
+				occupied++
+			}
+		}
+	}
+	return occupied, total
+}
+
+// ResizeSection changes the number of seats in a section. Growing adds
+// higher-numbered seats; shrinking removes trailing seats and fails if any of
+// them are occupied.
+func (sm *SeatManager) ResizeSection(sectionName string, newMax int) error {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	defer sm.seatsChanged()
+
+	section, err := sm.resizable(sectionName, newMax)
+	if err != nil {
+		return err
+	}
+
+	oldMax := section.MaxSeats
+	for seatNum := newMax + 1; seatNum <= oldMax; seatNum++ {
+		if seat, ok := section.Seats[seatNum]; ok {
+			delete(section.Seats, seatNum)
+			if seat.Available {
+				section.VacantSeats--
+			}
+		}
+	}
+
+	for seatNum := oldMax + 1; seatNum <= newMax; seatNum++ {
+		section.Seats[seatNum] = &Seat{
+			Number:    seatNum,
+			Available: true,
+		}
+		section.VacantSeats++
+	}
+
+	section.MaxSeats = newMax
+	recomputeFirstVacant(section)
+
+	sm.Logger.Info("Section resized",
+		zap.String("section", sectionName),
+		zap.Int("old_max_seats", oldMax),
+		zap.Int("new_max_seats", newMax),
+		zap.Int("vacant_seats", section.VacantSeats))
+
+	return nil
+}
+
+// CheckResize reports the error ResizeSection would return for the same
+// arguments, without changing the section.
+func (sm *SeatManager) CheckResize(sectionName string, newMax int) error {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	_, err := sm.resizable(sectionName, newMax)
+	return err
+}
+
+// resizable looks up a section that can be resized to newMax seats. Callers
+// must hold sm.mu.
+func (sm *SeatManager) resizable(sectionName string, newMax int) (*Section, error) {
+	section, exists := sm.Sections[sectionName]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrSectionNotFound, sectionName)
+	}
+
+	if newMax <= 0 {
+		return nil, fmt.Errorf("section %s must have at least one seat", sectionName)
+	}
+
+	// Only unoccupied trailing seats can be removed
+	if occupied := section.occupiedFrom(newMax + 1); len(occupied) > 0 {
+		return nil, fmt.Errorf("%w: seat %d in section %s", ErrSeatOccupied, occupied[0], sectionName)
+	}
+	return section, nil
+}
+
+// HasSection reports whether the named section exists.
+func (sm *SeatManager) HasSection(sectionName string) bool {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	_, exists := sm.Sections[sectionName]
+	return exists
+}
+
+// AddSection appends a new, empty section to the round-robin order.
+func (sm *SeatManager) AddSection(sectionConfig config.SectionConfig) error {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	defer sm.seatsChanged()
+
+	if _, exists := sm.Sections[sectionConfig.Name]; exists {
+		return fmt.Errorf("section %s already exists", sectionConfig.Name)
+	}
+	if sectionConfig.MaxSeats <= 0 {
+		return fmt.Errorf("section %s must have at least one seat", sectionConfig.Name)
+	}
+
+	section := newSection(sectionConfig.Name, sectionConfig.MaxSeats)
+	section.Descending = sectionConfig.FillDirection == config.FillDescending
+	for _, seatNum := range sectionConfig.BlockedSeats {
+		if !section.block(seatNum) {
+			sm.Logger.Warn("Blocked seat not in section",
+				zap.String("section", sectionConfig.Name),
+				zap.Int("seat_number", seatNum))
+		}
+	}
+	sm.Sections[sectionConfig.Name] = section
+	sm.SectionOrder = append(sm.SectionOrder, sectionConfig.Name)
+	if sectionConfig.OverflowPolicy != "" {
+		sm.overflowPolicies[sectionConfig.Name] = sectionConfig.OverflowPolicy
+	}
+	if price := newSectionPrice(sectionConfig); price != (SectionPrice{}) {
+		sm.sectionPrices[sectionConfig.Name] = price
+	}
+	if sectionConfig.Restricted {
+		sm.restricted[sectionConfig.Name] = true
+	}
+
+	sm.Logger.Info("Section added",
+		zap.String("section", sectionConfig.Name),
+		zap.Int("max_seats", sectionConfig.MaxSeats),
+		zap.Int("vacant_seats", section.VacantSeats))
+
+	return nil
+}
+
+// RemoveSection removes a section from the train. It fails if any of its seats
+// are occupied.
+func (sm *SeatManager) RemoveSection(sectionName string) error {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	defer sm.seatsChanged()
+
+	section, exists := sm.Sections[sectionName]
+	if !exists {
+		return fmt.Errorf("%w: %s", ErrSectionNotFound, sectionName)
+	}
+	if occupied := section.occupiedFrom(1); len(occupied) > 0 {
+		return fmt.Errorf("%w: seat %d in section %s", ErrSeatOccupied, occupied[0], sectionName)
+	}
+
+	idx := slices.Index(sm.SectionOrder, sectionName)
+	sm.SectionOrder = slices.Delete(sm.SectionOrder, idx, idx+1)
+	if idx < sm.nextSectionIdx {
+		sm.nextSectionIdx--
+	}
+	if sm.nextSectionIdx >= len(sm.SectionOrder) {
+		sm.nextSectionIdx = 0
+	}
+	delete(sm.Sections, sectionName)
+	delete(sm.overflowPolicies, sectionName)
+	delete(sm.sectionPrices, sectionName)
+	delete(sm.restricted, sectionName)
+	delete(sm.quiesced, sectionName)
+
+	sm.Logger.Info("Section removed", zap.String("section", sectionName))
+
+	return nil
+}
+
+// FreeSeatUpTo returns the lowest seat numbered at most limit in the section
+// that is free for the journey.
+func (sm *SeatManager) FreeSeatUpTo(sectionName string, limit int, journey Journey) (int, bool) {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	section, exists := sm.Sections[sectionName]
+	if !exists {
+		return 0, false
+	}
+	for seatNum := 1; seatNum <= min(limit, section.MaxSeats); seatNum++ {
+		if section.isFree(seatNum, journey) {
+			return seatNum, true
+		}
+	}
+	return 0, false
+}
@@ -3,6 +3,9 @@ package service
 import (
 	"github.com/sanjaykishor/rail-connect/internal/config"
 	"github.com/stretchr/testify/assert"
+	"fmt"
+	"math/rand"
+	"sync"
 	"testing"
 
 	"go.uber.org/zap"
@@ -43,7 +46,7 @@ func TestAssignSeat(t *testing.T) {
 	seatManager := CreateSeatManager()
 
 	// Assign a seat
-	sectionName, seatNumber, err := seatManager.AssignSeat()
+	sectionName, seatNumber, err := seatManager.AssignSeat(WholeRoute)
 	assert.NoError(t, err, "Should not return an error when assigning a seat")
 	assert.Equal(t, sectionName, "A", "First section in order should be A")
 	assert.Equal(t, seatNumber, 1, "First seat in section A should be assigned")
@@ -52,7 +55,7 @@ func TestAssignSeat(t *testing.T) {
 	assert.Equal(t, seatManager.Sections["A"].Seats[1].Available, false, "First seat in section A should not be available after assignment")
 
 	// Assign another seat
-	sectionName, seatNumber, err = seatManager.AssignSeat()
+	sectionName, seatNumber, err = seatManager.AssignSeat(WholeRoute)
 	assert.NoError(t, err, "Should not return an error when assigning a seat")
 	assert.Equal(t, sectionName, "B", "First section in order should be B")
 	assert.Equal(t, seatNumber, 1, "Second seat in section A should be assigned")
@@ -61,7 +64,7 @@ func TestAssignSeat(t *testing.T) {
 	assert.Equal(t, seatManager.Sections["B"].Seats[1].Available, false, "Second seat in section B should not be available after assignment")
 
 	// Assign another seat
-	sectionName, seatNumber, err = seatManager.AssignSeat()
+	sectionName, seatNumber, err = seatManager.AssignSeat(WholeRoute)
 	assert.NoError(t, err, "Should not return an error when assigning a seat")
 	assert.Equal(t, sectionName, "A", "First section in order should be A")
 	assert.Equal(t, seatNumber, 2, "Second seat in section A should be assigned")
@@ -77,7 +80,7 @@ func TestAssignSeat(t *testing.T) {
 	}
 	seatManager.Sections["A"].FirstVacant = 21
 	// Assign a seat
-	sectionName, seatNumber, err = seatManager.AssignSeat()
+	sectionName, seatNumber, err = seatManager.AssignSeat(WholeRoute)
 	assert.NoError(t, err, "Should not return an error when assigning a seat")
 	assert.Equal(t, sectionName, "B", "First section in order should be B")
 	assert.Equal(t, seatNumber, 2, "Second seat in section B should be assigned")
@@ -92,7 +95,7 @@ func TestAssignSeat(t *testing.T) {
 	}
 	seatManager.Sections["B"].FirstVacant = 21
 	// Assign a seat
-	sectionName, seatNumber, err = seatManager.AssignSeat()
+	sectionName, seatNumber, err = seatManager.AssignSeat(WholeRoute)
 	assert.Error(t, err, "Should return an error when no seats are available")
 	assert.Equal(t, sectionName, "", "Section name should be empty when no seats are available")
 	assert.Equal(t, seatNumber, -1, "Seat number should be -1 when no seats are available")
@@ -125,7 +128,7 @@ func TestReleaseSeat(t *testing.T) {
 		seatManager.Sections[test.sectionName].FirstVacant++
 
 		// Release the seat
-		err := seatManager.ReleaseSeat(test.sectionName, test.seatNumber)
+		err := seatManager.ReleaseSeat(test.sectionName, test.seatNumber, WholeRoute)
 		assert.NoError(t, err, "Should not return an error when releasing a seat")
 
 		// Check the expected values
@@ -135,15 +138,15 @@ func TestReleaseSeat(t *testing.T) {
 	}
 
 	// Test releasing a seat that is already available
-	err := seatManager.ReleaseSeat("A", 1)
+	err := seatManager.ReleaseSeat("A", 1, WholeRoute)
 	assert.Error(t, err, "Should return an error when releasing an already available seat")
 
 	// Test releasing a seat that does not exist
-	err = seatManager.ReleaseSeat("A", 100)
+	err = seatManager.ReleaseSeat("A", 100, WholeRoute)
 	assert.Error(t, err, "Should return an error when releasing a seat that does not exist")
 
 	// Test releasing a seat in a section that does not exist
-	err = seatManager.ReleaseSeat("C", 1)
+	err = seatManager.ReleaseSeat("C", 1, WholeRoute)
 	assert.Error(t, err, "Should return an error when releasing a seat in a section that does not exist")
 }
 
@@ -169,7 +172,7 @@ func TestUpdateSeat(t *testing.T) {
 		seatManager.Sections[test.sectionName].FirstVacant++
 
 		// Update the seat
-		err := seatManager.UpdateSeat(test.seatNumber, test.sectionName, test.newSeatNumber, test.newSectionName)
+		err := seatManager.UpdateSeat(test.seatNumber, test.sectionName, test.newSeatNumber, test.newSectionName, WholeRoute)
 		assert.NoError(t, err, "Should not return an error when updating a seat")
 
 		// Check the expected values
@@ -180,14 +183,468 @@ func TestUpdateSeat(t *testing.T) {
 
 	// Test updating a seat that is already available
 	seatManager.Sections["A"].Seats[1].Available = true
-	err := seatManager.UpdateSeat(1, "A", 1, "B")
+	err := seatManager.UpdateSeat(1, "A", 1, "B", WholeRoute)
 	assert.Error(t, err, "Should return an error when updating an already available seat")
+	assert.ErrorIs(t, err, ErrSeatAlreadyAvailable, "Error should identify the current seat as not occupied")
 
 	// Test updating a seat that does not exist
-	err = seatManager.UpdateSeat(100, "A", 1, "B")
+	err = seatManager.UpdateSeat(100, "A", 1, "B", WholeRoute)
 	assert.Error(t, err, "Should return an error when updating a seat that does not exist")
+	assert.ErrorIs(t, err, ErrSeatNotFound, "Error should identify the missing seat")
 
 	// Test updating a seat in a section that does not exist
-	err = seatManager.UpdateSeat(1, "C", 1, "B")
+	err = seatManager.UpdateSeat(1, "C", 1, "B", WholeRoute)
 	assert.Error(t, err, "Should return an error when updating a seat in a section that does not exist")
+	assert.ErrorIs(t, err, ErrSectionNotFound, "Error should identify the missing section")
+
+	// Test updating to a seat that is occupied
+	seatManager.Sections["A"].Seats[1].Available = false
+	seatManager.Sections["B"].Seats[2].Available = false
+	err = seatManager.UpdateSeat(1, "A", 2, "B", WholeRoute)
+	assert.Error(t, err, "Should return an error when the requested seat is occupied")
+	assert.ErrorIs(t, err, ErrSeatOccupied, "Error should identify the requested seat as occupied")
+}
+
+func TestSwapOccupiedSeats(t *testing.T) {
+	seatManager := CreateSeatManager()
+
+	seatManager.Sections["A"].Seats[1].Available = false
+	seatManager.Sections["B"].Seats[1].Available = false
+
+	// Test swapping two occupied seats
+	err := seatManager.SwapSeats("A", 1, WholeRoute, "B", 1, WholeRoute)
+	assert.NoError(t, err, "Should not return an error when swapping occupied seats")
+	assert.Equal(t, false, seatManager.Sections["A"].Seats[1].Available, "Seat A1 should remain occupied")
+	assert.Equal(t, false, seatManager.Sections["B"].Seats[1].Available, "Seat B1 should remain occupied")
+
+	// Test swapping with a vacant seat
+	err = seatManager.SwapSeats("A", 1, WholeRoute, "B", 2, WholeRoute)
+	assert.Error(t, err, "Should return an error when one of the seats is not occupied")
+
+	// Test swapping a seat that does not exist
+	err = seatManager.SwapSeats("A", 100, WholeRoute, "B", 1, WholeRoute)
+	assert.Error(t, err, "Should return an error when a seat does not exist")
+
+	// Test swapping in a section that does not exist
+	err = seatManager.SwapSeats("C", 1, WholeRoute, "B", 1, WholeRoute)
+	assert.Error(t, err, "Should return an error when a section does not exist")
+}
+
+func TestAssignSeatInSection(t *testing.T) {
+	seatManager := CreateSeatManager()
+
+	// Assign a seat in section B
+	seatNumber, err := seatManager.AssignSeatInSection("B", WholeRoute)
+	assert.NoError(t, err, "Should not return an error when assigning a seat in a section")
+	assert.Equal(t, 1, seatNumber, "First seat in section B should be assigned")
+	assert.Equal(t, 19, seatManager.Sections["B"].VacantSeats, "Section B should have 19 vacant seats after assignment")
+	assert.Equal(t, 20, seatManager.Sections["A"].VacantSeats, "Section A should be untouched")
+
+	// Fill up section B
+	for i := 2; i <= 20; i++ {
+		_, err = seatManager.AssignSeatInSection("B", WholeRoute)
+		assert.NoError(t, err)
+	}
+	_, err = seatManager.AssignSeatInSection("B", WholeRoute)
+	assert.Error(t, err, "Should return an error when the section is full")
+
+	// Test assigning in a section that does not exist
+	_, err = seatManager.AssignSeatInSection("C", WholeRoute)
+	assert.Error(t, err, "Should return an error when the section does not exist")
+}
+
+func TestUpdateSeatToSameSeat(t *testing.T) {
+	seatManager := CreateSeatManager()
+
+	sectionName, seatNumber, err := seatManager.AssignSeat(WholeRoute)
+	assert.NoError(t, err)
+
+	vacantBefore := seatManager.Sections[sectionName].VacantSeats
+	firstVacantBefore := seatManager.Sections[sectionName].FirstVacant
+
+	// Updating to the current seat is a successful no-op
+	err = seatManager.UpdateSeat(seatNumber, sectionName, seatNumber, sectionName, WholeRoute)
+	assert.NoError(t, err, "Should not return an error when updating to the same seat")
+	assert.Equal(t, vacantBefore, seatManager.Sections[sectionName].VacantSeats, "Vacant seats should be unchanged")
+	assert.Equal(t, firstVacantBefore, seatManager.Sections[sectionName].FirstVacant, "First vacant seat should be unchanged")
+	assert.Equal(t, false, seatManager.Sections[sectionName].Seats[seatNumber].Available, "Seat should remain occupied")
+}
+
+func TestAssignSeatDefaultSection(t *testing.T) {
+	seatManager := CreateSeatManager()
+	seatManager.DefaultSection = "B"
+
+	// Unpreferred bookings fill the default section first
+	for i := 1; i <= 20; i++ {
+		sectionName, seatNumber, err := seatManager.AssignSeat(WholeRoute)
+		assert.NoError(t, err)
+		assert.Equal(t, "B", sectionName, "Default section should be filled first")
+		assert.Equal(t, i, seatNumber)
+	}
+	assert.Equal(t, 20, seatManager.Sections["A"].VacantSeats, "Other sections should be untouched until the default is full")
+
+	// Once the default is full, round-robin takes over
+	sectionName, _, err := seatManager.AssignSeat(WholeRoute)
+	assert.NoError(t, err)
+	assert.Equal(t, "A", sectionName, "Round-robin should be used once the default section is full")
+}
+
+func TestResizeSection(t *testing.T) {
+	seatManager := CreateSeatManager()
+
+	// Fill section A completely
+	for i := 1; i <= 20; i++ {
+		_, err := seatManager.AssignSeatInSection("A", WholeRoute)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 21, seatManager.Sections["A"].FirstVacant)
+
+	// Grow adds higher-numbered vacant seats
+	err := seatManager.ResizeSection("A", 25)
+	assert.NoError(t, err, "Should not return an error when growing a section")
+	assert.Equal(t, 25, seatManager.Sections["A"].MaxSeats, "Section A should have 25 seats")
+	assert.Equal(t, 5, seatManager.Sections["A"].VacantSeats, "Section A should have 5 vacant seats")
+	assert.Equal(t, 21, seatManager.Sections["A"].FirstVacant, "First vacant seat should be the first new seat")
+	assert.Equal(t, true, seatManager.Sections["A"].Seats[25].Available, "New seats should be available")
+
+	sectionName, seatNumber, err := seatManager.AssignSeat(WholeRoute)
+	assert.NoError(t, err)
+	assert.Equal(t, "A", sectionName)
+	assert.Equal(t, 21, seatNumber, "New seats should be assignable")
+
+	// Shrink removes unoccupied trailing seats
+	err = seatManager.ResizeSection("A", 21)
+	assert.NoError(t, err, "Should not return an error when shrinking unoccupied seats")
+	assert.Equal(t, 21, seatManager.Sections["A"].MaxSeats, "Section A should have 21 seats")
+	assert.Equal(t, 0, seatManager.Sections["A"].VacantSeats, "Section A should have no vacant seats")
+	assert.Equal(t, 22, seatManager.Sections["A"].FirstVacant, "First vacant seat should point past the end")
+	assert.NotContains(t, seatManager.Sections["A"].Seats, 22, "Removed seats should be gone")
+
+	// Shrink is rejected when a trailing seat is occupied
+	err = seatManager.ResizeSection("A", 20)
+	assert.Error(t, err, "Should return an error when shrinking below an occupied seat")
+	assert.Equal(t, 21, seatManager.Sections["A"].MaxSeats, "Section should be unchanged after a rejected shrink")
+
+	// Test resizing a section that does not exist
+	err = seatManager.ResizeSection("C", 10)
+	assert.Error(t, err, "Should return an error when the section does not exist")
+}
+
+func TestPeekSeat(t *testing.T) {
+	seatManager := CreateSeatManager()
+
+	_, _, err := seatManager.AssignSeat(WholeRoute)
+	assert.NoError(t, err)
+
+	// Peeking reports the next round-robin seat without assigning it
+	sectionName, seatNumber, err := seatManager.PeekSeat(WholeRoute)
+	assert.NoError(t, err)
+	assert.Equal(t, "B", sectionName)
+	assert.Equal(t, 1, seatNumber)
+	assert.Equal(t, 20, seatManager.Sections["B"].VacantSeats, "Peeking should not consume a seat")
+	assert.Equal(t, 1, seatManager.nextSectionIdx, "Peeking should not advance round-robin")
+
+	seatNumber, err = seatManager.PeekSeatInSection("A", WholeRoute)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, seatNumber)
+	assert.Equal(t, 19, seatManager.Sections["A"].VacantSeats, "Peeking should not consume a seat")
+
+	_, err = seatManager.PeekSeatInSection("C", WholeRoute)
+	assert.ErrorIs(t, err, ErrSectionNotFound)
+}
+
+func TestJourney(t *testing.T) {
+	seatManager := CreateSeatManager()
+
+	journey, err := seatManager.Journey("London", "France")
+	assert.NoError(t, err)
+	assert.Equal(t, WholeRoute, journey, "Without a route every journey covers the whole route")
+
+	seatManager.Route = []string{"London", "Paris", "Lyon"}
+	tests := []struct {
+		name     string
+		from     string
+		to       string
+		expected Journey
+		err      bool
+	}{
+		{"First Segment", "London", "Paris", Journey{From: 0, To: 1}, false},
+		{"Second Segment", "Paris", "Lyon", Journey{From: 1, To: 2}, false},
+		{"Whole Route", "London", "Lyon", Journey{From: 0, To: 2}, false},
+		{"Reversed", "Lyon", "London", WholeRoute, true},
+		{"Unknown Station", "London", "France", WholeRoute, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			journey, err := seatManager.Journey(test.from, test.to)
+			if test.err {
+				assert.ErrorIs(t, err, ErrJourneyNotOnRoute)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, journey)
+		})
+	}
+}
+
+func TestAssignSeatSegments(t *testing.T) {
+	seatManager := CreateSeatManager()
+	seatManager.Route = []string{"London", "Paris", "Lyon", "Marseille"}
+
+	londonParis := Journey{From: 0, To: 1}
+	parisLyon := Journey{From: 1, To: 2}
+	londonLyon := Journey{From: 0, To: 2}
+
+	sectionName, seatNumber, err := seatManager.AssignSeat(londonParis)
+	assert.NoError(t, err)
+	assert.Equal(t, "A", sectionName)
+	assert.Equal(t, 1, seatNumber)
+
+	// A non-overlapping journey reuses the same physical seat
+	sectionName, seatNumber, err = seatManager.AssignSeat(parisLyon)
+	assert.NoError(t, err)
+	assert.Equal(t, "A", sectionName, "Non-overlapping journey should reuse the seat")
+	assert.Equal(t, 1, seatNumber, "Non-overlapping journey should reuse the seat")
+	assert.Equal(t, 19, seatManager.Sections["A"].VacantSeats, "Reusing a seat should not consume a vacant one")
+
+	// An overlapping journey needs another seat
+	sectionName, seatNumber, err = seatManager.AssignSeat(londonLyon)
+	assert.NoError(t, err)
+	assert.Equal(t, "B", sectionName, "Overlapping journey should take a vacant seat")
+	assert.Equal(t, 1, seatNumber)
+
+	// Seat A1 is still free for the last segment
+	seatNumber, err = seatManager.AssignSeatInSection("A", Journey{From: 2, To: 3})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, seatNumber, "Last segment should reuse the seat")
+
+	// Releasing one segment keeps the seat occupied for the others
+	err = seatManager.ReleaseSeat("A", 1, parisLyon)
+	assert.NoError(t, err)
+	assert.False(t, seatManager.Sections["A"].Seats[1].Available, "Seat should stay occupied while other segments are booked")
+
+	err = seatManager.ReleaseSeat("A", 1, parisLyon)
+	assert.ErrorIs(t, err, ErrSeatAlreadyAvailable, "Releasing an unbooked segment should fail")
+
+	err = seatManager.ReleaseSeat("A", 1, londonParis)
+	assert.NoError(t, err)
+	err = seatManager.ReleaseSeat("A", 1, Journey{From: 2, To: 3})
+	assert.NoError(t, err)
+	assert.True(t, seatManager.Sections["A"].Seats[1].Available, "Seat should be vacant once every segment is released")
+	assert.Equal(t, 20, seatManager.Sections["A"].VacantSeats)
+	assert.Equal(t, 1, seatManager.Sections["A"].FirstVacant)
+}
+
+func TestUpdateSeatSegments(t *testing.T) {
+	seatManager := CreateSeatManager()
+	seatManager.Route = []string{"London", "Paris", "Lyon"}
+
+	londonParis := Journey{From: 0, To: 1}
+	parisLyon := Journey{From: 1, To: 2}
+
+	_, err := seatManager.AssignSeatInSection("A", londonParis)
+	assert.NoError(t, err)
+	_, err = seatManager.AssignSeatInSection("B", parisLyon)
+	assert.NoError(t, err)
+
+	// B1 is only booked from Paris, so the London to Paris rider can move there
+	err = seatManager.UpdateSeat(1, "A", 1, "B", londonParis)
+	assert.NoError(t, err)
+	assert.True(t, seatManager.Sections["A"].Seats[1].Available, "Old seat should be vacant")
+	assert.Equal(t, 20, seatManager.Sections["A"].VacantSeats)
+	assert.Equal(t, 19, seatManager.Sections["B"].VacantSeats, "Sharing a seat should not consume a vacant one")
+
+	// The Paris to Lyon segment of B1 is taken
+	_, err = seatManager.AssignSeatInSection("A", parisLyon)
+	assert.NoError(t, err)
+	err = seatManager.UpdateSeat(1, "A", 1, "B", parisLyon)
+	assert.ErrorIs(t, err, ErrSeatOccupied, "Overlapping segments should report the seat is occupied")
+}
+
+func TestSwapSeatsSegments(t *testing.T) {
+	seatManager := CreateSeatManager()
+	seatManager.Route = []string{"London", "Paris", "Lyon"}
+
+	londonParis := Journey{From: 0, To: 1}
+	parisLyon := Journey{From: 1, To: 2}
+	londonLyon := Journey{From: 0, To: 2}
+
+	// A1 is shared by two riders, B1 is held for the whole route
+	_, err := seatManager.AssignSeatInSection("A", londonParis)
+	assert.NoError(t, err)
+	_, err = seatManager.AssignSeatInSection("A", parisLyon)
+	assert.NoError(t, err)
+	_, err = seatManager.AssignSeatInSection("B", londonLyon)
+	assert.NoError(t, err)
+
+	// The whole-route rider would overlap the rider staying on A1
+	err = seatManager.SwapSeats("A", 1, londonParis, "B", 1, londonLyon)
+	assert.ErrorIs(t, err, ErrSeatOccupied)
+
+	assert.True(t, seatManager.Sections["A"].Seats[1].isBooked(londonParis), "Failed swap should leave seats unchanged")
+	assert.True(t, seatManager.Sections["B"].Seats[1].isBooked(londonLyon), "Failed swap should leave seats unchanged")
+
+	// Riders on matching segments can always swap
+	seatNumber, err := seatManager.AssignSeatInSection("B", londonParis)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, seatNumber)
+	err = seatManager.SwapSeats("A", 1, londonParis, "B", 2, londonParis)
+	assert.NoError(t, err)
+	assert.True(t, seatManager.Sections["A"].Seats[1].isBooked(londonLyon), "Shared seat should stay booked for both segments")
+	assert.True(t, seatManager.Sections["B"].Seats[2].isBooked(londonParis))
+	assert.True(t, seatManager.Sections["B"].Seats[2].isFree(parisLyon))
+}
+
+func TestAssignSeatConcurrentDistribution(t *testing.T) {
+	sectionConfigs := []config.SectionConfig{
+		{Name: "A", MaxSeats: 50},
+		{Name: "B", MaxSeats: 50},
+		{Name: "C", MaxSeats: 50},
+		{Name: "D", MaxSeats: 50},
+	}
+	seatManager := NewSeatManager(sectionConfigs, zap.NewNop())
+	const bookings = 120
+
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		counts = make(map[string]int)
+	)
+	for i := 0; i < bookings; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sectionName, _, err := seatManager.AssignSeat(WholeRoute)
+			assert.NoError(t, err)
+
+			mu.Lock()
+			counts[sectionName]++
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	for _, name := range seatManager.SectionOrder {
+		assert.Equal(t, bookings/len(sectionConfigs), counts[name], "Section %s should get an even share of bookings", name)
+		assert.Equal(t, 50-bookings/len(sectionConfigs), seatManager.VacantSeats(name))
+	}
+}
+
+func TestAssignSeatSharedSeatsRoundRobin(t *testing.T) {
+	seatManager := CreateSeatManager()
+	seatManager.Route = []string{"London", "Paris", "Lyon"}
+
+	londonParis := Journey{From: 0, To: 1}
+	parisLyon := Journey{From: 1, To: 2}
+
+	for _, sectionName := range []string{"A", "A", "B", "B"} {
+		_, err := seatManager.AssignSeatInSection(sectionName, londonParis)
+		assert.NoError(t, err)
+	}
+
+	// Reused seats follow the round-robin instead of always filling section A
+	sectionName, seatNumber, err := seatManager.AssignSeat(parisLyon)
+	assert.NoError(t, err)
+	assert.Equal(t, "A", sectionName)
+	assert.Equal(t, 1, seatNumber)
+
+	sectionName, seatNumber, err = seatManager.AssignSeat(parisLyon)
+	assert.NoError(t, err)
+	assert.Equal(t, "B", sectionName, "Second shared booking should go to the next section")
+	assert.Equal(t, 1, seatNumber)
+}
+
+// assertFirstVacant checks that every section's FirstVacant is its lowest
+// vacant seat, or MaxSeats+1 when it is full.
+func assertFirstVacant(t *testing.T, seatManager *SeatManager, step int) {
+	t.Helper()
+	for name, section := range seatManager.Sections {
+		want := section.MaxSeats + 1
+		for seatNum := 1; seatNum <= section.MaxSeats; seatNum++ {
+			if section.Seats[seatNum].Available {
+				want = seatNum
+				break
+			}
+		}
+		assert.Equal(t, want, section.FirstVacant, "Step %d: section %s first vacant should be its lowest vacant seat", step, name)
+	}
+}
+
+func TestFirstVacantInvariant(t *testing.T) {
+	type booking struct {
+		section string
+		seat    int
+	}
+
+	for seed := int64(1); seed <= 20; seed++ {
+		rng := rand.New(rand.NewSource(seed))
+		seatManager := NewSeatManager([]config.SectionConfig{
+			{Name: "A", MaxSeats: 8},
+			{Name: "B", MaxSeats: 5},
+		}, zap.NewNop())
+		var booked []booking
+
+		for step := 0; step < 200; step++ {
+			switch op := rng.Intn(4); {
+			case op == 0 || len(booked) == 0:
+				if section, seat, err := seatManager.AssignSeat(WholeRoute); err == nil {
+					booked = append(booked, booking{section, seat})
+				}
+			case op == 1:
+				i := rng.Intn(len(booked))
+				err := seatManager.ReleaseSeat(booked[i].section, booked[i].seat, WholeRoute)
+				assert.NoError(t, err, "Seed %d step %d: release should succeed", seed, step)
+				booked = append(booked[:i], booked[i+1:]...)
+			case op == 2:
+				i := rng.Intn(len(booked))
+				section := seatManager.SectionOrder[rng.Intn(len(seatManager.SectionOrder))]
+				seat := 1 + rng.Intn(seatManager.Sections[section].MaxSeats)
+				if err := seatManager.UpdateSeat(booked[i].seat, booked[i].section, seat, section, WholeRoute); err == nil {
+					booked[i] = booking{section, seat}
+				}
+			default:
+				section := seatManager.SectionOrder[rng.Intn(len(seatManager.SectionOrder))]
+				seat := 1 + rng.Intn(seatManager.Sections[section].MaxSeats)
+				if seatManager.BlockSeat(section, seat) != nil {
+					seatManager.UnblockSeat(section, seat)
+				}
+			}
+			assertFirstVacant(t, seatManager, step)
+		}
+	}
+}
+
+// BenchmarkNewSeatManager builds a train of 100,000 seats in ten sections, to
+// measure the allocations made laying out large seat maps.
+func BenchmarkNewSeatManager(b *testing.B) {
+	sections := make([]config.SectionConfig, 10)
+	for i := range sections {
+		sections[i] = config.SectionConfig{Name: fmt.Sprintf("S%d", i+1), MaxSeats: 10000}
+	}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		NewSeatManager(sections, zap.NewNop())
+	}
+}
+
+func TestVacancyPointersFollowEveryChange(t *testing.T) {
+	section := newSection("A", 30)
+	random := rand.New(rand.NewSource(1))
+
+	// Moving the pointers one seat at a time must agree with a full rescan
+	for i := 0; i < 1000; i++ {
+		seatNum := random.Intn(section.MaxSeats) + 1
+		if section.Seats[seatNum].Available {
+			section.occupy(seatNum, WholeRoute)
+		} else {
+			section.release(seatNum, WholeRoute)
+		}
+
+		first, last := section.FirstVacant, section.LastVacant
+		recomputeFirstVacant(section)
+		assert.Equal(t, section.FirstVacant, first, "FirstVacant after changing seat %d", seatNum)
+		assert.Equal(t, section.LastVacant, last, "LastVacant after changing seat %d", seatNum)
+	}
 }
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"slices"
+
+	"github.com/sanjaykishor/rail-connect/internal/config"
+)
+
+// SeatPositions ranks the seats of a row for assignment, lowest first, so
+// e.g. window seats fill before aisle seats. Each entry is the rank of one
+// seat across the row; seat numbers run along the rows, so seat n sits in
+// column (n-1) % len.
+type SeatPositions []int
+
+// NewSeatPositions ranks the columns of a row layout such as "WMAAMW" by the
+// position order, e.g. [window, middle, aisle]. Positions missing from the
+// order rank after the listed ones. An empty order returns nil, which leaves
+// seats filling in number order.
+func NewSeatPositions(rowLayout string, order []string) SeatPositions {
+	if len(order) == 0 || rowLayout == "" {
+		return nil
+	}
+
+	positions := make(SeatPositions, 0, len(rowLayout))
+	for _, letter := range rowLayout {
+		rank := slices.Index(order, config.RowLayoutPositions[letter])
+		if rank < 0 {
+			rank = len(order)
+		}
+		positions = append(positions, rank)
+	}
+	return positions
+}
+
+// rank returns the assignment rank of a seat.
+func (p SeatPositions) rank(seatNum int) int {
+	return p[(seatNum-1)%len(p)]
+}
+
+// firstVacant returns the vacant seat to hand out next in the section: the
+// first seat in the section's fill direction of the best ranked position, or
+// simply the first vacant seat when no position order is set. Callers must
+// hold sm.mu.
+func (sm *SeatManager) firstVacant(section *Section) (int, bool) {
+	if len(sm.Positions) == 0 {
+		return findFirstVacant(section)
+	}
+	if section.VacantSeats <= 0 {
+		return -1, false
+	}
+
+	best := -1
+	first, last, step := section.scanOrder()
+	for seatNum := first; seatNum*step <= last*step; seatNum += step {
+		seat, exists := section.Seats[seatNum]
+		if !exists || !seat.Available {
+			continue
+		}
+		if best < 0 || sm.Positions.rank(seatNum) < sm.Positions.rank(best) {
+			best = seatNum
+		}
+	}
+	return best, best >= 0
+}
@@ -0,0 +1,51 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/sanjaykishor/rail-connect/internal/config"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestSeatPositionsFillOrder(t *testing.T) {
+	// Two rows of window, middle, aisle, aisle, middle, window
+	sm := NewSeatManager([]config.SectionConfig{{Name: "A", MaxSeats: 12}}, zap.NewNop())
+	sm.Positions = NewSeatPositions("WMAAMW", []string{config.PositionWindow, config.PositionMiddle, config.PositionAisle})
+
+	var order []int
+	for range 12 {
+		_, seatNum, err := sm.AssignSeat(WholeRoute)
+		assert.NoError(t, err)
+		order = append(order, seatNum)
+	}
+	assert.Equal(t, []int{1, 6, 7, 12, 2, 5, 8, 11, 3, 4, 9, 10}, order, "Windows should fill first and aisles last")
+}
+
+func TestSeatPositionsUnlistedLast(t *testing.T) {
+	sm := NewSeatManager([]config.SectionConfig{{Name: "A", MaxSeats: 4, BlockedSeats: []int{2}}}, zap.NewNop())
+	sm.Positions = NewSeatPositions("AWWA", []string{config.PositionWindow})
+
+	seatNum, err := sm.PeekSeatInSection("A", WholeRoute)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, seatNum, "Blocked seats should be skipped")
+
+	var order []int
+	for range 3 {
+		seatNum, err := sm.AssignSeatInSection("A", WholeRoute)
+		assert.NoError(t, err)
+		order = append(order, seatNum)
+	}
+	assert.Equal(t, []int{3, 1, 4}, order, "Unlisted positions should fill after listed ones, in seat order")
+}
+
+func TestSeatPositionsDeparture(t *testing.T) {
+	assert.Nil(t, NewSeatPositions("WAAW", nil))
+
+	sm := NewSeatManager([]config.SectionConfig{{Name: "A", MaxSeats: 4}}, zap.NewNop())
+	sm.Positions = NewSeatPositions("AWWA", []string{config.PositionWindow})
+	departure := sm.newDeparture()
+	_, seatNum, err := departure.AssignSeat(WholeRoute)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, seatNum, "Dated departures should keep the position order")
+}
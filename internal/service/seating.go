@@ -0,0 +1,205 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"github.com/sanjaykishor/rail-connect/internal/config"
+	pb "github.com/sanjaykishor/rail-connect/proto"
+	"go.uber.org/zap"
+)
+
+// seatAllocator hands out seats. SeatManager assigns them; seatPeeker only
+// reports what would be assigned, which lets dry runs share the seating logic.
+type seatAllocator interface {
+	Place(journey Journey) (Placement, error)
+	AssignSeatInSection(sectionName string, journey Journey) (int, error)
+}
+
+// seatPeeker is a seatAllocator that never changes seat state.
+type seatPeeker struct {
+	sm *SeatManager
+}
+
+func (p seatPeeker) Place(journey Journey) (Placement, error) {
+	return p.sm.PeekPlace(journey)
+}
+
+func (p seatPeeker) AssignSeatInSection(sectionName string, journey Journey) (int, error) {
+	return p.sm.PeekSeatInSection(sectionName, journey)
+}
+
+// allocator returns the seatAllocator to use for a purchase on the departure.
+func (tm *TicketManager) allocator(req *pb.PurchaseTicketRequest, departure *SeatManager) seatAllocator {
+	if req.DryRun {
+		return seatPeeker{departure}
+	}
+	return departure
+}
+
+// assignSeat picks a seat for a purchase according to the configured seating
+// options, falling back to round-robin across all sections of the purchase's
+// departure, and traces how the seat was chosen. Purchases holding a seat get
+// that seat. Purchases minimizing the price instead take the cheapest section
+// at the base fare and time now. When
+// a preferred or default section is full its overflow policy may instead fail
+// the purchase with ErrSectionFull or errWaitlisted, returning that section.
+// Callers must hold tm.mu.
+func (tm *TicketManager) assignSeat(req *pb.PurchaseTicketRequest, price float64, journey Journey, now time.Time) (string, int, AssignmentTrace, error) {
+	departure := tm.departure(req.TrainId, req.DepartureDate)
+	seats := tm.allocator(req, departure)
+	var trace AssignmentTrace
+
+	// Riders booking a held seat get that seat
+	if req.HoldId != "" {
+		section, seat := tm.takeHold(req)
+		trace.Strategy = StrategyHold
+		return section, seat, trace, nil
+	}
+
+	// Budget riders take the cheapest section with a free seat. Overflow
+	// policies don't apply since any section will do.
+	if req.MinimizePrice {
+		trace.Strategy = StrategyCheapestSection
+		for _, section := range tm.sectionsByFare(departure, price, now) {
+			seat, err := seats.AssignSeatInSection(section, journey)
+			if err == nil {
+				return section, seat, trace, nil
+			}
+			if !errors.Is(err, ErrNoSeatsAvailable) {
+				return section, -1, trace, err
+			}
+			trace.SkippedSections++
+		}
+		return "", -1, trace, ErrNoSeatsAvailable
+	}
+
+	// Try the rider's preferred sections in order, stopping at a full one
+	// whose overflow policy doesn't spill
+	for _, section := range req.PreferredSections {
+		seat, err := seats.AssignSeatInSection(section, journey)
+		if err == nil {
+			tm.Logger.Debug("Seat assigned by preference",
+				zap.String("section", section),
+				zap.Int("seat_number", seat),
+			)
+			trace.Strategy, trace.PreferenceHonored = StrategyPreference, true
+			return section, seat, trace, nil
+		}
+		trace.SkippedSections++
+		if errors.Is(err, ErrNoSeatsAvailable) {
+			if err := tm.overflow(section); err != nil {
+				return section, -1, trace, err
+			}
+		}
+	}
+
+	// Loyalty members try their tier's sections next, falling back to the
+	// usual seating when those are full
+	for _, section := range tm.tierSections(departure, req.LoyaltyTier) {
+		if seat, err := seats.AssignSeatInSection(section, journey); err == nil {
+			tm.Logger.Debug("Seat assigned by loyalty tier",
+				zap.String("loyalty_tier", req.LoyaltyTier),
+				zap.String("section", section),
+				zap.Int("seat_number", seat),
+			)
+			trace.Strategy = StrategyLoyaltyTier
+			return section, seat, trace, nil
+		}
+		trace.SkippedSections++
+	}
+
+	if tm.DestinationAffinity {
+		if section := tm.affinitySection(departure, departureKey{tm.trainOrDefault(req.TrainId), req.DepartureDate}, req.To); section != "" {
+			if seat, err := seats.AssignSeatInSection(section, journey); err == nil {
+				tm.Logger.Debug("Seat assigned by destination affinity",
+					zap.String("destination", req.To),
+					zap.String("section", section),
+					zap.Int("seat_number", seat),
+				)
+				trace.Strategy = StrategyDestinationAffinity
+				return section, seat, trace, nil
+			}
+			trace.SkippedSections++
+		}
+	}
+
+	// The default section is filled by AssignSeat, unless it must not spill
+	if section := tm.SeatManager.DefaultSection; tm.SeatManager.OverflowPolicy(section) != config.OverflowSpill {
+		seat, err := seats.AssignSeatInSection(section, journey)
+		if err == nil {
+			trace.Strategy = StrategyDefaultSection
+			return section, seat, trace, nil
+		}
+		trace.SkippedSections++
+		if errors.Is(err, ErrNoSeatsAvailable) {
+			if err := tm.overflow(section); err != nil {
+				return section, -1, trace, err
+			}
+		}
+	}
+
+	placement, err := seats.Place(journey)
+	trace.Strategy = placement.Strategy
+	trace.SkippedSections += placement.Skipped
+	return placement.Section, placement.Seat, trace, err
+}
+
+// tierSections returns the sections a loyalty tier is seated in first, in
+// configured order, leaving out any the departure no longer has or restricts.
+// Callers must hold tm.mu.
+func (tm *TicketManager) tierSections(departure *SeatManager, tier string) []string {
+	if tier == "" {
+		return nil
+	}
+
+	sections := make([]string, 0, len(tm.LoyaltySections[tier]))
+	for _, section := range tm.LoyaltySections[tier] {
+		if departure.AcceptingNew(section) {
+			sections = append(sections, section)
+		}
+	}
+	return sections
+}
+
+// destinationKey identifies a section of a departure, whose riders are counted
+// by destination.
+type destinationKey struct {
+	departure departureKey
+	section   string
+}
+
+// affinitySection returns the section accepting new riders hosting the most riders to
+// the destination on the departure that still has vacant seats, or "" if
+// there is none. Callers must hold tm.mu.
+func (tm *TicketManager) affinitySection(departure *SeatManager, key departureKey, destination string) string {
+	best, bestCount := "", 0
+	for _, name := range departure.SectionOrder {
+		count := tm.destinationCounts[destinationKey{key, name}][destination]
+		if count > bestCount && departure.VacantSeats(name) > 0 && departure.AcceptingNew(name) {
+			best, bestCount = name, count
+		}
+	}
+	return best
+}
+
+// trackDestination adjusts the number of riders to the receipt's destination
+// seated in a section of its departure. Callers must hold tm.mu.
+func (tm *TicketManager) trackDestination(receipt *pb.Receipt, section string, delta int) {
+	key := destinationKey{departureKey{tm.trainOrDefault(receipt.TrainId), receipt.DepartureDate}, section}
+	counts, exists := tm.destinationCounts[key]
+	if !exists {
+		counts = make(map[string]int)
+		tm.destinationCounts[key] = counts
+	}
+
+	counts[receipt.To] += delta
+	if counts[receipt.To] <= 0 {
+		delete(counts, receipt.To)
+	}
+}
+
+// journey returns the route segments covered by a receipt. Callers must hold tm.mu.
+func (tm *TicketManager) journey(receipt *pb.Receipt) (Journey, error) {
+	return tm.SeatManager.Journey(receipt.From, receipt.To)
+}
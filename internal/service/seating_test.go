@@ -0,0 +1,257 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// purchaseOption changes a test purchase request before it is sent.
+type purchaseOption func(*pb.PurchaseTicketRequest)
+
+// onDate books the departure on the date.
+func onDate(date string) purchaseOption {
+	return func(req *pb.PurchaseTicketRequest) { req.DepartureDate = date }
+}
+
+// onTrain books the train, or the default train if train is empty.
+func onTrain(train string) purchaseOption {
+	return func(req *pb.PurchaseTicketRequest) { req.TrainId = train }
+}
+
+// provisionally books a ticket that must be paid for later.
+func provisionally(req *pb.PurchaseTicketRequest) { req.Provisional = true }
+
+// minimizingPrice books in the cheapest open section.
+func minimizingPrice(req *pb.PurchaseTicketRequest) { req.MinimizePrice = true }
+
+// inClass books in the fare class.
+func inClass(class string) purchaseOption {
+	return func(req *pb.PurchaseTicketRequest) { req.FareClass = class }
+}
+
+// inSection books preferring the section.
+func inSection(section string) purchaseOption {
+	return func(req *pb.PurchaseTicketRequest) { req.PreferredSections = []string{section} }
+}
+
+// withCompanion books a second ticket for a companion travelling alongside.
+func withCompanion(email string) purchaseOption {
+	return func(req *pb.PurchaseTicketRequest) {
+		req.Companion = &pb.User{FirstName: "Priya", LastName: "Kishor", Email: email}
+	}
+}
+
+// withTier books for a member of the loyalty tier.
+func withTier(tier string) purchaseOption {
+	return func(req *pb.PurchaseTicketRequest) { req.LoyaltyTier = tier }
+}
+
+// withMetadata books a ticket carrying the metadata.
+func withMetadata(metadata map[string]string) purchaseOption {
+	return func(req *pb.PurchaseTicketRequest) { req.Metadata = metadata }
+}
+
+// purchaseRequest returns a request for the given user and route, changed by
+// the options.
+func purchaseRequest(email, from, to string, options ...purchaseOption) *pb.PurchaseTicketRequest {
+	req := &pb.PurchaseTicketRequest{
+		User: &pb.User{FirstName: "Sanjay", LastName: "Kishor", Email: email},
+		From: from,
+		To:   to,
+	}
+	for _, option := range options {
+		option(req)
+	}
+	return req
+}
+
+// tryPurchase books a London to France ticket for the given user, returning
+// the error for the test to check.
+func tryPurchase(tm *TicketManager, email string, options ...purchaseOption) (*pb.PurchaseTicketResponse, error) {
+	return tm.PurchaseTicket(context.Background(), purchaseRequest(email, "London", "France", options...))
+}
+
+// purchase books a ticket for the given user and route, failing the test on error.
+func purchase(t *testing.T, tm *TicketManager, email, from, to string, options ...purchaseOption) *pb.Receipt {
+	t.Helper()
+	response, err := tm.PurchaseTicket(context.Background(), purchaseRequest(email, from, to, options...))
+	require.NoError(t, err)
+	return response.Receipt
+}
+
+func TestDestinationAffinity(t *testing.T) {
+	tm := createTestTicketManager()
+	tm.DestinationAffinity = true
+	tm.StationConnection["London-Paris"] = 25.00
+
+	first := purchase(t, tm, "test1@example.com", "London", "France")
+	second := purchase(t, tm, "test2@example.com", "London", "France")
+	assert.Equal(t, first.Seat.Section, second.Seat.Section, "Riders to the same destination should share a section")
+
+	// A new destination has no affinity and falls back to round-robin
+	third := purchase(t, tm, "test3@example.com", "London", "Paris")
+	assert.Equal(t, "B", third.Seat.Section, "Riders without affinity should be seated round-robin")
+}
+
+func TestDestinationAffinityFallsBackWhenFull(t *testing.T) {
+	tm := createTestTicketManager()
+	tm.DestinationAffinity = true
+
+	first := purchase(t, tm, "test1@example.com", "London", "France")
+	assert.Equal(t, "A", first.Seat.Section)
+
+	// Fill up the rest of section A
+	for tm.SeatManager.Sections["A"].VacantSeats > 0 {
+		_, err := tm.SeatManager.AssignSeatInSection("A", WholeRoute)
+		assert.NoError(t, err)
+	}
+
+	second := purchase(t, tm, "test2@example.com", "London", "France")
+	assert.Equal(t, "B", second.Seat.Section, "Riders should fall back to round-robin when the affinity section is full")
+}
+
+func TestDestinationAffinityDisabled(t *testing.T) {
+	tm := createTestTicketManager()
+
+	first := purchase(t, tm, "test1@example.com", "London", "France")
+	second := purchase(t, tm, "test2@example.com", "London", "France")
+	assert.NotEqual(t, first.Seat.Section, second.Seat.Section, "Round-robin should be used by default")
+}
+
+func TestPurchaseTicketSegmentReuse(t *testing.T) {
+	tm := createTestTicketManager()
+	tm.SeatManager.Route = []string{"London", "Paris", "Lyon"}
+	tm.StationConnection["London-Paris"] = 15.00
+	tm.StationConnection["Paris-Lyon"] = 10.00
+	tm.StationConnection["London-Lyon"] = 22.00
+
+	first := purchase(t, tm, "test1@example.com", "London", "Paris")
+	second := purchase(t, tm, "test2@example.com", "Paris", "Lyon")
+	assert.Equal(t, first.Seat.Section, second.Seat.Section, "Non-overlapping journeys should share a seat")
+	assert.Equal(t, first.Seat.SeatNumber, second.Seat.SeatNumber, "Non-overlapping journeys should share a seat")
+
+	third := purchase(t, tm, "test3@example.com", "London", "Lyon")
+	assert.False(t, first.Seat.Section == third.Seat.Section && first.Seat.SeatNumber == third.Seat.SeatNumber,
+		"Overlapping journeys should not share a seat")
+
+	// Cancelling one rider keeps the seat booked for the other
+	_, err := tm.RemoveUser(context.Background(), &pb.RemoveUserRequest{Email: "test1@example.com"})
+	assert.NoError(t, err)
+	assert.False(t, tm.SeatManager.Sections[second.Seat.Section].Seats[int(second.Seat.SeatNumber)].Available)
+
+	// Journeys off the route are rejected
+	_, err = tm.PurchaseTicket(context.Background(), &pb.PurchaseTicketRequest{
+		User: &pb.User{FirstName: "Sanjay", LastName: "Kishor", Email: "test4@example.com"},
+		From: "London",
+		To:   "France",
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestPreferredSections(t *testing.T) {
+	tm := createTestTicketManager()
+	assert.NoError(t, tm.SeatManager.ResizeSection("A", 1))
+
+	request := func(email string, preferred ...string) *pb.PurchaseTicketRequest {
+		return &pb.PurchaseTicketRequest{
+			User:              &pb.User{FirstName: "Sanjay", LastName: "Kishor", Email: email},
+			From:              "London",
+			To:                "France",
+			PreferredSections: preferred,
+		}
+	}
+
+	// The first preference has room
+	response, err := tm.PurchaseTicket(context.Background(), request("test1@example.com", "A", "B"))
+	assert.NoError(t, err)
+	assert.Equal(t, "A", response.Receipt.Seat.Section)
+	assert.Equal(t, "A", response.MatchedPreference)
+
+	// The first preference is full, so the second is used
+	response, err = tm.PurchaseTicket(context.Background(), request("test2@example.com", "A", "B"))
+	assert.NoError(t, err)
+	assert.Equal(t, "B", response.Receipt.Seat.Section, "Second preference should be used when the first is full")
+	assert.Equal(t, "B", response.MatchedPreference)
+
+	// No preference can be satisfied, so round-robin takes over
+	response, err = tm.PurchaseTicket(context.Background(), request("test3@example.com", "A"))
+	assert.NoError(t, err)
+	assert.Equal(t, "B", response.Receipt.Seat.Section)
+	assert.Empty(t, response.MatchedPreference, "No preference should be reported as satisfied")
+
+	// Without preferences nothing is reported
+	response, err = tm.PurchaseTicket(context.Background(), request("test4@example.com"))
+	assert.NoError(t, err)
+	assert.Empty(t, response.MatchedPreference)
+
+	_, err = tm.PurchaseTicket(context.Background(), request("test5@example.com", "B", "Z"))
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "Unknown preferred sections should be rejected")
+	assert.Empty(t, tm.Receipts["test5@example.com"])
+}
+
+func TestLoyaltyTierSeating(t *testing.T) {
+	tm := createTestTicketManager()
+	tm.LoyaltySections = map[string][]string{"gold": {"B"}}
+
+	// Gold members land in the premium section while it has room, even when
+	// round-robin would pick another
+	for i, email := range []string{"gold1@example.com", "gold2@example.com"} {
+		receipt := purchase(t, tm, email, "London", "France", withTier("gold"))
+		assert.Equal(t, "B", receipt.Seat.Section, "Gold member %d should be seated in the premium section", i+1)
+		trace := tm.assignmentTraces[receipt.BookingReference]
+		assert.Equal(t, StrategyLoyaltyTier, trace.Strategy)
+	}
+
+	// Other tiers and non-members are seated round-robin
+	assert.Equal(t, "A", purchase(t, tm, "silver@example.com", "London", "France", withTier("silver")).Seat.Section)
+	assert.Equal(t, "B", purchase(t, tm, "member@example.com", "London", "France").Seat.Section)
+}
+
+func TestLoyaltyTierSeatingFallsBackWhenFull(t *testing.T) {
+	tm := createTestTicketManager()
+	tm.LoyaltySections = map[string][]string{"gold": {"B"}}
+	for tm.SeatManager.Sections["B"].VacantSeats > 0 {
+		_, err := tm.SeatManager.AssignSeatInSection("B", WholeRoute)
+		assert.NoError(t, err)
+	}
+
+	receipt := purchase(t, tm, "gold@example.com", "London", "France", withTier("gold"))
+	assert.Equal(t, "A", receipt.Seat.Section, "Gold members should fall back to the usual seating when the premium section is full")
+	trace := tm.assignmentTraces[receipt.BookingReference]
+	assert.NotEqual(t, StrategyLoyaltyTier, trace.Strategy)
+	assert.Equal(t, 1, trace.SkippedSections, "The full premium section should be counted as skipped")
+}
+
+func TestLoyaltyTierSeatingAfterPreference(t *testing.T) {
+	tm := createTestTicketManager()
+	tm.LoyaltySections = map[string][]string{"gold": {"B"}}
+
+	response, err := tm.PurchaseTicket(context.Background(), &pb.PurchaseTicketRequest{
+		User:              &pb.User{FirstName: "Sanjay", LastName: "Kishor", Email: "gold@example.com"},
+		From:              "London",
+		To:                "France",
+		PreferredSections: []string{"A"},
+		LoyaltyTier:       "gold",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "A", response.Receipt.Seat.Section, "A preferred section should win over the tier's")
+
+	// Pairs are seated in the tier's sections too
+	response, err = tm.PurchaseTicket(context.Background(), &pb.PurchaseTicketRequest{
+		User:        &pb.User{FirstName: "Sanjay", LastName: "Kishor", Email: "pair@example.com"},
+		From:        "London",
+		To:          "France",
+		Companion:   &pb.User{FirstName: "Priya", LastName: "Kishor", Email: "pair.companion@example.com"},
+		LoyaltyTier: "gold",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "B", response.Receipt.Seat.Section)
+	assert.Equal(t, "B", response.CompanionReceipt.Seat.Section)
+}
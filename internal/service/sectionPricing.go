@@ -0,0 +1,54 @@
+package service
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	"github.com/sanjaykishor/rail-connect/internal/config"
+)
+
+// SectionPrice adjusts the fare of seats in a section. The zero SectionPrice
+// leaves fares unchanged.
+type SectionPrice struct {
+	Multiplier float64 // Scales the fare; zero is treated as 1
+	Surcharge  float64 // Added to the fare after scaling
+}
+
+// newSectionPrice returns the configured price of a section.
+func newSectionPrice(sectionConfig config.SectionConfig) SectionPrice {
+	return SectionPrice{Multiplier: sectionConfig.PriceMultiplier, Surcharge: sectionConfig.Surcharge}
+}
+
+// multiplier returns the factor applied to fares, treating zero as 1.
+func (p SectionPrice) multiplier() float64 {
+	if p.Multiplier == 0 {
+		return 1
+	}
+	return p.Multiplier
+}
+
+// SectionPrice returns the fare adjustment of the named section.
+func (sm *SeatManager) SectionPrice(sectionName string) SectionPrice {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	return sm.sectionPrices[sectionName]
+}
+
+// sectionsByFare returns the departure's sections accepting new riders from the
+// cheapest effective fare for the base fare at now to the dearest. Sections at
+// the same fare keep their round-robin order. Callers must hold tm.mu.
+func (tm *TicketManager) sectionsByFare(departure *SeatManager, base float64, now time.Time) []string {
+	fares := make(map[string]float64, len(departure.SectionOrder))
+	for _, section := range departure.SectionOrder {
+		fares[section], _ = tm.effectiveFare(base, departure.SectionPrice(section), now)
+	}
+
+	sections := slices.DeleteFunc(slices.Clone(departure.SectionOrder), func(section string) bool {
+		return !departure.AcceptingNew(section)
+	})
+	slices.SortStableFunc(sections, func(a, b string) int {
+		return cmp.Compare(fares[a], fares[b])
+	})
+	return sections
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sanjaykishor/rail-connect/internal/config"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// createPricedTicketManager returns a ticket manager with three sections of
+// two seats, where a London to France ticket costs 30 in A, 25 in B and 20
+// in C.
+func createPricedTicketManager() *TicketManager {
+	seatManager := NewSeatManager([]config.SectionConfig{
+		{Name: "A", MaxSeats: 2, PriceMultiplier: 1.5},
+		{Name: "B", MaxSeats: 2, Surcharge: 5},
+		{Name: "C", MaxSeats: 2},
+	}, zap.NewNop())
+	return NewTicketManager(seatManager, map[string]float64{"London-France": 20.00}, zap.NewNop())
+}
+
+func TestSectionPricing(t *testing.T) {
+	tm := createPricedTicketManager()
+
+	// Round-robin seats riders in A, B and C in turn
+	for _, expected := range []struct {
+		section string
+		price   float64
+	}{{"A", 30.00}, {"B", 25.00}, {"C", 20.00}} {
+		receipt := purchase(t, tm, "test"+expected.section+"@example.com", "London", "France")
+		assert.Equal(t, expected.section, receipt.Seat.Section)
+		assert.Equal(t, expected.price, receipt.PricePaid, "Section %s should set the price", expected.section)
+		assert.Equal(t, 20.00, receipt.BaseFare)
+	}
+}
+
+func TestPurchaseMinimizePrice(t *testing.T) {
+	tm := createPricedTicketManager()
+
+	// C is cheapest until it fills, then B, then A
+	expected := []struct {
+		section string
+		price   float64
+		skipped int
+	}{{"C", 20.00, 0}, {"C", 20.00, 0}, {"B", 25.00, 1}, {"B", 25.00, 1}, {"A", 30.00, 2}}
+	for i, test := range expected {
+		response, err := tryPurchase(tm, string(rune('a'+i))+"@example.com", minimizingPrice)
+		assert.NoError(t, err)
+		assert.Equal(t, test.section, response.Receipt.Seat.Section)
+		assert.Equal(t, test.price, response.Receipt.PricePaid)
+		trace := assignmentTrace(t, tm, response.Receipt)
+		assert.Equal(t, StrategyCheapestSection, trace.Strategy)
+		assert.Equal(t, int32(test.skipped), trace.SkippedSections)
+	}
+}
+
+func TestPurchaseMinimizePriceTimeWindow(t *testing.T) {
+	tm := createPricedTicketManager()
+	tm.TimeWindows = []TimeWindow{{Name: "all_day", Start: 0, End: 24 * time.Hour, Multiplier: 0.4}}
+
+	// Discounting the fare makes A's multiplier cheaper than B's fixed surcharge
+	tm.SeatManager.Sections["C"].Seats[1].Available = false
+	tm.SeatManager.Sections["C"].Seats[2].Available = false
+	tm.SeatManager.Sections["C"].VacantSeats = 0
+
+	response, err := tryPurchase(tm, "test@example.com", minimizingPrice)
+	assert.NoError(t, err)
+	assert.Equal(t, "A", response.Receipt.Seat.Section)
+	assert.Equal(t, 12.00, response.Receipt.PricePaid)
+}
+
+func TestPurchaseMinimizePricePair(t *testing.T) {
+	tm := createPricedTicketManager()
+
+	response, err := tm.PurchaseTicket(context.Background(), &pb.PurchaseTicketRequest{
+		User:          &pb.User{FirstName: "Sanjay", LastName: "Kishor", Email: "test@example.com"},
+		Companion:     &pb.User{FirstName: "Priya", LastName: "Kishor", Email: "companion@example.com"},
+		From:          "London",
+		To:            "France",
+		MinimizePrice: true,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "C", response.Receipt.Seat.Section)
+	assert.Equal(t, "C", response.CompanionReceipt.Seat.Section)
+	assert.Equal(t, pb.PairSeating_PAIR_SEATING_ADJACENT, response.PairSeating)
+	assert.Empty(t, response.MatchedPreference)
+}
+
+func TestPurchaseMinimizePriceInvalid(t *testing.T) {
+	tm := createPricedTicketManager()
+
+	_, err := tm.PurchaseTicket(context.Background(), &pb.PurchaseTicketRequest{
+		User:              &pb.User{FirstName: "Sanjay", LastName: "Kishor", Email: "test@example.com"},
+		From:              "London",
+		To:                "France",
+		MinimizePrice:     true,
+		PreferredSections: []string{"A"},
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "Preferences can't be combined with minimizing the price")
+
+	// Once every section is full the purchase fails like any other
+	for i := 0; i < 6; i++ {
+		_, err := tryPurchase(tm, string(rune('a'+i))+"@example.com", minimizingPrice)
+		assert.NoError(t, err)
+	}
+	_, err = tryPurchase(tm, "late@example.com", minimizingPrice)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
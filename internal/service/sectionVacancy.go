@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GetSectionVacancy returns how many seats are left in a section of the default
+// train or of a dated departure, without creating the departure. It is meant
+// for frequent polling, so it reads the seat counts under the seat manager's
+// read lock, takes tm.mu only to find another departure's seats, and logs at
+// debug level.
+func (tm *TicketManager) GetSectionVacancy(ctx context.Context, req *pb.GetSectionVacancyRequest) (*pb.GetSectionVacancyResponse, error) {
+	tm.Logger.Debug("GetSectionVacancy request received")
+
+	// Validate the request
+	if req == nil {
+		tm.Logger.Error("GetSectionVacancy request is nil")
+		return nil, status.Error(codes.InvalidArgument, "request is nil")
+	}
+	if req.Section == "" {
+		tm.Logger.Error("GetSectionVacancy request missing required fields",
+			zap.String("section", req.Section),
+		)
+		return nil, status.Error(codes.InvalidArgument, "missing required fields")
+	}
+
+	if req.DepartureDate != "" {
+		if _, err := time.Parse(departureDateLayout, req.DepartureDate); err != nil {
+			tm.Logger.Error("GetSectionVacancy invalid departure date",
+				zap.String("departure_date", req.DepartureDate),
+			)
+			return nil, status.Error(codes.InvalidArgument, "departure date must be YYYY-MM-DD")
+		}
+	}
+	train, err := tm.resolveTrain(req.TrainId)
+	if err != nil {
+		tm.Logger.Error("GetSectionVacancy unknown train",
+			zap.String("train_id", req.TrainId),
+		)
+		return nil, err
+	}
+
+	tm.Logger.Debug("GetSectionVacancy request",
+		zap.String("section", req.Section),
+		zap.String("train_id", train),
+		zap.String("departure_date", req.DepartureDate),
+		zap.Time("timestamp", tm.Now()),
+	)
+
+	seats := tm.SeatManager
+	if !tm.isDefaultDeparture(train, req.DepartureDate) {
+		tm.mu.Lock()
+		seats = tm.readDeparture(train, req.DepartureDate)
+		tm.mu.Unlock()
+	}
+
+	vacant, maxSeats, err := seats.SectionVacancy(req.Section)
+	if err != nil {
+		tm.Logger.Error("GetSectionVacancy section not found",
+			zap.String("section", req.Section),
+		)
+		return nil, seatError(err)
+	}
+
+	tm.Logger.Debug("GetSectionVacancy successful",
+		zap.String("section", req.Section),
+		zap.Int("vacant_seats", vacant),
+		zap.Int("max_seats", maxSeats),
+	)
+	return &pb.GetSectionVacancyResponse{
+		VacantSeats: int32(vacant),
+		MaxSeats:    int32(maxSeats),
+	}, nil
+}
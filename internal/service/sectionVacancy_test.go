@@ -0,0 +1,45 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGetSectionVacancy(t *testing.T) {
+	tm := createTestTicketManager()
+	receipt := purchase(t, tm, "test@example.com", "London", "France")
+	section := tm.SeatManager.Sections[receipt.Seat.Section]
+	firstVacant := section.FirstVacant
+
+	response, err := tm.GetSectionVacancy(context.Background(), &pb.GetSectionVacancyRequest{Section: receipt.Seat.Section})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(19), response.VacantSeats)
+	assert.Equal(t, int32(20), response.MaxSeats)
+	assert.Equal(t, firstVacant, section.FirstVacant, "Reading the vacancy should not move FirstVacant")
+	assert.Equal(t, 19, section.VacantSeats)
+
+	tests := []struct {
+		name         string
+		request      *pb.GetSectionVacancyRequest
+		expectedCode codes.Code
+	}{
+		{"Nil Request", nil, codes.InvalidArgument},
+		{"Missing Section", &pb.GetSectionVacancyRequest{}, codes.InvalidArgument},
+		{"Nonexistent Section", &pb.GetSectionVacancyRequest{Section: "C"}, codes.NotFound},
+		{"Invalid Departure Date", &pb.GetSectionVacancyRequest{Section: "A", DepartureDate: "Monday"}, codes.InvalidArgument},
+		{"Unknown Train", &pb.GetSectionVacancyRequest{Section: "A", TrainId: "T9"}, codes.NotFound},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := tm.GetSectionVacancy(context.Background(), test.request)
+			assert.Equal(t, test.expectedCode, status.Code(err))
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package service
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+)
+
+// BuildInfo identifies the running build and configuration.
+type BuildInfo struct {
+	Version         string
+	GitCommit       string
+	EnabledFeatures []string
+}
+
+// GetServerInfo returns the server version, commit, uptime, enabled features
+// and seat assignment metrics. It does not touch booking state, so it's cheap
+// enough for liveness checks.
+func (tm *TicketManager) GetServerInfo(ctx context.Context, req *pb.GetServerInfoRequest) (*pb.GetServerInfoResponse, error) {
+	uptime := tm.Now().Sub(tm.startedAt)
+
+	tm.Logger.Debug("GetServerInfo successful",
+		zap.String("version", tm.BuildInfo.Version),
+		zap.String("git_commit", tm.BuildInfo.GitCommit),
+		zap.Duration("uptime", uptime),
+	)
+	return &pb.GetServerInfoResponse{
+		Version:         tm.BuildInfo.Version,
+		GitCommit:       tm.BuildInfo.GitCommit,
+		UptimeSeconds:   int64(uptime.Seconds()),
+		EnabledFeatures: tm.BuildInfo.EnabledFeatures,
+		SectionsScanned: tm.SeatManager.SectionsScanned.Counts(),
+	}, nil
+}
@@ -0,0 +1,34 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+)
+
+func TestGetServerInfo(t *testing.T) {
+	tm := createTestTicketManager()
+	tm.BuildInfo = BuildInfo{
+		Version:         "1.4.0",
+		GitCommit:       "abc1234",
+		EnabledFeatures: []string{"destination_affinity"},
+	}
+	tm.startedAt = time.Now().Add(-90 * time.Second)
+
+	response, err := tm.GetServerInfo(context.Background(), &pb.GetServerInfoRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, "1.4.0", response.Version)
+	assert.Equal(t, "abc1234", response.GitCommit)
+	assert.Equal(t, []string{"destination_affinity"}, response.EnabledFeatures)
+	assert.GreaterOrEqual(t, response.UptimeSeconds, int64(90), "Uptime should be measured from start")
+	assert.Empty(t, response.SectionsScanned)
+
+	purchase(t, tm, "test@example.com", "London", "France")
+	response, err = tm.GetServerInfo(context.Background(), &pb.GetServerInfoRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{1}, response.SectionsScanned, "Seat assignment metrics should be reported")
+}
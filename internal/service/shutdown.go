@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Flusher is a queue or store holding work that must be written out before
+// the server exits, such as a pending snapshot.
+type Flusher interface {
+	Flush(ctx context.Context) error
+}
+
+// ShutdownStats summarises the run, as logged by Shutdown.
+type ShutdownStats struct {
+	BookingsServed int
+	Revenue        float64
+	OccupiedSeats  int
+	TotalSeats     int
+	Uptime         time.Duration
+}
+
+// Shutdown waits for pending notifications, releases any held seats, flushes
+// every registered Flusher and logs the final stats as one record. It gives up
+// waiting once ctx is done but still flushes and reports, returning the errors
+// it ran into. Call it after the gRPC server has stopped taking requests.
+func (tm *TicketManager) Shutdown(ctx context.Context) (ShutdownStats, error) {
+	var errs []error
+
+	waited := make(chan struct{})
+	go func() {
+		tm.WaitForNotifications()
+		close(waited)
+	}()
+	select {
+	case <-waited:
+	case <-ctx.Done():
+		errs = append(errs, fmt.Errorf("notifications still pending: %w", ctx.Err()))
+	}
+
+	// Holds don't survive a restart, so give their seats back before the
+	// booking state is flushed
+	tm.ReleaseHolds()
+
+	for _, flusher := range tm.Flushers {
+		if err := flusher.Flush(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	tm.mu.Lock()
+	stats := ShutdownStats{
+		BookingsServed: tm.bookingsServed,
+		Revenue:        tm.revenue,
+		Uptime:         tm.Now().Sub(tm.startedAt),
+	}
+	tm.mu.Unlock()
+	stats.OccupiedSeats, stats.TotalSeats = tm.SeatManager.Occupancy()
+
+	err := errors.Join(errs...)
+	tm.Logger.Info("Shutdown summary",
+		zap.Int("bookings_served", stats.BookingsServed),
+		zap.Float64("revenue", stats.Revenue),
+		zap.Int("occupied_seats", stats.OccupiedSeats),
+		zap.Int("total_seats", stats.TotalSeats),
+		zap.Duration("uptime", stats.Uptime),
+		zap.Error(err),
+	)
+	return stats, err
+}
@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+)
+
+// fakeStore records the flushes Shutdown asks of it.
+type fakeStore struct {
+	flushed int
+	err     error
+}
+
+func (s *fakeStore) Flush(ctx context.Context) error {
+	s.flushed++
+	return s.err
+}
+
+func TestShutdown(t *testing.T) {
+	tm := createTestTicketManager()
+	tm.startedAt = time.Now().Add(-time.Minute)
+	store := &fakeStore{}
+	tm.Flushers = []Flusher{store}
+
+	purchase(t, tm, "a@example.com", "London", "France")
+	purchase(t, tm, "b@example.com", "London", "France")
+	purchase(t, tm, "c@example.com", "London", "France")
+	_, err := tm.RemoveUser(context.Background(), &pb.RemoveUserRequest{Email: "c@example.com"})
+	assert.NoError(t, err)
+
+	stats, err := tm.Shutdown(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, store.flushed, "Store should be flushed once")
+	assert.Equal(t, 3, stats.BookingsServed, "Cancelled bookings still count as served")
+	assert.Equal(t, 60.0, stats.Revenue)
+	assert.Equal(t, 2, stats.OccupiedSeats)
+	assert.Equal(t, 40, stats.TotalSeats)
+	assert.GreaterOrEqual(t, stats.Uptime, time.Minute)
+}
+
+func TestShutdownFlushError(t *testing.T) {
+	tm := createTestTicketManager()
+	failing := &fakeStore{err: errors.New("disk full")}
+	healthy := &fakeStore{}
+	tm.Flushers = []Flusher{failing, healthy}
+
+	_, err := tm.Shutdown(context.Background())
+	assert.ErrorContains(t, err, "disk full")
+	assert.Equal(t, 1, healthy.flushed, "A failing store should not stop the others flushing")
+}
@@ -0,0 +1,362 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"go.uber.org/zap"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// snapshotVersion is the format written by Snapshot. Version 2 added seat
+// holds and waitlists; Restore also reads version 1, which has neither.
+const snapshotVersion = 2
+
+// minSnapshotVersion is the oldest format Restore reads.
+const minSnapshotVersion = 1
+
+// ErrSnapshotVersion is returned by Restore for snapshots written in a format
+// this build does not understand.
+var ErrSnapshotVersion = errors.New("unsupported snapshot version")
+
+// snapshot is the JSON document written by Snapshot. Receipts and history
+// events are stored in their protobuf JSON form. Departures holds the dated
+// departures of the default train by date, and Trains the seats of every
+// other train by train ID and then date, empty for its undated departure.
+// Holds are stored as protobuf JSON too, and Waitlists holds the queued
+// purchase requests of each section in queue order.
+type snapshot struct {
+	Version        int                                     `json:"version"`
+	Sections       []sectionSnapshot                       `json:"sections"`
+	NextSectionIdx int                                     `json:"next_section_idx"`
+	Receipts       map[string]json.RawMessage              `json:"receipts"`
+	History        map[string][]json.RawMessage            `json:"history"`
+	Departures     map[string]departureSnapshot            `json:"departures,omitempty"`
+	Trains         map[string]map[string]departureSnapshot `json:"trains,omitempty"`
+	Holds          []json.RawMessage                       `json:"holds,omitempty"`
+	Waitlists      map[string][]json.RawMessage            `json:"waitlists,omitempty"`
+}
+
+// departureSnapshot records the seats of a dated departure or another train.
+type departureSnapshot struct {
+	Sections       []sectionSnapshot `json:"sections"`
+	NextSectionIdx int               `json:"next_section_idx"`
+}
+
+// sectionSnapshot records a section's size and its occupied seats.
+type sectionSnapshot struct {
+	Name     string         `json:"name"`
+	MaxSeats int            `json:"max_seats"`
+	Seats    []seatSnapshot `json:"seats"`
+}
+
+// seatSnapshot records the route segments booked on an occupied seat, or that
+// the seat is blocked.
+type seatSnapshot struct {
+	Number  int    `json:"number"`
+	Booked  uint64 `json:"booked"`
+	Blocked bool   `json:"blocked,omitempty"`
+}
+
+// Snapshot writes the booking state, including seat assignments, holds and
+// waitlists, to w so it can be reloaded with Restore after a restart.
+func (tm *TicketManager) Snapshot(w io.Writer) error {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	snap := snapshot{
+		Version:  snapshotVersion,
+		Receipts: make(map[string]json.RawMessage, len(tm.Receipts)),
+		History:  make(map[string][]json.RawMessage, len(tm.History)),
+	}
+	snap.Sections, snap.NextSectionIdx = tm.SeatManager.snapshot()
+	for key, seats := range tm.departures {
+		var departure departureSnapshot
+		departure.Sections, departure.NextSectionIdx = seats.snapshot()
+		if key.train == tm.DefaultTrain {
+			if snap.Departures == nil {
+				snap.Departures = make(map[string]departureSnapshot)
+			}
+			snap.Departures[key.date] = departure
+			continue
+		}
+		if snap.Trains == nil {
+			snap.Trains = make(map[string]map[string]departureSnapshot)
+		}
+		if snap.Trains[key.train] == nil {
+			snap.Trains[key.train] = make(map[string]departureSnapshot)
+		}
+		snap.Trains[key.train][key.date] = departure
+	}
+
+	for email, receipt := range tm.Receipts {
+		data, err := protojson.Marshal(receipt)
+		if err != nil {
+			return fmt.Errorf("failed to encode receipt for %s: %w", email, err)
+		}
+		snap.Receipts[email] = data
+	}
+
+	for email, events := range tm.History {
+		encoded := make([]json.RawMessage, 0, len(events))
+		for _, event := range events {
+			data, err := protojson.Marshal(event)
+			if err != nil {
+				return fmt.Errorf("failed to encode history for %s: %w", email, err)
+			}
+			encoded = append(encoded, data)
+		}
+		snap.History[email] = encoded
+	}
+
+	for id, hold := range tm.holds {
+		data, err := protojson.Marshal(hold)
+		if err != nil {
+			return fmt.Errorf("failed to encode hold %s: %w", id, err)
+		}
+		snap.Holds = append(snap.Holds, data)
+	}
+
+	for section, queue := range tm.waitlists {
+		if len(queue) == 0 {
+			continue
+		}
+		if snap.Waitlists == nil {
+			snap.Waitlists = make(map[string][]json.RawMessage)
+		}
+		encoded := make([]json.RawMessage, 0, len(queue))
+		for _, entry := range queue {
+			data, err := protojson.Marshal(entry.request)
+			if err != nil {
+				return fmt.Errorf("failed to encode waitlist for section %s: %w", section, err)
+			}
+			encoded = append(encoded, data)
+		}
+		snap.Waitlists[section] = encoded
+	}
+
+	if err := json.NewEncoder(w).Encode(snap); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+
+	tm.Logger.Info("Snapshot written",
+		zap.Int("receipts", len(snap.Receipts)),
+		zap.Int("sections", len(snap.Sections)),
+		zap.Int("holds", len(snap.Holds)),
+	)
+	return nil
+}
+
+// Restore replaces the booking state with a snapshot read from r. The state is
+// left untouched if the snapshot cannot be decoded or does not fit the
+// configured sections.
+func (tm *TicketManager) Restore(r io.Reader) error {
+	var snap snapshot
+	if err := json.NewDecoder(r).Decode(&snap); err != nil {
+		return fmt.Errorf("failed to read snapshot: %w", err)
+	}
+
+	if snap.Version < minSnapshotVersion || snap.Version > snapshotVersion {
+		return fmt.Errorf("%w: %d (expected %d to %d)", ErrSnapshotVersion, snap.Version, minSnapshotVersion, snapshotVersion)
+	}
+
+	receipts := make(map[string]*pb.Receipt, len(snap.Receipts))
+	for email, data := range snap.Receipts {
+		receipt := &pb.Receipt{}
+		if err := protojson.Unmarshal(data, receipt); err != nil {
+			return fmt.Errorf("failed to decode receipt for %s: %w", email, err)
+		}
+		if receipt.Seat == nil {
+			return fmt.Errorf("receipt for %s has no seat", email)
+		}
+		receipts[email] = receipt
+	}
+
+	history := make(map[string][]*pb.ReceiptEvent, len(snap.History))
+	for email, encoded := range snap.History {
+		events := make([]*pb.ReceiptEvent, 0, len(encoded))
+		for _, data := range encoded {
+			event := &pb.ReceiptEvent{}
+			if err := protojson.Unmarshal(data, event); err != nil {
+				return fmt.Errorf("failed to decode history for %s: %w", email, err)
+			}
+			events = append(events, event)
+		}
+		history[email] = events
+	}
+
+	holds := make(map[string]*pb.SeatHold, len(snap.Holds))
+	for _, data := range snap.Holds {
+		hold := &pb.SeatHold{}
+		if err := protojson.Unmarshal(data, hold); err != nil {
+			return fmt.Errorf("failed to decode hold: %w", err)
+		}
+		if hold.HoldId == "" || hold.Seat == nil {
+			return fmt.Errorf("hold %q has no ID or seat", hold.HoldId)
+		}
+		holds[hold.HoldId] = hold
+	}
+
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	// Waitlisted requests are only valid for the configured sections and route
+	waitlists := make(map[string][]waitlistEntry, len(snap.Waitlists))
+	for section, encoded := range snap.Waitlists {
+		if !tm.SeatManager.HasSection(section) {
+			return fmt.Errorf("%w: waitlist for %s", ErrSectionNotFound, section)
+		}
+		queue := make([]waitlistEntry, 0, len(encoded))
+		for _, data := range encoded {
+			request := &pb.PurchaseTicketRequest{}
+			if err := protojson.Unmarshal(data, request); err != nil {
+				return fmt.Errorf("failed to decode waitlist for section %s: %w", section, err)
+			}
+			journey, err := tm.SeatManager.Journey(request.From, request.To)
+			if err != nil {
+				return fmt.Errorf("failed to restore waitlist for section %s: %w", section, err)
+			}
+			queue = append(queue, waitlistEntry{request: request, journey: journey})
+		}
+		waitlists[section] = queue
+	}
+
+	// Restore the other departures first, so a bad one leaves the state untouched
+	departures := make(map[departureKey]*SeatManager, len(snap.Departures))
+	for date, departure := range snap.Departures {
+		seats := tm.SeatManager.newDeparture()
+		if err := seats.restore(departure.Sections, departure.NextSectionIdx); err != nil {
+			return fmt.Errorf("failed to restore departure %s: %w", date, err)
+		}
+		departures[departureKey{tm.DefaultTrain, date}] = seats
+	}
+	for train, trainDepartures := range snap.Trains {
+		for date, departure := range trainDepartures {
+			seats := tm.SeatManager.newDeparture()
+			if err := seats.restore(departure.Sections, departure.NextSectionIdx); err != nil {
+				return fmt.Errorf("failed to restore train %s departure %q: %w", train, date, err)
+			}
+			departures[departureKey{train, date}] = seats
+		}
+	}
+	if err := tm.SeatManager.restore(snap.Sections, snap.NextSectionIdx); err != nil {
+		return err
+	}
+
+	tm.Receipts = receipts
+	tm.History = history
+	tm.orderTimelines()
+	tm.departures = departures
+	tm.departuresAdded()
+	tm.recentPurchases = make(map[purchaseKey]recentPurchase)
+	tm.waitlists = waitlists
+	tm.holds = holds
+	tm.userHolds = make(map[string]int)
+	for _, hold := range holds {
+		tm.userHolds[hold.Email]++
+	}
+	tm.destinationCounts = make(map[destinationKey]map[string]int)
+	tm.routeCounts = make(map[string]int)
+	tm.fareClassCounts = make(map[string]int)
+	tm.references = make(map[string]string)
+	tm.assignmentTraces = make(map[string]AssignmentTrace)
+	for email, receipt := range receipts {
+		tm.trackDestination(receipt, receipt.Seat.Section, 1)
+		tm.trackRoute(receipt.From, receipt.To, 1)
+		tm.trackFareClass(receipt.FareClass, 1)
+		if receipt.BookingReference != "" {
+			tm.references[receipt.BookingReference] = email
+		}
+	}
+	// Receipts from snapshots taken before booking references existed get one
+	for email, receipt := range receipts {
+		if receipt.BookingReference == "" {
+			receipt.BookingReference = tm.newReference()
+			tm.references[receipt.BookingReference] = email
+		}
+	}
+
+	tm.Logger.Info("Snapshot restored",
+		zap.Int("receipts", len(receipts)),
+		zap.Int("sections", len(snap.Sections)),
+		zap.Int("holds", len(holds)),
+	)
+	return nil
+}
+
+// snapshot returns the size and occupied seats of every section, in section
+// order, along with the round-robin position.
+func (sm *SeatManager) snapshot() ([]sectionSnapshot, int) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	sections := make([]sectionSnapshot, 0, len(sm.SectionOrder))
+	for _, name := range sm.SectionOrder {
+		section := sm.Sections[name]
+		seats := make([]seatSnapshot, 0)
+		for seatNum := 1; seatNum <= section.MaxSeats; seatNum++ {
+			if seat, exists := section.Seats[seatNum]; exists && !seat.Available {
+				seats = append(seats, seatSnapshot{Number: seatNum, Booked: seat.bookedSegments(), Blocked: seat.Blocked})
+			}
+		}
+		sections = append(sections, sectionSnapshot{Name: name, MaxSeats: section.MaxSeats, Seats: seats})
+	}
+	return sections, sm.nextSectionIdx
+}
+
+// restore rebuilds the seat state from a snapshot. Every section in the
+// snapshot must be configured; configured sections missing from it start empty.
+func (sm *SeatManager) restore(snapSections []sectionSnapshot, nextSectionIdx int) error {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	defer sm.seatsChanged()
+
+	restored := make(map[string]*Section, len(sm.Sections))
+	for _, snapSection := range snapSections {
+		current, exists := sm.Sections[snapSection.Name]
+		if !exists {
+			return fmt.Errorf("%w: %s", ErrSectionNotFound, snapSection.Name)
+		}
+		if snapSection.MaxSeats <= 0 {
+			return fmt.Errorf("section %s must have at least one seat", snapSection.Name)
+		}
+
+		section := newSection(current.Name, snapSection.MaxSeats)
+		section.Descending = current.Descending
+		for _, snapSeat := range snapSection.Seats {
+			seat, exists := section.Seats[snapSeat.Number]
+			if !exists {
+				return fmt.Errorf("%w: seat %d in section %s", ErrSeatNotFound, snapSeat.Number, snapSection.Name)
+			}
+			if snapSeat.Blocked {
+				section.block(snapSeat.Number)
+				continue
+			}
+			if snapSeat.Booked == 0 || !seat.Available {
+				continue
+			}
+			seat.booked = snapSeat.Booked
+			seat.Available = false
+			section.VacantSeats--
+		}
+		recomputeFirstVacant(section)
+		restored[section.Name] = section
+	}
+
+	for name, section := range sm.Sections {
+		if _, exists := restored[name]; !exists {
+			restored[name] = emptySection(section)
+		}
+	}
+
+	sm.Sections = restored
+	sm.nextSectionIdx = 0
+	if nextSectionIdx >= 0 && nextSectionIdx < len(sm.SectionOrder) {
+		sm.nextSectionIdx = nextSectionIdx
+	}
+	return nil
+}
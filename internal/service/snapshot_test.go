@@ -0,0 +1,157 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/sanjaykishor/rail-connect/internal/config"
+	pb "github.com/sanjaykishor/rail-connect/proto"
+)
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	tm := createTestTicketManager()
+	tm.SeatManager.Route = []string{"London", "Paris", "France"}
+	tm.StationConnection["London-Paris"] = 10.00
+	tm.StationConnection["Paris-France"] = 10.00
+
+	for _, req := range []*pb.PurchaseTicketRequest{
+		{User: &pb.User{Email: "user1@example.com"}, From: "London", To: "Paris"},
+		{User: &pb.User{Email: "user2@example.com"}, From: "Paris", To: "France"},
+		{User: &pb.User{Email: "user3@example.com"}, From: "London", To: "France"},
+		{User: &pb.User{Email: "user4@example.com"}, From: "London", To: "France"},
+	} {
+		_, err := tm.PurchaseTicket(context.Background(), req)
+		assert.NoError(t, err)
+	}
+	_, err := tm.RemoveUser(context.Background(), &pb.RemoveUserRequest{Email: "user4@example.com"})
+	assert.NoError(t, err)
+	assert.NoError(t, tm.SeatManager.ResizeSection("B", 25))
+
+	var buf bytes.Buffer
+	assert.NoError(t, tm.Snapshot(&buf))
+
+	restored := createTestTicketManager()
+	restored.SeatManager.Route = tm.SeatManager.Route
+	assert.NoError(t, restored.Restore(&buf))
+
+	assert.Equal(t, len(tm.Receipts), len(restored.Receipts))
+	for email, receipt := range tm.Receipts {
+		assert.True(t, proto.Equal(receipt, restored.Receipts[email]), "Receipt for %s should be restored", email)
+	}
+	assert.Equal(t, tm.references, restored.references, "Booking references should be restored")
+	assert.Equal(t, len(tm.History), len(restored.History))
+	for email, events := range tm.History {
+		assert.Equal(t, len(events), len(restored.History[email]))
+		for i, event := range events {
+			assert.True(t, proto.Equal(event, restored.History[email][i]), "History for %s should be restored", email)
+		}
+	}
+	assert.Equal(t, tm.SeatManager.Sections, restored.SeatManager.Sections, "Seat state should be restored")
+	assert.Equal(t, tm.SeatManager.nextSectionIdx, restored.SeatManager.nextSectionIdx)
+	assert.Equal(t, tm.destinationCounts, restored.destinationCounts)
+	assert.Equal(t, tm.routeCounts, restored.routeCounts)
+
+	// Both managers hand out the same seat next
+	req := &pb.PurchaseTicketRequest{User: &pb.User{Email: "user5@example.com"}, From: "London", To: "France"}
+	original, err := tm.PurchaseTicket(context.Background(), req)
+	assert.NoError(t, err)
+	reloaded, err := restored.PurchaseTicket(context.Background(), req)
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(original.Receipt.Seat, reloaded.Receipt.Seat))
+}
+
+func TestSnapshotHoldsAndWaitlists(t *testing.T) {
+	newManager := func() *TicketManager {
+		tm := createOverflowTicketManager(config.OverflowWaitlist)
+		tm.HoldDuration = 5 * time.Minute
+		tm.Clock = NewFakeClock(time.Date(2024, 3, 10, 9, 0, 0, 0, time.UTC))
+		return tm
+	}
+	tm := newManager()
+	fillSectionA(t, tm)
+	response, err := tryPurchase(tm, "late@example.com", inSection("A"))
+	assert.NoError(t, err)
+	assert.Equal(t, "A", response.WaitlistedSection)
+	held, err := tm.HoldSeat(context.Background(), &pb.HoldSeatRequest{
+		Email: "holder@example.com",
+		From:  "London",
+		To:    "France",
+		Seat:  &pb.Seat{Section: "B", SeatNumber: 1},
+	})
+	assert.NoError(t, err)
+
+	var buf bytes.Buffer
+	assert.NoError(t, tm.Snapshot(&buf))
+	restored := newManager()
+	assert.NoError(t, restored.Restore(&buf))
+
+	// The hold survives and still owns its seat
+	assert.Len(t, restored.holds, 1)
+	assert.True(t, proto.Equal(held.Hold, restored.holds[held.Hold.HoldId]))
+	assert.Equal(t, 1, restored.userHolds["holder@example.com"])
+	_, err = restored.ReleaseHold(context.Background(), &pb.ReleaseHoldRequest{HoldId: held.Hold.HoldId, Email: "holder@example.com"})
+	assert.NoError(t, err)
+	assert.Equal(t, 4, restored.SeatManager.VacantSeats("B"), "Releasing a restored hold should free its seat")
+
+	// The waitlisted rider is booked once a seat frees up
+	assert.Len(t, restored.waitlists["A"], 1)
+	_, err = restored.RemoveUser(context.Background(), &pb.RemoveUserRequest{Email: "first@example.com"})
+	assert.NoError(t, err)
+	assert.Contains(t, restored.Receipts, "late@example.com")
+	assert.Empty(t, restored.waitlists["A"])
+}
+
+func TestRestoreVersion1Snapshot(t *testing.T) {
+	tm := createTestTicketManager()
+	snapshot := `{
+		"version": 1,
+		"sections": [{"name": "A", "max_seats": 20, "seats": [{"number": 1, "booked": 1}]}],
+		"receipts": {"test@example.com": {"user": {"email": "test@example.com"}, "from": "London", "to": "France", "seat": {"section": "A", "seatNumber": 1}}}
+	}`
+
+	assert.NoError(t, tm.Restore(strings.NewReader(snapshot)), "Snapshots from before holds and waitlists should be restored")
+	assert.Contains(t, tm.Receipts, "test@example.com")
+	assert.Equal(t, 19, tm.SeatManager.VacantSeats("A"))
+	assert.Empty(t, tm.holds)
+	assert.Empty(t, tm.waitlists)
+}
+
+func TestRestoreRejectsBadSnapshots(t *testing.T) {
+	tests := []struct {
+		name     string
+		snapshot string
+	}{
+		{"Version Mismatch", `{"version": 99}`},
+		{"Version Too Old", `{"version": 0}`},
+		{"Malformed", `{"version": `},
+		{"Unknown Section", `{"version": 2, "sections": [{"name": "Z", "max_seats": 5}]}`},
+		{"Unknown Seat", `{"version": 2, "sections": [{"name": "A", "max_seats": 5, "seats": [{"number": 9, "booked": 1}]}]}`},
+		{"Waitlist For Unknown Section", `{"version": 2, "waitlists": {"Z": []}}`},
+		{"Hold Without Seat", `{"version": 2, "holds": [{"holdId": "H1"}]}`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tm := createTestTicketManager()
+			_, err := tm.PurchaseTicket(context.Background(), &pb.PurchaseTicketRequest{
+				User: &pb.User{Email: "user1@example.com"},
+				From: "London",
+				To:   "France",
+			})
+			assert.NoError(t, err)
+
+			assert.Error(t, tm.Restore(strings.NewReader(test.snapshot)))
+			assert.Len(t, tm.Receipts, 1, "Failed restore should leave bookings untouched")
+			assert.Equal(t, 19, tm.SeatManager.VacantSeats("A"), "Failed restore should leave seats untouched")
+		})
+	}
+
+	err := createTestTicketManager().Restore(strings.NewReader(`{"version": 99}`))
+	assert.ErrorIs(t, err, ErrSnapshotVersion)
+}
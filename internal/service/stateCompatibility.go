@@ -0,0 +1,105 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/sanjaykishor/rail-connect/internal/config"
+)
+
+// StateConflict is a configured section change that would take away seats
+// riders are sitting in.
+type StateConflict struct {
+	// TrainId and DepartureDate name a dated departure; both are empty for
+	// the undated default train
+	TrainId       string
+	DepartureDate string
+	Section       string
+	Change        string // SectionShrunk or SectionRemoved
+	MaxSeats      int    // Configured seat count; zero for removals
+	OccupiedSeats []int  // Occupied seats the change takes away, in order
+}
+
+// CompatibilityReport says whether a configuration can be applied to a
+// running train as it is.
+type CompatibilityReport struct {
+	// Invalid is why the configuration fails validation, nil if it passes
+	Invalid error
+	// Conflicts are the section changes that would unseat riders, in the
+	// train's section order
+	Conflicts []StateConflict
+}
+
+// Compatible reports whether the configuration is valid and takes away no
+// occupied seat.
+func (r CompatibilityReport) Compatible() bool {
+	return r.Invalid == nil && len(r.Conflicts) == 0
+}
+
+// Err returns why the configuration is incompatible, reporting the first
+// conflict when it is valid, or nil if it is compatible.
+func (r CompatibilityReport) Err() error {
+	if r.Invalid != nil {
+		return r.Invalid
+	}
+	if len(r.Conflicts) > 0 {
+		conflict := r.Conflicts[0]
+		if conflict.DepartureDate != "" {
+			return fmt.Errorf("%w: section %s %s would take away seats %v of train %s on %s", ErrSeatOccupied, conflict.Section, conflict.Change, conflict.OccupiedSeats, conflict.TrainId, conflict.DepartureDate)
+		}
+		return fmt.Errorf("%w: section %s %s would take away seats %v", ErrSeatOccupied, conflict.Section, conflict.Change, conflict.OccupiedSeats)
+	}
+	return nil
+}
+
+// ValidateAgainstState checks a configuration against the live seats of a
+// train before it is applied: it must pass validation, and no section may
+// shrink below an occupied seat or be removed while occupied. Adding and
+// growing sections never conflict.
+func ValidateAgainstState(cfg *config.Config, sm *SeatManager) CompatibilityReport {
+	return CompatibilityReport{Invalid: cfg.Validate(), Conflicts: sectionConflicts(cfg, sm)}
+}
+
+// validateAgainstDepartures is ValidateAgainstState for every dated
+// departure, whose conflicts follow in train and date order. The default
+// train is left out: ReloadSections moves its riders out of the seats a
+// change takes away. Callers must hold tm.mu.
+func (tm *TicketManager) validateAgainstDepartures(cfg *config.Config) CompatibilityReport {
+	report := CompatibilityReport{Invalid: cfg.Validate()}
+
+	for _, key := range tm.departureKeys() {
+		for _, conflict := range sectionConflicts(cfg, tm.departures[key]) {
+			conflict.TrainId, conflict.DepartureDate = key.train, key.date
+			report.Conflicts = append(report.Conflicts, conflict)
+		}
+	}
+	return report
+}
+
+// sectionConflicts returns the configured section changes that would take
+// away occupied seats of sm, in its section order.
+func sectionConflicts(cfg *config.Config, sm *SeatManager) []StateConflict {
+	configured := make(map[string]int, len(cfg.Sections))
+	for _, section := range cfg.Sections {
+		configured[section.Name] = section.MaxSeats
+	}
+
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	var conflicts []StateConflict
+	for _, name := range sm.SectionOrder {
+		conflict := StateConflict{Section: name, Change: SectionRemoved}
+		maxSeats, kept := configured[name]
+		if kept {
+			if maxSeats >= sm.Sections[name].MaxSeats {
+				continue
+			}
+			conflict.Change, conflict.MaxSeats = SectionShrunk, maxSeats
+		}
+		conflict.OccupiedSeats = sm.Sections[name].occupiedFrom(maxSeats + 1)
+		if len(conflict.OccupiedSeats) > 0 {
+			conflicts = append(conflicts, conflict)
+		}
+	}
+	return conflicts
+}
@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sanjaykishor/rail-connect/internal/config"
+	pb "github.com/sanjaykishor/rail-connect/proto"
+)
+
+// stateTestConfig returns a configuration with the given sections and the
+// route the overflow test ticket manager sells.
+func stateTestConfig(sections ...config.SectionConfig) *config.Config {
+	return &config.Config{
+		Sections: sections,
+		Stations: config.StationConfig{Prices: map[string]float64{"London-France": 20.00}},
+	}
+}
+
+func TestValidateAgainstStateCompatible(t *testing.T) {
+	tm := createOverflowTicketManager("")
+	fillSectionA(t, tm)
+
+	// Growing, adding and shrinking or removing only free seats are all safe
+	report := ValidateAgainstState(stateTestConfig(
+		config.SectionConfig{Name: "A", MaxSeats: 3},
+		config.SectionConfig{Name: "C", MaxSeats: 5},
+	), tm.SeatManager)
+	assert.True(t, report.Compatible())
+	assert.NoError(t, report.Err())
+	assert.Empty(t, report.Conflicts)
+
+	report = ValidateAgainstState(stateTestConfig(
+		config.SectionConfig{Name: "A", MaxSeats: 2},
+		config.SectionConfig{Name: "B", MaxSeats: 1},
+	), tm.SeatManager)
+	assert.True(t, report.Compatible())
+}
+
+func TestValidateAgainstStateIncompatible(t *testing.T) {
+	tm := createOverflowTicketManager("")
+	fillSectionA(t, tm)
+	_, err := tryPurchase(tm, "third@example.com", inSection("B"))
+	assert.NoError(t, err)
+
+	report := ValidateAgainstState(stateTestConfig(
+		config.SectionConfig{Name: "A", MaxSeats: 1},
+	), tm.SeatManager)
+	assert.False(t, report.Compatible())
+	assert.NoError(t, report.Invalid)
+	assert.Equal(t, []StateConflict{
+		{Section: "A", Change: SectionShrunk, MaxSeats: 1, OccupiedSeats: []int{2}},
+		{Section: "B", Change: SectionRemoved, OccupiedSeats: []int{1}},
+	}, report.Conflicts)
+	assert.True(t, errors.Is(report.Err(), ErrSeatOccupied))
+
+	// Checking applies nothing
+	_, maxSeats, _ := tm.SeatManager.SectionVacancy("A")
+	assert.Equal(t, 2, maxSeats)
+	assert.Equal(t, []string{"A", "B"}, tm.SeatManager.SectionOrder)
+
+	// An invalid configuration is reported as well as its conflicts
+	report = ValidateAgainstState(stateTestConfig(
+		config.SectionConfig{Name: "A", MaxSeats: 1, OverflowPolicy: "bounce"},
+	), tm.SeatManager)
+	assert.Error(t, report.Invalid)
+	assert.Equal(t, report.Invalid, report.Err())
+	assert.Len(t, report.Conflicts, 2)
+}
+
+func TestReloadCompatibleSections(t *testing.T) {
+	tm := createOverflowTicketManager("")
+	tm.AdvanceDays = 7
+	tm.Clock = NewFakeClock(time.Date(2024, 3, 10, 9, 0, 0, 0, time.UTC))
+	dated := purchase(t, tm, "dated@example.com", "London", "France", onDate("2024-03-11"))
+
+	// A seat taken only on a dated departure still blocks the reload
+	compatibility, report := tm.ReloadCompatibleSections(stateTestConfig(
+		config.SectionConfig{Name: "B", MaxSeats: 4},
+	))
+	assert.False(t, compatibility.Compatible())
+	assert.Equal(t, []StateConflict{{
+		TrainId:       tm.DefaultTrain,
+		DepartureDate: "2024-03-11",
+		Section:       dated.Seat.Section,
+		Change:        SectionRemoved,
+		OccupiedSeats: []int{int(dated.Seat.SeatNumber)},
+	}}, compatibility.Conflicts)
+	assert.ErrorContains(t, compatibility.Err(), "2024-03-11")
+	assert.Empty(t, report.Applied)
+	assert.Equal(t, []string{"A", "B"}, tm.SeatManager.SectionOrder, "A rejected reload should change nothing")
+
+	// Riders of the default train are moved out of the seats a shrink takes away
+	fillSectionA(t, tm)
+	compatibility, report = tm.ReloadCompatibleSections(stateTestConfig(
+		config.SectionConfig{Name: "A", MaxSeats: 1},
+		config.SectionConfig{Name: "B", MaxSeats: 4},
+	))
+	assert.True(t, compatibility.Compatible())
+	assert.Equal(t, []SectionChange{{Section: "A", Change: SectionShrunk, MaxSeats: 1, Reason: "no free seat below 2 for second@example.com"}}, report.Skipped, "Seat 1 of A is taken, so the rider in seat 2 can't be moved")
+
+	_, err := tm.RemoveUser(context.Background(), &pb.RemoveUserRequest{Email: "first@example.com"})
+	assert.NoError(t, err)
+	compatibility, report = tm.ReloadCompatibleSections(stateTestConfig(
+		config.SectionConfig{Name: "A", MaxSeats: 1},
+		config.SectionConfig{Name: "B", MaxSeats: 4},
+	))
+	assert.True(t, compatibility.Compatible())
+	assert.Equal(t, []SectionChange{{Section: "A", Change: SectionShrunk, MaxSeats: 1, Migrated: 1}}, report.Applied)
+	assert.Equal(t, &pb.Seat{Section: "A", SeatNumber: 1}, tm.Receipts["second@example.com"].Seat)
+	_, maxSeats, _ := tm.SeatManager.SectionVacancy("A")
+	assert.Equal(t, 1, maxSeats)
+
+	// A compatible reload is applied
+	compatibility, report = tm.ReloadCompatibleSections(stateTestConfig(
+		config.SectionConfig{Name: "A", MaxSeats: 1},
+		config.SectionConfig{Name: "B", MaxSeats: 4},
+		config.SectionConfig{Name: "C", MaxSeats: 2},
+	))
+	assert.True(t, compatibility.Compatible())
+	assert.Equal(t, []SectionChange{{Section: "C", Change: SectionAdded, MaxSeats: 2}}, report.Applied)
+	assert.Equal(t, []string{"A", "B", "C"}, tm.SeatManager.SectionOrder)
+}
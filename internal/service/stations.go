@@ -0,0 +1,12 @@
+package service
+
+import "github.com/sanjaykishor/rail-connect/internal/config"
+
+// station returns name as used for lookups: in canonical form when
+// CanonicalizeStations is set, otherwise unchanged.
+func (tm *TicketManager) station(name string) string {
+	if !tm.CanonicalizeStations {
+		return name
+	}
+	return config.CanonicalStation(name)
+}
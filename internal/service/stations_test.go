@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCanonicalStationNames(t *testing.T) {
+	tm := createTestTicketManager()
+	tm.CanonicalizeStations = true
+
+	tests := []struct {
+		email, from, to string
+	}{
+		{"spaced@example.com", " london ", "france"},
+		{"upper@example.com", "LONDON", "FRANCE"},
+		{"collapsed@example.com", "London", "  France\t"},
+	}
+	for _, test := range tests {
+		receipt := purchase(t, tm, test.email, test.from, test.to)
+		assert.Equal(t, 20.00, receipt.PricePaid, "Should resolve to the configured London pricing")
+		assert.Equal(t, "London", receipt.From, "Receipt should show the canonical name")
+		assert.Equal(t, "France", receipt.To)
+	}
+
+	response, err := tm.GetUsersByRoute(context.Background(), &pb.GetUsersByRouteRequest{From: "london", To: "france"})
+	assert.NoError(t, err)
+	assert.Len(t, response.Users, 3)
+
+	_, err = tm.PurchaseTicket(context.Background(), &pb.PurchaseTicketRequest{
+		User: &pb.User{FirstName: "Blank", LastName: "Station", Email: "blank@example.com"},
+		From: "  ",
+		To:   "France",
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "Whitespace-only station should be missing")
+}
+
+func TestStationNamesCaseSensitiveByDefault(t *testing.T) {
+	tm := createTestTicketManager()
+
+	_, err := tm.PurchaseTicket(context.Background(), &pb.PurchaseTicketRequest{
+		User: &pb.User{FirstName: "Test", LastName: "User", Email: "test@example.com"},
+		From: "london",
+		To:   "France",
+	})
+	assert.Error(t, err, "Without canonicalization station names must match exactly")
+}
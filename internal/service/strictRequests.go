@@ -0,0 +1,24 @@
+package service
+
+import (
+	pb "github.com/sanjaykishor/rail-connect/proto"
+)
+
+// purchaseConflict describes the first pair of PurchaseTicket fields that
+// contradict each other, or returns empty if there is none. Each pair is
+// accepted outside strict mode, with one of the fields ignored:
+//   - a loyalty tier with minimizePrice, which picks the cheapest section
+//     whatever the tier
+//   - a loyalty tier with a held seat, which is already chosen
+//   - provisional with dryRun, which books nothing to pay for
+func purchaseConflict(req *pb.PurchaseTicketRequest) string {
+	switch {
+	case req.LoyaltyTier != "" && req.MinimizePrice:
+		return "loyalty tier cannot be combined with minimize price"
+	case req.LoyaltyTier != "" && req.HoldId != "":
+		return "loyalty tier cannot be combined with a held seat"
+	case req.Provisional && req.DryRun:
+		return "provisional cannot be combined with dry run"
+	}
+	return ""
+}
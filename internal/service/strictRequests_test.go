@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+)
+
+// createStrictTicketManager returns a test ticket manager taking provisional
+// bookings and seat holds, with strict request checking set as given.
+func createStrictTicketManager(strict bool) *TicketManager {
+	tm := createProvisionalTicketManager()
+	tm.HoldDuration = 5 * time.Minute
+	tm.StrictRequests = strict
+	return tm
+}
+
+func TestStrictRequestsContradictoryPurchases(t *testing.T) {
+	tests := []struct {
+		name     string
+		hold     bool
+		req      *pb.PurchaseTicketRequest
+		conflict string
+	}{
+		{
+			name:     "loyalty tier with minimize price",
+			req:      &pb.PurchaseTicketRequest{LoyaltyTier: "gold", MinimizePrice: true},
+			conflict: "loyalty tier cannot be combined with minimize price",
+		},
+		{
+			name:     "loyalty tier with held seat",
+			hold:     true,
+			req:      &pb.PurchaseTicketRequest{LoyaltyTier: "gold"},
+			conflict: "loyalty tier cannot be combined with a held seat",
+		},
+		{
+			name:     "provisional dry run",
+			req:      &pb.PurchaseTicketRequest{Provisional: true, DryRun: true},
+			conflict: "provisional cannot be combined with dry run",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, strict := range []bool{false, true} {
+				tm := createStrictTicketManager(strict)
+				req := &pb.PurchaseTicketRequest{
+					User:          &pb.User{FirstName: "Sanjay", LastName: "Kishor", Email: "test@example.com"},
+					From:          "London",
+					To:            "France",
+					LoyaltyTier:   tt.req.LoyaltyTier,
+					MinimizePrice: tt.req.MinimizePrice,
+					Provisional:   tt.req.Provisional,
+					DryRun:        tt.req.DryRun,
+				}
+				if tt.hold {
+					response, err := holdSeat(tm, "test@example.com", 7)
+					if err != nil {
+						t.Fatalf("Failed to hold seat: %v", err)
+					}
+					req.HoldId = response.Hold.HoldId
+				}
+
+				_, err := tm.PurchaseTicket(context.Background(), req)
+				if !strict {
+					assert.NoError(t, err, "Contradictory fields should be tolerated outside strict mode")
+					continue
+				}
+				assert.Equal(t, codes.InvalidArgument, status.Code(err))
+				assert.Equal(t, tt.conflict, status.Convert(err).Message())
+				assert.NotContains(t, tm.Receipts, "test@example.com")
+			}
+		})
+	}
+}
+
+func TestStrictRequestsConsistentPurchase(t *testing.T) {
+	tm := createStrictTicketManager(true)
+
+	_, err := tm.PurchaseTicket(context.Background(), &pb.PurchaseTicketRequest{
+		User:              &pb.User{FirstName: "Sanjay", LastName: "Kishor", Email: "test@example.com"},
+		From:              "London",
+		To:                "France",
+		LoyaltyTier:       "gold",
+		PreferredSections: []string{"B"},
+		Provisional:       true,
+	})
+	assert.NoError(t, err, "Fields that work together should be accepted in strict mode")
+}
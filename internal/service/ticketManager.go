@@ -2,12 +2,19 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"go.uber.org/zap"
 
+	"github.com/sanjaykishor/rail-connect/internal/config"
 	pb "github.com/sanjaykishor/rail-connect/proto"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -19,34 +26,240 @@ type TicketManager struct {
 	pb.UnimplementedTicketBookingServiceServer
 	SeatManager       *SeatManager
 	Receipts          map[string]*pb.Receipt
+	History           map[string][]*pb.ReceiptEvent
+	timelines         *timelineOrder // Order to forget History in once it is full
+	Archived          []*pb.Receipt  // Receipts of finished services, moved out of Receipts by Turnaround
 	mu                sync.Mutex
 	StationConnection map[string]float64
 	Logger            *zap.Logger
+
+	// DedupeWindow is how long a repeated purchase for the same user and route
+	// returns the original receipt. Zero disables duplicate detection.
+	DedupeWindow    time.Duration
+	recentPurchases map[purchaseKey]recentPurchase
+
+	// RebookCooldown is how long a user who cancelled with RemoveUser must
+	// wait before booking again. Zero disables it. recentCancels holds when
+	// each user last cancelled.
+	RebookCooldown time.Duration
+	recentCancels  map[string]time.Time
+
+	// AdvanceDays is how many days ahead a dated departure can be booked. Zero
+	// disables dated departures. departures holds the seats of each train
+	// other than the undated default train, which uses SeatManager.
+	AdvanceDays int
+	departures  map[departureKey]*SeatManager
+
+	// DefaultTrain is booked by requests that name no train. Trains lists
+	// every train the server runs, each with its own seats laid out like
+	// SeatManager; empty runs only the default train.
+	DefaultTrain string
+	Trains       []string
+
+	// PaymentWindow is how long a provisional booking waits for
+	// ConfirmPayment before it is cancelled. Zero disables provisional
+	// bookings.
+	PaymentWindow time.Duration
+
+	// HoldDuration is how long HoldSeat sets a seat aside before releasing
+	// it. Zero disables seat holds. MaxHoldsPerUser caps the holds one user
+	// may have at once; zero leaves them uncapped. holds keeps the active
+	// holds by ID and userHolds counts them per email.
+	HoldDuration    time.Duration
+	MaxHoldsPerUser int
+	holds           map[string]*pb.SeatHold
+	userHolds       map[string]int
+
+	// RouteCaps limits the tickets sold per route, keyed "From-To". Routes
+	// without a cap are limited only by seat availability.
+	RouteCaps   map[string]int
+	routeCounts map[string]int
+
+	// FareClasses are pools of discounted fares by name, and fareClassCounts
+	// the tickets sold in each
+	FareClasses     map[string]FareClass
+	fareClassCounts map[string]int
+
+	// CanonicalizeStations matches station names regardless of case and
+	// spacing. The configured names must already be canonical.
+	CanonicalizeStations bool
+
+	// StrictRequests rejects requests whose fields contradict each other
+	// instead of ignoring one of them
+	StrictRequests bool
+
+	// waitlists queue purchases for full sections whose overflow policy is
+	// waitlist, keyed by section
+	waitlists map[string][]waitlistEntry
+
+	// lastCancellation and cancellationGap track the moving average time
+	// between cancellations over cancellationGaps gaps, for wait estimates
+	lastCancellation time.Time
+	cancellationGap  time.Duration
+	cancellationGaps int
+
+	// DestinationAffinity seats riders in the section already hosting the most
+	// riders to the same destination, falling back to round-robin.
+	DestinationAffinity bool
+	destinationCounts   map[destinationKey]map[string]int
+
+	// LoyaltySections lists the sections each loyalty tier is seated in
+	// first when they have a free seat, before the usual seating strategies.
+	LoyaltySections map[string][]string
+
+	// maintenance disables mutating RPCs while set
+	maintenance atomic.Bool
+
+	// DistanceFares prices station pairs missing from StationConnection
+	DistanceFares DistanceFares
+
+	// ReferenceFormat sets the length and alphabet of booking references.
+	// references maps each receipt's reference to its email.
+	ReferenceFormat ReferenceFormat
+	references      map[string]string
+	randomIndex     func(n int) int
+
+	// assignmentTraces records how each booking's seat was chosen, keyed by
+	// booking reference
+	assignmentTraces map[string]AssignmentTrace
+
+	// TimeWindows adjust fares by the time of booking, e.g. for peak hours
+	TimeWindows []TimeWindow
+
+	// FareRounding is the config rounding mode applied to every fare; empty
+	// rounds to the nearest cent
+	FareRounding string
+
+	// BookingFee is added to the fare of every ticket and itemized on the
+	// receipt
+	BookingFee float64
+
+	// RefundPolicy sets how much of the fare a cancellation returns
+	RefundPolicy RefundPolicy
+
+	// Clock tells the time of bookings, cancellations and every other
+	// time-dependent decision. It defaults to SystemClock and is replaced in
+	// tests to control the time.
+	Clock Clock
+
+	// Notifier is told about bookings, seat changes and cancellations in the
+	// background after they succeed
+	Notifier      Notifier
+	notifications sync.WaitGroup
+
+	// hooks let code outside the service vet purchases and react to bookings
+	// and cancellations, registered with OnBeforePurchase, OnAfterPurchase
+	// and OnCancel
+	hooks hooks
+
+	// Flushers are flushed by Shutdown before the server exits
+	Flushers []Flusher
+	// ReadinessChecks must all pass for the server to report itself ready
+	ReadinessChecks []ReadinessCheck
+
+	// bookingsServed and revenue count the tickets sold since startup
+	bookingsServed int
+	revenue        float64
+
+	// trends remembers recent bookings and cancellations for GetBookingTrends
+	trends trendEvents
+
+	// cancellationReasons counts the tickets cancelled since startup by
+	// reason, for GetCancellationStats
+	cancellationReasons map[pb.CancellationReason]int
+
+	// Config is the configuration the service was built from, reported with
+	// its live values by GetConfig. Nil disables GetConfig.
+	Config *config.Config
+
+	// reloadedConfig is the configuration last recorded by RecordConfigReload,
+	// and configChanges and configReloadedAt what it changed and when, for
+	// GetConfigChanges
+	reloadedConfig   *config.Config
+	configChanges    []config.ConfigChange
+	configReloadedAt time.Time
+
+	// BuildInfo is reported by GetServerInfo
+	BuildInfo BuildInfo
+	startedAt time.Time
+
+	// watchersStopped is closed by StopWatchers to end WatchAvailability streams
+	watchersStopped  chan struct{}
+	stopWatchersOnce sync.Once
+	// departureAdded is closed and replaced whenever a departure is first
+	// booked, waking streams watching a departure nobody had booked yet
+	departureAdded chan struct{}
+}
+
+// purchaseKey identifies a purchase for duplicate detection.
+type purchaseKey struct {
+	email string
+	from  string
+	to    string
+	train string
+	date  string
+}
+
+// recentPurchase records when a receipt was issued for a purchaseKey.
+type recentPurchase struct {
+	receipt     *pb.Receipt
+	companion   *pb.Receipt
+	pairSeating pb.PairSeating
+	purchasedAt time.Time
 }
 
 // NewTicketManager creates a new TicketManager with the given seat manager and connection stations
 // and initializes the receipts map.
 func NewTicketManager(seatManager *SeatManager, connectionStations map[string]float64, logger *zap.Logger) *TicketManager {
 	return &TicketManager{
-		SeatManager:       seatManager,
-		StationConnection: connectionStations,
-		Receipts:          make(map[string]*pb.Receipt),
-		Logger:            logger,
+		SeatManager:         seatManager,
+		StationConnection:   connectionStations,
+		Receipts:            make(map[string]*pb.Receipt),
+		History:             make(map[string][]*pb.ReceiptEvent),
+		timelines:           newTimelineOrder(),
+		Logger:              logger,
+		recentPurchases:     make(map[purchaseKey]recentPurchase),
+		recentCancels:       make(map[string]time.Time),
+		departures:          make(map[departureKey]*SeatManager),
+		holds:               make(map[string]*pb.SeatHold),
+		userHolds:           make(map[string]int),
+		destinationCounts:   make(map[destinationKey]map[string]int),
+		waitlists:           make(map[string][]waitlistEntry),
+		routeCounts:         make(map[string]int),
+		fareClassCounts:     make(map[string]int),
+		ReferenceFormat:     NewReferenceFormat(config.ReferenceConfig{}),
+		references:          make(map[string]string),
+		assignmentTraces:    make(map[string]AssignmentTrace),
+		cancellationReasons: make(map[pb.CancellationReason]int),
+		randomIndex:         rand.IntN,
+		RefundPolicy:        ProratedRefund,
+		Notifier:            NopNotifier{},
+		Clock:               SystemClock{},
+		startedAt:           SystemClock{}.Now(),
+		watchersStopped:     make(chan struct{}),
+		departureAdded:      make(chan struct{}),
 	}
 }
 
+// Now returns the current time according to tm.Clock.
+func (tm *TicketManager) Now() time.Time {
+	return tm.Clock.Now()
+}
+
 // PurchaseTicket processes a ticket purchase request, assigns a seat, and returns a ticket receipt.
+// The request is validated and priced before tm.mu is taken, so concurrent
+// purchases only contend for the lock while booking.
 func (tm *TicketManager) PurchaseTicket(ctx context.Context, req *pb.PurchaseTicketRequest) (*pb.PurchaseTicketResponse, error) {
-	tm.mu.Lock()
-	defer tm.mu.Unlock()
-
 	tm.Logger.Info("PurchaseTicket request received")
+	timer := tm.newPhaseTimer()
+	defer timer.log(tm.Logger, "PurchaseTicket")
 
 	// Validate the request
 	if req == nil {
 		tm.Logger.Error("PurchaseTicket request is nil")
 		return nil, status.Error(codes.InvalidArgument, "request is nil")
 	}
+	req.From, req.To = tm.station(req.From), tm.station(req.To)
 
 	// Check if the user is valid
 	if req.User == nil || req.User.Email == "" || req.From == "" || req.To == "" {
@@ -65,33 +278,309 @@ func (tm *TicketManager) PurchaseTicket(ctx context.Context, req *pb.PurchaseTic
 		return nil, status.Error(codes.InvalidArgument, "missing required fields")
 	}
 
-	// TODO: To be decided if we want to allow multiple tickets for the same user
-	// if _, exists := tm.Receipts[req.User.Email]; exists {
-	// 	tm.Logger.Error("User already has a ticket",
-	// 		zap.String("user", req.User.Email),
-	// 	)
-	// 	return nil, status.Error(codes.AlreadyExists, "User already has a ticket")
-	// }
+	// A journey must go somewhere, whatever stations are configured
+	if req.From == req.To {
+		tm.Logger.Error("PurchaseTicket origin and destination are the same",
+			zap.String("user", req.User.Email),
+			zap.String("station", req.From),
+		)
+		return nil, status.Error(codes.InvalidArgument, "origin and destination must differ")
+	}
+
+	// A companion is booked as a second rider on the same journey
+	if req.Companion != nil && (req.Companion.Email == "" || req.Companion.Email == req.User.Email) {
+		tm.Logger.Error("PurchaseTicket invalid companion",
+			zap.String("user", req.User.Email),
+			zap.String("companion", req.Companion.Email),
+		)
+		return nil, status.Error(codes.InvalidArgument, "companion must be another rider with an email")
+	}
+
+	// Minimizing the price picks the section, so preferences can't apply
+	if req.MinimizePrice && len(req.PreferredSections) > 0 {
+		tm.Logger.Error("PurchaseTicket minimize price with preferred sections",
+			zap.String("user", req.User.Email),
+		)
+		return nil, status.Error(codes.InvalidArgument, "minimize price cannot be combined with preferred sections")
+	}
+
+	if req.Provisional && tm.PaymentWindow <= 0 {
+		tm.Logger.Error("PurchaseTicket provisional bookings not enabled",
+			zap.String("user", req.User.Email),
+		)
+		return nil, status.Error(codes.InvalidArgument, "provisional bookings are not enabled")
+	}
+
+	// A held seat is already chosen, so nothing else may pick one
+	if req.HoldId != "" && (req.Companion != nil || req.MinimizePrice || len(req.PreferredSections) > 0) {
+		tm.Logger.Error("PurchaseTicket hold with seat choice",
+			zap.String("user", req.User.Email),
+			zap.String("hold_id", req.HoldId),
+		)
+		return nil, status.Error(codes.InvalidArgument, "a held seat cannot be combined with a companion or section choice")
+	}
+
+	// Strict mode also rejects combinations that are otherwise tolerated
+	if conflict := purchaseConflict(req); tm.StrictRequests && conflict != "" {
+		tm.Logger.Error("PurchaseTicket contradictory fields",
+			zap.String("user", req.User.Email),
+			zap.String("conflict", conflict),
+		)
+		return nil, status.Error(codes.InvalidArgument, conflict)
+	}
+
+	if _, known := tm.FareClasses[req.FareClass]; req.FareClass != "" && !known {
+		tm.Logger.Error("PurchaseTicket unknown fare class",
+			zap.String("user", req.User.Email),
+			zap.String("fare_class", req.FareClass),
+		)
+		return nil, status.Error(codes.InvalidArgument, "unknown fare class")
+	}
+
+	// Check that every preferred section exists
+	for _, section := range req.PreferredSections {
+		if !tm.SeatManager.HasSection(section) {
+			tm.Logger.Error("PurchaseTicket unknown preferred section",
+				zap.String("user", req.User.Email),
+				zap.String("section", section),
+			)
+			return nil, status.Error(codes.InvalidArgument, "unknown preferred section")
+		}
+		if tm.SeatManager.Restricted(section) {
+			tm.Logger.Error("PurchaseTicket restricted preferred section",
+				zap.String("user", req.User.Email),
+				zap.String("section", section),
+			)
+			return nil, seatError(fmt.Errorf("%w: %s", ErrSectionRestricted, section))
+		}
+	}
+
+	// Cap the metadata integrators can carry on the receipt
+	if err := checkMetadata(req.Metadata); err != nil {
+		tm.Logger.Error("PurchaseTicket invalid metadata",
+			zap.String("user", req.User.Email),
+			zap.Error(err),
+		)
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := checkReceiptMask(req.ReceiptMask); err != nil {
+		tm.Logger.Error("PurchaseTicket invalid receipt mask",
+			zap.String("user", req.User.Email),
+			zap.Strings("paths", req.ReceiptMask.GetPaths()),
+		)
+		return nil, err
+	}
 
 	tm.Logger.Info("PurchaseTicket request",
 		zap.String("user", req.User.Email),
 		zap.String("from", req.From),
 		zap.String("to", req.To),
-		zap.Time("timestamp", time.Now()),
+		zap.String("departure_date", req.DepartureDate),
+		zap.Time("timestamp", tm.Now()),
 	)
 
+	now := tm.Now()
+
+	train, err := tm.resolveTrain(req.TrainId)
+	if err != nil {
+		tm.Logger.Error("PurchaseTicket unknown train",
+			zap.String("user", req.User.Email),
+			zap.String("train_id", req.TrainId),
+		)
+		return nil, err
+	}
+	req.TrainId = train
+
+	// Undated purchases are for the default train
+	if req.DepartureDate != "" {
+		if err := tm.checkDepartureDate(req.DepartureDate, now); err != nil {
+			tm.Logger.Error("PurchaseTicket invalid departure date",
+				zap.String("user", req.User.Email),
+				zap.String("departure_date", req.DepartureDate),
+				zap.Error(err),
+			)
+			return nil, err
+		}
+	}
+
 	// Validate the station names
-	connectionStations := fmt.Sprintf("%s-%s", req.From, req.To)
-	if tm.StationConnection[connectionStations] == 0 {
+	price, ok := tm.fare(req.From, req.To)
+	if !ok {
 		tm.Logger.Error("PurchaseTicket invalid station names",
 			zap.String("from", req.From),
 			zap.String("to", req.To),
-			zap.String("connection", connectionStations),
 		)
 		return nil, status.Error(codes.InvalidArgument, "invalid station")
 	}
 
-	section, seat, err := tm.SeatManager.AssignSeat()
+	// Routes beyond the train's service range are not sold, whatever their fare
+	if distance, ok := tm.DistanceFares.WithinRange(req.From, req.To); !ok {
+		tm.Logger.Error("PurchaseTicket journey beyond service range",
+			zap.String("from", req.From),
+			zap.String("to", req.To),
+			zap.Float64("distance_km", distance),
+			zap.Float64("max_distance_km", tm.DistanceFares.MaxDistanceKm),
+		)
+		return nil, status.Errorf(codes.InvalidArgument, "journey of %.0f km exceeds the maximum of %.0f km", distance, tm.DistanceFares.MaxDistanceKm)
+	}
+
+	// Seats are booked only for the segments of the route being travelled
+	journey, err := tm.SeatManager.Journey(req.From, req.To)
+	if err != nil {
+		tm.Logger.Error("PurchaseTicket journey not on route",
+			zap.String("from", req.From),
+			zap.String("to", req.To),
+			zap.Error(err),
+		)
+		return nil, seatError(err)
+	}
+
+	timer.mark("validate")
+
+	if err := tm.lockForPurchase(); err != nil {
+		return nil, seatError(err)
+	}
+	timer.mark("lock_wait")
+	response, booked, err := tm.book(req, price, journey, now, timer)
+	if !booked {
+		response = maskPurchase(response, req.ReceiptMask)
+		tm.mu.Unlock()
+		return response, err
+	}
+	// The receipt may change once the lock is released, so log a copy
+	fields := []zap.Field{
+		zap.String("user", req.User.Email),
+		zap.String("from", req.From),
+		zap.String("to", req.To),
+		zap.String("departure_date", req.DepartureDate),
+		zap.Int("seat_number", int(response.Receipt.Seat.SeatNumber)),
+		zap.String("section", response.Receipt.Seat.Section),
+		zap.Float64("price_paid", price),
+		zap.String("booking_reference", response.Receipt.BookingReference),
+		zap.String("matched_preference", response.MatchedPreference),
+	}
+	if companion := response.CompanionReceipt; companion != nil {
+		fields = append(fields,
+			zap.String("companion", companion.User.Email),
+			zap.Int("companion_seat_number", int(companion.Seat.SeatNumber)),
+			zap.String("companion_section", companion.Seat.Section),
+			zap.Stringer("pair_seating", response.PairSeating),
+		)
+	}
+	response = maskPurchase(response, req.ReceiptMask)
+	tm.mu.Unlock()
+
+	tm.Logger.Info("PurchaseTicket successful", fields...)
+	return response, nil
+}
+
+// book assigns a seat to a validated purchase and records the booking,
+// reporting whether a new booking was made rather than a duplicate, dry run or
+// waitlisting. The timer marks each phase of the booking. Callers must hold
+// tm.mu.
+func (tm *TicketManager) book(req *pb.PurchaseTicketRequest, price float64, journey Journey, now time.Time, timer *phaseTimer) (*pb.PurchaseTicketResponse, bool, error) {
+	// Return the original receipt if this is a retry of a recent identical purchase
+	if recent, exists := tm.findRecentPurchase(req, now); exists && !req.DryRun {
+		tm.Logger.Info("PurchaseTicket duplicate request within dedupe window",
+			zap.String("user", req.User.Email),
+			zap.String("from", req.From),
+			zap.String("to", req.To),
+		)
+		return &pb.PurchaseTicketResponse{
+			Message:          "Ticket booked successfully",
+			Receipt:          recent.receipt,
+			CompanionReceipt: recent.companion,
+			PairSeating:      recent.pairSeating,
+		}, false, nil
+	}
+
+	// Receipts are kept one per user, so a second ticket on any train or
+	// date would orphan the seat of the first
+	for _, user := range []*pb.User{req.User, req.Companion} {
+		if user == nil {
+			continue
+		}
+		if _, exists := tm.Receipts[user.Email]; exists {
+			tm.Logger.Error("PurchaseTicket user already has a ticket",
+				zap.String("user", user.Email),
+			)
+			return nil, false, status.Error(codes.AlreadyExists, "user already has a ticket")
+		}
+	}
+
+	// Stop users churning seats by cancelling and rebooking straight away
+	for _, user := range []*pb.User{req.User, req.Companion} {
+		if user == nil {
+			continue
+		}
+		if wait := tm.rebookWait(user.Email, now); wait > 0 {
+			tm.Logger.Error("PurchaseTicket within rebook cooldown",
+				zap.String("user", user.Email),
+				zap.Duration("retry_after", wait),
+			)
+			return nil, false, retryableError(codes.FailedPrecondition, "recently cancelled, try again later", wait)
+		}
+	}
+
+	// Keep seats back for other routes once this route's cap is reached
+	if !tm.withinRouteCap(req.From, req.To, riders(req)) {
+		tm.Logger.Error("PurchaseTicket route cap reached",
+			zap.String("user", req.User.Email),
+			zap.String("from", req.From),
+			zap.String("to", req.To),
+			zap.Int("route_cap", tm.RouteCaps[routeKey(req.From, req.To)]),
+		)
+		return nil, false, retryableError(codes.ResourceExhausted, "route cap reached", seatRetryDelay)
+	}
+
+	// Discounted fares are sold until their pool runs out, then the standard fare
+	if !tm.fareClassAvailable(req.FareClass, riders(req)) {
+		if tm.FareClasses[req.FareClass].RejectWhenSoldOut {
+			tm.Logger.Error("PurchaseTicket fare class sold out",
+				zap.String("user", req.User.Email),
+				zap.String("fare_class", req.FareClass),
+			)
+			return nil, false, status.Errorf(codes.ResourceExhausted, "%s fares are sold out", req.FareClass)
+		}
+		tm.Logger.Info("PurchaseTicket fare class sold out, charging the standard fare",
+			zap.String("user", req.User.Email),
+			zap.String("fare_class", req.FareClass),
+		)
+		req.FareClass = ""
+	}
+
+	// The held seat must still be held for this rider
+	if req.HoldId != "" {
+		if err := tm.checkHold(req, now); err != nil {
+			tm.Logger.Error("PurchaseTicket invalid seat hold",
+				zap.String("user", req.User.Email),
+				zap.String("hold_id", req.HoldId),
+				zap.Error(err),
+			)
+			return nil, false, err
+		}
+	}
+
+	// Registered hooks have the last say on whether the purchase goes ahead
+	if err := tm.runBeforePurchase(req); err != nil {
+		tm.Logger.Error("PurchaseTicket rejected by hook",
+			zap.String("user", req.User.Email),
+			zap.Error(err),
+		)
+		return nil, false, err
+	}
+
+	timer.mark("checks")
+
+	if req.Companion != nil {
+		return tm.bookPair(req, price, journey, now, timer)
+	}
+
+	section, seat, trace, err := tm.assignSeat(req, price, journey, now)
+	timer.mark("assign_seat")
+	if errors.Is(err, errWaitlisted) {
+		return tm.waitlistPurchase(req, section, journey), false, nil
+	}
 	if err != nil {
 		tm.Logger.Error("PurchaseTicket failed to assign seat",
 			zap.String("user", req.User.Email),
@@ -99,32 +588,166 @@ func (tm *TicketManager) PurchaseTicket(ctx context.Context, req *pb.PurchaseTic
 			zap.String("to", req.To),
 			zap.Error(err),
 		)
-		return nil, status.Error(codes.NotFound, "failed to assign seat")
+		return nil, false, seatError(err)
+	}
+
+	receipt := tm.newReceipt(req, section, seat, price, now)
+
+	// Report the preference satisfied, if any
+	matchedPreference := ""
+	if slices.Contains(req.PreferredSections, section) {
+		matchedPreference = section
+	}
+
+	// A dry run reports the would-be seat without recording anything
+	if req.DryRun {
+		tm.Logger.Info("PurchaseTicket dry run successful",
+			zap.String("user", req.User.Email),
+			zap.String("from", req.From),
+			zap.String("to", req.To),
+			zap.Int("seat_number", seat),
+			zap.String("section", section),
+		)
+		return &pb.PurchaseTicketResponse{
+			Message:           "Ticket can be booked",
+			Receipt:           receipt,
+			MatchedPreference: matchedPreference,
+		}, false, nil
 	}
 
+	tm.recordBooking(receipt, trace)
+	if tm.DedupeWindow > 0 {
+		tm.recentPurchases[purchaseKey{req.User.Email, req.From, req.To, req.TrainId, req.DepartureDate}] = recentPurchase{
+			receipt:     receipt,
+			purchasedAt: now,
+		}
+	}
+	timer.mark("record")
+
+	return &pb.PurchaseTicketResponse{
+		Message:           "Ticket booked successfully",
+		Receipt:           receipt,
+		MatchedPreference: matchedPreference,
+	}, true, nil
+}
+
+// newReceipt builds the receipt for a seat assigned to a purchase, applying
+// the fare class, any time window and the section's price to the base fare and
+// adding the booking fee. Provisional purchases are due to be paid within PaymentWindow.
+// Callers must hold tm.mu.
+func (tm *TicketManager) newReceipt(req *pb.PurchaseTicketRequest, section string, seat int, price float64, now time.Time) *pb.Receipt {
+	price = tm.fareClassFare(req.FareClass, price)
+	sectionPrice := tm.departure(req.TrainId, req.DepartureDate).SectionPrice(section)
+	effectivePrice, fareWindow := tm.effectiveFare(price, sectionPrice, now)
+	fee, total := tm.addBookingFee(effectivePrice)
 	receipt := &pb.Receipt{
-		User:      req.User,
-		From:      req.From,
-		To:        req.To,
-		PricePaid: tm.StationConnection[connectionStations],
-		Seat:      &pb.Seat{SeatNumber: int32(seat), Section: section},
+		User:          req.User,
+		From:          req.From,
+		To:            req.To,
+		PricePaid:     total,
+		Fare:          effectivePrice,
+		BookingFee:    fee,
+		Seat:          &pb.Seat{SeatNumber: int32(seat), Section: section},
+		BaseFare:      price,
+		FareWindow:    fareWindow,
+		TrainId:       req.TrainId,
+		DepartureDate: req.DepartureDate,
+		Metadata:      maps.Clone(req.Metadata),
+		FareClass:     req.FareClass,
+	}
+	if req.Provisional {
+		receipt.Status = pb.BookingStatus_BOOKING_STATUS_PENDING_PAYMENT
+		receipt.PaymentDueAt = now.Add(tm.PaymentWindow).Unix()
 	}
+	return receipt
+}
 
-	tm.Receipts[req.User.Email] = receipt
+// recordBooking stores a new booking with the trace of how its seat was
+// chosen and tells the rider and the after-purchase hooks about it. Provisional bookings count as sold
+// once paid for. Callers must hold tm.mu.
+func (tm *TicketManager) recordBooking(receipt *pb.Receipt, trace AssignmentTrace) {
+	email := receipt.User.Email
+	tm.assignReference(receipt)
+	tm.assignmentTraces[receipt.BookingReference] = trace
+	tm.Receipts[email] = receipt
+	tm.trackDestination(receipt, receipt.Seat.Section, 1)
+	tm.trackRoute(receipt.From, receipt.To, 1)
+	tm.trackFareClass(receipt.FareClass, 1)
+	if receipt.Status == pb.BookingStatus_BOOKING_STATUS_CONFIRMED {
+		tm.countSale(receipt)
+	}
+	tm.recordEvent(email, pb.ReceiptEventType_RECEIPT_EVENT_BOOKED, receipt)
+	tm.trackTrend(tm.Now(), false)
+	tm.notify(pb.ReceiptEventType_RECEIPT_EVENT_BOOKED, receipt)
+	tm.runAfterPurchase(receipt)
+}
 
-	tm.Logger.Info("PurchaseTicket successful",
+// countSale adds a paid booking to the sales reported at shutdown. Callers must
+// hold tm.mu.
+func (tm *TicketManager) countSale(receipt *pb.Receipt) {
+	tm.bookingsServed++
+	tm.revenue += receipt.PricePaid
+}
+
+// waitlistPurchase answers a purchase for a full section whose overflow policy
+// waitlists, queueing it unless it is a dry run. Callers must hold tm.mu.
+func (tm *TicketManager) waitlistPurchase(req *pb.PurchaseTicketRequest, section string, journey Journey) *pb.PurchaseTicketResponse {
+	if req.DryRun {
+		tm.Logger.Info("PurchaseTicket dry run would be waitlisted",
+			zap.String("user", req.User.Email),
+			zap.String("section", section),
+		)
+		return &pb.PurchaseTicketResponse{
+			Message:           "Section is full, ticket would be waitlisted",
+			WaitlistedSection: section,
+		}
+	}
+
+	position := tm.joinWaitlist(section, req, journey)
+	tm.Logger.Info("PurchaseTicket waitlisted",
 		zap.String("user", req.User.Email),
 		zap.String("from", req.From),
 		zap.String("to", req.To),
-		zap.Int("seat_number", seat),
 		zap.String("section", section),
-		zap.Float64("price_paid", tm.StationConnection[connectionStations]),
+		zap.Int("position", position),
 	)
 	return &pb.PurchaseTicketResponse{
-		Message: "Ticket booked successfully",
-		Receipt: receipt,
-	}, nil
+		Message:           fmt.Sprintf("Section is full, added to waitlist at position %d", position),
+		WaitlistedSection: section,
+	}
+}
 
+// findRecentPurchase returns an identical purchase made within the dedupe
+// window, pruning expired entries as it goes. Callers must hold tm.mu.
+func (tm *TicketManager) findRecentPurchase(req *pb.PurchaseTicketRequest, now time.Time) (recentPurchase, bool) {
+	if tm.DedupeWindow <= 0 {
+		return recentPurchase{}, false
+	}
+
+	for key, recent := range tm.recentPurchases {
+		if now.Sub(recent.purchasedAt) >= tm.DedupeWindow {
+			delete(tm.recentPurchases, key)
+		}
+	}
+
+	recent, exists := tm.recentPurchases[purchaseKey{req.User.Email, req.From, req.To, req.TrainId, req.DepartureDate}]
+	if !exists {
+		return recentPurchase{}, false
+	}
+
+	// The tickets may have been cancelled or replaced since they were issued,
+	// and a retry names the same companion, if any
+	if tm.Receipts[req.User.Email] != recent.receipt {
+		return recentPurchase{}, false
+	}
+	if recent.companion == nil || req.Companion == nil {
+		return recent, recent.companion == nil && req.Companion == nil
+	}
+	if tm.Receipts[req.Companion.Email] != recent.companion {
+		return recentPurchase{}, false
+	}
+
+	return recent, true
 }
 
 // GetReceipt retrieves the ticket receipt for a user based on their email
@@ -138,29 +761,54 @@ func (tm *TicketManager) GetReceipt(ctx context.Context, req *pb.GetReceiptReque
 		tm.Logger.Error("GetReceipt request is nil")
 		return nil, status.Error(codes.InvalidArgument, "request is nil")
 	}
-	// Check if the user is valid
-	if req.Email == "" {
+	// Check if the user or booking is given
+	if req.Email == "" && req.BookingReference == "" {
 		tm.Logger.Error("GetReceipt request missing required fields",
 			zap.String("email", req.Email),
+			zap.String("booking_reference", req.BookingReference),
 		)
 		return nil, status.Error(codes.InvalidArgument, "missing required fields")
 	}
+	if err := checkReceiptMask(req.ReceiptMask); err != nil {
+		tm.Logger.Error("GetReceipt invalid receipt mask",
+			zap.Strings("paths", req.ReceiptMask.GetPaths()),
+		)
+		return nil, err
+	}
 
 	tm.Logger.Info("GetReceipt request",
 		zap.String("email", req.Email),
-		zap.Time("timestamp", time.Now()),
+		zap.String("booking_reference", req.BookingReference),
+		zap.Time("timestamp", tm.Now()),
 	)
 
-	receipt, exists := tm.Receipts[req.Email]
+	// A booking reference takes precedence, but must belong to the email if both are given
+	var receipt *pb.Receipt
+	var exists bool
+	if req.BookingReference != "" {
+		receipt, exists = tm.receiptByReference(req.BookingReference)
+		exists = exists && (req.Email == "" || receipt.User.Email == req.Email) && visibleToCaller(ctx, receipt)
+	} else {
+		receipt, exists = tm.Receipts[req.Email]
+	}
 	if !exists {
 		tm.Logger.Error("GetReceipt ticket receipt not found",
 			zap.String("email", req.Email),
+			zap.String("booking_reference", req.BookingReference),
 		)
 		return nil, status.Error(codes.NotFound, "ticket receipt not found")
 	}
+	if receipt.Seat == nil {
+		tm.Logger.Error("GetReceipt ticket receipt has no seat",
+			zap.String("email", receipt.User.GetEmail()),
+			zap.String("booking_reference", receipt.BookingReference),
+		)
+		return nil, errReceiptWithoutSeat
+	}
 
 	tm.Logger.Info("GetReceipt successful",
-		zap.String("email", req.Email),
+		zap.String("email", receipt.User.Email),
+		zap.String("booking_reference", receipt.BookingReference),
 		zap.String("from", receipt.From),
 		zap.String("to", receipt.To),
 		zap.Int("seat_number", int(receipt.Seat.SeatNumber)),
@@ -168,7 +816,7 @@ func (tm *TicketManager) GetReceipt(ctx context.Context, req *pb.GetReceiptReque
 		zap.Float64("price_paid", receipt.PricePaid),
 	)
 	return &pb.GetReceiptResponse{
-		Receipt: receipt,
+		Receipt: maskReceipt(receipt, req.ReceiptMask),
 	}, nil
 }
 
@@ -191,7 +839,23 @@ func (tm *TicketManager) GetUsersBySection(ctx context.Context, req *pb.GetUsers
 		return nil, status.Error(codes.InvalidArgument, "missing required fields")
 	}
 
-	// Check if the section exists
+	if req.DepartureDate != "" {
+		if _, err := time.Parse(departureDateLayout, req.DepartureDate); err != nil {
+			tm.Logger.Error("GetUsersBySection invalid departure date",
+				zap.String("departure_date", req.DepartureDate),
+			)
+			return nil, status.Error(codes.InvalidArgument, "departure date must be YYYY-MM-DD")
+		}
+	}
+	train, err := tm.resolveTrain(req.TrainId)
+	if err != nil {
+		tm.Logger.Error("GetUsersBySection unknown train",
+			zap.String("train_id", req.TrainId),
+		)
+		return nil, err
+	}
+
+	// Check if the section exists; every departure has the same sections
 	if _, exists := tm.SeatManager.Sections[req.Section]; !exists {
 		tm.Logger.Error("GetUsersBySection section not found",
 			zap.String("section", req.Section),
@@ -201,12 +865,24 @@ func (tm *TicketManager) GetUsersBySection(ctx context.Context, req *pb.GetUsers
 
 	tm.Logger.Info("GetUsersBySection request",
 		zap.String("section", req.Section),
-		zap.Time("timestamp", time.Now()),
+		zap.String("train_id", train),
+		zap.String("departure_date", req.DepartureDate),
+		zap.Time("timestamp", tm.Now()),
 	)
 
 	users := make([]*pb.UserSeat, 0)
-	for _, receipt := range tm.Receipts {
-		if receipt.Seat.Section == req.Section {
+	for email, receipt := range tm.Receipts {
+		if receipt.Seat == nil {
+			// Leave a corrupted receipt out rather than failing the listing
+			tm.Logger.Error("GetUsersBySection ticket receipt has no seat",
+				zap.String("email", email),
+			)
+			continue
+		}
+		if req.ExcludePending && receipt.Status == pb.BookingStatus_BOOKING_STATUS_PENDING_PAYMENT {
+			continue
+		}
+		if receipt.Seat.Section == req.Section && tm.onDeparture(receipt, train, req.DepartureDate) {
 			users = append(users, &pb.UserSeat{
 				User:         receipt.User,
 				AllottedSeat: receipt.Seat.SeatNumber,
@@ -214,18 +890,43 @@ func (tm *TicketManager) GetUsersBySection(ctx context.Context, req *pb.GetUsers
 		}
 	}
 
+	// List users in seating order; riders sharing a seat on different
+	// segments are ordered by email
+	sort.Slice(users, func(i, j int) bool {
+		if users[i].AllottedSeat != users[j].AllottedSeat {
+			return users[i].AllottedSeat < users[j].AllottedSeat
+		}
+		return users[i].User.Email < users[j].User.Email
+	})
+
+	// Report the section's size so an empty section is distinguishable from
+	// a missing one
+	vacant, maxSeats, err := tm.readDeparture(train, req.DepartureDate).SectionVacancy(req.Section)
+	if err != nil {
+		tm.Logger.Error("GetUsersBySection failed to read vacancy",
+			zap.String("section", req.Section),
+			zap.Error(err),
+		)
+		return nil, seatError(err)
+	}
+
 	tm.Logger.Info("GetUsersBySection successful",
 		zap.String("section", req.Section),
 		zap.Int("user_count", len(users)),
+		zap.Int("vacant_seats", vacant),
 	)
 
 	return &pb.GetUsersBySectionResponse{
-		Section: req.Section,
-		Users:   users,
+		Section:     req.Section,
+		Users:       users,
+		MaxSeats:    int32(maxSeats),
+		VacantSeats: int32(vacant),
 	}, nil
 }
 
-// UpdateUserSeat changes the seat assignment for a user.
+// UpdateUserSeat changes the seat assignment for a user. Either part of the new
+// seat may be omitted: without a seat number any free seat in the requested
+// section is taken, and without a section the user stays in their current one.
 func (tm *TicketManager) UpdateUserSeat(ctx context.Context, req *pb.UpdateUserSeatRequest) (*pb.UpdateUserSeatResponse, error) {
 	tm.mu.Lock()
 	defer tm.mu.Unlock()
@@ -236,8 +937,8 @@ func (tm *TicketManager) UpdateUserSeat(ctx context.Context, req *pb.UpdateUserS
 		tm.Logger.Error("UpdateUserSeat request is nil")
 		return nil, status.Error(codes.InvalidArgument, "request is nil")
 	}
-	// Check if the user is valid
-	if req.Email == "" || req.NewSeat == nil || req.NewSeat.Section == "" || req.NewSeat.SeatNumber == 0 {
+	// Check if the user is valid and at least one part of the seat is given
+	if req.Email == "" || req.NewSeat == nil || (req.NewSeat.Section == "" && req.NewSeat.SeatNumber == 0) {
 		fields := []zap.Field{
 			zap.String("email", req.Email),
 		}
@@ -253,12 +954,18 @@ func (tm *TicketManager) UpdateUserSeat(ctx context.Context, req *pb.UpdateUserS
 
 		return nil, status.Error(codes.InvalidArgument, "missing required fields")
 	}
+	if err := checkReceiptMask(req.ReceiptMask); err != nil {
+		tm.Logger.Error("UpdateUserSeat invalid receipt mask",
+			zap.Strings("paths", req.ReceiptMask.GetPaths()),
+		)
+		return nil, err
+	}
 
 	tm.Logger.Info("UpdateUserSeat request",
 		zap.String("email", req.Email),
 		zap.String("new_section", req.NewSeat.Section),
 		zap.Int32("new_seat", req.NewSeat.SeatNumber),
-		zap.Time("timestamp", time.Now()),
+		zap.Time("timestamp", tm.Now()),
 	)
 
 	receipt, exists := tm.Receipts[req.Email]
@@ -268,32 +975,116 @@ func (tm *TicketManager) UpdateUserSeat(ctx context.Context, req *pb.UpdateUserS
 		)
 		return nil, status.Error(codes.NotFound, "ticket receipt not found")
 	}
+	if receipt.Seat == nil {
+		tm.Logger.Error("UpdateUserSeat ticket receipt has no seat",
+			zap.String("email", req.Email),
+		)
+		return nil, errReceiptWithoutSeat
+	}
 
-	if err := tm.SeatManager.UpdateSeat(int(receipt.Seat.SeatNumber), receipt.Seat.Section, int(req.NewSeat.SeatNumber), req.NewSeat.Section); err != nil {
-		tm.Logger.Error("UpdateUserSeat failed to update seat",
+	journey, err := tm.journey(receipt)
+	if err != nil {
+		tm.Logger.Error("UpdateUserSeat journey not on route",
+			zap.String("email", req.Email),
+			zap.Error(err),
+		)
+		return nil, seatError(err)
+	}
+
+	seats := tm.departure(receipt.TrainId, receipt.DepartureDate)
+	newSeat, err := tm.resolveSeat(seats, receipt, req.NewSeat, journey)
+	if err != nil {
+		tm.Logger.Error("UpdateUserSeat failed to find a seat",
 			zap.String("email", req.Email),
 			zap.String("new_section", req.NewSeat.Section),
 			zap.Int32("new_seat", req.NewSeat.SeatNumber),
 			zap.Error(err),
 		)
-		return nil, status.Error(codes.NotFound, "failed to update seat")
+		return nil, seatError(err)
+	}
+
+	// Moving to the seat already held is a no-op
+	if receipt.Seat.Section == newSeat.Section && receipt.Seat.SeatNumber == newSeat.SeatNumber {
+		tm.Logger.Info("UpdateUserSeat requested seat is the current seat",
+			zap.String("email", req.Email),
+			zap.String("section", receipt.Seat.Section),
+			zap.Int32("seat_number", receipt.Seat.SeatNumber),
+		)
+		return &pb.UpdateUserSeatResponse{
+			Message:        "Seat unchanged",
+			UpdatedReceipt: maskReceipt(receipt, req.ReceiptMask),
+		}, nil
 	}
 
-	receipt.Seat = req.NewSeat
+	// Only admins can move riders into restricted sections, with ForceReassign
+	if seats.Restricted(newSeat.Section) {
+		tm.Logger.Error("UpdateUserSeat section is restricted",
+			zap.String("email", req.Email),
+			zap.String("new_section", newSeat.Section),
+		)
+		return nil, seatError(fmt.Errorf("%w: %s", ErrSectionRestricted, newSeat.Section))
+	}
+
+	err = seats.UpdateSeat(int(receipt.Seat.SeatNumber), receipt.Seat.Section, int(newSeat.SeatNumber), newSeat.Section, journey)
+	if err != nil {
+		tm.Logger.Error("UpdateUserSeat failed to update seat",
+			zap.String("email", req.Email),
+			zap.String("new_section", newSeat.Section),
+			zap.Int32("new_seat", newSeat.SeatNumber),
+			zap.Error(err),
+		)
+		return nil, seatError(err)
+	}
+
+	oldSection := receipt.Seat.Section
+	tm.trackDestination(receipt, oldSection, -1)
+	tm.trackDestination(receipt, newSeat.Section, 1)
+	receipt.Seat = newSeat
+	tm.recordEvent(req.Email, pb.ReceiptEventType_RECEIPT_EVENT_SEAT_CHANGED, receipt)
+	tm.notify(pb.ReceiptEventType_RECEIPT_EVENT_SEAT_CHANGED, receipt)
+	tm.promoteWaitlist(oldSection, receipt.TrainId, receipt.DepartureDate)
 
 	tm.Logger.Info("UpdateUserSeat successful",
 		zap.String("email", req.Email),
-		zap.String("new_section", req.NewSeat.Section),
-		zap.Int32("new_seat", req.NewSeat.SeatNumber),
+		zap.String("new_section", newSeat.Section),
+		zap.Int32("new_seat", newSeat.SeatNumber),
 		zap.Float64("price_paid", receipt.PricePaid),
 	)
 	return &pb.UpdateUserSeatResponse{
 		Message:        "Seat updated successfully",
-		UpdatedReceipt: receipt,
+		UpdatedReceipt: maskReceipt(receipt, req.ReceiptMask),
 	}, nil
 }
 
-// RemoveUser cancels a user's ticket and releases the seat
+// resolveSeat fills in the parts of a requested seat the user left out. A
+// missing section means the current section; a missing seat number means any
+// seat free for the journey in the section, or the current seat if the user is
+// already seated there. seats are those of the receipt's departure. Callers
+// must hold tm.mu.
+func (tm *TicketManager) resolveSeat(seats *SeatManager, receipt *pb.Receipt, requested *pb.Seat, journey Journey) (*pb.Seat, error) {
+	seat := &pb.Seat{Section: requested.Section, SeatNumber: requested.SeatNumber}
+	if seat.Section == "" {
+		seat.Section = receipt.Seat.Section
+	}
+	if seat.SeatNumber != 0 {
+		return seat, nil
+	}
+
+	if seat.Section == receipt.Seat.Section {
+		seat.SeatNumber = receipt.Seat.SeatNumber
+		return seat, nil
+	}
+
+	seatNum, err := seats.PeekSeatInSection(seat.Section, journey)
+	if err != nil {
+		return nil, err
+	}
+	seat.SeatNumber = int32(seatNum)
+	return seat, nil
+}
+
+// RemoveUser cancels a user's ticket and releases the seat. The refund is
+// prorated by the refund policy when the journey was cut short at a station.
 func (tm *TicketManager) RemoveUser(ctx context.Context, req *pb.RemoveUserRequest) (*pb.RemoveUserResponse, error) {
 	tm.mu.Lock()
 	defer tm.mu.Unlock()
@@ -311,10 +1102,20 @@ func (tm *TicketManager) RemoveUser(ctx context.Context, req *pb.RemoveUserReque
 		)
 		return nil, status.Error(codes.InvalidArgument, "missing required fields")
 	}
+	if !knownCancellationReason(req.Reason) {
+		tm.Logger.Error("RemoveUser unknown cancellation reason",
+			zap.String("email", req.Email),
+			zap.Stringer("reason", req.Reason),
+		)
+		return nil, status.Error(codes.InvalidArgument, "unknown cancellation reason")
+	}
+	req.CancelledAt = tm.station(req.CancelledAt)
 
 	tm.Logger.Info("RemoveUser request",
 		zap.String("email", req.Email),
-		zap.Time("timestamp", time.Now()),
+		zap.String("cancelled_at", req.CancelledAt),
+		zap.Stringer("reason", req.Reason),
+		zap.Time("timestamp", tm.Now()),
 	)
 
 	receipt, exists := tm.Receipts[req.Email]
@@ -324,29 +1125,206 @@ func (tm *TicketManager) RemoveUser(ctx context.Context, req *pb.RemoveUserReque
 		)
 		return nil, status.Error(codes.NotFound, "ticket receipt not found")
 	}
+	if receipt.Seat == nil {
+		tm.Logger.Error("RemoveUser ticket receipt has no seat",
+			zap.String("email", req.Email),
+		)
+		return nil, errReceiptWithoutSeat
+	}
+
+	travelled, total, err := tm.segmentsTravelled(receipt, req.CancelledAt)
+	if err == nil && (travelled < 0 || travelled > total) {
+		err = fmt.Errorf("%w: %s", ErrStationNotOnJourney, req.CancelledAt)
+	}
+	if err != nil {
+		tm.Logger.Error("RemoveUser invalid cancellation point",
+			zap.String("email", req.Email),
+			zap.String("cancelled_at", req.CancelledAt),
+			zap.Error(err),
+		)
+		return nil, seatError(err)
+	}
+	refund := tm.refund(receipt, travelled, total)
 
 	// Store user before removing
 	user := receipt.User
 
-	if err := tm.SeatManager.ReleaseSeat(receipt.Seat.Section, int(receipt.Seat.SeatNumber)); err != nil {
+	if err := tm.cancelTicket(req.Email, receipt, req.Reason); err != nil {
 		tm.Logger.Error("RemoveUser failed to release seat",
 			zap.String("email", req.Email),
 			zap.String("section", receipt.Seat.Section),
 			zap.Int32("seat_number", receipt.Seat.SeatNumber),
 			zap.Error(err),
 		)
-		return nil, status.Error(codes.NotFound, "failed to release seat")
+		return nil, seatError(err)
 	}
-
-	delete(tm.Receipts, req.Email)
+	tm.recordCancellation(req.Email, tm.Now())
 
 	tm.Logger.Info("RemoveUser successful",
 		zap.String("email", req.Email),
 		zap.String("section", receipt.Seat.Section),
 		zap.Int32("seat_number", receipt.Seat.SeatNumber),
+		zap.Float64("refund", refund),
 	)
 	return &pb.RemoveUserResponse{
 		Message:     "Ticket cancelled successfully",
 		RemovedUser: user,
+		Refund:      refund,
+	}, nil
+}
+
+// cancelTicket releases the receipt's seat and removes the receipt, recording
+// why it was cancelled. Callers must hold tm.mu.
+func (tm *TicketManager) cancelTicket(email string, receipt *pb.Receipt, reason pb.CancellationReason) error {
+	if receipt.Seat == nil {
+		return errReceiptWithoutSeat
+	}
+	journey, err := tm.journey(receipt)
+	if err != nil {
+		return err
+	}
+
+	if err := tm.departure(receipt.TrainId, receipt.DepartureDate).ReleaseSeat(receipt.Seat.Section, int(receipt.Seat.SeatNumber), journey); err != nil {
+		return err
+	}
+
+	delete(tm.Receipts, email)
+	delete(tm.references, receipt.BookingReference)
+	delete(tm.assignmentTraces, receipt.BookingReference)
+	tm.trackDestination(receipt, receipt.Seat.Section, -1)
+	tm.trackRoute(receipt.From, receipt.To, -1)
+	tm.trackFareClass(receipt.FareClass, -1)
+	event := tm.recordEvent(email, pb.ReceiptEventType_RECEIPT_EVENT_CANCELLED, receipt)
+	event.CancellationReason = reason
+	tm.cancellationReasons[reason]++
+	tm.notify(pb.ReceiptEventType_RECEIPT_EVENT_CANCELLED, receipt)
+	tm.runCancel(receipt, reason)
+	tm.trackCancellationRate(tm.Now())
+	tm.trackTrend(tm.Now(), true)
+	tm.promoteWaitlist(receipt.Seat.Section, receipt.TrainId, receipt.DepartureDate)
+	return nil
+}
+
+// SwapSeats exchanges the seats of two passengers atomically. Either both
+// receipts are updated or neither is.
+func (tm *TicketManager) SwapSeats(ctx context.Context, req *pb.SwapSeatsRequest) (*pb.SwapSeatsResponse, error) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	tm.Logger.Info("SwapSeats request received")
+
+	// Validate the request
+	if req == nil {
+		tm.Logger.Error("SwapSeats request is nil")
+		return nil, status.Error(codes.InvalidArgument, "request is nil")
+	}
+	// Check if both users are valid
+	if req.EmailA == "" || req.EmailB == "" {
+		tm.Logger.Error("SwapSeats request missing required fields",
+			zap.String("email_a", req.EmailA),
+			zap.String("email_b", req.EmailB),
+		)
+		return nil, status.Error(codes.InvalidArgument, "missing required fields")
+	}
+
+	if req.EmailA == req.EmailB {
+		tm.Logger.Error("SwapSeats request with identical users",
+			zap.String("email", req.EmailA),
+		)
+		return nil, status.Error(codes.InvalidArgument, "cannot swap a seat with itself")
+	}
+
+	tm.Logger.Info("SwapSeats request",
+		zap.String("email_a", req.EmailA),
+		zap.String("email_b", req.EmailB),
+		zap.Time("timestamp", tm.Now()),
+	)
+
+	receiptA, existsA := tm.Receipts[req.EmailA]
+	receiptB, existsB := tm.Receipts[req.EmailB]
+	if !existsA || !existsB {
+		tm.Logger.Error("SwapSeats ticket receipt not found",
+			zap.String("email_a", req.EmailA),
+			zap.Bool("found_a", existsA),
+			zap.String("email_b", req.EmailB),
+			zap.Bool("found_b", existsB),
+		)
+		return nil, status.Error(codes.NotFound, "ticket receipt not found")
+	}
+	if receiptA.Seat == nil || receiptB.Seat == nil {
+		tm.Logger.Error("SwapSeats ticket receipt has no seat",
+			zap.String("email_a", req.EmailA),
+			zap.String("email_b", req.EmailB),
+		)
+		return nil, errReceiptWithoutSeat
+	}
+
+	// Seats can only be exchanged on the same train
+	if !tm.sameDeparture(receiptA, receiptB) {
+		tm.Logger.Error("SwapSeats tickets for different departures",
+			zap.String("email_a", req.EmailA),
+			zap.String("train_id_a", receiptA.TrainId),
+			zap.String("departure_date_a", receiptA.DepartureDate),
+			zap.String("email_b", req.EmailB),
+			zap.String("train_id_b", receiptB.TrainId),
+			zap.String("departure_date_b", receiptB.DepartureDate),
+		)
+		return nil, status.Error(codes.InvalidArgument, "tickets are for different departures")
+	}
+
+	journeyA, err := tm.journey(receiptA)
+	if err != nil {
+		return nil, seatError(err)
+	}
+	journeyB, err := tm.journey(receiptB)
+	if err != nil {
+		return nil, seatError(err)
+	}
+
+	// Only admins can move riders into restricted sections, with ForceReassign
+	seats := tm.departure(receiptA.TrainId, receiptA.DepartureDate)
+	if receiptA.Seat.Section != receiptB.Seat.Section {
+		for _, section := range []string{receiptA.Seat.Section, receiptB.Seat.Section} {
+			if seats.Restricted(section) {
+				tm.Logger.Error("SwapSeats section is restricted",
+					zap.String("email_a", req.EmailA),
+					zap.String("email_b", req.EmailB),
+					zap.String("section", section),
+				)
+				return nil, seatError(fmt.Errorf("%w: %s", ErrSectionRestricted, section))
+			}
+		}
+	}
+
+	if err := seats.SwapSeats(receiptA.Seat.Section, int(receiptA.Seat.SeatNumber), journeyA, receiptB.Seat.Section, int(receiptB.Seat.SeatNumber), journeyB); err != nil {
+		tm.Logger.Error("SwapSeats failed to swap seats",
+			zap.String("email_a", req.EmailA),
+			zap.String("email_b", req.EmailB),
+			zap.Error(err),
+		)
+		return nil, seatError(err)
+	}
+
+	tm.trackDestination(receiptA, receiptA.Seat.Section, -1)
+	tm.trackDestination(receiptB, receiptB.Seat.Section, -1)
+	receiptA.Seat, receiptB.Seat = receiptB.Seat, receiptA.Seat
+	tm.trackDestination(receiptA, receiptA.Seat.Section, 1)
+	tm.trackDestination(receiptB, receiptB.Seat.Section, 1)
+	tm.recordEvent(req.EmailA, pb.ReceiptEventType_RECEIPT_EVENT_SEAT_CHANGED, receiptA)
+	tm.recordEvent(req.EmailB, pb.ReceiptEventType_RECEIPT_EVENT_SEAT_CHANGED, receiptB)
+	tm.notify(pb.ReceiptEventType_RECEIPT_EVENT_SEAT_CHANGED, receiptA)
+	tm.notify(pb.ReceiptEventType_RECEIPT_EVENT_SEAT_CHANGED, receiptB)
+
+	tm.Logger.Info("SwapSeats successful",
+		zap.String("email_a", req.EmailA),
+		zap.String("section_a", receiptA.Seat.Section),
+		zap.Int32("seat_a", receiptA.Seat.SeatNumber),
+		zap.String("email_b", req.EmailB),
+		zap.String("section_b", receiptB.Seat.Section),
+		zap.Int32("seat_b", receiptB.Seat.SeatNumber),
+	)
+	return &pb.SwapSeatsResponse{
+		Message:  "Seats swapped successfully",
+		ReceiptA: receiptA,
+		ReceiptB: receiptB,
 	}, nil
 }
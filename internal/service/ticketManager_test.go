@@ -2,7 +2,11 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/sanjaykishor/rail-connect/internal/config"
 	"github.com/stretchr/testify/assert"
@@ -12,6 +16,7 @@ import (
 	"google.golang.org/grpc/status"
 
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 )
 
 func createTestTicketManager() *TicketManager {
@@ -27,6 +32,19 @@ func createTestTicketManager() *TicketManager {
 	return NewTicketManager(seatManager, connectionStations, logger)
 }
 
+func TestEveryRPCImplemented(t *testing.T) {
+	tm := createTestTicketManager()
+
+	// Call each RPC through the generated handler with an empty request, so a
+	// method renamed in the proto or the service falls back to Unimplemented
+	for _, method := range pb.TicketBookingService_ServiceDesc.Methods {
+		t.Run(method.MethodName, func(t *testing.T) {
+			_, err := method.Handler(tm, context.Background(), func(any) error { return nil }, nil)
+			assert.NotEqual(t, codes.Unimplemented, status.Code(err), "%s should be implemented by TicketManager", method.MethodName)
+		})
+	}
+}
+
 func TestNewTicketManager(t *testing.T) {
 	tm := createTestTicketManager()
 	assert.NotNil(t, tm, "Expected TicketManager to be created")
@@ -252,6 +270,52 @@ func TestGetUsersBySection(t *testing.T) {
 	}
 }
 
+func TestGetUsersBySectionEmpty(t *testing.T) {
+	tm := createTestTicketManager()
+	purchase(t, tm, "test@example.com", "London", "France")
+
+	// Round-robin seated the only rider in A, leaving B empty
+	response, err := tm.GetUsersBySection(context.Background(), &pb.GetUsersBySectionRequest{Section: "B"})
+	assert.NoError(t, err)
+	assert.Equal(t, "B", response.Section)
+	assert.Empty(t, response.Users)
+	assert.Equal(t, int32(20), response.MaxSeats, "An empty section should still report its size")
+	assert.Equal(t, int32(20), response.VacantSeats)
+
+	response, err = tm.GetUsersBySection(context.Background(), &pb.GetUsersBySectionRequest{Section: "A"})
+	assert.NoError(t, err)
+	assert.Len(t, response.Users, 1)
+	assert.Equal(t, int32(20), response.MaxSeats)
+	assert.Equal(t, int32(19), response.VacantSeats)
+}
+
+func TestGetUsersBySectionOrdering(t *testing.T) {
+	tm := createTestTicketManager()
+
+	for _, receipt := range []*pb.Receipt{
+		{User: &pb.User{Email: "seven@example.com"}, Seat: &pb.Seat{Section: "A", SeatNumber: 7}},
+		{User: &pb.User{Email: "two-b@example.com"}, Seat: &pb.Seat{Section: "A", SeatNumber: 2}},
+		{User: &pb.User{Email: "three@example.com"}, Seat: &pb.Seat{Section: "A", SeatNumber: 3}},
+		{User: &pb.User{Email: "two-a@example.com"}, Seat: &pb.Seat{Section: "A", SeatNumber: 2}},
+		{User: &pb.User{Email: "other@example.com"}, Seat: &pb.Seat{Section: "B", SeatNumber: 1}},
+	} {
+		tm.Receipts[receipt.User.Email] = receipt
+	}
+
+	// Map iteration order varies, so repeat to catch unstable ordering
+	for i := 0; i < 10; i++ {
+		response, err := tm.GetUsersBySection(context.Background(), &pb.GetUsersBySectionRequest{Section: "A"})
+		assert.NoError(t, err)
+
+		emails := make([]string, 0, len(response.Users))
+		for _, user := range response.Users {
+			emails = append(emails, user.User.Email)
+		}
+		assert.Equal(t, []string{"two-a@example.com", "two-b@example.com", "three@example.com", "seven@example.com"}, emails,
+			"Users should be sorted by seat number, then email")
+	}
+}
+
 func TestUpdateUserSeat(t *testing.T) {
 	tm := createTestTicketManager()
 
@@ -345,8 +409,23 @@ func TestUpdateUserSeat(t *testing.T) {
 			expectedError: true,
 			expectedCode:  codes.NotFound,
 		},
+		{
+			name: "Invalid Request - Occupied Seat",
+			request: &pb.UpdateUserSeatRequest{
+				Email: userEmail,
+				NewSeat: &pb.Seat{
+					Section:    "B",
+					SeatNumber: 1,
+				},
+			},
+			expectedError: true,
+			expectedCode:  codes.AlreadyExists,
+		},
 	}
 
+	// occupy the seat targeted by the conflicting request
+	tm.SeatManager.Sections["B"].Seats[1].Available = false
+
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			response, err := tm.UpdateUserSeat(context.Background(), test.request)
@@ -366,6 +445,25 @@ func TestUpdateUserSeat(t *testing.T) {
 
 }
 
+func TestUpdateUserSeatCurrentSeatNotOccupied(t *testing.T) {
+	tm := createTestTicketManager()
+
+	// A receipt whose seat was never marked occupied
+	tm.Receipts["test@example.com"] = &pb.Receipt{
+		User: &pb.User{FirstName: "Sanjay", LastName: "Kishor", Email: "test@example.com"},
+		Seat: &pb.Seat{Section: "A", SeatNumber: 1},
+		From: "London",
+		To:   "France",
+	}
+
+	response, err := tm.UpdateUserSeat(context.Background(), &pb.UpdateUserSeatRequest{
+		Email:   "test@example.com",
+		NewSeat: &pb.Seat{Section: "A", SeatNumber: 2},
+	})
+	assert.Nil(t, response)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
 func TestRemoveUser(t *testing.T) {
 	tm := createTestTicketManager()
 
@@ -432,3 +530,383 @@ func TestRemoveUser(t *testing.T) {
 		})
 	}
 }
+
+func TestSwapSeats(t *testing.T) {
+	tm := createTestTicketManager()
+
+	// Round-robin places the two users in different sections
+	for _, email := range []string{"test1@example.com", "test2@example.com"} {
+		_, err := tm.PurchaseTicket(context.Background(), &pb.PurchaseTicketRequest{
+			User: &pb.User{FirstName: "Sanjay", LastName: "Kishor", Email: email},
+			From: "London",
+			To:   "France",
+		})
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, "A", tm.Receipts["test1@example.com"].Seat.Section)
+	assert.Equal(t, "B", tm.Receipts["test2@example.com"].Seat.Section)
+
+	tests := []struct {
+		name          string
+		request       *pb.SwapSeatsRequest
+		expectedError bool
+		expectedCode  codes.Code
+	}{
+		{
+			name: "Valid Request - Different Sections",
+			request: &pb.SwapSeatsRequest{
+				EmailA: "test1@example.com",
+				EmailB: "test2@example.com",
+			},
+			expectedError: false,
+			expectedCode:  codes.OK,
+		},
+		{
+			name: "Invalid Request - Missing Email",
+			request: &pb.SwapSeatsRequest{
+				EmailA: "test1@example.com",
+			},
+			expectedError: true,
+			expectedCode:  codes.InvalidArgument,
+		},
+		{
+			name: "Invalid Request - Same User",
+			request: &pb.SwapSeatsRequest{
+				EmailA: "test1@example.com",
+				EmailB: "test1@example.com",
+			},
+			expectedError: true,
+			expectedCode:  codes.InvalidArgument,
+		},
+		{
+			name: "Invalid Request - Nonexistent Email",
+			request: &pb.SwapSeatsRequest{
+				EmailA: "test1@example.com",
+				EmailB: "nonexist@example.com",
+			},
+			expectedError: true,
+			expectedCode:  codes.NotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			response, err := tm.SwapSeats(context.Background(), test.request)
+			if test.expectedError {
+				assert.Error(t, err)
+				st, ok := status.FromError(err)
+				assert.True(t, ok)
+				assert.Equal(t, test.expectedCode, st.Code())
+				assert.Nil(t, response)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, response)
+				assert.Equal(t, "Seats swapped successfully", response.Message)
+				assert.Equal(t, "B", response.ReceiptA.Seat.Section)
+				assert.Equal(t, "A", response.ReceiptB.Seat.Section)
+			}
+		})
+	}
+
+	// A failed swap leaves both receipts untouched
+	assert.Equal(t, "B", tm.Receipts["test1@example.com"].Seat.Section)
+	assert.Equal(t, "A", tm.Receipts["test2@example.com"].Seat.Section)
+}
+
+func TestPurchaseTicketDedupeWindow(t *testing.T) {
+	tm := createTestTicketManager()
+	tm.DedupeWindow = time.Minute
+	clock := NewFakeClock(time.Date(2024, 3, 10, 9, 0, 0, 0, time.UTC))
+	tm.Clock = clock
+
+	request := &pb.PurchaseTicketRequest{
+		User: &pb.User{FirstName: "Sanjay", LastName: "Kishor", Email: "test@example.com"},
+		From: "London",
+		To:   "France",
+	}
+
+	first, err := tm.PurchaseTicket(context.Background(), request)
+	assert.NoError(t, err)
+
+	// A retry within the window returns the same receipt without a new seat
+	clock.Advance(59 * time.Second)
+	second, err := tm.PurchaseTicket(context.Background(), request)
+	assert.NoError(t, err)
+	assert.Same(t, first.Receipt, second.Receipt, "Retry within the window should return the original receipt")
+	assert.Equal(t, 19, tm.SeatManager.Sections["A"].VacantSeats, "Only one seat should be consumed")
+	assert.Equal(t, 20, tm.SeatManager.Sections["B"].VacantSeats, "Only one seat should be consumed")
+
+	// Once the window has passed, the purchase is treated as new and the user
+	// already has a ticket
+	clock.Advance(time.Second)
+	_, err = tm.PurchaseTicket(context.Background(), request)
+	assert.Equal(t, codes.AlreadyExists, status.Code(err), "Purchase after the window should not be deduplicated")
+	assert.Equal(t, 20, tm.SeatManager.Sections["B"].VacantSeats, "No new seat should be consumed after the window")
+}
+
+func TestPurchaseTicketDedupeDisabled(t *testing.T) {
+	tm := createTestTicketManager()
+
+	request := &pb.PurchaseTicketRequest{
+		User: &pb.User{FirstName: "Sanjay", LastName: "Kishor", Email: "test@example.com"},
+		From: "London",
+		To:   "France",
+	}
+
+	_, err := tm.PurchaseTicket(context.Background(), request)
+	assert.NoError(t, err)
+	_, err = tm.PurchaseTicket(context.Background(), request)
+	assert.Equal(t, codes.AlreadyExists, status.Code(err), "Duplicate detection should be disabled by default")
+}
+
+func TestUpdateUserSeatToSameSeat(t *testing.T) {
+	tm := createTestTicketManager()
+
+	receipt := purchase(t, tm, "test@example.com", "London", "France")
+	vacantBefore := tm.SeatManager.Sections[receipt.Seat.Section].VacantSeats
+
+	response, err := tm.UpdateUserSeat(context.Background(), &pb.UpdateUserSeatRequest{
+		Email:   "test@example.com",
+		NewSeat: &pb.Seat{Section: receipt.Seat.Section, SeatNumber: receipt.Seat.SeatNumber},
+	})
+	assert.NoError(t, err, "Updating to the current seat should succeed")
+	assert.Equal(t, "Seat unchanged", response.Message)
+	assert.Equal(t, receipt.Seat.SeatNumber, response.UpdatedReceipt.Seat.SeatNumber)
+	assert.Equal(t, vacantBefore, tm.SeatManager.Sections[receipt.Seat.Section].VacantSeats, "Vacant seats should be unchanged")
+	assert.Len(t, tm.History["test@example.com"], 1, "No seat change should be recorded")
+}
+
+func TestUpdateUserSeatPartial(t *testing.T) {
+	tm := createTestTicketManager()
+
+	receipt := purchase(t, tm, "test@example.com", "London", "France")
+	assert.Equal(t, "A", receipt.Seat.Section)
+	purchase(t, tm, "other@example.com", "London", "France")
+
+	update := func(seat *pb.Seat) (*pb.UpdateUserSeatResponse, error) {
+		return tm.UpdateUserSeat(context.Background(), &pb.UpdateUserSeatRequest{
+			Email:   "test@example.com",
+			NewSeat: seat,
+		})
+	}
+
+	// Only a seat number keeps the current section
+	response, err := update(&pb.Seat{SeatNumber: 5})
+	assert.NoError(t, err)
+	assert.Equal(t, "A", response.UpdatedReceipt.Seat.Section)
+	assert.Equal(t, int32(5), response.UpdatedReceipt.Seat.SeatNumber)
+	assert.False(t, tm.SeatManager.Sections["A"].Seats[5].Available)
+	assert.True(t, tm.SeatManager.Sections["A"].Seats[1].Available, "Old seat should be released")
+
+	// Only a section takes any free seat there
+	response, err = update(&pb.Seat{Section: "B"})
+	assert.NoError(t, err)
+	assert.Equal(t, "B", response.UpdatedReceipt.Seat.Section)
+	assert.Equal(t, int32(2), response.UpdatedReceipt.Seat.SeatNumber, "First free seat in B should be taken")
+	assert.Equal(t, 20, tm.SeatManager.Sections["A"].VacantSeats)
+
+	// Only the current section leaves the seat alone
+	response, err = update(&pb.Seat{Section: "B"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Seat unchanged", response.Message)
+	assert.Equal(t, int32(2), response.UpdatedReceipt.Seat.SeatNumber)
+
+	// A full section has no seat to offer
+	assert.NoError(t, tm.SeatManager.ResizeSection("A", 1))
+	purchase(t, tm, "third@example.com", "London", "France")
+	_, err = update(&pb.Seat{Section: "A"})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	// At least one part of the seat is required
+	_, err = update(&pb.Seat{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Len(t, tm.History["test@example.com"], 3, "Only the two moves should be recorded")
+}
+
+func TestPurchaseTicketDryRun(t *testing.T) {
+	tm := createTestTicketManager()
+
+	response, err := tm.PurchaseTicket(context.Background(), &pb.PurchaseTicketRequest{
+		User:   &pb.User{FirstName: "Sanjay", LastName: "Kishor", Email: "test@example.com"},
+		From:   "London",
+		To:     "France",
+		DryRun: true,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "Ticket can be booked", response.Message)
+	assert.Equal(t, "A", response.Receipt.Seat.Section, "Dry run should report the would-be section")
+	assert.Equal(t, int32(1), response.Receipt.Seat.SeatNumber, "Dry run should report the would-be seat")
+	assert.Equal(t, 20.00, response.Receipt.PricePaid)
+
+	// No seat is consumed and nothing is recorded
+	assert.Equal(t, 20, tm.SeatManager.Sections["A"].VacantSeats, "Dry run should not consume a seat")
+	assert.Equal(t, 1, tm.SeatManager.Sections["A"].FirstVacant, "Dry run should not move the first vacant pointer")
+	assert.Equal(t, true, tm.SeatManager.Sections["A"].Seats[1].Available)
+	assert.Empty(t, tm.Receipts, "Dry run should not record a receipt")
+	assert.Empty(t, tm.History, "Dry run should not record history")
+
+	// The real booking gets the seat the dry run predicted
+	receipt := purchase(t, tm, "test@example.com", "London", "France")
+	assert.Equal(t, response.Receipt.Seat.Section, receipt.Seat.Section)
+	assert.Equal(t, response.Receipt.Seat.SeatNumber, receipt.Seat.SeatNumber)
+
+	// Dry runs still validate the request
+	_, err = tm.PurchaseTicket(context.Background(), &pb.PurchaseTicketRequest{
+		User:   &pb.User{FirstName: "Sanjay", LastName: "Kishor", Email: "test@example.com"},
+		From:   "London",
+		To:     "Nowhere",
+		DryRun: true,
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "Dry run should reject invalid stations")
+}
+
+func TestPurchaseTicketSameStation(t *testing.T) {
+	tm := createTestTicketManager()
+	// Even a configured price doesn't make a round trip to nowhere bookable
+	tm.StationConnection["London-London"] = 5.00
+	tm.CanonicalizeStations = true
+
+	_, err := tm.PurchaseTicket(context.Background(), &pb.PurchaseTicketRequest{
+		User: &pb.User{FirstName: "Sanjay", LastName: "Kishor", Email: "test@example.com"},
+		From: "London",
+		To:   " london ",
+	})
+	st := status.Convert(err)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+	assert.Equal(t, "origin and destination must differ", st.Message())
+	assert.Empty(t, tm.Receipts)
+}
+
+func TestPurchaseTicketTrainFull(t *testing.T) {
+	tm := createTestTicketManager()
+	assert.NoError(t, tm.SeatManager.ResizeSection("A", 1))
+	assert.NoError(t, tm.SeatManager.ResizeSection("B", 1))
+
+	purchase(t, tm, "test1@example.com", "London", "France")
+	purchase(t, tm, "test2@example.com", "London", "France")
+
+	_, err := tm.PurchaseTicket(context.Background(), &pb.PurchaseTicketRequest{
+		User: &pb.User{FirstName: "Sanjay", LastName: "Kishor", Email: "test3@example.com"},
+		From: "London",
+		To:   "France",
+	})
+	st := status.Convert(err)
+	assert.Equal(t, codes.ResourceExhausted, st.Code(), "Full train is a capacity error, not a missing resource")
+	assert.Len(t, st.Details(), 1, "Full train should suggest when to retry")
+}
+
+func TestReceiptWithoutSeat(t *testing.T) {
+	tm := createTestTicketManager()
+	receipt := purchase(t, tm, "test@example.com", "London", "France")
+	purchase(t, tm, "other@example.com", "London", "France")
+	// Simulate a corrupted receipt loaded from outside the service
+	receipt.Seat = nil
+
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{"GetReceipt", func() error {
+			_, err := tm.GetReceipt(context.Background(), &pb.GetReceiptRequest{Email: "test@example.com"})
+			return err
+		}},
+		{"UpdateUserSeat", func() error {
+			_, err := tm.UpdateUserSeat(context.Background(), &pb.UpdateUserSeatRequest{Email: "test@example.com", NewSeat: &pb.Seat{Section: "B"}})
+			return err
+		}},
+		{"ChangeStations", func() error {
+			_, err := tm.ChangeStations(context.Background(), &pb.ChangeStationsRequest{Email: "test@example.com", From: "France", To: "London"})
+			return err
+		}},
+		{"RemoveUser", func() error {
+			_, err := tm.RemoveUser(context.Background(), &pb.RemoveUserRequest{Email: "test@example.com"})
+			return err
+		}},
+		{"SwapSeats", func() error {
+			_, err := tm.SwapSeats(context.Background(), &pb.SwapSeatsRequest{EmailA: "test@example.com", EmailB: "other@example.com"})
+			return err
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var err error
+			assert.NotPanics(t, func() { err = test.call() })
+			assert.Equal(t, codes.Internal, status.Code(err))
+			assert.Contains(t, status.Convert(err).Message(), "no seat")
+		})
+	}
+	assert.Contains(t, tm.Receipts, "test@example.com", "The corrupted receipt should be left for repair")
+}
+
+func TestListingsSkipReceiptWithoutSeat(t *testing.T) {
+	tm := createTestTicketManager()
+	receipt := purchase(t, tm, "test@example.com", "London", "France")
+	other := purchase(t, tm, "other@example.com", "London", "France")
+	// Simulate a corrupted receipt loaded from outside the service
+	receipt.Seat = nil
+
+	var (
+		section  *pb.GetUsersBySectionResponse
+		manifest *pb.GetManifestResponse
+		route    *pb.GetUsersByRouteResponse
+		prices   *pb.ListReceiptsByPriceRangeResponse
+		seat     *pb.GetSeatResponse
+		err      error
+	)
+	assert.NotPanics(t, func() {
+		section, err = tm.GetUsersBySection(context.Background(), &pb.GetUsersBySectionRequest{Section: other.Seat.Section})
+		assert.NoError(t, err)
+		manifest, err = tm.GetManifest(context.Background(), &pb.GetManifestRequest{})
+		assert.NoError(t, err)
+		route, err = tm.GetUsersByRoute(context.Background(), &pb.GetUsersByRouteRequest{From: "London", To: "France"})
+		assert.NoError(t, err)
+		prices, err = tm.ListReceiptsByPriceRange(context.Background(), &pb.ListReceiptsByPriceRangeRequest{MaxPrice: 100})
+		assert.NoError(t, err)
+		seat, err = tm.GetSeat(context.Background(), &pb.GetSeatRequest{Section: other.Seat.Section, SeatNumber: other.Seat.SeatNumber})
+		assert.NoError(t, err)
+	})
+	assert.Len(t, section.Users, 1, "The corrupted receipt should be left out")
+	assert.Len(t, manifest.Entries, 1)
+	assert.Len(t, route.Users, 1)
+	assert.Len(t, prices.Receipts, 1)
+	assert.Len(t, seat.Occupants, 1)
+
+	// Cancelling in bulk skips the corrupted receipt rather than panicking
+	assert.NotPanics(t, func() {
+		resp, err := tm.BulkCancel(context.Background(), &pb.BulkCancelRequest{From: "London", To: "France"})
+		assert.NoError(t, err)
+		assert.Len(t, resp.CancelledReceipts, 1)
+	})
+	assert.Contains(t, tm.Receipts, "test@example.com", "The corrupted receipt should be left for repair")
+}
+
+// BenchmarkPurchaseTicketParallel books and cancels tickets from many
+// goroutines at once, with a logger that encodes every record, to measure
+// contention on the booking lock.
+func BenchmarkPurchaseTicketParallel(b *testing.B) {
+	seatManager := NewSeatManager([]config.SectionConfig{
+		{Name: "A", MaxSeats: 1000},
+		{Name: "B", MaxSeats: 1000},
+	}, zap.NewNop())
+	logger := zap.New(zapcore.NewCore(zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()), zapcore.AddSync(io.Discard), zap.InfoLevel))
+	tm := NewTicketManager(seatManager, map[string]float64{"London-France": 20.00}, logger)
+
+	var next atomic.Int64
+	b.RunParallel(func(p *testing.PB) {
+		for p.Next() {
+			email := fmt.Sprintf("user%d@example.com", next.Add(1))
+			_, err := tm.PurchaseTicket(context.Background(), &pb.PurchaseTicketRequest{
+				User: &pb.User{FirstName: "Sanjay", LastName: "Kishor", Email: email},
+				From: "London",
+				To:   "France",
+			})
+			if err != nil {
+				b.Fatal(err)
+			}
+			if _, err := tm.RemoveUser(context.Background(), &pb.RemoveUserRequest{Email: email}); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
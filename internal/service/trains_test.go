@@ -0,0 +1,96 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+)
+
+// createTrainsTicketManager returns a test ticket manager running trains IC101,
+// the default, and IC102.
+func createTrainsTicketManager() *TicketManager {
+	tm := createTestTicketManager()
+	tm.DefaultTrain = "IC101"
+	tm.Trains = []string{"IC101", "IC102"}
+	return tm
+}
+
+func TestTrainsIsolated(t *testing.T) {
+	tm := createTrainsTicketManager()
+
+	first := purchase(t, tm, "first@example.com", "London", "France", onTrain(""))
+	second := purchase(t, tm, "second@example.com", "London", "France", onTrain("IC102"))
+	assert.Equal(t, "IC101", first.TrainId, "Bookings naming no train should go on the default train")
+	assert.Equal(t, "IC102", second.TrainId)
+	assert.Equal(t, first.Seat, second.Seat, "Each train should have its own seats")
+	assert.Equal(t, 19, tm.SeatManager.VacantSeats("A"))
+	assert.Equal(t, 19, tm.departure("IC102", "").VacantSeats("A"))
+
+	for train, email := range map[string]string{"": "first@example.com", "IC102": "second@example.com"} {
+		response, err := tm.GetUsersBySection(context.Background(), &pb.GetUsersBySectionRequest{Section: "A", TrainId: train})
+		assert.NoError(t, err)
+		assert.Len(t, response.Users, 1)
+		assert.Equal(t, email, response.Users[0].User.Email)
+
+		manifest, err := tm.GetManifest(context.Background(), &pb.GetManifestRequest{TrainId: train})
+		assert.NoError(t, err)
+		assert.Len(t, manifest.Entries, 1)
+		assert.Equal(t, email, manifest.Entries[0].User.Email)
+	}
+
+	// Cancelling frees the seat on its own train only
+	_, err := tm.RemoveUser(context.Background(), &pb.RemoveUserRequest{Email: "second@example.com"})
+	assert.NoError(t, err)
+	assert.Equal(t, 20, tm.departure("IC102", "").VacantSeats("A"))
+	assert.Equal(t, 19, tm.SeatManager.VacantSeats("A"))
+}
+
+func TestTrainsSwapSeatsAcrossTrains(t *testing.T) {
+	tm := createTrainsTicketManager()
+	purchase(t, tm, "first@example.com", "London", "France", onTrain("IC101"))
+	purchase(t, tm, "second@example.com", "London", "France", onTrain("IC102"))
+
+	_, err := tm.SwapSeats(context.Background(), &pb.SwapSeatsRequest{EmailA: "first@example.com", EmailB: "second@example.com"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestTrainsUnknownTrain(t *testing.T) {
+	tm := createTrainsTicketManager()
+
+	_, err := tm.PurchaseTicket(context.Background(), &pb.PurchaseTicketRequest{
+		User:    &pb.User{FirstName: "Sanjay", LastName: "Kishor", Email: "test@example.com"},
+		From:    "London",
+		To:      "France",
+		TrainId: "IC999",
+	})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.Empty(t, tm.departures, "Unknown trains should not get seats")
+
+	_, err = tm.GetUsersBySection(context.Background(), &pb.GetUsersBySectionRequest{Section: "A", TrainId: "IC999"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	// Without configured trains only the default train runs
+	_, err = createTestTicketManager().GetManifest(context.Background(), &pb.GetManifestRequest{TrainId: "IC101"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestSnapshotTrains(t *testing.T) {
+	tm := createTrainsTicketManager()
+	purchase(t, tm, "default@example.com", "London", "France", onTrain(""))
+	purchase(t, tm, "other@example.com", "London", "France", onTrain("IC102"))
+
+	var buf bytes.Buffer
+	assert.NoError(t, tm.Snapshot(&buf))
+
+	restored := createTrainsTicketManager()
+	assert.NoError(t, restored.Restore(&buf))
+	assert.Equal(t, tm.SeatManager.Sections, restored.SeatManager.Sections)
+	assert.Equal(t, tm.departure("IC102", "").Sections, restored.departure("IC102", "").Sections, "Seats on other trains should be restored")
+	assert.Equal(t, "IC102", restored.Receipts["other@example.com"].TrainId)
+}
@@ -0,0 +1,50 @@
+package service
+
+import "go.uber.org/zap"
+
+// Turnaround frees the default train for its next service. Every undated
+// receipt for it is moved to Archived and its booking reference released,
+// riders still waitlisted for the finished service are dropped, and every seat
+// is released, all under tm.mu so no booking sees a half-cleared train. Other
+// trains, dated departures and receipt history are kept. It returns the number of receipts
+// archived.
+func (tm *TicketManager) Turnaround() int {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	archived := 0
+	for email, receipt := range tm.Receipts {
+		if !tm.isDefaultDeparture(receipt.TrainId, receipt.DepartureDate) {
+			continue
+		}
+		delete(tm.Receipts, email)
+		tm.closeTimeline(email)
+		delete(tm.references, receipt.BookingReference)
+		delete(tm.assignmentTraces, receipt.BookingReference)
+		if receipt.Seat != nil {
+			tm.trackDestination(receipt, receipt.Seat.Section, -1)
+		}
+		tm.trackRoute(receipt.From, receipt.To, -1)
+		tm.trackFareClass(receipt.FareClass, -1)
+		tm.Archived = append(tm.Archived, receipt)
+		archived++
+	}
+
+	for section, queue := range tm.waitlists {
+		remaining := queue[:0]
+		for _, entry := range queue {
+			if !tm.isDefaultDeparture(entry.request.TrainId, entry.request.DepartureDate) {
+				remaining = append(remaining, entry)
+			}
+		}
+		tm.waitlists[section] = remaining
+	}
+
+	tm.SeatManager.ReleaseAll()
+
+	tm.Logger.Info("Turnaround successful",
+		zap.Int("archived_receipts", archived),
+		zap.Int("total_archived", len(tm.Archived)),
+	)
+	return archived
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+)
+
+func TestReleaseAll(t *testing.T) {
+	tm := createTestTicketManager()
+	for i := 0; i < 25; i++ {
+		purchase(t, tm, fmt.Sprintf("rider%d@example.com", i), "London", "France")
+	}
+	assert.NoError(t, tm.SeatManager.BlockSeat("B", 20))
+
+	tm.SeatManager.ReleaseAll()
+
+	assert.Equal(t, 20, tm.SeatManager.Sections["A"].VacantSeats)
+	assert.Equal(t, 1, tm.SeatManager.Sections["A"].FirstVacant)
+	assert.Equal(t, 19, tm.SeatManager.Sections["B"].VacantSeats, "Blocked seats should stay blocked")
+	assert.True(t, tm.SeatManager.Sections["B"].Seats[20].Blocked)
+	for _, report := range tm.SeatManager.Verify(false) {
+		assert.True(t, report.Healthy(), "Counters should match the seats in section %s", report.Section)
+	}
+}
+
+func TestTurnaround(t *testing.T) {
+	tm := createDatedTicketManager()
+	first := purchase(t, tm, "first@example.com", "London", "France")
+	purchase(t, tm, "second@example.com", "London", "France")
+	purchase(t, tm, "monday@example.com", "London", "France", onDate("2024-03-11"))
+
+	assert.Equal(t, 2, tm.Turnaround())
+
+	assert.Len(t, tm.Archived, 2)
+	assert.NotContains(t, tm.Receipts, "first@example.com")
+	assert.Contains(t, tm.Receipts, "monday@example.com", "Dated departures should be kept")
+	assert.Equal(t, 20, tm.SeatManager.Sections["A"].VacantSeats)
+	assert.Equal(t, 20, tm.SeatManager.Sections["B"].VacantSeats)
+	assert.Equal(t, 19, tm.departures[departureKey{"", "2024-03-11"}].Sections["A"].VacantSeats)
+	assert.NotEmpty(t, tm.History["first@example.com"], "History should survive the turnaround")
+
+	_, err := tm.GetReceipt(context.Background(), &pb.GetReceiptRequest{BookingReference: first.BookingReference})
+	assert.Error(t, err, "Archived references should no longer resolve")
+
+	// The next service starts from an empty train
+	receipt := purchase(t, tm, "first@example.com", "London", "France")
+	assert.Equal(t, int32(1), receipt.Seat.SeatNumber)
+	assert.Equal(t, 1, tm.Turnaround())
+	assert.Len(t, tm.Archived, 3)
+}
+
+func TestTurnaroundConcurrentPurchases(t *testing.T) {
+	tm := createTestTicketManager()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			tm.PurchaseTicket(context.Background(), &pb.PurchaseTicketRequest{
+				User: &pb.User{FirstName: "Sanjay", LastName: "Kishor", Email: fmt.Sprintf("rider%d@example.com", i)},
+				From: "London",
+				To:   "France",
+			})
+		}(i)
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		tm.Turnaround()
+	}()
+	wg.Wait()
+
+	// Every booking made after the turnaround still holds its seat
+	occupied, _ := tm.SeatManager.Occupancy()
+	assert.Equal(t, len(tm.Receipts), occupied)
+	assert.Equal(t, 20, len(tm.Receipts)+len(tm.Archived))
+}
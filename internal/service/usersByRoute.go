@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"sort"
+
+	"go.uber.org/zap"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GetUsersByRoute retrieves every user travelling from one station to another,
+// regardless of section, with their seats.
+func (tm *TicketManager) GetUsersByRoute(ctx context.Context, req *pb.GetUsersByRouteRequest) (*pb.GetUsersByRouteResponse, error) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	tm.Logger.Info("GetUsersByRoute request received")
+
+	// Validate the request
+	if req == nil {
+		tm.Logger.Error("GetUsersByRoute request is nil")
+		return nil, status.Error(codes.InvalidArgument, "request is nil")
+	}
+	req.From, req.To = tm.station(req.From), tm.station(req.To)
+	if req.From == "" || req.To == "" {
+		tm.Logger.Error("GetUsersByRoute request missing required fields",
+			zap.String("from", req.From),
+			zap.String("to", req.To),
+		)
+		return nil, status.Error(codes.InvalidArgument, "missing required fields")
+	}
+
+	// Validate the station names
+	if _, ok := tm.fare(req.From, req.To); !ok {
+		tm.Logger.Error("GetUsersByRoute invalid station names",
+			zap.String("from", req.From),
+			zap.String("to", req.To),
+		)
+		return nil, status.Error(codes.InvalidArgument, "invalid station")
+	}
+
+	tm.Logger.Info("GetUsersByRoute request",
+		zap.String("from", req.From),
+		zap.String("to", req.To),
+		zap.Time("timestamp", tm.Now()),
+	)
+
+	users := make([]*pb.RouteUser, 0)
+	for email, receipt := range tm.Receipts {
+		if receipt.Seat == nil {
+			tm.Logger.Error("GetUsersByRoute ticket receipt has no seat",
+				zap.String("email", email),
+			)
+			continue
+		}
+		if receipt.From == req.From && receipt.To == req.To {
+			users = append(users, &pb.RouteUser{
+				User: receipt.User,
+				Seat: receipt.Seat,
+			})
+		}
+	}
+
+	// List users in seating order
+	sort.Slice(users, func(i, j int) bool {
+		if users[i].Seat.Section != users[j].Seat.Section {
+			return users[i].Seat.Section < users[j].Seat.Section
+		}
+		return users[i].Seat.SeatNumber < users[j].Seat.SeatNumber
+	})
+
+	tm.Logger.Info("GetUsersByRoute successful",
+		zap.String("from", req.From),
+		zap.String("to", req.To),
+		zap.Int("user_count", len(users)),
+	)
+
+	return &pb.GetUsersByRouteResponse{
+		From:  req.From,
+		To:    req.To,
+		Users: users,
+	}, nil
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGetUsersByRoute(t *testing.T) {
+	tm := createTestTicketManager()
+	tm.StationConnection["London-Paris"] = 25.00
+
+	// Round-robin seats users alternately in A and B
+	purchase(t, tm, "test1@example.com", "London", "France")
+	purchase(t, tm, "test2@example.com", "London", "Paris")
+	purchase(t, tm, "test3@example.com", "London", "France")
+	purchase(t, tm, "test4@example.com", "London", "France")
+
+	response, err := tm.GetUsersByRoute(context.Background(), &pb.GetUsersByRouteRequest{From: "London", To: "France"})
+	assert.NoError(t, err)
+	assert.Equal(t, "London", response.From)
+	assert.Equal(t, "France", response.To)
+	assert.Len(t, response.Users, 3, "Only London-France passengers should be listed")
+
+	// Users from both sections are listed in seating order
+	assert.Equal(t, "test1@example.com", response.Users[0].User.Email)
+	assert.Equal(t, "A", response.Users[0].Seat.Section)
+	assert.Equal(t, "test3@example.com", response.Users[1].User.Email)
+	assert.Equal(t, "A", response.Users[1].Seat.Section)
+	assert.Equal(t, int32(2), response.Users[1].Seat.SeatNumber)
+	assert.Equal(t, "test4@example.com", response.Users[2].User.Email)
+	assert.Equal(t, "B", response.Users[2].Seat.Section)
+
+	response, err = tm.GetUsersByRoute(context.Background(), &pb.GetUsersByRouteRequest{From: "London", To: "Paris"})
+	assert.NoError(t, err)
+	assert.Len(t, response.Users, 1)
+	assert.Equal(t, "test2@example.com", response.Users[0].User.Email)
+}
+
+func TestGetUsersByRouteInvalidRequest(t *testing.T) {
+	tm := createTestTicketManager()
+
+	tests := []struct {
+		name         string
+		request      *pb.GetUsersByRouteRequest
+		expectedCode codes.Code
+	}{
+		{"Nil Request", nil, codes.InvalidArgument},
+		{"Missing Destination", &pb.GetUsersByRouteRequest{From: "London"}, codes.InvalidArgument},
+		{"Unknown Route", &pb.GetUsersByRouteRequest{From: "London", To: "Lyon"}, codes.InvalidArgument},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := tm.GetUsersByRoute(context.Background(), test.request)
+			assert.Equal(t, test.expectedCode, status.Code(err))
+		})
+	}
+
+	// A valid route with no passengers returns an empty list
+	response, err := tm.GetUsersByRoute(context.Background(), &pb.GetUsersByRouteRequest{From: "London", To: "France"})
+	assert.NoError(t, err)
+	assert.Empty(t, response.Users)
+}
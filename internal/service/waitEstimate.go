@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+)
+
+// cancellationSmoothing is the weight of the latest gap between cancellations
+// in their moving average; lower values smooth out bursts.
+const cancellationSmoothing = 0.2
+
+// trackCancellationRate folds the time since the previous cancellation into
+// the moving average gap between cancellations. Callers must hold tm.mu.
+func (tm *TicketManager) trackCancellationRate(now time.Time) {
+	if !tm.lastCancellation.IsZero() {
+		gap := now.Sub(tm.lastCancellation)
+		if tm.cancellationGaps == 0 {
+			tm.cancellationGap = gap
+		} else {
+			tm.cancellationGap += time.Duration(cancellationSmoothing * float64(gap-tm.cancellationGap))
+		}
+		tm.cancellationGaps++
+	}
+	tm.lastCancellation = now
+}
+
+// waitlistPosition returns the section the user is waitlisted for and their
+// place in its queue, starting at 1. Callers must hold tm.mu.
+func (tm *TicketManager) waitlistPosition(email string) (string, int, bool) {
+	for _, section := range tm.SeatManager.SectionOrder {
+		for i, entry := range tm.waitlists[section] {
+			if entry.request.User.Email == email {
+				return section, i + 1, true
+			}
+		}
+	}
+	return "", 0, false
+}
+
+// GetWaitEstimate estimates how long a waitlisted rider will wait for a seat:
+// one average gap between recent cancellations for each rider up to and
+// including them in the queue. Cancellations anywhere on the train count, so
+// it is only a rough guide.
+func (tm *TicketManager) GetWaitEstimate(ctx context.Context, req *pb.GetWaitEstimateRequest) (*pb.GetWaitEstimateResponse, error) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	tm.Logger.Info("GetWaitEstimate request received")
+
+	// Validate the request
+	if req == nil {
+		tm.Logger.Error("GetWaitEstimate request is nil")
+		return nil, status.Error(codes.InvalidArgument, "request is nil")
+	}
+	if req.Email == "" {
+		tm.Logger.Error("GetWaitEstimate request missing email")
+		return nil, status.Error(codes.InvalidArgument, "missing required fields")
+	}
+
+	tm.Logger.Info("GetWaitEstimate request",
+		zap.String("email", req.Email),
+		zap.Time("timestamp", tm.Now()),
+	)
+
+	section, position, waiting := tm.waitlistPosition(req.Email)
+	if !waiting {
+		tm.Logger.Error("GetWaitEstimate user not waitlisted",
+			zap.String("email", req.Email),
+		)
+		return nil, status.Error(codes.NotFound, "user is not waitlisted")
+	}
+
+	response := &pb.GetWaitEstimateResponse{
+		Section:  section,
+		Position: int32(position),
+	}
+	if tm.cancellationGaps > 0 {
+		response.HasEstimate = true
+		response.EstimatedWaitSeconds = int64((time.Duration(position) * tm.cancellationGap).Seconds())
+	}
+
+	tm.Logger.Info("GetWaitEstimate successful",
+		zap.String("email", req.Email),
+		zap.String("section", section),
+		zap.Int("position", position),
+		zap.Bool("has_estimate", response.HasEstimate),
+		zap.Int64("estimated_wait_seconds", response.EstimatedWaitSeconds),
+	)
+	return response, nil
+}
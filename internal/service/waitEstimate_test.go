@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sanjaykishor/rail-connect/internal/config"
+	"github.com/stretchr/testify/assert"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// createWaitingTicketManager returns a ticket manager with section A full and
+// two riders waitlisted for it.
+func createWaitingTicketManager(t *testing.T) *TicketManager {
+	t.Helper()
+	tm := createOverflowTicketManager(config.OverflowWaitlist)
+	fillSectionA(t, tm)
+	for _, email := range []string{"waiting1@example.com", "waiting2@example.com"} {
+		response, err := tryPurchase(tm, email, inSection("A"))
+		assert.NoError(t, err)
+		assert.Equal(t, "A", response.WaitlistedSection)
+	}
+	return tm
+}
+
+func TestGetWaitEstimate(t *testing.T) {
+	tm := createWaitingTicketManager(t)
+
+	// Cancellations every ten minutes, then a burst of three a minute apart
+	start := time.Date(2024, 3, 10, 9, 0, 0, 0, time.UTC)
+	for i := range 5 {
+		tm.trackCancellationRate(start.Add(time.Duration(i) * 10 * time.Minute))
+	}
+	assert.Equal(t, 10*time.Minute, tm.cancellationGap)
+
+	response, err := tm.GetWaitEstimate(context.Background(), &pb.GetWaitEstimateRequest{Email: "waiting2@example.com"})
+	assert.NoError(t, err)
+	assert.Equal(t, "A", response.Section)
+	assert.Equal(t, int32(2), response.Position)
+	assert.True(t, response.HasEstimate)
+	assert.Equal(t, int64((20 * time.Minute).Seconds()), response.EstimatedWaitSeconds, "Second in line should wait two gaps")
+
+	last := start.Add(40 * time.Minute)
+	for i := 1; i <= 3; i++ {
+		tm.trackCancellationRate(last.Add(time.Duration(i) * time.Minute))
+	}
+	response, err = tm.GetWaitEstimate(context.Background(), &pb.GetWaitEstimateRequest{Email: "waiting1@example.com"})
+	assert.NoError(t, err)
+	wait := time.Duration(response.EstimatedWaitSeconds) * time.Second
+	assert.Less(t, wait, 10*time.Minute, "A burst of cancellations should shorten the estimate")
+	assert.Greater(t, wait, time.Minute, "The moving average should not forget the earlier rate")
+}
+
+func TestGetWaitEstimateFromCancellations(t *testing.T) {
+	tm := createWaitingTicketManager(t)
+	clock := NewFakeClock(time.Date(2024, 3, 10, 9, 0, 0, 0, time.UTC))
+	tm.Clock = clock
+
+	response, err := tm.GetWaitEstimate(context.Background(), &pb.GetWaitEstimateRequest{Email: "waiting1@example.com"})
+	assert.NoError(t, err)
+	assert.False(t, response.HasEstimate, "No estimate without a cancellation history")
+
+	// Two cancellations in section B, five minutes apart
+	for _, email := range []string{"b1@example.com", "b2@example.com"} {
+		tryPurchase(tm, email, inSection("B"))
+	}
+	for _, email := range []string{"b1@example.com", "b2@example.com"} {
+		_, err = tm.RemoveUser(context.Background(), &pb.RemoveUserRequest{Email: email})
+		assert.NoError(t, err)
+		clock.Advance(5 * time.Minute)
+	}
+
+	response, err = tm.GetWaitEstimate(context.Background(), &pb.GetWaitEstimateRequest{Email: "waiting1@example.com"})
+	assert.NoError(t, err)
+	assert.True(t, response.HasEstimate)
+	assert.Equal(t, int64((5 * time.Minute).Seconds()), response.EstimatedWaitSeconds)
+}
+
+func TestGetWaitEstimateNotWaitlisted(t *testing.T) {
+	tm := createWaitingTicketManager(t)
+
+	_, err := tm.GetWaitEstimate(context.Background(), &pb.GetWaitEstimateRequest{Email: "first@example.com"})
+	assert.Equal(t, codes.NotFound, status.Code(err), "Booked riders are not waiting")
+
+	_, err = tm.GetWaitEstimate(context.Background(), &pb.GetWaitEstimateRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
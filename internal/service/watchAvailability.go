@@ -0,0 +1,208 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Watch registers a watcher woken whenever seats are assigned, released,
+// moved, blocked or resized. Wake-ups arriving while the watcher is busy are
+// coalesced into one. The returned stop function unregisters the watcher and
+// must be called once the caller is done; calling it again does nothing.
+func (sm *SeatManager) Watch() (<-chan struct{}, func()) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	wake := make(chan struct{}, 1)
+	if sm.watchers == nil {
+		sm.watchers = make(map[chan struct{}]struct{})
+	}
+	sm.watchers[wake] = struct{}{}
+
+	stop := func() {
+		sm.mu.Lock()
+		defer sm.mu.Unlock()
+		delete(sm.watchers, wake)
+	}
+	return wake, stop
+}
+
+// Watchers returns the number of registered watchers.
+func (sm *SeatManager) Watchers() int {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	return len(sm.watchers)
+}
+
+// seatsChanged raises any occupancy alert and wakes the watchers after seats
+// change. Callers must hold sm.mu.
+func (sm *SeatManager) seatsChanged() {
+	sm.checkOccupancy()
+	for wake := range sm.watchers {
+		select {
+		case wake <- struct{}{}:
+		default: // Already due to wake
+		}
+	}
+}
+
+// StopWatchers ends every WatchAvailability stream, and any opened later, so
+// that a graceful server stop isn't held up by clients still watching.
+func (tm *TicketManager) StopWatchers() {
+	tm.stopWatchersOnce.Do(func() { close(tm.watchersStopped) })
+}
+
+// WatchAvailability streams the vacancy of a departure's sections: once when
+// the stream opens and again whenever seats change. A departure nobody has
+// booked yet is reported empty until its first booking. The stream ends when
+// the client goes away or StopWatchers is called, unregistering its watcher.
+func (tm *TicketManager) WatchAvailability(req *pb.WatchAvailabilityRequest, stream grpc.ServerStreamingServer[pb.WatchAvailabilityResponse]) error {
+	train, err := tm.watchedDeparture(req)
+	if err != nil {
+		return err
+	}
+
+	departure, added := tm.watchableDeparture(train, req.DepartureDate)
+	if departure == nil {
+		// Watching must not create the departure, so report the empty layout
+		// and wait for someone to book it
+		if err := tm.sendAvailability(stream, tm.SeatManager.newDeparture(), req); err != nil {
+			return err
+		}
+		for departure == nil {
+			if err := tm.awaitWatch(stream.Context(), added, req); err != nil {
+				return err
+			}
+			departure, added = tm.watchableDeparture(train, req.DepartureDate)
+		}
+	}
+
+	wake, stop := departure.Watch()
+	defer stop()
+
+	for {
+		if err := tm.sendAvailability(stream, departure, req); err != nil {
+			return err
+		}
+		if err := tm.awaitWatch(stream.Context(), wake, req); err != nil {
+			return err
+		}
+	}
+}
+
+// watchableDeparture returns the seats of the watched departure, or nil and a
+// channel closed once another departure is first booked if nobody has booked
+// it yet.
+func (tm *TicketManager) watchableDeparture(train, date string) (*SeatManager, <-chan struct{}) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	if departure, exists := tm.existingDeparture(train, date); exists {
+		return departure, nil
+	}
+	return nil, tm.departureAdded
+}
+
+// sendAvailability sends the vacancy of the departure's watched sections.
+func (tm *TicketManager) sendAvailability(stream grpc.ServerStreamingServer[pb.WatchAvailabilityResponse], departure *SeatManager, req *pb.WatchAvailabilityRequest) error {
+	if err := stream.Send(sectionAvailability(departure, req.Section)); err != nil {
+		tm.Logger.Debug("WatchAvailability stream closed",
+			zap.String("section", req.Section),
+			zap.String("departure_date", req.DepartureDate),
+			zap.Error(err),
+		)
+		return err
+	}
+	return nil
+}
+
+// awaitWatch waits for wake, returning an error if the client goes away or
+// the server stops the watchers first.
+func (tm *TicketManager) awaitWatch(ctx context.Context, wake <-chan struct{}, req *pb.WatchAvailabilityRequest) error {
+	select {
+	case <-wake:
+		return nil
+	case <-ctx.Done():
+		tm.Logger.Info("WatchAvailability client disconnected",
+			zap.String("section", req.Section),
+			zap.String("departure_date", req.DepartureDate),
+		)
+		return status.FromContextError(ctx.Err()).Err()
+	case <-tm.watchersStopped:
+		tm.Logger.Info("WatchAvailability stopped by server",
+			zap.String("section", req.Section),
+			zap.String("departure_date", req.DepartureDate),
+		)
+		return status.Error(codes.Unavailable, "server is shutting down")
+	}
+}
+
+// watchedDeparture validates a WatchAvailability request and returns the train
+// it watches.
+func (tm *TicketManager) watchedDeparture(req *pb.WatchAvailabilityRequest) (string, error) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	tm.Logger.Info("WatchAvailability request received")
+
+	// Validate the request
+	if req == nil {
+		tm.Logger.Error("WatchAvailability request is nil")
+		return "", status.Error(codes.InvalidArgument, "request is nil")
+	}
+
+	if req.DepartureDate != "" {
+		if _, err := time.Parse(departureDateLayout, req.DepartureDate); err != nil {
+			tm.Logger.Error("WatchAvailability invalid departure date",
+				zap.String("departure_date", req.DepartureDate),
+			)
+			return "", status.Error(codes.InvalidArgument, "departure date must be YYYY-MM-DD")
+		}
+	}
+	train, err := tm.resolveTrain(req.TrainId)
+	if err != nil {
+		tm.Logger.Error("WatchAvailability unknown train",
+			zap.String("train_id", req.TrainId),
+		)
+		return "", err
+	}
+
+	// Check if the section exists; every departure has the same sections
+	if req.Section != "" && !tm.SeatManager.HasSection(req.Section) {
+		tm.Logger.Error("WatchAvailability section not found",
+			zap.String("section", req.Section),
+		)
+		return "", status.Error(codes.NotFound, "section not found")
+	}
+
+	tm.Logger.Info("WatchAvailability request",
+		zap.String("section", req.Section),
+		zap.String("train_id", train),
+		zap.String("departure_date", req.DepartureDate),
+		zap.Time("timestamp", tm.Now()),
+	)
+	return train, nil
+}
+
+// sectionAvailability reports the vacancy of the departure's sections in
+// round-robin order, or only of the named section if one is given.
+func sectionAvailability(departure *SeatManager, section string) *pb.WatchAvailabilityResponse {
+	response := &pb.WatchAvailabilityResponse{Sections: make([]*pb.SectionAvailability, 0)}
+	for _, info := range departure.SectionInfos() {
+		if section != "" && info.Name != section {
+			continue
+		}
+		response.Sections = append(response.Sections, &pb.SectionAvailability{
+			Section:     info.Name,
+			VacantSeats: int32(info.VacantSeats),
+			MaxSeats:    int32(info.MaxSeats),
+		})
+	}
+	return response
+}
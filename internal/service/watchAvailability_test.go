@@ -0,0 +1,195 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/sanjaykishor/rail-connect/proto"
+)
+
+// fakeWatchStream collects the updates sent on a WatchAvailability stream.
+// Sends fail with sendErr once it is set.
+type fakeWatchStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	sent    chan *pb.WatchAvailabilityResponse
+	sendErr error
+}
+
+func newFakeWatchStream(ctx context.Context) *fakeWatchStream {
+	return &fakeWatchStream{ctx: ctx, sent: make(chan *pb.WatchAvailabilityResponse, 16)}
+}
+
+func (s *fakeWatchStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *fakeWatchStream) Send(response *pb.WatchAvailabilityResponse) error {
+	if s.sendErr != nil {
+		return s.sendErr
+	}
+	s.sent <- response
+	return nil
+}
+
+// next returns the next update sent on the stream.
+func (s *fakeWatchStream) next(t *testing.T) *pb.WatchAvailabilityResponse {
+	t.Helper()
+	select {
+	case response := <-s.sent:
+		return response
+	case <-time.After(5 * time.Second):
+		t.Fatalf("no availability update sent")
+		return nil
+	}
+}
+
+// watch runs WatchAvailability on stream in the background and returns the
+// channel its result is sent on.
+func watch(tm *TicketManager, req *pb.WatchAvailabilityRequest, stream *fakeWatchStream) <-chan error {
+	done := make(chan error, 1)
+	go func() { done <- tm.WatchAvailability(req, stream) }()
+	return done
+}
+
+// waitForWatch returns the result of a WatchAvailability call started by watch.
+func waitForWatch(t *testing.T, done <-chan error) error {
+	t.Helper()
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(5 * time.Second):
+		t.Fatalf("WatchAvailability did not return")
+		return nil
+	}
+}
+
+func TestWatchAvailability(t *testing.T) {
+	tm := createTestTicketManager()
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := newFakeWatchStream(ctx)
+	done := watch(tm, &pb.WatchAvailabilityRequest{Section: "A"}, stream)
+
+	assert.Equal(t, []*pb.SectionAvailability{{Section: "A", VacantSeats: 20, MaxSeats: 20}}, stream.next(t).Sections)
+
+	// Bookings and cancellations each send an update
+	purchase(t, tm, "test@example.com", "London", "France")
+	assert.Equal(t, int32(19), stream.next(t).Sections[0].VacantSeats)
+	_, err := tm.RemoveUser(context.Background(), &pb.RemoveUserRequest{Email: "test@example.com"})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(20), stream.next(t).Sections[0].VacantSeats)
+
+	cancel()
+	assert.Equal(t, codes.Canceled, status.Code(waitForWatch(t, done)))
+	assert.Equal(t, 0, tm.SeatManager.Watchers(), "The watcher should be removed when the client leaves")
+}
+
+func TestWatchAvailabilityDeparture(t *testing.T) {
+	tm := createDatedTicketManager()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream := newFakeWatchStream(ctx)
+	watch(tm, &pb.WatchAvailabilityRequest{DepartureDate: "2024-03-12"}, stream)
+
+	sections := stream.next(t).Sections
+	assert.Len(t, sections, 2)
+	assert.Equal(t, "A", sections[0].Section)
+	assert.Equal(t, "B", sections[1].Section)
+	assert.Equal(t, int32(20), sections[0].VacantSeats)
+	assert.Empty(t, tm.departures, "Watching should not create the departure")
+
+	// Booking another departure is not reported
+	purchase(t, tm, "other@example.com", "London", "France", onDate("2024-03-11"))
+	select {
+	case response := <-stream.sent:
+		t.Fatalf("unexpected update for another departure: %v", response)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// The first booking of the watched departure is, and later changes too
+	purchase(t, tm, "test@example.com", "London", "France", onDate("2024-03-12"))
+	assert.Equal(t, int32(39), totalVacant(stream.next(t)))
+	assert.Equal(t, 0, tm.SeatManager.Watchers(), "Dated departures should be watched on their own seats")
+	assert.Equal(t, 1, tm.departure("", "2024-03-12").Watchers())
+	_, err := tm.RemoveUser(context.Background(), &pb.RemoveUserRequest{Email: "test@example.com"})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(40), totalVacant(stream.next(t)))
+}
+
+// totalVacant adds up the vacant seats of every section in an update.
+func totalVacant(response *pb.WatchAvailabilityResponse) int32 {
+	var vacant int32
+	for _, section := range response.Sections {
+		vacant += section.VacantSeats
+	}
+	return vacant
+}
+
+func TestWatchAvailabilityCleanup(t *testing.T) {
+	tm := createTestTicketManager()
+
+	// Open and close many streams, as reconnecting clients would
+	for i := 0; i < 50; i++ {
+		ctx, cancel := context.WithCancel(context.Background())
+		stream := newFakeWatchStream(ctx)
+		done := watch(tm, &pb.WatchAvailabilityRequest{}, stream)
+		stream.next(t)
+		cancel()
+		waitForWatch(t, done)
+	}
+	assert.Equal(t, 0, tm.SeatManager.Watchers(), "Closed streams should not leak watchers")
+
+	// Streams whose client is already gone end on the next failed send
+	stream := newFakeWatchStream(context.Background())
+	done := watch(tm, &pb.WatchAvailabilityRequest{}, stream)
+	stream.next(t)
+	stream.sendErr = errors.New("transport is closing")
+	purchase(t, tm, "test@example.com", "London", "France")
+	assert.EqualError(t, waitForWatch(t, done), "transport is closing")
+	assert.Equal(t, 0, tm.SeatManager.Watchers())
+}
+
+func TestStopWatchers(t *testing.T) {
+	tm := createTestTicketManager()
+
+	var dones []<-chan error
+	for i := 0; i < 10; i++ {
+		stream := newFakeWatchStream(context.Background())
+		dones = append(dones, watch(tm, &pb.WatchAvailabilityRequest{}, stream))
+		stream.next(t)
+	}
+	assert.Equal(t, 10, tm.SeatManager.Watchers())
+
+	tm.StopWatchers()
+	for _, done := range dones {
+		assert.Equal(t, codes.Unavailable, status.Code(waitForWatch(t, done)))
+	}
+	assert.Equal(t, 0, tm.SeatManager.Watchers(), "Stopped streams should remove their watchers")
+
+	// Streams opened after stopping end straight away
+	stream := newFakeWatchStream(context.Background())
+	assert.Equal(t, codes.Unavailable, status.Code(waitForWatch(t, watch(tm, &pb.WatchAvailabilityRequest{}, stream))))
+	tm.StopWatchers()
+}
+
+func TestWatchAvailabilityInvalid(t *testing.T) {
+	tm := createTestTicketManager()
+	stream := newFakeWatchStream(context.Background())
+
+	err := tm.WatchAvailability(nil, stream)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	err = tm.WatchAvailability(&pb.WatchAvailabilityRequest{Section: "Z"}, stream)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	err = tm.WatchAvailability(&pb.WatchAvailabilityRequest{DepartureDate: "tomorrow"}, stream)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, 0, tm.SeatManager.Watchers())
+}
@@ -9,6 +9,7 @@ package proto
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -21,14 +22,403 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// How close together a two-person booking could be seated, best first
+type PairSeating int32
+
+const (
+	PairSeating_PAIR_SEATING_UNSPECIFIED  PairSeating = 0 // No companion was booked
+	PairSeating_PAIR_SEATING_ADJACENT     PairSeating = 1 // Neighbouring seats
+	PairSeating_PAIR_SEATING_SAME_SECTION PairSeating = 2 // The same section, not next to each other
+	PairSeating_PAIR_SEATING_SPLIT        PairSeating = 3 // Different sections
+)
+
+// Enum value maps for PairSeating.
+var (
+	PairSeating_name = map[int32]string{
+		0: "PAIR_SEATING_UNSPECIFIED",
+		1: "PAIR_SEATING_ADJACENT",
+		2: "PAIR_SEATING_SAME_SECTION",
+		3: "PAIR_SEATING_SPLIT",
+	}
+	PairSeating_value = map[string]int32{
+		"PAIR_SEATING_UNSPECIFIED":  0,
+		"PAIR_SEATING_ADJACENT":     1,
+		"PAIR_SEATING_SAME_SECTION": 2,
+		"PAIR_SEATING_SPLIT":        3,
+	}
+)
+
+func (x PairSeating) Enum() *PairSeating {
+	p := new(PairSeating)
+	*p = x
+	return p
+}
+
+func (x PairSeating) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PairSeating) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_ticketBooking_proto_enumTypes[0].Descriptor()
+}
+
+func (PairSeating) Type() protoreflect.EnumType {
+	return &file_proto_ticketBooking_proto_enumTypes[0]
+}
+
+func (x PairSeating) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PairSeating.Descriptor instead.
+func (PairSeating) EnumDescriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{0}
+}
+
+// Whether a booking has been paid for
+type BookingStatus int32
+
+const (
+	BookingStatus_BOOKING_STATUS_CONFIRMED       BookingStatus = 0 // Paid, or booked without needing payment
+	BookingStatus_BOOKING_STATUS_PENDING_PAYMENT BookingStatus = 1 // Provisional, cancelled at paymentDueAt unless confirmed
+)
+
+// Enum value maps for BookingStatus.
+var (
+	BookingStatus_name = map[int32]string{
+		0: "BOOKING_STATUS_CONFIRMED",
+		1: "BOOKING_STATUS_PENDING_PAYMENT",
+	}
+	BookingStatus_value = map[string]int32{
+		"BOOKING_STATUS_CONFIRMED":       0,
+		"BOOKING_STATUS_PENDING_PAYMENT": 1,
+	}
+)
+
+func (x BookingStatus) Enum() *BookingStatus {
+	p := new(BookingStatus)
+	*p = x
+	return p
+}
+
+func (x BookingStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BookingStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_ticketBooking_proto_enumTypes[1].Descriptor()
+}
+
+func (BookingStatus) Type() protoreflect.EnumType {
+	return &file_proto_ticketBooking_proto_enumTypes[1]
+}
+
+func (x BookingStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BookingStatus.Descriptor instead.
+func (BookingStatus) EnumDescriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{1}
+}
+
+// Why a ticket was cancelled
+type CancellationReason int32
+
+const (
+	CancellationReason_CANCELLATION_REASON_UNSPECIFIED       CancellationReason = 0 // No reason given
+	CancellationReason_CANCELLATION_REASON_USER_REQUESTED    CancellationReason = 1 // The rider no longer wants to travel
+	CancellationReason_CANCELLATION_REASON_TRAIN_CANCELLED   CancellationReason = 2 // The train or route was cancelled; BulkCancel records this
+	CancellationReason_CANCELLATION_REASON_PAYMENT_FAILED    CancellationReason = 3 // Payment failed; expired provisional bookings record this
+	CancellationReason_CANCELLATION_REASON_DUPLICATE_BOOKING CancellationReason = 4 // The rider was booked twice by mistake
+	CancellationReason_CANCELLATION_REASON_OTHER             CancellationReason = 5 // Any other reason
+)
+
+// Enum value maps for CancellationReason.
+var (
+	CancellationReason_name = map[int32]string{
+		0: "CANCELLATION_REASON_UNSPECIFIED",
+		1: "CANCELLATION_REASON_USER_REQUESTED",
+		2: "CANCELLATION_REASON_TRAIN_CANCELLED",
+		3: "CANCELLATION_REASON_PAYMENT_FAILED",
+		4: "CANCELLATION_REASON_DUPLICATE_BOOKING",
+		5: "CANCELLATION_REASON_OTHER",
+	}
+	CancellationReason_value = map[string]int32{
+		"CANCELLATION_REASON_UNSPECIFIED":       0,
+		"CANCELLATION_REASON_USER_REQUESTED":    1,
+		"CANCELLATION_REASON_TRAIN_CANCELLED":   2,
+		"CANCELLATION_REASON_PAYMENT_FAILED":    3,
+		"CANCELLATION_REASON_DUPLICATE_BOOKING": 4,
+		"CANCELLATION_REASON_OTHER":             5,
+	}
+)
+
+func (x CancellationReason) Enum() *CancellationReason {
+	p := new(CancellationReason)
+	*p = x
+	return p
+}
+
+func (x CancellationReason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CancellationReason) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_ticketBooking_proto_enumTypes[2].Descriptor()
+}
+
+func (CancellationReason) Type() protoreflect.EnumType {
+	return &file_proto_ticketBooking_proto_enumTypes[2]
+}
+
+func (x CancellationReason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CancellationReason.Descriptor instead.
+func (CancellationReason) EnumDescriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{2}
+}
+
+// Messages for Receipt History
+type ReceiptEventType int32
+
+const (
+	ReceiptEventType_RECEIPT_EVENT_UNSPECIFIED       ReceiptEventType = 0
+	ReceiptEventType_RECEIPT_EVENT_BOOKED            ReceiptEventType = 1
+	ReceiptEventType_RECEIPT_EVENT_SEAT_CHANGED      ReceiptEventType = 2
+	ReceiptEventType_RECEIPT_EVENT_CANCELLED         ReceiptEventType = 3
+	ReceiptEventType_RECEIPT_EVENT_FORCE_REASSIGNED  ReceiptEventType = 4 // Seat changed by support with ForceReassign
+	ReceiptEventType_RECEIPT_EVENT_PAYMENT_CONFIRMED ReceiptEventType = 5 // Provisional booking paid for with ConfirmPayment
+	ReceiptEventType_RECEIPT_EVENT_ROUTE_CHANGED     ReceiptEventType = 6 // Stations changed with ChangeStations
+)
+
+// Enum value maps for ReceiptEventType.
+var (
+	ReceiptEventType_name = map[int32]string{
+		0: "RECEIPT_EVENT_UNSPECIFIED",
+		1: "RECEIPT_EVENT_BOOKED",
+		2: "RECEIPT_EVENT_SEAT_CHANGED",
+		3: "RECEIPT_EVENT_CANCELLED",
+		4: "RECEIPT_EVENT_FORCE_REASSIGNED",
+		5: "RECEIPT_EVENT_PAYMENT_CONFIRMED",
+		6: "RECEIPT_EVENT_ROUTE_CHANGED",
+	}
+	ReceiptEventType_value = map[string]int32{
+		"RECEIPT_EVENT_UNSPECIFIED":       0,
+		"RECEIPT_EVENT_BOOKED":            1,
+		"RECEIPT_EVENT_SEAT_CHANGED":      2,
+		"RECEIPT_EVENT_CANCELLED":         3,
+		"RECEIPT_EVENT_FORCE_REASSIGNED":  4,
+		"RECEIPT_EVENT_PAYMENT_CONFIRMED": 5,
+		"RECEIPT_EVENT_ROUTE_CHANGED":     6,
+	}
+)
+
+func (x ReceiptEventType) Enum() *ReceiptEventType {
+	p := new(ReceiptEventType)
+	*p = x
+	return p
+}
+
+func (x ReceiptEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ReceiptEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_ticketBooking_proto_enumTypes[3].Descriptor()
+}
+
+func (ReceiptEventType) Type() protoreflect.EnumType {
+	return &file_proto_ticketBooking_proto_enumTypes[3]
+}
+
+func (x ReceiptEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ReceiptEventType.Descriptor instead.
+func (ReceiptEventType) EnumDescriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{3}
+}
+
+// Messages for Seat Detail
+type SeatPosition int32
+
+const (
+	SeatPosition_SEAT_POSITION_UNSPECIFIED SeatPosition = 0
+	SeatPosition_SEAT_POSITION_WINDOW      SeatPosition = 1
+	SeatPosition_SEAT_POSITION_AISLE       SeatPosition = 2
+	SeatPosition_SEAT_POSITION_MIDDLE      SeatPosition = 3
+)
+
+// Enum value maps for SeatPosition.
+var (
+	SeatPosition_name = map[int32]string{
+		0: "SEAT_POSITION_UNSPECIFIED",
+		1: "SEAT_POSITION_WINDOW",
+		2: "SEAT_POSITION_AISLE",
+		3: "SEAT_POSITION_MIDDLE",
+	}
+	SeatPosition_value = map[string]int32{
+		"SEAT_POSITION_UNSPECIFIED": 0,
+		"SEAT_POSITION_WINDOW":      1,
+		"SEAT_POSITION_AISLE":       2,
+		"SEAT_POSITION_MIDDLE":      3,
+	}
+)
+
+func (x SeatPosition) Enum() *SeatPosition {
+	p := new(SeatPosition)
+	*p = x
+	return p
+}
+
+func (x SeatPosition) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SeatPosition) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_ticketBooking_proto_enumTypes[4].Descriptor()
+}
+
+func (SeatPosition) Type() protoreflect.EnumType {
+	return &file_proto_ticketBooking_proto_enumTypes[4]
+}
+
+func (x SeatPosition) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SeatPosition.Descriptor instead.
+func (SeatPosition) EnumDescriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{4}
+}
+
+// Span and granularity of the counts returned by GetBookingTrends
+type TrendBucket int32
+
+const (
+	TrendBucket_TREND_BUCKET_UNSPECIFIED TrendBucket = 0 // Not a valid bucket
+	TrendBucket_TREND_BUCKET_MINUTE      TrendBucket = 1 // The last hour in one-minute buckets
+	TrendBucket_TREND_BUCKET_HOUR        TrendBucket = 2 // The last day in one-hour buckets
+)
+
+// Enum value maps for TrendBucket.
+var (
+	TrendBucket_name = map[int32]string{
+		0: "TREND_BUCKET_UNSPECIFIED",
+		1: "TREND_BUCKET_MINUTE",
+		2: "TREND_BUCKET_HOUR",
+	}
+	TrendBucket_value = map[string]int32{
+		"TREND_BUCKET_UNSPECIFIED": 0,
+		"TREND_BUCKET_MINUTE":      1,
+		"TREND_BUCKET_HOUR":        2,
+	}
+)
+
+func (x TrendBucket) Enum() *TrendBucket {
+	p := new(TrendBucket)
+	*p = x
+	return p
+}
+
+func (x TrendBucket) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TrendBucket) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_ticketBooking_proto_enumTypes[5].Descriptor()
+}
+
+func (TrendBucket) Type() protoreflect.EnumType {
+	return &file_proto_ticketBooking_proto_enumTypes[5]
+}
+
+func (x TrendBucket) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TrendBucket.Descriptor instead.
+func (TrendBucket) EnumDescriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{5}
+}
+
+// How a setting differs after a reload
+type ConfigChangeType int32
+
+const (
+	ConfigChangeType_CONFIG_CHANGE_TYPE_UNSPECIFIED ConfigChangeType = 0
+	ConfigChangeType_CONFIG_CHANGE_TYPE_ADDED       ConfigChangeType = 1 // A station, section or other entry was added
+	ConfigChangeType_CONFIG_CHANGE_TYPE_REMOVED     ConfigChangeType = 2 // An entry was removed
+	ConfigChangeType_CONFIG_CHANGE_TYPE_CHANGED     ConfigChangeType = 3 // A setting has a new value
+)
+
+// Enum value maps for ConfigChangeType.
+var (
+	ConfigChangeType_name = map[int32]string{
+		0: "CONFIG_CHANGE_TYPE_UNSPECIFIED",
+		1: "CONFIG_CHANGE_TYPE_ADDED",
+		2: "CONFIG_CHANGE_TYPE_REMOVED",
+		3: "CONFIG_CHANGE_TYPE_CHANGED",
+	}
+	ConfigChangeType_value = map[string]int32{
+		"CONFIG_CHANGE_TYPE_UNSPECIFIED": 0,
+		"CONFIG_CHANGE_TYPE_ADDED":       1,
+		"CONFIG_CHANGE_TYPE_REMOVED":     2,
+		"CONFIG_CHANGE_TYPE_CHANGED":     3,
+	}
+)
+
+func (x ConfigChangeType) Enum() *ConfigChangeType {
+	p := new(ConfigChangeType)
+	*p = x
+	return p
+}
+
+func (x ConfigChangeType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ConfigChangeType) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_ticketBooking_proto_enumTypes[6].Descriptor()
+}
+
+func (ConfigChangeType) Type() protoreflect.EnumType {
+	return &file_proto_ticketBooking_proto_enumTypes[6]
+}
+
+func (x ConfigChangeType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ConfigChangeType.Descriptor instead.
+func (ConfigChangeType) EnumDescriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{6}
+}
+
 // Messages for Ticket Purchase
 type PurchaseTicketRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
-	From          string                 `protobuf:"bytes,4,opt,name=from,proto3" json:"from,omitempty"`
-	To            string                 `protobuf:"bytes,5,opt,name=to,proto3" json:"to,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	User              *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	From              string                 `protobuf:"bytes,4,opt,name=from,proto3" json:"from,omitempty"`
+	To                string                 `protobuf:"bytes,5,opt,name=to,proto3" json:"to,omitempty"`
+	DryRun            bool                   `protobuf:"varint,6,opt,name=dryRun,proto3" json:"dryRun,omitempty"`                                                                               // Validate and report the would-be seat without booking it
+	PreferredSections []string               `protobuf:"bytes,7,rep,name=preferredSections,proto3" json:"preferredSections,omitempty"`                                                          // Sections to try in order before round-robin
+	DepartureDate     string                 `protobuf:"bytes,8,opt,name=departureDate,proto3" json:"departureDate,omitempty"`                                                                  // Date of travel as YYYY-MM-DD; empty books the default train
+	Companion         *User                  `protobuf:"bytes,9,opt,name=companion,proto3" json:"companion,omitempty"`                                                                          // Second rider on the same journey, seated next to the user where possible
+	Metadata          map[string]string      `protobuf:"bytes,10,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Integrator context such as loyalty IDs or promo codes, copied onto the receipt
+	MinimizePrice     bool                   `protobuf:"varint,11,opt,name=minimizePrice,proto3" json:"minimizePrice,omitempty"`                                                                // Seat the rider in the section with the lowest fare that has a free seat, instead of round-robin
+	Provisional       bool                   `protobuf:"varint,12,opt,name=provisional,proto3" json:"provisional,omitempty"`                                                                    // Book pending payment; the booking is cancelled unless ConfirmPayment is called in time
+	HoldId            string                 `protobuf:"bytes,13,opt,name=holdId,proto3" json:"holdId,omitempty"`                                                                               // Book the seat set aside by HoldSeat for this user and journey
+	TrainId           string                 `protobuf:"bytes,14,opt,name=trainId,proto3" json:"trainId,omitempty"`                                                                             // Train to book; empty books the configured default train
+	ReceiptMask       *fieldmaskpb.FieldMask `protobuf:"bytes,15,opt,name=receiptMask,proto3" json:"receiptMask,omitempty"`                                                                     // Receipt fields to return, such as "seat" or "user.email"; empty returns the whole receipt
+	LoyaltyTier       string                 `protobuf:"bytes,16,opt,name=loyaltyTier,proto3" json:"loyaltyTier,omitempty"`                                                                     // Rider's loyalty tier, such as "gold"; members are seated in the tier's configured sections first when free
+	FareClass         string                 `protobuf:"bytes,17,opt,name=fareClass,proto3" json:"fareClass,omitempty"`                                                                         // Discounted fare class to buy from, such as "saver"; when its pool is sold out the standard fare is charged or the purchase rejected, as configured
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *PurchaseTicketRequest) Reset() {
@@ -82,12 +472,100 @@ func (x *PurchaseTicketRequest) GetTo() string {
 	return ""
 }
 
+func (x *PurchaseTicketRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *PurchaseTicketRequest) GetPreferredSections() []string {
+	if x != nil {
+		return x.PreferredSections
+	}
+	return nil
+}
+
+func (x *PurchaseTicketRequest) GetDepartureDate() string {
+	if x != nil {
+		return x.DepartureDate
+	}
+	return ""
+}
+
+func (x *PurchaseTicketRequest) GetCompanion() *User {
+	if x != nil {
+		return x.Companion
+	}
+	return nil
+}
+
+func (x *PurchaseTicketRequest) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *PurchaseTicketRequest) GetMinimizePrice() bool {
+	if x != nil {
+		return x.MinimizePrice
+	}
+	return false
+}
+
+func (x *PurchaseTicketRequest) GetProvisional() bool {
+	if x != nil {
+		return x.Provisional
+	}
+	return false
+}
+
+func (x *PurchaseTicketRequest) GetHoldId() string {
+	if x != nil {
+		return x.HoldId
+	}
+	return ""
+}
+
+func (x *PurchaseTicketRequest) GetTrainId() string {
+	if x != nil {
+		return x.TrainId
+	}
+	return ""
+}
+
+func (x *PurchaseTicketRequest) GetReceiptMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.ReceiptMask
+	}
+	return nil
+}
+
+func (x *PurchaseTicketRequest) GetLoyaltyTier() string {
+	if x != nil {
+		return x.LoyaltyTier
+	}
+	return ""
+}
+
+func (x *PurchaseTicketRequest) GetFareClass() string {
+	if x != nil {
+		return x.FareClass
+	}
+	return ""
+}
+
 type PurchaseTicketResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
-	Receipt       *Receipt               `protobuf:"bytes,2,opt,name=receipt,proto3" json:"receipt,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Message           string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Receipt           *Receipt               `protobuf:"bytes,2,opt,name=receipt,proto3" json:"receipt,omitempty"`
+	MatchedPreference string                 `protobuf:"bytes,3,opt,name=matchedPreference,proto3" json:"matchedPreference,omitempty"`                     // Preferred section the seat is in, empty if none was satisfied
+	WaitlistedSection string                 `protobuf:"bytes,4,opt,name=waitlistedSection,proto3" json:"waitlistedSection,omitempty"`                     // Full section the rider is waitlisted for instead of booked, empty if booked
+	CompanionReceipt  *Receipt               `protobuf:"bytes,5,opt,name=companionReceipt,proto3" json:"companionReceipt,omitempty"`                       // Receipt of the companion, if one was booked
+	PairSeating       PairSeating            `protobuf:"varint,6,opt,name=pairSeating,proto3,enum=ticketBooking.PairSeating" json:"pairSeating,omitempty"` // How close together the user and companion are seated
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *PurchaseTicketResponse) Reset() {
@@ -134,15 +612,54 @@ func (x *PurchaseTicketResponse) GetReceipt() *Receipt {
 	return nil
 }
 
+func (x *PurchaseTicketResponse) GetMatchedPreference() string {
+	if x != nil {
+		return x.MatchedPreference
+	}
+	return ""
+}
+
+func (x *PurchaseTicketResponse) GetWaitlistedSection() string {
+	if x != nil {
+		return x.WaitlistedSection
+	}
+	return ""
+}
+
+func (x *PurchaseTicketResponse) GetCompanionReceipt() *Receipt {
+	if x != nil {
+		return x.CompanionReceipt
+	}
+	return nil
+}
+
+func (x *PurchaseTicketResponse) GetPairSeating() PairSeating {
+	if x != nil {
+		return x.PairSeating
+	}
+	return PairSeating_PAIR_SEATING_UNSPECIFIED
+}
+
 type Receipt struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	From          string                 `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
-	To            string                 `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
-	User          *User                  `protobuf:"bytes,3,opt,name=user,proto3" json:"user,omitempty"`
-	PricePaid     float64                `protobuf:"fixed64,4,opt,name=pricePaid,proto3" json:"pricePaid,omitempty"`
-	Seat          *Seat                  `protobuf:"bytes,5,opt,name=seat,proto3" json:"seat,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	From             string                 `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To               string                 `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	User             *User                  `protobuf:"bytes,3,opt,name=user,proto3" json:"user,omitempty"`
+	PricePaid        float64                `protobuf:"fixed64,4,opt,name=pricePaid,proto3" json:"pricePaid,omitempty"` // Total paid: the fare plus the booking fee
+	Seat             *Seat                  `protobuf:"bytes,5,opt,name=seat,proto3" json:"seat,omitempty"`
+	BaseFare         float64                `protobuf:"fixed64,6,opt,name=baseFare,proto3" json:"baseFare,omitempty"`                                                                          // Fare before any time window multiplier
+	FareWindow       string                 `protobuf:"bytes,7,opt,name=fareWindow,proto3" json:"fareWindow,omitempty"`                                                                        // Time window that set the price, empty off-peak
+	BookingReference string                 `protobuf:"bytes,8,opt,name=bookingReference,proto3" json:"bookingReference,omitempty"`                                                            // Short code identifying the booking, usable instead of the email in GetReceipt
+	DepartureDate    string                 `protobuf:"bytes,9,opt,name=departureDate,proto3" json:"departureDate,omitempty"`                                                                  // Date of travel as YYYY-MM-DD, empty for the default train
+	Metadata         map[string]string      `protobuf:"bytes,10,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Metadata given when the ticket was purchased
+	Fare             float64                `protobuf:"fixed64,11,opt,name=fare,proto3" json:"fare,omitempty"`                                                                                 // Effective fare, after any time window multiplier and section price
+	BookingFee       float64                `protobuf:"fixed64,12,opt,name=bookingFee,proto3" json:"bookingFee,omitempty"`                                                                     // Flat fee charged on top of the fare
+	Status           BookingStatus          `protobuf:"varint,13,opt,name=status,proto3,enum=ticketBooking.BookingStatus" json:"status,omitempty"`
+	PaymentDueAt     int64                  `protobuf:"varint,14,opt,name=paymentDueAt,proto3" json:"paymentDueAt,omitempty"` // Unix time in seconds when a provisional booking is cancelled unless confirmed, 0 once confirmed
+	TrainId          string                 `protobuf:"bytes,15,opt,name=trainId,proto3" json:"trainId,omitempty"`            // Train the ticket is for
+	FareClass        string                 `protobuf:"bytes,16,opt,name=fareClass,proto3" json:"fareClass,omitempty"`        // Discounted fare class the ticket was sold in, such as "saver"; empty at the standard fare
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *Receipt) Reset() {
@@ -210,13 +727,90 @@ func (x *Receipt) GetSeat() *Seat {
 	return nil
 }
 
-type User struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	FirstName     string                 `protobuf:"bytes,1,opt,name=firstName,proto3" json:"firstName,omitempty"`
-	LastName      string                 `protobuf:"bytes,2,opt,name=lastName,proto3" json:"lastName,omitempty"`
-	Email         string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *Receipt) GetBaseFare() float64 {
+	if x != nil {
+		return x.BaseFare
+	}
+	return 0
+}
+
+func (x *Receipt) GetFareWindow() string {
+	if x != nil {
+		return x.FareWindow
+	}
+	return ""
+}
+
+func (x *Receipt) GetBookingReference() string {
+	if x != nil {
+		return x.BookingReference
+	}
+	return ""
+}
+
+func (x *Receipt) GetDepartureDate() string {
+	if x != nil {
+		return x.DepartureDate
+	}
+	return ""
+}
+
+func (x *Receipt) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *Receipt) GetFare() float64 {
+	if x != nil {
+		return x.Fare
+	}
+	return 0
+}
+
+func (x *Receipt) GetBookingFee() float64 {
+	if x != nil {
+		return x.BookingFee
+	}
+	return 0
+}
+
+func (x *Receipt) GetStatus() BookingStatus {
+	if x != nil {
+		return x.Status
+	}
+	return BookingStatus_BOOKING_STATUS_CONFIRMED
+}
+
+func (x *Receipt) GetPaymentDueAt() int64 {
+	if x != nil {
+		return x.PaymentDueAt
+	}
+	return 0
+}
+
+func (x *Receipt) GetTrainId() string {
+	if x != nil {
+		return x.TrainId
+	}
+	return ""
+}
+
+func (x *Receipt) GetFareClass() string {
+	if x != nil {
+		return x.FareClass
+	}
+	return ""
+}
+
+type User struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FirstName     string                 `protobuf:"bytes,1,opt,name=firstName,proto3" json:"firstName,omitempty"`
+	LastName      string                 `protobuf:"bytes,2,opt,name=lastName,proto3" json:"lastName,omitempty"`
+	Email         string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *User) Reset() {
@@ -244,55 +838,4833 @@ func (x *User) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use User.ProtoReflect.Descriptor instead.
-func (*User) Descriptor() ([]byte, []int) {
-	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use User.ProtoReflect.Descriptor instead.
+func (*User) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *User) GetFirstName() string {
+	if x != nil {
+		return x.FirstName
+	}
+	return ""
+}
+
+func (x *User) GetLastName() string {
+	if x != nil {
+		return x.LastName
+	}
+	return ""
+}
+
+func (x *User) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+// Messages for Receipt Retrieval
+type GetReceiptRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Email            string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	BookingReference string                 `protobuf:"bytes,2,opt,name=bookingReference,proto3" json:"bookingReference,omitempty"` // Looks the receipt up by reference; the email is optional when set
+	ReceiptMask      *fieldmaskpb.FieldMask `protobuf:"bytes,3,opt,name=receiptMask,proto3" json:"receiptMask,omitempty"`           // Receipt fields to return, such as "seat" or "user.email"; empty returns the whole receipt
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *GetReceiptRequest) Reset() {
+	*x = GetReceiptRequest{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReceiptRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReceiptRequest) ProtoMessage() {}
+
+func (x *GetReceiptRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReceiptRequest.ProtoReflect.Descriptor instead.
+func (*GetReceiptRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetReceiptRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *GetReceiptRequest) GetBookingReference() string {
+	if x != nil {
+		return x.BookingReference
+	}
+	return ""
+}
+
+func (x *GetReceiptRequest) GetReceiptMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.ReceiptMask
+	}
+	return nil
+}
+
+type GetReceiptResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Receipt       *Receipt               `protobuf:"bytes,1,opt,name=receipt,proto3" json:"receipt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReceiptResponse) Reset() {
+	*x = GetReceiptResponse{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReceiptResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReceiptResponse) ProtoMessage() {}
+
+func (x *GetReceiptResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReceiptResponse.ProtoReflect.Descriptor instead.
+func (*GetReceiptResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetReceiptResponse) GetReceipt() *Receipt {
+	if x != nil {
+		return x.Receipt
+	}
+	return nil
+}
+
+// Messages for View User Seats by Section
+type UserSeat struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	AllottedSeat  int32                  `protobuf:"varint,2,opt,name=allottedSeat,proto3" json:"allottedSeat,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserSeat) Reset() {
+	*x = UserSeat{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserSeat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserSeat) ProtoMessage() {}
+
+func (x *UserSeat) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserSeat.ProtoReflect.Descriptor instead.
+func (*UserSeat) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *UserSeat) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *UserSeat) GetAllottedSeat() int32 {
+	if x != nil {
+		return x.AllottedSeat
+	}
+	return 0
+}
+
+type GetUsersBySectionRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Section        string                 `protobuf:"bytes,1,opt,name=section,proto3" json:"section,omitempty"`
+	DepartureDate  string                 `protobuf:"bytes,2,opt,name=departureDate,proto3" json:"departureDate,omitempty"`    // Date of travel as YYYY-MM-DD; empty lists the default train
+	ExcludePending bool                   `protobuf:"varint,3,opt,name=excludePending,proto3" json:"excludePending,omitempty"` // Leave out provisional bookings not yet paid for
+	TrainId        string                 `protobuf:"bytes,4,opt,name=trainId,proto3" json:"trainId,omitempty"`                // Train to list; empty lists the configured default train
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetUsersBySectionRequest) Reset() {
+	*x = GetUsersBySectionRequest{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUsersBySectionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUsersBySectionRequest) ProtoMessage() {}
+
+func (x *GetUsersBySectionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUsersBySectionRequest.ProtoReflect.Descriptor instead.
+func (*GetUsersBySectionRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetUsersBySectionRequest) GetSection() string {
+	if x != nil {
+		return x.Section
+	}
+	return ""
+}
+
+func (x *GetUsersBySectionRequest) GetDepartureDate() string {
+	if x != nil {
+		return x.DepartureDate
+	}
+	return ""
+}
+
+func (x *GetUsersBySectionRequest) GetExcludePending() bool {
+	if x != nil {
+		return x.ExcludePending
+	}
+	return false
+}
+
+func (x *GetUsersBySectionRequest) GetTrainId() string {
+	if x != nil {
+		return x.TrainId
+	}
+	return ""
+}
+
+type GetUsersBySectionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Section       string                 `protobuf:"bytes,1,opt,name=section,proto3" json:"section,omitempty"`
+	Users         []*UserSeat            `protobuf:"bytes,2,rep,name=users,proto3" json:"users,omitempty"`
+	MaxSeats      int32                  `protobuf:"varint,3,opt,name=maxSeats,proto3" json:"maxSeats,omitempty"`       // Seats in the section, so an empty section still reports its size
+	VacantSeats   int32                  `protobuf:"varint,4,opt,name=vacantSeats,proto3" json:"vacantSeats,omitempty"` // Seats free for the whole journey on the requested departure
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUsersBySectionResponse) Reset() {
+	*x = GetUsersBySectionResponse{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUsersBySectionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUsersBySectionResponse) ProtoMessage() {}
+
+func (x *GetUsersBySectionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUsersBySectionResponse.ProtoReflect.Descriptor instead.
+func (*GetUsersBySectionResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetUsersBySectionResponse) GetSection() string {
+	if x != nil {
+		return x.Section
+	}
+	return ""
+}
+
+func (x *GetUsersBySectionResponse) GetUsers() []*UserSeat {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+func (x *GetUsersBySectionResponse) GetMaxSeats() int32 {
+	if x != nil {
+		return x.MaxSeats
+	}
+	return 0
+}
+
+func (x *GetUsersBySectionResponse) GetVacantSeats() int32 {
+	if x != nil {
+		return x.VacantSeats
+	}
+	return 0
+}
+
+type Seat struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Section       string                 `protobuf:"bytes,1,opt,name=section,proto3" json:"section,omitempty"`
+	SeatNumber    int32                  `protobuf:"varint,2,opt,name=seatNumber,proto3" json:"seatNumber,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Seat) Reset() {
+	*x = Seat{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Seat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Seat) ProtoMessage() {}
+
+func (x *Seat) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Seat.ProtoReflect.Descriptor instead.
+func (*Seat) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Seat) GetSection() string {
+	if x != nil {
+		return x.Section
+	}
+	return ""
+}
+
+func (x *Seat) GetSeatNumber() int32 {
+	if x != nil {
+		return x.SeatNumber
+	}
+	return 0
+}
+
+// Messages for User Removal
+type RemoveUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	CancelledAt   string                 `protobuf:"bytes,2,opt,name=cancelledAt,proto3" json:"cancelledAt,omitempty"`                              // Station where the journey was cut short, empty before departure
+	Reason        CancellationReason     `protobuf:"varint,3,opt,name=reason,proto3,enum=ticketBooking.CancellationReason" json:"reason,omitempty"` // Why the ticket is cancelled, recorded in the receipt history
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveUserRequest) Reset() {
+	*x = RemoveUserRequest{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveUserRequest) ProtoMessage() {}
+
+func (x *RemoveUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveUserRequest.ProtoReflect.Descriptor instead.
+func (*RemoveUserRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *RemoveUserRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *RemoveUserRequest) GetCancelledAt() string {
+	if x != nil {
+		return x.CancelledAt
+	}
+	return ""
+}
+
+func (x *RemoveUserRequest) GetReason() CancellationReason {
+	if x != nil {
+		return x.Reason
+	}
+	return CancellationReason_CANCELLATION_REASON_UNSPECIFIED
+}
+
+type RemoveUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	RemovedUser   *User                  `protobuf:"bytes,2,opt,name=removedUser,proto3" json:"removedUser,omitempty"`
+	Refund        float64                `protobuf:"fixed64,3,opt,name=refund,proto3" json:"refund,omitempty"` // Share of the fare returned under the refund policy
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveUserResponse) Reset() {
+	*x = RemoveUserResponse{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveUserResponse) ProtoMessage() {}
+
+func (x *RemoveUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveUserResponse.ProtoReflect.Descriptor instead.
+func (*RemoveUserResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RemoveUserResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *RemoveUserResponse) GetRemovedUser() *User {
+	if x != nil {
+		return x.RemovedUser
+	}
+	return nil
+}
+
+func (x *RemoveUserResponse) GetRefund() float64 {
+	if x != nil {
+		return x.Refund
+	}
+	return 0
+}
+
+// Messages for Seat Modification
+type UpdateUserSeatRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	NewSeat       *Seat                  `protobuf:"bytes,2,opt,name=newSeat,proto3" json:"newSeat,omitempty"`
+	ReceiptMask   *fieldmaskpb.FieldMask `protobuf:"bytes,3,opt,name=receiptMask,proto3" json:"receiptMask,omitempty"` // Receipt fields to return, such as "seat" or "user.email"; empty returns the whole receipt
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateUserSeatRequest) Reset() {
+	*x = UpdateUserSeatRequest{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateUserSeatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateUserSeatRequest) ProtoMessage() {}
+
+func (x *UpdateUserSeatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateUserSeatRequest.ProtoReflect.Descriptor instead.
+func (*UpdateUserSeatRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *UpdateUserSeatRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *UpdateUserSeatRequest) GetNewSeat() *Seat {
+	if x != nil {
+		return x.NewSeat
+	}
+	return nil
+}
+
+func (x *UpdateUserSeatRequest) GetReceiptMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.ReceiptMask
+	}
+	return nil
+}
+
+type UpdateUserSeatResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Message        string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	UpdatedReceipt *Receipt               `protobuf:"bytes,2,opt,name=updatedReceipt,proto3" json:"updatedReceipt,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *UpdateUserSeatResponse) Reset() {
+	*x = UpdateUserSeatResponse{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateUserSeatResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateUserSeatResponse) ProtoMessage() {}
+
+func (x *UpdateUserSeatResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateUserSeatResponse.ProtoReflect.Descriptor instead.
+func (*UpdateUserSeatResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *UpdateUserSeatResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *UpdateUserSeatResponse) GetUpdatedReceipt() *Receipt {
+	if x != nil {
+		return x.UpdatedReceipt
+	}
+	return nil
+}
+
+// Messages for Seat Swap
+type SwapSeatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EmailA        string                 `protobuf:"bytes,1,opt,name=emailA,proto3" json:"emailA,omitempty"`
+	EmailB        string                 `protobuf:"bytes,2,opt,name=emailB,proto3" json:"emailB,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SwapSeatsRequest) Reset() {
+	*x = SwapSeatsRequest{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SwapSeatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SwapSeatsRequest) ProtoMessage() {}
+
+func (x *SwapSeatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SwapSeatsRequest.ProtoReflect.Descriptor instead.
+func (*SwapSeatsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SwapSeatsRequest) GetEmailA() string {
+	if x != nil {
+		return x.EmailA
+	}
+	return ""
+}
+
+func (x *SwapSeatsRequest) GetEmailB() string {
+	if x != nil {
+		return x.EmailB
+	}
+	return ""
+}
+
+type SwapSeatsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	ReceiptA      *Receipt               `protobuf:"bytes,2,opt,name=receiptA,proto3" json:"receiptA,omitempty"`
+	ReceiptB      *Receipt               `protobuf:"bytes,3,opt,name=receiptB,proto3" json:"receiptB,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SwapSeatsResponse) Reset() {
+	*x = SwapSeatsResponse{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SwapSeatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SwapSeatsResponse) ProtoMessage() {}
+
+func (x *SwapSeatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SwapSeatsResponse.ProtoReflect.Descriptor instead.
+func (*SwapSeatsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *SwapSeatsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *SwapSeatsResponse) GetReceiptA() *Receipt {
+	if x != nil {
+		return x.ReceiptA
+	}
+	return nil
+}
+
+func (x *SwapSeatsResponse) GetReceiptB() *Receipt {
+	if x != nil {
+		return x.ReceiptB
+	}
+	return nil
+}
+
+type ReceiptEvent struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Type               ReceiptEventType       `protobuf:"varint,1,opt,name=type,proto3,enum=ticketBooking.ReceiptEventType" json:"type,omitempty"`
+	From               string                 `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To                 string                 `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	Seat               *Seat                  `protobuf:"bytes,4,opt,name=seat,proto3" json:"seat,omitempty"`
+	Timestamp          int64                  `protobuf:"varint,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`                                                         // Unix time in seconds
+	Reason             string                 `protobuf:"bytes,6,opt,name=reason,proto3" json:"reason,omitempty"`                                                                // Why support forced the change, for forced reassignments
+	CancellationReason CancellationReason     `protobuf:"varint,7,opt,name=cancellationReason,proto3,enum=ticketBooking.CancellationReason" json:"cancellationReason,omitempty"` // Why the ticket was cancelled, for cancellations
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ReceiptEvent) Reset() {
+	*x = ReceiptEvent{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReceiptEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReceiptEvent) ProtoMessage() {}
+
+func (x *ReceiptEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReceiptEvent.ProtoReflect.Descriptor instead.
+func (*ReceiptEvent) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ReceiptEvent) GetType() ReceiptEventType {
+	if x != nil {
+		return x.Type
+	}
+	return ReceiptEventType_RECEIPT_EVENT_UNSPECIFIED
+}
+
+func (x *ReceiptEvent) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *ReceiptEvent) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *ReceiptEvent) GetSeat() *Seat {
+	if x != nil {
+		return x.Seat
+	}
+	return nil
+}
+
+func (x *ReceiptEvent) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *ReceiptEvent) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *ReceiptEvent) GetCancellationReason() CancellationReason {
+	if x != nil {
+		return x.CancellationReason
+	}
+	return CancellationReason_CANCELLATION_REASON_UNSPECIFIED
+}
+
+type GetReceiptHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReceiptHistoryRequest) Reset() {
+	*x = GetReceiptHistoryRequest{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReceiptHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReceiptHistoryRequest) ProtoMessage() {}
+
+func (x *GetReceiptHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReceiptHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetReceiptHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetReceiptHistoryRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+type GetReceiptHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	Events        []*ReceiptEvent        `protobuf:"bytes,2,rep,name=events,proto3" json:"events,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReceiptHistoryResponse) Reset() {
+	*x = GetReceiptHistoryResponse{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReceiptHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReceiptHistoryResponse) ProtoMessage() {}
+
+func (x *GetReceiptHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReceiptHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetReceiptHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetReceiptHistoryResponse) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *GetReceiptHistoryResponse) GetEvents() []*ReceiptEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+// Messages for Maintenance Mode
+type SetMaintenanceModeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Enabled       bool                   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetMaintenanceModeRequest) Reset() {
+	*x = SetMaintenanceModeRequest{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetMaintenanceModeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetMaintenanceModeRequest) ProtoMessage() {}
+
+func (x *SetMaintenanceModeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetMaintenanceModeRequest.ProtoReflect.Descriptor instead.
+func (*SetMaintenanceModeRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *SetMaintenanceModeRequest) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+type SetMaintenanceModeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Enabled       bool                   `protobuf:"varint,2,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetMaintenanceModeResponse) Reset() {
+	*x = SetMaintenanceModeResponse{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetMaintenanceModeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetMaintenanceModeResponse) ProtoMessage() {}
+
+func (x *SetMaintenanceModeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetMaintenanceModeResponse.ProtoReflect.Descriptor instead.
+func (*SetMaintenanceModeResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *SetMaintenanceModeResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *SetMaintenanceModeResponse) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+// Messages for Bulk Cancellation
+type BulkCancelRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Section       string                 `protobuf:"bytes,1,opt,name=section,proto3" json:"section,omitempty"`
+	From          string                 `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To            string                 `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	CancelledAt   string                 `protobuf:"bytes,4,opt,name=cancelledAt,proto3" json:"cancelledAt,omitempty"`     // Station where the train was stopped, empty before departure
+	TrainId       string                 `protobuf:"bytes,5,opt,name=trainId,proto3" json:"trainId,omitempty"`             // Train to cancel on; empty cancels on the configured default train
+	DepartureDate string                 `protobuf:"bytes,6,opt,name=departureDate,proto3" json:"departureDate,omitempty"` // Date of travel as YYYY-MM-DD; empty cancels on the default train
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkCancelRequest) Reset() {
+	*x = BulkCancelRequest{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkCancelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkCancelRequest) ProtoMessage() {}
+
+func (x *BulkCancelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkCancelRequest.ProtoReflect.Descriptor instead.
+func (*BulkCancelRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *BulkCancelRequest) GetSection() string {
+	if x != nil {
+		return x.Section
+	}
+	return ""
+}
+
+func (x *BulkCancelRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *BulkCancelRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *BulkCancelRequest) GetCancelledAt() string {
+	if x != nil {
+		return x.CancelledAt
+	}
+	return ""
+}
+
+func (x *BulkCancelRequest) GetTrainId() string {
+	if x != nil {
+		return x.TrainId
+	}
+	return ""
+}
+
+func (x *BulkCancelRequest) GetDepartureDate() string {
+	if x != nil {
+		return x.DepartureDate
+	}
+	return ""
+}
+
+type BulkCancelResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Message           string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	CancelledReceipts []*Receipt             `protobuf:"bytes,2,rep,name=cancelledReceipts,proto3" json:"cancelledReceipts,omitempty"`
+	Refunds           []*Refund              `protobuf:"bytes,3,rep,name=refunds,proto3" json:"refunds,omitempty"` // Refund for each cancelled receipt
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *BulkCancelResponse) Reset() {
+	*x = BulkCancelResponse{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkCancelResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkCancelResponse) ProtoMessage() {}
+
+func (x *BulkCancelResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkCancelResponse.ProtoReflect.Descriptor instead.
+func (*BulkCancelResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *BulkCancelResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *BulkCancelResponse) GetCancelledReceipts() []*Receipt {
+	if x != nil {
+		return x.CancelledReceipts
+	}
+	return nil
+}
+
+func (x *BulkCancelResponse) GetRefunds() []*Refund {
+	if x != nil {
+		return x.Refunds
+	}
+	return nil
+}
+
+type Refund struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	Amount        float64                `protobuf:"fixed64,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Refund) Reset() {
+	*x = Refund{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Refund) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Refund) ProtoMessage() {}
+
+func (x *Refund) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Refund.ProtoReflect.Descriptor instead.
+func (*Refund) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *Refund) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *Refund) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+// Messages for Section Resizing
+type ResizeSectionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Section       string                 `protobuf:"bytes,1,opt,name=section,proto3" json:"section,omitempty"`
+	MaxSeats      int32                  `protobuf:"varint,2,opt,name=maxSeats,proto3" json:"maxSeats,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResizeSectionRequest) Reset() {
+	*x = ResizeSectionRequest{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResizeSectionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResizeSectionRequest) ProtoMessage() {}
+
+func (x *ResizeSectionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResizeSectionRequest.ProtoReflect.Descriptor instead.
+func (*ResizeSectionRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ResizeSectionRequest) GetSection() string {
+	if x != nil {
+		return x.Section
+	}
+	return ""
+}
+
+func (x *ResizeSectionRequest) GetMaxSeats() int32 {
+	if x != nil {
+		return x.MaxSeats
+	}
+	return 0
+}
+
+type ResizeSectionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Section       string                 `protobuf:"bytes,2,opt,name=section,proto3" json:"section,omitempty"`
+	MaxSeats      int32                  `protobuf:"varint,3,opt,name=maxSeats,proto3" json:"maxSeats,omitempty"`
+	VacantSeats   int32                  `protobuf:"varint,4,opt,name=vacantSeats,proto3" json:"vacantSeats,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResizeSectionResponse) Reset() {
+	*x = ResizeSectionResponse{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResizeSectionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResizeSectionResponse) ProtoMessage() {}
+
+func (x *ResizeSectionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResizeSectionResponse.ProtoReflect.Descriptor instead.
+func (*ResizeSectionResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ResizeSectionResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ResizeSectionResponse) GetSection() string {
+	if x != nil {
+		return x.Section
+	}
+	return ""
+}
+
+func (x *ResizeSectionResponse) GetMaxSeats() int32 {
+	if x != nil {
+		return x.MaxSeats
+	}
+	return 0
+}
+
+func (x *ResizeSectionResponse) GetVacantSeats() int32 {
+	if x != nil {
+		return x.VacantSeats
+	}
+	return 0
+}
+
+// Messages for Server Info
+type GetServerInfoRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetServerInfoRequest) Reset() {
+	*x = GetServerInfoRequest{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetServerInfoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetServerInfoRequest) ProtoMessage() {}
+
+func (x *GetServerInfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetServerInfoRequest.ProtoReflect.Descriptor instead.
+func (*GetServerInfoRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{26}
+}
+
+type GetServerInfoResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Version         string                 `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	GitCommit       string                 `protobuf:"bytes,2,opt,name=gitCommit,proto3" json:"gitCommit,omitempty"`
+	UptimeSeconds   int64                  `protobuf:"varint,3,opt,name=uptimeSeconds,proto3" json:"uptimeSeconds,omitempty"`
+	EnabledFeatures []string               `protobuf:"bytes,4,rep,name=enabledFeatures,proto3" json:"enabledFeatures,omitempty"`
+	SectionsScanned []int64                `protobuf:"varint,5,rep,packed,name=sectionsScanned,proto3" json:"sectionsScanned,omitempty"` // Seat assignments by sections scanned to place the rider: the first entry counts those placed in the first section tried, the second in the second, and so on
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetServerInfoResponse) Reset() {
+	*x = GetServerInfoResponse{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetServerInfoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetServerInfoResponse) ProtoMessage() {}
+
+func (x *GetServerInfoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetServerInfoResponse.ProtoReflect.Descriptor instead.
+func (*GetServerInfoResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GetServerInfoResponse) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *GetServerInfoResponse) GetGitCommit() string {
+	if x != nil {
+		return x.GitCommit
+	}
+	return ""
+}
+
+func (x *GetServerInfoResponse) GetUptimeSeconds() int64 {
+	if x != nil {
+		return x.UptimeSeconds
+	}
+	return 0
+}
+
+func (x *GetServerInfoResponse) GetEnabledFeatures() []string {
+	if x != nil {
+		return x.EnabledFeatures
+	}
+	return nil
+}
+
+func (x *GetServerInfoResponse) GetSectionsScanned() []int64 {
+	if x != nil {
+		return x.SectionsScanned
+	}
+	return nil
+}
+
+// Messages for View Users by Route
+type GetUsersByRouteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          string                 `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To            string                 `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUsersByRouteRequest) Reset() {
+	*x = GetUsersByRouteRequest{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUsersByRouteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUsersByRouteRequest) ProtoMessage() {}
+
+func (x *GetUsersByRouteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUsersByRouteRequest.ProtoReflect.Descriptor instead.
+func (*GetUsersByRouteRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *GetUsersByRouteRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *GetUsersByRouteRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+type RouteUser struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	Seat          *Seat                  `protobuf:"bytes,2,opt,name=seat,proto3" json:"seat,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RouteUser) Reset() {
+	*x = RouteUser{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RouteUser) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RouteUser) ProtoMessage() {}
+
+func (x *RouteUser) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RouteUser.ProtoReflect.Descriptor instead.
+func (*RouteUser) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *RouteUser) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *RouteUser) GetSeat() *Seat {
+	if x != nil {
+		return x.Seat
+	}
+	return nil
+}
+
+type GetUsersByRouteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          string                 `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To            string                 `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	Users         []*RouteUser           `protobuf:"bytes,3,rep,name=users,proto3" json:"users,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUsersByRouteResponse) Reset() {
+	*x = GetUsersByRouteResponse{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUsersByRouteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUsersByRouteResponse) ProtoMessage() {}
+
+func (x *GetUsersByRouteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUsersByRouteResponse.ProtoReflect.Descriptor instead.
+func (*GetUsersByRouteResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *GetUsersByRouteResponse) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *GetUsersByRouteResponse) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *GetUsersByRouteResponse) GetUsers() []*RouteUser {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+// Messages for Seat Blocking
+type BlockSeatRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Section       string                 `protobuf:"bytes,1,opt,name=section,proto3" json:"section,omitempty"`
+	SeatNumber    int32                  `protobuf:"varint,2,opt,name=seatNumber,proto3" json:"seatNumber,omitempty"`
+	TrainId       string                 `protobuf:"bytes,3,opt,name=trainId,proto3" json:"trainId,omitempty"`             // Train to block the seat on; empty blocks it on the configured default train
+	DepartureDate string                 `protobuf:"bytes,4,opt,name=departureDate,proto3" json:"departureDate,omitempty"` // Date of travel as YYYY-MM-DD; empty blocks it on the default train, or with no trainId on every train and date
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BlockSeatRequest) Reset() {
+	*x = BlockSeatRequest{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlockSeatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockSeatRequest) ProtoMessage() {}
+
+func (x *BlockSeatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockSeatRequest.ProtoReflect.Descriptor instead.
+func (*BlockSeatRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *BlockSeatRequest) GetSection() string {
+	if x != nil {
+		return x.Section
+	}
+	return ""
+}
+
+func (x *BlockSeatRequest) GetSeatNumber() int32 {
+	if x != nil {
+		return x.SeatNumber
+	}
+	return 0
+}
+
+func (x *BlockSeatRequest) GetTrainId() string {
+	if x != nil {
+		return x.TrainId
+	}
+	return ""
+}
+
+func (x *BlockSeatRequest) GetDepartureDate() string {
+	if x != nil {
+		return x.DepartureDate
+	}
+	return ""
+}
+
+type BlockSeatResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Seat          *Seat                  `protobuf:"bytes,2,opt,name=seat,proto3" json:"seat,omitempty"`
+	VacantSeats   int32                  `protobuf:"varint,3,opt,name=vacantSeats,proto3" json:"vacantSeats,omitempty"` // Vacant seats left in the section
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BlockSeatResponse) Reset() {
+	*x = BlockSeatResponse{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlockSeatResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockSeatResponse) ProtoMessage() {}
+
+func (x *BlockSeatResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockSeatResponse.ProtoReflect.Descriptor instead.
+func (*BlockSeatResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *BlockSeatResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *BlockSeatResponse) GetSeat() *Seat {
+	if x != nil {
+		return x.Seat
+	}
+	return nil
+}
+
+func (x *BlockSeatResponse) GetVacantSeats() int32 {
+	if x != nil {
+		return x.VacantSeats
+	}
+	return 0
+}
+
+type UnblockSeatRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Section       string                 `protobuf:"bytes,1,opt,name=section,proto3" json:"section,omitempty"`
+	SeatNumber    int32                  `protobuf:"varint,2,opt,name=seatNumber,proto3" json:"seatNumber,omitempty"`
+	TrainId       string                 `protobuf:"bytes,3,opt,name=trainId,proto3" json:"trainId,omitempty"`             // Train to unblock the seat on; empty unblocks it on the configured default train
+	DepartureDate string                 `protobuf:"bytes,4,opt,name=departureDate,proto3" json:"departureDate,omitempty"` // Date of travel as YYYY-MM-DD; empty unblocks it on the default train, or with no trainId on every train and date
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnblockSeatRequest) Reset() {
+	*x = UnblockSeatRequest{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnblockSeatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnblockSeatRequest) ProtoMessage() {}
+
+func (x *UnblockSeatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnblockSeatRequest.ProtoReflect.Descriptor instead.
+func (*UnblockSeatRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *UnblockSeatRequest) GetSection() string {
+	if x != nil {
+		return x.Section
+	}
+	return ""
+}
+
+func (x *UnblockSeatRequest) GetSeatNumber() int32 {
+	if x != nil {
+		return x.SeatNumber
+	}
+	return 0
+}
+
+func (x *UnblockSeatRequest) GetTrainId() string {
+	if x != nil {
+		return x.TrainId
+	}
+	return ""
+}
+
+func (x *UnblockSeatRequest) GetDepartureDate() string {
+	if x != nil {
+		return x.DepartureDate
+	}
+	return ""
+}
+
+type UnblockSeatResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Seat          *Seat                  `protobuf:"bytes,2,opt,name=seat,proto3" json:"seat,omitempty"`
+	VacantSeats   int32                  `protobuf:"varint,3,opt,name=vacantSeats,proto3" json:"vacantSeats,omitempty"` // Vacant seats left in the section
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnblockSeatResponse) Reset() {
+	*x = UnblockSeatResponse{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnblockSeatResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnblockSeatResponse) ProtoMessage() {}
+
+func (x *UnblockSeatResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnblockSeatResponse.ProtoReflect.Descriptor instead.
+func (*UnblockSeatResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *UnblockSeatResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *UnblockSeatResponse) GetSeat() *Seat {
+	if x != nil {
+		return x.Seat
+	}
+	return nil
+}
+
+func (x *UnblockSeatResponse) GetVacantSeats() int32 {
+	if x != nil {
+		return x.VacantSeats
+	}
+	return 0
+}
+
+// Messages for Seat Integrity Verification
+type VerifyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Repair        bool                   `protobuf:"varint,1,opt,name=repair,proto3" json:"repair,omitempty"`              // Recreate missing seats and recount vacant seats
+	TrainId       string                 `protobuf:"bytes,2,opt,name=trainId,proto3" json:"trainId,omitempty"`             // Train to check; empty checks the configured default train
+	DepartureDate string                 `protobuf:"bytes,3,opt,name=departureDate,proto3" json:"departureDate,omitempty"` // Date of travel as YYYY-MM-DD; empty checks the default train
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyRequest) Reset() {
+	*x = VerifyRequest{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyRequest) ProtoMessage() {}
+
+func (x *VerifyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyRequest.ProtoReflect.Descriptor instead.
+func (*VerifyRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *VerifyRequest) GetRepair() bool {
+	if x != nil {
+		return x.Repair
+	}
+	return false
+}
+
+func (x *VerifyRequest) GetTrainId() string {
+	if x != nil {
+		return x.TrainId
+	}
+	return ""
+}
+
+func (x *VerifyRequest) GetDepartureDate() string {
+	if x != nil {
+		return x.DepartureDate
+	}
+	return ""
+}
+
+type SectionIntegrity struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Section             string                 `protobuf:"bytes,1,opt,name=section,proto3" json:"section,omitempty"`
+	MissingSeats        []int32                `protobuf:"varint,2,rep,packed,name=missingSeats,proto3" json:"missingSeats,omitempty"` // Seat numbers up to maxSeats with no seat
+	RecordedVacantSeats int32                  `protobuf:"varint,3,opt,name=recordedVacantSeats,proto3" json:"recordedVacantSeats,omitempty"`
+	ActualVacantSeats   int32                  `protobuf:"varint,4,opt,name=actualVacantSeats,proto3" json:"actualVacantSeats,omitempty"` // Available seats actually present
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *SectionIntegrity) Reset() {
+	*x = SectionIntegrity{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SectionIntegrity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SectionIntegrity) ProtoMessage() {}
+
+func (x *SectionIntegrity) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SectionIntegrity.ProtoReflect.Descriptor instead.
+func (*SectionIntegrity) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *SectionIntegrity) GetSection() string {
+	if x != nil {
+		return x.Section
+	}
+	return ""
+}
+
+func (x *SectionIntegrity) GetMissingSeats() []int32 {
+	if x != nil {
+		return x.MissingSeats
+	}
+	return nil
+}
+
+func (x *SectionIntegrity) GetRecordedVacantSeats() int32 {
+	if x != nil {
+		return x.RecordedVacantSeats
+	}
+	return 0
+}
+
+func (x *SectionIntegrity) GetActualVacantSeats() int32 {
+	if x != nil {
+		return x.ActualVacantSeats
+	}
+	return 0
+}
+
+type VerifyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Healthy       bool                   `protobuf:"varint,2,opt,name=healthy,proto3" json:"healthy,omitempty"` // True when no section had discrepancies
+	Sections      []*SectionIntegrity    `protobuf:"bytes,3,rep,name=sections,proto3" json:"sections,omitempty"`
+	Repaired      bool                   `protobuf:"varint,4,opt,name=repaired,proto3" json:"repaired,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyResponse) Reset() {
+	*x = VerifyResponse{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyResponse) ProtoMessage() {}
+
+func (x *VerifyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyResponse.ProtoReflect.Descriptor instead.
+func (*VerifyResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *VerifyResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *VerifyResponse) GetHealthy() bool {
+	if x != nil {
+		return x.Healthy
+	}
+	return false
+}
+
+func (x *VerifyResponse) GetSections() []*SectionIntegrity {
+	if x != nil {
+		return x.Sections
+	}
+	return nil
+}
+
+func (x *VerifyResponse) GetRepaired() bool {
+	if x != nil {
+		return x.Repaired
+	}
+	return false
+}
+
+type GetSeatRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Section       string                 `protobuf:"bytes,1,opt,name=section,proto3" json:"section,omitempty"`
+	SeatNumber    int32                  `protobuf:"varint,2,opt,name=seatNumber,proto3" json:"seatNumber,omitempty"`
+	TrainId       string                 `protobuf:"bytes,3,opt,name=trainId,proto3" json:"trainId,omitempty"`             // Train to look at; empty looks at the configured default train
+	DepartureDate string                 `protobuf:"bytes,4,opt,name=departureDate,proto3" json:"departureDate,omitempty"` // Date of travel as YYYY-MM-DD; empty looks at the default train
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSeatRequest) Reset() {
+	*x = GetSeatRequest{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSeatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSeatRequest) ProtoMessage() {}
+
+func (x *GetSeatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSeatRequest.ProtoReflect.Descriptor instead.
+func (*GetSeatRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *GetSeatRequest) GetSection() string {
+	if x != nil {
+		return x.Section
+	}
+	return ""
+}
+
+func (x *GetSeatRequest) GetSeatNumber() int32 {
+	if x != nil {
+		return x.SeatNumber
+	}
+	return 0
+}
+
+func (x *GetSeatRequest) GetTrainId() string {
+	if x != nil {
+		return x.TrainId
+	}
+	return ""
+}
+
+func (x *GetSeatRequest) GetDepartureDate() string {
+	if x != nil {
+		return x.DepartureDate
+	}
+	return ""
+}
+
+type GetSeatResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Seat          *Seat                  `protobuf:"bytes,1,opt,name=seat,proto3" json:"seat,omitempty"`
+	Position      SeatPosition           `protobuf:"varint,2,opt,name=position,proto3,enum=ticketBooking.SeatPosition" json:"position,omitempty"` // From seating.row_layout; unspecified when no layout is configured
+	Available     bool                   `protobuf:"varint,3,opt,name=available,proto3" json:"available,omitempty"`                               // True while no segment of the route is booked
+	Blocked       bool                   `protobuf:"varint,4,opt,name=blocked,proto3" json:"blocked,omitempty"`
+	Occupants     []*Receipt             `protobuf:"bytes,5,rep,name=occupants,proto3" json:"occupants,omitempty"` // Receipts booked on the seat; several riders can share it on different segments. Only admin API keys see other riders' receipts
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSeatResponse) Reset() {
+	*x = GetSeatResponse{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSeatResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSeatResponse) ProtoMessage() {}
+
+func (x *GetSeatResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSeatResponse.ProtoReflect.Descriptor instead.
+func (*GetSeatResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *GetSeatResponse) GetSeat() *Seat {
+	if x != nil {
+		return x.Seat
+	}
+	return nil
+}
+
+func (x *GetSeatResponse) GetPosition() SeatPosition {
+	if x != nil {
+		return x.Position
+	}
+	return SeatPosition_SEAT_POSITION_UNSPECIFIED
+}
+
+func (x *GetSeatResponse) GetAvailable() bool {
+	if x != nil {
+		return x.Available
+	}
+	return false
+}
+
+func (x *GetSeatResponse) GetBlocked() bool {
+	if x != nil {
+		return x.Blocked
+	}
+	return false
+}
+
+func (x *GetSeatResponse) GetOccupants() []*Receipt {
+	if x != nil {
+		return x.Occupants
+	}
+	return nil
+}
+
+// Messages for Cheapest Route Search
+type GetCheapestRoutesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          string                 `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"` // Maximum routes returned; zero returns every bookable route
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCheapestRoutesRequest) Reset() {
+	*x = GetCheapestRoutesRequest{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCheapestRoutesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCheapestRoutesRequest) ProtoMessage() {}
+
+func (x *GetCheapestRoutesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCheapestRoutesRequest.ProtoReflect.Descriptor instead.
+func (*GetCheapestRoutesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *GetCheapestRoutesRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *GetCheapestRoutesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type RouteFare struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	From           string                 `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To             string                 `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	Price          float64                `protobuf:"fixed64,3,opt,name=price,proto3" json:"price,omitempty"`                  // Fare a booking would pay now, after any time window multiplier
+	AvailableSeats int32                  `protobuf:"varint,4,opt,name=availableSeats,proto3" json:"availableSeats,omitempty"` // Seats free for the whole journey
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RouteFare) Reset() {
+	*x = RouteFare{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RouteFare) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RouteFare) ProtoMessage() {}
+
+func (x *RouteFare) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RouteFare.ProtoReflect.Descriptor instead.
+func (*RouteFare) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *RouteFare) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *RouteFare) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *RouteFare) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *RouteFare) GetAvailableSeats() int32 {
+	if x != nil {
+		return x.AvailableSeats
+	}
+	return 0
+}
+
+type GetCheapestRoutesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Routes        []*RouteFare           `protobuf:"bytes,1,rep,name=routes,proto3" json:"routes,omitempty"` // Cheapest first
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCheapestRoutesResponse) Reset() {
+	*x = GetCheapestRoutesResponse{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCheapestRoutesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCheapestRoutesResponse) ProtoMessage() {}
+
+func (x *GetCheapestRoutesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCheapestRoutesResponse.ProtoReflect.Descriptor instead.
+func (*GetCheapestRoutesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *GetCheapestRoutesResponse) GetRoutes() []*RouteFare {
+	if x != nil {
+		return x.Routes
+	}
+	return nil
+}
+
+// Messages for Section Vacancy
+type GetSectionVacancyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Section       string                 `protobuf:"bytes,1,opt,name=section,proto3" json:"section,omitempty"`
+	TrainId       string                 `protobuf:"bytes,2,opt,name=trainId,proto3" json:"trainId,omitempty"`             // Train to check; empty checks the configured default train
+	DepartureDate string                 `protobuf:"bytes,3,opt,name=departureDate,proto3" json:"departureDate,omitempty"` // Date of travel as YYYY-MM-DD; empty checks the default train
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSectionVacancyRequest) Reset() {
+	*x = GetSectionVacancyRequest{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSectionVacancyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSectionVacancyRequest) ProtoMessage() {}
+
+func (x *GetSectionVacancyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSectionVacancyRequest.ProtoReflect.Descriptor instead.
+func (*GetSectionVacancyRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *GetSectionVacancyRequest) GetSection() string {
+	if x != nil {
+		return x.Section
+	}
+	return ""
+}
+
+func (x *GetSectionVacancyRequest) GetTrainId() string {
+	if x != nil {
+		return x.TrainId
+	}
+	return ""
+}
+
+func (x *GetSectionVacancyRequest) GetDepartureDate() string {
+	if x != nil {
+		return x.DepartureDate
+	}
+	return ""
+}
+
+type GetSectionVacancyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VacantSeats   int32                  `protobuf:"varint,1,opt,name=vacantSeats,proto3" json:"vacantSeats,omitempty"`
+	MaxSeats      int32                  `protobuf:"varint,2,opt,name=maxSeats,proto3" json:"maxSeats,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSectionVacancyResponse) Reset() {
+	*x = GetSectionVacancyResponse{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSectionVacancyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSectionVacancyResponse) ProtoMessage() {}
+
+func (x *GetSectionVacancyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSectionVacancyResponse.ProtoReflect.Descriptor instead.
+func (*GetSectionVacancyResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *GetSectionVacancyResponse) GetVacantSeats() int32 {
+	if x != nil {
+		return x.VacantSeats
+	}
+	return 0
+}
+
+func (x *GetSectionVacancyResponse) GetMaxSeats() int32 {
+	if x != nil {
+		return x.MaxSeats
+	}
+	return 0
+}
+
+// Messages for Config Inspection
+type GetConfigRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetConfigRequest) Reset() {
+	*x = GetConfigRequest{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConfigRequest) ProtoMessage() {}
+
+func (x *GetConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConfigRequest.ProtoReflect.Descriptor instead.
+func (*GetConfigRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{45}
+}
+
+type GetConfigResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Config        string                 `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"` // Effective configuration as YAML, with secrets redacted
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetConfigResponse) Reset() {
+	*x = GetConfigResponse{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetConfigResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConfigResponse) ProtoMessage() {}
+
+func (x *GetConfigResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConfigResponse.ProtoReflect.Descriptor instead.
+func (*GetConfigResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *GetConfigResponse) GetConfig() string {
+	if x != nil {
+		return x.Config
+	}
+	return ""
+}
+
+// Messages for Section Discovery
+type GetAllSectionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TrainId       string                 `protobuf:"bytes,1,opt,name=trainId,proto3" json:"trainId,omitempty"`             // Train to list; empty lists the configured default train
+	DepartureDate string                 `protobuf:"bytes,2,opt,name=departureDate,proto3" json:"departureDate,omitempty"` // Date of travel as YYYY-MM-DD; empty lists the default train
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAllSectionsRequest) Reset() {
+	*x = GetAllSectionsRequest{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAllSectionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAllSectionsRequest) ProtoMessage() {}
+
+func (x *GetAllSectionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAllSectionsRequest.ProtoReflect.Descriptor instead.
+func (*GetAllSectionsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *GetAllSectionsRequest) GetTrainId() string {
+	if x != nil {
+		return x.TrainId
+	}
+	return ""
+}
+
+func (x *GetAllSectionsRequest) GetDepartureDate() string {
+	if x != nil {
+		return x.DepartureDate
+	}
+	return ""
+}
+
+type SectionInfo struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Name           string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	MaxSeats       int32                  `protobuf:"varint,2,opt,name=maxSeats,proto3" json:"maxSeats,omitempty"`
+	VacantSeats    int32                  `protobuf:"varint,3,opt,name=vacantSeats,proto3" json:"vacantSeats,omitempty"`
+	BlockedSeats   int32                  `protobuf:"varint,4,opt,name=blockedSeats,proto3" json:"blockedSeats,omitempty"`    // Seats out of order, counted in maxSeats but never vacant
+	OverflowPolicy string                 `protobuf:"bytes,5,opt,name=overflowPolicy,proto3" json:"overflowPolicy,omitempty"` // What happens to bookings for the section once full: spill, reject or waitlist
+	IsDefault      bool                   `protobuf:"varint,6,opt,name=isDefault,proto3" json:"isDefault,omitempty"`          // Filled first by bookings without a preference
+	Restricted     bool                   `protobuf:"varint,7,opt,name=restricted,proto3" json:"restricted,omitempty"`        // Crew or staff only; never sold, so it can't be preferred
+	Quiesced       bool                   `protobuf:"varint,8,opt,name=quiesced,proto3" json:"quiesced,omitempty"`            // Taking no new bookings until resumed, while its riders keep their seats
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SectionInfo) Reset() {
+	*x = SectionInfo{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SectionInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SectionInfo) ProtoMessage() {}
+
+func (x *SectionInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SectionInfo.ProtoReflect.Descriptor instead.
+func (*SectionInfo) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *SectionInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SectionInfo) GetMaxSeats() int32 {
+	if x != nil {
+		return x.MaxSeats
+	}
+	return 0
+}
+
+func (x *SectionInfo) GetVacantSeats() int32 {
+	if x != nil {
+		return x.VacantSeats
+	}
+	return 0
+}
+
+func (x *SectionInfo) GetBlockedSeats() int32 {
+	if x != nil {
+		return x.BlockedSeats
+	}
+	return 0
+}
+
+func (x *SectionInfo) GetOverflowPolicy() string {
+	if x != nil {
+		return x.OverflowPolicy
+	}
+	return ""
+}
+
+func (x *SectionInfo) GetIsDefault() bool {
+	if x != nil {
+		return x.IsDefault
+	}
+	return false
+}
+
+func (x *SectionInfo) GetRestricted() bool {
+	if x != nil {
+		return x.Restricted
+	}
+	return false
+}
+
+func (x *SectionInfo) GetQuiesced() bool {
+	if x != nil {
+		return x.Quiesced
+	}
+	return false
+}
+
+type GetAllSectionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sections      []*SectionInfo         `protobuf:"bytes,1,rep,name=sections,proto3" json:"sections,omitempty"` // In round-robin order
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAllSectionsResponse) Reset() {
+	*x = GetAllSectionsResponse{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAllSectionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAllSectionsResponse) ProtoMessage() {}
+
+func (x *GetAllSectionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAllSectionsResponse.ProtoReflect.Descriptor instead.
+func (*GetAllSectionsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *GetAllSectionsResponse) GetSections() []*SectionInfo {
+	if x != nil {
+		return x.Sections
+	}
+	return nil
+}
+
+// Messages for Booking Reference Lookup
+type GetReceiptByReferenceRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	BookingReference string                 `protobuf:"bytes,1,opt,name=bookingReference,proto3" json:"bookingReference,omitempty"`
+	ReceiptMask      *fieldmaskpb.FieldMask `protobuf:"bytes,2,opt,name=receiptMask,proto3" json:"receiptMask,omitempty"` // Receipt fields to return, such as "seat" or "user.email"; empty returns the whole receipt
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *GetReceiptByReferenceRequest) Reset() {
+	*x = GetReceiptByReferenceRequest{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReceiptByReferenceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReceiptByReferenceRequest) ProtoMessage() {}
+
+func (x *GetReceiptByReferenceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReceiptByReferenceRequest.ProtoReflect.Descriptor instead.
+func (*GetReceiptByReferenceRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *GetReceiptByReferenceRequest) GetBookingReference() string {
+	if x != nil {
+		return x.BookingReference
+	}
+	return ""
+}
+
+func (x *GetReceiptByReferenceRequest) GetReceiptMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.ReceiptMask
+	}
+	return nil
+}
+
+type GetReceiptByReferenceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Receipt       *Receipt               `protobuf:"bytes,1,opt,name=receipt,proto3" json:"receipt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReceiptByReferenceResponse) Reset() {
+	*x = GetReceiptByReferenceResponse{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReceiptByReferenceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReceiptByReferenceResponse) ProtoMessage() {}
+
+func (x *GetReceiptByReferenceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReceiptByReferenceResponse.ProtoReflect.Descriptor instead.
+func (*GetReceiptByReferenceResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *GetReceiptByReferenceResponse) GetReceipt() *Receipt {
+	if x != nil {
+		return x.Receipt
+	}
+	return nil
+}
+
+// Messages for Waitlist Estimates
+type GetWaitEstimateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWaitEstimateRequest) Reset() {
+	*x = GetWaitEstimateRequest{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWaitEstimateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWaitEstimateRequest) ProtoMessage() {}
+
+func (x *GetWaitEstimateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWaitEstimateRequest.ProtoReflect.Descriptor instead.
+func (*GetWaitEstimateRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *GetWaitEstimateRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+type GetWaitEstimateResponse struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Section              string                 `protobuf:"bytes,1,opt,name=section,proto3" json:"section,omitempty"`                            // Full section the rider is waitlisted for
+	Position             int32                  `protobuf:"varint,2,opt,name=position,proto3" json:"position,omitempty"`                         // Place in the section's waitlist, starting at 1
+	HasEstimate          bool                   `protobuf:"varint,3,opt,name=hasEstimate,proto3" json:"hasEstimate,omitempty"`                   // False until enough cancellations have been seen to estimate a rate
+	EstimatedWaitSeconds int64                  `protobuf:"varint,4,opt,name=estimatedWaitSeconds,proto3" json:"estimatedWaitSeconds,omitempty"` // Expected time until a seat frees up for the rider
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *GetWaitEstimateResponse) Reset() {
+	*x = GetWaitEstimateResponse{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWaitEstimateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWaitEstimateResponse) ProtoMessage() {}
+
+func (x *GetWaitEstimateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWaitEstimateResponse.ProtoReflect.Descriptor instead.
+func (*GetWaitEstimateResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *GetWaitEstimateResponse) GetSection() string {
+	if x != nil {
+		return x.Section
+	}
+	return ""
+}
+
+func (x *GetWaitEstimateResponse) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+func (x *GetWaitEstimateResponse) GetHasEstimate() bool {
+	if x != nil {
+		return x.HasEstimate
+	}
+	return false
+}
+
+func (x *GetWaitEstimateResponse) GetEstimatedWaitSeconds() int64 {
+	if x != nil {
+		return x.EstimatedWaitSeconds
+	}
+	return 0
+}
+
+// Messages for Seat Assignment Tracing
+type GetAssignmentTraceRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	BookingReference string                 `protobuf:"bytes,1,opt,name=bookingReference,proto3" json:"bookingReference,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *GetAssignmentTraceRequest) Reset() {
+	*x = GetAssignmentTraceRequest{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAssignmentTraceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAssignmentTraceRequest) ProtoMessage() {}
+
+func (x *GetAssignmentTraceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAssignmentTraceRequest.ProtoReflect.Descriptor instead.
+func (*GetAssignmentTraceRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *GetAssignmentTraceRequest) GetBookingReference() string {
+	if x != nil {
+		return x.BookingReference
+	}
+	return ""
+}
+
+type GetAssignmentTraceResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Strategy          string                 `protobuf:"bytes,1,opt,name=strategy,proto3" json:"strategy,omitempty"`                    // How the seat was chosen: preference, cheapest_section, destination_affinity, default_section, shared_seat, round_robin, hold, pair or waitlist
+	PreferenceHonored bool                   `protobuf:"varint,2,opt,name=preferenceHonored,proto3" json:"preferenceHonored,omitempty"` // Whether the seat is in one of the rider's preferred sections
+	SkippedSections   int32                  `protobuf:"varint,3,opt,name=skippedSections,proto3" json:"skippedSections,omitempty"`     // Sections tried or passed over before the one assigned
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *GetAssignmentTraceResponse) Reset() {
+	*x = GetAssignmentTraceResponse{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAssignmentTraceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAssignmentTraceResponse) ProtoMessage() {}
+
+func (x *GetAssignmentTraceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAssignmentTraceResponse.ProtoReflect.Descriptor instead.
+func (*GetAssignmentTraceResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *GetAssignmentTraceResponse) GetStrategy() string {
+	if x != nil {
+		return x.Strategy
+	}
+	return ""
+}
+
+func (x *GetAssignmentTraceResponse) GetPreferenceHonored() bool {
+	if x != nil {
+		return x.PreferenceHonored
+	}
+	return false
+}
+
+func (x *GetAssignmentTraceResponse) GetSkippedSections() int32 {
+	if x != nil {
+		return x.SkippedSections
+	}
+	return 0
+}
+
+// Messages for Passenger Manifests
+type GetManifestRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Section       string                 `protobuf:"bytes,1,opt,name=section,proto3" json:"section,omitempty"`             // Only list this section, empty for every section
+	DepartureDate string                 `protobuf:"bytes,2,opt,name=departureDate,proto3" json:"departureDate,omitempty"` // Date of travel as YYYY-MM-DD, empty for the default train
+	TrainId       string                 `protobuf:"bytes,3,opt,name=trainId,proto3" json:"trainId,omitempty"`             // Train to list; empty lists the configured default train
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetManifestRequest) Reset() {
+	*x = GetManifestRequest{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetManifestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetManifestRequest) ProtoMessage() {}
+
+func (x *GetManifestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetManifestRequest.ProtoReflect.Descriptor instead.
+func (*GetManifestRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *GetManifestRequest) GetSection() string {
+	if x != nil {
+		return x.Section
+	}
+	return ""
+}
+
+func (x *GetManifestRequest) GetDepartureDate() string {
+	if x != nil {
+		return x.DepartureDate
+	}
+	return ""
+}
+
+func (x *GetManifestRequest) GetTrainId() string {
+	if x != nil {
+		return x.TrainId
+	}
+	return ""
+}
+
+type ManifestEntry struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Seat             *Seat                  `protobuf:"bytes,1,opt,name=seat,proto3" json:"seat,omitempty"`
+	User             *User                  `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`
+	From             string                 `protobuf:"bytes,3,opt,name=from,proto3" json:"from,omitempty"`
+	To               string                 `protobuf:"bytes,4,opt,name=to,proto3" json:"to,omitempty"`
+	PricePaid        float64                `protobuf:"fixed64,5,opt,name=pricePaid,proto3" json:"pricePaid,omitempty"`
+	BookingReference string                 `protobuf:"bytes,6,opt,name=bookingReference,proto3" json:"bookingReference,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ManifestEntry) Reset() {
+	*x = ManifestEntry{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ManifestEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ManifestEntry) ProtoMessage() {}
+
+func (x *ManifestEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ManifestEntry.ProtoReflect.Descriptor instead.
+func (*ManifestEntry) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *ManifestEntry) GetSeat() *Seat {
+	if x != nil {
+		return x.Seat
+	}
+	return nil
+}
+
+func (x *ManifestEntry) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *ManifestEntry) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *ManifestEntry) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *ManifestEntry) GetPricePaid() float64 {
+	if x != nil {
+		return x.PricePaid
+	}
+	return 0
+}
+
+func (x *ManifestEntry) GetBookingReference() string {
+	if x != nil {
+		return x.BookingReference
+	}
+	return ""
+}
+
+type GetManifestResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Entries         []*ManifestEntry       `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"` // Ordered by section, then seat, then email
+	TotalPassengers int32                  `protobuf:"varint,2,opt,name=totalPassengers,proto3" json:"totalPassengers,omitempty"`
+	OccupiedSeats   int32                  `protobuf:"varint,3,opt,name=occupiedSeats,proto3" json:"occupiedSeats,omitempty"` // Seats with at least one passenger; riders on different segments may share one
+	TotalFares      float64                `protobuf:"fixed64,4,opt,name=totalFares,proto3" json:"totalFares,omitempty"`      // Sum of the fares paid by the listed passengers
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetManifestResponse) Reset() {
+	*x = GetManifestResponse{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetManifestResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetManifestResponse) ProtoMessage() {}
+
+func (x *GetManifestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetManifestResponse.ProtoReflect.Descriptor instead.
+func (*GetManifestResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *GetManifestResponse) GetEntries() []*ManifestEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *GetManifestResponse) GetTotalPassengers() int32 {
+	if x != nil {
+		return x.TotalPassengers
+	}
+	return 0
+}
+
+func (x *GetManifestResponse) GetOccupiedSeats() int32 {
+	if x != nil {
+		return x.OccupiedSeats
+	}
+	return 0
+}
+
+func (x *GetManifestResponse) GetTotalFares() float64 {
+	if x != nil {
+		return x.TotalFares
+	}
+	return 0
+}
+
+// Messages for Price Range Reporting
+type ListReceiptsByPriceRangeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MinPrice      float64                `protobuf:"fixed64,1,opt,name=minPrice,proto3" json:"minPrice,omitempty"`     // Lowest price paid to include
+	MaxPrice      float64                `protobuf:"fixed64,2,opt,name=maxPrice,proto3" json:"maxPrice,omitempty"`     // Highest price paid to include
+	ReceiptMask   *fieldmaskpb.FieldMask `protobuf:"bytes,3,opt,name=receiptMask,proto3" json:"receiptMask,omitempty"` // Receipt fields to return, such as "seat" or "user.email"; empty returns the whole receipt
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListReceiptsByPriceRangeRequest) Reset() {
+	*x = ListReceiptsByPriceRangeRequest{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListReceiptsByPriceRangeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReceiptsByPriceRangeRequest) ProtoMessage() {}
+
+func (x *ListReceiptsByPriceRangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReceiptsByPriceRangeRequest.ProtoReflect.Descriptor instead.
+func (*ListReceiptsByPriceRangeRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *ListReceiptsByPriceRangeRequest) GetMinPrice() float64 {
+	if x != nil {
+		return x.MinPrice
+	}
+	return 0
+}
+
+func (x *ListReceiptsByPriceRangeRequest) GetMaxPrice() float64 {
+	if x != nil {
+		return x.MaxPrice
+	}
+	return 0
+}
+
+func (x *ListReceiptsByPriceRangeRequest) GetReceiptMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.ReceiptMask
+	}
+	return nil
+}
+
+type ListReceiptsByPriceRangeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Receipts      []*Receipt             `protobuf:"bytes,1,rep,name=receipts,proto3" json:"receipts,omitempty"` // Cheapest first, then by email
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListReceiptsByPriceRangeResponse) Reset() {
+	*x = ListReceiptsByPriceRangeResponse{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListReceiptsByPriceRangeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReceiptsByPriceRangeResponse) ProtoMessage() {}
+
+func (x *ListReceiptsByPriceRangeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReceiptsByPriceRangeResponse.ProtoReflect.Descriptor instead.
+func (*ListReceiptsByPriceRangeResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *ListReceiptsByPriceRangeResponse) GetReceipts() []*Receipt {
+	if x != nil {
+		return x.Receipts
+	}
+	return nil
+}
+
+// Messages for Availability Watching
+type WatchAvailabilityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Section       string                 `protobuf:"bytes,1,opt,name=section,proto3" json:"section,omitempty"`             // Only watch this section, empty for every section
+	DepartureDate string                 `protobuf:"bytes,2,opt,name=departureDate,proto3" json:"departureDate,omitempty"` // Date of travel as YYYY-MM-DD, empty for the default train
+	TrainId       string                 `protobuf:"bytes,3,opt,name=trainId,proto3" json:"trainId,omitempty"`             // Train to watch; empty watches the configured default train
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchAvailabilityRequest) Reset() {
+	*x = WatchAvailabilityRequest{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchAvailabilityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchAvailabilityRequest) ProtoMessage() {}
+
+func (x *WatchAvailabilityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchAvailabilityRequest.ProtoReflect.Descriptor instead.
+func (*WatchAvailabilityRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *WatchAvailabilityRequest) GetSection() string {
+	if x != nil {
+		return x.Section
+	}
+	return ""
+}
+
+func (x *WatchAvailabilityRequest) GetDepartureDate() string {
+	if x != nil {
+		return x.DepartureDate
+	}
+	return ""
+}
+
+func (x *WatchAvailabilityRequest) GetTrainId() string {
+	if x != nil {
+		return x.TrainId
+	}
+	return ""
+}
+
+type SectionAvailability struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Section       string                 `protobuf:"bytes,1,opt,name=section,proto3" json:"section,omitempty"`
+	VacantSeats   int32                  `protobuf:"varint,2,opt,name=vacantSeats,proto3" json:"vacantSeats,omitempty"`
+	MaxSeats      int32                  `protobuf:"varint,3,opt,name=maxSeats,proto3" json:"maxSeats,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SectionAvailability) Reset() {
+	*x = SectionAvailability{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SectionAvailability) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SectionAvailability) ProtoMessage() {}
+
+func (x *SectionAvailability) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SectionAvailability.ProtoReflect.Descriptor instead.
+func (*SectionAvailability) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *SectionAvailability) GetSection() string {
+	if x != nil {
+		return x.Section
+	}
+	return ""
+}
+
+func (x *SectionAvailability) GetVacantSeats() int32 {
+	if x != nil {
+		return x.VacantSeats
+	}
+	return 0
+}
+
+func (x *SectionAvailability) GetMaxSeats() int32 {
+	if x != nil {
+		return x.MaxSeats
+	}
+	return 0
+}
+
+type WatchAvailabilityResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sections      []*SectionAvailability `protobuf:"bytes,1,rep,name=sections,proto3" json:"sections,omitempty"` // In round-robin order
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchAvailabilityResponse) Reset() {
+	*x = WatchAvailabilityResponse{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchAvailabilityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchAvailabilityResponse) ProtoMessage() {}
+
+func (x *WatchAvailabilityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchAvailabilityResponse.ProtoReflect.Descriptor instead.
+func (*WatchAvailabilityResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *WatchAvailabilityResponse) GetSections() []*SectionAvailability {
+	if x != nil {
+		return x.Sections
+	}
+	return nil
+}
+
+// Messages for Forced Reassignment
+type ForceReassignRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	TargetSection string                 `protobuf:"bytes,2,opt,name=targetSection,proto3" json:"targetSection,omitempty"`
+	TargetSeat    int32                  `protobuf:"varint,3,opt,name=targetSeat,proto3" json:"targetSeat,omitempty"`
+	Reason        string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"` // Why the passenger is being moved, kept in the receipt history
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ForceReassignRequest) Reset() {
+	*x = ForceReassignRequest{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ForceReassignRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForceReassignRequest) ProtoMessage() {}
+
+func (x *ForceReassignRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForceReassignRequest.ProtoReflect.Descriptor instead.
+func (*ForceReassignRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *ForceReassignRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *ForceReassignRequest) GetTargetSection() string {
+	if x != nil {
+		return x.TargetSection
+	}
+	return ""
+}
+
+func (x *ForceReassignRequest) GetTargetSeat() int32 {
+	if x != nil {
+		return x.TargetSeat
+	}
+	return 0
+}
+
+func (x *ForceReassignRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type ForceReassignResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Message        string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	UpdatedReceipt *Receipt               `protobuf:"bytes,2,opt,name=updatedReceipt,proto3" json:"updatedReceipt,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ForceReassignResponse) Reset() {
+	*x = ForceReassignResponse{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ForceReassignResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForceReassignResponse) ProtoMessage() {}
+
+func (x *ForceReassignResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForceReassignResponse.ProtoReflect.Descriptor instead.
+func (*ForceReassignResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *ForceReassignResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ForceReassignResponse) GetUpdatedReceipt() *Receipt {
+	if x != nil {
+		return x.UpdatedReceipt
+	}
+	return nil
+}
+
+// Messages for Provisional Bookings
+type ConfirmPaymentRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	BookingReference string                 `protobuf:"bytes,1,opt,name=bookingReference,proto3" json:"bookingReference,omitempty"`
+	ReceiptMask      *fieldmaskpb.FieldMask `protobuf:"bytes,2,opt,name=receiptMask,proto3" json:"receiptMask,omitempty"` // Receipt fields to return, such as "seat" or "user.email"; empty returns the whole receipt
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ConfirmPaymentRequest) Reset() {
+	*x = ConfirmPaymentRequest{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmPaymentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmPaymentRequest) ProtoMessage() {}
+
+func (x *ConfirmPaymentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmPaymentRequest.ProtoReflect.Descriptor instead.
+func (*ConfirmPaymentRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *ConfirmPaymentRequest) GetBookingReference() string {
+	if x != nil {
+		return x.BookingReference
+	}
+	return ""
+}
+
+func (x *ConfirmPaymentRequest) GetReceiptMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.ReceiptMask
+	}
+	return nil
+}
+
+type ConfirmPaymentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Receipt       *Receipt               `protobuf:"bytes,2,opt,name=receipt,proto3" json:"receipt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmPaymentResponse) Reset() {
+	*x = ConfirmPaymentResponse{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmPaymentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmPaymentResponse) ProtoMessage() {}
+
+func (x *ConfirmPaymentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmPaymentResponse.ProtoReflect.Descriptor instead.
+func (*ConfirmPaymentResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *ConfirmPaymentResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ConfirmPaymentResponse) GetReceipt() *Receipt {
+	if x != nil {
+		return x.Receipt
+	}
+	return nil
+}
+
+// Messages for Seat Holds
+type SeatHold struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	HoldId        string                 `protobuf:"bytes,1,opt,name=holdId,proto3" json:"holdId,omitempty"`
+	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	Seat          *Seat                  `protobuf:"bytes,3,opt,name=seat,proto3" json:"seat,omitempty"`
+	From          string                 `protobuf:"bytes,4,opt,name=from,proto3" json:"from,omitempty"`
+	To            string                 `protobuf:"bytes,5,opt,name=to,proto3" json:"to,omitempty"`
+	DepartureDate string                 `protobuf:"bytes,6,opt,name=departureDate,proto3" json:"departureDate,omitempty"` // Date of travel as YYYY-MM-DD, empty for the default train
+	ExpiresAt     int64                  `protobuf:"varint,7,opt,name=expiresAt,proto3" json:"expiresAt,omitempty"`        // Unix time in seconds when the seat is released unless booked
+	TrainId       string                 `protobuf:"bytes,8,opt,name=trainId,proto3" json:"trainId,omitempty"`             // Train the seat is on
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SeatHold) Reset() {
+	*x = SeatHold{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SeatHold) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SeatHold) ProtoMessage() {}
+
+func (x *SeatHold) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SeatHold.ProtoReflect.Descriptor instead.
+func (*SeatHold) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *SeatHold) GetHoldId() string {
+	if x != nil {
+		return x.HoldId
+	}
+	return ""
+}
+
+func (x *SeatHold) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *SeatHold) GetSeat() *Seat {
+	if x != nil {
+		return x.Seat
+	}
+	return nil
+}
+
+func (x *SeatHold) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *SeatHold) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *SeatHold) GetDepartureDate() string {
+	if x != nil {
+		return x.DepartureDate
+	}
+	return ""
+}
+
+func (x *SeatHold) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+func (x *SeatHold) GetTrainId() string {
+	if x != nil {
+		return x.TrainId
+	}
+	return ""
+}
+
+type HoldSeatRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	From          string                 `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To            string                 `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	Seat          *Seat                  `protobuf:"bytes,4,opt,name=seat,proto3" json:"seat,omitempty"`
+	DepartureDate string                 `protobuf:"bytes,5,opt,name=departureDate,proto3" json:"departureDate,omitempty"` // Date of travel as YYYY-MM-DD; empty holds a seat on the default train
+	TrainId       string                 `protobuf:"bytes,6,opt,name=trainId,proto3" json:"trainId,omitempty"`             // Train to hold a seat on; empty holds one on the configured default train
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HoldSeatRequest) Reset() {
+	*x = HoldSeatRequest{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HoldSeatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HoldSeatRequest) ProtoMessage() {}
+
+func (x *HoldSeatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HoldSeatRequest.ProtoReflect.Descriptor instead.
+func (*HoldSeatRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *HoldSeatRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *HoldSeatRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *HoldSeatRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *HoldSeatRequest) GetSeat() *Seat {
+	if x != nil {
+		return x.Seat
+	}
+	return nil
+}
+
+func (x *HoldSeatRequest) GetDepartureDate() string {
+	if x != nil {
+		return x.DepartureDate
+	}
+	return ""
+}
+
+func (x *HoldSeatRequest) GetTrainId() string {
+	if x != nil {
+		return x.TrainId
+	}
+	return ""
+}
+
+type HoldSeatResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Hold          *SeatHold              `protobuf:"bytes,2,opt,name=hold,proto3" json:"hold,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HoldSeatResponse) Reset() {
+	*x = HoldSeatResponse{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HoldSeatResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HoldSeatResponse) ProtoMessage() {}
+
+func (x *HoldSeatResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HoldSeatResponse.ProtoReflect.Descriptor instead.
+func (*HoldSeatResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *HoldSeatResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *HoldSeatResponse) GetHold() *SeatHold {
+	if x != nil {
+		return x.Hold
+	}
+	return nil
+}
+
+type ReleaseHoldRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	HoldId        string                 `protobuf:"bytes,1,opt,name=holdId,proto3" json:"holdId,omitempty"`
+	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"` // User who made the hold; only they may release it
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseHoldRequest) Reset() {
+	*x = ReleaseHoldRequest{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseHoldRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseHoldRequest) ProtoMessage() {}
+
+func (x *ReleaseHoldRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseHoldRequest.ProtoReflect.Descriptor instead.
+func (*ReleaseHoldRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *ReleaseHoldRequest) GetHoldId() string {
+	if x != nil {
+		return x.HoldId
+	}
+	return ""
+}
+
+func (x *ReleaseHoldRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+type ReleaseHoldResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseHoldResponse) Reset() {
+	*x = ReleaseHoldResponse{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseHoldResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseHoldResponse) ProtoMessage() {}
+
+func (x *ReleaseHoldResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseHoldResponse.ProtoReflect.Descriptor instead.
+func (*ReleaseHoldResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *ReleaseHoldResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type GetExpiringHoldsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WithinSeconds int32                  `protobuf:"varint,1,opt,name=withinSeconds,proto3" json:"withinSeconds,omitempty"` // Only holds expiring within this many seconds are returned
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetExpiringHoldsRequest) Reset() {
+	*x = GetExpiringHoldsRequest{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetExpiringHoldsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetExpiringHoldsRequest) ProtoMessage() {}
+
+func (x *GetExpiringHoldsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetExpiringHoldsRequest.ProtoReflect.Descriptor instead.
+func (*GetExpiringHoldsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *GetExpiringHoldsRequest) GetWithinSeconds() int32 {
+	if x != nil {
+		return x.WithinSeconds
+	}
+	return 0
+}
+
+type ExpiringHold struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Seat             *Seat                  `protobuf:"bytes,1,opt,name=seat,proto3" json:"seat,omitempty"`
+	DepartureDate    string                 `protobuf:"bytes,2,opt,name=departureDate,proto3" json:"departureDate,omitempty"`        // Date of travel as YYYY-MM-DD, empty for the default train
+	ExpiresAt        int64                  `protobuf:"varint,3,opt,name=expiresAt,proto3" json:"expiresAt,omitempty"`               // Unix time in seconds when the seat is released unless booked
+	RemainingSeconds int32                  `protobuf:"varint,4,opt,name=remainingSeconds,proto3" json:"remainingSeconds,omitempty"` // Seconds left until expiresAt
+	TrainId          string                 `protobuf:"bytes,5,opt,name=trainId,proto3" json:"trainId,omitempty"`                    // Train the seat is on
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ExpiringHold) Reset() {
+	*x = ExpiringHold{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExpiringHold) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExpiringHold) ProtoMessage() {}
+
+func (x *ExpiringHold) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExpiringHold.ProtoReflect.Descriptor instead.
+func (*ExpiringHold) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *ExpiringHold) GetSeat() *Seat {
+	if x != nil {
+		return x.Seat
+	}
+	return nil
+}
+
+func (x *ExpiringHold) GetDepartureDate() string {
+	if x != nil {
+		return x.DepartureDate
+	}
+	return ""
+}
+
+func (x *ExpiringHold) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+func (x *ExpiringHold) GetRemainingSeconds() int32 {
+	if x != nil {
+		return x.RemainingSeconds
+	}
+	return 0
+}
+
+func (x *ExpiringHold) GetTrainId() string {
+	if x != nil {
+		return x.TrainId
+	}
+	return ""
+}
+
+type GetExpiringHoldsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Holds         []*ExpiringHold        `protobuf:"bytes,1,rep,name=holds,proto3" json:"holds,omitempty"` // Soonest to expire first
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetExpiringHoldsResponse) Reset() {
+	*x = GetExpiringHoldsResponse{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetExpiringHoldsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetExpiringHoldsResponse) ProtoMessage() {}
+
+func (x *GetExpiringHoldsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetExpiringHoldsResponse.ProtoReflect.Descriptor instead.
+func (*GetExpiringHoldsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *GetExpiringHoldsResponse) GetHolds() []*ExpiringHold {
+	if x != nil {
+		return x.Holds
+	}
+	return nil
+}
+
+type GetBookingTrendsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bucket        TrendBucket            `protobuf:"varint,1,opt,name=bucket,proto3,enum=ticketBooking.TrendBucket" json:"bucket,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBookingTrendsRequest) Reset() {
+	*x = GetBookingTrendsRequest{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBookingTrendsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBookingTrendsRequest) ProtoMessage() {}
+
+func (x *GetBookingTrendsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBookingTrendsRequest.ProtoReflect.Descriptor instead.
+func (*GetBookingTrendsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *GetBookingTrendsRequest) GetBucket() TrendBucket {
+	if x != nil {
+		return x.Bucket
+	}
+	return TrendBucket_TREND_BUCKET_UNSPECIFIED
+}
+
+type BookingTrend struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StartsAt      int64                  `protobuf:"varint,1,opt,name=startsAt,proto3" json:"startsAt,omitempty"`           // Unix time in seconds at which the bucket starts
+	Bookings      int32                  `protobuf:"varint,2,opt,name=bookings,proto3" json:"bookings,omitempty"`           // Tickets booked in the bucket
+	Cancellations int32                  `protobuf:"varint,3,opt,name=cancellations,proto3" json:"cancellations,omitempty"` // Tickets cancelled in the bucket, including expired provisional bookings
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BookingTrend) Reset() {
+	*x = BookingTrend{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BookingTrend) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BookingTrend) ProtoMessage() {}
+
+func (x *BookingTrend) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BookingTrend.ProtoReflect.Descriptor instead.
+func (*BookingTrend) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *BookingTrend) GetStartsAt() int64 {
+	if x != nil {
+		return x.StartsAt
+	}
+	return 0
+}
+
+func (x *BookingTrend) GetBookings() int32 {
+	if x != nil {
+		return x.Bookings
+	}
+	return 0
+}
+
+func (x *BookingTrend) GetCancellations() int32 {
+	if x != nil {
+		return x.Cancellations
+	}
+	return 0
+}
+
+type GetBookingTrendsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Buckets       []*BookingTrend        `protobuf:"bytes,1,rep,name=buckets,proto3" json:"buckets,omitempty"` // Oldest first; the last bucket is the one in progress
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBookingTrendsResponse) Reset() {
+	*x = GetBookingTrendsResponse{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBookingTrendsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBookingTrendsResponse) ProtoMessage() {}
+
+func (x *GetBookingTrendsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBookingTrendsResponse.ProtoReflect.Descriptor instead.
+func (*GetBookingTrendsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *GetBookingTrendsResponse) GetBuckets() []*BookingTrend {
+	if x != nil {
+		return x.Buckets
+	}
+	return nil
+}
+
+// Messages for importing bookings from another system
+type ImportReceiptsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Receipts      []*Receipt             `protobuf:"bytes,1,rep,name=receipts,proto3" json:"receipts,omitempty"` // Bookings to load, each with the seat it holds
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportReceiptsRequest) Reset() {
+	*x = ImportReceiptsRequest{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportReceiptsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportReceiptsRequest) ProtoMessage() {}
+
+func (x *ImportReceiptsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportReceiptsRequest.ProtoReflect.Descriptor instead.
+func (*ImportReceiptsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *ImportReceiptsRequest) GetReceipts() []*Receipt {
+	if x != nil {
+		return x.Receipts
+	}
+	return nil
+}
+
+type ImportError struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Index         int32                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"` // Position of the rejected receipt in the request
+	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"` // Why the receipt was rejected
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportError) Reset() {
+	*x = ImportError{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportError) ProtoMessage() {}
+
+func (x *ImportError) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportError.ProtoReflect.Descriptor instead.
+func (*ImportError) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *ImportError) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *ImportError) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *ImportError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type ImportReceiptsResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Message          string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	ImportedReceipts []*Receipt             `protobuf:"bytes,2,rep,name=importedReceipts,proto3" json:"importedReceipts,omitempty"` // Receipts as stored, with their booking references
+	Errors           []*ImportError         `protobuf:"bytes,3,rep,name=errors,proto3" json:"errors,omitempty"`                     // Rejected receipts; nothing is imported if there are any
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ImportReceiptsResponse) Reset() {
+	*x = ImportReceiptsResponse{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportReceiptsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportReceiptsResponse) ProtoMessage() {}
+
+func (x *ImportReceiptsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportReceiptsResponse.ProtoReflect.Descriptor instead.
+func (*ImportReceiptsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *ImportReceiptsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ImportReceiptsResponse) GetImportedReceipts() []*Receipt {
+	if x != nil {
+		return x.ImportedReceipts
+	}
+	return nil
+}
+
+func (x *ImportReceiptsResponse) GetErrors() []*ImportError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+// Messages for Cancellation Analytics
+type GetCancellationStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCancellationStatsRequest) Reset() {
+	*x = GetCancellationStatsRequest{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCancellationStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCancellationStatsRequest) ProtoMessage() {}
+
+func (x *GetCancellationStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCancellationStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetCancellationStatsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{82}
+}
+
+type CancellationCount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reason        CancellationReason     `protobuf:"varint,1,opt,name=reason,proto3,enum=ticketBooking.CancellationReason" json:"reason,omitempty"`
+	Count         int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancellationCount) Reset() {
+	*x = CancellationCount{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[83]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancellationCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancellationCount) ProtoMessage() {}
+
+func (x *CancellationCount) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[83]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancellationCount.ProtoReflect.Descriptor instead.
+func (*CancellationCount) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *CancellationCount) GetReason() CancellationReason {
+	if x != nil {
+		return x.Reason
+	}
+	return CancellationReason_CANCELLATION_REASON_UNSPECIFIED
+}
+
+func (x *CancellationCount) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type GetCancellationStatsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Counts        []*CancellationCount   `protobuf:"bytes,1,rep,name=counts,proto3" json:"counts,omitempty"` // One entry per reason, in enum order, including reasons with no cancellations
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`  // Cancellations since the server started
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCancellationStatsResponse) Reset() {
+	*x = GetCancellationStatsResponse{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[84]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCancellationStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCancellationStatsResponse) ProtoMessage() {}
+
+func (x *GetCancellationStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[84]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCancellationStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetCancellationStatsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{84}
+}
+
+func (x *GetCancellationStatsResponse) GetCounts() []*CancellationCount {
+	if x != nil {
+		return x.Counts
+	}
+	return nil
+}
+
+func (x *GetCancellationStatsResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type GetNearbyAvailableSeatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Section       string                 `protobuf:"bytes,1,opt,name=section,proto3" json:"section,omitempty"`
+	SeatNumber    int32                  `protobuf:"varint,2,opt,name=seatNumber,proto3" json:"seatNumber,omitempty"`      // The anchor seat, e.g. the caller's own
+	Radius        int32                  `protobuf:"varint,3,opt,name=radius,proto3" json:"radius,omitempty"`              // How many seats away to look; with a row layout, how many rows and seats across
+	TrainId       string                 `protobuf:"bytes,4,opt,name=trainId,proto3" json:"trainId,omitempty"`             // Train to look at; empty looks at the configured default train
+	DepartureDate string                 `protobuf:"bytes,5,opt,name=departureDate,proto3" json:"departureDate,omitempty"` // Date of travel as YYYY-MM-DD; empty looks at the default train
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetNearbyAvailableSeatsRequest) Reset() {
+	*x = GetNearbyAvailableSeatsRequest{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[85]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNearbyAvailableSeatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNearbyAvailableSeatsRequest) ProtoMessage() {}
+
+func (x *GetNearbyAvailableSeatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[85]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNearbyAvailableSeatsRequest.ProtoReflect.Descriptor instead.
+func (*GetNearbyAvailableSeatsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{85}
+}
+
+func (x *GetNearbyAvailableSeatsRequest) GetSection() string {
+	if x != nil {
+		return x.Section
+	}
+	return ""
+}
+
+func (x *GetNearbyAvailableSeatsRequest) GetSeatNumber() int32 {
+	if x != nil {
+		return x.SeatNumber
+	}
+	return 0
 }
 
-func (x *User) GetFirstName() string {
+func (x *GetNearbyAvailableSeatsRequest) GetRadius() int32 {
 	if x != nil {
-		return x.FirstName
+		return x.Radius
 	}
-	return ""
+	return 0
 }
 
-func (x *User) GetLastName() string {
+func (x *GetNearbyAvailableSeatsRequest) GetTrainId() string {
 	if x != nil {
-		return x.LastName
+		return x.TrainId
 	}
 	return ""
 }
 
-func (x *User) GetEmail() string {
+func (x *GetNearbyAvailableSeatsRequest) GetDepartureDate() string {
 	if x != nil {
-		return x.Email
+		return x.DepartureDate
 	}
 	return ""
 }
 
-// Messages for Receipt Retrieval
-type GetReceiptRequest struct {
+type GetNearbyAvailableSeatsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	Seats         []*Seat                `protobuf:"bytes,1,rep,name=seats,proto3" json:"seats,omitempty"` // Vacant seats around the anchor on the default train, closest first
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetReceiptRequest) Reset() {
-	*x = GetReceiptRequest{}
-	mi := &file_proto_ticketBooking_proto_msgTypes[4]
+func (x *GetNearbyAvailableSeatsResponse) Reset() {
+	*x = GetNearbyAvailableSeatsResponse{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[86]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetReceiptRequest) String() string {
+func (x *GetNearbyAvailableSeatsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetReceiptRequest) ProtoMessage() {}
+func (*GetNearbyAvailableSeatsResponse) ProtoMessage() {}
 
-func (x *GetReceiptRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_ticketBooking_proto_msgTypes[4]
+func (x *GetNearbyAvailableSeatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[86]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -303,40 +5675,41 @@ func (x *GetReceiptRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetReceiptRequest.ProtoReflect.Descriptor instead.
-func (*GetReceiptRequest) Descriptor() ([]byte, []int) {
-	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use GetNearbyAvailableSeatsResponse.ProtoReflect.Descriptor instead.
+func (*GetNearbyAvailableSeatsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{86}
 }
 
-func (x *GetReceiptRequest) GetEmail() string {
+func (x *GetNearbyAvailableSeatsResponse) GetSeats() []*Seat {
 	if x != nil {
-		return x.Email
+		return x.Seats
 	}
-	return ""
+	return nil
 }
 
-type GetReceiptResponse struct {
+// Messages for quiescing a section before maintenance
+type QuiesceSectionRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Receipt       *Receipt               `protobuf:"bytes,1,opt,name=receipt,proto3" json:"receipt,omitempty"`
+	Section       string                 `protobuf:"bytes,1,opt,name=section,proto3" json:"section,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetReceiptResponse) Reset() {
-	*x = GetReceiptResponse{}
-	mi := &file_proto_ticketBooking_proto_msgTypes[5]
+func (x *QuiesceSectionRequest) Reset() {
+	*x = QuiesceSectionRequest{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[87]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetReceiptResponse) String() string {
+func (x *QuiesceSectionRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetReceiptResponse) ProtoMessage() {}
+func (*QuiesceSectionRequest) ProtoMessage() {}
 
-func (x *GetReceiptResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_ticketBooking_proto_msgTypes[5]
+func (x *QuiesceSectionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[87]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -347,42 +5720,42 @@ func (x *GetReceiptResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetReceiptResponse.ProtoReflect.Descriptor instead.
-func (*GetReceiptResponse) Descriptor() ([]byte, []int) {
-	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use QuiesceSectionRequest.ProtoReflect.Descriptor instead.
+func (*QuiesceSectionRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{87}
 }
 
-func (x *GetReceiptResponse) GetReceipt() *Receipt {
+func (x *QuiesceSectionRequest) GetSection() string {
 	if x != nil {
-		return x.Receipt
+		return x.Section
 	}
-	return nil
+	return ""
 }
 
-// Messages for View User Seats by Section
-type UserSeat struct {
+type QuiesceSectionResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
-	AllottedSeat  int32                  `protobuf:"varint,2,opt,name=allottedSeat,proto3" json:"allottedSeat,omitempty"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Section       string                 `protobuf:"bytes,2,opt,name=section,proto3" json:"section,omitempty"`
+	OccupiedSeats int32                  `protobuf:"varint,3,opt,name=occupiedSeats,proto3" json:"occupiedSeats,omitempty"` // Riders still seated in the section on every departure, to move or wait out before maintenance
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UserSeat) Reset() {
-	*x = UserSeat{}
-	mi := &file_proto_ticketBooking_proto_msgTypes[6]
+func (x *QuiesceSectionResponse) Reset() {
+	*x = QuiesceSectionResponse{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[88]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UserSeat) String() string {
+func (x *QuiesceSectionResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UserSeat) ProtoMessage() {}
+func (*QuiesceSectionResponse) ProtoMessage() {}
 
-func (x *UserSeat) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_ticketBooking_proto_msgTypes[6]
+func (x *QuiesceSectionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[88]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -393,47 +5766,54 @@ func (x *UserSeat) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UserSeat.ProtoReflect.Descriptor instead.
-func (*UserSeat) Descriptor() ([]byte, []int) {
-	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use QuiesceSectionResponse.ProtoReflect.Descriptor instead.
+func (*QuiesceSectionResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{88}
 }
 
-func (x *UserSeat) GetUser() *User {
+func (x *QuiesceSectionResponse) GetMessage() string {
 	if x != nil {
-		return x.User
+		return x.Message
 	}
-	return nil
+	return ""
 }
 
-func (x *UserSeat) GetAllottedSeat() int32 {
+func (x *QuiesceSectionResponse) GetSection() string {
 	if x != nil {
-		return x.AllottedSeat
+		return x.Section
+	}
+	return ""
+}
+
+func (x *QuiesceSectionResponse) GetOccupiedSeats() int32 {
+	if x != nil {
+		return x.OccupiedSeats
 	}
 	return 0
 }
 
-type GetUsersBySectionRequest struct {
+type ResumeSectionRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Section       string                 `protobuf:"bytes,1,opt,name=section,proto3" json:"section,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetUsersBySectionRequest) Reset() {
-	*x = GetUsersBySectionRequest{}
-	mi := &file_proto_ticketBooking_proto_msgTypes[7]
+func (x *ResumeSectionRequest) Reset() {
+	*x = ResumeSectionRequest{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[89]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetUsersBySectionRequest) String() string {
+func (x *ResumeSectionRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetUsersBySectionRequest) ProtoMessage() {}
+func (*ResumeSectionRequest) ProtoMessage() {}
 
-func (x *GetUsersBySectionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_ticketBooking_proto_msgTypes[7]
+func (x *ResumeSectionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[89]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -444,41 +5824,41 @@ func (x *GetUsersBySectionRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetUsersBySectionRequest.ProtoReflect.Descriptor instead.
-func (*GetUsersBySectionRequest) Descriptor() ([]byte, []int) {
-	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use ResumeSectionRequest.ProtoReflect.Descriptor instead.
+func (*ResumeSectionRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{89}
 }
 
-func (x *GetUsersBySectionRequest) GetSection() string {
+func (x *ResumeSectionRequest) GetSection() string {
 	if x != nil {
 		return x.Section
 	}
 	return ""
 }
 
-type GetUsersBySectionResponse struct {
+type ResumeSectionResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Section       string                 `protobuf:"bytes,1,opt,name=section,proto3" json:"section,omitempty"`
-	Users         []*UserSeat            `protobuf:"bytes,2,rep,name=users,proto3" json:"users,omitempty"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Section       string                 `protobuf:"bytes,2,opt,name=section,proto3" json:"section,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetUsersBySectionResponse) Reset() {
-	*x = GetUsersBySectionResponse{}
-	mi := &file_proto_ticketBooking_proto_msgTypes[8]
+func (x *ResumeSectionResponse) Reset() {
+	*x = ResumeSectionResponse{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[90]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetUsersBySectionResponse) String() string {
+func (x *ResumeSectionResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetUsersBySectionResponse) ProtoMessage() {}
+func (*ResumeSectionResponse) ProtoMessage() {}
 
-func (x *GetUsersBySectionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_ticketBooking_proto_msgTypes[8]
+func (x *ResumeSectionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[90]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -489,48 +5869,47 @@ func (x *GetUsersBySectionResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetUsersBySectionResponse.ProtoReflect.Descriptor instead.
-func (*GetUsersBySectionResponse) Descriptor() ([]byte, []int) {
-	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use ResumeSectionResponse.ProtoReflect.Descriptor instead.
+func (*ResumeSectionResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{90}
 }
 
-func (x *GetUsersBySectionResponse) GetSection() string {
+func (x *ResumeSectionResponse) GetMessage() string {
 	if x != nil {
-		return x.Section
+		return x.Message
 	}
 	return ""
 }
 
-func (x *GetUsersBySectionResponse) GetUsers() []*UserSeat {
+func (x *ResumeSectionResponse) GetSection() string {
 	if x != nil {
-		return x.Users
+		return x.Section
 	}
-	return nil
+	return ""
 }
 
-type Seat struct {
+// Messages for the configuration change log
+type GetConfigChangesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Section       string                 `protobuf:"bytes,1,opt,name=section,proto3" json:"section,omitempty"`
-	SeatNumber    int32                  `protobuf:"varint,2,opt,name=seatNumber,proto3" json:"seatNumber,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Seat) Reset() {
-	*x = Seat{}
-	mi := &file_proto_ticketBooking_proto_msgTypes[9]
+func (x *GetConfigChangesRequest) Reset() {
+	*x = GetConfigChangesRequest{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[91]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Seat) String() string {
+func (x *GetConfigChangesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Seat) ProtoMessage() {}
+func (*GetConfigChangesRequest) ProtoMessage() {}
 
-func (x *Seat) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_ticketBooking_proto_msgTypes[9]
+func (x *GetConfigChangesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[91]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -541,48 +5920,36 @@ func (x *Seat) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Seat.ProtoReflect.Descriptor instead.
-func (*Seat) Descriptor() ([]byte, []int) {
-	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{9}
-}
-
-func (x *Seat) GetSection() string {
-	if x != nil {
-		return x.Section
-	}
-	return ""
-}
-
-func (x *Seat) GetSeatNumber() int32 {
-	if x != nil {
-		return x.SeatNumber
-	}
-	return 0
+// Deprecated: Use GetConfigChangesRequest.ProtoReflect.Descriptor instead.
+func (*GetConfigChangesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{91}
 }
 
-// Messages for User Removal
-type RemoveUserRequest struct {
+type ConfigChange struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"` // YAML keys of the setting, e.g. "stations.London-France" or "sections[A].max_seats"
+	Change        ConfigChangeType       `protobuf:"varint,2,opt,name=change,proto3,enum=ticketBooking.ConfigChangeType" json:"change,omitempty"`
+	OldValue      string                 `protobuf:"bytes,3,opt,name=oldValue,proto3" json:"oldValue,omitempty"` // Empty for added entries
+	NewValue      string                 `protobuf:"bytes,4,opt,name=newValue,proto3" json:"newValue,omitempty"` // Empty for removed entries
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RemoveUserRequest) Reset() {
-	*x = RemoveUserRequest{}
-	mi := &file_proto_ticketBooking_proto_msgTypes[10]
+func (x *ConfigChange) Reset() {
+	*x = ConfigChange{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[92]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RemoveUserRequest) String() string {
+func (x *ConfigChange) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RemoveUserRequest) ProtoMessage() {}
+func (*ConfigChange) ProtoMessage() {}
 
-func (x *RemoveUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_ticketBooking_proto_msgTypes[10]
+func (x *ConfigChange) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[92]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -593,41 +5960,62 @@ func (x *RemoveUserRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RemoveUserRequest.ProtoReflect.Descriptor instead.
-func (*RemoveUserRequest) Descriptor() ([]byte, []int) {
-	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use ConfigChange.ProtoReflect.Descriptor instead.
+func (*ConfigChange) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{92}
 }
 
-func (x *RemoveUserRequest) GetEmail() string {
+func (x *ConfigChange) GetPath() string {
 	if x != nil {
-		return x.Email
+		return x.Path
 	}
 	return ""
 }
 
-type RemoveUserResponse struct {
+func (x *ConfigChange) GetChange() ConfigChangeType {
+	if x != nil {
+		return x.Change
+	}
+	return ConfigChangeType_CONFIG_CHANGE_TYPE_UNSPECIFIED
+}
+
+func (x *ConfigChange) GetOldValue() string {
+	if x != nil {
+		return x.OldValue
+	}
+	return ""
+}
+
+func (x *ConfigChange) GetNewValue() string {
+	if x != nil {
+		return x.NewValue
+	}
+	return ""
+}
+
+type GetConfigChangesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
-	RemovedUser   *User                  `protobuf:"bytes,2,opt,name=removedUser,proto3" json:"removedUser,omitempty"`
+	Changes       []*ConfigChange        `protobuf:"bytes,1,rep,name=changes,proto3" json:"changes,omitempty"`        // Differences from the previous file made by the latest reload, secrets redacted
+	ReloadedAt    int64                  `protobuf:"varint,2,opt,name=reloadedAt,proto3" json:"reloadedAt,omitempty"` // Unix time of the latest reload, zero if the configuration was never reloaded
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RemoveUserResponse) Reset() {
-	*x = RemoveUserResponse{}
-	mi := &file_proto_ticketBooking_proto_msgTypes[11]
+func (x *GetConfigChangesResponse) Reset() {
+	*x = GetConfigChangesResponse{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[93]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RemoveUserResponse) String() string {
+func (x *GetConfigChangesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RemoveUserResponse) ProtoMessage() {}
+func (*GetConfigChangesResponse) ProtoMessage() {}
 
-func (x *RemoveUserResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_ticketBooking_proto_msgTypes[11]
+func (x *GetConfigChangesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[93]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -638,49 +6026,51 @@ func (x *RemoveUserResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RemoveUserResponse.ProtoReflect.Descriptor instead.
-func (*RemoveUserResponse) Descriptor() ([]byte, []int) {
-	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use GetConfigChangesResponse.ProtoReflect.Descriptor instead.
+func (*GetConfigChangesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{93}
 }
 
-func (x *RemoveUserResponse) GetMessage() string {
+func (x *GetConfigChangesResponse) GetChanges() []*ConfigChange {
 	if x != nil {
-		return x.Message
+		return x.Changes
 	}
-	return ""
+	return nil
 }
 
-func (x *RemoveUserResponse) GetRemovedUser() *User {
+func (x *GetConfigChangesResponse) GetReloadedAt() int64 {
 	if x != nil {
-		return x.RemovedUser
+		return x.ReloadedAt
 	}
-	return nil
+	return 0
 }
 
-// Messages for Seat Modification
-type UpdateUserSeatRequest struct {
+// Messages for Route Change
+type ChangeStationsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
-	NewSeat       *Seat                  `protobuf:"bytes,2,opt,name=newSeat,proto3" json:"newSeat,omitempty"`
+	From          string                 `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To            string                 `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	ReceiptMask   *fieldmaskpb.FieldMask `protobuf:"bytes,4,opt,name=receiptMask,proto3" json:"receiptMask,omitempty"` // Receipt fields to return, such as "seat" or "user.email"; empty returns the whole receipt
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateUserSeatRequest) Reset() {
-	*x = UpdateUserSeatRequest{}
-	mi := &file_proto_ticketBooking_proto_msgTypes[12]
+func (x *ChangeStationsRequest) Reset() {
+	*x = ChangeStationsRequest{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[94]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateUserSeatRequest) String() string {
+func (x *ChangeStationsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateUserSeatRequest) ProtoMessage() {}
+func (*ChangeStationsRequest) ProtoMessage() {}
 
-func (x *UpdateUserSeatRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_ticketBooking_proto_msgTypes[12]
+func (x *ChangeStationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[94]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -691,26 +6081,40 @@ func (x *UpdateUserSeatRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateUserSeatRequest.ProtoReflect.Descriptor instead.
-func (*UpdateUserSeatRequest) Descriptor() ([]byte, []int) {
-	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use ChangeStationsRequest.ProtoReflect.Descriptor instead.
+func (*ChangeStationsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{94}
 }
 
-func (x *UpdateUserSeatRequest) GetEmail() string {
+func (x *ChangeStationsRequest) GetEmail() string {
 	if x != nil {
 		return x.Email
 	}
 	return ""
 }
 
-func (x *UpdateUserSeatRequest) GetNewSeat() *Seat {
+func (x *ChangeStationsRequest) GetFrom() string {
 	if x != nil {
-		return x.NewSeat
+		return x.From
+	}
+	return ""
+}
+
+func (x *ChangeStationsRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *ChangeStationsRequest) GetReceiptMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.ReceiptMask
 	}
 	return nil
 }
 
-type UpdateUserSeatResponse struct {
+type ChangeStationsResponse struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
 	Message        string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
 	UpdatedReceipt *Receipt               `protobuf:"bytes,2,opt,name=updatedReceipt,proto3" json:"updatedReceipt,omitempty"`
@@ -718,21 +6122,21 @@ type UpdateUserSeatResponse struct {
 	sizeCache      protoimpl.SizeCache
 }
 
-func (x *UpdateUserSeatResponse) Reset() {
-	*x = UpdateUserSeatResponse{}
-	mi := &file_proto_ticketBooking_proto_msgTypes[13]
+func (x *ChangeStationsResponse) Reset() {
+	*x = ChangeStationsResponse{}
+	mi := &file_proto_ticketBooking_proto_msgTypes[95]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateUserSeatResponse) String() string {
+func (x *ChangeStationsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateUserSeatResponse) ProtoMessage() {}
+func (*ChangeStationsResponse) ProtoMessage() {}
 
-func (x *UpdateUserSeatResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_ticketBooking_proto_msgTypes[13]
+func (x *ChangeStationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketBooking_proto_msgTypes[95]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -743,19 +6147,19 @@ func (x *UpdateUserSeatResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateUserSeatResponse.ProtoReflect.Descriptor instead.
-func (*UpdateUserSeatResponse) Descriptor() ([]byte, []int) {
-	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use ChangeStationsResponse.ProtoReflect.Descriptor instead.
+func (*ChangeStationsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ticketBooking_proto_rawDescGZIP(), []int{95}
 }
 
-func (x *UpdateUserSeatResponse) GetMessage() string {
+func (x *ChangeStationsResponse) GetMessage() string {
 	if x != nil {
 		return x.Message
 	}
 	return ""
 }
 
-func (x *UpdateUserSeatResponse) GetUpdatedReceipt() *Receipt {
+func (x *ChangeStationsResponse) GetUpdatedReceipt() *Receipt {
 	if x != nil {
 		return x.UpdatedReceipt
 	}
@@ -766,52 +6170,455 @@ var File_proto_ticketBooking_proto protoreflect.FileDescriptor
 
 const file_proto_ticketBooking_proto_rawDesc = "" +
 	"\n" +
-	"\x19proto/ticketBooking.proto\x12\rticketBooking\"d\n" +
+	"\x19proto/ticketBooking.proto\x12\rticketBooking\x1a google/protobuf/field_mask.proto\"\x88\x05\n" +
 	"\x15PurchaseTicketRequest\x12'\n" +
 	"\x04user\x18\x01 \x01(\v2\x13.ticketBooking.UserR\x04user\x12\x12\n" +
 	"\x04from\x18\x04 \x01(\tR\x04from\x12\x0e\n" +
-	"\x02to\x18\x05 \x01(\tR\x02to\"d\n" +
+	"\x02to\x18\x05 \x01(\tR\x02to\x12\x16\n" +
+	"\x06dryRun\x18\x06 \x01(\bR\x06dryRun\x12,\n" +
+	"\x11preferredSections\x18\a \x03(\tR\x11preferredSections\x12$\n" +
+	"\rdepartureDate\x18\b \x01(\tR\rdepartureDate\x121\n" +
+	"\tcompanion\x18\t \x01(\v2\x13.ticketBooking.UserR\tcompanion\x12N\n" +
+	"\bmetadata\x18\n" +
+	" \x03(\v22.ticketBooking.PurchaseTicketRequest.MetadataEntryR\bmetadata\x12$\n" +
+	"\rminimizePrice\x18\v \x01(\bR\rminimizePrice\x12 \n" +
+	"\vprovisional\x18\f \x01(\bR\vprovisional\x12\x16\n" +
+	"\x06holdId\x18\r \x01(\tR\x06holdId\x12\x18\n" +
+	"\atrainId\x18\x0e \x01(\tR\atrainId\x12<\n" +
+	"\vreceiptMask\x18\x0f \x01(\v2\x1a.google.protobuf.FieldMaskR\vreceiptMask\x12 \n" +
+	"\vloyaltyTier\x18\x10 \x01(\tR\vloyaltyTier\x12\x1c\n" +
+	"\tfareClass\x18\x11 \x01(\tR\tfareClass\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xc2\x02\n" +
 	"\x16PurchaseTicketResponse\x12\x18\n" +
 	"\amessage\x18\x01 \x01(\tR\amessage\x120\n" +
-	"\areceipt\x18\x02 \x01(\v2\x16.ticketBooking.ReceiptR\areceipt\"\x9d\x01\n" +
+	"\areceipt\x18\x02 \x01(\v2\x16.ticketBooking.ReceiptR\areceipt\x12,\n" +
+	"\x11matchedPreference\x18\x03 \x01(\tR\x11matchedPreference\x12,\n" +
+	"\x11waitlistedSection\x18\x04 \x01(\tR\x11waitlistedSection\x12B\n" +
+	"\x10companionReceipt\x18\x05 \x01(\v2\x16.ticketBooking.ReceiptR\x10companionReceipt\x12<\n" +
+	"\vpairSeating\x18\x06 \x01(\x0e2\x1a.ticketBooking.PairSeatingR\vpairSeating\"\xf0\x04\n" +
 	"\aReceipt\x12\x12\n" +
 	"\x04from\x18\x01 \x01(\tR\x04from\x12\x0e\n" +
 	"\x02to\x18\x02 \x01(\tR\x02to\x12'\n" +
 	"\x04user\x18\x03 \x01(\v2\x13.ticketBooking.UserR\x04user\x12\x1c\n" +
 	"\tpricePaid\x18\x04 \x01(\x01R\tpricePaid\x12'\n" +
-	"\x04seat\x18\x05 \x01(\v2\x13.ticketBooking.SeatR\x04seat\"V\n" +
+	"\x04seat\x18\x05 \x01(\v2\x13.ticketBooking.SeatR\x04seat\x12\x1a\n" +
+	"\bbaseFare\x18\x06 \x01(\x01R\bbaseFare\x12\x1e\n" +
+	"\n" +
+	"fareWindow\x18\a \x01(\tR\n" +
+	"fareWindow\x12*\n" +
+	"\x10bookingReference\x18\b \x01(\tR\x10bookingReference\x12$\n" +
+	"\rdepartureDate\x18\t \x01(\tR\rdepartureDate\x12@\n" +
+	"\bmetadata\x18\n" +
+	" \x03(\v2$.ticketBooking.Receipt.MetadataEntryR\bmetadata\x12\x12\n" +
+	"\x04fare\x18\v \x01(\x01R\x04fare\x12\x1e\n" +
+	"\n" +
+	"bookingFee\x18\f \x01(\x01R\n" +
+	"bookingFee\x124\n" +
+	"\x06status\x18\r \x01(\x0e2\x1c.ticketBooking.BookingStatusR\x06status\x12\"\n" +
+	"\fpaymentDueAt\x18\x0e \x01(\x03R\fpaymentDueAt\x12\x18\n" +
+	"\atrainId\x18\x0f \x01(\tR\atrainId\x12\x1c\n" +
+	"\tfareClass\x18\x10 \x01(\tR\tfareClass\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"V\n" +
 	"\x04User\x12\x1c\n" +
 	"\tfirstName\x18\x01 \x01(\tR\tfirstName\x12\x1a\n" +
 	"\blastName\x18\x02 \x01(\tR\blastName\x12\x14\n" +
-	"\x05email\x18\x03 \x01(\tR\x05email\")\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\"\x93\x01\n" +
 	"\x11GetReceiptRequest\x12\x14\n" +
-	"\x05email\x18\x01 \x01(\tR\x05email\"F\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\x12*\n" +
+	"\x10bookingReference\x18\x02 \x01(\tR\x10bookingReference\x12<\n" +
+	"\vreceiptMask\x18\x03 \x01(\v2\x1a.google.protobuf.FieldMaskR\vreceiptMask\"F\n" +
 	"\x12GetReceiptResponse\x120\n" +
 	"\areceipt\x18\x01 \x01(\v2\x16.ticketBooking.ReceiptR\areceipt\"W\n" +
 	"\bUserSeat\x12'\n" +
 	"\x04user\x18\x01 \x01(\v2\x13.ticketBooking.UserR\x04user\x12\"\n" +
-	"\fallottedSeat\x18\x02 \x01(\x05R\fallottedSeat\"4\n" +
+	"\fallottedSeat\x18\x02 \x01(\x05R\fallottedSeat\"\x9c\x01\n" +
 	"\x18GetUsersBySectionRequest\x12\x18\n" +
-	"\asection\x18\x01 \x01(\tR\asection\"d\n" +
+	"\asection\x18\x01 \x01(\tR\asection\x12$\n" +
+	"\rdepartureDate\x18\x02 \x01(\tR\rdepartureDate\x12&\n" +
+	"\x0eexcludePending\x18\x03 \x01(\bR\x0eexcludePending\x12\x18\n" +
+	"\atrainId\x18\x04 \x01(\tR\atrainId\"\xa2\x01\n" +
 	"\x19GetUsersBySectionResponse\x12\x18\n" +
 	"\asection\x18\x01 \x01(\tR\asection\x12-\n" +
-	"\x05users\x18\x02 \x03(\v2\x17.ticketBooking.UserSeatR\x05users\"@\n" +
+	"\x05users\x18\x02 \x03(\v2\x17.ticketBooking.UserSeatR\x05users\x12\x1a\n" +
+	"\bmaxSeats\x18\x03 \x01(\x05R\bmaxSeats\x12 \n" +
+	"\vvacantSeats\x18\x04 \x01(\x05R\vvacantSeats\"@\n" +
 	"\x04Seat\x12\x18\n" +
 	"\asection\x18\x01 \x01(\tR\asection\x12\x1e\n" +
 	"\n" +
 	"seatNumber\x18\x02 \x01(\x05R\n" +
-	"seatNumber\")\n" +
+	"seatNumber\"\x86\x01\n" +
 	"\x11RemoveUserRequest\x12\x14\n" +
-	"\x05email\x18\x01 \x01(\tR\x05email\"e\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\x12 \n" +
+	"\vcancelledAt\x18\x02 \x01(\tR\vcancelledAt\x129\n" +
+	"\x06reason\x18\x03 \x01(\x0e2!.ticketBooking.CancellationReasonR\x06reason\"}\n" +
 	"\x12RemoveUserResponse\x12\x18\n" +
 	"\amessage\x18\x01 \x01(\tR\amessage\x125\n" +
-	"\vremovedUser\x18\x02 \x01(\v2\x13.ticketBooking.UserR\vremovedUser\"\\\n" +
+	"\vremovedUser\x18\x02 \x01(\v2\x13.ticketBooking.UserR\vremovedUser\x12\x16\n" +
+	"\x06refund\x18\x03 \x01(\x01R\x06refund\"\x9a\x01\n" +
 	"\x15UpdateUserSeatRequest\x12\x14\n" +
 	"\x05email\x18\x01 \x01(\tR\x05email\x12-\n" +
-	"\anewSeat\x18\x02 \x01(\v2\x13.ticketBooking.SeatR\anewSeat\"r\n" +
+	"\anewSeat\x18\x02 \x01(\v2\x13.ticketBooking.SeatR\anewSeat\x12<\n" +
+	"\vreceiptMask\x18\x03 \x01(\v2\x1a.google.protobuf.FieldMaskR\vreceiptMask\"r\n" +
 	"\x16UpdateUserSeatResponse\x12\x18\n" +
 	"\amessage\x18\x01 \x01(\tR\amessage\x12>\n" +
-	"\x0eupdatedReceipt\x18\x02 \x01(\v2\x16.ticketBooking.ReceiptR\x0eupdatedReceipt2\xec\x03\n" +
+	"\x0eupdatedReceipt\x18\x02 \x01(\v2\x16.ticketBooking.ReceiptR\x0eupdatedReceipt\"B\n" +
+	"\x10SwapSeatsRequest\x12\x16\n" +
+	"\x06emailA\x18\x01 \x01(\tR\x06emailA\x12\x16\n" +
+	"\x06emailB\x18\x02 \x01(\tR\x06emailB\"\x95\x01\n" +
+	"\x11SwapSeatsResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x122\n" +
+	"\breceiptA\x18\x02 \x01(\v2\x16.ticketBooking.ReceiptR\breceiptA\x122\n" +
+	"\breceiptB\x18\x03 \x01(\v2\x16.ticketBooking.ReceiptR\breceiptB\"\x99\x02\n" +
+	"\fReceiptEvent\x123\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x1f.ticketBooking.ReceiptEventTypeR\x04type\x12\x12\n" +
+	"\x04from\x18\x02 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x03 \x01(\tR\x02to\x12'\n" +
+	"\x04seat\x18\x04 \x01(\v2\x13.ticketBooking.SeatR\x04seat\x12\x1c\n" +
+	"\ttimestamp\x18\x05 \x01(\x03R\ttimestamp\x12\x16\n" +
+	"\x06reason\x18\x06 \x01(\tR\x06reason\x12Q\n" +
+	"\x12cancellationReason\x18\a \x01(\x0e2!.ticketBooking.CancellationReasonR\x12cancellationReason\"0\n" +
+	"\x18GetReceiptHistoryRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\"f\n" +
+	"\x19GetReceiptHistoryResponse\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\x123\n" +
+	"\x06events\x18\x02 \x03(\v2\x1b.ticketBooking.ReceiptEventR\x06events\"5\n" +
+	"\x19SetMaintenanceModeRequest\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\"P\n" +
+	"\x1aSetMaintenanceModeResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12\x18\n" +
+	"\aenabled\x18\x02 \x01(\bR\aenabled\"\xb3\x01\n" +
+	"\x11BulkCancelRequest\x12\x18\n" +
+	"\asection\x18\x01 \x01(\tR\asection\x12\x12\n" +
+	"\x04from\x18\x02 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x03 \x01(\tR\x02to\x12 \n" +
+	"\vcancelledAt\x18\x04 \x01(\tR\vcancelledAt\x12\x18\n" +
+	"\atrainId\x18\x05 \x01(\tR\atrainId\x12$\n" +
+	"\rdepartureDate\x18\x06 \x01(\tR\rdepartureDate\"\xa5\x01\n" +
+	"\x12BulkCancelResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12D\n" +
+	"\x11cancelledReceipts\x18\x02 \x03(\v2\x16.ticketBooking.ReceiptR\x11cancelledReceipts\x12/\n" +
+	"\arefunds\x18\x03 \x03(\v2\x15.ticketBooking.RefundR\arefunds\"6\n" +
+	"\x06Refund\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x01R\x06amount\"L\n" +
+	"\x14ResizeSectionRequest\x12\x18\n" +
+	"\asection\x18\x01 \x01(\tR\asection\x12\x1a\n" +
+	"\bmaxSeats\x18\x02 \x01(\x05R\bmaxSeats\"\x89\x01\n" +
+	"\x15ResizeSectionResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12\x18\n" +
+	"\asection\x18\x02 \x01(\tR\asection\x12\x1a\n" +
+	"\bmaxSeats\x18\x03 \x01(\x05R\bmaxSeats\x12 \n" +
+	"\vvacantSeats\x18\x04 \x01(\x05R\vvacantSeats\"\x16\n" +
+	"\x14GetServerInfoRequest\"\xc9\x01\n" +
+	"\x15GetServerInfoResponse\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\tR\aversion\x12\x1c\n" +
+	"\tgitCommit\x18\x02 \x01(\tR\tgitCommit\x12$\n" +
+	"\ruptimeSeconds\x18\x03 \x01(\x03R\ruptimeSeconds\x12(\n" +
+	"\x0fenabledFeatures\x18\x04 \x03(\tR\x0fenabledFeatures\x12(\n" +
+	"\x0fsectionsScanned\x18\x05 \x03(\x03R\x0fsectionsScanned\"<\n" +
+	"\x16GetUsersByRouteRequest\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\tR\x02to\"]\n" +
+	"\tRouteUser\x12'\n" +
+	"\x04user\x18\x01 \x01(\v2\x13.ticketBooking.UserR\x04user\x12'\n" +
+	"\x04seat\x18\x02 \x01(\v2\x13.ticketBooking.SeatR\x04seat\"m\n" +
+	"\x17GetUsersByRouteResponse\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\tR\x02to\x12.\n" +
+	"\x05users\x18\x03 \x03(\v2\x18.ticketBooking.RouteUserR\x05users\"\x8c\x01\n" +
+	"\x10BlockSeatRequest\x12\x18\n" +
+	"\asection\x18\x01 \x01(\tR\asection\x12\x1e\n" +
+	"\n" +
+	"seatNumber\x18\x02 \x01(\x05R\n" +
+	"seatNumber\x12\x18\n" +
+	"\atrainId\x18\x03 \x01(\tR\atrainId\x12$\n" +
+	"\rdepartureDate\x18\x04 \x01(\tR\rdepartureDate\"x\n" +
+	"\x11BlockSeatResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12'\n" +
+	"\x04seat\x18\x02 \x01(\v2\x13.ticketBooking.SeatR\x04seat\x12 \n" +
+	"\vvacantSeats\x18\x03 \x01(\x05R\vvacantSeats\"\x8e\x01\n" +
+	"\x12UnblockSeatRequest\x12\x18\n" +
+	"\asection\x18\x01 \x01(\tR\asection\x12\x1e\n" +
+	"\n" +
+	"seatNumber\x18\x02 \x01(\x05R\n" +
+	"seatNumber\x12\x18\n" +
+	"\atrainId\x18\x03 \x01(\tR\atrainId\x12$\n" +
+	"\rdepartureDate\x18\x04 \x01(\tR\rdepartureDate\"z\n" +
+	"\x13UnblockSeatResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12'\n" +
+	"\x04seat\x18\x02 \x01(\v2\x13.ticketBooking.SeatR\x04seat\x12 \n" +
+	"\vvacantSeats\x18\x03 \x01(\x05R\vvacantSeats\"g\n" +
+	"\rVerifyRequest\x12\x16\n" +
+	"\x06repair\x18\x01 \x01(\bR\x06repair\x12\x18\n" +
+	"\atrainId\x18\x02 \x01(\tR\atrainId\x12$\n" +
+	"\rdepartureDate\x18\x03 \x01(\tR\rdepartureDate\"\xb0\x01\n" +
+	"\x10SectionIntegrity\x12\x18\n" +
+	"\asection\x18\x01 \x01(\tR\asection\x12\"\n" +
+	"\fmissingSeats\x18\x02 \x03(\x05R\fmissingSeats\x120\n" +
+	"\x13recordedVacantSeats\x18\x03 \x01(\x05R\x13recordedVacantSeats\x12,\n" +
+	"\x11actualVacantSeats\x18\x04 \x01(\x05R\x11actualVacantSeats\"\x9d\x01\n" +
+	"\x0eVerifyResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12\x18\n" +
+	"\ahealthy\x18\x02 \x01(\bR\ahealthy\x12;\n" +
+	"\bsections\x18\x03 \x03(\v2\x1f.ticketBooking.SectionIntegrityR\bsections\x12\x1a\n" +
+	"\brepaired\x18\x04 \x01(\bR\brepaired\"\x8a\x01\n" +
+	"\x0eGetSeatRequest\x12\x18\n" +
+	"\asection\x18\x01 \x01(\tR\asection\x12\x1e\n" +
+	"\n" +
+	"seatNumber\x18\x02 \x01(\x05R\n" +
+	"seatNumber\x12\x18\n" +
+	"\atrainId\x18\x03 \x01(\tR\atrainId\x12$\n" +
+	"\rdepartureDate\x18\x04 \x01(\tR\rdepartureDate\"\xe1\x01\n" +
+	"\x0fGetSeatResponse\x12'\n" +
+	"\x04seat\x18\x01 \x01(\v2\x13.ticketBooking.SeatR\x04seat\x127\n" +
+	"\bposition\x18\x02 \x01(\x0e2\x1b.ticketBooking.SeatPositionR\bposition\x12\x1c\n" +
+	"\tavailable\x18\x03 \x01(\bR\tavailable\x12\x18\n" +
+	"\ablocked\x18\x04 \x01(\bR\ablocked\x124\n" +
+	"\toccupants\x18\x05 \x03(\v2\x16.ticketBooking.ReceiptR\toccupants\"D\n" +
+	"\x18GetCheapestRoutesRequest\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\tR\x04from\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"m\n" +
+	"\tRouteFare\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\tR\x02to\x12\x14\n" +
+	"\x05price\x18\x03 \x01(\x01R\x05price\x12&\n" +
+	"\x0eavailableSeats\x18\x04 \x01(\x05R\x0eavailableSeats\"M\n" +
+	"\x19GetCheapestRoutesResponse\x120\n" +
+	"\x06routes\x18\x01 \x03(\v2\x18.ticketBooking.RouteFareR\x06routes\"t\n" +
+	"\x18GetSectionVacancyRequest\x12\x18\n" +
+	"\asection\x18\x01 \x01(\tR\asection\x12\x18\n" +
+	"\atrainId\x18\x02 \x01(\tR\atrainId\x12$\n" +
+	"\rdepartureDate\x18\x03 \x01(\tR\rdepartureDate\"Y\n" +
+	"\x19GetSectionVacancyResponse\x12 \n" +
+	"\vvacantSeats\x18\x01 \x01(\x05R\vvacantSeats\x12\x1a\n" +
+	"\bmaxSeats\x18\x02 \x01(\x05R\bmaxSeats\"\x12\n" +
+	"\x10GetConfigRequest\"+\n" +
+	"\x11GetConfigResponse\x12\x16\n" +
+	"\x06config\x18\x01 \x01(\tR\x06config\"W\n" +
+	"\x15GetAllSectionsRequest\x12\x18\n" +
+	"\atrainId\x18\x01 \x01(\tR\atrainId\x12$\n" +
+	"\rdepartureDate\x18\x02 \x01(\tR\rdepartureDate\"\x85\x02\n" +
+	"\vSectionInfo\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1a\n" +
+	"\bmaxSeats\x18\x02 \x01(\x05R\bmaxSeats\x12 \n" +
+	"\vvacantSeats\x18\x03 \x01(\x05R\vvacantSeats\x12\"\n" +
+	"\fblockedSeats\x18\x04 \x01(\x05R\fblockedSeats\x12&\n" +
+	"\x0eoverflowPolicy\x18\x05 \x01(\tR\x0eoverflowPolicy\x12\x1c\n" +
+	"\tisDefault\x18\x06 \x01(\bR\tisDefault\x12\x1e\n" +
+	"\n" +
+	"restricted\x18\a \x01(\bR\n" +
+	"restricted\x12\x1a\n" +
+	"\bquiesced\x18\b \x01(\bR\bquiesced\"P\n" +
+	"\x16GetAllSectionsResponse\x126\n" +
+	"\bsections\x18\x01 \x03(\v2\x1a.ticketBooking.SectionInfoR\bsections\"\x88\x01\n" +
+	"\x1cGetReceiptByReferenceRequest\x12*\n" +
+	"\x10bookingReference\x18\x01 \x01(\tR\x10bookingReference\x12<\n" +
+	"\vreceiptMask\x18\x02 \x01(\v2\x1a.google.protobuf.FieldMaskR\vreceiptMask\"Q\n" +
+	"\x1dGetReceiptByReferenceResponse\x120\n" +
+	"\areceipt\x18\x01 \x01(\v2\x16.ticketBooking.ReceiptR\areceipt\".\n" +
+	"\x16GetWaitEstimateRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\"\xa5\x01\n" +
+	"\x17GetWaitEstimateResponse\x12\x18\n" +
+	"\asection\x18\x01 \x01(\tR\asection\x12\x1a\n" +
+	"\bposition\x18\x02 \x01(\x05R\bposition\x12 \n" +
+	"\vhasEstimate\x18\x03 \x01(\bR\vhasEstimate\x122\n" +
+	"\x14estimatedWaitSeconds\x18\x04 \x01(\x03R\x14estimatedWaitSeconds\"G\n" +
+	"\x19GetAssignmentTraceRequest\x12*\n" +
+	"\x10bookingReference\x18\x01 \x01(\tR\x10bookingReference\"\x90\x01\n" +
+	"\x1aGetAssignmentTraceResponse\x12\x1a\n" +
+	"\bstrategy\x18\x01 \x01(\tR\bstrategy\x12,\n" +
+	"\x11preferenceHonored\x18\x02 \x01(\bR\x11preferenceHonored\x12(\n" +
+	"\x0fskippedSections\x18\x03 \x01(\x05R\x0fskippedSections\"n\n" +
+	"\x12GetManifestRequest\x12\x18\n" +
+	"\asection\x18\x01 \x01(\tR\asection\x12$\n" +
+	"\rdepartureDate\x18\x02 \x01(\tR\rdepartureDate\x12\x18\n" +
+	"\atrainId\x18\x03 \x01(\tR\atrainId\"\xcf\x01\n" +
+	"\rManifestEntry\x12'\n" +
+	"\x04seat\x18\x01 \x01(\v2\x13.ticketBooking.SeatR\x04seat\x12'\n" +
+	"\x04user\x18\x02 \x01(\v2\x13.ticketBooking.UserR\x04user\x12\x12\n" +
+	"\x04from\x18\x03 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x04 \x01(\tR\x02to\x12\x1c\n" +
+	"\tpricePaid\x18\x05 \x01(\x01R\tpricePaid\x12*\n" +
+	"\x10bookingReference\x18\x06 \x01(\tR\x10bookingReference\"\xbd\x01\n" +
+	"\x13GetManifestResponse\x126\n" +
+	"\aentries\x18\x01 \x03(\v2\x1c.ticketBooking.ManifestEntryR\aentries\x12(\n" +
+	"\x0ftotalPassengers\x18\x02 \x01(\x05R\x0ftotalPassengers\x12$\n" +
+	"\roccupiedSeats\x18\x03 \x01(\x05R\roccupiedSeats\x12\x1e\n" +
+	"\n" +
+	"totalFares\x18\x04 \x01(\x01R\n" +
+	"totalFares\"\x97\x01\n" +
+	"\x1fListReceiptsByPriceRangeRequest\x12\x1a\n" +
+	"\bminPrice\x18\x01 \x01(\x01R\bminPrice\x12\x1a\n" +
+	"\bmaxPrice\x18\x02 \x01(\x01R\bmaxPrice\x12<\n" +
+	"\vreceiptMask\x18\x03 \x01(\v2\x1a.google.protobuf.FieldMaskR\vreceiptMask\"V\n" +
+	" ListReceiptsByPriceRangeResponse\x122\n" +
+	"\breceipts\x18\x01 \x03(\v2\x16.ticketBooking.ReceiptR\breceipts\"t\n" +
+	"\x18WatchAvailabilityRequest\x12\x18\n" +
+	"\asection\x18\x01 \x01(\tR\asection\x12$\n" +
+	"\rdepartureDate\x18\x02 \x01(\tR\rdepartureDate\x12\x18\n" +
+	"\atrainId\x18\x03 \x01(\tR\atrainId\"m\n" +
+	"\x13SectionAvailability\x12\x18\n" +
+	"\asection\x18\x01 \x01(\tR\asection\x12 \n" +
+	"\vvacantSeats\x18\x02 \x01(\x05R\vvacantSeats\x12\x1a\n" +
+	"\bmaxSeats\x18\x03 \x01(\x05R\bmaxSeats\"[\n" +
+	"\x19WatchAvailabilityResponse\x12>\n" +
+	"\bsections\x18\x01 \x03(\v2\".ticketBooking.SectionAvailabilityR\bsections\"\x8a\x01\n" +
+	"\x14ForceReassignRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\x12$\n" +
+	"\rtargetSection\x18\x02 \x01(\tR\rtargetSection\x12\x1e\n" +
+	"\n" +
+	"targetSeat\x18\x03 \x01(\x05R\n" +
+	"targetSeat\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\"q\n" +
+	"\x15ForceReassignResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12>\n" +
+	"\x0eupdatedReceipt\x18\x02 \x01(\v2\x16.ticketBooking.ReceiptR\x0eupdatedReceipt\"\x81\x01\n" +
+	"\x15ConfirmPaymentRequest\x12*\n" +
+	"\x10bookingReference\x18\x01 \x01(\tR\x10bookingReference\x12<\n" +
+	"\vreceiptMask\x18\x02 \x01(\v2\x1a.google.protobuf.FieldMaskR\vreceiptMask\"d\n" +
+	"\x16ConfirmPaymentResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x120\n" +
+	"\areceipt\x18\x02 \x01(\v2\x16.ticketBooking.ReceiptR\areceipt\"\xe3\x01\n" +
+	"\bSeatHold\x12\x16\n" +
+	"\x06holdId\x18\x01 \x01(\tR\x06holdId\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\x12'\n" +
+	"\x04seat\x18\x03 \x01(\v2\x13.ticketBooking.SeatR\x04seat\x12\x12\n" +
+	"\x04from\x18\x04 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x05 \x01(\tR\x02to\x12$\n" +
+	"\rdepartureDate\x18\x06 \x01(\tR\rdepartureDate\x12\x1c\n" +
+	"\texpiresAt\x18\a \x01(\x03R\texpiresAt\x12\x18\n" +
+	"\atrainId\x18\b \x01(\tR\atrainId\"\xb4\x01\n" +
+	"\x0fHoldSeatRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\x12\x12\n" +
+	"\x04from\x18\x02 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x03 \x01(\tR\x02to\x12'\n" +
+	"\x04seat\x18\x04 \x01(\v2\x13.ticketBooking.SeatR\x04seat\x12$\n" +
+	"\rdepartureDate\x18\x05 \x01(\tR\rdepartureDate\x12\x18\n" +
+	"\atrainId\x18\x06 \x01(\tR\atrainId\"Y\n" +
+	"\x10HoldSeatResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12+\n" +
+	"\x04hold\x18\x02 \x01(\v2\x17.ticketBooking.SeatHoldR\x04hold\"B\n" +
+	"\x12ReleaseHoldRequest\x12\x16\n" +
+	"\x06holdId\x18\x01 \x01(\tR\x06holdId\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\"/\n" +
+	"\x13ReleaseHoldResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\"?\n" +
+	"\x17GetExpiringHoldsRequest\x12$\n" +
+	"\rwithinSeconds\x18\x01 \x01(\x05R\rwithinSeconds\"\xc1\x01\n" +
+	"\fExpiringHold\x12'\n" +
+	"\x04seat\x18\x01 \x01(\v2\x13.ticketBooking.SeatR\x04seat\x12$\n" +
+	"\rdepartureDate\x18\x02 \x01(\tR\rdepartureDate\x12\x1c\n" +
+	"\texpiresAt\x18\x03 \x01(\x03R\texpiresAt\x12*\n" +
+	"\x10remainingSeconds\x18\x04 \x01(\x05R\x10remainingSeconds\x12\x18\n" +
+	"\atrainId\x18\x05 \x01(\tR\atrainId\"M\n" +
+	"\x18GetExpiringHoldsResponse\x121\n" +
+	"\x05holds\x18\x01 \x03(\v2\x1b.ticketBooking.ExpiringHoldR\x05holds\"M\n" +
+	"\x17GetBookingTrendsRequest\x122\n" +
+	"\x06bucket\x18\x01 \x01(\x0e2\x1a.ticketBooking.TrendBucketR\x06bucket\"l\n" +
+	"\fBookingTrend\x12\x1a\n" +
+	"\bstartsAt\x18\x01 \x01(\x03R\bstartsAt\x12\x1a\n" +
+	"\bbookings\x18\x02 \x01(\x05R\bbookings\x12$\n" +
+	"\rcancellations\x18\x03 \x01(\x05R\rcancellations\"Q\n" +
+	"\x18GetBookingTrendsResponse\x125\n" +
+	"\abuckets\x18\x01 \x03(\v2\x1b.ticketBooking.BookingTrendR\abuckets\"K\n" +
+	"\x15ImportReceiptsRequest\x122\n" +
+	"\breceipts\x18\x01 \x03(\v2\x16.ticketBooking.ReceiptR\breceipts\"S\n" +
+	"\vImportError\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"\xaa\x01\n" +
+	"\x16ImportReceiptsResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12B\n" +
+	"\x10importedReceipts\x18\x02 \x03(\v2\x16.ticketBooking.ReceiptR\x10importedReceipts\x122\n" +
+	"\x06errors\x18\x03 \x03(\v2\x1a.ticketBooking.ImportErrorR\x06errors\"\x1d\n" +
+	"\x1bGetCancellationStatsRequest\"d\n" +
+	"\x11CancellationCount\x129\n" +
+	"\x06reason\x18\x01 \x01(\x0e2!.ticketBooking.CancellationReasonR\x06reason\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\"n\n" +
+	"\x1cGetCancellationStatsResponse\x128\n" +
+	"\x06counts\x18\x01 \x03(\v2 .ticketBooking.CancellationCountR\x06counts\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\"\xb2\x01\n" +
+	"\x1eGetNearbyAvailableSeatsRequest\x12\x18\n" +
+	"\asection\x18\x01 \x01(\tR\asection\x12\x1e\n" +
+	"\n" +
+	"seatNumber\x18\x02 \x01(\x05R\n" +
+	"seatNumber\x12\x16\n" +
+	"\x06radius\x18\x03 \x01(\x05R\x06radius\x12\x18\n" +
+	"\atrainId\x18\x04 \x01(\tR\atrainId\x12$\n" +
+	"\rdepartureDate\x18\x05 \x01(\tR\rdepartureDate\"L\n" +
+	"\x1fGetNearbyAvailableSeatsResponse\x12)\n" +
+	"\x05seats\x18\x01 \x03(\v2\x13.ticketBooking.SeatR\x05seats\"1\n" +
+	"\x15QuiesceSectionRequest\x12\x18\n" +
+	"\asection\x18\x01 \x01(\tR\asection\"r\n" +
+	"\x16QuiesceSectionResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12\x18\n" +
+	"\asection\x18\x02 \x01(\tR\asection\x12$\n" +
+	"\roccupiedSeats\x18\x03 \x01(\x05R\roccupiedSeats\"0\n" +
+	"\x14ResumeSectionRequest\x12\x18\n" +
+	"\asection\x18\x01 \x01(\tR\asection\"K\n" +
+	"\x15ResumeSectionResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12\x18\n" +
+	"\asection\x18\x02 \x01(\tR\asection\"\x19\n" +
+	"\x17GetConfigChangesRequest\"\x93\x01\n" +
+	"\fConfigChange\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x127\n" +
+	"\x06change\x18\x02 \x01(\x0e2\x1f.ticketBooking.ConfigChangeTypeR\x06change\x12\x1a\n" +
+	"\boldValue\x18\x03 \x01(\tR\boldValue\x12\x1a\n" +
+	"\bnewValue\x18\x04 \x01(\tR\bnewValue\"q\n" +
+	"\x18GetConfigChangesResponse\x125\n" +
+	"\achanges\x18\x01 \x03(\v2\x1b.ticketBooking.ConfigChangeR\achanges\x12\x1e\n" +
+	"\n" +
+	"reloadedAt\x18\x02 \x01(\x03R\n" +
+	"reloadedAt\"\x8f\x01\n" +
+	"\x15ChangeStationsRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\x12\x12\n" +
+	"\x04from\x18\x02 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x03 \x01(\tR\x02to\x12<\n" +
+	"\vreceiptMask\x18\x04 \x01(\v2\x1a.google.protobuf.FieldMaskR\vreceiptMask\"r\n" +
+	"\x16ChangeStationsResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12>\n" +
+	"\x0eupdatedReceipt\x18\x02 \x01(\v2\x16.ticketBooking.ReceiptR\x0eupdatedReceipt*}\n" +
+	"\vPairSeating\x12\x1c\n" +
+	"\x18PAIR_SEATING_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15PAIR_SEATING_ADJACENT\x10\x01\x12\x1d\n" +
+	"\x19PAIR_SEATING_SAME_SECTION\x10\x02\x12\x16\n" +
+	"\x12PAIR_SEATING_SPLIT\x10\x03*Q\n" +
+	"\rBookingStatus\x12\x1c\n" +
+	"\x18BOOKING_STATUS_CONFIRMED\x10\x00\x12\"\n" +
+	"\x1eBOOKING_STATUS_PENDING_PAYMENT\x10\x01*\xfc\x01\n" +
+	"\x12CancellationReason\x12#\n" +
+	"\x1fCANCELLATION_REASON_UNSPECIFIED\x10\x00\x12&\n" +
+	"\"CANCELLATION_REASON_USER_REQUESTED\x10\x01\x12'\n" +
+	"#CANCELLATION_REASON_TRAIN_CANCELLED\x10\x02\x12&\n" +
+	"\"CANCELLATION_REASON_PAYMENT_FAILED\x10\x03\x12)\n" +
+	"%CANCELLATION_REASON_DUPLICATE_BOOKING\x10\x04\x12\x1d\n" +
+	"\x19CANCELLATION_REASON_OTHER\x10\x05*\xf2\x01\n" +
+	"\x10ReceiptEventType\x12\x1d\n" +
+	"\x19RECEIPT_EVENT_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14RECEIPT_EVENT_BOOKED\x10\x01\x12\x1e\n" +
+	"\x1aRECEIPT_EVENT_SEAT_CHANGED\x10\x02\x12\x1b\n" +
+	"\x17RECEIPT_EVENT_CANCELLED\x10\x03\x12\"\n" +
+	"\x1eRECEIPT_EVENT_FORCE_REASSIGNED\x10\x04\x12#\n" +
+	"\x1fRECEIPT_EVENT_PAYMENT_CONFIRMED\x10\x05\x12\x1f\n" +
+	"\x1bRECEIPT_EVENT_ROUTE_CHANGED\x10\x06*z\n" +
+	"\fSeatPosition\x12\x1d\n" +
+	"\x19SEAT_POSITION_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14SEAT_POSITION_WINDOW\x10\x01\x12\x17\n" +
+	"\x13SEAT_POSITION_AISLE\x10\x02\x12\x18\n" +
+	"\x14SEAT_POSITION_MIDDLE\x10\x03*[\n" +
+	"\vTrendBucket\x12\x1c\n" +
+	"\x18TREND_BUCKET_UNSPECIFIED\x10\x00\x12\x17\n" +
+	"\x13TREND_BUCKET_MINUTE\x10\x01\x12\x15\n" +
+	"\x11TREND_BUCKET_HOUR\x10\x02*\x94\x01\n" +
+	"\x10ConfigChangeType\x12\"\n" +
+	"\x1eCONFIG_CHANGE_TYPE_UNSPECIFIED\x10\x00\x12\x1c\n" +
+	"\x18CONFIG_CHANGE_TYPE_ADDED\x10\x01\x12\x1e\n" +
+	"\x1aCONFIG_CHANGE_TYPE_REMOVED\x10\x02\x12\x1e\n" +
+	"\x1aCONFIG_CHANGE_TYPE_CHANGED\x10\x032\xe5\x1d\n" +
 	"\x14TicketBookingService\x12_\n" +
 	"\x0ePurchaseTicket\x12$.ticketBooking.PurchaseTicketRequest\x1a%.ticketBooking.PurchaseTicketResponse\"\x00\x12S\n" +
 	"\n" +
@@ -819,7 +6626,42 @@ const file_proto_ticketBooking_proto_rawDesc = "" +
 	"\x11GetUsersBySection\x12'.ticketBooking.GetUsersBySectionRequest\x1a(.ticketBooking.GetUsersBySectionResponse\"\x00\x12S\n" +
 	"\n" +
 	"RemoveUser\x12 .ticketBooking.RemoveUserRequest\x1a!.ticketBooking.RemoveUserResponse\"\x00\x12_\n" +
-	"\x0eUpdateUserSeat\x12$.ticketBooking.UpdateUserSeatRequest\x1a%.ticketBooking.UpdateUserSeatResponse\"\x00B,Z*github.com/sanjaykishor/rail-connect/protob\x06proto3"
+	"\x0eUpdateUserSeat\x12$.ticketBooking.UpdateUserSeatRequest\x1a%.ticketBooking.UpdateUserSeatResponse\"\x00\x12P\n" +
+	"\tSwapSeats\x12\x1f.ticketBooking.SwapSeatsRequest\x1a .ticketBooking.SwapSeatsResponse\"\x00\x12h\n" +
+	"\x11GetReceiptHistory\x12'.ticketBooking.GetReceiptHistoryRequest\x1a(.ticketBooking.GetReceiptHistoryResponse\"\x00\x12k\n" +
+	"\x12SetMaintenanceMode\x12(.ticketBooking.SetMaintenanceModeRequest\x1a).ticketBooking.SetMaintenanceModeResponse\"\x00\x12S\n" +
+	"\n" +
+	"BulkCancel\x12 .ticketBooking.BulkCancelRequest\x1a!.ticketBooking.BulkCancelResponse\"\x00\x12\\\n" +
+	"\rResizeSection\x12#.ticketBooking.ResizeSectionRequest\x1a$.ticketBooking.ResizeSectionResponse\"\x00\x12\\\n" +
+	"\rGetServerInfo\x12#.ticketBooking.GetServerInfoRequest\x1a$.ticketBooking.GetServerInfoResponse\"\x00\x12b\n" +
+	"\x0fGetUsersByRoute\x12%.ticketBooking.GetUsersByRouteRequest\x1a&.ticketBooking.GetUsersByRouteResponse\"\x00\x12P\n" +
+	"\tBlockSeat\x12\x1f.ticketBooking.BlockSeatRequest\x1a .ticketBooking.BlockSeatResponse\"\x00\x12V\n" +
+	"\vUnblockSeat\x12!.ticketBooking.UnblockSeatRequest\x1a\".ticketBooking.UnblockSeatResponse\"\x00\x12G\n" +
+	"\x06Verify\x12\x1c.ticketBooking.VerifyRequest\x1a\x1d.ticketBooking.VerifyResponse\"\x00\x12J\n" +
+	"\aGetSeat\x12\x1d.ticketBooking.GetSeatRequest\x1a\x1e.ticketBooking.GetSeatResponse\"\x00\x12h\n" +
+	"\x11GetCheapestRoutes\x12'.ticketBooking.GetCheapestRoutesRequest\x1a(.ticketBooking.GetCheapestRoutesResponse\"\x00\x12h\n" +
+	"\x11GetSectionVacancy\x12'.ticketBooking.GetSectionVacancyRequest\x1a(.ticketBooking.GetSectionVacancyResponse\"\x00\x12P\n" +
+	"\tGetConfig\x12\x1f.ticketBooking.GetConfigRequest\x1a .ticketBooking.GetConfigResponse\"\x00\x12_\n" +
+	"\x0eGetAllSections\x12$.ticketBooking.GetAllSectionsRequest\x1a%.ticketBooking.GetAllSectionsResponse\"\x00\x12t\n" +
+	"\x15GetReceiptByReference\x12+.ticketBooking.GetReceiptByReferenceRequest\x1a,.ticketBooking.GetReceiptByReferenceResponse\"\x00\x12b\n" +
+	"\x0fGetWaitEstimate\x12%.ticketBooking.GetWaitEstimateRequest\x1a&.ticketBooking.GetWaitEstimateResponse\"\x00\x12k\n" +
+	"\x12GetAssignmentTrace\x12(.ticketBooking.GetAssignmentTraceRequest\x1a).ticketBooking.GetAssignmentTraceResponse\"\x00\x12V\n" +
+	"\vGetManifest\x12!.ticketBooking.GetManifestRequest\x1a\".ticketBooking.GetManifestResponse\"\x00\x12}\n" +
+	"\x18ListReceiptsByPriceRange\x12..ticketBooking.ListReceiptsByPriceRangeRequest\x1a/.ticketBooking.ListReceiptsByPriceRangeResponse\"\x00\x12j\n" +
+	"\x11WatchAvailability\x12'.ticketBooking.WatchAvailabilityRequest\x1a(.ticketBooking.WatchAvailabilityResponse\"\x000\x01\x12\\\n" +
+	"\rForceReassign\x12#.ticketBooking.ForceReassignRequest\x1a$.ticketBooking.ForceReassignResponse\"\x00\x12_\n" +
+	"\x0eConfirmPayment\x12$.ticketBooking.ConfirmPaymentRequest\x1a%.ticketBooking.ConfirmPaymentResponse\"\x00\x12M\n" +
+	"\bHoldSeat\x12\x1e.ticketBooking.HoldSeatRequest\x1a\x1f.ticketBooking.HoldSeatResponse\"\x00\x12V\n" +
+	"\vReleaseHold\x12!.ticketBooking.ReleaseHoldRequest\x1a\".ticketBooking.ReleaseHoldResponse\"\x00\x12e\n" +
+	"\x10GetExpiringHolds\x12&.ticketBooking.GetExpiringHoldsRequest\x1a'.ticketBooking.GetExpiringHoldsResponse\"\x00\x12e\n" +
+	"\x10GetBookingTrends\x12&.ticketBooking.GetBookingTrendsRequest\x1a'.ticketBooking.GetBookingTrendsResponse\"\x00\x12_\n" +
+	"\x0eImportReceipts\x12$.ticketBooking.ImportReceiptsRequest\x1a%.ticketBooking.ImportReceiptsResponse\"\x00\x12q\n" +
+	"\x14GetCancellationStats\x12*.ticketBooking.GetCancellationStatsRequest\x1a+.ticketBooking.GetCancellationStatsResponse\"\x00\x12z\n" +
+	"\x17GetNearbyAvailableSeats\x12-.ticketBooking.GetNearbyAvailableSeatsRequest\x1a..ticketBooking.GetNearbyAvailableSeatsResponse\"\x00\x12_\n" +
+	"\x0eQuiesceSection\x12$.ticketBooking.QuiesceSectionRequest\x1a%.ticketBooking.QuiesceSectionResponse\"\x00\x12\\\n" +
+	"\rResumeSection\x12#.ticketBooking.ResumeSectionRequest\x1a$.ticketBooking.ResumeSectionResponse\"\x00\x12e\n" +
+	"\x10GetConfigChanges\x12&.ticketBooking.GetConfigChangesRequest\x1a'.ticketBooking.GetConfigChangesResponse\"\x00\x12_\n" +
+	"\x0eChangeStations\x12$.ticketBooking.ChangeStationsRequest\x1a%.ticketBooking.ChangeStationsResponse\"\x00B,Z*github.com/sanjaykishor/rail-connect/protob\x06proto3"
 
 var (
 	file_proto_ticketBooking_proto_rawDescOnce sync.Once
@@ -833,49 +6675,267 @@ func file_proto_ticketBooking_proto_rawDescGZIP() []byte {
 	return file_proto_ticketBooking_proto_rawDescData
 }
 
-var file_proto_ticketBooking_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_proto_ticketBooking_proto_enumTypes = make([]protoimpl.EnumInfo, 7)
+var file_proto_ticketBooking_proto_msgTypes = make([]protoimpl.MessageInfo, 98)
 var file_proto_ticketBooking_proto_goTypes = []any{
-	(*PurchaseTicketRequest)(nil),     // 0: ticketBooking.PurchaseTicketRequest
-	(*PurchaseTicketResponse)(nil),    // 1: ticketBooking.PurchaseTicketResponse
-	(*Receipt)(nil),                   // 2: ticketBooking.Receipt
-	(*User)(nil),                      // 3: ticketBooking.User
-	(*GetReceiptRequest)(nil),         // 4: ticketBooking.GetReceiptRequest
-	(*GetReceiptResponse)(nil),        // 5: ticketBooking.GetReceiptResponse
-	(*UserSeat)(nil),                  // 6: ticketBooking.UserSeat
-	(*GetUsersBySectionRequest)(nil),  // 7: ticketBooking.GetUsersBySectionRequest
-	(*GetUsersBySectionResponse)(nil), // 8: ticketBooking.GetUsersBySectionResponse
-	(*Seat)(nil),                      // 9: ticketBooking.Seat
-	(*RemoveUserRequest)(nil),         // 10: ticketBooking.RemoveUserRequest
-	(*RemoveUserResponse)(nil),        // 11: ticketBooking.RemoveUserResponse
-	(*UpdateUserSeatRequest)(nil),     // 12: ticketBooking.UpdateUserSeatRequest
-	(*UpdateUserSeatResponse)(nil),    // 13: ticketBooking.UpdateUserSeatResponse
+	(PairSeating)(0),                         // 0: ticketBooking.PairSeating
+	(BookingStatus)(0),                       // 1: ticketBooking.BookingStatus
+	(CancellationReason)(0),                  // 2: ticketBooking.CancellationReason
+	(ReceiptEventType)(0),                    // 3: ticketBooking.ReceiptEventType
+	(SeatPosition)(0),                        // 4: ticketBooking.SeatPosition
+	(TrendBucket)(0),                         // 5: ticketBooking.TrendBucket
+	(ConfigChangeType)(0),                    // 6: ticketBooking.ConfigChangeType
+	(*PurchaseTicketRequest)(nil),            // 7: ticketBooking.PurchaseTicketRequest
+	(*PurchaseTicketResponse)(nil),           // 8: ticketBooking.PurchaseTicketResponse
+	(*Receipt)(nil),                          // 9: ticketBooking.Receipt
+	(*User)(nil),                             // 10: ticketBooking.User
+	(*GetReceiptRequest)(nil),                // 11: ticketBooking.GetReceiptRequest
+	(*GetReceiptResponse)(nil),               // 12: ticketBooking.GetReceiptResponse
+	(*UserSeat)(nil),                         // 13: ticketBooking.UserSeat
+	(*GetUsersBySectionRequest)(nil),         // 14: ticketBooking.GetUsersBySectionRequest
+	(*GetUsersBySectionResponse)(nil),        // 15: ticketBooking.GetUsersBySectionResponse
+	(*Seat)(nil),                             // 16: ticketBooking.Seat
+	(*RemoveUserRequest)(nil),                // 17: ticketBooking.RemoveUserRequest
+	(*RemoveUserResponse)(nil),               // 18: ticketBooking.RemoveUserResponse
+	(*UpdateUserSeatRequest)(nil),            // 19: ticketBooking.UpdateUserSeatRequest
+	(*UpdateUserSeatResponse)(nil),           // 20: ticketBooking.UpdateUserSeatResponse
+	(*SwapSeatsRequest)(nil),                 // 21: ticketBooking.SwapSeatsRequest
+	(*SwapSeatsResponse)(nil),                // 22: ticketBooking.SwapSeatsResponse
+	(*ReceiptEvent)(nil),                     // 23: ticketBooking.ReceiptEvent
+	(*GetReceiptHistoryRequest)(nil),         // 24: ticketBooking.GetReceiptHistoryRequest
+	(*GetReceiptHistoryResponse)(nil),        // 25: ticketBooking.GetReceiptHistoryResponse
+	(*SetMaintenanceModeRequest)(nil),        // 26: ticketBooking.SetMaintenanceModeRequest
+	(*SetMaintenanceModeResponse)(nil),       // 27: ticketBooking.SetMaintenanceModeResponse
+	(*BulkCancelRequest)(nil),                // 28: ticketBooking.BulkCancelRequest
+	(*BulkCancelResponse)(nil),               // 29: ticketBooking.BulkCancelResponse
+	(*Refund)(nil),                           // 30: ticketBooking.Refund
+	(*ResizeSectionRequest)(nil),             // 31: ticketBooking.ResizeSectionRequest
+	(*ResizeSectionResponse)(nil),            // 32: ticketBooking.ResizeSectionResponse
+	(*GetServerInfoRequest)(nil),             // 33: ticketBooking.GetServerInfoRequest
+	(*GetServerInfoResponse)(nil),            // 34: ticketBooking.GetServerInfoResponse
+	(*GetUsersByRouteRequest)(nil),           // 35: ticketBooking.GetUsersByRouteRequest
+	(*RouteUser)(nil),                        // 36: ticketBooking.RouteUser
+	(*GetUsersByRouteResponse)(nil),          // 37: ticketBooking.GetUsersByRouteResponse
+	(*BlockSeatRequest)(nil),                 // 38: ticketBooking.BlockSeatRequest
+	(*BlockSeatResponse)(nil),                // 39: ticketBooking.BlockSeatResponse
+	(*UnblockSeatRequest)(nil),               // 40: ticketBooking.UnblockSeatRequest
+	(*UnblockSeatResponse)(nil),              // 41: ticketBooking.UnblockSeatResponse
+	(*VerifyRequest)(nil),                    // 42: ticketBooking.VerifyRequest
+	(*SectionIntegrity)(nil),                 // 43: ticketBooking.SectionIntegrity
+	(*VerifyResponse)(nil),                   // 44: ticketBooking.VerifyResponse
+	(*GetSeatRequest)(nil),                   // 45: ticketBooking.GetSeatRequest
+	(*GetSeatResponse)(nil),                  // 46: ticketBooking.GetSeatResponse
+	(*GetCheapestRoutesRequest)(nil),         // 47: ticketBooking.GetCheapestRoutesRequest
+	(*RouteFare)(nil),                        // 48: ticketBooking.RouteFare
+	(*GetCheapestRoutesResponse)(nil),        // 49: ticketBooking.GetCheapestRoutesResponse
+	(*GetSectionVacancyRequest)(nil),         // 50: ticketBooking.GetSectionVacancyRequest
+	(*GetSectionVacancyResponse)(nil),        // 51: ticketBooking.GetSectionVacancyResponse
+	(*GetConfigRequest)(nil),                 // 52: ticketBooking.GetConfigRequest
+	(*GetConfigResponse)(nil),                // 53: ticketBooking.GetConfigResponse
+	(*GetAllSectionsRequest)(nil),            // 54: ticketBooking.GetAllSectionsRequest
+	(*SectionInfo)(nil),                      // 55: ticketBooking.SectionInfo
+	(*GetAllSectionsResponse)(nil),           // 56: ticketBooking.GetAllSectionsResponse
+	(*GetReceiptByReferenceRequest)(nil),     // 57: ticketBooking.GetReceiptByReferenceRequest
+	(*GetReceiptByReferenceResponse)(nil),    // 58: ticketBooking.GetReceiptByReferenceResponse
+	(*GetWaitEstimateRequest)(nil),           // 59: ticketBooking.GetWaitEstimateRequest
+	(*GetWaitEstimateResponse)(nil),          // 60: ticketBooking.GetWaitEstimateResponse
+	(*GetAssignmentTraceRequest)(nil),        // 61: ticketBooking.GetAssignmentTraceRequest
+	(*GetAssignmentTraceResponse)(nil),       // 62: ticketBooking.GetAssignmentTraceResponse
+	(*GetManifestRequest)(nil),               // 63: ticketBooking.GetManifestRequest
+	(*ManifestEntry)(nil),                    // 64: ticketBooking.ManifestEntry
+	(*GetManifestResponse)(nil),              // 65: ticketBooking.GetManifestResponse
+	(*ListReceiptsByPriceRangeRequest)(nil),  // 66: ticketBooking.ListReceiptsByPriceRangeRequest
+	(*ListReceiptsByPriceRangeResponse)(nil), // 67: ticketBooking.ListReceiptsByPriceRangeResponse
+	(*WatchAvailabilityRequest)(nil),         // 68: ticketBooking.WatchAvailabilityRequest
+	(*SectionAvailability)(nil),              // 69: ticketBooking.SectionAvailability
+	(*WatchAvailabilityResponse)(nil),        // 70: ticketBooking.WatchAvailabilityResponse
+	(*ForceReassignRequest)(nil),             // 71: ticketBooking.ForceReassignRequest
+	(*ForceReassignResponse)(nil),            // 72: ticketBooking.ForceReassignResponse
+	(*ConfirmPaymentRequest)(nil),            // 73: ticketBooking.ConfirmPaymentRequest
+	(*ConfirmPaymentResponse)(nil),           // 74: ticketBooking.ConfirmPaymentResponse
+	(*SeatHold)(nil),                         // 75: ticketBooking.SeatHold
+	(*HoldSeatRequest)(nil),                  // 76: ticketBooking.HoldSeatRequest
+	(*HoldSeatResponse)(nil),                 // 77: ticketBooking.HoldSeatResponse
+	(*ReleaseHoldRequest)(nil),               // 78: ticketBooking.ReleaseHoldRequest
+	(*ReleaseHoldResponse)(nil),              // 79: ticketBooking.ReleaseHoldResponse
+	(*GetExpiringHoldsRequest)(nil),          // 80: ticketBooking.GetExpiringHoldsRequest
+	(*ExpiringHold)(nil),                     // 81: ticketBooking.ExpiringHold
+	(*GetExpiringHoldsResponse)(nil),         // 82: ticketBooking.GetExpiringHoldsResponse
+	(*GetBookingTrendsRequest)(nil),          // 83: ticketBooking.GetBookingTrendsRequest
+	(*BookingTrend)(nil),                     // 84: ticketBooking.BookingTrend
+	(*GetBookingTrendsResponse)(nil),         // 85: ticketBooking.GetBookingTrendsResponse
+	(*ImportReceiptsRequest)(nil),            // 86: ticketBooking.ImportReceiptsRequest
+	(*ImportError)(nil),                      // 87: ticketBooking.ImportError
+	(*ImportReceiptsResponse)(nil),           // 88: ticketBooking.ImportReceiptsResponse
+	(*GetCancellationStatsRequest)(nil),      // 89: ticketBooking.GetCancellationStatsRequest
+	(*CancellationCount)(nil),                // 90: ticketBooking.CancellationCount
+	(*GetCancellationStatsResponse)(nil),     // 91: ticketBooking.GetCancellationStatsResponse
+	(*GetNearbyAvailableSeatsRequest)(nil),   // 92: ticketBooking.GetNearbyAvailableSeatsRequest
+	(*GetNearbyAvailableSeatsResponse)(nil),  // 93: ticketBooking.GetNearbyAvailableSeatsResponse
+	(*QuiesceSectionRequest)(nil),            // 94: ticketBooking.QuiesceSectionRequest
+	(*QuiesceSectionResponse)(nil),           // 95: ticketBooking.QuiesceSectionResponse
+	(*ResumeSectionRequest)(nil),             // 96: ticketBooking.ResumeSectionRequest
+	(*ResumeSectionResponse)(nil),            // 97: ticketBooking.ResumeSectionResponse
+	(*GetConfigChangesRequest)(nil),          // 98: ticketBooking.GetConfigChangesRequest
+	(*ConfigChange)(nil),                     // 99: ticketBooking.ConfigChange
+	(*GetConfigChangesResponse)(nil),         // 100: ticketBooking.GetConfigChangesResponse
+	(*ChangeStationsRequest)(nil),            // 101: ticketBooking.ChangeStationsRequest
+	(*ChangeStationsResponse)(nil),           // 102: ticketBooking.ChangeStationsResponse
+	nil,                                      // 103: ticketBooking.PurchaseTicketRequest.MetadataEntry
+	nil,                                      // 104: ticketBooking.Receipt.MetadataEntry
+	(*fieldmaskpb.FieldMask)(nil),            // 105: google.protobuf.FieldMask
 }
 var file_proto_ticketBooking_proto_depIdxs = []int32{
-	3,  // 0: ticketBooking.PurchaseTicketRequest.user:type_name -> ticketBooking.User
-	2,  // 1: ticketBooking.PurchaseTicketResponse.receipt:type_name -> ticketBooking.Receipt
-	3,  // 2: ticketBooking.Receipt.user:type_name -> ticketBooking.User
-	9,  // 3: ticketBooking.Receipt.seat:type_name -> ticketBooking.Seat
-	2,  // 4: ticketBooking.GetReceiptResponse.receipt:type_name -> ticketBooking.Receipt
-	3,  // 5: ticketBooking.UserSeat.user:type_name -> ticketBooking.User
-	6,  // 6: ticketBooking.GetUsersBySectionResponse.users:type_name -> ticketBooking.UserSeat
-	3,  // 7: ticketBooking.RemoveUserResponse.removedUser:type_name -> ticketBooking.User
-	9,  // 8: ticketBooking.UpdateUserSeatRequest.newSeat:type_name -> ticketBooking.Seat
-	2,  // 9: ticketBooking.UpdateUserSeatResponse.updatedReceipt:type_name -> ticketBooking.Receipt
-	0,  // 10: ticketBooking.TicketBookingService.PurchaseTicket:input_type -> ticketBooking.PurchaseTicketRequest
-	4,  // 11: ticketBooking.TicketBookingService.GetReceipt:input_type -> ticketBooking.GetReceiptRequest
-	7,  // 12: ticketBooking.TicketBookingService.GetUsersBySection:input_type -> ticketBooking.GetUsersBySectionRequest
-	10, // 13: ticketBooking.TicketBookingService.RemoveUser:input_type -> ticketBooking.RemoveUserRequest
-	12, // 14: ticketBooking.TicketBookingService.UpdateUserSeat:input_type -> ticketBooking.UpdateUserSeatRequest
-	1,  // 15: ticketBooking.TicketBookingService.PurchaseTicket:output_type -> ticketBooking.PurchaseTicketResponse
-	5,  // 16: ticketBooking.TicketBookingService.GetReceipt:output_type -> ticketBooking.GetReceiptResponse
-	8,  // 17: ticketBooking.TicketBookingService.GetUsersBySection:output_type -> ticketBooking.GetUsersBySectionResponse
-	11, // 18: ticketBooking.TicketBookingService.RemoveUser:output_type -> ticketBooking.RemoveUserResponse
-	13, // 19: ticketBooking.TicketBookingService.UpdateUserSeat:output_type -> ticketBooking.UpdateUserSeatResponse
-	15, // [15:20] is the sub-list for method output_type
-	10, // [10:15] is the sub-list for method input_type
-	10, // [10:10] is the sub-list for extension type_name
-	10, // [10:10] is the sub-list for extension extendee
-	0,  // [0:10] is the sub-list for field type_name
+	10,  // 0: ticketBooking.PurchaseTicketRequest.user:type_name -> ticketBooking.User
+	10,  // 1: ticketBooking.PurchaseTicketRequest.companion:type_name -> ticketBooking.User
+	103, // 2: ticketBooking.PurchaseTicketRequest.metadata:type_name -> ticketBooking.PurchaseTicketRequest.MetadataEntry
+	105, // 3: ticketBooking.PurchaseTicketRequest.receiptMask:type_name -> google.protobuf.FieldMask
+	9,   // 4: ticketBooking.PurchaseTicketResponse.receipt:type_name -> ticketBooking.Receipt
+	9,   // 5: ticketBooking.PurchaseTicketResponse.companionReceipt:type_name -> ticketBooking.Receipt
+	0,   // 6: ticketBooking.PurchaseTicketResponse.pairSeating:type_name -> ticketBooking.PairSeating
+	10,  // 7: ticketBooking.Receipt.user:type_name -> ticketBooking.User
+	16,  // 8: ticketBooking.Receipt.seat:type_name -> ticketBooking.Seat
+	104, // 9: ticketBooking.Receipt.metadata:type_name -> ticketBooking.Receipt.MetadataEntry
+	1,   // 10: ticketBooking.Receipt.status:type_name -> ticketBooking.BookingStatus
+	105, // 11: ticketBooking.GetReceiptRequest.receiptMask:type_name -> google.protobuf.FieldMask
+	9,   // 12: ticketBooking.GetReceiptResponse.receipt:type_name -> ticketBooking.Receipt
+	10,  // 13: ticketBooking.UserSeat.user:type_name -> ticketBooking.User
+	13,  // 14: ticketBooking.GetUsersBySectionResponse.users:type_name -> ticketBooking.UserSeat
+	2,   // 15: ticketBooking.RemoveUserRequest.reason:type_name -> ticketBooking.CancellationReason
+	10,  // 16: ticketBooking.RemoveUserResponse.removedUser:type_name -> ticketBooking.User
+	16,  // 17: ticketBooking.UpdateUserSeatRequest.newSeat:type_name -> ticketBooking.Seat
+	105, // 18: ticketBooking.UpdateUserSeatRequest.receiptMask:type_name -> google.protobuf.FieldMask
+	9,   // 19: ticketBooking.UpdateUserSeatResponse.updatedReceipt:type_name -> ticketBooking.Receipt
+	9,   // 20: ticketBooking.SwapSeatsResponse.receiptA:type_name -> ticketBooking.Receipt
+	9,   // 21: ticketBooking.SwapSeatsResponse.receiptB:type_name -> ticketBooking.Receipt
+	3,   // 22: ticketBooking.ReceiptEvent.type:type_name -> ticketBooking.ReceiptEventType
+	16,  // 23: ticketBooking.ReceiptEvent.seat:type_name -> ticketBooking.Seat
+	2,   // 24: ticketBooking.ReceiptEvent.cancellationReason:type_name -> ticketBooking.CancellationReason
+	23,  // 25: ticketBooking.GetReceiptHistoryResponse.events:type_name -> ticketBooking.ReceiptEvent
+	9,   // 26: ticketBooking.BulkCancelResponse.cancelledReceipts:type_name -> ticketBooking.Receipt
+	30,  // 27: ticketBooking.BulkCancelResponse.refunds:type_name -> ticketBooking.Refund
+	10,  // 28: ticketBooking.RouteUser.user:type_name -> ticketBooking.User
+	16,  // 29: ticketBooking.RouteUser.seat:type_name -> ticketBooking.Seat
+	36,  // 30: ticketBooking.GetUsersByRouteResponse.users:type_name -> ticketBooking.RouteUser
+	16,  // 31: ticketBooking.BlockSeatResponse.seat:type_name -> ticketBooking.Seat
+	16,  // 32: ticketBooking.UnblockSeatResponse.seat:type_name -> ticketBooking.Seat
+	43,  // 33: ticketBooking.VerifyResponse.sections:type_name -> ticketBooking.SectionIntegrity
+	16,  // 34: ticketBooking.GetSeatResponse.seat:type_name -> ticketBooking.Seat
+	4,   // 35: ticketBooking.GetSeatResponse.position:type_name -> ticketBooking.SeatPosition
+	9,   // 36: ticketBooking.GetSeatResponse.occupants:type_name -> ticketBooking.Receipt
+	48,  // 37: ticketBooking.GetCheapestRoutesResponse.routes:type_name -> ticketBooking.RouteFare
+	55,  // 38: ticketBooking.GetAllSectionsResponse.sections:type_name -> ticketBooking.SectionInfo
+	105, // 39: ticketBooking.GetReceiptByReferenceRequest.receiptMask:type_name -> google.protobuf.FieldMask
+	9,   // 40: ticketBooking.GetReceiptByReferenceResponse.receipt:type_name -> ticketBooking.Receipt
+	16,  // 41: ticketBooking.ManifestEntry.seat:type_name -> ticketBooking.Seat
+	10,  // 42: ticketBooking.ManifestEntry.user:type_name -> ticketBooking.User
+	64,  // 43: ticketBooking.GetManifestResponse.entries:type_name -> ticketBooking.ManifestEntry
+	105, // 44: ticketBooking.ListReceiptsByPriceRangeRequest.receiptMask:type_name -> google.protobuf.FieldMask
+	9,   // 45: ticketBooking.ListReceiptsByPriceRangeResponse.receipts:type_name -> ticketBooking.Receipt
+	69,  // 46: ticketBooking.WatchAvailabilityResponse.sections:type_name -> ticketBooking.SectionAvailability
+	9,   // 47: ticketBooking.ForceReassignResponse.updatedReceipt:type_name -> ticketBooking.Receipt
+	105, // 48: ticketBooking.ConfirmPaymentRequest.receiptMask:type_name -> google.protobuf.FieldMask
+	9,   // 49: ticketBooking.ConfirmPaymentResponse.receipt:type_name -> ticketBooking.Receipt
+	16,  // 50: ticketBooking.SeatHold.seat:type_name -> ticketBooking.Seat
+	16,  // 51: ticketBooking.HoldSeatRequest.seat:type_name -> ticketBooking.Seat
+	75,  // 52: ticketBooking.HoldSeatResponse.hold:type_name -> ticketBooking.SeatHold
+	16,  // 53: ticketBooking.ExpiringHold.seat:type_name -> ticketBooking.Seat
+	81,  // 54: ticketBooking.GetExpiringHoldsResponse.holds:type_name -> ticketBooking.ExpiringHold
+	5,   // 55: ticketBooking.GetBookingTrendsRequest.bucket:type_name -> ticketBooking.TrendBucket
+	84,  // 56: ticketBooking.GetBookingTrendsResponse.buckets:type_name -> ticketBooking.BookingTrend
+	9,   // 57: ticketBooking.ImportReceiptsRequest.receipts:type_name -> ticketBooking.Receipt
+	9,   // 58: ticketBooking.ImportReceiptsResponse.importedReceipts:type_name -> ticketBooking.Receipt
+	87,  // 59: ticketBooking.ImportReceiptsResponse.errors:type_name -> ticketBooking.ImportError
+	2,   // 60: ticketBooking.CancellationCount.reason:type_name -> ticketBooking.CancellationReason
+	90,  // 61: ticketBooking.GetCancellationStatsResponse.counts:type_name -> ticketBooking.CancellationCount
+	16,  // 62: ticketBooking.GetNearbyAvailableSeatsResponse.seats:type_name -> ticketBooking.Seat
+	6,   // 63: ticketBooking.ConfigChange.change:type_name -> ticketBooking.ConfigChangeType
+	99,  // 64: ticketBooking.GetConfigChangesResponse.changes:type_name -> ticketBooking.ConfigChange
+	105, // 65: ticketBooking.ChangeStationsRequest.receiptMask:type_name -> google.protobuf.FieldMask
+	9,   // 66: ticketBooking.ChangeStationsResponse.updatedReceipt:type_name -> ticketBooking.Receipt
+	7,   // 67: ticketBooking.TicketBookingService.PurchaseTicket:input_type -> ticketBooking.PurchaseTicketRequest
+	11,  // 68: ticketBooking.TicketBookingService.GetReceipt:input_type -> ticketBooking.GetReceiptRequest
+	14,  // 69: ticketBooking.TicketBookingService.GetUsersBySection:input_type -> ticketBooking.GetUsersBySectionRequest
+	17,  // 70: ticketBooking.TicketBookingService.RemoveUser:input_type -> ticketBooking.RemoveUserRequest
+	19,  // 71: ticketBooking.TicketBookingService.UpdateUserSeat:input_type -> ticketBooking.UpdateUserSeatRequest
+	21,  // 72: ticketBooking.TicketBookingService.SwapSeats:input_type -> ticketBooking.SwapSeatsRequest
+	24,  // 73: ticketBooking.TicketBookingService.GetReceiptHistory:input_type -> ticketBooking.GetReceiptHistoryRequest
+	26,  // 74: ticketBooking.TicketBookingService.SetMaintenanceMode:input_type -> ticketBooking.SetMaintenanceModeRequest
+	28,  // 75: ticketBooking.TicketBookingService.BulkCancel:input_type -> ticketBooking.BulkCancelRequest
+	31,  // 76: ticketBooking.TicketBookingService.ResizeSection:input_type -> ticketBooking.ResizeSectionRequest
+	33,  // 77: ticketBooking.TicketBookingService.GetServerInfo:input_type -> ticketBooking.GetServerInfoRequest
+	35,  // 78: ticketBooking.TicketBookingService.GetUsersByRoute:input_type -> ticketBooking.GetUsersByRouteRequest
+	38,  // 79: ticketBooking.TicketBookingService.BlockSeat:input_type -> ticketBooking.BlockSeatRequest
+	40,  // 80: ticketBooking.TicketBookingService.UnblockSeat:input_type -> ticketBooking.UnblockSeatRequest
+	42,  // 81: ticketBooking.TicketBookingService.Verify:input_type -> ticketBooking.VerifyRequest
+	45,  // 82: ticketBooking.TicketBookingService.GetSeat:input_type -> ticketBooking.GetSeatRequest
+	47,  // 83: ticketBooking.TicketBookingService.GetCheapestRoutes:input_type -> ticketBooking.GetCheapestRoutesRequest
+	50,  // 84: ticketBooking.TicketBookingService.GetSectionVacancy:input_type -> ticketBooking.GetSectionVacancyRequest
+	52,  // 85: ticketBooking.TicketBookingService.GetConfig:input_type -> ticketBooking.GetConfigRequest
+	54,  // 86: ticketBooking.TicketBookingService.GetAllSections:input_type -> ticketBooking.GetAllSectionsRequest
+	57,  // 87: ticketBooking.TicketBookingService.GetReceiptByReference:input_type -> ticketBooking.GetReceiptByReferenceRequest
+	59,  // 88: ticketBooking.TicketBookingService.GetWaitEstimate:input_type -> ticketBooking.GetWaitEstimateRequest
+	61,  // 89: ticketBooking.TicketBookingService.GetAssignmentTrace:input_type -> ticketBooking.GetAssignmentTraceRequest
+	63,  // 90: ticketBooking.TicketBookingService.GetManifest:input_type -> ticketBooking.GetManifestRequest
+	66,  // 91: ticketBooking.TicketBookingService.ListReceiptsByPriceRange:input_type -> ticketBooking.ListReceiptsByPriceRangeRequest
+	68,  // 92: ticketBooking.TicketBookingService.WatchAvailability:input_type -> ticketBooking.WatchAvailabilityRequest
+	71,  // 93: ticketBooking.TicketBookingService.ForceReassign:input_type -> ticketBooking.ForceReassignRequest
+	73,  // 94: ticketBooking.TicketBookingService.ConfirmPayment:input_type -> ticketBooking.ConfirmPaymentRequest
+	76,  // 95: ticketBooking.TicketBookingService.HoldSeat:input_type -> ticketBooking.HoldSeatRequest
+	78,  // 96: ticketBooking.TicketBookingService.ReleaseHold:input_type -> ticketBooking.ReleaseHoldRequest
+	80,  // 97: ticketBooking.TicketBookingService.GetExpiringHolds:input_type -> ticketBooking.GetExpiringHoldsRequest
+	83,  // 98: ticketBooking.TicketBookingService.GetBookingTrends:input_type -> ticketBooking.GetBookingTrendsRequest
+	86,  // 99: ticketBooking.TicketBookingService.ImportReceipts:input_type -> ticketBooking.ImportReceiptsRequest
+	89,  // 100: ticketBooking.TicketBookingService.GetCancellationStats:input_type -> ticketBooking.GetCancellationStatsRequest
+	92,  // 101: ticketBooking.TicketBookingService.GetNearbyAvailableSeats:input_type -> ticketBooking.GetNearbyAvailableSeatsRequest
+	94,  // 102: ticketBooking.TicketBookingService.QuiesceSection:input_type -> ticketBooking.QuiesceSectionRequest
+	96,  // 103: ticketBooking.TicketBookingService.ResumeSection:input_type -> ticketBooking.ResumeSectionRequest
+	98,  // 104: ticketBooking.TicketBookingService.GetConfigChanges:input_type -> ticketBooking.GetConfigChangesRequest
+	101, // 105: ticketBooking.TicketBookingService.ChangeStations:input_type -> ticketBooking.ChangeStationsRequest
+	8,   // 106: ticketBooking.TicketBookingService.PurchaseTicket:output_type -> ticketBooking.PurchaseTicketResponse
+	12,  // 107: ticketBooking.TicketBookingService.GetReceipt:output_type -> ticketBooking.GetReceiptResponse
+	15,  // 108: ticketBooking.TicketBookingService.GetUsersBySection:output_type -> ticketBooking.GetUsersBySectionResponse
+	18,  // 109: ticketBooking.TicketBookingService.RemoveUser:output_type -> ticketBooking.RemoveUserResponse
+	20,  // 110: ticketBooking.TicketBookingService.UpdateUserSeat:output_type -> ticketBooking.UpdateUserSeatResponse
+	22,  // 111: ticketBooking.TicketBookingService.SwapSeats:output_type -> ticketBooking.SwapSeatsResponse
+	25,  // 112: ticketBooking.TicketBookingService.GetReceiptHistory:output_type -> ticketBooking.GetReceiptHistoryResponse
+	27,  // 113: ticketBooking.TicketBookingService.SetMaintenanceMode:output_type -> ticketBooking.SetMaintenanceModeResponse
+	29,  // 114: ticketBooking.TicketBookingService.BulkCancel:output_type -> ticketBooking.BulkCancelResponse
+	32,  // 115: ticketBooking.TicketBookingService.ResizeSection:output_type -> ticketBooking.ResizeSectionResponse
+	34,  // 116: ticketBooking.TicketBookingService.GetServerInfo:output_type -> ticketBooking.GetServerInfoResponse
+	37,  // 117: ticketBooking.TicketBookingService.GetUsersByRoute:output_type -> ticketBooking.GetUsersByRouteResponse
+	39,  // 118: ticketBooking.TicketBookingService.BlockSeat:output_type -> ticketBooking.BlockSeatResponse
+	41,  // 119: ticketBooking.TicketBookingService.UnblockSeat:output_type -> ticketBooking.UnblockSeatResponse
+	44,  // 120: ticketBooking.TicketBookingService.Verify:output_type -> ticketBooking.VerifyResponse
+	46,  // 121: ticketBooking.TicketBookingService.GetSeat:output_type -> ticketBooking.GetSeatResponse
+	49,  // 122: ticketBooking.TicketBookingService.GetCheapestRoutes:output_type -> ticketBooking.GetCheapestRoutesResponse
+	51,  // 123: ticketBooking.TicketBookingService.GetSectionVacancy:output_type -> ticketBooking.GetSectionVacancyResponse
+	53,  // 124: ticketBooking.TicketBookingService.GetConfig:output_type -> ticketBooking.GetConfigResponse
+	56,  // 125: ticketBooking.TicketBookingService.GetAllSections:output_type -> ticketBooking.GetAllSectionsResponse
+	58,  // 126: ticketBooking.TicketBookingService.GetReceiptByReference:output_type -> ticketBooking.GetReceiptByReferenceResponse
+	60,  // 127: ticketBooking.TicketBookingService.GetWaitEstimate:output_type -> ticketBooking.GetWaitEstimateResponse
+	62,  // 128: ticketBooking.TicketBookingService.GetAssignmentTrace:output_type -> ticketBooking.GetAssignmentTraceResponse
+	65,  // 129: ticketBooking.TicketBookingService.GetManifest:output_type -> ticketBooking.GetManifestResponse
+	67,  // 130: ticketBooking.TicketBookingService.ListReceiptsByPriceRange:output_type -> ticketBooking.ListReceiptsByPriceRangeResponse
+	70,  // 131: ticketBooking.TicketBookingService.WatchAvailability:output_type -> ticketBooking.WatchAvailabilityResponse
+	72,  // 132: ticketBooking.TicketBookingService.ForceReassign:output_type -> ticketBooking.ForceReassignResponse
+	74,  // 133: ticketBooking.TicketBookingService.ConfirmPayment:output_type -> ticketBooking.ConfirmPaymentResponse
+	77,  // 134: ticketBooking.TicketBookingService.HoldSeat:output_type -> ticketBooking.HoldSeatResponse
+	79,  // 135: ticketBooking.TicketBookingService.ReleaseHold:output_type -> ticketBooking.ReleaseHoldResponse
+	82,  // 136: ticketBooking.TicketBookingService.GetExpiringHolds:output_type -> ticketBooking.GetExpiringHoldsResponse
+	85,  // 137: ticketBooking.TicketBookingService.GetBookingTrends:output_type -> ticketBooking.GetBookingTrendsResponse
+	88,  // 138: ticketBooking.TicketBookingService.ImportReceipts:output_type -> ticketBooking.ImportReceiptsResponse
+	91,  // 139: ticketBooking.TicketBookingService.GetCancellationStats:output_type -> ticketBooking.GetCancellationStatsResponse
+	93,  // 140: ticketBooking.TicketBookingService.GetNearbyAvailableSeats:output_type -> ticketBooking.GetNearbyAvailableSeatsResponse
+	95,  // 141: ticketBooking.TicketBookingService.QuiesceSection:output_type -> ticketBooking.QuiesceSectionResponse
+	97,  // 142: ticketBooking.TicketBookingService.ResumeSection:output_type -> ticketBooking.ResumeSectionResponse
+	100, // 143: ticketBooking.TicketBookingService.GetConfigChanges:output_type -> ticketBooking.GetConfigChangesResponse
+	102, // 144: ticketBooking.TicketBookingService.ChangeStations:output_type -> ticketBooking.ChangeStationsResponse
+	106, // [106:145] is the sub-list for method output_type
+	67,  // [67:106] is the sub-list for method input_type
+	67,  // [67:67] is the sub-list for extension type_name
+	67,  // [67:67] is the sub-list for extension extendee
+	0,   // [0:67] is the sub-list for field type_name
 }
 
 func init() { file_proto_ticketBooking_proto_init() }
@@ -888,13 +6948,14 @@ func file_proto_ticketBooking_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_ticketBooking_proto_rawDesc), len(file_proto_ticketBooking_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   14,
+			NumEnums:      7,
+			NumMessages:   98,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_proto_ticketBooking_proto_goTypes,
 		DependencyIndexes: file_proto_ticketBooking_proto_depIdxs,
+		EnumInfos:         file_proto_ticketBooking_proto_enumTypes,
 		MessageInfos:      file_proto_ticketBooking_proto_msgTypes,
 	}.Build()
 	File_proto_ticketBooking_proto = out.File
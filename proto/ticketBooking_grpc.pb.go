@@ -19,11 +19,45 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	TicketBookingService_PurchaseTicket_FullMethodName    = "/ticketBooking.TicketBookingService/PurchaseTicket"
-	TicketBookingService_GetReceipt_FullMethodName        = "/ticketBooking.TicketBookingService/GetReceipt"
-	TicketBookingService_GetUsersBySection_FullMethodName = "/ticketBooking.TicketBookingService/GetUsersBySection"
-	TicketBookingService_RemoveUser_FullMethodName        = "/ticketBooking.TicketBookingService/RemoveUser"
-	TicketBookingService_UpdateUserSeat_FullMethodName    = "/ticketBooking.TicketBookingService/UpdateUserSeat"
+	TicketBookingService_PurchaseTicket_FullMethodName           = "/ticketBooking.TicketBookingService/PurchaseTicket"
+	TicketBookingService_GetReceipt_FullMethodName               = "/ticketBooking.TicketBookingService/GetReceipt"
+	TicketBookingService_GetUsersBySection_FullMethodName        = "/ticketBooking.TicketBookingService/GetUsersBySection"
+	TicketBookingService_RemoveUser_FullMethodName               = "/ticketBooking.TicketBookingService/RemoveUser"
+	TicketBookingService_UpdateUserSeat_FullMethodName           = "/ticketBooking.TicketBookingService/UpdateUserSeat"
+	TicketBookingService_SwapSeats_FullMethodName                = "/ticketBooking.TicketBookingService/SwapSeats"
+	TicketBookingService_GetReceiptHistory_FullMethodName        = "/ticketBooking.TicketBookingService/GetReceiptHistory"
+	TicketBookingService_SetMaintenanceMode_FullMethodName       = "/ticketBooking.TicketBookingService/SetMaintenanceMode"
+	TicketBookingService_BulkCancel_FullMethodName               = "/ticketBooking.TicketBookingService/BulkCancel"
+	TicketBookingService_ResizeSection_FullMethodName            = "/ticketBooking.TicketBookingService/ResizeSection"
+	TicketBookingService_GetServerInfo_FullMethodName            = "/ticketBooking.TicketBookingService/GetServerInfo"
+	TicketBookingService_GetUsersByRoute_FullMethodName          = "/ticketBooking.TicketBookingService/GetUsersByRoute"
+	TicketBookingService_BlockSeat_FullMethodName                = "/ticketBooking.TicketBookingService/BlockSeat"
+	TicketBookingService_UnblockSeat_FullMethodName              = "/ticketBooking.TicketBookingService/UnblockSeat"
+	TicketBookingService_Verify_FullMethodName                   = "/ticketBooking.TicketBookingService/Verify"
+	TicketBookingService_GetSeat_FullMethodName                  = "/ticketBooking.TicketBookingService/GetSeat"
+	TicketBookingService_GetCheapestRoutes_FullMethodName        = "/ticketBooking.TicketBookingService/GetCheapestRoutes"
+	TicketBookingService_GetSectionVacancy_FullMethodName        = "/ticketBooking.TicketBookingService/GetSectionVacancy"
+	TicketBookingService_GetConfig_FullMethodName                = "/ticketBooking.TicketBookingService/GetConfig"
+	TicketBookingService_GetAllSections_FullMethodName           = "/ticketBooking.TicketBookingService/GetAllSections"
+	TicketBookingService_GetReceiptByReference_FullMethodName    = "/ticketBooking.TicketBookingService/GetReceiptByReference"
+	TicketBookingService_GetWaitEstimate_FullMethodName          = "/ticketBooking.TicketBookingService/GetWaitEstimate"
+	TicketBookingService_GetAssignmentTrace_FullMethodName       = "/ticketBooking.TicketBookingService/GetAssignmentTrace"
+	TicketBookingService_GetManifest_FullMethodName              = "/ticketBooking.TicketBookingService/GetManifest"
+	TicketBookingService_ListReceiptsByPriceRange_FullMethodName = "/ticketBooking.TicketBookingService/ListReceiptsByPriceRange"
+	TicketBookingService_WatchAvailability_FullMethodName        = "/ticketBooking.TicketBookingService/WatchAvailability"
+	TicketBookingService_ForceReassign_FullMethodName            = "/ticketBooking.TicketBookingService/ForceReassign"
+	TicketBookingService_ConfirmPayment_FullMethodName           = "/ticketBooking.TicketBookingService/ConfirmPayment"
+	TicketBookingService_HoldSeat_FullMethodName                 = "/ticketBooking.TicketBookingService/HoldSeat"
+	TicketBookingService_ReleaseHold_FullMethodName              = "/ticketBooking.TicketBookingService/ReleaseHold"
+	TicketBookingService_GetExpiringHolds_FullMethodName         = "/ticketBooking.TicketBookingService/GetExpiringHolds"
+	TicketBookingService_GetBookingTrends_FullMethodName         = "/ticketBooking.TicketBookingService/GetBookingTrends"
+	TicketBookingService_ImportReceipts_FullMethodName           = "/ticketBooking.TicketBookingService/ImportReceipts"
+	TicketBookingService_GetCancellationStats_FullMethodName     = "/ticketBooking.TicketBookingService/GetCancellationStats"
+	TicketBookingService_GetNearbyAvailableSeats_FullMethodName  = "/ticketBooking.TicketBookingService/GetNearbyAvailableSeats"
+	TicketBookingService_QuiesceSection_FullMethodName           = "/ticketBooking.TicketBookingService/QuiesceSection"
+	TicketBookingService_ResumeSection_FullMethodName            = "/ticketBooking.TicketBookingService/ResumeSection"
+	TicketBookingService_GetConfigChanges_FullMethodName         = "/ticketBooking.TicketBookingService/GetConfigChanges"
+	TicketBookingService_ChangeStations_FullMethodName           = "/ticketBooking.TicketBookingService/ChangeStations"
 )
 
 // TicketBookingServiceClient is the client API for TicketBookingService service.
@@ -37,6 +71,40 @@ type TicketBookingServiceClient interface {
 	GetUsersBySection(ctx context.Context, in *GetUsersBySectionRequest, opts ...grpc.CallOption) (*GetUsersBySectionResponse, error)
 	RemoveUser(ctx context.Context, in *RemoveUserRequest, opts ...grpc.CallOption) (*RemoveUserResponse, error)
 	UpdateUserSeat(ctx context.Context, in *UpdateUserSeatRequest, opts ...grpc.CallOption) (*UpdateUserSeatResponse, error)
+	SwapSeats(ctx context.Context, in *SwapSeatsRequest, opts ...grpc.CallOption) (*SwapSeatsResponse, error)
+	GetReceiptHistory(ctx context.Context, in *GetReceiptHistoryRequest, opts ...grpc.CallOption) (*GetReceiptHistoryResponse, error)
+	SetMaintenanceMode(ctx context.Context, in *SetMaintenanceModeRequest, opts ...grpc.CallOption) (*SetMaintenanceModeResponse, error)
+	BulkCancel(ctx context.Context, in *BulkCancelRequest, opts ...grpc.CallOption) (*BulkCancelResponse, error)
+	ResizeSection(ctx context.Context, in *ResizeSectionRequest, opts ...grpc.CallOption) (*ResizeSectionResponse, error)
+	GetServerInfo(ctx context.Context, in *GetServerInfoRequest, opts ...grpc.CallOption) (*GetServerInfoResponse, error)
+	GetUsersByRoute(ctx context.Context, in *GetUsersByRouteRequest, opts ...grpc.CallOption) (*GetUsersByRouteResponse, error)
+	BlockSeat(ctx context.Context, in *BlockSeatRequest, opts ...grpc.CallOption) (*BlockSeatResponse, error)
+	UnblockSeat(ctx context.Context, in *UnblockSeatRequest, opts ...grpc.CallOption) (*UnblockSeatResponse, error)
+	Verify(ctx context.Context, in *VerifyRequest, opts ...grpc.CallOption) (*VerifyResponse, error)
+	GetSeat(ctx context.Context, in *GetSeatRequest, opts ...grpc.CallOption) (*GetSeatResponse, error)
+	GetCheapestRoutes(ctx context.Context, in *GetCheapestRoutesRequest, opts ...grpc.CallOption) (*GetCheapestRoutesResponse, error)
+	GetSectionVacancy(ctx context.Context, in *GetSectionVacancyRequest, opts ...grpc.CallOption) (*GetSectionVacancyResponse, error)
+	GetConfig(ctx context.Context, in *GetConfigRequest, opts ...grpc.CallOption) (*GetConfigResponse, error)
+	GetAllSections(ctx context.Context, in *GetAllSectionsRequest, opts ...grpc.CallOption) (*GetAllSectionsResponse, error)
+	GetReceiptByReference(ctx context.Context, in *GetReceiptByReferenceRequest, opts ...grpc.CallOption) (*GetReceiptByReferenceResponse, error)
+	GetWaitEstimate(ctx context.Context, in *GetWaitEstimateRequest, opts ...grpc.CallOption) (*GetWaitEstimateResponse, error)
+	GetAssignmentTrace(ctx context.Context, in *GetAssignmentTraceRequest, opts ...grpc.CallOption) (*GetAssignmentTraceResponse, error)
+	GetManifest(ctx context.Context, in *GetManifestRequest, opts ...grpc.CallOption) (*GetManifestResponse, error)
+	ListReceiptsByPriceRange(ctx context.Context, in *ListReceiptsByPriceRangeRequest, opts ...grpc.CallOption) (*ListReceiptsByPriceRangeResponse, error)
+	WatchAvailability(ctx context.Context, in *WatchAvailabilityRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchAvailabilityResponse], error)
+	ForceReassign(ctx context.Context, in *ForceReassignRequest, opts ...grpc.CallOption) (*ForceReassignResponse, error)
+	ConfirmPayment(ctx context.Context, in *ConfirmPaymentRequest, opts ...grpc.CallOption) (*ConfirmPaymentResponse, error)
+	HoldSeat(ctx context.Context, in *HoldSeatRequest, opts ...grpc.CallOption) (*HoldSeatResponse, error)
+	ReleaseHold(ctx context.Context, in *ReleaseHoldRequest, opts ...grpc.CallOption) (*ReleaseHoldResponse, error)
+	GetExpiringHolds(ctx context.Context, in *GetExpiringHoldsRequest, opts ...grpc.CallOption) (*GetExpiringHoldsResponse, error)
+	GetBookingTrends(ctx context.Context, in *GetBookingTrendsRequest, opts ...grpc.CallOption) (*GetBookingTrendsResponse, error)
+	ImportReceipts(ctx context.Context, in *ImportReceiptsRequest, opts ...grpc.CallOption) (*ImportReceiptsResponse, error)
+	GetCancellationStats(ctx context.Context, in *GetCancellationStatsRequest, opts ...grpc.CallOption) (*GetCancellationStatsResponse, error)
+	GetNearbyAvailableSeats(ctx context.Context, in *GetNearbyAvailableSeatsRequest, opts ...grpc.CallOption) (*GetNearbyAvailableSeatsResponse, error)
+	QuiesceSection(ctx context.Context, in *QuiesceSectionRequest, opts ...grpc.CallOption) (*QuiesceSectionResponse, error)
+	ResumeSection(ctx context.Context, in *ResumeSectionRequest, opts ...grpc.CallOption) (*ResumeSectionResponse, error)
+	GetConfigChanges(ctx context.Context, in *GetConfigChangesRequest, opts ...grpc.CallOption) (*GetConfigChangesResponse, error)
+	ChangeStations(ctx context.Context, in *ChangeStationsRequest, opts ...grpc.CallOption) (*ChangeStationsResponse, error)
 }
 
 type ticketBookingServiceClient struct {
@@ -97,6 +165,355 @@ func (c *ticketBookingServiceClient) UpdateUserSeat(ctx context.Context, in *Upd
 	return out, nil
 }
 
+func (c *ticketBookingServiceClient) SwapSeats(ctx context.Context, in *SwapSeatsRequest, opts ...grpc.CallOption) (*SwapSeatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SwapSeatsResponse)
+	err := c.cc.Invoke(ctx, TicketBookingService_SwapSeats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketBookingServiceClient) GetReceiptHistory(ctx context.Context, in *GetReceiptHistoryRequest, opts ...grpc.CallOption) (*GetReceiptHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetReceiptHistoryResponse)
+	err := c.cc.Invoke(ctx, TicketBookingService_GetReceiptHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketBookingServiceClient) SetMaintenanceMode(ctx context.Context, in *SetMaintenanceModeRequest, opts ...grpc.CallOption) (*SetMaintenanceModeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetMaintenanceModeResponse)
+	err := c.cc.Invoke(ctx, TicketBookingService_SetMaintenanceMode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketBookingServiceClient) BulkCancel(ctx context.Context, in *BulkCancelRequest, opts ...grpc.CallOption) (*BulkCancelResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BulkCancelResponse)
+	err := c.cc.Invoke(ctx, TicketBookingService_BulkCancel_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketBookingServiceClient) ResizeSection(ctx context.Context, in *ResizeSectionRequest, opts ...grpc.CallOption) (*ResizeSectionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResizeSectionResponse)
+	err := c.cc.Invoke(ctx, TicketBookingService_ResizeSection_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketBookingServiceClient) GetServerInfo(ctx context.Context, in *GetServerInfoRequest, opts ...grpc.CallOption) (*GetServerInfoResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetServerInfoResponse)
+	err := c.cc.Invoke(ctx, TicketBookingService_GetServerInfo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketBookingServiceClient) GetUsersByRoute(ctx context.Context, in *GetUsersByRouteRequest, opts ...grpc.CallOption) (*GetUsersByRouteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetUsersByRouteResponse)
+	err := c.cc.Invoke(ctx, TicketBookingService_GetUsersByRoute_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketBookingServiceClient) BlockSeat(ctx context.Context, in *BlockSeatRequest, opts ...grpc.CallOption) (*BlockSeatResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BlockSeatResponse)
+	err := c.cc.Invoke(ctx, TicketBookingService_BlockSeat_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketBookingServiceClient) UnblockSeat(ctx context.Context, in *UnblockSeatRequest, opts ...grpc.CallOption) (*UnblockSeatResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UnblockSeatResponse)
+	err := c.cc.Invoke(ctx, TicketBookingService_UnblockSeat_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketBookingServiceClient) Verify(ctx context.Context, in *VerifyRequest, opts ...grpc.CallOption) (*VerifyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VerifyResponse)
+	err := c.cc.Invoke(ctx, TicketBookingService_Verify_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketBookingServiceClient) GetSeat(ctx context.Context, in *GetSeatRequest, opts ...grpc.CallOption) (*GetSeatResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSeatResponse)
+	err := c.cc.Invoke(ctx, TicketBookingService_GetSeat_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketBookingServiceClient) GetCheapestRoutes(ctx context.Context, in *GetCheapestRoutesRequest, opts ...grpc.CallOption) (*GetCheapestRoutesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCheapestRoutesResponse)
+	err := c.cc.Invoke(ctx, TicketBookingService_GetCheapestRoutes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketBookingServiceClient) GetSectionVacancy(ctx context.Context, in *GetSectionVacancyRequest, opts ...grpc.CallOption) (*GetSectionVacancyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSectionVacancyResponse)
+	err := c.cc.Invoke(ctx, TicketBookingService_GetSectionVacancy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketBookingServiceClient) GetConfig(ctx context.Context, in *GetConfigRequest, opts ...grpc.CallOption) (*GetConfigResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetConfigResponse)
+	err := c.cc.Invoke(ctx, TicketBookingService_GetConfig_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketBookingServiceClient) GetAllSections(ctx context.Context, in *GetAllSectionsRequest, opts ...grpc.CallOption) (*GetAllSectionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAllSectionsResponse)
+	err := c.cc.Invoke(ctx, TicketBookingService_GetAllSections_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketBookingServiceClient) GetReceiptByReference(ctx context.Context, in *GetReceiptByReferenceRequest, opts ...grpc.CallOption) (*GetReceiptByReferenceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetReceiptByReferenceResponse)
+	err := c.cc.Invoke(ctx, TicketBookingService_GetReceiptByReference_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketBookingServiceClient) GetWaitEstimate(ctx context.Context, in *GetWaitEstimateRequest, opts ...grpc.CallOption) (*GetWaitEstimateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetWaitEstimateResponse)
+	err := c.cc.Invoke(ctx, TicketBookingService_GetWaitEstimate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketBookingServiceClient) GetAssignmentTrace(ctx context.Context, in *GetAssignmentTraceRequest, opts ...grpc.CallOption) (*GetAssignmentTraceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAssignmentTraceResponse)
+	err := c.cc.Invoke(ctx, TicketBookingService_GetAssignmentTrace_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketBookingServiceClient) GetManifest(ctx context.Context, in *GetManifestRequest, opts ...grpc.CallOption) (*GetManifestResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetManifestResponse)
+	err := c.cc.Invoke(ctx, TicketBookingService_GetManifest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketBookingServiceClient) ListReceiptsByPriceRange(ctx context.Context, in *ListReceiptsByPriceRangeRequest, opts ...grpc.CallOption) (*ListReceiptsByPriceRangeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListReceiptsByPriceRangeResponse)
+	err := c.cc.Invoke(ctx, TicketBookingService_ListReceiptsByPriceRange_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketBookingServiceClient) WatchAvailability(ctx context.Context, in *WatchAvailabilityRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchAvailabilityResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &TicketBookingService_ServiceDesc.Streams[0], TicketBookingService_WatchAvailability_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchAvailabilityRequest, WatchAvailabilityResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TicketBookingService_WatchAvailabilityClient = grpc.ServerStreamingClient[WatchAvailabilityResponse]
+
+func (c *ticketBookingServiceClient) ForceReassign(ctx context.Context, in *ForceReassignRequest, opts ...grpc.CallOption) (*ForceReassignResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ForceReassignResponse)
+	err := c.cc.Invoke(ctx, TicketBookingService_ForceReassign_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketBookingServiceClient) ConfirmPayment(ctx context.Context, in *ConfirmPaymentRequest, opts ...grpc.CallOption) (*ConfirmPaymentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConfirmPaymentResponse)
+	err := c.cc.Invoke(ctx, TicketBookingService_ConfirmPayment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketBookingServiceClient) HoldSeat(ctx context.Context, in *HoldSeatRequest, opts ...grpc.CallOption) (*HoldSeatResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HoldSeatResponse)
+	err := c.cc.Invoke(ctx, TicketBookingService_HoldSeat_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketBookingServiceClient) ReleaseHold(ctx context.Context, in *ReleaseHoldRequest, opts ...grpc.CallOption) (*ReleaseHoldResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReleaseHoldResponse)
+	err := c.cc.Invoke(ctx, TicketBookingService_ReleaseHold_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketBookingServiceClient) GetExpiringHolds(ctx context.Context, in *GetExpiringHoldsRequest, opts ...grpc.CallOption) (*GetExpiringHoldsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetExpiringHoldsResponse)
+	err := c.cc.Invoke(ctx, TicketBookingService_GetExpiringHolds_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketBookingServiceClient) GetBookingTrends(ctx context.Context, in *GetBookingTrendsRequest, opts ...grpc.CallOption) (*GetBookingTrendsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetBookingTrendsResponse)
+	err := c.cc.Invoke(ctx, TicketBookingService_GetBookingTrends_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketBookingServiceClient) ImportReceipts(ctx context.Context, in *ImportReceiptsRequest, opts ...grpc.CallOption) (*ImportReceiptsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ImportReceiptsResponse)
+	err := c.cc.Invoke(ctx, TicketBookingService_ImportReceipts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketBookingServiceClient) GetCancellationStats(ctx context.Context, in *GetCancellationStatsRequest, opts ...grpc.CallOption) (*GetCancellationStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCancellationStatsResponse)
+	err := c.cc.Invoke(ctx, TicketBookingService_GetCancellationStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketBookingServiceClient) GetNearbyAvailableSeats(ctx context.Context, in *GetNearbyAvailableSeatsRequest, opts ...grpc.CallOption) (*GetNearbyAvailableSeatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetNearbyAvailableSeatsResponse)
+	err := c.cc.Invoke(ctx, TicketBookingService_GetNearbyAvailableSeats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketBookingServiceClient) QuiesceSection(ctx context.Context, in *QuiesceSectionRequest, opts ...grpc.CallOption) (*QuiesceSectionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(QuiesceSectionResponse)
+	err := c.cc.Invoke(ctx, TicketBookingService_QuiesceSection_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketBookingServiceClient) ResumeSection(ctx context.Context, in *ResumeSectionRequest, opts ...grpc.CallOption) (*ResumeSectionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResumeSectionResponse)
+	err := c.cc.Invoke(ctx, TicketBookingService_ResumeSection_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketBookingServiceClient) GetConfigChanges(ctx context.Context, in *GetConfigChangesRequest, opts ...grpc.CallOption) (*GetConfigChangesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetConfigChangesResponse)
+	err := c.cc.Invoke(ctx, TicketBookingService_GetConfigChanges_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketBookingServiceClient) ChangeStations(ctx context.Context, in *ChangeStationsRequest, opts ...grpc.CallOption) (*ChangeStationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChangeStationsResponse)
+	err := c.cc.Invoke(ctx, TicketBookingService_ChangeStations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TicketBookingServiceServer is the server API for TicketBookingService service.
 // All implementations must embed UnimplementedTicketBookingServiceServer
 // for forward compatibility.
@@ -108,138 +525,879 @@ type TicketBookingServiceServer interface {
 	GetUsersBySection(context.Context, *GetUsersBySectionRequest) (*GetUsersBySectionResponse, error)
 	RemoveUser(context.Context, *RemoveUserRequest) (*RemoveUserResponse, error)
 	UpdateUserSeat(context.Context, *UpdateUserSeatRequest) (*UpdateUserSeatResponse, error)
+	SwapSeats(context.Context, *SwapSeatsRequest) (*SwapSeatsResponse, error)
+	GetReceiptHistory(context.Context, *GetReceiptHistoryRequest) (*GetReceiptHistoryResponse, error)
+	SetMaintenanceMode(context.Context, *SetMaintenanceModeRequest) (*SetMaintenanceModeResponse, error)
+	BulkCancel(context.Context, *BulkCancelRequest) (*BulkCancelResponse, error)
+	ResizeSection(context.Context, *ResizeSectionRequest) (*ResizeSectionResponse, error)
+	GetServerInfo(context.Context, *GetServerInfoRequest) (*GetServerInfoResponse, error)
+	GetUsersByRoute(context.Context, *GetUsersByRouteRequest) (*GetUsersByRouteResponse, error)
+	BlockSeat(context.Context, *BlockSeatRequest) (*BlockSeatResponse, error)
+	UnblockSeat(context.Context, *UnblockSeatRequest) (*UnblockSeatResponse, error)
+	Verify(context.Context, *VerifyRequest) (*VerifyResponse, error)
+	GetSeat(context.Context, *GetSeatRequest) (*GetSeatResponse, error)
+	GetCheapestRoutes(context.Context, *GetCheapestRoutesRequest) (*GetCheapestRoutesResponse, error)
+	GetSectionVacancy(context.Context, *GetSectionVacancyRequest) (*GetSectionVacancyResponse, error)
+	GetConfig(context.Context, *GetConfigRequest) (*GetConfigResponse, error)
+	GetAllSections(context.Context, *GetAllSectionsRequest) (*GetAllSectionsResponse, error)
+	GetReceiptByReference(context.Context, *GetReceiptByReferenceRequest) (*GetReceiptByReferenceResponse, error)
+	GetWaitEstimate(context.Context, *GetWaitEstimateRequest) (*GetWaitEstimateResponse, error)
+	GetAssignmentTrace(context.Context, *GetAssignmentTraceRequest) (*GetAssignmentTraceResponse, error)
+	GetManifest(context.Context, *GetManifestRequest) (*GetManifestResponse, error)
+	ListReceiptsByPriceRange(context.Context, *ListReceiptsByPriceRangeRequest) (*ListReceiptsByPriceRangeResponse, error)
+	WatchAvailability(*WatchAvailabilityRequest, grpc.ServerStreamingServer[WatchAvailabilityResponse]) error
+	ForceReassign(context.Context, *ForceReassignRequest) (*ForceReassignResponse, error)
+	ConfirmPayment(context.Context, *ConfirmPaymentRequest) (*ConfirmPaymentResponse, error)
+	HoldSeat(context.Context, *HoldSeatRequest) (*HoldSeatResponse, error)
+	ReleaseHold(context.Context, *ReleaseHoldRequest) (*ReleaseHoldResponse, error)
+	GetExpiringHolds(context.Context, *GetExpiringHoldsRequest) (*GetExpiringHoldsResponse, error)
+	GetBookingTrends(context.Context, *GetBookingTrendsRequest) (*GetBookingTrendsResponse, error)
+	ImportReceipts(context.Context, *ImportReceiptsRequest) (*ImportReceiptsResponse, error)
+	GetCancellationStats(context.Context, *GetCancellationStatsRequest) (*GetCancellationStatsResponse, error)
+	GetNearbyAvailableSeats(context.Context, *GetNearbyAvailableSeatsRequest) (*GetNearbyAvailableSeatsResponse, error)
+	QuiesceSection(context.Context, *QuiesceSectionRequest) (*QuiesceSectionResponse, error)
+	ResumeSection(context.Context, *ResumeSectionRequest) (*ResumeSectionResponse, error)
+	GetConfigChanges(context.Context, *GetConfigChangesRequest) (*GetConfigChangesResponse, error)
+	ChangeStations(context.Context, *ChangeStationsRequest) (*ChangeStationsResponse, error)
 	mustEmbedUnimplementedTicketBookingServiceServer()
 }
 
-// UnimplementedTicketBookingServiceServer must be embedded to have
-// forward compatible implementations.
-//
-// NOTE: this should be embedded by value instead of pointer to avoid a nil
-// pointer dereference when methods are called.
-type UnimplementedTicketBookingServiceServer struct{}
+// UnimplementedTicketBookingServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedTicketBookingServiceServer struct{}
+
+func (UnimplementedTicketBookingServiceServer) PurchaseTicket(context.Context, *PurchaseTicketRequest) (*PurchaseTicketResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PurchaseTicket not implemented")
+}
+func (UnimplementedTicketBookingServiceServer) GetReceipt(context.Context, *GetReceiptRequest) (*GetReceiptResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReceipt not implemented")
+}
+func (UnimplementedTicketBookingServiceServer) GetUsersBySection(context.Context, *GetUsersBySectionRequest) (*GetUsersBySectionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUsersBySection not implemented")
+}
+func (UnimplementedTicketBookingServiceServer) RemoveUser(context.Context, *RemoveUserRequest) (*RemoveUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveUser not implemented")
+}
+func (UnimplementedTicketBookingServiceServer) UpdateUserSeat(context.Context, *UpdateUserSeatRequest) (*UpdateUserSeatResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateUserSeat not implemented")
+}
+func (UnimplementedTicketBookingServiceServer) SwapSeats(context.Context, *SwapSeatsRequest) (*SwapSeatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SwapSeats not implemented")
+}
+func (UnimplementedTicketBookingServiceServer) GetReceiptHistory(context.Context, *GetReceiptHistoryRequest) (*GetReceiptHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReceiptHistory not implemented")
+}
+func (UnimplementedTicketBookingServiceServer) SetMaintenanceMode(context.Context, *SetMaintenanceModeRequest) (*SetMaintenanceModeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetMaintenanceMode not implemented")
+}
+func (UnimplementedTicketBookingServiceServer) BulkCancel(context.Context, *BulkCancelRequest) (*BulkCancelResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BulkCancel not implemented")
+}
+func (UnimplementedTicketBookingServiceServer) ResizeSection(context.Context, *ResizeSectionRequest) (*ResizeSectionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResizeSection not implemented")
+}
+func (UnimplementedTicketBookingServiceServer) GetServerInfo(context.Context, *GetServerInfoRequest) (*GetServerInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetServerInfo not implemented")
+}
+func (UnimplementedTicketBookingServiceServer) GetUsersByRoute(context.Context, *GetUsersByRouteRequest) (*GetUsersByRouteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUsersByRoute not implemented")
+}
+func (UnimplementedTicketBookingServiceServer) BlockSeat(context.Context, *BlockSeatRequest) (*BlockSeatResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BlockSeat not implemented")
+}
+func (UnimplementedTicketBookingServiceServer) UnblockSeat(context.Context, *UnblockSeatRequest) (*UnblockSeatResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnblockSeat not implemented")
+}
+func (UnimplementedTicketBookingServiceServer) Verify(context.Context, *VerifyRequest) (*VerifyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Verify not implemented")
+}
+func (UnimplementedTicketBookingServiceServer) GetSeat(context.Context, *GetSeatRequest) (*GetSeatResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSeat not implemented")
+}
+func (UnimplementedTicketBookingServiceServer) GetCheapestRoutes(context.Context, *GetCheapestRoutesRequest) (*GetCheapestRoutesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCheapestRoutes not implemented")
+}
+func (UnimplementedTicketBookingServiceServer) GetSectionVacancy(context.Context, *GetSectionVacancyRequest) (*GetSectionVacancyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSectionVacancy not implemented")
+}
+func (UnimplementedTicketBookingServiceServer) GetConfig(context.Context, *GetConfigRequest) (*GetConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetConfig not implemented")
+}
+func (UnimplementedTicketBookingServiceServer) GetAllSections(context.Context, *GetAllSectionsRequest) (*GetAllSectionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAllSections not implemented")
+}
+func (UnimplementedTicketBookingServiceServer) GetReceiptByReference(context.Context, *GetReceiptByReferenceRequest) (*GetReceiptByReferenceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReceiptByReference not implemented")
+}
+func (UnimplementedTicketBookingServiceServer) GetWaitEstimate(context.Context, *GetWaitEstimateRequest) (*GetWaitEstimateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWaitEstimate not implemented")
+}
+func (UnimplementedTicketBookingServiceServer) GetAssignmentTrace(context.Context, *GetAssignmentTraceRequest) (*GetAssignmentTraceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAssignmentTrace not implemented")
+}
+func (UnimplementedTicketBookingServiceServer) GetManifest(context.Context, *GetManifestRequest) (*GetManifestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetManifest not implemented")
+}
+func (UnimplementedTicketBookingServiceServer) ListReceiptsByPriceRange(context.Context, *ListReceiptsByPriceRangeRequest) (*ListReceiptsByPriceRangeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListReceiptsByPriceRange not implemented")
+}
+func (UnimplementedTicketBookingServiceServer) WatchAvailability(*WatchAvailabilityRequest, grpc.ServerStreamingServer[WatchAvailabilityResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchAvailability not implemented")
+}
+func (UnimplementedTicketBookingServiceServer) ForceReassign(context.Context, *ForceReassignRequest) (*ForceReassignResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ForceReassign not implemented")
+}
+func (UnimplementedTicketBookingServiceServer) ConfirmPayment(context.Context, *ConfirmPaymentRequest) (*ConfirmPaymentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfirmPayment not implemented")
+}
+func (UnimplementedTicketBookingServiceServer) HoldSeat(context.Context, *HoldSeatRequest) (*HoldSeatResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HoldSeat not implemented")
+}
+func (UnimplementedTicketBookingServiceServer) ReleaseHold(context.Context, *ReleaseHoldRequest) (*ReleaseHoldResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseHold not implemented")
+}
+func (UnimplementedTicketBookingServiceServer) GetExpiringHolds(context.Context, *GetExpiringHoldsRequest) (*GetExpiringHoldsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetExpiringHolds not implemented")
+}
+func (UnimplementedTicketBookingServiceServer) GetBookingTrends(context.Context, *GetBookingTrendsRequest) (*GetBookingTrendsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBookingTrends not implemented")
+}
+func (UnimplementedTicketBookingServiceServer) ImportReceipts(context.Context, *ImportReceiptsRequest) (*ImportReceiptsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportReceipts not implemented")
+}
+func (UnimplementedTicketBookingServiceServer) GetCancellationStats(context.Context, *GetCancellationStatsRequest) (*GetCancellationStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCancellationStats not implemented")
+}
+func (UnimplementedTicketBookingServiceServer) GetNearbyAvailableSeats(context.Context, *GetNearbyAvailableSeatsRequest) (*GetNearbyAvailableSeatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNearbyAvailableSeats not implemented")
+}
+func (UnimplementedTicketBookingServiceServer) QuiesceSection(context.Context, *QuiesceSectionRequest) (*QuiesceSectionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QuiesceSection not implemented")
+}
+func (UnimplementedTicketBookingServiceServer) ResumeSection(context.Context, *ResumeSectionRequest) (*ResumeSectionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResumeSection not implemented")
+}
+func (UnimplementedTicketBookingServiceServer) GetConfigChanges(context.Context, *GetConfigChangesRequest) (*GetConfigChangesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetConfigChanges not implemented")
+}
+func (UnimplementedTicketBookingServiceServer) ChangeStations(context.Context, *ChangeStationsRequest) (*ChangeStationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChangeStations not implemented")
+}
+func (UnimplementedTicketBookingServiceServer) mustEmbedUnimplementedTicketBookingServiceServer() {}
+func (UnimplementedTicketBookingServiceServer) testEmbeddedByValue()                              {}
+
+// UnsafeTicketBookingServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TicketBookingServiceServer will
+// result in compilation errors.
+type UnsafeTicketBookingServiceServer interface {
+	mustEmbedUnimplementedTicketBookingServiceServer()
+}
+
+func RegisterTicketBookingServiceServer(s grpc.ServiceRegistrar, srv TicketBookingServiceServer) {
+	// If the following call pancis, it indicates UnimplementedTicketBookingServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&TicketBookingService_ServiceDesc, srv)
+}
+
+func _TicketBookingService_PurchaseTicket_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurchaseTicketRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketBookingServiceServer).PurchaseTicket(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicketBookingService_PurchaseTicket_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketBookingServiceServer).PurchaseTicket(ctx, req.(*PurchaseTicketRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketBookingService_GetReceipt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReceiptRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketBookingServiceServer).GetReceipt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicketBookingService_GetReceipt_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketBookingServiceServer).GetReceipt(ctx, req.(*GetReceiptRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketBookingService_GetUsersBySection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUsersBySectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketBookingServiceServer).GetUsersBySection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicketBookingService_GetUsersBySection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketBookingServiceServer).GetUsersBySection(ctx, req.(*GetUsersBySectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketBookingService_RemoveUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketBookingServiceServer).RemoveUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicketBookingService_RemoveUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketBookingServiceServer).RemoveUser(ctx, req.(*RemoveUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketBookingService_UpdateUserSeat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateUserSeatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketBookingServiceServer).UpdateUserSeat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicketBookingService_UpdateUserSeat_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketBookingServiceServer).UpdateUserSeat(ctx, req.(*UpdateUserSeatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketBookingService_SwapSeats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SwapSeatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketBookingServiceServer).SwapSeats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicketBookingService_SwapSeats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketBookingServiceServer).SwapSeats(ctx, req.(*SwapSeatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketBookingService_GetReceiptHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReceiptHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketBookingServiceServer).GetReceiptHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicketBookingService_GetReceiptHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketBookingServiceServer).GetReceiptHistory(ctx, req.(*GetReceiptHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketBookingService_SetMaintenanceMode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetMaintenanceModeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketBookingServiceServer).SetMaintenanceMode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicketBookingService_SetMaintenanceMode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketBookingServiceServer).SetMaintenanceMode(ctx, req.(*SetMaintenanceModeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketBookingService_BulkCancel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkCancelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketBookingServiceServer).BulkCancel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicketBookingService_BulkCancel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketBookingServiceServer).BulkCancel(ctx, req.(*BulkCancelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketBookingService_ResizeSection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResizeSectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketBookingServiceServer).ResizeSection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicketBookingService_ResizeSection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketBookingServiceServer).ResizeSection(ctx, req.(*ResizeSectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketBookingService_GetServerInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetServerInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketBookingServiceServer).GetServerInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicketBookingService_GetServerInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketBookingServiceServer).GetServerInfo(ctx, req.(*GetServerInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketBookingService_GetUsersByRoute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUsersByRouteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketBookingServiceServer).GetUsersByRoute(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicketBookingService_GetUsersByRoute_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketBookingServiceServer).GetUsersByRoute(ctx, req.(*GetUsersByRouteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketBookingService_BlockSeat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BlockSeatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketBookingServiceServer).BlockSeat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicketBookingService_BlockSeat_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketBookingServiceServer).BlockSeat(ctx, req.(*BlockSeatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketBookingService_UnblockSeat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnblockSeatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketBookingServiceServer).UnblockSeat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicketBookingService_UnblockSeat_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketBookingServiceServer).UnblockSeat(ctx, req.(*UnblockSeatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketBookingService_Verify_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketBookingServiceServer).Verify(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicketBookingService_Verify_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketBookingServiceServer).Verify(ctx, req.(*VerifyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketBookingService_GetSeat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSeatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketBookingServiceServer).GetSeat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicketBookingService_GetSeat_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketBookingServiceServer).GetSeat(ctx, req.(*GetSeatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketBookingService_GetCheapestRoutes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCheapestRoutesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketBookingServiceServer).GetCheapestRoutes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicketBookingService_GetCheapestRoutes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketBookingServiceServer).GetCheapestRoutes(ctx, req.(*GetCheapestRoutesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketBookingService_GetSectionVacancy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSectionVacancyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketBookingServiceServer).GetSectionVacancy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicketBookingService_GetSectionVacancy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketBookingServiceServer).GetSectionVacancy(ctx, req.(*GetSectionVacancyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketBookingService_GetConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketBookingServiceServer).GetConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicketBookingService_GetConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketBookingServiceServer).GetConfig(ctx, req.(*GetConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketBookingService_GetAllSections_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAllSectionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketBookingServiceServer).GetAllSections(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicketBookingService_GetAllSections_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketBookingServiceServer).GetAllSections(ctx, req.(*GetAllSectionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketBookingService_GetReceiptByReference_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReceiptByReferenceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketBookingServiceServer).GetReceiptByReference(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicketBookingService_GetReceiptByReference_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketBookingServiceServer).GetReceiptByReference(ctx, req.(*GetReceiptByReferenceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketBookingService_GetWaitEstimate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWaitEstimateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketBookingServiceServer).GetWaitEstimate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicketBookingService_GetWaitEstimate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketBookingServiceServer).GetWaitEstimate(ctx, req.(*GetWaitEstimateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketBookingService_GetAssignmentTrace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAssignmentTraceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketBookingServiceServer).GetAssignmentTrace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicketBookingService_GetAssignmentTrace_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketBookingServiceServer).GetAssignmentTrace(ctx, req.(*GetAssignmentTraceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketBookingService_GetManifest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetManifestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketBookingServiceServer).GetManifest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicketBookingService_GetManifest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketBookingServiceServer).GetManifest(ctx, req.(*GetManifestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
 
-func (UnimplementedTicketBookingServiceServer) PurchaseTicket(context.Context, *PurchaseTicketRequest) (*PurchaseTicketResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method PurchaseTicket not implemented")
+func _TicketBookingService_ListReceiptsByPriceRange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListReceiptsByPriceRangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketBookingServiceServer).ListReceiptsByPriceRange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicketBookingService_ListReceiptsByPriceRange_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketBookingServiceServer).ListReceiptsByPriceRange(ctx, req.(*ListReceiptsByPriceRangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedTicketBookingServiceServer) GetReceipt(context.Context, *GetReceiptRequest) (*GetReceiptResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetReceipt not implemented")
+
+func _TicketBookingService_WatchAvailability_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchAvailabilityRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TicketBookingServiceServer).WatchAvailability(m, &grpc.GenericServerStream[WatchAvailabilityRequest, WatchAvailabilityResponse]{ServerStream: stream})
 }
-func (UnimplementedTicketBookingServiceServer) GetUsersBySection(context.Context, *GetUsersBySectionRequest) (*GetUsersBySectionResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetUsersBySection not implemented")
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TicketBookingService_WatchAvailabilityServer = grpc.ServerStreamingServer[WatchAvailabilityResponse]
+
+func _TicketBookingService_ForceReassign_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ForceReassignRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketBookingServiceServer).ForceReassign(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicketBookingService_ForceReassign_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketBookingServiceServer).ForceReassign(ctx, req.(*ForceReassignRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedTicketBookingServiceServer) RemoveUser(context.Context, *RemoveUserRequest) (*RemoveUserResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method RemoveUser not implemented")
+
+func _TicketBookingService_ConfirmPayment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfirmPaymentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketBookingServiceServer).ConfirmPayment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicketBookingService_ConfirmPayment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketBookingServiceServer).ConfirmPayment(ctx, req.(*ConfirmPaymentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedTicketBookingServiceServer) UpdateUserSeat(context.Context, *UpdateUserSeatRequest) (*UpdateUserSeatResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateUserSeat not implemented")
+
+func _TicketBookingService_HoldSeat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HoldSeatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketBookingServiceServer).HoldSeat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicketBookingService_HoldSeat_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketBookingServiceServer).HoldSeat(ctx, req.(*HoldSeatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedTicketBookingServiceServer) mustEmbedUnimplementedTicketBookingServiceServer() {}
-func (UnimplementedTicketBookingServiceServer) testEmbeddedByValue()                              {}
 
-// UnsafeTicketBookingServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to TicketBookingServiceServer will
-// result in compilation errors.
-type UnsafeTicketBookingServiceServer interface {
-	mustEmbedUnimplementedTicketBookingServiceServer()
+func _TicketBookingService_ReleaseHold_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseHoldRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketBookingServiceServer).ReleaseHold(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicketBookingService_ReleaseHold_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketBookingServiceServer).ReleaseHold(ctx, req.(*ReleaseHoldRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterTicketBookingServiceServer(s grpc.ServiceRegistrar, srv TicketBookingServiceServer) {
-	// If the following call pancis, it indicates UnimplementedTicketBookingServiceServer was
-	// embedded by pointer and is nil.  This will cause panics if an
-	// unimplemented method is ever invoked, so we test this at initialization
-	// time to prevent it from happening at runtime later due to I/O.
-	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
-		t.testEmbeddedByValue()
+func _TicketBookingService_GetExpiringHolds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetExpiringHoldsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	s.RegisterService(&TicketBookingService_ServiceDesc, srv)
+	if interceptor == nil {
+		return srv.(TicketBookingServiceServer).GetExpiringHolds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicketBookingService_GetExpiringHolds_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketBookingServiceServer).GetExpiringHolds(ctx, req.(*GetExpiringHoldsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _TicketBookingService_PurchaseTicket_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(PurchaseTicketRequest)
+func _TicketBookingService_GetBookingTrends_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBookingTrendsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(TicketBookingServiceServer).PurchaseTicket(ctx, in)
+		return srv.(TicketBookingServiceServer).GetBookingTrends(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: TicketBookingService_PurchaseTicket_FullMethodName,
+		FullMethod: TicketBookingService_GetBookingTrends_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TicketBookingServiceServer).PurchaseTicket(ctx, req.(*PurchaseTicketRequest))
+		return srv.(TicketBookingServiceServer).GetBookingTrends(ctx, req.(*GetBookingTrendsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _TicketBookingService_GetReceipt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetReceiptRequest)
+func _TicketBookingService_ImportReceipts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportReceiptsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(TicketBookingServiceServer).GetReceipt(ctx, in)
+		return srv.(TicketBookingServiceServer).ImportReceipts(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: TicketBookingService_GetReceipt_FullMethodName,
+		FullMethod: TicketBookingService_ImportReceipts_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TicketBookingServiceServer).GetReceipt(ctx, req.(*GetReceiptRequest))
+		return srv.(TicketBookingServiceServer).ImportReceipts(ctx, req.(*ImportReceiptsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _TicketBookingService_GetUsersBySection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetUsersBySectionRequest)
+func _TicketBookingService_GetCancellationStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCancellationStatsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(TicketBookingServiceServer).GetUsersBySection(ctx, in)
+		return srv.(TicketBookingServiceServer).GetCancellationStats(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: TicketBookingService_GetUsersBySection_FullMethodName,
+		FullMethod: TicketBookingService_GetCancellationStats_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TicketBookingServiceServer).GetUsersBySection(ctx, req.(*GetUsersBySectionRequest))
+		return srv.(TicketBookingServiceServer).GetCancellationStats(ctx, req.(*GetCancellationStatsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _TicketBookingService_RemoveUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RemoveUserRequest)
+func _TicketBookingService_GetNearbyAvailableSeats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNearbyAvailableSeatsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(TicketBookingServiceServer).RemoveUser(ctx, in)
+		return srv.(TicketBookingServiceServer).GetNearbyAvailableSeats(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: TicketBookingService_RemoveUser_FullMethodName,
+		FullMethod: TicketBookingService_GetNearbyAvailableSeats_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TicketBookingServiceServer).RemoveUser(ctx, req.(*RemoveUserRequest))
+		return srv.(TicketBookingServiceServer).GetNearbyAvailableSeats(ctx, req.(*GetNearbyAvailableSeatsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _TicketBookingService_UpdateUserSeat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateUserSeatRequest)
+func _TicketBookingService_QuiesceSection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QuiesceSectionRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(TicketBookingServiceServer).UpdateUserSeat(ctx, in)
+		return srv.(TicketBookingServiceServer).QuiesceSection(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: TicketBookingService_UpdateUserSeat_FullMethodName,
+		FullMethod: TicketBookingService_QuiesceSection_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TicketBookingServiceServer).UpdateUserSeat(ctx, req.(*UpdateUserSeatRequest))
+		return srv.(TicketBookingServiceServer).QuiesceSection(ctx, req.(*QuiesceSectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketBookingService_ResumeSection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResumeSectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketBookingServiceServer).ResumeSection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicketBookingService_ResumeSection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketBookingServiceServer).ResumeSection(ctx, req.(*ResumeSectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketBookingService_GetConfigChanges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetConfigChangesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketBookingServiceServer).GetConfigChanges(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicketBookingService_GetConfigChanges_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketBookingServiceServer).GetConfigChanges(ctx, req.(*GetConfigChangesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketBookingService_ChangeStations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangeStationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketBookingServiceServer).ChangeStations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TicketBookingService_ChangeStations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketBookingServiceServer).ChangeStations(ctx, req.(*ChangeStationsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -271,7 +1429,145 @@ var TicketBookingService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateUserSeat",
 			Handler:    _TicketBookingService_UpdateUserSeat_Handler,
 		},
+		{
+			MethodName: "SwapSeats",
+			Handler:    _TicketBookingService_SwapSeats_Handler,
+		},
+		{
+			MethodName: "GetReceiptHistory",
+			Handler:    _TicketBookingService_GetReceiptHistory_Handler,
+		},
+		{
+			MethodName: "SetMaintenanceMode",
+			Handler:    _TicketBookingService_SetMaintenanceMode_Handler,
+		},
+		{
+			MethodName: "BulkCancel",
+			Handler:    _TicketBookingService_BulkCancel_Handler,
+		},
+		{
+			MethodName: "ResizeSection",
+			Handler:    _TicketBookingService_ResizeSection_Handler,
+		},
+		{
+			MethodName: "GetServerInfo",
+			Handler:    _TicketBookingService_GetServerInfo_Handler,
+		},
+		{
+			MethodName: "GetUsersByRoute",
+			Handler:    _TicketBookingService_GetUsersByRoute_Handler,
+		},
+		{
+			MethodName: "BlockSeat",
+			Handler:    _TicketBookingService_BlockSeat_Handler,
+		},
+		{
+			MethodName: "UnblockSeat",
+			Handler:    _TicketBookingService_UnblockSeat_Handler,
+		},
+		{
+			MethodName: "Verify",
+			Handler:    _TicketBookingService_Verify_Handler,
+		},
+		{
+			MethodName: "GetSeat",
+			Handler:    _TicketBookingService_GetSeat_Handler,
+		},
+		{
+			MethodName: "GetCheapestRoutes",
+			Handler:    _TicketBookingService_GetCheapestRoutes_Handler,
+		},
+		{
+			MethodName: "GetSectionVacancy",
+			Handler:    _TicketBookingService_GetSectionVacancy_Handler,
+		},
+		{
+			MethodName: "GetConfig",
+			Handler:    _TicketBookingService_GetConfig_Handler,
+		},
+		{
+			MethodName: "GetAllSections",
+			Handler:    _TicketBookingService_GetAllSections_Handler,
+		},
+		{
+			MethodName: "GetReceiptByReference",
+			Handler:    _TicketBookingService_GetReceiptByReference_Handler,
+		},
+		{
+			MethodName: "GetWaitEstimate",
+			Handler:    _TicketBookingService_GetWaitEstimate_Handler,
+		},
+		{
+			MethodName: "GetAssignmentTrace",
+			Handler:    _TicketBookingService_GetAssignmentTrace_Handler,
+		},
+		{
+			MethodName: "GetManifest",
+			Handler:    _TicketBookingService_GetManifest_Handler,
+		},
+		{
+			MethodName: "ListReceiptsByPriceRange",
+			Handler:    _TicketBookingService_ListReceiptsByPriceRange_Handler,
+		},
+		{
+			MethodName: "ForceReassign",
+			Handler:    _TicketBookingService_ForceReassign_Handler,
+		},
+		{
+			MethodName: "ConfirmPayment",
+			Handler:    _TicketBookingService_ConfirmPayment_Handler,
+		},
+		{
+			MethodName: "HoldSeat",
+			Handler:    _TicketBookingService_HoldSeat_Handler,
+		},
+		{
+			MethodName: "ReleaseHold",
+			Handler:    _TicketBookingService_ReleaseHold_Handler,
+		},
+		{
+			MethodName: "GetExpiringHolds",
+			Handler:    _TicketBookingService_GetExpiringHolds_Handler,
+		},
+		{
+			MethodName: "GetBookingTrends",
+			Handler:    _TicketBookingService_GetBookingTrends_Handler,
+		},
+		{
+			MethodName: "ImportReceipts",
+			Handler:    _TicketBookingService_ImportReceipts_Handler,
+		},
+		{
+			MethodName: "GetCancellationStats",
+			Handler:    _TicketBookingService_GetCancellationStats_Handler,
+		},
+		{
+			MethodName: "GetNearbyAvailableSeats",
+			Handler:    _TicketBookingService_GetNearbyAvailableSeats_Handler,
+		},
+		{
+			MethodName: "QuiesceSection",
+			Handler:    _TicketBookingService_QuiesceSection_Handler,
+		},
+		{
+			MethodName: "ResumeSection",
+			Handler:    _TicketBookingService_ResumeSection_Handler,
+		},
+		{
+			MethodName: "GetConfigChanges",
+			Handler:    _TicketBookingService_GetConfigChanges_Handler,
+		},
+		{
+			MethodName: "ChangeStations",
+			Handler:    _TicketBookingService_ChangeStations_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchAvailability",
+			Handler:       _TicketBookingService_WatchAvailability_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/ticketBooking.proto",
 }